@@ -81,6 +81,12 @@ func RestoreKubeadmConfigSpec(restored *bootstrapv1.KubeadmConfigSpec, dst *boot
 			dst.ClusterConfiguration.CACertificateValidityPeriodDays = restored.ClusterConfiguration.CACertificateValidityPeriodDays
 		}
 	}
+	dst.BootstrapDataTemplating = restored.BootstrapDataTemplating
+	dst.KubeletConfiguration = restored.KubeletConfiguration
+	dst.Ignition.Butane = restored.Ignition.Butane
+	dst.Ignition.Systemd = restored.Ignition.Systemd
+	dst.Ignition.Storage = restored.Ignition.Storage
+	dst.Ignition.KernelArguments = restored.Ignition.KernelArguments
 }
 
 func RestoreBoolIntentKubeadmConfigSpec(src *KubeadmConfigSpec, dst *bootstrapv1.KubeadmConfigSpec, hasRestored bool, restored *bootstrapv1.KubeadmConfigSpec) error {
@@ -1023,6 +1023,10 @@ func autoConvert_v1beta1_IgnitionSpec_To_v1beta2_IgnitionSpec(in *IgnitionSpec,
 
 func autoConvert_v1beta2_IgnitionSpec_To_v1beta1_IgnitionSpec(in *v1beta2.IgnitionSpec, out *IgnitionSpec, s conversion.Scope) error {
 	// WARNING: in.ContainerLinuxConfig requires manual conversion: inconvertible types (sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2.ContainerLinuxConfig vs *sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1.ContainerLinuxConfig)
+	// WARNING: in.Butane requires manual conversion: does not exist in peer-type
+	// WARNING: in.Systemd requires manual conversion: does not exist in peer-type
+	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
+	// WARNING: in.KernelArguments requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1396,6 +1400,8 @@ func autoConvert_v1beta2_KubeadmConfigSpec_To_v1beta1_KubeadmConfigSpec(in *v1be
 	out.Format = Format(in.Format)
 	out.Verbosity = (*int32)(unsafe.Pointer(in.Verbosity))
 	// WARNING: in.Ignition requires manual conversion: inconvertible types (sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2.IgnitionSpec vs *sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1.IgnitionSpec)
+	// WARNING: in.BootstrapDataTemplating requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfiguration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
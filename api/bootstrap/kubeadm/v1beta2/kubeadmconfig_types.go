@@ -17,18 +17,25 @@ limitations under the License.
 package v1beta2
 
 import (
+	"cmp"
 	"fmt"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/feature"
 )
 
 // Format specifies the output format of the bootstrap data
-// +kubebuilder:validation:Enum=cloud-config;ignition
+// +kubebuilder:validation:Enum=cloud-config;ignition;shell-script
 type Format string
 
 const (
@@ -37,16 +44,21 @@ const (
 
 	// Ignition make the bootstrap data to be of Ignition format.
 	Ignition Format = "ignition"
+
+	// ShellScript make the bootstrap data to be a POSIX shell script.
+	ShellScript Format = "shell-script"
 )
 
 var (
-	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
-	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
-	conflictingUserSourceMsg                         = "only one of passwd or passwdFrom may be specified for a single user"
-	kubeadmBootstrapFormatIgnitionFeatureDisabledMsg = "can be set only if the KubeadmBootstrapFormatIgnition feature gate is enabled"
-	missingSecretNameMsg                             = "secret file source must specify non-empty secret name"
-	missingSecretKeyMsg                              = "secret file source must specify non-empty secret key"
-	pathConflictMsg                                  = "path property must be unique among all files"
+	cannotUseWithIgnition                               = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
+	conflictingFileSourceMsg                            = "only one of content or contentFrom may be specified for a single file"
+	conflictingUserSourceMsg                            = "only one of passwd or passwdFrom may be specified for a single user"
+	kubeadmBootstrapDataTemplatingFeatureDisabledMsg    = "can be set only if the KubeadmBootstrapDataTemplating feature gate is enabled"
+	kubeadmBootstrapFormatShellScriptFeatureDisabledMsg = "can be set to shell-script only if the KubeadmBootstrapFormatShellScript feature gate is enabled"
+	missingSecretNameMsg                                = "secret file source must specify non-empty secret name"
+	missingSecretKeyMsg                                 = "secret file source must specify non-empty secret key"
+	pathConflictMsg                                     = "path property must be unique among all files"
+	storagePathConflictMsg                              = "path property must be unique among all files, links and directories"
 )
 
 // KubeadmConfigSpec defines the desired state of KubeadmConfig.
@@ -129,6 +141,8 @@ type KubeadmConfigSpec struct {
 
 	// format specifies the output format of the bootstrap data.
 	// Defaults to cloud-config if not set.
+	// The shell-script format renders the bootstrap data as a self-contained POSIX shell script, for nodes
+	// that are not running cloud-init or Ignition; it requires the KubeadmBootstrapFormatShellScript feature gate.
 	// +optional
 	Format Format `json:"format,omitempty"`
 
@@ -140,6 +154,21 @@ type KubeadmConfigSpec struct {
 	// ignition contains Ignition specific configuration.
 	// +optional
 	Ignition IgnitionSpec `json:"ignition,omitempty,omitzero"`
+
+	// bootstrapDataTemplating configures a templating pass over the content of files, bootCommands,
+	// preKubeadmCommands and postKubeadmCommands, which is executed by the KubeadmConfig controller
+	// when generating the bootstrap data.
+	// +optional
+	BootstrapDataTemplating BootstrapDataTemplating `json:"bootstrapDataTemplating,omitempty,omitzero"`
+
+	// kubeletConfiguration contains kubelet configuration settings applied on top of the KubeletConfiguration
+	// generated by kubeadm.
+	// For Kubernetes >= v1.25 the settings are applied by "kubeadm init" and "kubeadm join" using a patch for the
+	// kubeletconfiguration patch target, thus allowing different settings for each node.
+	// For older Kubernetes versions the settings are added as a KubeletConfiguration document to the configuration
+	// used by "kubeadm init", which stores it in the kubelet-config ConfigMap used by all the joining nodes.
+	// +optional
+	KubeletConfiguration KubeletConfiguration `json:"kubeletConfiguration,omitempty,omitzero"`
 }
 
 // Validate ensures the KubeadmConfigSpec is valid.
@@ -149,6 +178,9 @@ func (c *KubeadmConfigSpec) Validate(isKCP bool, pathPrefix *field.Path) field.E
 	allErrs = append(allErrs, c.validateFiles(pathPrefix)...)
 	allErrs = append(allErrs, c.validateUsers(pathPrefix)...)
 	allErrs = append(allErrs, c.validateIgnition(pathPrefix)...)
+	allErrs = append(allErrs, c.validateShellScript(pathPrefix)...)
+	allErrs = append(allErrs, c.validateKubeletConfiguration(pathPrefix)...)
+	allErrs = append(allErrs, c.validateBootstrapDataTemplating(pathPrefix)...)
 
 	// Validate JoinConfiguration.
 	if c.JoinConfiguration.IsDefined() {
@@ -302,20 +334,6 @@ func (c *KubeadmConfigSpec) validateUsers(pathPrefix *field.Path) field.ErrorLis
 func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	if !feature.Gates.Enabled(feature.KubeadmBootstrapFormatIgnition) {
-		if c.Format == Ignition {
-			allErrs = append(allErrs, field.Forbidden(
-				pathPrefix.Child("format"), kubeadmBootstrapFormatIgnitionFeatureDisabledMsg))
-		}
-
-		if c.Ignition.IsDefined() {
-			allErrs = append(allErrs, field.Forbidden(
-				pathPrefix.Child("ignition"), kubeadmBootstrapFormatIgnitionFeatureDisabledMsg))
-		}
-
-		return allErrs
-	}
-
 	if c.Format != Ignition {
 		if c.Ignition.IsDefined() {
 			allErrs = append(
@@ -404,6 +422,260 @@ func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.Error
 		}
 	}
 
+	allErrs = append(allErrs, c.validateIgnitionSpec(pathPrefix.Child("ignition"))...)
+
+	return allErrs
+}
+
+// validateIgnitionSpec validates the Ignition specific configuration.
+func (c *KubeadmConfigSpec) validateIgnitionSpec(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.Ignition.Butane.IsDefined() && c.Ignition.ContainerLinuxConfig.IsDefined() {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("butane"),
+				"cannot be set together with spec.ignition.containerLinuxConfig",
+			),
+		)
+	}
+
+	if c.Ignition.KernelArguments.IsDefined() && !c.Ignition.Butane.IsDefined() {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("kernelArguments"),
+				"can only be set together with spec.ignition.butane",
+			),
+		)
+	}
+
+	for i, unit := range c.Ignition.Systemd.Units {
+		if !isValidSystemdUnitName(unit.Name) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("systemd", "units").Index(i).Child("name"),
+					unit.Name,
+					fmt.Sprintf("must end with a valid unit type suffix, one of: %s", strings.Join(systemdUnitTypes, ", ")),
+				),
+			)
+		}
+
+		for j, dropin := range unit.Dropins {
+			if !strings.HasSuffix(dropin.Name, ".conf") || strings.Contains(dropin.Name, "/") {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						pathPrefix.Child("systemd", "units").Index(i).Child("dropins").Index(j).Child("name"),
+						dropin.Name,
+						"must be a file name with the .conf suffix",
+					),
+				)
+			}
+		}
+	}
+
+	// Ignition does not allow files, links and directories with the same path.
+	knownPaths := map[string]struct{}{}
+	for _, file := range c.Files {
+		knownPaths[file.Path] = struct{}{}
+	}
+
+	for i, link := range c.Ignition.Storage.Links {
+		linkPath := pathPrefix.Child("storage", "links").Index(i)
+		if !path.IsAbs(link.Path) {
+			allErrs = append(allErrs, field.Invalid(linkPath.Child("path"), link.Path, "must be an absolute path"))
+		}
+		if _, conflict := knownPaths[link.Path]; conflict {
+			allErrs = append(allErrs, field.Invalid(linkPath.Child("path"), link.Path, storagePathConflictMsg))
+		}
+		knownPaths[link.Path] = struct{}{}
+	}
+
+	for i, directory := range c.Ignition.Storage.Directories {
+		directoryPath := pathPrefix.Child("storage", "directories").Index(i)
+		if !path.IsAbs(directory.Path) {
+			allErrs = append(allErrs, field.Invalid(directoryPath.Child("path"), directory.Path, "must be an absolute path"))
+		}
+		if _, conflict := knownPaths[directory.Path]; conflict {
+			allErrs = append(allErrs, field.Invalid(directoryPath.Child("path"), directory.Path, storagePathConflictMsg))
+		}
+		knownPaths[directory.Path] = struct{}{}
+
+		if directory.Permissions != "" {
+			if _, err := strconv.ParseUint(directory.Permissions, 8, 32); err != nil {
+				allErrs = append(allErrs, field.Invalid(directoryPath.Child("permissions"), directory.Permissions, "must be an octal number, e.g. 0750"))
+			}
+		}
+	}
+
+	shouldExist := sets.New[string](c.Ignition.KernelArguments.ShouldExist...)
+	for i, arg := range c.Ignition.KernelArguments.ShouldNotExist {
+		if shouldExist.Has(arg) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("kernelArguments", "shouldNotExist").Index(i),
+					arg,
+					"must not be set in spec.ignition.kernelArguments.shouldExist at the same time",
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+// systemdUnitTypes are the unit type suffixes supported by systemd.
+var systemdUnitTypes = []string{".service", ".socket", ".device", ".mount", ".automount", ".swap", ".target", ".path", ".timer", ".slice", ".scope"}
+
+func isValidSystemdUnitName(name string) bool {
+	if strings.Contains(name, "/") {
+		return false
+	}
+	for _, unitType := range systemdUnitTypes {
+		if strings.HasSuffix(name, unitType) && len(name) > len(unitType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *KubeadmConfigSpec) validateShellScript(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !feature.Gates.Enabled(feature.KubeadmBootstrapFormatShellScript) && c.Format == ShellScript {
+		allErrs = append(allErrs, field.Forbidden(
+			pathPrefix.Child("format"), kubeadmBootstrapFormatShellScriptFeatureDisabledMsg))
+	}
+
+	return allErrs
+}
+
+// evictionSignals are the eviction signals supported by the kubelet.
+var evictionSignals = sets.New[string](
+	"memory.available",
+	"allocatableMemory.available",
+	"nodefs.available",
+	"nodefs.inodesFree",
+	"imagefs.available",
+	"imagefs.inodesFree",
+	"containerfs.available",
+	"containerfs.inodesFree",
+	"pid.available",
+)
+
+func (c *KubeadmConfigSpec) validateKubeletConfiguration(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	kc := c.KubeletConfiguration
+	if !kc.IsDefined() {
+		return allErrs
+	}
+	kcPath := pathPrefix.Child("kubeletConfiguration")
+
+	for i, r := range kc.KubeReserved {
+		if _, err := resource.ParseQuantity(r.Quantity); err != nil {
+			allErrs = append(allErrs, field.Invalid(kcPath.Child("kubeReserved").Index(i).Child("quantity"), r.Quantity, err.Error()))
+		}
+	}
+	for i, r := range kc.SystemReserved {
+		if _, err := resource.ParseQuantity(r.Quantity); err != nil {
+			allErrs = append(allErrs, field.Invalid(kcPath.Child("systemReserved").Index(i).Child("quantity"), r.Quantity, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, validateEvictionThresholds(kc.EvictionHard, kcPath.Child("evictionHard"))...)
+	allErrs = append(allErrs, validateEvictionThresholds(kc.EvictionSoft, kcPath.Child("evictionSoft"))...)
+
+	softSignals := sets.New[string]()
+	for _, t := range kc.EvictionSoft {
+		softSignals.Insert(t.Signal)
+	}
+	gracePeriodSignals := sets.New[string]()
+	for i, p := range kc.EvictionSoftGracePeriod {
+		gracePeriodSignals.Insert(p.Signal)
+		if !softSignals.Has(p.Signal) {
+			allErrs = append(allErrs, field.Invalid(kcPath.Child("evictionSoftGracePeriod").Index(i).Child("signal"), p.Signal,
+				"a grace period can be set only for signals in evictionSoft"))
+		}
+	}
+	for i, t := range kc.EvictionSoft {
+		if !gracePeriodSignals.Has(t.Signal) {
+			allErrs = append(allErrs, field.Required(kcPath.Child("evictionSoft").Index(i),
+				fmt.Sprintf("a grace period for signal %q must be set in evictionSoftGracePeriod", t.Signal)))
+		}
+	}
+
+	if kc.ImageGCHighThresholdPercent != nil && kc.ImageGCLowThresholdPercent != nil &&
+		*kc.ImageGCLowThresholdPercent >= *kc.ImageGCHighThresholdPercent {
+		allErrs = append(allErrs, field.Invalid(kcPath.Child("imageGCLowThresholdPercent"), *kc.ImageGCLowThresholdPercent,
+			"must be lower than imageGCHighThresholdPercent"))
+	}
+
+	if kc.MaxParallelImagePulls != nil && *kc.MaxParallelImagePulls > 1 && (kc.SerializeImagePulls == nil || *kc.SerializeImagePulls) {
+		allErrs = append(allErrs, field.Invalid(kcPath.Child("maxParallelImagePulls"), *kc.MaxParallelImagePulls,
+			"can be greater than 1 only if serializeImagePulls is false"))
+	}
+
+	if kc.ContainerLogMaxSize != "" {
+		if _, err := resource.ParseQuantity(kc.ContainerLogMaxSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(kcPath.Child("containerLogMaxSize"), kc.ContainerLogMaxSize, err.Error()))
+		}
+	}
+
+	if kc.ShutdownGracePeriodCriticalPodsSeconds != nil &&
+		*kc.ShutdownGracePeriodCriticalPodsSeconds > ptr.Deref(kc.ShutdownGracePeriodSeconds, 0) {
+		allErrs = append(allErrs, field.Invalid(kcPath.Child("shutdownGracePeriodCriticalPodsSeconds"), *kc.ShutdownGracePeriodCriticalPodsSeconds,
+			"must be lower than or equal to shutdownGracePeriodSeconds"))
+	}
+
+	// Files must not collide with the patch file generated from kubeletConfiguration.
+	patchFilePaths := sets.New(
+		path.Join(cmp.Or(c.InitConfiguration.Patches.Directory, DefaultPatchesDirectory), KubeletConfigurationPatchFileName),
+		path.Join(cmp.Or(c.JoinConfiguration.Patches.Directory, DefaultPatchesDirectory), KubeletConfigurationPatchFileName),
+	)
+	for i, file := range c.Files {
+		if patchFilePaths.Has(path.Clean(file.Path)) {
+			allErrs = append(allErrs, field.Invalid(pathPrefix.Child("files").Index(i).Child("path"), file.Path,
+				"conflicts with the patch file generated from kubeletConfiguration"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateEvictionThresholds(thresholds []KubeletEvictionThreshold, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, t := range thresholds {
+		if !evictionSignals.Has(t.Signal) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("signal"), t.Signal, sets.List(evictionSignals)))
+		}
+		if percentage, ok := strings.CutSuffix(t.Value, "%"); ok {
+			if v, err := strconv.ParseFloat(percentage, 64); err != nil || v < 0 || v > 100 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("value"), t.Value, "must be a percentage between 0% and 100%"))
+			}
+			continue
+		}
+		if _, err := resource.ParseQuantity(t.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("value"), t.Value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func (c *KubeadmConfigSpec) validateBootstrapDataTemplating(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !feature.Gates.Enabled(feature.KubeadmBootstrapDataTemplating) && c.BootstrapDataTemplating.IsDefined() {
+		allErrs = append(allErrs, field.Forbidden(
+			pathPrefix.Child("bootstrapDataTemplating"), kubeadmBootstrapDataTemplatingFeatureDisabledMsg))
+	}
+
 	return allErrs
 }
 
@@ -411,8 +683,29 @@ func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.Error
 // +kubebuilder:validation:MinProperties=1
 type IgnitionSpec struct {
 	// containerLinuxConfig contains CLC specific configuration.
+	// Cannot be set together with butane.
 	// +optional
 	ContainerLinuxConfig ContainerLinuxConfig `json:"containerLinuxConfig,omitempty,omitzero"`
+
+	// butane contains Butane specific configuration.
+	// If set, the bootstrap data is generated as an Ignition v3 config for the given Butane variant,
+	// instead of being transpiled from a Container Linux Config into an Ignition v2 config.
+	// Cannot be set together with containerLinuxConfig.
+	// +optional
+	Butane ButaneConfig `json:"butane,omitempty,omitzero"`
+
+	// systemd contains systemd units to be added to the generated Ignition config.
+	// +optional
+	Systemd IgnitionSystemd `json:"systemd,omitempty,omitzero"`
+
+	// storage contains links and directories to be added to the generated Ignition config.
+	// +optional
+	Storage IgnitionStorage `json:"storage,omitempty,omitzero"`
+
+	// kernelArguments contains kernel arguments to be added or removed by Ignition.
+	// Can only be set together with butane.
+	// +optional
+	KernelArguments IgnitionKernelArguments `json:"kernelArguments,omitempty,omitzero"`
 }
 
 // IsDefined returns true if the IgnitionSpec is defined.
@@ -444,6 +737,464 @@ func (r *ContainerLinuxConfig) IsDefined() bool {
 	return !reflect.DeepEqual(r, &ContainerLinuxConfig{})
 }
 
+// ButaneVariant defines the Butane variant the Ignition config is generated for.
+// +kubebuilder:validation:Enum=fcos;flatcar
+type ButaneVariant string
+
+const (
+	// FCOSButaneVariant generates an Ignition config for Fedora CoreOS.
+	FCOSButaneVariant ButaneVariant = "fcos"
+
+	// FlatcarButaneVariant generates an Ignition config for Flatcar Container Linux.
+	FlatcarButaneVariant ButaneVariant = "flatcar"
+)
+
+// ButaneConfig contains Butane specific configuration.
+// +kubebuilder:validation:MinProperties=1
+type ButaneConfig struct {
+	// variant is the Butane variant the Ignition config is generated for.
+	// The variant determines the Ignition spec version of the generated config and
+	// distribution specific defaults, e.g. the NTP daemon in use.
+	// +required
+	Variant ButaneVariant `json:"variant,omitempty"`
+}
+
+// IsDefined returns true if the ButaneConfig is defined.
+func (r *ButaneConfig) IsDefined() bool {
+	return !reflect.DeepEqual(r, &ButaneConfig{})
+}
+
+// IgnitionSystemd contains systemd specific Ignition configuration.
+// +kubebuilder:validation:MinProperties=1
+type IgnitionSystemd struct {
+	// units is a list of systemd units.
+	// If a unit has the same name of a unit generated by the bootstrap provider, e.g. kubeadm.service,
+	// fields set on the unit take precedence and its dropins are appended.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Units []IgnitionSystemdUnit `json:"units,omitempty"`
+}
+
+// IsDefined returns true if the IgnitionSystemd is defined.
+func (r *IgnitionSystemd) IsDefined() bool {
+	return !reflect.DeepEqual(r, &IgnitionSystemd{})
+}
+
+// IgnitionSystemdUnit defines a systemd unit.
+type IgnitionSystemdUnit struct {
+	// name is the name of the unit, including its type suffix, e.g. "containerd.service".
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+
+	// enabled specifies whether the unit is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// mask specifies whether the unit is masked.
+	// +optional
+	Mask *bool `json:"mask,omitempty"`
+
+	// contents is the content of the unit.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Contents string `json:"contents,omitempty"`
+
+	// dropins is a list of drop-ins for the unit.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	Dropins []IgnitionSystemdDropin `json:"dropins,omitempty"`
+}
+
+// IgnitionSystemdDropin defines a drop-in for a systemd unit.
+type IgnitionSystemdDropin struct {
+	// name is the name of the drop-in, e.g. "10-override.conf".
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+
+	// contents is the content of the drop-in.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Contents string `json:"contents,omitempty"`
+}
+
+// IgnitionStorage contains storage specific Ignition configuration.
+// NOTE: files, disks and filesystems are configured using spec.files and spec.diskSetup.
+// +kubebuilder:validation:MinProperties=1
+type IgnitionStorage struct {
+	// links is a list of links to be created.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Links []IgnitionLink `json:"links,omitempty"`
+
+	// directories is a list of directories to be created.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Directories []IgnitionDirectory `json:"directories,omitempty"`
+}
+
+// IsDefined returns true if the IgnitionStorage is defined.
+func (r *IgnitionStorage) IsDefined() bool {
+	return !reflect.DeepEqual(r, &IgnitionStorage{})
+}
+
+// IgnitionLink defines a link to be created.
+type IgnitionLink struct {
+	// path specifies the full path on disk of the link.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Path string `json:"path,omitempty"`
+
+	// target specifies the target of the link.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Target string `json:"target,omitempty"`
+
+	// hard specifies whether the link is a hard link instead of a symbolic link.
+	// +optional
+	Hard *bool `json:"hard,omitempty"`
+
+	// owner specifies the ownership of the link, e.g. "root:root".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Owner string `json:"owner,omitempty"`
+
+	// overwrite specifies whether to overwrite an existing file at path.
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// IgnitionDirectory defines a directory to be created.
+type IgnitionDirectory struct {
+	// path specifies the full path on disk of the directory.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Path string `json:"path,omitempty"`
+
+	// owner specifies the ownership of the directory, e.g. "root:root".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Owner string `json:"owner,omitempty"`
+
+	// permissions specifies the permissions to assign to the directory, e.g. "0750".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=16
+	Permissions string `json:"permissions,omitempty"`
+
+	// overwrite specifies whether to overwrite an existing file at path.
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// IgnitionKernelArguments contains kernel arguments to be added or removed by Ignition.
+// +kubebuilder:validation:MinProperties=1
+type IgnitionKernelArguments struct {
+	// shouldExist is a list of kernel arguments that should exist.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	ShouldExist []string `json:"shouldExist,omitempty"`
+
+	// shouldNotExist is a list of kernel arguments that should not exist.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	ShouldNotExist []string `json:"shouldNotExist,omitempty"`
+}
+
+// IsDefined returns true if the IgnitionKernelArguments is defined.
+func (r *IgnitionKernelArguments) IsDefined() bool {
+	return !reflect.DeepEqual(r, &IgnitionKernelArguments{})
+}
+
+// BootstrapDataTemplatingEngine defines the engine used to render bootstrap data templates.
+// +kubebuilder:validation:Enum=GoTemplate
+type BootstrapDataTemplatingEngine string
+
+const (
+	// GoTemplateBootstrapDataTemplatingEngine renders bootstrap data templates using Go templates.
+	// Sprig functions, with the exception of non-hermetic functions, are available in templates.
+	GoTemplateBootstrapDataTemplatingEngine BootstrapDataTemplatingEngine = "GoTemplate"
+)
+
+// BootstrapDataTemplating defines a templating pass over files and commands of a KubeadmConfig.
+//
+// The following data can be used in templates:
+//   - .machine.name, .machine.namespace, .machine.labels and .machine.failureDomain of the Machine
+//     owning the KubeadmConfig; not available when the KubeadmConfig is owned by a MachinePool.
+//   - .cluster.name, .cluster.namespace, .cluster.labels, .cluster.network.pods, .cluster.network.services
+//     and .cluster.network.serviceDomain of the Cluster.
+//   - .ipAddresses, the list of IPAddresses bound to IPAddressClaims owned by the Machine or by its
+//     InfrastructureMachine, sorted by claim name. Each item has name, claimName, address, prefix and gateway.
+//
+// Referencing data that is not available makes rendering fail, and rendering is retried later;
+// e.g. a template using {{ (index .ipAddresses 0).address }} is rendered only after the first IPAddressClaim is bound.
+// Expressions that must be passed through as is, e.g. cloud-init's {{ ds.meta_data.hostname }},
+// must be escaped, e.g. {{ "{{ ds.meta_data.hostname }}" }}.
+// The content of files with an encoding is not rendered.
+// +kubebuilder:validation:MinProperties=1
+type BootstrapDataTemplating struct {
+	// engine is the templating engine used to render the content of files and commands.
+	// +required
+	Engine BootstrapDataTemplatingEngine `json:"engine,omitempty"`
+}
+
+// IsDefined returns true if the BootstrapDataTemplating is defined.
+func (r *BootstrapDataTemplating) IsDefined() bool {
+	return !reflect.DeepEqual(r, &BootstrapDataTemplating{})
+}
+
+// CgroupDriver defines the driver the kubelet uses to manipulate cgroups on the host.
+// +kubebuilder:validation:Enum=systemd;cgroupfs
+type CgroupDriver string
+
+const (
+	// SystemdCgroupDriver uses systemd to manipulate cgroups.
+	SystemdCgroupDriver CgroupDriver = "systemd"
+
+	// CgroupfsCgroupDriver uses cgroupfs to manipulate cgroups.
+	CgroupfsCgroupDriver CgroupDriver = "cgroupfs"
+)
+
+// CPUManagerPolicy defines the policy of the kubelet CPU manager.
+// +kubebuilder:validation:Enum=none;static
+type CPUManagerPolicy string
+
+const (
+	// NoneCPUManagerPolicy is the default CPU manager policy, which does not pin containers to CPUs.
+	NoneCPUManagerPolicy CPUManagerPolicy = "none"
+
+	// StaticCPUManagerPolicy allows containers in Guaranteed pods with integer CPU requests exclusive access to CPUs.
+	StaticCPUManagerPolicy CPUManagerPolicy = "static"
+)
+
+// TopologyManagerPolicy defines the policy of the kubelet topology manager.
+// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+type TopologyManagerPolicy string
+
+const (
+	// NoneTopologyManagerPolicy does not perform any topology alignment.
+	NoneTopologyManagerPolicy TopologyManagerPolicy = "none"
+
+	// BestEffortTopologyManagerPolicy prefers topology aligned resources, but admits pods anyway.
+	BestEffortTopologyManagerPolicy TopologyManagerPolicy = "best-effort"
+
+	// RestrictedTopologyManagerPolicy rejects pods for which topology aligned resources cannot be allocated.
+	RestrictedTopologyManagerPolicy TopologyManagerPolicy = "restricted"
+
+	// SingleNUMANodeTopologyManagerPolicy rejects pods for which resources cannot be allocated on a single NUMA node.
+	SingleNUMANodeTopologyManagerPolicy TopologyManagerPolicy = "single-numa-node"
+)
+
+const (
+	// KubeletConfigurationPatchFileName is the name of the patch file generated from kubeletConfiguration
+	// in the patches directory.
+	// NOTE: The file has no suffix, so it is applied before any other kubeletconfiguration patch provided by users.
+	KubeletConfigurationPatchFileName = "kubeletconfiguration+strategic.json"
+
+	// DefaultPatchesDirectory is the patches directory used when kubeletConfiguration is set and
+	// no patches directory is set in the InitConfiguration or JoinConfiguration.
+	DefaultPatchesDirectory = "/etc/kubernetes/patches"
+)
+
+// KubeletConfiguration contains kubelet configuration settings.
+// See https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/ for more details about each setting;
+// settings which are not exposed by this type can still be configured using files and patches.
+// +kubebuilder:validation:MinProperties=1
+type KubeletConfiguration struct {
+	// cgroupDriver is the driver the kubelet uses to manipulate cgroups on the host.
+	// It must match the cgroup driver of the container runtime.
+	// +optional
+	CgroupDriver CgroupDriver `json:"cgroupDriver,omitempty"`
+
+	// maxPods is the maximum number of pods that can run on the node.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPods *int32 `json:"maxPods,omitempty"`
+
+	// podPidsLimit is the maximum number of PIDs in any pod; -1 means no limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	PodPidsLimit *int64 `json:"podPidsLimit,omitempty"`
+
+	// kubeReserved is the set of resources reserved for Kubernetes system components.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	KubeReserved []KubeletResourceReservation `json:"kubeReserved,omitempty"`
+
+	// systemReserved is the set of resources reserved for non-Kubernetes system components.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	SystemReserved []KubeletResourceReservation `json:"systemReserved,omitempty"`
+
+	// evictionHard is the set of hard eviction thresholds.
+	// +optional
+	// +listType=map
+	// +listMapKey=signal
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	EvictionHard []KubeletEvictionThreshold `json:"evictionHard,omitempty"`
+
+	// evictionSoft is the set of soft eviction thresholds.
+	// Each signal must have a corresponding grace period in evictionSoftGracePeriod.
+	// +optional
+	// +listType=map
+	// +listMapKey=signal
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	EvictionSoft []KubeletEvictionThreshold `json:"evictionSoft,omitempty"`
+
+	// evictionSoftGracePeriod is the set of grace periods for soft eviction thresholds.
+	// +optional
+	// +listType=map
+	// +listMapKey=signal
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	EvictionSoftGracePeriod []KubeletEvictionGracePeriod `json:"evictionSoftGracePeriod,omitempty"`
+
+	// imageGCHighThresholdPercent is the percent of disk usage after which image garbage collection is always run.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ImageGCHighThresholdPercent *int32 `json:"imageGCHighThresholdPercent,omitempty"`
+
+	// imageGCLowThresholdPercent is the percent of disk usage before which image garbage collection is never run.
+	// It must be lower than imageGCHighThresholdPercent.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ImageGCLowThresholdPercent *int32 `json:"imageGCLowThresholdPercent,omitempty"`
+
+	// serializeImagePulls tells the kubelet to pull images one at a time.
+	// +optional
+	SerializeImagePulls *bool `json:"serializeImagePulls,omitempty"`
+
+	// maxParallelImagePulls is the maximum number of image pulls in parallel.
+	// It can be set only if serializeImagePulls is false.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelImagePulls *int32 `json:"maxParallelImagePulls,omitempty"`
+
+	// containerLogMaxSize is the maximum size of a container log file before it is rotated, e.g. 10Mi.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	ContainerLogMaxSize string `json:"containerLogMaxSize,omitempty"`
+
+	// containerLogMaxFiles is the maximum number of container log files that can be present for a container.
+	// +optional
+	// +kubebuilder:validation:Minimum=2
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+
+	// shutdownGracePeriodSeconds is the total duration the node should delay the shutdown by,
+	// to allow pods to terminate gracefully.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ShutdownGracePeriodSeconds *int32 `json:"shutdownGracePeriodSeconds,omitempty"`
+
+	// shutdownGracePeriodCriticalPodsSeconds is the part of shutdownGracePeriodSeconds reserved to
+	// terminate critical pods. It must be lower than or equal to shutdownGracePeriodSeconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ShutdownGracePeriodCriticalPodsSeconds *int32 `json:"shutdownGracePeriodCriticalPodsSeconds,omitempty"`
+
+	// cpuManagerPolicy is the policy of the kubelet CPU manager.
+	// +optional
+	CPUManagerPolicy CPUManagerPolicy `json:"cpuManagerPolicy,omitempty"`
+
+	// topologyManagerPolicy is the policy of the kubelet topology manager.
+	// +optional
+	TopologyManagerPolicy TopologyManagerPolicy `json:"topologyManagerPolicy,omitempty"`
+
+	// protectKernelDefaults makes the kubelet error if kernel flags are not as it expects.
+	// +optional
+	ProtectKernelDefaults *bool `json:"protectKernelDefaults,omitempty"`
+}
+
+// IsDefined returns true if the KubeletConfiguration is defined.
+func (r *KubeletConfiguration) IsDefined() bool {
+	return !reflect.DeepEqual(r, &KubeletConfiguration{})
+}
+
+// KubeletResourceReservation defines the amount of a resource reserved on a node.
+type KubeletResourceReservation struct {
+	// name of the resource, e.g. cpu, memory, ephemeral-storage or pid.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+
+	// quantity of the resource, e.g. 500m or 1Gi.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Quantity string `json:"quantity,omitempty"`
+}
+
+// KubeletEvictionThreshold defines an eviction threshold for an eviction signal.
+type KubeletEvictionThreshold struct {
+	// signal is the eviction signal, e.g. memory.available or nodefs.available.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Signal string `json:"signal,omitempty"`
+
+	// value is the threshold of the signal, either a quantity, e.g. 100Mi, or a percentage, e.g. 10%.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	Value string `json:"value,omitempty"`
+}
+
+// KubeletEvictionGracePeriod defines the grace period for a soft eviction threshold.
+type KubeletEvictionGracePeriod struct {
+	// signal is the eviction signal, e.g. memory.available or nodefs.available.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Signal string `json:"signal,omitempty"`
+
+	// periodSeconds is the grace period for the signal.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+}
+
 // KubeadmConfigStatus defines the observed state of KubeadmConfig.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmConfigStatus struct {
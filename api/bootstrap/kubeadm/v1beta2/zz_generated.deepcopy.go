@@ -107,6 +107,21 @@ func (in *Arg) DeepCopy() *Arg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapDataTemplating) DeepCopyInto(out *BootstrapDataTemplating) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapDataTemplating.
+func (in *BootstrapDataTemplating) DeepCopy() *BootstrapDataTemplating {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapDataTemplating)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapToken) DeepCopyInto(out *BootstrapToken) {
 	*out = *in
@@ -179,6 +194,21 @@ func (in *BootstrapTokenString) DeepCopy() *BootstrapTokenString {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ButaneConfig) DeepCopyInto(out *ButaneConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ButaneConfig.
+func (in *ButaneConfig) DeepCopy() *ButaneConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ButaneConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfiguration) DeepCopyInto(out *ClusterConfiguration) {
 	*out = *in
@@ -495,10 +525,84 @@ func (in *HostPathMount) DeepCopy() *HostPathMount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionDirectory) DeepCopyInto(out *IgnitionDirectory) {
+	*out = *in
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionDirectory.
+func (in *IgnitionDirectory) DeepCopy() *IgnitionDirectory {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionDirectory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionKernelArguments) DeepCopyInto(out *IgnitionKernelArguments) {
+	*out = *in
+	if in.ShouldExist != nil {
+		in, out := &in.ShouldExist, &out.ShouldExist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShouldNotExist != nil {
+		in, out := &in.ShouldNotExist, &out.ShouldNotExist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionKernelArguments.
+func (in *IgnitionKernelArguments) DeepCopy() *IgnitionKernelArguments {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionKernelArguments)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionLink) DeepCopyInto(out *IgnitionLink) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionLink.
+func (in *IgnitionLink) DeepCopy() *IgnitionLink {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IgnitionSpec) DeepCopyInto(out *IgnitionSpec) {
 	*out = *in
 	in.ContainerLinuxConfig.DeepCopyInto(&out.ContainerLinuxConfig)
+	out.Butane = in.Butane
+	in.Systemd.DeepCopyInto(&out.Systemd)
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.KernelArguments.DeepCopyInto(&out.KernelArguments)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionSpec.
@@ -511,6 +615,102 @@ func (in *IgnitionSpec) DeepCopy() *IgnitionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionStorage) DeepCopyInto(out *IgnitionStorage) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]IgnitionLink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]IgnitionDirectory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionStorage.
+func (in *IgnitionStorage) DeepCopy() *IgnitionStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionSystemd) DeepCopyInto(out *IgnitionSystemd) {
+	*out = *in
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]IgnitionSystemdUnit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionSystemd.
+func (in *IgnitionSystemd) DeepCopy() *IgnitionSystemd {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionSystemd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionSystemdDropin) DeepCopyInto(out *IgnitionSystemdDropin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionSystemdDropin.
+func (in *IgnitionSystemdDropin) DeepCopy() *IgnitionSystemdDropin {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionSystemdDropin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnitionSystemdUnit) DeepCopyInto(out *IgnitionSystemdUnit) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Mask != nil {
+		in, out := &in.Mask, &out.Mask
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Dropins != nil {
+		in, out := &in.Dropins, &out.Dropins
+		*out = make([]IgnitionSystemdDropin, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnitionSystemdUnit.
+func (in *IgnitionSystemdUnit) DeepCopy() *IgnitionSystemdUnit {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnitionSystemdUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InitConfiguration) DeepCopyInto(out *InitConfiguration) {
 	*out = *in
@@ -849,6 +1049,8 @@ func (in *KubeadmConfigSpec) DeepCopyInto(out *KubeadmConfigSpec) {
 		**out = **in
 	}
 	in.Ignition.DeepCopyInto(&out.Ignition)
+	out.BootstrapDataTemplating = in.BootstrapDataTemplating
+	in.KubeletConfiguration.DeepCopyInto(&out.KubeletConfiguration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigSpec.
@@ -1002,6 +1204,148 @@ func (in *KubeadmConfigV1Beta1DeprecatedStatus) DeepCopy() *KubeadmConfigV1Beta1
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfiguration) DeepCopyInto(out *KubeletConfiguration) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodPidsLimit != nil {
+		in, out := &in.PodPidsLimit, &out.PodPidsLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make([]KubeletResourceReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make([]KubeletResourceReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make([]KubeletEvictionThreshold, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictionSoft != nil {
+		in, out := &in.EvictionSoft, &out.EvictionSoft
+		*out = make([]KubeletEvictionThreshold, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictionSoftGracePeriod != nil {
+		in, out := &in.EvictionSoftGracePeriod, &out.EvictionSoftGracePeriod
+		*out = make([]KubeletEvictionGracePeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImageGCHighThresholdPercent != nil {
+		in, out := &in.ImageGCHighThresholdPercent, &out.ImageGCHighThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageGCLowThresholdPercent != nil {
+		in, out := &in.ImageGCLowThresholdPercent, &out.ImageGCLowThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SerializeImagePulls != nil {
+		in, out := &in.SerializeImagePulls, &out.SerializeImagePulls
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxParallelImagePulls != nil {
+		in, out := &in.MaxParallelImagePulls, &out.MaxParallelImagePulls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ShutdownGracePeriodSeconds != nil {
+		in, out := &in.ShutdownGracePeriodSeconds, &out.ShutdownGracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ShutdownGracePeriodCriticalPodsSeconds != nil {
+		in, out := &in.ShutdownGracePeriodCriticalPodsSeconds, &out.ShutdownGracePeriodCriticalPodsSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProtectKernelDefaults != nil {
+		in, out := &in.ProtectKernelDefaults, &out.ProtectKernelDefaults
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfiguration.
+func (in *KubeletConfiguration) DeepCopy() *KubeletConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletEvictionGracePeriod) DeepCopyInto(out *KubeletEvictionGracePeriod) {
+	*out = *in
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletEvictionGracePeriod.
+func (in *KubeletEvictionGracePeriod) DeepCopy() *KubeletEvictionGracePeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletEvictionGracePeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletEvictionThreshold) DeepCopyInto(out *KubeletEvictionThreshold) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletEvictionThreshold.
+func (in *KubeletEvictionThreshold) DeepCopy() *KubeletEvictionThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletEvictionThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletResourceReservation) DeepCopyInto(out *KubeletResourceReservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletResourceReservation.
+func (in *KubeletResourceReservation) DeepCopy() *KubeletResourceReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletResourceReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalEtcd) DeepCopyInto(out *LocalEtcd) {
 	*out = *in
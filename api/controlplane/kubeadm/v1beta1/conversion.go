@@ -78,6 +78,10 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dst.Spec.KubeadmConfigSpec)
+		dst.Spec.EtcdMaintenance = restored.Spec.EtcdMaintenance
+		dst.Status.Etcd = restored.Status.Etcd
+		dst.Spec.Encryption = restored.Spec.Encryption
+		dst.Status.Encryption = restored.Status.Encryption
 	}
 
 	if src.Spec.RemediationStrategy != nil {
@@ -134,6 +138,8 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.Template.Spec.KubeadmConfigSpec, &dst.Spec.Template.Spec.KubeadmConfigSpec)
+		dst.Spec.Template.Spec.EtcdMaintenance = restored.Spec.Template.Spec.EtcdMaintenance
+		dst.Spec.Template.Spec.Encryption = restored.Spec.Template.Spec.Encryption
 	}
 
 	if src.Spec.Template.Spec.RemediationStrategy != nil {
@@ -69,6 +69,20 @@ const (
 	// ensure it runs last (thus ensuring that kubelet is still working while other pre-terminate hooks run).
 	PreTerminateHookCleanupAnnotation = clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/kcp-cleanup"
 
+	// EncryptionPrimaryKeyAnnotation is the annotation KCP sets on the Secret storing the encryption keys to track the
+	// name of the primary key, i.e. the key used for encryption when no key rotation is in progress.
+	// NOTE: The state of the key rotation is stored on the Secret storing the keys, so it is updated atomically with the
+	// keys and it is preserved when the KCP status is lost, e.g. during clusterctl move.
+	EncryptionPrimaryKeyAnnotation = "controlplane.cluster.x-k8s.io/encryption-primary-key"
+
+	// EncryptionKeyRotationPhaseAnnotation is the annotation KCP sets on the Secret storing the encryption keys to track
+	// the phase of the key rotation in progress, if any.
+	EncryptionKeyRotationPhaseAnnotation = "controlplane.cluster.x-k8s.io/encryption-key-rotation-phase"
+
+	// EncryptionNewKeyAnnotation is the annotation KCP sets on the Secret storing the encryption keys to track
+	// the name of the key which is replacing the primary key during a key rotation.
+	EncryptionNewKeyAnnotation = "controlplane.cluster.x-k8s.io/encryption-new-key"
+
 	// DefaultMinHealthyPeriodSeconds defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriodSeconds = int32(60 * 60)
@@ -402,6 +416,10 @@ const (
 	// KubeadmControlPlaneMachineEtcdMemberHealthyReason surfaces when the etcd member hosted on a KubeadmControlPlane controlled machine is healthy.
 	KubeadmControlPlaneMachineEtcdMemberHealthyReason = "Healthy"
 
+	// KubeadmControlPlaneMachineEtcdMemberLearnerReason surfaces when the etcd member hosted on a KubeadmControlPlane controlled machine
+	// is a learner, not yet promoted to voting member.
+	KubeadmControlPlaneMachineEtcdMemberLearnerReason = "Learner"
+
 	// KubeadmControlPlaneMachineEtcdMemberInspectionFailedReason documents a failure when inspecting the status of an
 	// etcd member hosted on a KubeadmControlPlane controlled machine.
 	KubeadmControlPlaneMachineEtcdMemberInspectionFailedReason = clusterv1.InspectionFailedReason
@@ -458,6 +476,21 @@ type KubeadmControlPlaneSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcdMaintenance controls the maintenance operations KCP runs on the etcd cluster,
+	// e.g. defragmentation of etcd members and remediation of the NOSPACE alarm.
+	// Maintenance operations are only run for the etcd cluster managed by KCP (local etcd).
+	// Note: This field is considered only if the KubeadmControlPlaneEtcdMaintenance feature flag is enabled.
+	// +optional
+	EtcdMaintenance KubeadmControlPlaneEtcdMaintenanceSpec `json:"etcdMaintenance,omitempty,omitzero"`
+
+	// encryption configures encryption at rest of Secrets stored in etcd by the API server of the workload cluster.
+	// KCP generates the encryption keys, stores them in a Secret in the management cluster and
+	// rotates them when requested.
+	// Once set, encryption cannot be disabled and the provider cannot be changed.
+	// Note: This field is considered only if the KubeadmControlPlaneEncryptionAtRest feature flag is enabled.
+	// +optional
+	Encryption KubeadmControlPlaneEncryptionSpec `json:"encryption,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -657,6 +690,147 @@ type MachineNamingSpec struct {
 	Template string `json:"template,omitempty"`
 }
 
+// KubeadmControlPlaneEtcdNoSpaceAlarmRemediationPolicy defines how KCP reacts to the etcd NOSPACE alarm.
+// +kubebuilder:validation:Enum=None;Remediate
+type KubeadmControlPlaneEtcdNoSpaceAlarmRemediationPolicy string
+
+const (
+	// KubeadmControlPlaneEtcdNoSpaceAlarmRemediationNone means that KCP only reports the NOSPACE alarm.
+	KubeadmControlPlaneEtcdNoSpaceAlarmRemediationNone KubeadmControlPlaneEtcdNoSpaceAlarmRemediationPolicy = "None"
+
+	// KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate means that KCP compacts the etcd keyspace,
+	// defragments all the etcd members and then disarms the NOSPACE alarm.
+	KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate KubeadmControlPlaneEtcdNoSpaceAlarmRemediationPolicy = "Remediate"
+)
+
+// KubeadmControlPlaneEtcdMaintenanceSpec controls the maintenance operations KCP runs on the etcd cluster.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdMaintenanceSpec struct {
+	// compaction controls when KCP compacts the etcd keyspace.
+	// Compaction removes the key revisions older than the current revision minus a retention window of
+	// 10000 revisions, so watchers lagging behind can still resume; the space freed by the compaction
+	// is reclaimed when etcd members are defragmented.
+	// +optional
+	Compaction KubeadmControlPlaneEtcdCompactionSpec `json:"compaction,omitempty,omitzero"`
+
+	// defragmentation controls when KCP defragments etcd members.
+	// Members are defragmented one at a time, the etcd leader last; before defragmenting the leader,
+	// KCP moves leadership to another member.
+	// +optional
+	Defragmentation KubeadmControlPlaneEtcdDefragmentationSpec `json:"defragmentation,omitempty,omitzero"`
+
+	// noSpaceAlarmRemediation defines how KCP reacts when etcd raises the NOSPACE alarm because the
+	// database size exceeded the backend quota (see the etcd quota-backend-bytes flag).
+	// When set to Remediate, KCP compacts the etcd keyspace to the current revision, defragments all the members
+	// one at a time and then disarms the alarm.
+	// When set to None, KCP only reports the alarm in the EtcdMemberHealthy condition of the Machines.
+	// If not set, this value is defaulted to None.
+	// +optional
+	NoSpaceAlarmRemediation KubeadmControlPlaneEtcdNoSpaceAlarmRemediationPolicy `json:"noSpaceAlarmRemediation,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdCompactionSpec controls when KCP compacts the etcd keyspace.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdCompactionSpec struct {
+	// intervalSeconds is the interval between two compactions of the etcd keyspace.
+	// If not set, the etcd keyspace is not compacted periodically.
+	// +optional
+	// +kubebuilder:validation:Minimum=3600
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdDefragmentationSpec controls when KCP defragments etcd members.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdDefragmentationSpec struct {
+	// intervalSeconds is the interval between two defragmentations of the same etcd member.
+	// If not set, etcd members are not defragmented periodically.
+	// +optional
+	// +kubebuilder:validation:Minimum=3600
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// fragmentationThresholdPercent is the percentage of the database of an etcd member which is not in use
+	// above which KCP defragments the member.
+	// If not set, etcd members are not defragmented based on their fragmentation.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	FragmentationThresholdPercent *int32 `json:"fragmentationThresholdPercent,omitempty"`
+}
+
+// KubeadmControlPlaneEncryptionProvider defines the provider used to encrypt Secrets at rest.
+// +kubebuilder:validation:Enum=AESCBC;AESGCM;Secretbox;KMS
+type KubeadmControlPlaneEncryptionProvider string
+
+const (
+	// KubeadmControlPlaneEncryptionProviderAESCBC encrypts Secrets using AES-CBC with PKCS#7 padding and a 32-byte key.
+	KubeadmControlPlaneEncryptionProviderAESCBC KubeadmControlPlaneEncryptionProvider = "AESCBC"
+
+	// KubeadmControlPlaneEncryptionProviderAESGCM encrypts Secrets using AES-GCM with a random nonce and a 32-byte key.
+	KubeadmControlPlaneEncryptionProviderAESGCM KubeadmControlPlaneEncryptionProvider = "AESGCM"
+
+	// KubeadmControlPlaneEncryptionProviderSecretbox encrypts Secrets using XSalsa20 and Poly1305 with a 32-byte key.
+	KubeadmControlPlaneEncryptionProviderSecretbox KubeadmControlPlaneEncryptionProvider = "Secretbox"
+
+	// KubeadmControlPlaneEncryptionProviderKMS encrypts Secrets using envelope encryption with a KMS v2 plugin
+	// running on the control plane Machines; keys are managed by the KMS plugin.
+	KubeadmControlPlaneEncryptionProviderKMS KubeadmControlPlaneEncryptionProvider = "KMS"
+)
+
+// KubeadmControlPlaneEncryptionSpec configures encryption at rest of Secrets.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEncryptionSpec struct {
+	// provider is the provider used to encrypt Secrets.
+	// +required
+	Provider KubeadmControlPlaneEncryptionProvider `json:"provider,omitempty"`
+
+	// kms configures the KMS v2 plugin used to encrypt Secrets.
+	// This field is required if provider is KMS, and it must not be set otherwise.
+	// +optional
+	KMS KubeadmControlPlaneEncryptionKMSSpec `json:"kms,omitempty,omitzero"`
+
+	// rotateKeyAfter is a field to indicate a rotation of the encryption key should be performed
+	// after the specified time, if the current key was generated before it.
+	// A rotation adds a new key, rolls out the control plane, promotes the new key as primary key,
+	// rolls out the control plane again, rewrites all the Secrets in the workload cluster and finally
+	// removes the old key, rolling out the control plane a last time.
+	// Key rotation is not supported with the KMS provider, because keys are managed by the KMS plugin.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the rotateKeyAfter target as March 9, 2023, at 9 am UTC
+	// use "2023-03-09T09:00:00Z".
+	// +optional
+	RotateKeyAfter metav1.Time `json:"rotateKeyAfter,omitempty,omitzero"`
+}
+
+// IsDefined returns true if encryption at rest is configured.
+func (e *KubeadmControlPlaneEncryptionSpec) IsDefined() bool {
+	return e.Provider != ""
+}
+
+// KubeadmControlPlaneEncryptionKMSSpec configures a KMS v2 plugin.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEncryptionKMSSpec struct {
+	// name is the name of the KMS plugin.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// endpoint is the unix socket the KMS plugin listens on, e.g. unix:///var/run/kms-plugin/socket.sock.
+	// The directory containing the socket is mounted in the kube-apiserver Pod, so a dedicated directory should be used.
+	// Note: The KMS plugin must be deployed on the control plane Machines, e.g. as a static Pod or using
+	// the files and preKubeadmCommands fields of the KubeadmConfigSpec.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// timeoutSeconds is the timeout for the gRPC calls to the KMS plugin.
+	// If not set, the API server default is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmControlPlaneStatus struct {
@@ -717,6 +891,16 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation LastRemediationStatus `json:"lastRemediation,omitempty,omitzero"`
 
+	// etcd reports the observed state of the etcd cluster managed by KCP.
+	// Note: This field is set only if the KubeadmControlPlaneEtcdMaintenance feature flag is enabled.
+	// +optional
+	Etcd KubeadmControlPlaneEtcdStatus `json:"etcd,omitempty,omitzero"`
+
+	// encryption reports the observed state of encryption at rest.
+	// Note: This field is set only if the KubeadmControlPlaneEncryptionAtRest feature flag is enabled.
+	// +optional
+	Encryption KubeadmControlPlaneEncryptionStatus `json:"encryption,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *KubeadmControlPlaneDeprecatedStatus `json:"deprecated,omitempty"`
@@ -797,6 +981,119 @@ type KubeadmControlPlaneV1Beta1DeprecatedStatus struct {
 	UnavailableReplicas int32 `json:"unavailableReplicas"` //nolint:kubeapilinter // field will be removed when v1beta1 is removed
 }
 
+// KubeadmControlPlaneEtcdStatus reports the observed state of the etcd cluster managed by KCP.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdStatus struct {
+	// members reports the status of the etcd members.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Members []KubeadmControlPlaneEtcdMemberStatus `json:"members,omitempty"`
+
+	// lastCompactionTime is the time when KCP last compacted the etcd keyspace while remediating the NOSPACE alarm.
+	// +optional
+	LastCompactionTime metav1.Time `json:"lastCompactionTime,omitempty,omitzero"`
+
+	// lastAlarmDisarmTime is the time when KCP last disarmed the NOSPACE alarm.
+	// +optional
+	LastAlarmDisarmTime metav1.Time `json:"lastAlarmDisarmTime,omitempty,omitzero"`
+
+	// lastScheduledCompactionTime is the time when KCP last compacted the etcd keyspace because of
+	// spec.etcdMaintenance.compaction.
+	// +optional
+	LastScheduledCompactionTime metav1.Time `json:"lastScheduledCompactionTime,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdMemberStatus reports the status of an etcd member.
+type KubeadmControlPlaneEtcdMemberStatus struct {
+	// name is the name of the etcd member, which is the name of the Node hosting the member.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// dbSizeBytes is the size of the database of the etcd member, in bytes.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DBSizeBytes *int64 `json:"dbSizeBytes,omitempty"`
+
+	// dbSizeInUseBytes is the size of the database of the etcd member which is logically in use, in bytes.
+	// The difference between dbSizeBytes and dbSizeInUseBytes is the space which can be reclaimed by defragmenting the member.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DBSizeInUseBytes *int64 `json:"dbSizeInUseBytes,omitempty"`
+
+	// lastDefragmentationTime is the time when KCP last defragmented the etcd member.
+	// +optional
+	LastDefragmentationTime metav1.Time `json:"lastDefragmentationTime,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEncryptionKeyRotationPhase is a phase of the rotation of the encryption key.
+// +kubebuilder:validation:Enum=AddingKey;PromotingKey;RewritingSecrets;RemovingKey
+type KubeadmControlPlaneEncryptionKeyRotationPhase string
+
+const (
+	// KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase is the phase when the new key is added to the
+	// encryption configuration as a secondary key, which is used only for decryption.
+	KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase KubeadmControlPlaneEncryptionKeyRotationPhase = "AddingKey"
+
+	// KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase is the phase when the new key is promoted as the
+	// primary key, which is used for encryption.
+	KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase KubeadmControlPlaneEncryptionKeyRotationPhase = "PromotingKey"
+
+	// KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase is the phase when all the Secrets in the workload
+	// cluster are rewritten, so they are encrypted with the new key.
+	KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase KubeadmControlPlaneEncryptionKeyRotationPhase = "RewritingSecrets"
+
+	// KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase is the phase when the old key is removed from the
+	// encryption configuration.
+	KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase KubeadmControlPlaneEncryptionKeyRotationPhase = "RemovingKey"
+)
+
+// KubeadmControlPlaneEncryptionStatus reports the observed state of encryption at rest.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEncryptionStatus struct {
+	// configurationSecretName is the name of the Secret containing the encryption configuration
+	// which is expected on the control plane Machines.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ConfigurationSecretName string `json:"configurationSecretName,omitempty"`
+
+	// primaryKeyName is the name of the key used to encrypt Secrets when no rotation is in progress.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	PrimaryKeyName string `json:"primaryKeyName,omitempty"`
+
+	// primaryKeyCreationTime is the time when the primary key was generated.
+	// +optional
+	PrimaryKeyCreationTime metav1.Time `json:"primaryKeyCreationTime,omitempty,omitzero"`
+
+	// keyRotation reports the progress of the rotation of the encryption key, if a rotation is in progress.
+	// +optional
+	KeyRotation KubeadmControlPlaneEncryptionKeyRotationStatus `json:"keyRotation,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEncryptionKeyRotationStatus reports the progress of the rotation of the encryption key.
+type KubeadmControlPlaneEncryptionKeyRotationStatus struct {
+	// phase is the current phase of the key rotation.
+	// +required
+	Phase KubeadmControlPlaneEncryptionKeyRotationPhase `json:"phase,omitempty"`
+
+	// newKeyName is the name of the key which is replacing the primary key.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	NewKeyName string `json:"newKeyName,omitempty"`
+
+	// newKeyCreationTime is the time when the new key was generated.
+	// +required
+	NewKeyCreationTime metav1.Time `json:"newKeyCreationTime,omitempty,omitzero"`
+}
+
 // LastRemediationStatus  stores info about last remediation performed.
 // NOTE: if for any reason information about last remediation are lost, RetryCount is going to restart from 0 and thus
 // more remediations than expected might happen.
@@ -111,6 +111,21 @@ type KubeadmControlPlaneTemplateResourceSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcdMaintenance controls the maintenance operations KCP runs on the etcd cluster,
+	// e.g. defragmentation of etcd members and remediation of the NOSPACE alarm.
+	// Maintenance operations are only run for the etcd cluster managed by KCP (local etcd).
+	// Note: This field is considered only if the KubeadmControlPlaneEtcdMaintenance feature flag is enabled.
+	// +optional
+	EtcdMaintenance KubeadmControlPlaneEtcdMaintenanceSpec `json:"etcdMaintenance,omitempty,omitzero"`
+
+	// encryption configures encryption at rest of Secrets stored in etcd by the API server of the workload cluster.
+	// KCP generates the encryption keys, stores them in a Secret in the management cluster and
+	// rotates them when requested.
+	// Once set, encryption cannot be disabled and the provider cannot be changed.
+	// Note: This field is considered only if the KubeadmControlPlaneEncryptionAtRest feature flag is enabled.
+	// +optional
+	Encryption KubeadmControlPlaneEncryptionSpec `json:"encryption,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -74,6 +74,189 @@ func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopy() *KubeadmControlPlaneDe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEncryptionKMSSpec) DeepCopyInto(out *KubeadmControlPlaneEncryptionKMSSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEncryptionKMSSpec.
+func (in *KubeadmControlPlaneEncryptionKMSSpec) DeepCopy() *KubeadmControlPlaneEncryptionKMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEncryptionKMSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEncryptionKeyRotationStatus) DeepCopyInto(out *KubeadmControlPlaneEncryptionKeyRotationStatus) {
+	*out = *in
+	in.NewKeyCreationTime.DeepCopyInto(&out.NewKeyCreationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEncryptionKeyRotationStatus.
+func (in *KubeadmControlPlaneEncryptionKeyRotationStatus) DeepCopy() *KubeadmControlPlaneEncryptionKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEncryptionKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEncryptionSpec) DeepCopyInto(out *KubeadmControlPlaneEncryptionSpec) {
+	*out = *in
+	in.KMS.DeepCopyInto(&out.KMS)
+	in.RotateKeyAfter.DeepCopyInto(&out.RotateKeyAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEncryptionSpec.
+func (in *KubeadmControlPlaneEncryptionSpec) DeepCopy() *KubeadmControlPlaneEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEncryptionStatus) DeepCopyInto(out *KubeadmControlPlaneEncryptionStatus) {
+	*out = *in
+	in.PrimaryKeyCreationTime.DeepCopyInto(&out.PrimaryKeyCreationTime)
+	in.KeyRotation.DeepCopyInto(&out.KeyRotation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEncryptionStatus.
+func (in *KubeadmControlPlaneEncryptionStatus) DeepCopy() *KubeadmControlPlaneEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdCompactionSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdCompactionSpec) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdCompactionSpec.
+func (in *KubeadmControlPlaneEtcdCompactionSpec) DeepCopy() *KubeadmControlPlaneEtcdCompactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdCompactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdDefragmentationSpec) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FragmentationThresholdPercent != nil {
+		in, out := &in.FragmentationThresholdPercent, &out.FragmentationThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdDefragmentationSpec.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopy() *KubeadmControlPlaneEtcdDefragmentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdDefragmentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdMaintenanceSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdMaintenanceSpec) {
+	*out = *in
+	in.Compaction.DeepCopyInto(&out.Compaction)
+	in.Defragmentation.DeepCopyInto(&out.Defragmentation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdMaintenanceSpec.
+func (in *KubeadmControlPlaneEtcdMaintenanceSpec) DeepCopy() *KubeadmControlPlaneEtcdMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdMemberStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdMemberStatus) {
+	*out = *in
+	if in.DBSizeBytes != nil {
+		in, out := &in.DBSizeBytes, &out.DBSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DBSizeInUseBytes != nil {
+		in, out := &in.DBSizeInUseBytes, &out.DBSizeInUseBytes
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastDefragmentationTime.DeepCopyInto(&out.LastDefragmentationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdMemberStatus.
+func (in *KubeadmControlPlaneEtcdMemberStatus) DeepCopy() *KubeadmControlPlaneEtcdMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]KubeadmControlPlaneEtcdMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastCompactionTime.DeepCopyInto(&out.LastCompactionTime)
+	in.LastAlarmDisarmTime.DeepCopyInto(&out.LastAlarmDisarmTime)
+	in.LastScheduledCompactionTime.DeepCopyInto(&out.LastScheduledCompactionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdStatus.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopy() *KubeadmControlPlaneEtcdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneInitializationStatus) DeepCopyInto(out *KubeadmControlPlaneInitializationStatus) {
 	*out = *in
@@ -307,6 +490,8 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.EtcdMaintenance.DeepCopyInto(&out.EtcdMaintenance)
+	in.Encryption.DeepCopyInto(&out.Encryption)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -351,6 +536,8 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 		**out = **in
 	}
 	in.LastRemediation.DeepCopyInto(&out.LastRemediation)
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.Encryption.DeepCopyInto(&out.Encryption)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(KubeadmControlPlaneDeprecatedStatus)
@@ -514,6 +701,8 @@ func (in *KubeadmControlPlaneTemplateResourceSpec) DeepCopyInto(out *KubeadmCont
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.EtcdMaintenance.DeepCopyInto(&out.EtcdMaintenance)
+	in.Encryption.DeepCopyInto(&out.Encryption)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneTemplateResourceSpec.
@@ -84,6 +84,26 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 	if !reflect.DeepEqual(initialization, clusterv1.ClusterInitializationStatus{}) {
 		dst.Status.Initialization = initialization
 	}
+
+	// Restore the topology status and the pinned ClusterClass revision which only exist in v1beta2.
+	if ok {
+		dst.Status.Topology = restored.Status.Topology
+		if dst.Spec.Topology.IsDefined() {
+			dst.Spec.Topology.ClassRef.Revision = restored.Spec.Topology.ClassRef.Revision
+		}
+	}
+
+	// Restore MachinePool health checks which only exist in v1beta2.
+	if ok && dst.Spec.Topology.IsDefined() {
+		for i, mp := range dst.Spec.Topology.Workers.MachinePools {
+			for _, restoredMP := range restored.Spec.Topology.Workers.MachinePools {
+				if restoredMP.Name == mp.Name {
+					dst.Spec.Topology.Workers.MachinePools[i].HealthCheck = restoredMP.HealthCheck
+					break
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -176,6 +196,75 @@ func (src *ClusterClass) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
+	// Restore rollout spec and status which only exist in v1beta2.
+	if ok {
+		dst.Spec.Rollout = restored.Spec.Rollout
+		dst.Status.Rollout = restored.Status.Rollout
+		dst.Status.Revision = restored.Status.Revision
+	}
+
+	// Restore add-ons which only exist in v1beta2.
+	if ok {
+		dst.Spec.Addons = restored.Spec.Addons
+	}
+
+	// Restore MachinePool health checks and MachineDrainRules which only exist in v1beta2.
+	if ok {
+		for i, md := range dst.Spec.Workers.MachineDeployments {
+			for _, restoredMD := range restored.Spec.Workers.MachineDeployments {
+				if restoredMD.Class == md.Class {
+					dst.Spec.Workers.MachineDeployments[i].MachineDrainRules = restoredMD.MachineDrainRules
+					break
+				}
+			}
+		}
+		for i, mp := range dst.Spec.Workers.MachinePools {
+			for _, restoredMP := range restored.Spec.Workers.MachinePools {
+				if restoredMP.Class == mp.Class {
+					dst.Spec.Workers.MachinePools[i].HealthCheck = restoredMP.HealthCheck
+					dst.Spec.Workers.MachinePools[i].MachineDrainRules = restoredMP.MachineDrainRules
+					break
+				}
+			}
+		}
+	}
+
+	// Restore CEL expressions, add-on selectors and fields of external patches which only exist in v1beta2.
+	if ok {
+		for i, patch := range dst.Spec.Patches {
+			for _, p := range restored.Spec.Patches {
+				if p.Name != patch.Name {
+					continue
+				}
+				dst.Spec.Patches[i].EnabledIfExpression = p.EnabledIfExpression
+				if p.External != nil && dst.Spec.Patches[i].External != nil {
+					dst.Spec.Patches[i].External.DefaultClusterVariablesExtension = p.External.DefaultClusterVariablesExtension
+				}
+				if len(p.Definitions) != len(patch.Definitions) {
+					break
+				}
+				for j, definition := range patch.Definitions {
+					dst.Spec.Patches[i].Definitions[j].Selector.MatchResources.AddonClass = p.Definitions[j].Selector.MatchResources.AddonClass
+					if len(p.Definitions[j].JSONPatches) != len(definition.JSONPatches) {
+						continue
+					}
+					for k, jsonPatch := range definition.JSONPatches {
+						restoredValueFrom := p.Definitions[j].JSONPatches[k].ValueFrom
+						if restoredValueFrom == nil || restoredValueFrom.Expression == "" {
+							continue
+						}
+						if jsonPatch.ValueFrom == nil {
+							jsonPatch.ValueFrom = &clusterv1.JSONPatchValue{}
+						}
+						jsonPatch.ValueFrom.Expression = restoredValueFrom.Expression
+						dst.Spec.Patches[i].Definitions[j].JSONPatches[k] = jsonPatch
+					}
+				}
+				break
+			}
+		}
+	}
+
 	for i, variable := range dst.Spec.Variables {
 		var srcVariable *ClusterClassVariable
 		for _, v := range src.Spec.Variables {
@@ -1878,6 +1967,18 @@ func Convert_v1beta2_ClusterClassVariable_To_v1beta1_ClusterClassVariable(in *cl
 	return autoConvert_v1beta2_ClusterClassVariableMetadata_To_v1beta1_ClusterClassVariableMetadata(&in.DeprecatedV1Beta1Metadata, &out.Metadata, s)
 }
 
+func Convert_v1beta2_ClusterClassPatch_To_v1beta1_ClusterClassPatch(in *clusterv1.ClusterClassPatch, out *ClusterClassPatch, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ClusterClassPatch_To_v1beta1_ClusterClassPatch(in, out, s)
+}
+
+func Convert_v1beta2_PatchSelectorMatch_To_v1beta1_PatchSelectorMatch(in *clusterv1.PatchSelectorMatch, out *PatchSelectorMatch, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_PatchSelectorMatch_To_v1beta1_PatchSelectorMatch(in, out, s)
+}
+
+func Convert_v1beta2_JSONPatchValue_To_v1beta1_JSONPatchValue(in *clusterv1.JSONPatchValue, out *JSONPatchValue, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_JSONPatchValue_To_v1beta1_JSONPatchValue(in, out, s)
+}
+
 func Convert_v1beta1_ExternalPatchDefinition_To_v1beta2_ExternalPatchDefinition(in *ExternalPatchDefinition, out *clusterv1.ExternalPatchDefinition, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta1_ExternalPatchDefinition_To_v1beta2_ExternalPatchDefinition(in, out, s); err != nil {
 		return err
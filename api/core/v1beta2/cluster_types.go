@@ -119,6 +119,14 @@ const (
 	// with the ClusterClass surfaced in the ClusterClass status or controller logs.
 	ClusterTopologyReconciledClusterClassNotReconciledReason = "ClusterClassNotReconciled"
 
+	// ClusterTopologyReconciledClusterClassRolloutPendingReason documents reconciliation of a Cluster topology not
+	// yet completed because the current generation of the ClusterClass has not been released to the Cluster yet.
+	ClusterTopologyReconciledClusterClassRolloutPendingReason = "ClusterClassRolloutPending"
+
+	// ClusterTopologyReconciledClusterClassRolloutAbortedReason documents reconciliation of a Cluster topology with the
+	// revision of the ClusterClass before the current generation, because the rollout of the current generation has been aborted.
+	ClusterTopologyReconciledClusterClassRolloutAbortedReason = "ClusterClassRolloutAborted"
+
 	// ClusterTopologyReconciledDeletingReason surfaces when the Cluster is deleting because the
 	// DeletionTimestamp is set.
 	ClusterTopologyReconciledDeletingReason = DeletingReason
@@ -595,6 +603,15 @@ type ClusterClassRef struct {
 	// +kubebuilder:validation:MaxLength=63
 	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
 	Namespace string `json:"namespace,omitempty"`
+
+	// revision pins the Cluster to a revision of the ClusterClass, as reported in the ClusterClass status.revision.
+	// If set, the topology of the Cluster is computed from the corresponding ClusterClassRevision instead of the
+	// current spec of the ClusterClass.
+	// Note: This field is considered only if the ClusterClassRevisions feature flag is enabled.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Revision string `json:"revision,omitempty"`
 }
 
 // ControlPlaneTopology specifies the parameters for the control plane nodes in the cluster.
@@ -1160,6 +1177,11 @@ type MachinePoolTopology struct {
 	// +kubebuilder:validation:items:MaxLength=256
 	FailureDomains []string `json:"failureDomains,omitempty"`
 
+	// healthCheck allows to enable, disable and override MachinePool health check
+	// configuration from the ClusterClass for this MachinePool.
+	// +optional
+	HealthCheck MachinePoolTopologyHealthCheck `json:"healthCheck,omitempty,omitzero"`
+
 	// deletion contains configuration options for Machine deletion.
 	// +optional
 	Deletion MachinePoolTopologyMachineDeletionSpec `json:"deletion,omitempty,omitzero"`
@@ -1184,6 +1206,132 @@ type MachinePoolTopology struct {
 	Variables MachinePoolVariables `json:"variables,omitempty,omitzero"`
 }
 
+// MachinePoolTopologyHealthCheck defines a MachineHealthCheck for MachinePool machines.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolTopologyHealthCheck struct {
+	// enabled controls if a MachineHealthCheck should be created for the target machines.
+	//
+	// If false: No MachineHealthCheck will be created.
+	//
+	// If not set(default): A MachineHealthCheck will be created if it is defined here or
+	//  in the associated ClusterClass. If no MachineHealthCheck is defined then none will be created.
+	//
+	// If true: A MachineHealthCheck is guaranteed to be created. Cluster validation will
+	// block if `enable` is true and no MachineHealthCheck definition is available.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// checks are the checks that are used to evaluate if a Machine is healthy.
+	//
+	// If one of checks and remediation fields are set, the system assumes that an healthCheck override is defined,
+	// and as a consequence the checks and remediation fields from Cluster will be used instead of the
+	// corresponding fields in ClusterClass.
+	//
+	// Independent of this configuration the MachineHealthCheck controller will always
+	// flag Machines with `cluster.x-k8s.io/remediate-machine` annotation and
+	// Machines with deleted Nodes as unhealthy.
+	//
+	// Furthermore, if checks.nodeStartupTimeoutSeconds is not set it
+	// is defaulted to 10 minutes and evaluated accordingly.
+	//
+	// +optional
+	Checks MachinePoolTopologyHealthCheckChecks `json:"checks,omitempty,omitzero"`
+
+	// remediation configures if and how remediations are triggered if a Machine is unhealthy.
+	//
+	// If one of checks and remediation fields are set, the system assumes that an healthCheck override is defined,
+	// and as a consequence the checks and remediation fields from cluster will be used instead of the
+	// corresponding fields in ClusterClass.
+	//
+	// If an health check override is defined and remediation or remediation.triggerIf is not set,
+	// remediation will always be triggered for unhealthy Machines.
+	//
+	// If an health check override is defined and remediation or remediation.templateRef is not set,
+	// the OwnerRemediated condition will be set on unhealthy Machines to trigger remediation via
+	// the owner of the Machines, i.e. the MachinePool; note that remediation of MachinePool Machines
+	// must be implemented by the infrastructure provider of the MachinePool.
+	//
+	// +optional
+	Remediation MachinePoolTopologyHealthCheckRemediation `json:"remediation,omitempty,omitzero"`
+}
+
+// IsDefined returns true if one of checks and remediation are not zero.
+func (m *MachinePoolTopologyHealthCheck) IsDefined() bool {
+	return !reflect.ValueOf(m.Checks).IsZero() || !reflect.ValueOf(m.Remediation).IsZero()
+}
+
+// MachinePoolTopologyHealthCheckChecks are the checks that are used to evaluate if a MachinePool Machine is healthy.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolTopologyHealthCheckChecks struct {
+	// nodeStartupTimeoutSeconds allows to set the maximum time for MachineHealthCheck
+	// to consider a Machine unhealthy if a corresponding Node isn't associated
+	// through a `Spec.ProviderID` field.
+	//
+	// The duration set in this field is compared to the greatest of:
+	// - Cluster's infrastructure ready condition timestamp (if and when available)
+	// - Control Plane's initialized condition timestamp (if and when available)
+	// - Machine's infrastructure ready condition timestamp (if and when available)
+	// - Machine's metadata creation timestamp
+	//
+	// Defaults to 10 minutes.
+	// If you wish to disable this feature, set the value explicitly to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	NodeStartupTimeoutSeconds *int32 `json:"nodeStartupTimeoutSeconds,omitempty"`
+
+	// unhealthyNodeConditions contains a list of conditions that determine
+	// whether a node is considered unhealthy. The conditions are combined in a
+	// logical OR, i.e. if any of the conditions is met, the node is unhealthy.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	UnhealthyNodeConditions []UnhealthyNodeCondition `json:"unhealthyNodeConditions,omitempty"`
+}
+
+// MachinePoolTopologyHealthCheckRemediation configures if and how remediations are triggered if a MachinePool Machine is unhealthy.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolTopologyHealthCheckRemediation struct {
+	// triggerIf configures if remediations are triggered.
+	// If this field is not set, remediations are always triggered.
+	// +optional
+	TriggerIf MachinePoolTopologyHealthCheckRemediationTriggerIf `json:"triggerIf,omitempty,omitzero"`
+
+	// templateRef is a reference to a remediation template
+	// provided by an infrastructure provider.
+	//
+	// This field is completely optional, when filled, the MachineHealthCheck controller
+	// creates a new object from the template referenced and hands off remediation of the machine to
+	// a controller that lives outside of Cluster API.
+	// +optional
+	TemplateRef MachineHealthCheckRemediationTemplateReference `json:"templateRef,omitempty,omitzero"`
+}
+
+// MachinePoolTopologyHealthCheckRemediationTriggerIf configures if remediations are triggered.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolTopologyHealthCheckRemediationTriggerIf struct {
+	// unhealthyLessThanOrEqualTo specifies that remediations are only triggered if the number of
+	// unhealthy Machines is less than or equal to the configured value.
+	// unhealthyInRange takes precedence if set.
+	//
+	// +optional
+	UnhealthyLessThanOrEqualTo *intstr.IntOrString `json:"unhealthyLessThanOrEqualTo,omitempty"`
+
+	// unhealthyInRange specifies that remediations are only triggered if the number of
+	// unhealthy Machines is in the configured range.
+	// Takes precedence over unhealthyLessThanOrEqualTo.
+	// Eg. "[3-5]" - This means that remediation will be allowed only when:
+	// (a) there are at least 3 unhealthy Machines (and)
+	// (b) there are at most 5 unhealthy Machines
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=^\[[0-9]+-[0-9]+\]$
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	UnhealthyInRange string `json:"unhealthyInRange,omitempty"`
+}
+
 // MachinePoolTopologyMachineDeletionSpec contains configuration options for Machine deletion.
 // +kubebuilder:validation:MinProperties=1
 type MachinePoolTopologyMachineDeletionSpec struct {
@@ -1353,11 +1501,88 @@ type ClusterStatus struct {
 	// +kubebuilder:validation:Minimum=1
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// topology groups all the observations about the managed topology of the Cluster.
+	// +optional
+	Topology *ClusterTopologyStatus `json:"topology,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *ClusterDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// ClusterTopologyStatus groups all the observations about the managed topology of a Cluster.
+type ClusterTopologyStatus struct {
+	// classGeneration is the generation of the ClusterClass the topology of the Cluster has been last reconciled with.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ClassGeneration int64 `json:"classGeneration,omitempty"`
+
+	// classRevision is the revision of the ClusterClass the topology of the Cluster has been last reconciled with.
+	// While the current generation of the ClusterClass is not released to the Cluster, the topology of the Cluster
+	// is reconciled with the corresponding ClusterClassRevision.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClassRevision string `json:"classRevision,omitempty"`
+
+	// addons are the add-ons of the ClusterClass which have been applied to the Cluster.
+	// They are used to delete the objects of add-ons which have been removed from the ClusterClass, as well as
+	// objects which have been removed from the manifests of an add-on.
+	// Note: This field is set only if the ClusterClassAddons feature flag is enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=100
+	Addons []ClusterTopologyAddonStatus `json:"addons,omitempty"`
+}
+
+// ClusterTopologyAddonStatus groups the objects which have been applied for an add-on of the ClusterClass.
+type ClusterTopologyAddonStatus struct {
+	// name of the add-on in the ClusterClass.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name,omitempty"`
+
+	// object is a reference to the add-on object in the management cluster.
+	// The add-on object is in the namespace of the Cluster.
+	// +optional
+	Object *ClusterTopologyAddonObjectReference `json:"object,omitempty"`
+
+	// workloadClusterObjects are references to the objects which have been applied to the workload cluster.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	WorkloadClusterObjects []ClusterTopologyAddonObjectReference `json:"workloadClusterObjects,omitempty"`
+}
+
+// ClusterTopologyAddonObjectReference is a reference to an object applied for an add-on.
+type ClusterTopologyAddonObjectReference struct {
+	// apiVersion of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// kind of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind,omitempty"`
+
+	// namespace of the object, empty for cluster-scoped objects.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
 // ClusterInitializationStatus provides observations of the Cluster initialization process.
 // NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial Cluster provisioning.
 // +kubebuilder:validation:MinProperties=1
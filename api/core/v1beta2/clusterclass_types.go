@@ -65,6 +65,44 @@ const (
 	ClusterClassRefVersionsUpToDateInternalErrorReason = InternalErrorReason
 )
 
+// ClusterClass RollingOut condition and corresponding reasons.
+const (
+	// ClusterClassRollingOutCondition is true if the current generation of the ClusterClass is being rolled out
+	// to the Clusters using it in waves, as defined in spec.rollout.
+	ClusterClassRollingOutCondition = RollingOutCondition
+
+	// ClusterClassRollingOutReason surfaces that the current generation of the ClusterClass is being rolled out.
+	ClusterClassRollingOutReason = RollingOutReason
+
+	// ClusterClassNotRollingOutReason surfaces that the current generation of the ClusterClass has been rolled out
+	// to all the Clusters, or that no waves are defined.
+	ClusterClassNotRollingOutReason = NotRollingOutReason
+
+	// ClusterClassRolloutPausedReason surfaces that the rollout of the current generation of the ClusterClass
+	// is paused.
+	ClusterClassRolloutPausedReason = "RolloutPaused"
+
+	// ClusterClassRolloutAbortedReason surfaces that the rollout of the current generation of the ClusterClass
+	// has been aborted.
+	ClusterClassRolloutAbortedReason = "RolloutAborted"
+
+	// ClusterClassRollingOutInternalErrorReason surfaces unexpected failures when computing the progress of the rollout
+	// of the current generation of the ClusterClass.
+	ClusterClassRollingOutInternalErrorReason = InternalErrorReason
+)
+
+const (
+	// ClusterClassRolloutPausedAnnotation can be set on a ClusterClass to pause the rollout of its current generation;
+	// no further waves are released while the annotation is set.
+	ClusterClassRolloutPausedAnnotation = "topology.cluster.x-k8s.io/rollout-paused"
+
+	// ClusterClassRolloutAbortedAnnotation can be set on a ClusterClass to abort the rollout of a generation; the value
+	// must be the aborted generation. Clusters which already adopted the aborted generation are reverted to the revision
+	// of the ClusterClass before the aborted generation, and all the Clusters will only adopt the next generation of
+	// the ClusterClass.
+	ClusterClassRolloutAbortedAnnotation = "topology.cluster.x-k8s.io/rollout-aborted"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterclasses,shortName=cc,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
@@ -119,6 +157,16 @@ type ClusterClassSpec struct {
 	// +optional
 	Workers WorkersClass `json:"workers,omitempty,omitzero"`
 
+	// addons is a list of add-ons which are created for every Cluster using this ClusterClass,
+	// e.g. IPPools or identities in the management cluster or manifests in the workload cluster.
+	// Note: This field is considered only if the ClusterClassAddons feature flag is enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Addons []AddonClass `json:"addons,omitempty"`
+
 	// variables defines the variables which can be configured
 	// in the Cluster topology and are then used in patches.
 	// +optional
@@ -135,6 +183,53 @@ type ClusterClassSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=1000
 	Patches []ClusterClassPatch `json:"patches,omitempty"`
+
+	// rollout defines how changes to the ClusterClass are rolled out to the Clusters using it.
+	// If not set, changes are rolled out to all the Clusters at once.
+	// Note: This field is considered only if the ClusterClassRollout feature flag is enabled.
+	// +optional
+	Rollout ClusterClassRollout `json:"rollout,omitempty,omitzero"`
+}
+
+// ClusterClassRollout defines how changes to a ClusterClass are rolled out to the Clusters using it.
+// +kubebuilder:validation:MinProperties=1
+type ClusterClassRollout struct {
+	// waves define the waves in which a new generation of the ClusterClass is rolled out to the Clusters.
+	// Waves are released one after the other, in the order of the array. A Cluster is part of the first wave
+	// selecting it; Clusters not selected by any wave are part of an implicit last wave.
+	// A wave is only released once all the Clusters of the previous waves adopted the new generation and
+	// are healthy, i.e. the Cluster is Available and it is not RollingOut (ControlPlane, MachineDeployments
+	// and MachinePools are not rolling out).
+	// Note: Clusters which did not adopt any generation of the ClusterClass yet, e.g. new Clusters, always
+	// adopt the current generation.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Waves []ClusterClassRolloutWave `json:"waves,omitempty"`
+}
+
+// ClusterClassRolloutWave defines a wave in which a new generation of the ClusterClass is rolled out.
+type ClusterClassRolloutWave struct {
+	// name of the wave.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name,omitempty"`
+
+	// selector selects the Clusters of the wave by labels.
+	// If not set, all the Clusters not selected by previous waves are candidates for this wave.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// percentage is the percentage of the candidate Clusters which are part of this wave.
+	// Clusters are selected in order of namespace and name.
+	// If not set, all the candidate Clusters are part of this wave.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
 }
 
 // InfrastructureClass defines the class for the infrastructure cluster.
@@ -390,6 +485,47 @@ type WorkersClass struct {
 	MachinePools []MachinePoolClass `json:"machinePools,omitempty"`
 }
 
+// AddonClass defines an add-on which is created for every Cluster using the ClusterClass.
+// +kubebuilder:validation:XValidation:rule="has(self.templateRef) != has(self.workloadClusterManifests)",message="exactly one of templateRef or workloadClusterManifests must be set"
+type AddonClass struct {
+	// name of the add-on, this name MUST be unique within a ClusterClass.
+	// The name is used to compute the name of the add-on object created in the management cluster.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// templateRef is a reference to the template of an add-on object in the management cluster.
+	// The template must follow the Cluster API template contract: the add-on object is created
+	// in the namespace of the Cluster, with the apiVersion of the template, the kind of the template
+	// without the Template suffix and with metadata and spec from the template's spec.template.
+	// Templates of add-on objects can be customized via patches, like all the other templates of the ClusterClass.
+	// +optional
+	TemplateRef ClusterClassTemplateReference `json:"templateRef,omitempty,omitzero"`
+
+	// workloadClusterManifests defines manifests which are applied to the workload cluster.
+	// +optional
+	WorkloadClusterManifests AddonClassWorkloadClusterManifests `json:"workloadClusterManifests,omitempty,omitzero"`
+}
+
+// AddonClassWorkloadClusterManifests defines manifests which are applied to the workload cluster.
+// +kubebuilder:validation:MinProperties=1
+type AddonClassWorkloadClusterManifests struct {
+	// configMapName is the name of a ConfigMap in the namespace of the ClusterClass.
+	// Every entry in the ConfigMap data must contain one or more YAML manifests, which are
+	// applied to the workload cluster using server side apply once the control plane is initialized.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// IsDefined returns true if the AddonClassWorkloadClusterManifests is set.
+func (m *AddonClassWorkloadClusterManifests) IsDefined() bool {
+	return m != nil && m.ConfigMapName != ""
+}
+
 // MachineDeploymentClass serves as a template to define a set of worker nodes of the cluster
 // provisioned using the `ClusterClass`.
 type MachineDeploymentClass struct {
@@ -420,6 +556,16 @@ type MachineDeploymentClass struct {
 	// +optional
 	HealthCheck MachineDeploymentClassHealthCheck `json:"healthCheck,omitempty,omitzero"`
 
+	// machineDrainRules defines MachineDrainRules for the Machines of this MachineDeploymentClass.
+	// The topology controller creates one MachineDrainRule per entry for every MachineDeployment
+	// using this class; the MachineDrainRules only select the Machines of the corresponding MachineDeployment.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	MachineDrainRules []MachineDrainRuleClass `json:"machineDrainRules,omitempty"`
+
 	// failureDomain is the failure domain the machines will be created in.
 	// Must match the name of a FailureDomain from the Cluster status.
 	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachineDeploymentClass.
@@ -721,6 +867,20 @@ type MachinePoolClass struct {
 	// +required
 	Infrastructure MachinePoolClassInfrastructureTemplate `json:"infrastructure,omitempty,omitzero"`
 
+	// healthCheck defines a MachineHealthCheck for this MachinePoolClass.
+	// +optional
+	HealthCheck MachinePoolClassHealthCheck `json:"healthCheck,omitempty,omitzero"`
+
+	// machineDrainRules defines MachineDrainRules for the Machines of this MachinePoolClass.
+	// The topology controller creates one MachineDrainRule per entry for every MachinePool
+	// using this class; the MachineDrainRules only select the Machines of the corresponding MachinePool.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	MachineDrainRules []MachineDrainRuleClass `json:"machineDrainRules,omitempty"`
+
 	// failureDomains is the list of failure domains the MachinePool should be attached to.
 	// Must match a key in the FailureDomains map stored on the cluster object.
 	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachinePoolClass.
@@ -749,6 +909,144 @@ type MachinePoolClass struct {
 	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
 }
 
+// MachinePoolClassHealthCheck defines a MachineHealthCheck for MachinePool machines.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolClassHealthCheck struct {
+	// checks are the checks that are used to evaluate if a Machine is healthy.
+	//
+	// Independent of this configuration the MachineHealthCheck controller will always
+	// flag Machines with `cluster.x-k8s.io/remediate-machine` annotation and
+	// Machines with deleted Nodes as unhealthy.
+	//
+	// Furthermore, if checks.nodeStartupTimeoutSeconds is not set it
+	// is defaulted to 10 minutes and evaluated accordingly.
+	//
+	// +optional
+	Checks MachinePoolClassHealthCheckChecks `json:"checks,omitempty,omitzero"`
+
+	// remediation configures if and how remediations are triggered if a Machine is unhealthy.
+	//
+	// If remediation or remediation.triggerIf is not set,
+	// remediation will always be triggered for unhealthy Machines.
+	//
+	// If remediation or remediation.templateRef is not set,
+	// the OwnerRemediated condition will be set on unhealthy Machines to trigger remediation via
+	// the owner of the Machines, i.e. the MachinePool; note that remediation of MachinePool Machines
+	// must be implemented by the infrastructure provider of the MachinePool.
+	//
+	// +optional
+	Remediation MachinePoolClassHealthCheckRemediation `json:"remediation,omitempty,omitzero"`
+}
+
+// IsDefined returns true if one of checks and remediation are not zero.
+func (m *MachinePoolClassHealthCheck) IsDefined() bool {
+	return !reflect.ValueOf(m.Checks).IsZero() || !reflect.ValueOf(m.Remediation).IsZero()
+}
+
+// MachinePoolClassHealthCheckChecks are the checks that are used to evaluate if a MachinePool Machine is healthy.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolClassHealthCheckChecks struct {
+	// nodeStartupTimeoutSeconds allows to set the maximum time for MachineHealthCheck
+	// to consider a Machine unhealthy if a corresponding Node isn't associated
+	// through a `Spec.ProviderID` field.
+	//
+	// The duration set in this field is compared to the greatest of:
+	// - Cluster's infrastructure ready condition timestamp (if and when available)
+	// - Control Plane's initialized condition timestamp (if and when available)
+	// - Machine's infrastructure ready condition timestamp (if and when available)
+	// - Machine's metadata creation timestamp
+	//
+	// Defaults to 10 minutes.
+	// If you wish to disable this feature, set the value explicitly to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	NodeStartupTimeoutSeconds *int32 `json:"nodeStartupTimeoutSeconds,omitempty"`
+
+	// unhealthyNodeConditions contains a list of conditions that determine
+	// whether a node is considered unhealthy. The conditions are combined in a
+	// logical OR, i.e. if any of the conditions is met, the node is unhealthy.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	UnhealthyNodeConditions []UnhealthyNodeCondition `json:"unhealthyNodeConditions,omitempty"`
+}
+
+// MachinePoolClassHealthCheckRemediation configures if and how remediations are triggered if a MachinePool Machine is unhealthy.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolClassHealthCheckRemediation struct {
+	// triggerIf configures if remediations are triggered.
+	// If this field is not set, remediations are always triggered.
+	// +optional
+	TriggerIf MachinePoolClassHealthCheckRemediationTriggerIf `json:"triggerIf,omitempty,omitzero"`
+
+	// templateRef is a reference to a remediation template
+	// provided by an infrastructure provider.
+	//
+	// This field is completely optional, when filled, the MachineHealthCheck controller
+	// creates a new object from the template referenced and hands off remediation of the machine to
+	// a controller that lives outside of Cluster API.
+	// +optional
+	TemplateRef MachineHealthCheckRemediationTemplateReference `json:"templateRef,omitempty,omitzero"`
+}
+
+// MachinePoolClassHealthCheckRemediationTriggerIf configures if remediations are triggered.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolClassHealthCheckRemediationTriggerIf struct {
+	// unhealthyLessThanOrEqualTo specifies that remediations are only triggered if the number of
+	// unhealthy Machines is less than or equal to the configured value.
+	// unhealthyInRange takes precedence if set.
+	//
+	// +optional
+	UnhealthyLessThanOrEqualTo *intstr.IntOrString `json:"unhealthyLessThanOrEqualTo,omitempty"`
+
+	// unhealthyInRange specifies that remediations are only triggered if the number of
+	// unhealthy Machines is in the configured range.
+	// Takes precedence over unhealthyLessThanOrEqualTo.
+	// Eg. "[3-5]" - This means that remediation will be allowed only when:
+	// (a) there are at least 3 unhealthy Machines (and)
+	// (b) there are at most 5 unhealthy Machines
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=^\[[0-9]+-[0-9]+\]$
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	UnhealthyInRange string `json:"unhealthyInRange,omitempty"`
+}
+
+// MachineDrainRuleClass defines a MachineDrainRule for the Machines of a MachineDeployment or a MachinePool.
+type MachineDrainRuleClass struct {
+	// name of the MachineDrainRule.
+	// The name MUST be unique within the MachineDeploymentClass or MachinePoolClass.
+	// The name of the MachineDrainRule object is generated by appending this name to the name
+	// of the MachineDeployment or MachinePool.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// drain configures if and how Pods are drained.
+	// +required
+	Drain MachineDrainRuleDrainConfig `json:"drain,omitempty,omitzero"`
+
+	// pods defines to which Pods this MachineDrainRule should be applied.
+	//
+	// If pods is not set, the MachineDrainRule applies to all Pods in all Namespaces.
+	// If pods contains multiple selectors, the results are ORed.
+	// Within a single Pod selector the results of selector and namespaceSelector are ANDed.
+	// Pods will be selected from all Namespaces unless otherwise
+	// restricted with the namespaceSelector.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, x == y))",message="entries in pods must be unique"
+	Pods []MachineDrainRulePodSelector `json:"pods,omitempty"`
+}
+
 // MachinePoolClassMachineDeletionSpec contains configuration options for Machine deletion.
 // +kubebuilder:validation:MinProperties=1
 type MachinePoolClassMachineDeletionSpec struct {
@@ -1221,11 +1519,22 @@ type ClusterClassPatch struct {
 	// The patch will be enabled if the template evaluates to `true`, otherwise it will
 	// be disabled.
 	// If EnabledIf is not set, the patch will be enabled per default.
+	// Note: Only one of EnabledIf and EnabledIfExpression is allowed to be set at the same time.
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=256
 	EnabledIf string `json:"enabledIf,omitempty"`
 
+	// enabledIfExpression is a CEL expression to be used to calculate if a patch should be enabled.
+	// It can reference variables defined in .spec.variables via `variables`, builtin variables
+	// via `builtin`, and it must evaluate to a bool.
+	// The patch will be enabled if the expression evaluates to true, otherwise it will be disabled.
+	// Note: Only one of EnabledIf and EnabledIfExpression is allowed to be set at the same time.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	EnabledIfExpression string `json:"enabledIfExpression,omitempty"`
+
 	// definitions define inline patches.
 	// Note: Patches will be applied in the order of the array.
 	// Note: Exactly one of Definitions or External must be set.
@@ -1307,6 +1616,11 @@ type PatchSelectorMatch struct {
 	// .spec.workers.machinePools.
 	// +optional
 	MachinePoolClass *PatchSelectorMatchMachinePoolClass `json:"machinePoolClass,omitempty"`
+
+	// addonClass selects templates referenced in specific AddonClasses in
+	// .spec.addons.
+	// +optional
+	AddonClass *PatchSelectorMatchAddonClass `json:"addonClass,omitempty"`
 }
 
 // PatchSelectorMatchMachineDeploymentClass selects templates referenced
@@ -1333,6 +1647,18 @@ type PatchSelectorMatchMachinePoolClass struct {
 	Names []string `json:"names,omitempty"`
 }
 
+// PatchSelectorMatchAddonClass selects templates referenced
+// in specific AddonClasses in .spec.addons.
+type PatchSelectorMatchAddonClass struct {
+	// names selects templates by add-on names.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	Names []string `json:"names,omitempty"`
+}
+
 // JSONPatch defines a JSON patch.
 type JSONPatch struct {
 	// op defines the operation of the patch.
@@ -1385,6 +1711,15 @@ type JSONPatchValue struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=10240
 	Template string `json:"template,omitempty"`
+
+	// expression is the CEL expression to be used to calculate the value.
+	// An expression can reference variables defined in .spec.variables via `variables`,
+	// builtin variables via `builtin` and the template the patch is applied to via `template`.
+	// The expression is type checked when the ClusterClass is created or updated.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Expression string `json:"expression,omitempty"`
 }
 
 // ExternalPatchDefinition defines an external patch.
@@ -1408,6 +1743,13 @@ type ExternalPatchDefinition struct {
 	// +kubebuilder:validation:MaxLength=512
 	DiscoverVariablesExtension string `json:"discoverVariablesExtension,omitempty"`
 
+	// defaultClusterVariablesExtension references an extension which is called to compute default values
+	// for the variables of Clusters using the ClusterClass.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	DefaultClusterVariablesExtension string `json:"defaultClusterVariablesExtension,omitempty"`
+
 	// settings defines key value pairs to be passed to the extensions.
 	// Values defined here take precedence over the values defined in the
 	// corresponding ExtensionConfig.
@@ -1507,7 +1849,7 @@ func (r *ClusterClassTemplateReference) GroupVersionKind() schema.GroupVersionKi
 // +kubebuilder:validation:MinProperties=1
 type ClusterClassStatus struct {
 	// conditions represents the observations of a ClusterClass's current state.
-	// Known condition types are VariablesReady, RefVersionsUpToDate, Paused.
+	// Known condition types are VariablesReady, RefVersionsUpToDate, RollingOut, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -1520,6 +1862,18 @@ type ClusterClassStatus struct {
 	// +kubebuilder:validation:MaxItems=1000
 	Variables []ClusterClassStatusVariable `json:"variables,omitempty"`
 
+	// rollout reports the progress of the rollout of the current generation of the ClusterClass, if spec.rollout is set.
+	// +optional
+	Rollout *ClusterClassRolloutStatus `json:"rollout,omitempty"`
+
+	// revision is the revision of the current spec of the ClusterClass; a ClusterClassRevision with the same
+	// revision captures the ClusterClass at this point in time, so Clusters can pin it via spec.topology.classRef.revision.
+	// Note: This field is set only if the ClusterClassRevisions or the ClusterClassRollout feature flag is enabled.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Revision string `json:"revision,omitempty"`
+
 	// observedGeneration is the latest generation observed by the controller.
 	// +optional
 	// +kubebuilder:validation:Minimum=1
@@ -1530,6 +1884,67 @@ type ClusterClassStatus struct {
 	Deprecated *ClusterClassDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// ClusterClassRolloutStatus reports the progress of the rollout of a generation of a ClusterClass.
+type ClusterClassRolloutStatus struct {
+	// generation is the generation of the ClusterClass which is rolled out.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Generation int64 `json:"generation,omitempty"`
+
+	// revision is the revision of the ClusterClass which is rolled out.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Revision string `json:"revision,omitempty"`
+
+	// previousRevision is the revision of the ClusterClass before the generation which is rolled out.
+	// If the rollout of the generation is aborted, Clusters are reverted to this revision.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// releasedWaves is the number of waves the generation has been released to.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ReleasedWaves *int32 `json:"releasedWaves,omitempty"`
+
+	// clusters is the number of Clusters using the ClusterClass.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Clusters *int32 `json:"clusters,omitempty"`
+
+	// upToDateClusters is the number of Clusters which adopted the generation.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	UpToDateClusters *int32 `json:"upToDateClusters,omitempty"`
+
+	// waves are the Clusters assigned to the waves defined in spec.rollout when the rollout of the generation started.
+	// Clusters not assigned to any of these waves are part of the implicit last wave.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	Waves []ClusterClassRolloutWaveStatus `json:"waves,omitempty"`
+}
+
+// ClusterClassRolloutWaveStatus reports the Clusters assigned to a wave of the rollout of a generation of a ClusterClass.
+type ClusterClassRolloutWaveStatus struct {
+	// name of the wave.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name,omitempty"`
+
+	// clusters are the names of the Clusters assigned to the wave.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=10000
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=63
+	Clusters []string `json:"clusters,omitempty"`
+}
+
 // ClusterClassDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type ClusterClassDeprecatedStatus struct {
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterclassrevisions,shortName=ccr,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="ClusterClass",type="string",JSONPath=".spec.clusterClassName",description="Name of the ClusterClass"
+// +kubebuilder:printcolumn:name="Revision",type="string",JSONPath=".spec.revision",description="Revision of the ClusterClass"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of the ClusterClassRevision"
+
+// ClusterClassRevision is an immutable snapshot of a ClusterClass.
+// ClusterClassRevisions are created by the ClusterClass controller and Clusters can pin a revision
+// via spec.topology.classRef.revision.
+type ClusterClassRevision struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +required
+	metav1.ObjectMeta `json:"metadata"`
+
+	// spec is the snapshot of the ClusterClass.
+	// spec is immutable.
+	// +required
+	Spec ClusterClassRevisionSpec `json:"spec,omitempty,omitzero"`
+}
+
+// ClusterClassRevisionSpec is the snapshot of a ClusterClass.
+type ClusterClassRevisionSpec struct {
+	// clusterClassName is the name of the ClusterClass the revision has been created for.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ClusterClassName string `json:"clusterClassName,omitempty"`
+
+	// revision is the content hash of the ClusterClass spec and variables captured in this revision.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Revision string `json:"revision,omitempty"`
+
+	// clusterClassSpec is the spec of the ClusterClass at the time the revision has been created.
+	// Note: Templates are captured by reference; in-place changes to the templates referenced by the
+	// ClusterClass are not captured by revisions.
+	// +required
+	ClusterClassSpec ClusterClassSpec `json:"clusterClassSpec,omitempty,omitzero"`
+
+	// variables are the variables of the ClusterClass at the time the revision has been created,
+	// including variables discovered from external patches.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=1000
+	Variables []ClusterClassStatusVariable `json:"variables,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterClassRevisionList contains a list of ClusterClassRevisions.
+type ClusterClassRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard list's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#lists-and-simple-kinds
+	// +required
+	metav1.ListMeta `json:"metadata"`
+
+	// items contains the items of the ClusterClassRevisionList.
+	Items []ClusterClassRevision `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &ClusterClassRevision{}, &ClusterClassRevisionList{})
+}
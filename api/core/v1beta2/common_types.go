@@ -64,6 +64,10 @@ const (
 	// to track the name of the MachinePool topology it represents.
 	ClusterTopologyMachinePoolNameLabel = "topology.cluster.x-k8s.io/pool-name"
 
+	// ClusterTopologyAddonNameLabel is the label set on the generated add-on objects
+	// to track the name of the add-on in the ClusterClass it represents.
+	ClusterTopologyAddonNameLabel = "topology.cluster.x-k8s.io/addon-name"
+
 	// ClusterTopologyUnsafeUpdateClassNameAnnotation can be used to disable the webhook check on
 	// update that disallows a pre-existing Cluster to be populated with Topology information and Class.
 	ClusterTopologyUnsafeUpdateClassNameAnnotation = "unsafe.topology.cluster.x-k8s.io/disable-update-class-name-check"
@@ -116,6 +120,13 @@ const (
 	// with reconciliation of the object only if this label and a configured value is present.
 	WatchLabel = "cluster.x-k8s.io/watch-filter"
 
+	// ShardLabel is a label that can be applied to Cluster objects to pin a Cluster to a specific shard
+	// when controllers are sharded across replicas. The value must be a shard number in [0, shard count);
+	// if the label is not set or invalid, the shard is determined by hashing the namespace and the name of the Cluster.
+	//
+	// Note: This label is considered only if the ControllerSharding feature flag is enabled.
+	ShardLabel = "cluster.x-k8s.io/shard"
+
 	// DeleteMachineAnnotation marks control plane and worker nodes that will be given priority for deletion
 	// when KCP or a machineset scales down. This annotation is given top priority on all delete policies.
 	DeleteMachineAnnotation = "cluster.x-k8s.io/delete-machine"
@@ -162,6 +173,13 @@ const (
 	// External infrastructure providers should ensure that the annotation, once set, cannot be removed.
 	ManagedByAnnotation = "cluster.x-k8s.io/managed-by"
 
+	// ReverseTunnelAnnotation is an annotation that can be applied to Cluster objects to signify that the
+	// API server of the workload cluster must be accessed through a reverse tunnel opened by an agent running
+	// in the workload cluster, e.g. because the workload cluster is behind NAT.
+	//
+	// Note: This annotation is considered only if the ClusterReverseTunnel feature flag is enabled.
+	ReverseTunnelAnnotation = "cluster.x-k8s.io/reverse-tunnel"
+
 	// TopologyDryRunAnnotation is an annotation that gets set on objects by the topology controller
 	// only during a server side dry run apply operation. It is used for validating
 	// update webhooks for objects which get updated by template rotation (e.g. InfrastructureMachineTemplate).
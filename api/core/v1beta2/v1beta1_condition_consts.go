@@ -334,6 +334,14 @@ const (
 	// with the ClusterClass surfaced in the ClusterClass status or controller logs.
 	TopologyReconciledClusterClassNotReconciledV1Beta1Reason = "ClusterClassNotReconciled"
 
+	// TopologyReconciledClusterClassRolloutPendingV1Beta1Reason (Severity=Info) documents reconciliation of a Cluster topology
+	// not yet completed because the current generation of the ClusterClass has not been released to the Cluster yet.
+	TopologyReconciledClusterClassRolloutPendingV1Beta1Reason = "ClusterClassRolloutPending"
+
+	// TopologyReconciledClusterClassRolloutAbortedV1Beta1Reason (Severity=Info) documents reconciliation of a Cluster topology
+	// with the revision of the ClusterClass before the current generation, because the rollout of the current generation has been aborted.
+	TopologyReconciledClusterClassRolloutAbortedV1Beta1Reason = "ClusterClassRolloutAborted"
+
 	// TopologyReconciledPausedV1Beta1Reason (Severity=Info) surfaces when the Cluster is paused.
 	TopologyReconciledPausedV1Beta1Reason = "Paused"
 )
@@ -44,6 +44,38 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonClass) DeepCopyInto(out *AddonClass) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	out.WorkloadClusterManifests = in.WorkloadClusterManifests
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonClass.
+func (in *AddonClass) DeepCopy() *AddonClass {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonClassWorkloadClusterManifests) DeepCopyInto(out *AddonClassWorkloadClusterManifests) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonClassWorkloadClusterManifests.
+func (in *AddonClassWorkloadClusterManifests) DeepCopy() *AddonClassWorkloadClusterManifests {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonClassWorkloadClusterManifests)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootstrap) DeepCopyInto(out *Bootstrap) {
 	*out = *in
@@ -228,6 +260,191 @@ func (in *ClusterClassRef) DeepCopy() *ClusterClassRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRevision) DeepCopyInto(out *ClusterClassRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRevision.
+func (in *ClusterClassRevision) DeepCopy() *ClusterClassRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClassRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRevisionList) DeepCopyInto(out *ClusterClassRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterClassRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRevisionList.
+func (in *ClusterClassRevisionList) DeepCopy() *ClusterClassRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClassRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRevisionSpec) DeepCopyInto(out *ClusterClassRevisionSpec) {
+	*out = *in
+	in.ClusterClassSpec.DeepCopyInto(&out.ClusterClassSpec)
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]ClusterClassStatusVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRevisionSpec.
+func (in *ClusterClassRevisionSpec) DeepCopy() *ClusterClassRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRollout) DeepCopyInto(out *ClusterClassRollout) {
+	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]ClusterClassRolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRollout.
+func (in *ClusterClassRollout) DeepCopy() *ClusterClassRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRolloutStatus) DeepCopyInto(out *ClusterClassRolloutStatus) {
+	*out = *in
+	if in.ReleasedWaves != nil {
+		in, out := &in.ReleasedWaves, &out.ReleasedWaves
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpToDateClusters != nil {
+		in, out := &in.UpToDateClusters, &out.UpToDateClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]ClusterClassRolloutWaveStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRolloutStatus.
+func (in *ClusterClassRolloutStatus) DeepCopy() *ClusterClassRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRolloutWave) DeepCopyInto(out *ClusterClassRolloutWave) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRolloutWave.
+func (in *ClusterClassRolloutWave) DeepCopy() *ClusterClassRolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassRolloutWaveStatus) DeepCopyInto(out *ClusterClassRolloutWaveStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassRolloutWaveStatus.
+func (in *ClusterClassRolloutWaveStatus) DeepCopy() *ClusterClassRolloutWaveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassRolloutWaveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClassSpec) DeepCopyInto(out *ClusterClassSpec) {
 	*out = *in
@@ -239,6 +456,11 @@ func (in *ClusterClassSpec) DeepCopyInto(out *ClusterClassSpec) {
 	out.Infrastructure = in.Infrastructure
 	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
 	in.Workers.DeepCopyInto(&out.Workers)
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]AddonClass, len(*in))
+		copy(*out, *in)
+	}
 	if in.Variables != nil {
 		in, out := &in.Variables, &out.Variables
 		*out = make([]ClusterClassVariable, len(*in))
@@ -253,6 +475,7 @@ func (in *ClusterClassSpec) DeepCopyInto(out *ClusterClassSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassSpec.
@@ -282,6 +505,11 @@ func (in *ClusterClassStatus) DeepCopyInto(out *ClusterClassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ClusterClassRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(ClusterClassDeprecatedStatus)
@@ -628,6 +856,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(ClusterTopologyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(ClusterDeprecatedStatus)
@@ -645,6 +878,68 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopologyAddonObjectReference) DeepCopyInto(out *ClusterTopologyAddonObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyAddonObjectReference.
+func (in *ClusterTopologyAddonObjectReference) DeepCopy() *ClusterTopologyAddonObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopologyAddonObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopologyAddonStatus) DeepCopyInto(out *ClusterTopologyAddonStatus) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ClusterTopologyAddonObjectReference)
+		**out = **in
+	}
+	if in.WorkloadClusterObjects != nil {
+		in, out := &in.WorkloadClusterObjects, &out.WorkloadClusterObjects
+		*out = make([]ClusterTopologyAddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyAddonStatus.
+func (in *ClusterTopologyAddonStatus) DeepCopy() *ClusterTopologyAddonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopologyAddonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopologyStatus) DeepCopyInto(out *ClusterTopologyStatus) {
+	*out = *in
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]ClusterTopologyAddonStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyStatus.
+func (in *ClusterTopologyStatus) DeepCopy() *ClusterTopologyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopologyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterV1Beta1DeprecatedStatus) DeepCopyInto(out *ClusterV1Beta1DeprecatedStatus) {
 	*out = *in
@@ -1494,6 +1789,13 @@ func (in *MachineDeploymentClass) DeepCopyInto(out *MachineDeploymentClass) {
 	out.Bootstrap = in.Bootstrap
 	out.Infrastructure = in.Infrastructure
 	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+	if in.MachineDrainRules != nil {
+		in, out := &in.MachineDrainRules, &out.MachineDrainRules
+		*out = make([]MachineDrainRuleClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Naming = in.Naming
 	in.Deletion.DeepCopyInto(&out.Deletion)
 	if in.MinReadySeconds != nil {
@@ -2265,6 +2567,29 @@ func (in *MachineDrainRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDrainRuleClass) DeepCopyInto(out *MachineDrainRuleClass) {
+	*out = *in
+	in.Drain.DeepCopyInto(&out.Drain)
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]MachineDrainRulePodSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleClass.
+func (in *MachineDrainRuleClass) DeepCopy() *MachineDrainRuleClass {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDrainRuleClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDrainRuleDrainConfig) DeepCopyInto(out *MachineDrainRuleDrainConfig) {
 	*out = *in
@@ -2762,6 +3087,14 @@ func (in *MachinePoolClass) DeepCopyInto(out *MachinePoolClass) {
 	in.Metadata.DeepCopyInto(&out.Metadata)
 	out.Bootstrap = in.Bootstrap
 	out.Infrastructure = in.Infrastructure
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+	if in.MachineDrainRules != nil {
+		in, out := &in.MachineDrainRules, &out.MachineDrainRules
+		*out = make([]MachineDrainRuleClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make([]string, len(*in))
@@ -2802,6 +3135,87 @@ func (in *MachinePoolClassBootstrapTemplate) DeepCopy() *MachinePoolClassBootstr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolClassHealthCheck) DeepCopyInto(out *MachinePoolClassHealthCheck) {
+	*out = *in
+	in.Checks.DeepCopyInto(&out.Checks)
+	in.Remediation.DeepCopyInto(&out.Remediation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClassHealthCheck.
+func (in *MachinePoolClassHealthCheck) DeepCopy() *MachinePoolClassHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolClassHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolClassHealthCheckChecks) DeepCopyInto(out *MachinePoolClassHealthCheckChecks) {
+	*out = *in
+	if in.NodeStartupTimeoutSeconds != nil {
+		in, out := &in.NodeStartupTimeoutSeconds, &out.NodeStartupTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnhealthyNodeConditions != nil {
+		in, out := &in.UnhealthyNodeConditions, &out.UnhealthyNodeConditions
+		*out = make([]UnhealthyNodeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClassHealthCheckChecks.
+func (in *MachinePoolClassHealthCheckChecks) DeepCopy() *MachinePoolClassHealthCheckChecks {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolClassHealthCheckChecks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolClassHealthCheckRemediation) DeepCopyInto(out *MachinePoolClassHealthCheckRemediation) {
+	*out = *in
+	in.TriggerIf.DeepCopyInto(&out.TriggerIf)
+	out.TemplateRef = in.TemplateRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClassHealthCheckRemediation.
+func (in *MachinePoolClassHealthCheckRemediation) DeepCopy() *MachinePoolClassHealthCheckRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolClassHealthCheckRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolClassHealthCheckRemediationTriggerIf) DeepCopyInto(out *MachinePoolClassHealthCheckRemediationTriggerIf) {
+	*out = *in
+	if in.UnhealthyLessThanOrEqualTo != nil {
+		in, out := &in.UnhealthyLessThanOrEqualTo, &out.UnhealthyLessThanOrEqualTo
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClassHealthCheckRemediationTriggerIf.
+func (in *MachinePoolClassHealthCheckRemediationTriggerIf) DeepCopy() *MachinePoolClassHealthCheckRemediationTriggerIf {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolClassHealthCheckRemediationTriggerIf)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolClassInfrastructureTemplate) DeepCopyInto(out *MachinePoolClassInfrastructureTemplate) {
 	*out = *in
@@ -3033,6 +3447,7 @@ func (in *MachinePoolTopology) DeepCopyInto(out *MachinePoolTopology) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
 	in.Deletion.DeepCopyInto(&out.Deletion)
 	if in.MinReadySeconds != nil {
 		in, out := &in.MinReadySeconds, &out.MinReadySeconds
@@ -3057,6 +3472,92 @@ func (in *MachinePoolTopology) DeepCopy() *MachinePoolTopology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolTopologyHealthCheck) DeepCopyInto(out *MachinePoolTopologyHealthCheck) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.Checks.DeepCopyInto(&out.Checks)
+	in.Remediation.DeepCopyInto(&out.Remediation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolTopologyHealthCheck.
+func (in *MachinePoolTopologyHealthCheck) DeepCopy() *MachinePoolTopologyHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolTopologyHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolTopologyHealthCheckChecks) DeepCopyInto(out *MachinePoolTopologyHealthCheckChecks) {
+	*out = *in
+	if in.NodeStartupTimeoutSeconds != nil {
+		in, out := &in.NodeStartupTimeoutSeconds, &out.NodeStartupTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnhealthyNodeConditions != nil {
+		in, out := &in.UnhealthyNodeConditions, &out.UnhealthyNodeConditions
+		*out = make([]UnhealthyNodeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolTopologyHealthCheckChecks.
+func (in *MachinePoolTopologyHealthCheckChecks) DeepCopy() *MachinePoolTopologyHealthCheckChecks {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolTopologyHealthCheckChecks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolTopologyHealthCheckRemediation) DeepCopyInto(out *MachinePoolTopologyHealthCheckRemediation) {
+	*out = *in
+	in.TriggerIf.DeepCopyInto(&out.TriggerIf)
+	out.TemplateRef = in.TemplateRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolTopologyHealthCheckRemediation.
+func (in *MachinePoolTopologyHealthCheckRemediation) DeepCopy() *MachinePoolTopologyHealthCheckRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolTopologyHealthCheckRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolTopologyHealthCheckRemediationTriggerIf) DeepCopyInto(out *MachinePoolTopologyHealthCheckRemediationTriggerIf) {
+	*out = *in
+	if in.UnhealthyLessThanOrEqualTo != nil {
+		in, out := &in.UnhealthyLessThanOrEqualTo, &out.UnhealthyLessThanOrEqualTo
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolTopologyHealthCheckRemediationTriggerIf.
+func (in *MachinePoolTopologyHealthCheckRemediationTriggerIf) DeepCopy() *MachinePoolTopologyHealthCheckRemediationTriggerIf {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolTopologyHealthCheckRemediationTriggerIf)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolTopologyMachineDeletionSpec) DeepCopyInto(out *MachinePoolTopologyMachineDeletionSpec) {
 	*out = *in
@@ -3587,6 +4088,11 @@ func (in *PatchSelectorMatch) DeepCopyInto(out *PatchSelectorMatch) {
 		*out = new(PatchSelectorMatchMachinePoolClass)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AddonClass != nil {
+		in, out := &in.AddonClass, &out.AddonClass
+		*out = new(PatchSelectorMatchAddonClass)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchSelectorMatch.
@@ -3599,6 +4105,26 @@ func (in *PatchSelectorMatch) DeepCopy() *PatchSelectorMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchSelectorMatchAddonClass) DeepCopyInto(out *PatchSelectorMatchAddonClass) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchSelectorMatchAddonClass.
+func (in *PatchSelectorMatchAddonClass) DeepCopy() *PatchSelectorMatchAddonClass {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchSelectorMatchAddonClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PatchSelectorMatchMachineDeploymentClass) DeepCopyInto(out *PatchSelectorMatchMachineDeploymentClass) {
 	*out = *in
@@ -206,6 +206,133 @@ type BeforeClusterDeleteResponse struct {
 // and before the cluster and its underlying objects are deleted.
 func BeforeClusterDelete(*BeforeClusterDeleteRequest, *BeforeClusterDeleteResponse) {}
 
+// BeforeMachineCreateRequest is the request of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1beta1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineCreateResponse{}
+
+// BeforeMachineCreateResponse is the response of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineCreate is the hook that will be called before the infrastructure and bootstrap config of a Machine
+// are provisioned.
+func BeforeMachineCreate(*BeforeMachineCreateRequest, *BeforeMachineCreateResponse) {}
+
+// AfterMachineReadyRequest is the request of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1beta1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ ResponseObject = &AfterMachineReadyResponse{}
+
+// AfterMachineReadyResponse is the response of the AfterMachineReady hook.
+// +kubebuilder:object:root=true
+type AfterMachineReadyResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterMachineReady is the hook that will be called after a Machine is ready for the first time.
+func AfterMachineReady(*AfterMachineReadyRequest, *AfterMachineReadyResponse) {}
+
+// BeforeMachineDeleteRequest is the request of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1beta1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeleteResponse{}
+
+// BeforeMachineDeleteResponse is the response of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDelete is the hook that is called after delete is issued on a Machine
+// and before the Node of the Machine is drained.
+func BeforeMachineDelete(*BeforeMachineDeleteRequest, *BeforeMachineDeleteResponse) {}
+
+// AfterMachineDrainRequest is the request of the AfterMachineDrain hook.
+// +kubebuilder:object:root=true
+type AfterMachineDrainRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1beta1.Cluster `json:"cluster"`
+
+	// machine is the machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1beta1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &AfterMachineDrainResponse{}
+
+// AfterMachineDrainResponse is the response of the AfterMachineDrain hook.
+// +kubebuilder:object:root=true
+type AfterMachineDrainResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// AfterMachineDrain is the hook that is called after the Node of a Machine is drained and its volumes are detached,
+// and before the infrastructure of the Machine is deleted.
+func AfterMachineDrain(*AfterMachineDrainRequest, *AfterMachineDrainResponse) {}
+
 func init() {
 	catalogBuilder.RegisterHook(BeforeClusterCreate, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
@@ -281,4 +408,54 @@ func init() {
 			"- This is a blocking hook; Runtime Extension implementers can use this hook  to execute " +
 			"tasks before objects of the Cluster are deleted",
 	})
+
+	catalogBuilder.RegisterHook(BeforeMachineCreate, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a Machine is provisioned",
+		Description: "Cluster API Runtime will call this hook after the Machine is created and immediately before " +
+			"the Machine's bootstrap config and infrastructure machine are going to be provisioned.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the Machine is provisioned",
+	})
+
+	catalogBuilder.RegisterHook(AfterMachineReady, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after a Machine is ready for the first time",
+		Description: "Cluster API Runtime will call this hook after the Machine's Ready condition is true for the first time.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines for which the BeforeMachineCreate hook has been called\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a non-blocking hook",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDelete, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a Machine is deleted",
+		Description: "Cluster API Runtime will call this hook after the Machine deletion has been triggered, " +
+			"and immediately before the Node of the Machine is going to be drained.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The hook is called before pre-drain lifecycle hook annotations are evaluated\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the Node of the Machine is drained",
+	})
+
+	catalogBuilder.RegisterHook(AfterMachineDrain, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after the Node of a Machine is drained",
+		Description: "Cluster API Runtime will call this hook after the Node of a Machine that is being deleted has been drained " +
+			"and its volumes have been detached, and immediately before the infrastructure of the Machine is going to be deleted.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The hook is called before pre-terminate lifecycle hook annotations are evaluated\n" +
+			"- The hook is called also if drain and wait for volume detach are skipped, e.g. because the Machine has no Node\n" +
+			"- The call's request contains the Cluster and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the infrastructure of the Machine is deleted",
+	})
 }
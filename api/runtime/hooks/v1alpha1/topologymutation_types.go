@@ -229,6 +229,47 @@ var _ ResponseObject = &DiscoverVariablesResponse{}
 // DiscoverVariables returns variable schemas defined by a Runtime Extension.
 func DiscoverVariables(*DiscoverVariablesRequest, *DiscoverVariablesResponse) {}
 
+// DefaultClusterVariablesRequest is the request of the DefaultClusterVariables hook.
+// +kubebuilder:object:root=true
+type DefaultClusterVariablesRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains Settings field common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the Cluster object the variables are defaulted for.
+	// +required
+	Cluster clusterv1beta1.Cluster `json:"cluster"`
+
+	// variables are the current values of the Cluster variables.
+	// +optional
+	Variables []Variable `json:"variables,omitempty"`
+
+	// dryRun is true if the Cluster is created or updated by a dry-run request.
+	// Runtime Extensions must not have side effects, e.g. allocating an IP address, for dry-run requests.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+var _ ResponseObject = &DefaultClusterVariablesResponse{}
+
+// DefaultClusterVariablesResponse is the response of the DefaultClusterVariables hook.
+// +kubebuilder:object:root=true
+type DefaultClusterVariablesResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+
+	// variables are the values of the Cluster variables to be set by the Runtime Extension.
+	// Values are set only for variables which are not set yet in the Cluster.
+	// +optional
+	Variables []Variable `json:"variables,omitempty"`
+}
+
+// DefaultClusterVariables computes default values for the variables of a Cluster.
+func DefaultClusterVariables(*DefaultClusterVariablesRequest, *DefaultClusterVariablesResponse) {}
+
 func init() {
 	catalogBuilder.RegisterHook(GeneratePatches, &runtimecatalog.HookMeta{
 		Tags:    []string{"Topology Mutation Hook"},
@@ -262,4 +303,18 @@ func init() {
 			"Notes:\n" +
 			"- The response must contain the schemas of all variables defined by the patch.",
 	})
+
+	catalogBuilder.RegisterHook(DefaultClusterVariables, &runtimecatalog.HookMeta{
+		Tags:    []string{"Topology Mutation Hook"},
+		Summary: "Cluster API Runtime will call this hook when a Cluster using a ClusterClass is created or has variables not set yet",
+		Description: "Cluster API Runtime will call this hook in the Cluster defaulting webhook when a Cluster " +
+			"using a ClusterClass is created, or when it is updated and some of the variables defined by the external patch are not set yet, " +
+			"before the variables are defaulted and validated using the schemas of the ClusterClass variables. " +
+			"The hook is not called for Clusters being deleted.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The call's request contains the Cluster and the current values of the Cluster variables\n" +
+			"- The response must contain the values of the variables to be set in the Cluster; the values are persisted in Cluster.spec.topology.variables only for variables not set yet\n" +
+			"- Values returned by the hook are validated using the schemas of the ClusterClass variables",
+	})
 }
@@ -41,6 +41,52 @@ type ExtensionConfigSpec struct {
 	// Note: Settings can be overridden on the ClusterClass.
 	// +optional
 	Settings map[string]string `json:"settings,omitempty"`
+
+	// maxConcurrentCalls is the maximum number of concurrent calls from a Cluster API controller to the Extension.
+	// Calls exceeding the limit wait until an ongoing call completes; if the timeout of the corresponding handler
+	// expires while waiting, the call fails and the failurePolicy of the handler is applied.
+	// Note: The limit applies to each controller manager calling the Extension individually.
+	// If not set, the number of concurrent calls is not limited.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentCalls int32 `json:"maxConcurrentCalls,omitempty"`
+
+	// circuitBreaker configures a circuit breaker for calls to the Extension.
+	// When the circuit is open, calls are not sent to the Extension but they fail immediately,
+	// and the failurePolicy of the corresponding handler is applied.
+	// If not set, the circuit breaker is disabled.
+	// +optional
+	CircuitBreaker CircuitBreaker `json:"circuitBreaker,omitempty,omitzero"`
+}
+
+// CircuitBreaker defines a circuit breaker for calls to an Extension.
+type CircuitBreaker struct {
+	// failureThreshold is the number of consecutive failed calls after which the circuit is opened.
+	// Calls are considered failed if the Extension could not be reached, did not answer before the timeout,
+	// or answered with an unexpected HTTP status code; responses with status Failure do not count as failed calls.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// openSeconds is the duration in seconds the circuit stays open before a single call is sent to the Extension
+	// to probe if it recovered. Every time the probe fails the duration is doubled, up to maxOpenSeconds; the duration
+	// is reset as soon as a call succeeds.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	OpenSeconds *int32 `json:"openSeconds,omitempty"`
+
+	// maxOpenSeconds is the maximum duration in seconds the circuit stays open before a call is sent to the Extension
+	// to probe if it recovered.
+	// Defaults to 300.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxOpenSeconds *int32 `json:"maxOpenSeconds,omitempty"`
+}
+
+// IsDefined returns true if the CircuitBreaker is set.
+func (c *CircuitBreaker) IsDefined() bool {
+	return !reflect.DeepEqual(c, &CircuitBreaker{})
 }
 
 // ClientConfig contains the information to make a client
@@ -81,6 +127,12 @@ type ClientConfig struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=51200
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// authentication defines the credentials used by Cluster API to authenticate calls to the Extension server.
+	// If not set, the client certificate configured for the controller via the --runtime-extension-client-cert-file
+	// and --runtime-extension-client-key-file flags is used, if any.
+	// +optional
+	Authentication ClientAuthentication `json:"authentication,omitempty,omitzero"`
 }
 
 // ServiceReference holds a reference to a Kubernetes Service of an Extension server.
@@ -116,11 +168,81 @@ func (r *ServiceReference) IsDefined() bool {
 	return !reflect.DeepEqual(r, &ServiceReference{})
 }
 
+// ClientAuthentication defines the credentials used by Cluster API to authenticate calls to an Extension server.
+// Note: Exactly one of `clientCertificate` or `serviceAccountToken` must be specified.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type ClientAuthentication struct {
+	// clientCertificate configures a client certificate which is presented to the Extension server
+	// instead of the client certificate configured for the controller.
+	// +optional
+	ClientCertificate ClientCertificateAuthentication `json:"clientCertificate,omitempty,omitzero"`
+
+	// serviceAccountToken configures a projected ServiceAccount token of the controller which is sent
+	// to the Extension server as bearer token.
+	// +optional
+	ServiceAccountToken ServiceAccountTokenAuthentication `json:"serviceAccountToken,omitempty,omitzero"`
+}
+
+// IsDefined returns true if the ClientAuthentication is set.
+func (a *ClientAuthentication) IsDefined() bool {
+	return !reflect.DeepEqual(a, &ClientAuthentication{})
+}
+
+// ClientCertificateAuthentication defines a client certificate used to authenticate calls to an Extension server.
+type ClientCertificateAuthentication struct {
+	// secretRef is a reference to a Secret containing the PEM encoded client certificate and key
+	// in the tls.crt and tls.key entries.
+	// The client certificate is reloaded when the Secret changes.
+	// +required
+	SecretRef SecretReference `json:"secretRef,omitempty,omitzero"`
+}
+
+// IsDefined returns true if the ClientCertificateAuthentication is set.
+func (a *ClientCertificateAuthentication) IsDefined() bool {
+	return !reflect.DeepEqual(a, &ClientCertificateAuthentication{})
+}
+
+// SecretReference is a reference to a Secret.
+type SecretReference struct {
+	// namespace is the namespace of the Secret.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the Secret.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// ServiceAccountTokenAuthentication defines a projected ServiceAccount token used to authenticate calls to an Extension server.
+type ServiceAccountTokenAuthentication struct {
+	// audience is the audience of the ServiceAccount token.
+	// The token is read from the file named after the audience in the directory configured for the controller
+	// via the --runtime-extension-token-dir flag; the file is expected to be populated by a projected
+	// serviceAccountToken volume with the same audience, and it is re-read on every call so token rotation
+	// done by the kubelet is picked up automatically.
+	// Extension servers should verify that the token is bound to this audience, which should be unique per Extension.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Audience string `json:"audience,omitempty"`
+}
+
+// IsDefined returns true if the ServiceAccountTokenAuthentication is set.
+func (a *ServiceAccountTokenAuthentication) IsDefined() bool {
+	return !reflect.DeepEqual(a, &ServiceAccountTokenAuthentication{})
+}
+
 // ExtensionConfigStatus defines the observed state of ExtensionConfig.
 // +kubebuilder:validation:MinProperties=1
 type ExtensionConfigStatus struct {
 	// conditions represents the observations of a ExtensionConfig's current state.
-	// Known condition types are Discovered, Paused.
+	// Known condition types are Discovered, CircuitBreakerOpen, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -299,6 +421,18 @@ const (
 	ExtensionConfigNotDiscoveredReason = "NotDiscovered"
 )
 
+// ExtensionConfig's CircuitBreakerOpen conditions and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// ExtensionConfigCircuitBreakerOpenCondition is true if the circuit breaker for calls to the runtime extension is open.
+	ExtensionConfigCircuitBreakerOpenCondition = "CircuitBreakerOpen"
+
+	// ExtensionConfigCircuitBreakerOpenReason surfaces that the circuit breaker for calls to the runtime extension is open.
+	ExtensionConfigCircuitBreakerOpenReason = "CircuitBreakerOpen"
+
+	// ExtensionConfigCircuitBreakerNotOpenReason surfaces that the circuit breaker for calls to the runtime extension is not open.
+	ExtensionConfigCircuitBreakerNotOpenReason = "CircuitBreakerNotOpen"
+)
+
 const (
 	// RuntimeExtensionDiscoveredV1Beta1Condition is a condition set on an ExtensionConfig object once it has been discovered by the Runtime SDK client.
 	RuntimeExtensionDiscoveredV1Beta1Condition clusterv1.ConditionType = "Discovered"
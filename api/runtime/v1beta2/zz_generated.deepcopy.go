@@ -26,6 +26,64 @@ import (
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+	*out = *in
+	if in.OpenSeconds != nil {
+		in, out := &in.OpenSeconds, &out.OpenSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxOpenSeconds != nil {
+		in, out := &in.MaxOpenSeconds, &out.MaxOpenSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
+func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientAuthentication) DeepCopyInto(out *ClientAuthentication) {
+	*out = *in
+	out.ClientCertificate = in.ClientCertificate
+	out.ServiceAccountToken = in.ServiceAccountToken
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientAuthentication.
+func (in *ClientAuthentication) DeepCopy() *ClientAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateAuthentication) DeepCopyInto(out *ClientCertificateAuthentication) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateAuthentication.
+func (in *ClientCertificateAuthentication) DeepCopy() *ClientCertificateAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
 	*out = *in
@@ -35,6 +93,7 @@ func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	out.Authentication = in.Authentication
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientConfig.
@@ -142,6 +201,7 @@ func (in *ExtensionConfigSpec) DeepCopyInto(out *ExtensionConfigSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.CircuitBreaker.DeepCopyInto(&out.CircuitBreaker)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionConfigSpec.
@@ -239,6 +299,36 @@ func (in *GroupVersionHook) DeepCopy() *GroupVersionHook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenAuthentication) DeepCopyInto(out *ServiceAccountTokenAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenAuthentication.
+func (in *ServiceAccountTokenAuthentication) DeepCopy() *ServiceAccountTokenAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
 	*out = *in
@@ -48,6 +48,7 @@ import (
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/locking"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/shellscript"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstream"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
@@ -537,11 +538,22 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(&scope.Config.Spec.InitConfiguration, parsedVersion)
+	// DeepCopy the InitConfiguration to prevent updating the actual KubeadmConfig when defaulting the patches directory.
+	initConfiguration := scope.Config.Spec.InitConfiguration.DeepCopy()
+	kubeletConfigurationFiles, kubeletConfigurationDocument, err := kubeletConfigurationBootstrapData(&scope.Config.Spec.KubeletConfiguration, &initConfiguration.Patches, parsedVersion)
+	if err != nil {
+		scope.Error(err, "Failed to generate kubelet configuration")
+		return ctrl.Result{}, err
+	}
+
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(initConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
 	}
+	if kubeletConfigurationDocument != "" {
+		initdata = fmt.Sprintf("%s---\n%s", initdata, kubeletConfigurationDocument)
+	}
 
 	certificates := secret.NewCertificatesForInitialControlPlane(&scope.Config.Spec.ClusterConfiguration)
 
@@ -608,6 +620,19 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
+	renderedData, err := r.renderBootstrapDataTemplates(ctx, scope, files)
+	if err != nil {
+		v1beta1conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableV1Beta1Condition, bootstrapv1.DataSecretGenerationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		conditions.Set(scope.Config, metav1.Condition{
+			Type:    bootstrapv1.KubeadmConfigDataSecretAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  bootstrapv1.KubeadmConfigDataSecretNotAvailableReason,
+			Message: "Failed to render templates for spec.files and commands",
+		})
+		return ctrl.Result{}, err
+	}
+	files = append(renderedData.Files, kubeletConfigurationFiles...)
+
 	controlPlaneInput := &cloudinit.ControlPlaneInput{
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles: files,
@@ -617,9 +642,9 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 				}
 				return nil
 			}(),
-			BootCommands:        scope.Config.Spec.BootCommands,
-			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
-			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
+			BootCommands:        renderedData.BootCommands,
+			PreKubeadmCommands:  renderedData.PreKubeadmCommands,
+			PostKubeadmCommands: renderedData.PostKubeadmCommands,
 			Users:               users,
 			Mounts:              scope.Config.Spec.Mounts,
 			DiskSetup: func() *bootstrapv1.DiskSetup {
@@ -643,6 +668,8 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 			ControlPlaneInput: controlPlaneInput,
 			Ignition:          &scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.ShellScript:
+		bootstrapInitData, err = shellscript.NewInitControlPlane(controlPlaneInput)
 	default:
 		bootstrapInitData, err = cloudinit.NewInitControlPlane(controlPlaneInput)
 	}
@@ -721,6 +748,14 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 
 	// NOTE: It is not required to provide in input ClusterConfiguration because only clusterConfiguration.APIServer.TimeoutForControlPlane
 	// has been migrated to JoinConfiguration in the kubeadm v1beta4 API version, and this field does not apply to workers.
+	// NOTE: For Kubernetes < v1.25 kubeletConfiguration can't be applied by kubeadm join, and the KubeadmConfig
+	// webhook rejects kubeletConfiguration for workers; thus the KubeletConfiguration document is ignored here.
+	kubeletConfigurationFiles, _, err := kubeletConfigurationBootstrapData(&scope.Config.Spec.KubeletConfiguration, &joinConfiguration.Patches, parsedVersion)
+	if err != nil {
+		scope.Error(err, "Failed to generate kubelet configuration")
+		return ctrl.Result{}, err
+	}
+
 	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(joinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
@@ -760,6 +795,19 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		return ctrl.Result{}, err
 	}
 
+	renderedData, err := r.renderBootstrapDataTemplates(ctx, scope, files)
+	if err != nil {
+		v1beta1conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableV1Beta1Condition, bootstrapv1.DataSecretGenerationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		conditions.Set(scope.Config, metav1.Condition{
+			Type:    bootstrapv1.KubeadmConfigDataSecretAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  bootstrapv1.KubeadmConfigDataSecretNotAvailableReason,
+			Message: "Failed to render templates for spec.files and commands",
+		})
+		return ctrl.Result{}, err
+	}
+	files = append(renderedData.Files, kubeletConfigurationFiles...)
+
 	if discoveryFile := scope.Config.Spec.JoinConfiguration.Discovery.File; discoveryFile.KubeConfig.IsDefined() {
 		kubeconfig, err := r.resolveDiscoveryKubeConfig(discoveryFile)
 		if err != nil {
@@ -784,9 +832,9 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 				}
 				return nil
 			}(),
-			BootCommands:        scope.Config.Spec.BootCommands,
-			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
-			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
+			BootCommands:        renderedData.BootCommands,
+			PreKubeadmCommands:  renderedData.PreKubeadmCommands,
+			PostKubeadmCommands: renderedData.PostKubeadmCommands,
 			Users:               users,
 			Mounts:              scope.Config.Spec.Mounts,
 			DiskSetup: func() *bootstrapv1.DiskSetup {
@@ -808,6 +856,8 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 			NodeInput: nodeInput,
 			Ignition:  &scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.ShellScript:
+		bootstrapJoinData, err = shellscript.NewNode(nodeInput)
 	default:
 		bootstrapJoinData, err = cloudinit.NewNode(nodeInput)
 	}
@@ -885,7 +935,17 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kubernetesVersion)
 	}
 
-	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(&scope.Config.Spec.JoinConfiguration, parsedVersion)
+	// DeepCopy the JoinConfiguration to prevent updating the actual KubeadmConfig when defaulting the patches directory.
+	// NOTE: For Kubernetes < v1.25 kubeletConfiguration is applied by kubeadm init only, and joining nodes
+	// get it from the kubelet-config ConfigMap; thus the KubeletConfiguration document is ignored here.
+	joinConfiguration := scope.Config.Spec.JoinConfiguration.DeepCopy()
+	kubeletConfigurationFiles, _, err := kubeletConfigurationBootstrapData(&scope.Config.Spec.KubeletConfiguration, &joinConfiguration.Patches, parsedVersion)
+	if err != nil {
+		scope.Error(err, "Failed to generate kubelet configuration")
+		return ctrl.Result{}, err
+	}
+
+	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(joinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
 		return ctrl.Result{}, err
@@ -920,6 +980,19 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, err
 	}
 
+	renderedData, err := r.renderBootstrapDataTemplates(ctx, scope, files)
+	if err != nil {
+		v1beta1conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableV1Beta1Condition, bootstrapv1.DataSecretGenerationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+		conditions.Set(scope.Config, metav1.Condition{
+			Type:    bootstrapv1.KubeadmConfigDataSecretAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  bootstrapv1.KubeadmConfigDataSecretNotAvailableReason,
+			Message: "Failed to render templates for spec.files and commands",
+		})
+		return ctrl.Result{}, err
+	}
+	files = append(renderedData.Files, kubeletConfigurationFiles...)
+
 	if discoveryFile := scope.Config.Spec.JoinConfiguration.Discovery.File; discoveryFile.KubeConfig.IsDefined() {
 		kubeconfig, err := r.resolveDiscoveryKubeConfig(discoveryFile)
 		if err != nil {
@@ -946,9 +1019,9 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 				}
 				return nil
 			}(),
-			BootCommands:        scope.Config.Spec.BootCommands,
-			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
-			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
+			BootCommands:        renderedData.BootCommands,
+			PreKubeadmCommands:  renderedData.PreKubeadmCommands,
+			PostKubeadmCommands: renderedData.PostKubeadmCommands,
 			Users:               users,
 			Mounts:              scope.Config.Spec.Mounts,
 			DiskSetup: func() *bootstrapv1.DiskSetup {
@@ -969,6 +1042,8 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 			ControlPlaneJoinInput: controlPlaneJoinInput,
 			Ignition:              &scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.ShellScript:
+		bootstrapJoinData, err = shellscript.NewJoinControlPlane(controlPlaneJoinInput)
 	default:
 		bootstrapJoinData, err = cloudinit.NewJoinControlPlane(controlPlaneJoinInput)
 	}
@@ -39,6 +39,7 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	bootstrapbuilder "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/builder"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/feature"
@@ -831,6 +832,21 @@ func TestBootstrapDataFormat(t *testing.T) {
 			format:             bootstrapv1.Ignition,
 			clusterInitialized: true,
 		},
+		{
+			name:   "shell-script init config",
+			format: bootstrapv1.ShellScript,
+		},
+		{
+			name:               "shell-script control plane join config",
+			format:             bootstrapv1.ShellScript,
+			clusterInitialized: true,
+		},
+		{
+			name:               "shell-script worker join config",
+			isWorker:           true,
+			format:             bootstrapv1.ShellScript,
+			clusterInitialized: true,
+		},
 		{
 			name:   "Empty format field",
 			format: bootstrapv1.CloudConfig,
@@ -923,11 +939,144 @@ func TestBootstrapDataFormat(t *testing.T) {
 				_, reports, err := ignition.Parse(data)
 				g.Expect(err).ToNot(HaveOccurred())
 				g.Expect(reports.IsFatal()).NotTo(BeTrue())
+			case bootstrapv1.ShellScript:
+				// Verify the bootstrap data is a shell script.
+				g.Expect(string(data)).To(HavePrefix("#!/bin/sh\n"))
 			}
 		})
 	}
 }
 
+func TestKubeadmConfigReconciler_Reconcile_BootstrapDataTemplating(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+	cluster.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{"192.168.0.0/16"}
+
+	machine := newWorkerMachineForCluster(cluster)
+	machine.Spec.FailureDomain = "fd1"
+	machine.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{
+		APIGroup: builder.InfrastructureGroupVersion.Group,
+		Kind:     builder.GenericInfrastructureMachineKind,
+		Name:     "inframachine",
+	}
+	config := newWorkerJoinKubeadmConfig(metav1.NamespaceDefault, "worker-join-cfg")
+	addKubeadmConfigToMachine(config, machine)
+	config.Spec.BootstrapDataTemplating = bootstrapv1.BootstrapDataTemplating{
+		Engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+	}
+	config.Spec.Files = []bootstrapv1.File{
+		{
+			Path:    "/etc/netplan/50-static.yaml",
+			Content: "addresses: [{{ (index .ipAddresses 0).address }}/{{ (index .ipAddresses 0).prefix }}]",
+		},
+	}
+	config.Spec.PreKubeadmCommands = []string{
+		"echo {{ .machine.name }} {{ .machine.failureDomain }} {{ index .cluster.network.pods 0 }}",
+		`echo {{ "{{ ds.meta_data.hostname }}" }}`,
+	}
+
+	// IPAddressClaim owned by the InfrastructureMachine of the Machine.
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "inframachine-0",
+			Namespace: metav1.NamespaceDefault,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: builder.InfrastructureGroupVersion.String(),
+					Kind:       builder.GenericInfrastructureMachineKind,
+					Name:       "inframachine",
+				},
+			},
+		},
+	}
+	// IPAddressClaim owned by another InfrastructureMachine.
+	otherClaim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-inframachine-0",
+			Namespace: metav1.NamespaceDefault,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: builder.InfrastructureGroupVersion.String(),
+					Kind:       builder.GenericInfrastructureMachineKind,
+					Name:       "other-inframachine",
+				},
+			},
+		},
+		Status: ipamv1.IPAddressClaimStatus{
+			AddressRef: ipamv1.IPAddressReference{Name: "other-address"},
+		},
+	}
+
+	objects := []client.Object{
+		cluster,
+		machine,
+		config,
+		claim,
+		otherClaim,
+	}
+	objects = append(objects, createSecrets(t, cluster, config)...)
+
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}, &ipamv1.IPAddressClaim{}).Build()
+
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "worker-join-cfg",
+		},
+	}
+
+	// Rendering fails while the IPAddressClaim is not bound.
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).To(HaveOccurred())
+	cfg, err := getKubeadmConfig(myclient, "worker-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Status.DataSecretName).To(BeEmpty())
+
+	// Bind the IPAddressClaim.
+	address := &ipamv1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "address",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: ipamv1.IPAddressClaimReference{Name: claim.Name},
+			Address:  "10.0.0.10",
+			Prefix:   ptr.To[int32](24),
+		},
+	}
+	g.Expect(myclient.Create(ctx, address)).To(Succeed())
+	claim.Status.AddressRef = ipamv1.IPAddressReference{Name: address.Name}
+	g.Expect(myclient.Status().Update(ctx, claim)).To(Succeed())
+
+	_, err = k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg, err = getKubeadmConfig(myclient, "worker-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Status.DataSecretName).NotTo(BeEmpty())
+
+	secret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: cfg.Status.DataSecretName}, secret)).To(Succeed())
+	data := string(secret.Data["value"])
+	g.Expect(data).To(ContainSubstring("addresses: [10.0.0.10/24]"))
+	g.Expect(data).To(ContainSubstring("echo worker-machine fd1 192.168.0.0/16"))
+	g.Expect(data).To(ContainSubstring("echo {{ ds.meta_data.hostname }}"))
+
+	// The KubeadmConfig is not modified.
+	g.Expect(cfg.Spec.Files[0].Content).To(Equal(config.Spec.Files[0].Content))
+	g.Expect(cfg.Spec.PreKubeadmCommands).To(Equal(config.Spec.PreKubeadmCommands))
+}
+
 // during kubeadmconfig reconcile it is possible that bootstrap secret gets created
 // but kubeadmconfig is not patched, do not error if secret already exists.
 // ignore the alreadyexists error and update the status to ready.
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/version"
+)
+
+// minKubeletConfigurationPatchVersion is the min Kubernetes version supporting the kubeletconfiguration patch target.
+var minKubeletConfigurationPatchVersion = semver.MustParse("1.25.0")
+
+// kubeletConfigurationData is the kubelet.config.k8s.io/v1beta1 KubeletConfiguration corresponding to bootstrapv1.KubeletConfiguration.
+type kubeletConfigurationData struct {
+	APIVersion                      string            `json:"apiVersion"`
+	Kind                            string            `json:"kind"`
+	CgroupDriver                    string            `json:"cgroupDriver,omitempty"`
+	MaxPods                         *int32            `json:"maxPods,omitempty"`
+	PodPidsLimit                    *int64            `json:"podPidsLimit,omitempty"`
+	KubeReserved                    map[string]string `json:"kubeReserved,omitempty"`
+	SystemReserved                  map[string]string `json:"systemReserved,omitempty"`
+	EvictionHard                    map[string]string `json:"evictionHard,omitempty"`
+	EvictionSoft                    map[string]string `json:"evictionSoft,omitempty"`
+	EvictionSoftGracePeriod         map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	ImageGCHighThresholdPercent     *int32            `json:"imageGCHighThresholdPercent,omitempty"`
+	ImageGCLowThresholdPercent      *int32            `json:"imageGCLowThresholdPercent,omitempty"`
+	SerializeImagePulls             *bool             `json:"serializeImagePulls,omitempty"`
+	MaxParallelImagePulls           *int32            `json:"maxParallelImagePulls,omitempty"`
+	ContainerLogMaxSize             string            `json:"containerLogMaxSize,omitempty"`
+	ContainerLogMaxFiles            *int32            `json:"containerLogMaxFiles,omitempty"`
+	ShutdownGracePeriod             *metav1.Duration  `json:"shutdownGracePeriod,omitempty"`
+	ShutdownGracePeriodCriticalPods *metav1.Duration  `json:"shutdownGracePeriodCriticalPods,omitempty"`
+	CPUManagerPolicy                string            `json:"cpuManagerPolicy,omitempty"`
+	TopologyManagerPolicy           string            `json:"topologyManagerPolicy,omitempty"`
+	ProtectKernelDefaults           *bool             `json:"protectKernelDefaults,omitempty"`
+}
+
+// kubeletConfigurationBootstrapData returns the bootstrap data for applying kubeletConfiguration:
+//   - for Kubernetes >= v1.25, a kubeletconfiguration patch file; in this case the patches directory is
+//     defaulted in the given patches, that must be a copy of the ones in the InitConfiguration or JoinConfiguration.
+//   - for older Kubernetes versions, a KubeletConfiguration YAML document, to be appended to the configuration
+//     for kubeadm init.
+func kubeletConfigurationBootstrapData(kubeletConfiguration *bootstrapv1.KubeletConfiguration, patches *bootstrapv1.Patches, kubernetesVersion semver.Version) ([]bootstrapv1.File, string, error) {
+	if !kubeletConfiguration.IsDefined() {
+		return nil, "", nil
+	}
+
+	data, err := json.Marshal(convertKubeletConfiguration(kubeletConfiguration))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal kubeletConfiguration")
+	}
+
+	if version.Compare(kubernetesVersion, minKubeletConfigurationPatchVersion, version.WithoutPreReleases()) < 0 {
+		document, err := yaml.JSONToYAML(data)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to marshal kubeletConfiguration")
+		}
+		return nil, string(document), nil
+	}
+
+	if patches.Directory == "" {
+		patches.Directory = bootstrapv1.DefaultPatchesDirectory
+	}
+	return []bootstrapv1.File{
+		{
+			Path:        path.Join(patches.Directory, bootstrapv1.KubeletConfigurationPatchFileName),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     string(data),
+		},
+	}, "", nil
+}
+
+func convertKubeletConfiguration(in *bootstrapv1.KubeletConfiguration) *kubeletConfigurationData {
+	out := &kubeletConfigurationData{
+		APIVersion:                  "kubelet.config.k8s.io/v1beta1",
+		Kind:                        "KubeletConfiguration",
+		CgroupDriver:                string(in.CgroupDriver),
+		MaxPods:                     in.MaxPods,
+		PodPidsLimit:                in.PodPidsLimit,
+		ImageGCHighThresholdPercent: in.ImageGCHighThresholdPercent,
+		ImageGCLowThresholdPercent:  in.ImageGCLowThresholdPercent,
+		SerializeImagePulls:         in.SerializeImagePulls,
+		MaxParallelImagePulls:       in.MaxParallelImagePulls,
+		ContainerLogMaxSize:         in.ContainerLogMaxSize,
+		ContainerLogMaxFiles:        in.ContainerLogMaxFiles,
+		CPUManagerPolicy:            string(in.CPUManagerPolicy),
+		TopologyManagerPolicy:       string(in.TopologyManagerPolicy),
+		ProtectKernelDefaults:       in.ProtectKernelDefaults,
+	}
+
+	if len(in.KubeReserved) > 0 {
+		out.KubeReserved = map[string]string{}
+		for _, r := range in.KubeReserved {
+			out.KubeReserved[r.Name] = r.Quantity
+		}
+	}
+	if len(in.SystemReserved) > 0 {
+		out.SystemReserved = map[string]string{}
+		for _, r := range in.SystemReserved {
+			out.SystemReserved[r.Name] = r.Quantity
+		}
+	}
+	if len(in.EvictionHard) > 0 {
+		out.EvictionHard = map[string]string{}
+		for _, t := range in.EvictionHard {
+			out.EvictionHard[t.Signal] = t.Value
+		}
+	}
+	if len(in.EvictionSoft) > 0 {
+		out.EvictionSoft = map[string]string{}
+		for _, t := range in.EvictionSoft {
+			out.EvictionSoft[t.Signal] = t.Value
+		}
+	}
+	if len(in.EvictionSoftGracePeriod) > 0 {
+		out.EvictionSoftGracePeriod = map[string]string{}
+		for _, p := range in.EvictionSoftGracePeriod {
+			if p.PeriodSeconds != nil {
+				out.EvictionSoftGracePeriod[p.Signal] = secondsToDuration(*p.PeriodSeconds).Duration.String()
+			}
+		}
+	}
+	if in.ShutdownGracePeriodSeconds != nil {
+		out.ShutdownGracePeriod = secondsToDuration(*in.ShutdownGracePeriodSeconds)
+	}
+	if in.ShutdownGracePeriodCriticalPodsSeconds != nil {
+		out.ShutdownGracePeriodCriticalPods = secondsToDuration(*in.ShutdownGracePeriodCriticalPodsSeconds)
+	}
+
+	return out
+}
+
+func secondsToDuration(seconds int32) *metav1.Duration {
+	return &metav1.Duration{Duration: time.Duration(seconds) * time.Second}
+}
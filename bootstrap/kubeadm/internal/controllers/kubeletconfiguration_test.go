@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+func TestKubeletConfigurationBootstrapData(t *testing.T) {
+	kubeletConfiguration := bootstrapv1.KubeletConfiguration{
+		CgroupDriver: bootstrapv1.SystemdCgroupDriver,
+		MaxPods:      ptr.To[int32](200),
+		KubeReserved: []bootstrapv1.KubeletResourceReservation{
+			{Name: "cpu", Quantity: "500m"},
+			{Name: "memory", Quantity: "1Gi"},
+		},
+		EvictionSoft: []bootstrapv1.KubeletEvictionThreshold{
+			{Signal: "memory.available", Value: "10%"},
+		},
+		EvictionSoftGracePeriod: []bootstrapv1.KubeletEvictionGracePeriod{
+			{Signal: "memory.available", PeriodSeconds: ptr.To[int32](90)},
+		},
+		ShutdownGracePeriodSeconds: ptr.To[int32](30),
+	}
+	expectedJSON := `{
+  "apiVersion": "kubelet.config.k8s.io/v1beta1",
+  "kind": "KubeletConfiguration",
+  "cgroupDriver": "systemd",
+  "maxPods": 200,
+  "kubeReserved": {"cpu": "500m", "memory": "1Gi"},
+  "evictionSoft": {"memory.available": "10%"},
+  "evictionSoftGracePeriod": {"memory.available": "1m30s"},
+  "shutdownGracePeriod": "30s"
+}`
+
+	tests := []struct {
+		name                 string
+		kubeletConfiguration bootstrapv1.KubeletConfiguration
+		patches              bootstrapv1.Patches
+		version              semver.Version
+		wantFilePath         string
+		wantPatchesDirectory string
+		wantDocument         bool
+	}{
+		{
+			name:                 "No bootstrap data if kubeletConfiguration is not set",
+			kubeletConfiguration: bootstrapv1.KubeletConfiguration{},
+			version:              semver.MustParse("1.33.0"),
+		},
+		{
+			name:                 "Patch file in the default patches directory",
+			kubeletConfiguration: kubeletConfiguration,
+			version:              semver.MustParse("1.33.0"),
+			wantFilePath:         "/etc/kubernetes/patches/kubeletconfiguration+strategic.json",
+			wantPatchesDirectory: "/etc/kubernetes/patches",
+		},
+		{
+			name:                 "Patch file in the patches directory set by the user",
+			kubeletConfiguration: kubeletConfiguration,
+			patches:              bootstrapv1.Patches{Directory: "/opt/patches"},
+			version:              semver.MustParse("1.25.0-rc.1"),
+			wantFilePath:         "/opt/patches/kubeletconfiguration+strategic.json",
+			wantPatchesDirectory: "/opt/patches",
+		},
+		{
+			name:                 "KubeletConfiguration document for Kubernetes < v1.25",
+			kubeletConfiguration: kubeletConfiguration,
+			version:              semver.MustParse("1.24.17"),
+			wantDocument:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			patches := tt.patches
+			files, document, err := kubeletConfigurationBootstrapData(&tt.kubeletConfiguration, &patches, tt.version)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(patches.Directory).To(Equal(tt.wantPatchesDirectory))
+
+			if tt.wantFilePath == "" {
+				g.Expect(files).To(BeEmpty())
+			} else {
+				g.Expect(files).To(HaveLen(1))
+				g.Expect(files[0].Path).To(Equal(tt.wantFilePath))
+				g.Expect(files[0].Content).To(MatchJSON(expectedJSON))
+			}
+
+			if tt.wantDocument {
+				g.Expect(document).To(MatchYAML(expectedJSON))
+			} else {
+				g.Expect(document).To(BeEmpty())
+			}
+		})
+	}
+}
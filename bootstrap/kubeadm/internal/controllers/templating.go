@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/templating"
+)
+
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims;ipaddresses,verbs=get;list;watch
+
+// renderBootstrapDataTemplates renders files and commands of the KubeadmConfig according to
+// .Spec.BootstrapDataTemplating. If templating is not configured, files and commands are returned as is.
+func (r *KubeadmConfigReconciler) renderBootstrapDataTemplates(ctx context.Context, scope *Scope, files []bootstrapv1.File) (*templating.Input, error) {
+	input := &templating.Input{
+		Files:               files,
+		BootCommands:        scope.Config.Spec.BootCommands,
+		PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
+		PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
+	}
+	if !scope.Config.Spec.BootstrapDataTemplating.IsDefined() {
+		return input, nil
+	}
+
+	data, err := r.computeBootstrapDataTemplateData(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return templating.Render(scope.Config.Spec.BootstrapDataTemplating.Engine, input, data)
+}
+
+// computeBootstrapDataTemplateData computes the data that can be used in bootstrap data templates.
+func (r *KubeadmConfigReconciler) computeBootstrapDataTemplateData(ctx context.Context, scope *Scope) (*templating.Data, error) {
+	data := &templating.Data{
+		Cluster: templating.Cluster{
+			Name:      scope.Cluster.Name,
+			Namespace: scope.Cluster.Namespace,
+			Labels:    labelsOrEmpty(scope.Cluster.Labels),
+			Network: templating.ClusterNetwork{
+				Pods:          scope.Cluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
+				Services:      scope.Cluster.Spec.ClusterNetwork.Services.CIDRBlocks,
+				ServiceDomain: scope.Cluster.Spec.ClusterNetwork.ServiceDomain,
+			},
+		},
+		IPAddresses: []templating.IPAddress{},
+	}
+
+	// Machine data and IPAddresses are only available for KubeadmConfigs owned by a Machine.
+	if scope.ConfigOwner.IsMachinePool() {
+		return data, nil
+	}
+
+	machine := &clusterv1.Machine{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(scope.ConfigOwner.Object, machine); err != nil {
+		return nil, errors.Wrapf(err, "cannot convert %s to Machine", scope.ConfigOwner.GetKind())
+	}
+	data.Machine = &templating.Machine{
+		Name:          machine.Name,
+		Namespace:     machine.Namespace,
+		Labels:        labelsOrEmpty(machine.Labels),
+		FailureDomain: machine.Spec.FailureDomain,
+	}
+
+	ipAddresses, err := r.getMachineIPAddresses(ctx, machine)
+	if err != nil {
+		return nil, err
+	}
+	data.IPAddresses = ipAddresses
+	return data, nil
+}
+
+// getMachineIPAddresses returns the IPAddresses bound to IPAddressClaims owned by the Machine or by its
+// InfrastructureMachine, sorted by claim name. Claims that are not bound yet are ignored.
+func (r *KubeadmConfigReconciler) getMachineIPAddresses(ctx context.Context, machine *clusterv1.Machine) ([]templating.IPAddress, error) {
+	claims := &ipamv1.IPAddressClaimList{}
+	if err := r.Client.List(ctx, claims, client.InNamespace(machine.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list IPAddressClaims")
+	}
+
+	sort.Slice(claims.Items, func(i, j int) bool {
+		return claims.Items[i].Name < claims.Items[j].Name
+	})
+
+	ipAddresses := []templating.IPAddress{}
+	for _, claim := range claims.Items {
+		if !isOwnedByMachine(claim.OwnerReferences, machine) || claim.Status.AddressRef.Name == "" {
+			continue
+		}
+
+		address := &ipamv1.IPAddress{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Status.AddressRef.Name}, address); err != nil {
+			return nil, errors.Wrapf(err, "failed to get IPAddress for IPAddressClaim %s", claim.Name)
+		}
+		ipAddresses = append(ipAddresses, templating.IPAddress{
+			Name:      address.Name,
+			ClaimName: claim.Name,
+			Address:   address.Spec.Address,
+			Prefix:    ptr.Deref(address.Spec.Prefix, 0),
+			Gateway:   address.Spec.Gateway,
+		})
+	}
+	return ipAddresses, nil
+}
+
+// isOwnedByMachine returns true if one of the ownerReferences is the Machine or its InfrastructureMachine.
+func isOwnedByMachine(ownerReferences []metav1.OwnerReference, machine *clusterv1.Machine) bool {
+	for _, ref := range ownerReferences {
+		if machine.UID != "" && ref.UID == machine.UID {
+			return true
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		if machine.Spec.InfrastructureRef.IsDefined() &&
+			gv.Group == machine.Spec.InfrastructureRef.APIGroup &&
+			ref.Kind == machine.Spec.InfrastructureRef.Kind &&
+			ref.Name == machine.Spec.InfrastructureRef.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func labelsOrEmpty(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
@@ -0,0 +1,568 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package butane generates bootstrap data in Ignition v3 format for the Butane variants supported by
+// the bootstrap provider, i.e. Fedora CoreOS and Flatcar Container Linux.
+//
+// The generated Ignition config is equivalent to the one Butane would generate for the given variant,
+// and it follows the same approach of the 'clc' package: kubeadm is run by the kubeadm.service systemd
+// unit executing the /etc/kubeadm.sh script, only if /etc/kubeadm.yml exists; at the end of the script
+// /etc/kubeadm.yml is moved to /tmp, so the script runs only once.
+//
+// Systemd units, links, directories and kernel arguments from the IgnitionSpec are added to the generated
+// config. Units with the same name of a generated unit, e.g. kubeadm.service, are merged into it.
+package butane
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+const (
+	// ignitionVersion is the Ignition spec version generated by Butane for the fcos v1.5.0 and flatcar v1.1.0 variants.
+	ignitionVersion = "3.4.0"
+
+	kubeadmUnit = `[Unit]
+Description=kubeadm
+# Run only once. After successful run, this file is moved to /tmp/.
+ConditionPathExists=/etc/kubeadm.yml
+After=network.target
+[Service]
+# To not restart the unit when it exits, as it is expected.
+Type=oneshot
+ExecStart=/etc/kubeadm.sh
+[Install]
+WantedBy=multi-user.target
+`
+
+	kubeadmScriptTemplate = `#!/bin/bash
+set -e
+{{ range .PreKubeadmCommands }}
+{{ . }}
+{{- end }}
+
+{{ .KubeadmCommand }}
+mkdir -p /run/cluster-api && echo success > /run/cluster-api/bootstrap-success.complete
+mv /etc/kubeadm.yml /tmp/
+{{ range .PostKubeadmCommands }}
+{{ . }}
+{{- end }}
+`
+
+	mountUnitTemplate = `[Unit]
+Description = Mount {{ .Label }}
+
+[Mount]
+What={{ .Device }}
+Where={{ .Mountpoint }}
+Options={{ .Options }}
+
+[Install]
+WantedBy=multi-user.target
+`
+
+	sshdConfigTemplate = `# Use most defaults for sshd configuration.
+Subsystem sftp internal-sftp
+ClientAliveInterval 180
+UseDNS no
+UsePAM yes
+PrintLastLog no # handled by PAM
+PrintMotd no # handled by PAM
+
+Match User %s
+  PasswordAuthentication yes
+`
+
+	ntpdConfigTemplate = `# Common pool
+{{- range . }}
+server {{ . }}
+{{- end }}
+
+# Warning: Using default NTP settings will leave your NTP
+# server accessible to all hosts on the Internet.
+
+# If you want to deny all machines (including your own)
+# from accessing the NTP server, uncomment:
+#restrict default ignore
+
+# Default configuration:
+# - Allow only time queries, at a limited rate, sending KoD when in excess.
+# - Allow all local queries (IPv4, IPv6)
+restrict default nomodify nopeer noquery notrap limited kod
+restrict 127.0.0.1
+restrict [::1]
+`
+
+	chronyConfigTemplate = `# Common pool
+{{- range . }}
+server {{ . }} iburst
+{{- end }}
+
+driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+logdir /var/log/chrony
+`
+)
+
+var (
+	kubeadmScript = template.Must(template.New("kubeadm.sh").Parse(kubeadmScriptTemplate))
+	mountUnit     = template.Must(template.New("mount").Parse(mountUnitTemplate))
+	ntpdConfig    = template.Must(template.New("ntp.conf").Parse(ntpdConfigTemplate))
+	chronyConfig  = template.Must(template.New("chrony.conf").Parse(chronyConfigTemplate))
+)
+
+// Render renders the provided user data and the IgnitionSpec into an Ignition v3 config
+// for the Butane variant defined in the IgnitionSpec.
+func Render(input *cloudinit.BaseUserData, ignitionSpec *bootstrapv1.IgnitionSpec, kubeadmConfig string) ([]byte, string, error) {
+	if input == nil {
+		return nil, "", errors.New("empty base user data")
+	}
+
+	if ignitionSpec == nil || !ignitionSpec.Butane.IsDefined() {
+		return nil, "", errors.New("butane configuration must be defined")
+	}
+
+	variant := ignitionSpec.Butane.Variant
+	if variant != bootstrapv1.FCOSButaneVariant && variant != bootstrapv1.FlatcarButaneVariant {
+		return nil, "", errors.Errorf("unknown Butane variant %q", variant)
+	}
+
+	b := &builder{
+		variant: variant,
+		config: config{
+			Ignition: ignitionInfo{Version: ignitionVersion},
+			Storage:  &storage{},
+			Systemd:  &systemd{},
+		},
+	}
+
+	if err := b.addUsers(input.Users); err != nil {
+		return nil, "", err
+	}
+	b.addDiskSetup(input.DiskSetup)
+	if err := b.addKubeadm(input, kubeadmConfig); err != nil {
+		return nil, "", err
+	}
+	if err := b.addMounts(input.Mounts, input.DiskSetup); err != nil {
+		return nil, "", err
+	}
+	if err := b.addWriteFiles(input.WriteFiles); err != nil {
+		return nil, "", err
+	}
+	if err := b.addNTP(input.NTP); err != nil {
+		return nil, "", err
+	}
+	if err := b.addIgnitionSpec(ignitionSpec); err != nil {
+		return nil, "", err
+	}
+	if err := b.validatePaths(); err != nil {
+		return nil, "", err
+	}
+
+	userData, err := json.Marshal(b.config)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "marshaling generated Ignition config into JSON")
+	}
+
+	return userData, "", nil
+}
+
+type builder struct {
+	variant bootstrapv1.ButaneVariant
+	config  config
+}
+
+func (b *builder) addUsers(users []bootstrapv1.User) error {
+	usersWithPasswordAuth := []string{}
+	for _, user := range users {
+		passwdUser := passwdUser{
+			Name:              user.Name,
+			Gecos:             stringOrNil(user.Gecos),
+			HomeDir:           stringOrNil(user.HomeDir),
+			PasswordHash:      stringOrNil(user.Passwd),
+			PrimaryGroup:      stringOrNil(user.PrimaryGroup),
+			Shell:             stringOrNil(user.Shell),
+			SSHAuthorizedKeys: user.SSHAuthorizedKeys,
+		}
+		if user.Groups != "" {
+			for _, group := range strings.Split(user.Groups, ",") {
+				passwdUser.Groups = append(passwdUser.Groups, strings.TrimSpace(group))
+			}
+		}
+		if b.config.Passwd == nil {
+			b.config.Passwd = &passwd{}
+		}
+		b.config.Passwd.Users = append(b.config.Passwd.Users, passwdUser)
+
+		if user.Sudo != "" {
+			if err := b.addFile(bootstrapv1.File{
+				Path:        fmt.Sprintf("/etc/sudoers.d/%s", user.Name),
+				Permissions: "0600",
+				Content:     fmt.Sprintf("%s %s\n", user.Name, user.Sudo),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if user.LockPassword != nil && !*user.LockPassword {
+			usersWithPasswordAuth = append(usersWithPasswordAuth, user.Name)
+		}
+	}
+
+	if len(usersWithPasswordAuth) > 0 {
+		return b.addFile(bootstrapv1.File{
+			Path:        "/etc/ssh/sshd_config",
+			Permissions: "0600",
+			Content:     fmt.Sprintf(sshdConfigTemplate, strings.Join(usersWithPasswordAuth, ",")),
+		})
+	}
+	return nil
+}
+
+func (b *builder) addDiskSetup(diskSetup *bootstrapv1.DiskSetup) {
+	if diskSetup == nil {
+		return
+	}
+
+	for _, p := range diskSetup.Partitions {
+		d := disk{
+			Device:    p.Device,
+			WipeTable: p.Overwrite,
+		}
+		if ptr.Deref(p.Layout, false) {
+			d.Partitions = []partition{{}}
+		}
+		b.config.Storage.Disks = append(b.config.Storage.Disks, d)
+	}
+
+	for _, fs := range diskSetup.Filesystems {
+		b.config.Storage.Filesystems = append(b.config.Storage.Filesystems, filesystem{
+			Device:         fs.Device,
+			Format:         stringOrNil(fs.Filesystem),
+			Label:          stringOrNil(fs.Label),
+			Options:        fs.ExtraOpts,
+			WipeFilesystem: fs.Overwrite,
+		})
+	}
+}
+
+func (b *builder) addKubeadm(input *cloudinit.BaseUserData, kubeadmConfig string) error {
+	b.addUnit(unit{
+		Name:     "kubeadm.service",
+		Enabled:  ptr.To(true),
+		Contents: ptr.To(kubeadmUnit),
+	})
+
+	var script bytes.Buffer
+	if err := kubeadmScript.Execute(&script, input); err != nil {
+		return errors.Wrapf(err, "failed to render /etc/kubeadm.sh")
+	}
+	if err := b.addFile(bootstrapv1.File{
+		Path:        "/etc/kubeadm.sh",
+		Permissions: "0700",
+		Content:     script.String(),
+	}); err != nil {
+		return err
+	}
+
+	return b.addFile(bootstrapv1.File{
+		Path:        "/etc/kubeadm.yml",
+		Permissions: "0600",
+		Content:     fmt.Sprintf("---\n%s\n", kubeadmConfig),
+	})
+}
+
+func (b *builder) addMounts(mounts []bootstrapv1.MountPoints, diskSetup *bootstrapv1.DiskSetup) error {
+	filesystemDevicesByLabel := map[string]string{}
+	if diskSetup != nil {
+		for _, filesystem := range diskSetup.Filesystems {
+			filesystemDevicesByLabel[filesystem.Label] = filesystem.Device
+		}
+	}
+
+	for _, mount := range mounts {
+		if len(mount) < 2 {
+			return errors.Errorf("mount %v must define at least a label and a mount point", mount)
+		}
+
+		var contents bytes.Buffer
+		if err := mountUnit.Execute(&contents, map[string]string{
+			"Label":      mount[0],
+			"Device":     filesystemDevicesByLabel[mount[0]],
+			"Mountpoint": mount[1],
+			"Options":    strings.Join(mount[2:], ","),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to render mount unit for %s", mount[1])
+		}
+		b.addUnit(unit{
+			Name:     fmt.Sprintf("%s.mount", mountpointName(mount[1])),
+			Enabled:  ptr.To(true),
+			Contents: ptr.To(contents.String()),
+		})
+	}
+	return nil
+}
+
+func (b *builder) addWriteFiles(files []bootstrapv1.File) error {
+	for _, f := range files {
+		if err := b.addFile(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) addNTP(ntp *bootstrapv1.NTP) error {
+	if ntp == nil || !ptr.Deref(ntp.Enabled, false) {
+		return nil
+	}
+
+	// Flatcar uses ntpd, while Fedora CoreOS uses chronyd.
+	unitName, configPath, configTemplate := "ntpd.service", "/etc/ntp.conf", ntpdConfig
+	if b.variant == bootstrapv1.FCOSButaneVariant {
+		unitName, configPath, configTemplate = "chronyd.service", "/etc/chrony.conf", chronyConfig
+	}
+
+	b.addUnit(unit{
+		Name:    unitName,
+		Enabled: ptr.To(true),
+	})
+
+	if len(ntp.Servers) == 0 {
+		return nil
+	}
+
+	var contents bytes.Buffer
+	if err := configTemplate.Execute(&contents, ntp.Servers); err != nil {
+		return errors.Wrapf(err, "failed to render %s", configPath)
+	}
+	return b.addFile(bootstrapv1.File{
+		Path:        configPath,
+		Permissions: "0644",
+		Content:     contents.String(),
+	})
+}
+
+func (b *builder) addIgnitionSpec(ignitionSpec *bootstrapv1.IgnitionSpec) error {
+	for _, u := range ignitionSpec.Systemd.Units {
+		systemdUnit := unit{
+			Name:     u.Name,
+			Enabled:  u.Enabled,
+			Mask:     u.Mask,
+			Contents: stringOrNil(u.Contents),
+		}
+		for _, d := range u.Dropins {
+			systemdUnit.Dropins = append(systemdUnit.Dropins, dropin{
+				Name:     d.Name,
+				Contents: ptr.To(d.Contents),
+			})
+		}
+		b.addUnit(systemdUnit)
+	}
+
+	for _, l := range ignitionSpec.Storage.Links {
+		b.config.Storage.Links = append(b.config.Storage.Links, link{
+			node:   newNode(l.Path, l.Owner, l.Overwrite),
+			Hard:   l.Hard,
+			Target: l.Target,
+		})
+	}
+
+	for _, d := range ignitionSpec.Storage.Directories {
+		mode, err := parseMode(d.Permissions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse permissions of directory %q", d.Path)
+		}
+		b.config.Storage.Directories = append(b.config.Storage.Directories, directory{
+			node: newNode(d.Path, d.Owner, d.Overwrite),
+			Mode: mode,
+		})
+	}
+
+	if ignitionSpec.KernelArguments.IsDefined() {
+		b.config.KernelArguments = &kernelArguments{
+			ShouldExist:    ignitionSpec.KernelArguments.ShouldExist,
+			ShouldNotExist: ignitionSpec.KernelArguments.ShouldNotExist,
+		}
+	}
+	return nil
+}
+
+// addUnit adds a unit to the config; if a unit with the same name already exists,
+// fields set on the new unit take precedence and its dropins are appended.
+func (b *builder) addUnit(u unit) {
+	for i, existing := range b.config.Systemd.Units {
+		if existing.Name != u.Name {
+			continue
+		}
+		if u.Enabled != nil {
+			existing.Enabled = u.Enabled
+		}
+		if u.Mask != nil {
+			existing.Mask = u.Mask
+		}
+		if u.Contents != nil {
+			existing.Contents = u.Contents
+		}
+		existing.Dropins = append(existing.Dropins, u.Dropins...)
+		b.config.Systemd.Units[i] = existing
+		return
+	}
+	b.config.Systemd.Units = append(b.config.Systemd.Units, u)
+}
+
+func (b *builder) addFile(f bootstrapv1.File) error {
+	mode, err := parseMode(f.Permissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse permissions of file %q", f.Path)
+	}
+
+	var source string
+	switch f.Encoding {
+	case "":
+		source = dataURL([]byte(f.Content))
+	case bootstrapv1.Base64:
+		// Content is already base64 encoded, only whitespaces have to be removed.
+		source = "data:;base64," + strings.Join(strings.Fields(f.Content), "")
+	default:
+		return errors.Errorf("encoding %q of file %q is not supported", f.Encoding, f.Path)
+	}
+
+	ignitionFile := file{
+		node: newNode(f.Path, f.Owner, nil),
+		Mode: mode,
+	}
+	if ptr.Deref(f.Append, false) {
+		ignitionFile.Append = []resource{{Source: ptr.To(source)}}
+	} else {
+		ignitionFile.Overwrite = ptr.To(true)
+		ignitionFile.Contents = &resource{Source: ptr.To(source)}
+	}
+	b.config.Storage.Files = append(b.config.Storage.Files, ignitionFile)
+	return nil
+}
+
+// validatePaths checks that files, links and directories have unique paths, because
+// Ignition v3 fails on conflicting paths.
+func (b *builder) validatePaths() error {
+	paths := map[string]struct{}{}
+	check := func(path string) error {
+		if _, ok := paths[path]; ok {
+			return errors.Errorf("path %q is defined more than once in files, links and directories", path)
+		}
+		paths[path] = struct{}{}
+		return nil
+	}
+	for _, f := range b.config.Storage.Files {
+		if err := check(f.Path); err != nil {
+			return err
+		}
+	}
+	for _, l := range b.config.Storage.Links {
+		if err := check(l.Path); err != nil {
+			return err
+		}
+	}
+	for _, d := range b.config.Storage.Directories {
+		if err := check(d.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newNode(path, ownerRaw string, overwrite *bool) node {
+	n := node{
+		Path:      path,
+		Overwrite: overwrite,
+	}
+	if ownerRaw == "" {
+		return n
+	}
+
+	owner := strings.SplitN(ownerRaw, ":", 2)
+	if u := strings.TrimSpace(owner[0]); u != "" {
+		n.User = &nodeUser{Name: ptr.To(u)}
+	}
+	if len(owner) == 2 {
+		if g := strings.TrimSpace(owner[1]); g != "" {
+			n.Group = &nodeGroup{Name: ptr.To(g)}
+		}
+	}
+	return n
+}
+
+func parseMode(permissions string) (*int, error) {
+	if permissions == "" {
+		return nil, nil
+	}
+	mode, err := strconv.ParseInt(permissions, 8, 32)
+	if err != nil {
+		return nil, err
+	}
+	return ptr.To(int(mode)), nil
+}
+
+func dataURL(data []byte) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// mountpointName returns the name of the systemd mount unit for a mount point, without the .mount suffix,
+// escaping the path like systemd-escape --path does, e.g. /var/lib/my-dir becomes var-lib-my\x2ddir.
+func mountpointName(name string) string {
+	p := strings.Trim(path.Clean("/"+name), "/")
+	if p == "" {
+		return "-"
+	}
+
+	var sb strings.Builder
+	for i := range len(p) {
+		c := p[i]
+		switch {
+		case c == '/':
+			sb.WriteByte('-')
+		case c == '.' && i == 0:
+			// A leading dot is escaped, so the unit name is not a hidden file.
+			fmt.Fprintf(&sb, `\x%02x`, c)
+		case c == '.' || c == '_' || c == ':' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, `\x%02x`, c)
+		}
+	}
+	return sb.String()
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
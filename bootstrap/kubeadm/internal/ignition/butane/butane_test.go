@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package butane
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.BaseUserData{
+		PreKubeadmCommands:  []string{"pre-command"},
+		PostKubeadmCommands: []string{"post-command"},
+		KubeadmCommand:      "kubeadm join",
+		NTP: &bootstrapv1.NTP{
+			Enabled: ptr.To(true),
+			Servers: []string{"foo.bar"},
+		},
+		Users: []bootstrapv1.User{
+			{
+				Name:              "foo",
+				Groups:            "foo, bar",
+				Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+				SSHAuthorizedKeys: []string{"foo"},
+			},
+		},
+		DiskSetup: &bootstrapv1.DiskSetup{
+			Partitions: []bootstrapv1.Partition{
+				{
+					Device:    "/dev/sdb",
+					Layout:    ptr.To(true),
+					Overwrite: ptr.To(true),
+				},
+			},
+			Filesystems: []bootstrapv1.Filesystem{
+				{
+					Device:     "/dev/sdb",
+					Filesystem: "ext4",
+					Label:      "test_disk",
+				},
+			},
+		},
+		Mounts: []bootstrapv1.MountPoints{
+			{"test_disk", "/var/lib/testdir", "defaults"},
+		},
+		WriteFiles: []bootstrapv1.File{
+			{
+				Path:        "/etc/testfile.yaml",
+				Encoding:    bootstrapv1.Base64,
+				Content:     "Zm9vCg==",
+				Permissions: "0600",
+				Owner:       "nobody:nobody",
+			},
+			{
+				Path:    "/etc/appended",
+				Content: "foo",
+				Append:  ptr.To(true),
+			},
+		},
+	}
+	ignitionSpec := &bootstrapv1.IgnitionSpec{
+		Butane: bootstrapv1.ButaneConfig{
+			Variant: bootstrapv1.FlatcarButaneVariant,
+		},
+		Systemd: bootstrapv1.IgnitionSystemd{
+			Units: []bootstrapv1.IgnitionSystemdUnit{
+				{
+					Name: "kubeadm.service",
+					Dropins: []bootstrapv1.IgnitionSystemdDropin{
+						{
+							Name:     "10-override.conf",
+							Contents: "[Service]\nTimeoutStartSec=600\n",
+						},
+					},
+				},
+				{
+					Name: "update-engine.service",
+					Mask: ptr.To(true),
+				},
+			},
+		},
+		Storage: bootstrapv1.IgnitionStorage{
+			Links: []bootstrapv1.IgnitionLink{
+				{
+					Path:   "/opt/bin/kubectl",
+					Target: "/usr/bin/kubectl",
+				},
+			},
+			Directories: []bootstrapv1.IgnitionDirectory{
+				{
+					Path:        "/var/lib/foo",
+					Owner:       "core:core",
+					Permissions: "0750",
+				},
+			},
+		},
+		KernelArguments: bootstrapv1.IgnitionKernelArguments{
+			ShouldExist:    []string{"systemd.unified_cgroup_hierarchy=1"},
+			ShouldNotExist: []string{"mitigations=off"},
+		},
+	}
+
+	userData, warnings, err := Render(input, ignitionSpec, "kind: JoinConfiguration")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+
+	expected := `{
+  "ignition": {"version": "3.4.0"},
+  "kernelArguments": {
+    "shouldExist": ["systemd.unified_cgroup_hierarchy=1"],
+    "shouldNotExist": ["mitigations=off"]
+  },
+  "passwd": {
+    "users": [
+      {"name": "foo", "groups": ["foo", "bar"], "sshAuthorizedKeys": ["foo"]}
+    ]
+  },
+  "storage": {
+    "directories": [
+      {"path": "/var/lib/foo", "user": {"name": "core"}, "group": {"name": "core"}, "mode": 488}
+    ],
+    "disks": [
+      {"device": "/dev/sdb", "partitions": [{}], "wipeTable": true}
+    ],
+    "files": [
+      {"path": "/etc/sudoers.d/foo", "overwrite": true, "mode": 384, "contents": {"source": "` + dataURL([]byte("foo ALL=(ALL) NOPASSWD:ALL\n")) + `"}},
+      {"path": "/etc/kubeadm.sh", "overwrite": true, "mode": 448, "contents": {"source": "` + dataURL([]byte("#!/bin/bash\nset -e\n\npre-command\n\nkubeadm join\nmkdir -p /run/cluster-api && echo success > /run/cluster-api/bootstrap-success.complete\nmv /etc/kubeadm.yml /tmp/\n\npost-command\n")) + `"}},
+      {"path": "/etc/kubeadm.yml", "overwrite": true, "mode": 384, "contents": {"source": "` + dataURL([]byte("---\nkind: JoinConfiguration\n")) + `"}},
+      {"path": "/etc/testfile.yaml", "overwrite": true, "user": {"name": "nobody"}, "group": {"name": "nobody"}, "mode": 384, "contents": {"source": "data:;base64,Zm9vCg=="}},
+      {"path": "/etc/appended", "append": [{"source": "` + dataURL([]byte("foo")) + `"}]},
+      {"path": "/etc/ntp.conf", "overwrite": true, "mode": 420, "contents": {"source": "` + dataURL([]byte("# Common pool\nserver foo.bar\n\n# Warning: Using default NTP settings will leave your NTP\n# server accessible to all hosts on the Internet.\n\n# If you want to deny all machines (including your own)\n# from accessing the NTP server, uncomment:\n#restrict default ignore\n\n# Default configuration:\n# - Allow only time queries, at a limited rate, sending KoD when in excess.\n# - Allow all local queries (IPv4, IPv6)\nrestrict default nomodify nopeer noquery notrap limited kod\nrestrict 127.0.0.1\nrestrict [::1]\n")) + `"}}
+    ],
+    "filesystems": [
+      {"device": "/dev/sdb", "format": "ext4", "label": "test_disk"}
+    ],
+    "links": [
+      {"path": "/opt/bin/kubectl", "target": "/usr/bin/kubectl"}
+    ]
+  },
+  "systemd": {
+    "units": [
+      {
+        "name": "kubeadm.service",
+        "enabled": true,
+        "contents": "[Unit]\nDescription=kubeadm\n# Run only once. After successful run, this file is moved to /tmp/.\nConditionPathExists=/etc/kubeadm.yml\nAfter=network.target\n[Service]\n# To not restart the unit when it exits, as it is expected.\nType=oneshot\nExecStart=/etc/kubeadm.sh\n[Install]\nWantedBy=multi-user.target\n",
+        "dropins": [{"name": "10-override.conf", "contents": "[Service]\nTimeoutStartSec=600\n"}]
+      },
+      {
+        "name": "var-lib-testdir.mount",
+        "enabled": true,
+        "contents": "[Unit]\nDescription = Mount test_disk\n\n[Mount]\nWhat=/dev/sdb\nWhere=/var/lib/testdir\nOptions=defaults\n\n[Install]\nWantedBy=multi-user.target\n"
+      },
+      {"name": "ntpd.service", "enabled": true},
+      {"name": "update-engine.service", "mask": true}
+    ]
+  }
+}`
+	g.Expect(string(userData)).To(MatchJSON(expected))
+}
+
+func TestRenderFCOSNTP(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.BaseUserData{
+		NTP: &bootstrapv1.NTP{
+			Enabled: ptr.To(true),
+			Servers: []string{"foo.bar"},
+		},
+	}
+	ignitionSpec := &bootstrapv1.IgnitionSpec{
+		Butane: bootstrapv1.ButaneConfig{
+			Variant: bootstrapv1.FCOSButaneVariant,
+		},
+	}
+
+	userData, _, err := Render(input, ignitionSpec, "")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got := config{}
+	g.Expect(json.Unmarshal(userData, &got)).To(Succeed())
+	g.Expect(got.Systemd.Units).To(ContainElement(unit{Name: "chronyd.service", Enabled: ptr.To(true)}))
+
+	var chronyConfig *file
+	for _, f := range got.Storage.Files {
+		if f.Path == "/etc/chrony.conf" {
+			chronyConfig = &f
+		}
+	}
+	g.Expect(chronyConfig).ToNot(BeNil())
+	g.Expect(*chronyConfig.Contents.Source).To(HavePrefix("data:;base64,"))
+	content, err := base64.StdEncoding.DecodeString((*chronyConfig.Contents.Source)[len("data:;base64,"):])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("server foo.bar iburst\n"))
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        *cloudinit.BaseUserData
+		ignitionSpec *bootstrapv1.IgnitionSpec
+	}{
+		{
+			name:  "Fails without input",
+			input: nil,
+			ignitionSpec: &bootstrapv1.IgnitionSpec{
+				Butane: bootstrapv1.ButaneConfig{Variant: bootstrapv1.FlatcarButaneVariant},
+			},
+		},
+		{
+			name:         "Fails without butane configuration",
+			input:        &cloudinit.BaseUserData{},
+			ignitionSpec: &bootstrapv1.IgnitionSpec{},
+		},
+		{
+			name:  "Fails for unknown variant",
+			input: &cloudinit.BaseUserData{},
+			ignitionSpec: &bootstrapv1.IgnitionSpec{
+				Butane: bootstrapv1.ButaneConfig{Variant: "rhcos"},
+			},
+		},
+		{
+			name: "Fails for gzip encoded files",
+			input: &cloudinit.BaseUserData{
+				WriteFiles: []bootstrapv1.File{
+					{Path: "/etc/foo", Content: "foo", Encoding: bootstrapv1.Gzip},
+				},
+			},
+			ignitionSpec: &bootstrapv1.IgnitionSpec{
+				Butane: bootstrapv1.ButaneConfig{Variant: bootstrapv1.FlatcarButaneVariant},
+			},
+		},
+		{
+			name: "Fails for conflicting paths",
+			input: &cloudinit.BaseUserData{
+				WriteFiles: []bootstrapv1.File{
+					{Path: "/opt/bin/kubectl", Content: "foo"},
+				},
+			},
+			ignitionSpec: &bootstrapv1.IgnitionSpec{
+				Butane: bootstrapv1.ButaneConfig{Variant: bootstrapv1.FlatcarButaneVariant},
+				Storage: bootstrapv1.IgnitionStorage{
+					Links: []bootstrapv1.IgnitionLink{
+						{Path: "/opt/bin/kubectl", Target: "/usr/bin/kubectl"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, _, err := Render(tt.input, tt.ignitionSpec, "")
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestMountpointName(t *testing.T) {
+	tests := []struct {
+		mountpoint string
+		want       string
+	}{
+		{mountpoint: "/var/lib/testdir", want: "var-lib-testdir"},
+		{mountpoint: "/var/lib/test-dir", want: `var-lib-test\x2ddir`},
+		{mountpoint: "/var/lib/test dir/", want: `var-lib-test\x20dir`},
+		{mountpoint: "//var//lib/", want: "var-lib"},
+		{mountpoint: "/.hidden/dir", want: `\x2ehidden-dir`},
+		{mountpoint: "/mnt/disk_1.0", want: "mnt-disk_1.0"},
+		{mountpoint: "/", want: "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mountpoint, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(mountpointName(tt.mountpoint)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package butane
+
+// The types below are the subset of the Ignition v3 config spec used by the bootstrap provider.
+// See https://coreos.github.io/ignition/configuration-v3_4/ for the full spec.
+
+type config struct {
+	Ignition        ignitionInfo     `json:"ignition"`
+	KernelArguments *kernelArguments `json:"kernelArguments,omitempty"`
+	Passwd          *passwd          `json:"passwd,omitempty"`
+	Storage         *storage         `json:"storage,omitempty"`
+	Systemd         *systemd         `json:"systemd,omitempty"`
+}
+
+type ignitionInfo struct {
+	Version string `json:"version"`
+}
+
+type kernelArguments struct {
+	ShouldExist    []string `json:"shouldExist,omitempty"`
+	ShouldNotExist []string `json:"shouldNotExist,omitempty"`
+}
+
+type passwd struct {
+	Users []passwdUser `json:"users,omitempty"`
+}
+
+type passwdUser struct {
+	Name              string   `json:"name"`
+	Gecos             *string  `json:"gecos,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	HomeDir           *string  `json:"homeDir,omitempty"`
+	PasswordHash      *string  `json:"passwordHash,omitempty"`
+	PrimaryGroup      *string  `json:"primaryGroup,omitempty"`
+	Shell             *string  `json:"shell,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+type storage struct {
+	Directories []directory  `json:"directories,omitempty"`
+	Disks       []disk       `json:"disks,omitempty"`
+	Files       []file       `json:"files,omitempty"`
+	Filesystems []filesystem `json:"filesystems,omitempty"`
+	Links       []link       `json:"links,omitempty"`
+}
+
+type disk struct {
+	Device     string      `json:"device"`
+	Partitions []partition `json:"partitions,omitempty"`
+	WipeTable  *bool       `json:"wipeTable,omitempty"`
+}
+
+type partition struct{}
+
+type filesystem struct {
+	Device         string   `json:"device"`
+	Format         *string  `json:"format,omitempty"`
+	Label          *string  `json:"label,omitempty"`
+	Options        []string `json:"options,omitempty"`
+	WipeFilesystem *bool    `json:"wipeFilesystem,omitempty"`
+}
+
+type node struct {
+	Group     *nodeGroup `json:"group,omitempty"`
+	Overwrite *bool      `json:"overwrite,omitempty"`
+	Path      string     `json:"path"`
+	User      *nodeUser  `json:"user,omitempty"`
+}
+
+type nodeGroup struct {
+	Name *string `json:"name,omitempty"`
+}
+
+type nodeUser struct {
+	Name *string `json:"name,omitempty"`
+}
+
+type file struct {
+	node
+	Append   []resource `json:"append,omitempty"`
+	Contents *resource  `json:"contents,omitempty"`
+	Mode     *int       `json:"mode,omitempty"`
+}
+
+type resource struct {
+	Source *string `json:"source,omitempty"`
+}
+
+type link struct {
+	node
+	Hard   *bool  `json:"hard,omitempty"`
+	Target string `json:"target"`
+}
+
+type directory struct {
+	node
+	Mode *int `json:"mode,omitempty"`
+}
+
+type systemd struct {
+	Units []unit `json:"units,omitempty"`
+}
+
+type unit struct {
+	Contents *string  `json:"contents,omitempty"`
+	Dropins  []dropin `json:"dropins,omitempty"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+	Mask     *bool    `json:"mask,omitempty"`
+	Name     string   `json:"name"`
+}
+
+type dropin struct {
+	Contents *string `json:"contents,omitempty"`
+	Name     string  `json:"name"`
+}
@@ -30,7 +30,7 @@ limitations under the License.
 // using 'envsubst' or 'sed'.
 //
 // To override the behavior of kubeadm.service unit, one should create an override drop-in
-// using AdditionalConfig field. Data from this field takes precedence and will be merged with
+// using the structured systemd units or the AdditionalConfig field. Data from this field takes precedence and will be merged with
 // configuration generated by the bootstrap provider, overriding already defined fields following the
 // merge strategy described in https://coreos.github.io/ignition/operator-notes/#config-merging.
 package clc
@@ -39,6 +39,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -350,18 +351,22 @@ func renderCLC(input *cloudinit.BaseUserData, kubeadmConfig string) ([]byte, err
 	return out.Bytes(), nil
 }
 
-// Render renders the provided user data and CLC snippets into Ignition config.
-func Render(input *cloudinit.BaseUserData, clc *bootstrapv1.ContainerLinuxConfig, kubeadmConfig string) ([]byte, string, error) {
+// Render renders the provided user data, the structured Ignition configuration and CLC snippets into Ignition config.
+func Render(input *cloudinit.BaseUserData, ignitionSpec *bootstrapv1.IgnitionSpec, kubeadmConfig string) ([]byte, string, error) {
 	if input == nil {
 		return nil, "", errors.New("empty base user data")
 	}
 
+	if ignitionSpec == nil {
+		ignitionSpec = &bootstrapv1.IgnitionSpec{}
+	}
+
 	clcBytes, err := renderCLC(input, kubeadmConfig)
 	if err != nil {
 		return nil, "", errors.Wrapf(err, "rendering CLC configuration")
 	}
 
-	userData, warnings, err := buildIgnitionConfig(clcBytes, clc)
+	userData, warnings, err := buildIgnitionConfig(clcBytes, ignitionSpec)
 	if err != nil {
 		return nil, "", errors.Wrapf(err, "building Ignition config")
 	}
@@ -369,16 +374,31 @@ func Render(input *cloudinit.BaseUserData, clc *bootstrapv1.ContainerLinuxConfig
 	return userData, warnings, nil
 }
 
-func buildIgnitionConfig(baseCLC []byte, clc *bootstrapv1.ContainerLinuxConfig) ([]byte, string, error) {
+// Validate validates the provided CLC snippet, treating warnings as errors if strict is set.
+// Warnings are returned if strict is not set.
+func Validate(additionalConfig string, strict bool) (string, error) {
+	_, warnings, err := clcToIgnition([]byte(additionalConfig), strict)
+	return warnings, err
+}
+
+func buildIgnitionConfig(baseCLC []byte, ignitionSpec *bootstrapv1.IgnitionSpec) ([]byte, string, error) {
 	// We control baseCLC config, so treat it as strict.
 	ign, _, err := clcToIgnition(baseCLC, true)
 	if err != nil {
 		return nil, "", errors.Wrapf(err, "converting generated CLC to Ignition")
 	}
 
+	// Structured configuration is appended before additional config, so that additional config takes precedence.
+	structuredIgn, err := structuredToIgnition(ignitionSpec)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "converting structured Ignition configuration")
+	}
+	ign = ignition.Append(ign, structuredIgn)
+
 	var clcWarnings string
 
-	if clc != nil && clc.AdditionalConfig != "" {
+	clc := ignitionSpec.ContainerLinuxConfig
+	if clc.AdditionalConfig != "" {
 		additionalIgn, warnings, err := clcToIgnition([]byte(clc.AdditionalConfig), ptr.Deref(clc.Strict, false))
 		if err != nil {
 			return nil, "", errors.Wrapf(err, "converting additional CLC to Ignition")
@@ -413,3 +433,66 @@ func clcToIgnition(data []byte, strict bool) (ignitionTypes.Config, string, erro
 
 	return ign, reports.String(), nil
 }
+
+// structuredToIgnition converts systemd units, links and directories from the IgnitionSpec into Ignition config.
+func structuredToIgnition(ignitionSpec *bootstrapv1.IgnitionSpec) (ignitionTypes.Config, error) {
+	ign := ignitionTypes.Config{}
+
+	for _, unit := range ignitionSpec.Systemd.Units {
+		ignUnit := ignitionTypes.Unit{
+			Name:     unit.Name,
+			Enabled:  unit.Enabled,
+			Mask:     ptr.Deref(unit.Mask, false),
+			Contents: unit.Contents,
+		}
+		for _, dropin := range unit.Dropins {
+			ignUnit.Dropins = append(ignUnit.Dropins, ignitionTypes.SystemdDropin{
+				Name:     dropin.Name,
+				Contents: dropin.Contents,
+			})
+		}
+		ign.Systemd.Units = append(ign.Systemd.Units, ignUnit)
+	}
+
+	for _, link := range ignitionSpec.Storage.Links {
+		ign.Storage.Links = append(ign.Storage.Links, ignitionTypes.Link{
+			Node: node(link.Path, link.Owner, link.Overwrite),
+			LinkEmbedded1: ignitionTypes.LinkEmbedded1{
+				Hard:   ptr.Deref(link.Hard, false),
+				Target: link.Target,
+			},
+		})
+	}
+
+	for _, directory := range ignitionSpec.Storage.Directories {
+		ignDirectory := ignitionTypes.Directory{
+			Node: node(directory.Path, directory.Owner, directory.Overwrite),
+		}
+		if directory.Permissions != "" {
+			mode, err := strconv.ParseInt(directory.Permissions, 8, 32)
+			if err != nil {
+				return ignitionTypes.Config{}, errors.Wrapf(err, "failed to parse permissions of directory %q", directory.Path)
+			}
+			ignDirectory.Mode = ptr.To(int(mode))
+		}
+		ign.Storage.Directories = append(ign.Storage.Directories, ignDirectory)
+	}
+
+	return ign, nil
+}
+
+func node(path, ownerRaw string, overwrite *bool) ignitionTypes.Node {
+	n := ignitionTypes.Node{
+		Filesystem: "root",
+		Path:       path,
+		Overwrite:  overwrite,
+	}
+	o := parseOwner(ownerRaw)
+	if o.User != nil {
+		n.User = &ignitionTypes.NodeUser{Name: *o.User}
+	}
+	if o.Group != nil {
+		n.Group = &ignitionTypes.NodeGroup{Name: *o.Group}
+	}
+	return n
+}
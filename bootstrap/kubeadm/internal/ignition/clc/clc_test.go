@@ -563,7 +563,7 @@ func TestRender(t *testing.T) {
 		t.Run(tt.desc, func(t *testing.T) {
 			t.Parallel()
 
-			ignitionBytes, _, err := clc.Render(tt.input, &bootstrapv1.ContainerLinuxConfig{}, "foo")
+			ignitionBytes, _, err := clc.Render(tt.input, &bootstrapv1.IgnitionSpec{}, "foo")
 			if err != nil {
 				t.Fatalf("rendering: %v", err)
 			}
@@ -586,7 +586,7 @@ func TestRender(t *testing.T) {
 	t.Run("validates input parameter", func(t *testing.T) {
 		t.Parallel()
 
-		if _, _, err := clc.Render(nil, &bootstrapv1.ContainerLinuxConfig{}, "foo"); err == nil {
+		if _, _, err := clc.Render(nil, &bootstrapv1.IgnitionSpec{}, "foo"); err == nil {
 			t.Fatal("expected error when passing empty input data")
 		}
 	})
@@ -599,10 +599,116 @@ func TestRender(t *testing.T) {
 		}
 	})
 
+	t.Run("renders structured configuration", func(t *testing.T) {
+		t.Parallel()
+
+		config := &bootstrapv1.IgnitionSpec{
+			Systemd: bootstrapv1.IgnitionSystemd{
+				Units: []bootstrapv1.IgnitionSystemdUnit{
+					{
+						Name: "kubeadm.service",
+						Dropins: []bootstrapv1.IgnitionSystemdDropin{
+							{
+								Name:     "10-override.conf",
+								Contents: "[Service]\nTimeoutStartSec=600\n",
+							},
+						},
+					},
+					{
+						Name: "update-engine.service",
+						Mask: ptr.To(true),
+					},
+				},
+			},
+			Storage: bootstrapv1.IgnitionStorage{
+				Links: []bootstrapv1.IgnitionLink{
+					{
+						Path:   "/opt/bin/kubectl",
+						Target: "/usr/bin/kubectl",
+					},
+				},
+				Directories: []bootstrapv1.IgnitionDirectory{
+					{
+						Path:        "/var/lib/foo",
+						Owner:       "core:core",
+						Permissions: "0750",
+					},
+				},
+			},
+		}
+
+		ignitionBytes, _, err := clc.Render(&cloudinit.BaseUserData{}, config, "foo")
+		if err != nil {
+			t.Fatalf("rendering: %v", err)
+		}
+
+		ign, reports, err := ignition.Parse(ignitionBytes)
+		if err != nil {
+			t.Fatalf("Parsing generated Ignition: %v", err)
+		}
+
+		if reports.IsFatal() {
+			t.Fatalf("Generated Ignition has fatal reports: %s", reports)
+		}
+
+		wantUnits := []types.Unit{
+			{
+				Name: "kubeadm.service",
+				Dropins: []types.SystemdDropin{
+					{
+						Name:     "10-override.conf",
+						Contents: "[Service]\nTimeoutStartSec=600\n",
+					},
+				},
+			},
+			{
+				Name: "update-engine.service",
+				Mask: true,
+			},
+		}
+		if diff := cmp.Diff(wantUnits, ign.Systemd.Units[1:]); diff != "" {
+			t.Fatalf("Units mismatch (-want +got):\n%s", diff)
+		}
+
+		wantLinks := []types.Link{
+			{
+				Node: types.Node{
+					Filesystem: "root",
+					Path:       "/opt/bin/kubectl",
+				},
+				LinkEmbedded1: types.LinkEmbedded1{
+					Target: "/usr/bin/kubectl",
+				},
+			},
+		}
+		if diff := cmp.Diff(wantLinks, ign.Storage.Links); diff != "" {
+			t.Fatalf("Links mismatch (-want +got):\n%s", diff)
+		}
+
+		wantDirectories := []types.Directory{
+			{
+				Node: types.Node{
+					Filesystem: "root",
+					Path:       "/var/lib/foo",
+					User:       &types.NodeUser{Name: "core"},
+					Group:      &types.NodeGroup{Name: "core"},
+				},
+				DirectoryEmbedded1: types.DirectoryEmbedded1{
+					Mode: ptr.To(488),
+				},
+			},
+		}
+		if diff := cmp.Diff(wantDirectories, ign.Storage.Directories); diff != "" {
+			t.Fatalf("Directories mismatch (-want +got):\n%s", diff)
+		}
+	})
+
 	t.Run("treats warnings as errors in strict mode", func(t *testing.T) {
-		config := &bootstrapv1.ContainerLinuxConfig{
-			Strict:           ptr.To(true),
-			AdditionalConfig: configWithWarning,
+		config := &bootstrapv1.IgnitionSpec{
+			ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+				Strict:           ptr.To(true),
+				AdditionalConfig: configWithWarning,
+			},
 		}
 
 		if _, _, err := clc.Render(&cloudinit.BaseUserData{}, config, "foo"); err == nil {
@@ -611,8 +717,10 @@ func TestRender(t *testing.T) {
 	})
 
 	t.Run("returns warnings", func(t *testing.T) {
-		config := &bootstrapv1.ContainerLinuxConfig{
-			AdditionalConfig: configWithWarning,
+		config := &bootstrapv1.IgnitionSpec{
+			ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+				AdditionalConfig: configWithWarning,
+			},
 		}
 
 		data, warnings, err := clc.Render(&cloudinit.BaseUserData{}, config, "foo")
@@ -630,8 +738,10 @@ func TestRender(t *testing.T) {
 	})
 
 	t.Run("returns Ignition warnings", func(t *testing.T) {
-		config := &bootstrapv1.ContainerLinuxConfig{
-			AdditionalConfig: configWithIgnitionWarning,
+		config := &bootstrapv1.IgnitionSpec{
+			ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+				AdditionalConfig: configWithIgnitionWarning,
+			},
 		}
 
 		data, warnings, err := clc.Render(&cloudinit.BaseUserData{}, config, "foo")
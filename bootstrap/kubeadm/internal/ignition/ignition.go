@@ -23,6 +23,7 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/butane"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/clc"
 )
 
@@ -106,10 +107,13 @@ func NewInitControlPlane(input *ControlPlaneInput) ([]byte, string, error) {
 }
 
 func render(input *cloudinit.BaseUserData, ignitionConfig *bootstrapv1.IgnitionSpec, kubeadmConfig string) ([]byte, string, error) {
-	clcConfig := &bootstrapv1.ContainerLinuxConfig{}
-	if ignitionConfig != nil && ignitionConfig.ContainerLinuxConfig.IsDefined() {
-		clcConfig = &ignitionConfig.ContainerLinuxConfig
+	if ignitionConfig == nil {
+		ignitionConfig = &bootstrapv1.IgnitionSpec{}
 	}
 
-	return clc.Render(input, clcConfig, kubeadmConfig)
+	if ignitionConfig.Butane.IsDefined() {
+		return butane.Render(input, ignitionConfig, kubeadmConfig)
+	}
+
+	return clc.Render(input, ignitionConfig, kubeadmConfig)
 }
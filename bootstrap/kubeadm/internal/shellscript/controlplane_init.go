@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+import (
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+// NewInitControlPlane returns the shell script to be used on a control plane instance.
+func NewInitControlPlane(input *cloudinit.ControlPlaneInput) ([]byte, error) {
+	files := input.AsFiles()
+	files = append(files, input.AdditionalFiles...)
+	files = append(files, bootstrapv1.File{
+		Path:        "/run/kubeadm/kubeadm.yaml",
+		Owner:       "root:root",
+		Permissions: "0640",
+		Content:     "---\n" + input.ClusterConfiguration + "\n---\n" + input.InitConfiguration,
+	})
+
+	data, err := newScriptData(&input.BaseUserData, files, initCommand)
+	if err != nil {
+		return nil, err
+	}
+	return generate("InitControlplane", data)
+}
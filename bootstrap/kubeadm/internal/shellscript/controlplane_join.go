@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+import (
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+// NewJoinControlPlane returns the shell script to be used on a new control plane instance.
+func NewJoinControlPlane(input *cloudinit.ControlPlaneJoinInput) ([]byte, error) {
+	files := input.AsFiles()
+	files = append(files, input.AdditionalFiles...)
+	files = append(files, bootstrapv1.File{
+		Path:        "/run/kubeadm/kubeadm-join-config.yaml",
+		Owner:       "root:root",
+		Permissions: "0640",
+		Content:     input.JoinConfiguration,
+	})
+
+	data, err := newScriptData(&input.BaseUserData, files, joinCommand)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate bootstrap script for machine joining control plane")
+	}
+
+	userData, err := generate("JoinControlplane", data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate bootstrap script for machine joining control plane")
+	}
+	return userData, nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+const (
+	fsSetupTemplate = `{{ define "fs_setup" -}}
+{{- if . }}
+
+# fs_setup
+capi_fs_setup() {
+{{- range . }}
+  format_fs {{ Quote .Device }} {{ Quote .Partition }} {{ Quote .Filesystem }} {{ Quote .Label }} {{ .Overwrite }} {{ Quote .ReplaceFS }}
+  {{- if .ExtraOpts }} {{ QuoteAll .ExtraOpts }}{{ end }}
+{{- end }}
+}
+run_step fs_setup capi_fs_setup
+{{- end -}}
+{{- end -}}
+`
+)
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+const (
+	// helpersTemplate defines the shell functions used by the other sections of the script.
+	helpersTemplate = `{{ define "helpers" }}
+# run_step runs a step of the bootstrap only once, recording its completion in the state directory.
+run_step() {
+  if [ -e "${CAPI_STATE_DIR}/steps/$1" ]; then
+    return 0
+  fi
+  "$2"
+  mkdir -p "${CAPI_STATE_DIR}/steps"
+  touch "${CAPI_STATE_DIR}/steps/$1"
+}
+
+# write_file <path> <owner> <permissions> <append> writes the base64 encoded content read from stdin to a file.
+write_file() {
+  mkdir -p "$(dirname "$1")"
+  if [ "$4" = "true" ]; then
+    base64 -d >> "$1"
+  else
+    base64 -d > "$1.capi-tmp"
+    mv -f "$1.capi-tmp" "$1"
+  fi
+  chown "$2" "$1"
+  chmod "$3" "$1"
+}
+
+# partition_disk <device> <table type> <overwrite> creates a single partition spanning the whole device.
+partition_disk() {
+  if [ "$3" != "true" ] && { sfdisk -d "$1" >/dev/null 2>&1 || blkid "$1" >/dev/null 2>&1; }; then
+    echo "skipping partitioning of $1: device is already in use"
+    return 0
+  fi
+  printf ',,\n' | sfdisk --force --wipe always --label "$2" "$1"
+  partprobe "$1" >/dev/null 2>&1 || true
+  udevadm settle >/dev/null 2>&1 || true
+}
+
+# resolve_partition <device> <partition> prints the device to use for a filesystem.
+resolve_partition() {
+  case "$2" in
+    ""|none)
+      echo "$1"
+      ;;
+    auto|any|auto\|any)
+      part=$(lsblk -lnpo NAME,TYPE "$1" 2>/dev/null | awk '$2 == "part" { print $1; exit }')
+      echo "${part:-$1}"
+      ;;
+    *)
+      case "$1" in
+        *[0-9]) echo "$1p$2" ;;
+        *) echo "$1$2" ;;
+      esac
+      ;;
+  esac
+}
+
+# format_fs <device> <partition> <filesystem> <label> <overwrite> <replace fs> [extra opts...] creates a filesystem.
+format_fs() {
+  dev=$(resolve_partition "$1" "$2")
+  fstype=$3
+  label=$4
+  overwrite=$5
+  replacefs=$6
+  shift 6
+  existing=$(blkid -o value -s TYPE "${dev}" 2>/dev/null || true)
+  if [ -n "${existing}" ] && [ "${overwrite}" != "true" ] && [ "${existing}" != "${replacefs}" ]; then
+    echo "skipping creation of filesystem on ${dev}: found existing ${existing} filesystem"
+    return 0
+  fi
+  if [ -n "${label}" ]; then
+    case "${fstype}" in
+      vfat|fat) set -- -n "${label}" "$@" ;;
+      *) set -- -L "${label}" "$@" ;;
+    esac
+  fi
+  case "${fstype}" in
+    swap) mkswap -f "$@" "${dev}" ;;
+    ext2|ext3|ext4) "mkfs.${fstype}" -F "$@" "${dev}" ;;
+    xfs|btrfs) "mkfs.${fstype}" -f "$@" "${dev}" ;;
+    *) "mkfs.${fstype}" "$@" "${dev}" ;;
+  esac
+}
+
+# add_mount <spec> <dir> <type> <options> <freq> <passno> adds an entry to /etc/fstab, if missing.
+add_mount() {
+  if [ "$3" != "swap" ]; then
+    mkdir -p "$2"
+  fi
+  if ! awk -v spec="$1" -v dir="$2" '$1 == spec && $2 == dir { found = 1 } END { exit !found }' /etc/fstab 2>/dev/null; then
+    printf '%s\t%s\t%s\t%s\t%s\t%s\n' "$@" >> /etc/fstab
+  fi
+}
+
+# ensure_group <name> creates a group, if missing.
+ensure_group() {
+  if ! getent group "$1" >/dev/null 2>&1; then
+    groupadd "$1"
+  fi
+}
+
+# add_sudoers <user> <rule> grants sudo rights to a user.
+add_sudoers() {
+  mkdir -p /etc/sudoers.d
+  printf '%s %s\n' "$1" "$2" > "/etc/sudoers.d/90-cluster-api-$1"
+  chmod 0440 "/etc/sudoers.d/90-cluster-api-$1"
+}
+
+# add_authorized_key <user> <key> adds an ssh authorized key to a user, if missing.
+add_authorized_key() {
+  home=$(getent passwd "$1" | cut -d: -f6)
+  mkdir -p "${home}/.ssh"
+  touch "${home}/.ssh/authorized_keys"
+  if ! grep -qxF "$2" "${home}/.ssh/authorized_keys"; then
+    printf '%s\n' "$2" >> "${home}/.ssh/authorized_keys"
+  fi
+  chmod 0700 "${home}/.ssh"
+  chmod 0600 "${home}/.ssh/authorized_keys"
+  chown -R "$1:$(id -gn "$1")" "${home}/.ssh"
+}
+
+# restart_service <name...> enables and restarts the first of the given services that exists.
+restart_service() {
+  for svc in "$@"; do
+    if systemctl cat "${svc}.service" >/dev/null 2>&1; then
+      systemctl enable "${svc}.service"
+      systemctl restart "${svc}.service"
+      return 0
+    fi
+  done
+}
+
+# configure_ntp [servers...] configures the first available NTP client among chrony, ntpd and systemd-timesyncd.
+configure_ntp() {
+  if command -v chronyd >/dev/null 2>&1; then
+    if [ "$#" -gt 0 ]; then
+      conf=/etc/chrony.conf
+      if [ -d /etc/chrony ]; then
+        conf=/etc/chrony/chrony.conf
+      fi
+      {
+        for server in "$@"; do
+          echo "server ${server} iburst"
+        done
+        echo "driftfile /var/lib/chrony/drift"
+        echo "makestep 1.0 3"
+        echo "rtcsync"
+      } > "${conf}"
+    fi
+    restart_service chronyd chrony
+  elif command -v ntpd >/dev/null 2>&1; then
+    if [ "$#" -gt 0 ]; then
+      {
+        for server in "$@"; do
+          echo "server ${server} iburst"
+        done
+        echo "driftfile /var/lib/ntp/drift"
+        echo "restrict default nomodify nopeer noquery notrap limited kod"
+        echo "restrict 127.0.0.1"
+        echo "restrict [::1]"
+      } > /etc/ntp.conf
+    fi
+    restart_service ntpd ntp
+  else
+    if [ "$#" -gt 0 ]; then
+      mkdir -p /etc/systemd/timesyncd.conf.d
+      printf '[Time]\nNTP=%s\n' "$*" > /etc/systemd/timesyncd.conf.d/cluster-api.conf
+    fi
+    restart_service systemd-timesyncd
+  fi
+}
+{{- end -}}
+`
+)
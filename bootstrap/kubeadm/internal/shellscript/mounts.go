@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+const (
+	mountsTemplate = `{{ define "mounts" -}}
+{{- if . }}
+
+# mounts
+capi_mounts() {
+{{- range . }}
+  add_mount {{ Quote .Spec }} {{ Quote .Dir }} {{ Quote .Type }} {{ Quote .Options }} {{ Quote .Freq }} {{ Quote .Passno }}
+{{- end }}
+  mount -a || echo "failed to mount filesystems" >&2
+  swapon -a || echo "failed to activate swap" >&2
+}
+run_step mounts capi_mounts
+{{- end -}}
+{{- end -}}
+`
+)
@@ -0,0 +1,326 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shellscript generates bootstrap data as a self-contained POSIX shell script, for nodes that are not
+// running cloud-init or Ignition.
+package shellscript
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+const (
+	initCommand = "kubeadm init --config /run/kubeadm/kubeadm.yaml %s"
+	joinCommand = "kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml %s"
+	// sentinelFileCommand writes a file to /run/cluster-api to signal successful Kubernetes bootstrapping.
+	sentinelFileCommand = "mkdir -p /run/cluster-api && echo success > /run/cluster-api/bootstrap-success.complete"
+
+	defaultFileOwner       = "root:root"
+	defaultFilePermissions = "0644"
+
+	// Defaults for mount entries, as applied by cloud-init.
+	defaultMountType    = "auto"
+	defaultMountOptions = "defaults,nofail"
+	defaultMountFreq    = "0"
+	defaultMountPassno  = "2"
+
+	// scriptTemplate defines the layout of the script; sections are run in the same order cloud-init runs
+	// the corresponding modules: bootcmd, write_files, disk_setup, fs_setup, mounts, users, ntp and runcmd.
+	scriptTemplate = `#!/bin/sh
+# Bootstrap script generated by the Cluster API bootstrap provider kubeadm.
+#
+# The script is idempotent and it is safe to run it on every boot: boot commands are run every time, each
+# of the following steps is run only once, and the script is a no-op once kubeadm has succeeded.
+set -eu
+
+CAPI_STATE_DIR=/var/lib/cluster-api
+CAPI_BOOTSTRAPPED="${CAPI_STATE_DIR}/bootstrapped"
+{{ template "helpers" }}
+{{- template "boot_commands" .BootCommands }}
+
+if [ -e "${CAPI_BOOTSTRAPPED}" ]; then
+  exit 0
+fi
+{{- template "files" .Files }}
+{{- template "disk_setup" .Partitions }}
+{{- template "fs_setup" .Filesystems }}
+{{- template "mounts" .Mounts }}
+{{- template "users" .Users }}
+{{- template "ntp" .NTP }}
+
+# runcmd
+# NOTE: As in cloud-init, commands are run without errexit and a failing command does not stop the script.
+set +e
+{{- template "commands" .PreKubeadmCommands }}
+{{ .KubeadmCommand }}
+kubeadm_rc=$?
+if [ "${kubeadm_rc}" -eq 0 ]; then
+  mkdir -p "${CAPI_STATE_DIR}" && touch "${CAPI_BOOTSTRAPPED}"
+  {{ .SentinelFileCommand }}
+fi
+{{- template "commands" .PostKubeadmCommands }}
+exit "${kubeadm_rc}"
+`
+)
+
+// scriptData is the data used to render the script; compared to cloudinit.BaseUserData, defaults are applied
+// and file contents are decoded, so that templates only have to deal with shell quoting.
+type scriptData struct {
+	BootCommands        []string
+	Files               []scriptFile
+	Partitions          []scriptPartition
+	Filesystems         []scriptFilesystem
+	Mounts              []scriptMount
+	Users               []bootstrapv1.User
+	NTP                 *bootstrapv1.NTP
+	PreKubeadmCommands  []string
+	PostKubeadmCommands []string
+	KubeadmCommand      string
+	SentinelFileCommand string
+}
+
+type scriptFile struct {
+	Path        string
+	Owner       string
+	Permissions string
+	Append      bool
+	// Content is the decoded content of the file, base64 encoded and wrapped for embedding into the script.
+	Content string
+}
+
+type scriptPartition struct {
+	Device    string
+	TableType string
+	Overwrite bool
+}
+
+type scriptFilesystem struct {
+	Device     string
+	Partition  string
+	Filesystem string
+	Label      string
+	Overwrite  bool
+	ReplaceFS  string
+	ExtraOpts  []string
+}
+
+type scriptMount struct {
+	Spec    string
+	Dir     string
+	Type    string
+	Options string
+	Freq    string
+	Passno  string
+}
+
+func newScriptData(input *cloudinit.BaseUserData, files []bootstrapv1.File, kubeadmCommand string) (*scriptData, error) {
+	data := &scriptData{
+		BootCommands:        input.BootCommands,
+		Users:               input.Users,
+		NTP:                 input.NTP,
+		PreKubeadmCommands:  input.PreKubeadmCommands,
+		PostKubeadmCommands: input.PostKubeadmCommands,
+		KubeadmCommand:      strings.TrimSpace(fmt.Sprintf(kubeadmCommand, input.KubeadmVerbosity)),
+		SentinelFileCommand: sentinelFileCommand,
+	}
+
+	for _, f := range files {
+		file, err := toScriptFile(f)
+		if err != nil {
+			return nil, err
+		}
+		data.Files = append(data.Files, file)
+	}
+
+	if input.DiskSetup != nil {
+		for _, p := range input.DiskSetup.Partitions {
+			// Same as in cloud-init, partitioning is skipped unless a layout is requested.
+			if p.Layout == nil || !*p.Layout {
+				continue
+			}
+			tableType := "dos"
+			if p.TableType == "gpt" {
+				tableType = "gpt"
+			}
+			data.Partitions = append(data.Partitions, scriptPartition{
+				Device:    p.Device,
+				TableType: tableType,
+				Overwrite: p.Overwrite != nil && *p.Overwrite,
+			})
+		}
+		for _, fs := range input.DiskSetup.Filesystems {
+			data.Filesystems = append(data.Filesystems, scriptFilesystem{
+				Device:     fs.Device,
+				Partition:  fs.Partition,
+				Filesystem: fs.Filesystem,
+				Label:      fs.Label,
+				Overwrite:  fs.Overwrite != nil && *fs.Overwrite,
+				ReplaceFS:  fs.ReplaceFS,
+				ExtraOpts:  fs.ExtraOpts,
+			})
+		}
+	}
+
+	for i, m := range input.Mounts {
+		mount, err := toScriptMount(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid mount at index %d", i)
+		}
+		data.Mounts = append(data.Mounts, mount)
+	}
+
+	return data, nil
+}
+
+func toScriptFile(f bootstrapv1.File) (scriptFile, error) {
+	content := []byte(f.Content)
+	if f.Encoding == bootstrapv1.Base64 || f.Encoding == bootstrapv1.GzipBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(f.Content))
+		if err != nil {
+			return scriptFile{}, errors.Wrapf(err, "failed to decode content of file %q", f.Path)
+		}
+		content = decoded
+	}
+	if f.Encoding == bootstrapv1.Gzip || f.Encoding == bootstrapv1.GzipBase64 {
+		r, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return scriptFile{}, errors.Wrapf(err, "failed to decompress content of file %q", f.Path)
+		}
+		decompressed, err := io.ReadAll(r)
+		if err != nil {
+			return scriptFile{}, errors.Wrapf(err, "failed to decompress content of file %q", f.Path)
+		}
+		content = decompressed
+	}
+
+	file := scriptFile{
+		Path:        f.Path,
+		Owner:       f.Owner,
+		Permissions: f.Permissions,
+		Append:      f.Append != nil && *f.Append,
+		Content:     wrap(base64.StdEncoding.EncodeToString(content), 76),
+	}
+	if file.Owner == "" {
+		file.Owner = defaultFileOwner
+	}
+	if file.Permissions == "" {
+		file.Permissions = defaultFilePermissions
+	}
+	return file, nil
+}
+
+// toScriptMount applies the same defaults and device name handling as the cloud-init mounts module.
+func toScriptMount(m bootstrapv1.MountPoints) (scriptMount, error) {
+	if len(m) < 2 {
+		return scriptMount{}, errors.New("a mount requires at least a device and a mount point")
+	}
+	if len(m) > 6 {
+		return scriptMount{}, errors.New("a mount can have at most 6 fields")
+	}
+
+	fields := append([]string{}, m...)
+	defaults := []string{"", "", defaultMountType, defaultMountOptions, defaultMountFreq, defaultMountPassno}
+	for i := len(fields); i < len(defaults); i++ {
+		fields = append(fields, defaults[i])
+	}
+
+	spec := fields[0]
+	if !strings.HasPrefix(spec, "/") && !strings.Contains(spec, "=") {
+		spec = "/dev/" + spec
+	}
+
+	return scriptMount{
+		Spec:    spec,
+		Dir:     fields[1],
+		Type:    fields[2],
+		Options: fields[3],
+		Freq:    fields[4],
+		Passno:  fields[5],
+	}, nil
+}
+
+func wrap(s string, width int) string {
+	var b strings.Builder
+	for len(s) > width {
+		b.WriteString(s[:width])
+		b.WriteString("\n")
+		s = s[width:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func generate(kind string, data *scriptData) ([]byte, error) {
+	tm := template.New(kind).Funcs(defaultTemplateFuncMap)
+	if _, err := tm.Parse(helpersTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse helpers template")
+	}
+
+	if _, err := tm.Parse(bootCommandsTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse boot commands template")
+	}
+
+	if _, err := tm.Parse(commandsTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse commands template")
+	}
+
+	if _, err := tm.Parse(filesTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse files template")
+	}
+
+	if _, err := tm.Parse(diskSetupTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse disk setup template")
+	}
+
+	if _, err := tm.Parse(fsSetupTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse fs setup template")
+	}
+
+	if _, err := tm.Parse(mountsTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse mounts template")
+	}
+
+	if _, err := tm.Parse(usersTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse users template")
+	}
+
+	if _, err := tm.Parse(ntpTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse ntp template")
+	}
+
+	t, err := tm.Parse(scriptTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s template", kind)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return nil, errors.Wrapf(err, "failed to generate %s template", kind)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the shell script tests")
+
+func baseUserData() cloudinit.BaseUserData {
+	return cloudinit.BaseUserData{
+		BootCommands:        []string{"echo booting"},
+		PreKubeadmCommands:  []string{"echo 'pre kubeadm'"},
+		PostKubeadmCommands: []string{"echo post kubeadm"},
+		AdditionalFiles: []bootstrapv1.File{
+			{
+				Path:        "/etc/foo.conf",
+				Content:     "foo",
+				Owner:       "foo:foo",
+				Permissions: "0600",
+			},
+			{
+				Path:     "/etc/bar.conf",
+				Content:  "YmFyCg==",
+				Encoding: bootstrapv1.Base64,
+				Append:   ptr.To(true),
+			},
+		},
+		Users: []bootstrapv1.User{
+			{
+				Name:              "capi",
+				Gecos:             "Cluster API",
+				Groups:            "docker, wheel",
+				Shell:             "/bin/bash",
+				Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+				SSHAuthorizedKeys: []string{"ssh-rsa AAAA capi@example.com"},
+			},
+			{
+				Name:         "unlocked",
+				Passwd:       "$6$rounds=4096$salt$hash",
+				LockPassword: ptr.To(false),
+				Inactive:     ptr.To(true),
+			},
+		},
+		NTP: &bootstrapv1.NTP{
+			Servers: []string{"0.pool.ntp.org", "1.pool.ntp.org"},
+		},
+		DiskSetup: &bootstrapv1.DiskSetup{
+			Partitions: []bootstrapv1.Partition{
+				{
+					Device:    "/dev/sdb",
+					Layout:    ptr.To(true),
+					TableType: "gpt",
+				},
+				{
+					Device: "/dev/sdc",
+					Layout: ptr.To(false),
+				},
+			},
+			Filesystems: []bootstrapv1.Filesystem{
+				{
+					Device:     "/dev/sdb",
+					Partition:  "1",
+					Filesystem: "ext4",
+					Label:      "etcd_disk",
+					ExtraOpts:  []string{"-E", "lazy_itable_init=1"},
+				},
+				{
+					Device:     "/dev/sdc",
+					Partition:  "any",
+					Filesystem: "xfs",
+					Label:      "data",
+					Overwrite:  ptr.To(true),
+				},
+			},
+		},
+		Mounts: []bootstrapv1.MountPoints{
+			{"LABEL=etcd_disk", "/var/lib/etcddisk"},
+			{"sdc1", "/var/lib/data", "xfs", "defaults", "0", "0"},
+		},
+		KubeadmVerbosity: "--v=5",
+	}
+}
+
+func certificates() secret.Certificates {
+	return secret.Certificates{
+		&secret.Certificate{
+			Purpose:  secret.ClusterCA,
+			CertFile: "/etc/kubernetes/pki/ca.crt",
+			KeyFile:  "/etc/kubernetes/pki/ca.key",
+			KeyPair:  &certs.KeyPair{Cert: []byte("ca-cert"), Key: []byte("ca-key")},
+		},
+	}
+}
+
+func TestNewNode(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.NodeInput{
+		BaseUserData:      baseUserData(),
+		JoinConfiguration: "apiVersion: kubeadm.k8s.io/v1beta4\nkind: JoinConfiguration\n",
+	}
+
+	out, err := NewNode(input)
+	g.Expect(err).ToNot(HaveOccurred())
+	assertGolden(t, "node.sh", out)
+}
+
+func TestNewInitControlPlane(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.ControlPlaneInput{
+		BaseUserData:         baseUserData(),
+		Certificates:         certificates(),
+		ClusterConfiguration: "apiVersion: kubeadm.k8s.io/v1beta4\nkind: ClusterConfiguration\n",
+		InitConfiguration:    "apiVersion: kubeadm.k8s.io/v1beta4\nkind: InitConfiguration\n",
+	}
+
+	out, err := NewInitControlPlane(input)
+	g.Expect(err).ToNot(HaveOccurred())
+	assertGolden(t, "controlplane_init.sh", out)
+}
+
+func TestNewJoinControlPlane(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.ControlPlaneJoinInput{
+		BaseUserData:      baseUserData(),
+		Certificates:      certificates(),
+		BootstrapToken:    "abcdef.0123456789abcdef",
+		JoinConfiguration: "apiVersion: kubeadm.k8s.io/v1beta4\nkind: JoinConfiguration\n",
+	}
+
+	out, err := NewJoinControlPlane(input)
+	g.Expect(err).ToNot(HaveOccurred())
+	assertGolden(t, "controlplane_join.sh", out)
+}
+
+func TestNewNodeMinimal(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &cloudinit.NodeInput{
+		JoinConfiguration: "apiVersion: kubeadm.k8s.io/v1beta4\nkind: JoinConfiguration\n",
+	}
+
+	out, err := NewNode(input)
+	g.Expect(err).ToNot(HaveOccurred())
+	assertGolden(t, "node_minimal.sh", out)
+}
+
+func TestNewNodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input cloudinit.BaseUserData
+	}{
+		{
+			name: "Fails for invalid base64 content",
+			input: cloudinit.BaseUserData{
+				AdditionalFiles: []bootstrapv1.File{
+					{Path: "/etc/foo", Content: "not base64!", Encoding: bootstrapv1.Base64},
+				},
+			},
+		},
+		{
+			name: "Fails for invalid gzip content",
+			input: cloudinit.BaseUserData{
+				AdditionalFiles: []bootstrapv1.File{
+					{Path: "/etc/foo", Content: "foo", Encoding: bootstrapv1.Gzip},
+				},
+			},
+		},
+		{
+			name: "Fails for mounts without mount point",
+			input: cloudinit.BaseUserData{
+				Mounts: []bootstrapv1.MountPoints{{"/dev/sdb"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := NewNode(&cloudinit.NodeInput{BaseUserData: tt.input})
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+// assertGolden compares the generated script with the golden file in testdata; the golden files can be
+// regenerated by running the tests with the -update flag.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	g := NewWithT(t)
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		g.Expect(os.WriteFile(path, got, 0600)).To(Succeed())
+	}
+
+	want, err := os.ReadFile(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(got)).To(Equal(string(want)))
+
+	// Validate the syntax of the script, if a shell is available.
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		return
+	}
+	cmd := exec.Command(sh, "-n")
+	cmd.Stdin = bytes.NewReader(got)
+	out, err := cmd.CombinedOutput()
+	g.Expect(err).ToNot(HaveOccurred(), string(out))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+const (
+	usersTemplate = `{{ define "users" -}}
+{{- if . }}
+
+# users
+capi_users() {
+{{- range $user := . }}
+{{- if .PrimaryGroup }}
+  ensure_group {{ Quote .PrimaryGroup }}
+{{- end }}
+{{- range SplitList .Groups }}
+  ensure_group {{ Quote . }}
+{{- end }}
+  if ! id {{ Quote .Name }} >/dev/null 2>&1; then
+    useradd -m
+    {{- if .Gecos }} -c {{ Quote .Gecos }}{{ end }}
+    {{- if .HomeDir }} -d {{ Quote .HomeDir }}{{ end }}
+    {{- if .Shell }} -s {{ Quote .Shell }}{{ end }}
+    {{- if .PrimaryGroup }} -g {{ Quote .PrimaryGroup }}{{ end }}
+    {{- if .Groups }} -G {{ Quote (Join (SplitList .Groups) ",") }}{{ end }}
+    {{- if .Passwd }} -p {{ Quote .Passwd }}{{ end }} {{ Quote .Name }}
+  fi
+{{- if not (IsDisabled .LockPassword) }}
+  passwd -l {{ Quote .Name }}
+{{- end }}
+{{- if and .Inactive (not (IsDisabled .Inactive)) }}
+  usermod --expiredate 1 {{ Quote .Name }}
+{{- end }}
+{{- if .Sudo }}
+  add_sudoers {{ Quote .Name }} {{ Quote .Sudo }}
+{{- end }}
+{{- range .SSHAuthorizedKeys }}
+  add_authorized_key {{ Quote $user.Name }} {{ Quote . }}
+{{- end }}
+{{- end }}
+}
+run_step users capi_users
+{{- end -}}
+{{- end -}}
+`
+)
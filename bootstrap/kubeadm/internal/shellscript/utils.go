@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shellscript
+
+import (
+	"strings"
+	"text/template"
+)
+
+var (
+	defaultTemplateFuncMap = template.FuncMap{
+		"Quote":      templateShellQuote,
+		"QuoteAll":   templateShellQuoteAll,
+		"SplitList":  templateSplitList,
+		"Join":       strings.Join,
+		"IsDisabled": templateIsDisabled,
+	}
+)
+
+// templateShellQuote quotes the input so that it is passed verbatim as a single shell word.
+func templateShellQuote(input string) string {
+	return "'" + strings.ReplaceAll(input, "'", `'"'"'`) + "'"
+}
+
+// templateShellQuoteAll quotes each element of the input and joins them with spaces.
+func templateShellQuoteAll(input []string) string {
+	quoted := make([]string, 0, len(input))
+	for _, s := range input {
+		quoted = append(quoted, templateShellQuote(s))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// templateSplitList splits a comma separated list, like the groups of a user, dropping empty elements.
+func templateSplitList(input string) []string {
+	var list []string
+	for _, s := range strings.Split(input, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// templateIsDisabled returns true only if the input is explicitly set to false.
+func templateIsDisabled(input *bool) bool {
+	return input != nil && !*input
+}
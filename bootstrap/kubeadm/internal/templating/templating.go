@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templating implements rendering of KubeadmConfig files and commands as templates.
+package templating
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+// Data is the data that can be used in bootstrap data templates.
+// NOTE: Data is converted to a nested map before rendering, so that it can be consumed in
+// templates using the json field names, e.g. `{{ .machine.name }}`.
+type Data struct {
+	Machine     *Machine    `json:"machine,omitempty"`
+	Cluster     Cluster     `json:"cluster"`
+	IPAddresses []IPAddress `json:"ipAddresses"`
+}
+
+// Machine is the Machine data that can be used in bootstrap data templates.
+type Machine struct {
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	Labels        map[string]string `json:"labels"`
+	FailureDomain string            `json:"failureDomain"`
+}
+
+// Cluster is the Cluster data that can be used in bootstrap data templates.
+type Cluster struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels"`
+	Network   ClusterNetwork    `json:"network"`
+}
+
+// ClusterNetwork is the Cluster network data that can be used in bootstrap data templates.
+type ClusterNetwork struct {
+	Pods          []string `json:"pods"`
+	Services      []string `json:"services"`
+	ServiceDomain string   `json:"serviceDomain"`
+}
+
+// IPAddress is the IPAddress data that can be used in bootstrap data templates.
+type IPAddress struct {
+	Name      string `json:"name"`
+	ClaimName string `json:"claimName"`
+	Address   string `json:"address"`
+	Prefix    int32  `json:"prefix"`
+	Gateway   string `json:"gateway"`
+}
+
+// Input contains the files and commands to be rendered.
+type Input struct {
+	Files               []bootstrapv1.File
+	BootCommands        []string
+	PreKubeadmCommands  []string
+	PostKubeadmCommands []string
+}
+
+// Validate checks that value is a valid template for the given engine.
+func Validate(engine bootstrapv1.BootstrapDataTemplatingEngine, value string) error {
+	_, err := parse(engine, value)
+	return err
+}
+
+// Render renders the content of files and the commands in input using data.
+// The input is not modified, a new Input with the rendered values is returned.
+func Render(engine bootstrapv1.BootstrapDataTemplatingEngine, input *Input, data *Data) (*Input, error) {
+	templateData, err := toTemplateData(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate template data")
+	}
+
+	output := &Input{
+		Files: make([]bootstrapv1.File, 0, len(input.Files)),
+	}
+	for _, file := range input.Files {
+		// Encoded content can't be rendered.
+		if file.Encoding == "" {
+			content, err := render(engine, file.Content, templateData)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to render content of file %q", file.Path)
+			}
+			file.Content = content
+		}
+		output.Files = append(output.Files, file)
+	}
+
+	if output.BootCommands, err = renderCommands(engine, "bootCommands", input.BootCommands, templateData); err != nil {
+		return nil, err
+	}
+	if output.PreKubeadmCommands, err = renderCommands(engine, "preKubeadmCommands", input.PreKubeadmCommands, templateData); err != nil {
+		return nil, err
+	}
+	if output.PostKubeadmCommands, err = renderCommands(engine, "postKubeadmCommands", input.PostKubeadmCommands, templateData); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func renderCommands(engine bootstrapv1.BootstrapDataTemplatingEngine, name string, commands []string, data map[string]interface{}) ([]string, error) {
+	if commands == nil {
+		return nil, nil
+	}
+	rendered := make([]string, 0, len(commands))
+	for i, command := range commands {
+		value, err := render(engine, command, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render %s[%d]", name, i)
+		}
+		rendered = append(rendered, value)
+	}
+	return rendered, nil
+}
+
+func render(engine bootstrapv1.BootstrapDataTemplatingEngine, value string, data map[string]interface{}) (string, error) {
+	// Skip parsing for values that can't contain any action.
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	tpl, err := parse(engine, value)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "failed to execute template")
+	}
+	return buf.String(), nil
+}
+
+func parse(engine bootstrapv1.BootstrapDataTemplatingEngine, value string) (*template.Template, error) {
+	switch engine {
+	case bootstrapv1.GoTemplateBootstrapDataTemplatingEngine:
+		// NOTE: Only hermetic functions are allowed, so rendering does not depend on the environment
+		// of the controller, e.g. env variables or the current time.
+		tpl, err := template.New("tpl").Funcs(sprig.HermeticTxtFuncMap()).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse template")
+		}
+		return tpl, nil
+	default:
+		return nil, errors.Errorf("unknown templating engine %q", engine)
+	}
+}
+
+// toTemplateData converts data into a nested map, so that it can be consumed in templates
+// using the json field names.
+func toTemplateData(data *Data) (map[string]interface{}, error) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	templateData := map[string]interface{}{}
+	if err := json.Unmarshal(dataJSON, &templateData); err != nil {
+		return nil, err
+	}
+	return templateData, nil
+}
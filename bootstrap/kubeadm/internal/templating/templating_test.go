@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templating
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+func TestRender(t *testing.T) {
+	data := &Data{
+		Machine: &Machine{
+			Name:          "machine-1",
+			Namespace:     "default",
+			Labels:        map[string]string{"role": "worker"},
+			FailureDomain: "fd1",
+		},
+		Cluster: Cluster{
+			Name:      "cluster-1",
+			Namespace: "default",
+			Labels:    map[string]string{},
+			Network: ClusterNetwork{
+				Pods:          []string{"192.168.0.0/16"},
+				Services:      []string{"10.128.0.0/12"},
+				ServiceDomain: "cluster.local",
+			},
+		},
+		IPAddresses: []IPAddress{
+			{
+				Name:      "address-1",
+				ClaimName: "claim-1",
+				Address:   "10.0.0.10",
+				Prefix:    24,
+				Gateway:   "10.0.0.1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		input   *Input
+		data    *Data
+		want    *Input
+		wantErr bool
+	}{
+		{
+			name: "Render files and commands",
+			input: &Input{
+				Files: []bootstrapv1.File{
+					{
+						Path:    "/etc/netplan/50-static.yaml",
+						Content: "addresses: [{{ (index .ipAddresses 0).address }}/{{ (index .ipAddresses 0).prefix }}]\ngateway4: {{ (index .ipAddresses 0).gateway }}",
+					},
+					{
+						Path:    "/etc/static",
+						Content: "no actions",
+					},
+				},
+				BootCommands:        []string{"echo {{ .machine.failureDomain }}"},
+				PreKubeadmCommands:  []string{"echo {{ .machine.name }} {{ .machine.labels.role }}"},
+				PostKubeadmCommands: []string{"echo {{ .cluster.name | upper }} {{ index .cluster.network.pods 0 }} {{ .cluster.network.serviceDomain }}"},
+			},
+			data: data,
+			want: &Input{
+				Files: []bootstrapv1.File{
+					{
+						Path:    "/etc/netplan/50-static.yaml",
+						Content: "addresses: [10.0.0.10/24]\ngateway4: 10.0.0.1",
+					},
+					{
+						Path:    "/etc/static",
+						Content: "no actions",
+					},
+				},
+				BootCommands:        []string{"echo fd1"},
+				PreKubeadmCommands:  []string{"echo machine-1 worker"},
+				PostKubeadmCommands: []string{"echo CLUSTER-1 192.168.0.0/16 cluster.local"},
+			},
+		},
+		{
+			name: "Escaped expressions are passed through",
+			input: &Input{
+				PreKubeadmCommands: []string{`echo {{ "{{ ds.meta_data.hostname }}" }}`},
+			},
+			data: data,
+			want: &Input{
+				Files:              []bootstrapv1.File{},
+				PreKubeadmCommands: []string{"echo {{ ds.meta_data.hostname }}"},
+			},
+		},
+		{
+			name: "Content of files with an encoding is not rendered",
+			input: &Input{
+				Files: []bootstrapv1.File{
+					{
+						Path:     "/etc/encoded",
+						Content:  "{{ not a template",
+						Encoding: bootstrapv1.Base64,
+					},
+				},
+			},
+			data: data,
+			want: &Input{
+				Files: []bootstrapv1.File{
+					{
+						Path:     "/etc/encoded",
+						Content:  "{{ not a template",
+						Encoding: bootstrapv1.Base64,
+					},
+				},
+			},
+		},
+		{
+			name: "Fail for missing keys",
+			input: &Input{
+				PreKubeadmCommands: []string{"echo {{ .machine.labels.doesnotexist }}"},
+			},
+			data:    data,
+			wantErr: true,
+		},
+		{
+			name: "Fail for Machine data if the KubeadmConfig is not owned by a Machine",
+			input: &Input{
+				PreKubeadmCommands: []string{"echo {{ .machine.name }}"},
+			},
+			data: &Data{
+				Cluster: data.Cluster,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fail for IPAddresses not bound yet",
+			input: &Input{
+				Files: []bootstrapv1.File{
+					{
+						Path:    "/etc/netplan/50-static.yaml",
+						Content: "addresses: [{{ (index .ipAddresses 0).address }}]",
+					},
+				},
+			},
+			data: &Data{
+				Machine:     data.Machine,
+				Cluster:     data.Cluster,
+				IPAddresses: []IPAddress{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := Render(bootstrapv1.GoTemplateBootstrapDataTemplatingEngine, tt.input, tt.data)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  bootstrapv1.BootstrapDataTemplatingEngine
+		value   string
+		wantErr bool
+	}{
+		{
+			name:   "Valid template",
+			engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+			value:  "{{ .machine.name | upper }}",
+		},
+		{
+			name:    "Invalid template",
+			engine:  bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+			value:   "{{ .machine.name ",
+			wantErr: true,
+		},
+		{
+			name:    "Non-hermetic functions are not allowed",
+			engine:  bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+			value:   `{{ env "HOME" }}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown engine",
+			engine:  "Jinja",
+			value:   "{{ machine.name }}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := Validate(tt.engine, tt.value)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -20,16 +20,31 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/clc"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/templating"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/version"
 )
 
+// minKubeletConfigurationJoinVersion is the min Kubernetes version supporting kubeletConfiguration with kubeadm join,
+// i.e. the min version supporting the kubeletconfiguration patch target.
+var minKubeletConfigurationJoinVersion = semver.MustParse("1.25.0")
+
 func (webhook *KubeadmConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&bootstrapv1.KubeadmConfig{}).
@@ -40,28 +55,32 @@ func (webhook *KubeadmConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta2-kubeadmconfig,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,versions=v1beta2,name=validation.kubeadmconfig.bootstrap.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // KubeadmConfig implements a validation and defaulting webhook for KubeadmConfig.
-type KubeadmConfig struct{}
+type KubeadmConfig struct {
+	// Client is used to read the owner of a KubeadmConfig, to validate settings depending on the Kubernetes version.
+	// NOTE: If Client is not set, validations depending on the Kubernetes version are skipped.
+	Client client.Reader
+}
 
 var _ webhook.CustomValidator = &KubeadmConfig{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *KubeadmConfig) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (webhook *KubeadmConfig) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	c, ok := obj.(*bootstrapv1.KubeadmConfig)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a KubeadmConfig but got a %T", obj))
 	}
 
-	return nil, webhook.validate(c.Spec, c.Name)
+	return nil, webhook.validate(ctx, c)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *KubeadmConfig) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+func (webhook *KubeadmConfig) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
 	newC, ok := newObj.(*bootstrapv1.KubeadmConfig)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a KubeadmConfig but got a %T", newObj))
 	}
 
-	return nil, webhook.validate(newC.Spec, newC.Name)
+	return nil, webhook.validate(ctx, newC)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -69,12 +88,141 @@ func (webhook *KubeadmConfig) ValidateDelete(_ context.Context, _ runtime.Object
 	return nil, nil
 }
 
-func (webhook *KubeadmConfig) validate(c bootstrapv1.KubeadmConfigSpec, name string) error {
+func (webhook *KubeadmConfig) validate(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig) error {
+	c := kubeadmConfig.Spec
 	allErrs := c.Validate(false, field.NewPath("spec"))
+	allErrs = append(allErrs, validateBootstrapDataTemplates(&c, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateIgnitionAdditionalConfig(&c, field.NewPath("spec"))...)
+
+	kubeletConfigurationErrs, err := webhook.validateJoinKubeletConfiguration(ctx, kubeadmConfig, field.NewPath("spec"))
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	allErrs = append(allErrs, kubeletConfigurationErrs...)
 
 	if len(allErrs) == 0 {
 		return nil
 	}
 
-	return apierrors.NewInvalid(bootstrapv1.GroupVersion.WithKind("KubeadmConfig").GroupKind(), name, allErrs)
+	return apierrors.NewInvalid(bootstrapv1.GroupVersion.WithKind("KubeadmConfig").GroupKind(), kubeadmConfig.Name, allErrs)
+}
+
+// validateJoinKubeletConfiguration validates that kubeletConfiguration is not used with kubeadm join for
+// Kubernetes < v1.25, because in this case the kubelet configuration of joining nodes is read from the kubelet-config
+// ConfigMap and kubeletConfiguration can't be applied.
+// NOTE: The Kubernetes version is read from the Machine, MachineSet or MachinePool owning the KubeadmConfig;
+// KubeadmConfigs owned by a KubeadmControlPlane are skipped, because kubeletConfiguration is applied by kubeadm init
+// for the first control plane Machine, and then read by joining control plane Machines from the kubelet-config ConfigMap.
+func (webhook *KubeadmConfig) validateJoinKubeletConfiguration(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, pathPrefix *field.Path) (field.ErrorList, error) {
+	if webhook.Client == nil || !kubeadmConfig.DeletionTimestamp.IsZero() ||
+		!kubeadmConfig.Spec.JoinConfiguration.IsDefined() || !kubeadmConfig.Spec.KubeletConfiguration.IsDefined() {
+		return nil, nil
+	}
+
+	kubernetesVersion, err := webhook.getOwnerKubernetesVersion(ctx, kubeadmConfig)
+	if err != nil || kubernetesVersion == "" {
+		return nil, err
+	}
+
+	parsedVersion, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return nil, nil //nolint:nilerr // Invalid versions are reported by the webhooks of the owner.
+	}
+	if version.Compare(parsedVersion, minKubeletConfigurationJoinVersion, version.WithoutPreReleases()) < 0 {
+		return field.ErrorList{
+			field.Forbidden(pathPrefix.Child("kubeletConfiguration"),
+				fmt.Sprintf("cannot be used with joinConfiguration for Kubernetes version %s, kubeletConfiguration requires Kubernetes >= v%s for joining nodes", kubernetesVersion, minKubeletConfigurationJoinVersion)),
+		}, nil
+	}
+	return nil, nil
+}
+
+// getOwnerKubernetesVersion returns the Kubernetes version of the Machine, MachineSet or MachinePool owning a KubeadmConfig,
+// or an empty string if the KubeadmConfig doesn't have such an owner yet.
+func (webhook *KubeadmConfig) getOwnerKubernetesVersion(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig) (string, error) {
+	for _, ref := range kubeadmConfig.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != clusterv1.GroupVersion.Group {
+			continue
+		}
+
+		var obj client.Object
+		var getVersion func() string
+		switch ref.Kind {
+		case "Machine":
+			machine := &clusterv1.Machine{}
+			obj, getVersion = machine, func() string { return machine.Spec.Version }
+		case "MachineSet":
+			machineSet := &clusterv1.MachineSet{}
+			obj, getVersion = machineSet, func() string { return machineSet.Spec.Template.Spec.Version }
+		case "MachinePool":
+			machinePool := &clusterv1.MachinePool{}
+			obj, getVersion = machinePool, func() string { return machinePool.Spec.Template.Spec.Version }
+		default:
+			continue
+		}
+
+		if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: kubeadmConfig.Namespace, Name: ref.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", errors.Wrapf(err, "failed to get %s %s", ref.Kind, klog.KRef(kubeadmConfig.Namespace, ref.Name))
+		}
+		return getVersion(), nil
+	}
+	return "", nil
+}
+
+// validateBootstrapDataTemplates validates that files content and commands are valid templates,
+// if templating is configured.
+// NOTE: The content of files using contentFrom is only known at reconcile time, and it is validated when rendering.
+func validateBootstrapDataTemplates(c *bootstrapv1.KubeadmConfigSpec, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !feature.Gates.Enabled(feature.KubeadmBootstrapDataTemplating) || !c.BootstrapDataTemplating.IsDefined() {
+		return allErrs
+	}
+
+	engine := c.BootstrapDataTemplating.Engine
+	for i, file := range c.Files {
+		if file.Encoding != "" {
+			continue
+		}
+		if err := templating.Validate(engine, file.Content); err != nil {
+			allErrs = append(allErrs, field.Invalid(pathPrefix.Child("files").Index(i).Child("content"), file.Content, err.Error()))
+		}
+	}
+	for _, commands := range []struct {
+		name     string
+		commands []string
+	}{
+		{name: "bootCommands", commands: c.BootCommands},
+		{name: "preKubeadmCommands", commands: c.PreKubeadmCommands},
+		{name: "postKubeadmCommands", commands: c.PostKubeadmCommands},
+	} {
+		for i, command := range commands.commands {
+			if err := templating.Validate(engine, command); err != nil {
+				allErrs = append(allErrs, field.Invalid(pathPrefix.Child(commands.name).Index(i), command, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateIgnitionAdditionalConfig validates that the additional CLC config can be converted to Ignition.
+// If strict is set, warnings are treated as errors, the same way as when the bootstrap data is generated.
+func validateIgnitionAdditionalConfig(c *bootstrapv1.KubeadmConfigSpec, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	clcConfig := c.Ignition.ContainerLinuxConfig
+	if c.Format != bootstrapv1.Ignition || clcConfig.AdditionalConfig == "" {
+		return allErrs
+	}
+
+	if _, err := clc.Validate(clcConfig.AdditionalConfig, ptr.Deref(clcConfig.Strict, false)); err != nil {
+		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("ignition", "containerLinuxConfig", "additionalConfig"), clcConfig.AdditionalConfig, err.Error()))
+	}
+
+	return allErrs
 }
@@ -21,11 +21,14 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/feature"
 )
 
@@ -33,9 +36,10 @@ var ctx = ctrl.SetupSignalHandler()
 
 func TestKubeadmConfigValidate(t *testing.T) {
 	cases := map[string]struct {
-		in                    *bootstrapv1.KubeadmConfig
-		enableIgnitionFeature bool
-		expectErr             bool
+		in                          *bootstrapv1.KubeadmConfig
+		enableDataTemplatingFeature bool
+		enableShellScriptFeature    bool
+		expectErr                   bool
 	}{
 		"valid content": {
 			in: &bootstrapv1.KubeadmConfig{
@@ -253,7 +257,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"Ignition field is set, format is not Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -270,7 +273,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"Ignition field is not set, format is Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -282,7 +284,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 		},
 		"format is Ignition, user is inactive": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -300,7 +301,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"format is Ignition, non-GPT partition configured": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -319,7 +319,7 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
-		"feature gate disabled, format is Ignition": {
+		"format is Ignition": {
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -329,9 +329,24 @@ func TestKubeadmConfigValidate(t *testing.T) {
 					Format: bootstrapv1.Ignition,
 				},
 			},
-			expectErr: true,
 		},
-		"feature gate disabled, Ignition field is set": {
+		"valid Ignition additionalConfig": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+							AdditionalConfig: "storage:\n  directories:\n  - path: /foo\n    filesystem: root\n",
+						},
+					},
+				},
+			},
+		},
+		"invalid Ignition additionalConfig": {
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -348,8 +363,271 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"Ignition additionalConfig with warnings in strict mode": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+							AdditionalConfig: "storage:\n  files:\n  - path: /foo\n    contents:\n      inline: foo\n",
+							Strict:           ptr.To(true),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition additionalConfig with warnings": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+							AdditionalConfig: "storage:\n  files:\n  - path: /foo\n    contents:\n      inline: foo\n",
+						},
+					},
+				},
+			},
+		},
+		"valid Ignition butane configuration": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Files: []bootstrapv1.File{
+						{
+							Path:    "/etc/foo",
+							Content: "foo",
+						},
+					},
+					Ignition: bootstrapv1.IgnitionSpec{
+						Butane: bootstrapv1.ButaneConfig{
+							Variant: bootstrapv1.FlatcarButaneVariant,
+						},
+						Systemd: bootstrapv1.IgnitionSystemd{
+							Units: []bootstrapv1.IgnitionSystemdUnit{
+								{
+									Name: "kubeadm.service",
+									Dropins: []bootstrapv1.IgnitionSystemdDropin{
+										{
+											Name:     "10-override.conf",
+											Contents: "[Service]\nTimeoutStartSec=600\n",
+										},
+									},
+								},
+							},
+						},
+						Storage: bootstrapv1.IgnitionStorage{
+							Links: []bootstrapv1.IgnitionLink{
+								{
+									Path:   "/opt/bin/kubectl",
+									Target: "/usr/bin/kubectl",
+								},
+							},
+							Directories: []bootstrapv1.IgnitionDirectory{
+								{
+									Path:        "/var/lib/foo",
+									Permissions: "0750",
+								},
+							},
+						},
+						KernelArguments: bootstrapv1.IgnitionKernelArguments{
+							ShouldExist: []string{"systemd.unified_cgroup_hierarchy=1"},
+						},
+					},
+				},
+			},
+		},
+		"Ignition butane and containerLinuxConfig are both set": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Butane: bootstrapv1.ButaneConfig{
+							Variant: bootstrapv1.FlatcarButaneVariant,
+						},
+						ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+							Strict: ptr.To(true),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition kernelArguments without butane": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						KernelArguments: bootstrapv1.IgnitionKernelArguments{
+							ShouldExist: []string{"foo"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition kernelArguments should exist and should not exist": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Butane: bootstrapv1.ButaneConfig{
+							Variant: bootstrapv1.FlatcarButaneVariant,
+						},
+						KernelArguments: bootstrapv1.IgnitionKernelArguments{
+							ShouldExist:    []string{"foo"},
+							ShouldNotExist: []string{"foo"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition systemd unit without type suffix": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Systemd: bootstrapv1.IgnitionSystemd{
+							Units: []bootstrapv1.IgnitionSystemdUnit{
+								{
+									Name:     "foo",
+									Contents: "[Unit]",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition systemd dropin without conf suffix": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Systemd: bootstrapv1.IgnitionSystemd{
+							Units: []bootstrapv1.IgnitionSystemdUnit{
+								{
+									Name: "foo.service",
+									Dropins: []bootstrapv1.IgnitionSystemdDropin{
+										{
+											Name:     "10-override",
+											Contents: "[Service]",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition link conflicting with a file": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Files: []bootstrapv1.File{
+						{
+							Path:    "/opt/bin/kubectl",
+							Content: "foo",
+						},
+					},
+					Ignition: bootstrapv1.IgnitionSpec{
+						Storage: bootstrapv1.IgnitionStorage{
+							Links: []bootstrapv1.IgnitionLink{
+								{
+									Path:   "/opt/bin/kubectl",
+									Target: "/usr/bin/kubectl",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition directory with relative path": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Storage: bootstrapv1.IgnitionStorage{
+							Directories: []bootstrapv1.IgnitionDirectory{
+								{
+									Path: "var/lib/foo",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition directory with invalid permissions": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.Ignition,
+					Ignition: bootstrapv1.IgnitionSpec{
+						Storage: bootstrapv1.IgnitionStorage{
+							Directories: []bootstrapv1.IgnitionDirectory{
+								{
+									Path:        "/var/lib/foo",
+									Permissions: "0999",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
 		"replaceFS specified with Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -369,7 +647,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"filesystem partition specified with Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -389,7 +666,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"file encoding gzip specified with Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -407,7 +683,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"file encoding gzip+base64 specified with Ignition": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -425,7 +700,6 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			expectErr: true,
 		},
 		"bootCommands configured with Ignition format": {
-			enableIgnitionFeature: true,
 			in: &bootstrapv1.KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "baz",
@@ -502,14 +776,349 @@ func TestKubeadmConfigValidate(t *testing.T) {
 				},
 			},
 		},
+		"bootstrapDataTemplating not allowed with feature gate disabled": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					BootstrapDataTemplating: bootstrapv1.BootstrapDataTemplating{
+						Engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"valid bootstrapDataTemplating": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					BootstrapDataTemplating: bootstrapv1.BootstrapDataTemplating{
+						Engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+					},
+					Files: []bootstrapv1.File{
+						{
+							Path:    "/etc/hostname",
+							Content: "{{ .machine.name }}",
+						},
+						{
+							Path:     "/etc/encoded",
+							Content:  "{{ not a template",
+							Encoding: bootstrapv1.Base64,
+						},
+					},
+					PreKubeadmCommands: []string{"echo {{ .cluster.name | upper }}"},
+				},
+			},
+			enableDataTemplatingFeature: true,
+		},
+		"invalid bootstrapDataTemplating file content": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					BootstrapDataTemplating: bootstrapv1.BootstrapDataTemplating{
+						Engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+					},
+					Files: []bootstrapv1.File{
+						{
+							Path:    "/etc/hostname",
+							Content: "{{ .machine.name",
+						},
+					},
+				},
+			},
+			enableDataTemplatingFeature: true,
+			expectErr:                   true,
+		},
+		"invalid bootstrapDataTemplating command": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					BootstrapDataTemplating: bootstrapv1.BootstrapDataTemplating{
+						Engine: bootstrapv1.GoTemplateBootstrapDataTemplatingEngine,
+					},
+					PostKubeadmCommands: []string{"echo {{ env \"HOME\" }}"},
+				},
+			},
+			enableDataTemplatingFeature: true,
+			expectErr:                   true,
+		},
+		"valid kubeletConfiguration": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						CgroupDriver: bootstrapv1.SystemdCgroupDriver,
+						MaxPods:      ptr.To[int32](200),
+						KubeReserved: []bootstrapv1.KubeletResourceReservation{
+							{Name: "cpu", Quantity: "500m"},
+						},
+						EvictionHard: []bootstrapv1.KubeletEvictionThreshold{
+							{Signal: "memory.available", Value: "100Mi"},
+							{Signal: "nodefs.available", Value: "10%"},
+						},
+						EvictionSoft: []bootstrapv1.KubeletEvictionThreshold{
+							{Signal: "memory.available", Value: "500Mi"},
+						},
+						EvictionSoftGracePeriod: []bootstrapv1.KubeletEvictionGracePeriod{
+							{Signal: "memory.available", PeriodSeconds: ptr.To[int32](90)},
+						},
+						ImageGCHighThresholdPercent:            ptr.To[int32](85),
+						ImageGCLowThresholdPercent:             ptr.To[int32](80),
+						SerializeImagePulls:                    ptr.To(false),
+						MaxParallelImagePulls:                  ptr.To[int32](5),
+						ContainerLogMaxSize:                    "10Mi",
+						ShutdownGracePeriodSeconds:             ptr.To[int32](30),
+						ShutdownGracePeriodCriticalPodsSeconds: ptr.To[int32](10),
+					},
+				},
+			},
+		},
+		"invalid kubeletConfiguration reserved quantity": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						SystemReserved: []bootstrapv1.KubeletResourceReservation{
+							{Name: "memory", Quantity: "1 gigabyte"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration with a file colliding with the generated patch file": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						MaxPods: ptr.To[int32](200),
+					},
+					Files: []bootstrapv1.File{
+						{Path: "/etc/kubernetes/patches/kubeletconfiguration+strategic.json", Content: "{}"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration with a file colliding with the generated patch file in the join patches directory": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: bootstrapv1.JoinConfiguration{
+						Patches: bootstrapv1.Patches{Directory: "/etc/patches"},
+					},
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						MaxPods: ptr.To[int32](200),
+					},
+					Files: []bootstrapv1.File{
+						{Path: "/etc/patches/kubeletconfiguration+strategic.json", Content: "{}"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"valid kubeletConfiguration with user provided patch files": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						MaxPods: ptr.To[int32](200),
+					},
+					Files: []bootstrapv1.File{
+						{Path: "/etc/kubernetes/patches/kubeletconfiguration0+strategic.json", Content: "{}"},
+					},
+				},
+			},
+		},
+		"invalid kubeletConfiguration eviction signal": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						EvictionHard: []bootstrapv1.KubeletEvictionThreshold{
+							{Signal: "memory.free", Value: "100Mi"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration eviction percentage": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						EvictionHard: []bootstrapv1.KubeletEvictionThreshold{
+							{Signal: "nodefs.available", Value: "110%"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration soft eviction without grace period": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						EvictionSoft: []bootstrapv1.KubeletEvictionThreshold{
+							{Signal: "memory.available", Value: "500Mi"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration grace period without soft eviction": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						EvictionSoftGracePeriod: []bootstrapv1.KubeletEvictionGracePeriod{
+							{Signal: "memory.available", PeriodSeconds: ptr.To[int32](90)},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration image gc thresholds": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						ImageGCHighThresholdPercent: ptr.To[int32](80),
+						ImageGCLowThresholdPercent:  ptr.To[int32](85),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration parallel image pulls with serialized image pulls": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						MaxParallelImagePulls: ptr.To[int32](5),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid kubeletConfiguration shutdown grace period for critical pods": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						ShutdownGracePeriodSeconds:             ptr.To[int32](10),
+						ShutdownGracePeriodCriticalPodsSeconds: ptr.To[int32](30),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"shell-script format with feature gate disabled": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.ShellScript,
+				},
+			},
+			expectErr: true,
+		},
+		"shell-script format": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.ShellScript,
+				},
+			},
+			enableShellScriptFeature: true,
+		},
+		"shell-script format with ignition configuration": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Format: bootstrapv1.ShellScript,
+					Ignition: bootstrapv1.IgnitionSpec{
+						ContainerLinuxConfig: bootstrapv1.ContainerLinuxConfig{
+							AdditionalConfig: "storage: {}",
+						},
+					},
+				},
+			},
+			enableShellScriptFeature: true,
+			expectErr:                true,
+		},
 	}
 
 	for name, tt := range cases {
 		t.Run(name, func(t *testing.T) {
-			if tt.enableIgnitionFeature {
-				// NOTE: KubeadmBootstrapFormatIgnition feature flag is disabled by default.
+			if tt.enableDataTemplatingFeature {
+				// NOTE: KubeadmBootstrapDataTemplating feature flag is disabled by default.
+				// Enabling the feature flag temporarily for this test.
+				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapDataTemplating, true)
+			}
+			if tt.enableShellScriptFeature {
+				// NOTE: KubeadmBootstrapFormatShellScript feature flag is disabled by default.
 				// Enabling the feature flag temporarily for this test.
-				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)
+				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatShellScript, true)
 			}
 			g := NewWithT(t)
 
@@ -533,3 +1142,97 @@ func TestKubeadmConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeadmConfigValidateJoinKubeletConfiguration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	machineSet := func(name, version string) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec: clusterv1.MachineSetSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{Version: version},
+				},
+			},
+		}
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-1-24", Namespace: metav1.NamespaceDefault},
+		Spec:       clusterv1.MachineSpec{Version: "v1.24.17"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		machineSet("ms-1-24", "v1.24.17"),
+		machineSet("ms-1-25", "v1.25.0"),
+		machine,
+	).Build()
+
+	kubeadmConfig := func(ownerKind, ownerName string, joinConfiguration bool) *bootstrapv1.KubeadmConfig {
+		c := &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "config",
+				Namespace: metav1.NamespaceDefault,
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: clusterv1.GroupVersion.String(), Kind: ownerKind, Name: ownerName},
+				},
+			},
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+					MaxPods: ptr.To[int32](200),
+				},
+			},
+		}
+		if joinConfiguration {
+			c.Spec.JoinConfiguration.NodeRegistration.Name = "node"
+		}
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		in        *bootstrapv1.KubeadmConfig
+		expectErr bool
+	}{
+		{
+			name:      "reject joinConfiguration with kubeletConfiguration for Kubernetes < v1.25 (MachineSet owner)",
+			in:        kubeadmConfig("MachineSet", "ms-1-24", true),
+			expectErr: true,
+		},
+		{
+			name:      "reject joinConfiguration with kubeletConfiguration for Kubernetes < v1.25 (Machine owner)",
+			in:        kubeadmConfig("Machine", "machine-1-24", true),
+			expectErr: true,
+		},
+		{
+			name: "allow joinConfiguration with kubeletConfiguration for Kubernetes >= v1.25",
+			in:   kubeadmConfig("MachineSet", "ms-1-25", true),
+		},
+		{
+			name: "allow initConfiguration with kubeletConfiguration for Kubernetes < v1.25",
+			in:   kubeadmConfig("MachineSet", "ms-1-24", false),
+		},
+		{
+			name: "allow joinConfiguration with kubeletConfiguration if the owner is not found",
+			in:   kubeadmConfig("MachineSet", "does-not-exist", true),
+		},
+		{
+			name: "allow joinConfiguration with kubeletConfiguration if the KubeadmConfig is owned by a KubeadmControlPlane",
+			in:   kubeadmConfig("KubeadmControlPlane", "kcp", true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &KubeadmConfig{Client: fakeClient}
+
+			_, err := webhook.ValidateCreate(ctx, tt.in)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -95,6 +95,8 @@ func (webhook *KubeadmConfigTemplate) validate(r *bootstrapv1.KubeadmConfigTempl
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.Template.Spec.Validate(false, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateBootstrapDataTemplates(&r.Template.Spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateIgnitionAdditionalConfig(&r.Template.Spec, field.NewPath("spec", "template", "spec"))...)
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, r.Template.ObjectMeta.Validate(field.NewPath("spec", "template", "metadata"))...)
 
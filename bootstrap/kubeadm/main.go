@@ -49,6 +49,7 @@ import (
 	bootstrapv1beta1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	kubeadmbootstrapcontrollers "sigs.k8s.io/cluster-api/bootstrap/kubeadm/controllers"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/webhooks"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
@@ -59,6 +60,7 @@ import (
 	bootstrapv1alpha4 "sigs.k8s.io/cluster-api/internal/api/bootstrap/kubeadm/v1alpha4"
 	"sigs.k8s.io/cluster-api/util/apiwarnings"
 	"sigs.k8s.io/cluster-api/util/flags"
+	"sigs.k8s.io/cluster-api/util/tunnel"
 	"sigs.k8s.io/cluster-api/version"
 )
 
@@ -86,6 +88,8 @@ var (
 	webhookCertName             string
 	webhookKeyName              string
 	healthAddr                  string
+	reverseTunnelGatewayAddress string
+	reverseTunnelProxyTokenFile string
 	managerOptions              = flags.ManagerOptions{}
 	logOptions                  = logs.NewOptions()
 	// CABPK specific flags.
@@ -100,6 +104,7 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = apiextensionsv1.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 	_ = bootstrapv1alpha3.AddToScheme(scheme)
 	_ = bootstrapv1alpha4.AddToScheme(scheme)
 	_ = bootstrapv1beta1.AddToScheme(scheme)
@@ -180,6 +185,15 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&healthAddr, "health-addr", ":9440",
 		"The address the health endpoint binds to.")
 
+	fs.StringVar(&reverseTunnelGatewayAddress, "reverse-tunnel-gateway-address", "",
+		"Address (host:port) of the proxy endpoint of the reverse tunnel gateway, used to access workload clusters with the 'cluster.x-k8s.io/reverse-tunnel' annotation. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelProxyTokenFile, "reverse-tunnel-proxy-token-file", "",
+		"Path of the file containing the token used to authenticate with the proxy endpoint of the reverse tunnel gateway; "+
+			"it is required if --reverse-tunnel-gateway-address is set. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -333,6 +347,16 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	var clusterDialer clustercache.ClusterDialer
+	if feature.Gates.Enabled(feature.ClusterReverseTunnel) && reverseTunnelGatewayAddress != "" {
+		proxyToken, err := tunnel.ReadTokenFile(reverseTunnelProxyTokenFile)
+		if err != nil {
+			setupLog.Error(err, "Unable to read reverse tunnel proxy token")
+			os.Exit(1)
+		}
+		clusterDialer = tunnel.NewDialer(mgr.GetClient(), reverseTunnelGatewayAddress, proxyToken).DialContextForCluster
+	}
+
 	clusterCache, err := clustercache.SetupWithManager(ctx, mgr, clustercache.Options{
 		SecretClient: secretCachingClient,
 		Cache:        clustercache.CacheOptions{},
@@ -340,6 +364,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			QPS:       clusterCacheClientQPS,
 			Burst:     clusterCacheClientBurst,
 			UserAgent: remote.DefaultClusterAPIUserAgent(controllerName),
+			Dialer:    clusterDialer,
 			Cache: clustercache.ClientCacheOptions{
 				DisableFor: []client.Object{
 					// Don't cache ConfigMaps & Secrets.
@@ -389,7 +414,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 }
 
 func setupWebhooks(mgr ctrl.Manager) {
-	if err := (&webhooks.KubeadmConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.KubeadmConfig{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KubeadmConfig")
 		os.Exit(1)
 	}
@@ -27,7 +27,7 @@ type KubeadmConfig struct{}
 
 // SetupWebhookWithManager sets up KubeadmConfig webhooks.
 func (webhook *KubeadmConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return (&webhooks.KubeadmConfig{}).SetupWebhookWithManager(mgr)
+	return (&webhooks.KubeadmConfig{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr)
 }
 
 // KubeadmConfigTemplate implements a validating and defaulting webhook for KubeadmConfigTemplate.
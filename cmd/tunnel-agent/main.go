@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// main is the main package for the reverse tunnel agent.
+// The agent runs in a workload cluster (e.g. as a static Pod on control plane Machines) and opens a reverse tunnel
+// to the reverse tunnel gateway running in the management cluster.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/component-base/logs"
+	logsv1 "k8s.io/component-base/logs/api/v1"
+	_ "k8s.io/component-base/logs/json/register"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/util/tunnel"
+)
+
+var (
+	setupLog   = ctrl.Log.WithName("setup")
+	logOptions = logs.NewOptions()
+
+	gatewayAddress   string
+	gatewayCAFile    string
+	clusterNamespace string
+	clusterName      string
+	tokenFile        string
+	targetAddress    string
+)
+
+// InitFlags initializes the flags.
+func InitFlags(fs *pflag.FlagSet) {
+	logsv1.AddFlags(logOptions, fs)
+
+	fs.StringVar(&gatewayAddress, "gateway-address", "",
+		"Address (host:port) of the agent endpoint of the reverse tunnel gateway.")
+
+	fs.StringVar(&gatewayCAFile, "gateway-ca-file", "",
+		"Path of the PEM-encoded CA certificate used to verify the certificate of the reverse tunnel gateway. If unspecified, the system CAs are used.")
+
+	fs.StringVar(&clusterNamespace, "cluster-namespace", "",
+		"Namespace of the Cluster object in the management cluster.")
+
+	fs.StringVar(&clusterName, "cluster-name", "",
+		"Name of the Cluster object in the management cluster.")
+
+	fs.StringVar(&tokenFile, "token-file", "",
+		"Path of the file containing the token used to authenticate with the reverse tunnel gateway; "+
+			"the token must match the token stored in the <cluster-name>-tunnel Secret in the management cluster.")
+
+	fs.StringVar(&targetAddress, "target-address", "127.0.0.1:6443",
+		"Address (host:port) of the API server of the workload cluster.")
+}
+
+func main() {
+	InitFlags(pflag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	if err := logsv1.ValidateAndApply(logOptions, nil); err != nil {
+		setupLog.Error(err, "Unable to start agent")
+		os.Exit(1)
+	}
+	ctrl.SetLogger(klog.Background())
+
+	tlsConfig, err := gatewayTLSConfig()
+	if err != nil {
+		setupLog.Error(err, "Unable to start agent")
+		os.Exit(1)
+	}
+
+	token, err := os.ReadFile(tokenFile) //nolint:gosec // The path of the token file is provided by the user.
+	if err != nil {
+		setupLog.Error(err, "Unable to start agent: failed to read token file")
+		os.Exit(1)
+	}
+
+	agent, err := tunnel.NewAgent(tunnel.AgentOptions{
+		GatewayAddress: gatewayAddress,
+		TLSConfig:      tlsConfig,
+		Cluster:        client.ObjectKey{Namespace: clusterNamespace, Name: clusterName},
+		Token:          strings.TrimSpace(string(token)),
+		TargetAddress:  targetAddress,
+	})
+	if err != nil {
+		setupLog.Error(err, "Unable to start agent")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	setupLog.Info("Starting agent", "gatewayAddress", gatewayAddress, "targetAddress", targetAddress)
+	agent.Run(ctx)
+}
+
+func gatewayTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if gatewayCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	caData, err := os.ReadFile(gatewayCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read gateway CA file")
+	}
+	tlsConfig.RootCAs = x509.NewCertPool()
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(caData) {
+		return nil, errors.Errorf("failed to parse gateway CA file %s", gatewayCAFile)
+	}
+	return tlsConfig, nil
+}
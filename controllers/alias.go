@@ -43,6 +43,7 @@ import (
 	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
 	machinedeploymenttopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machinedeployment"
 	machinesettopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machineset"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // Following types provides access to reconcilers implemented in internal/controllers, thus
@@ -57,6 +58,8 @@ type ClusterReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	Sharder *sharding.Sharder
+
 	RemoteConnectionGracePeriod time.Duration
 }
 
@@ -66,6 +69,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		APIReader:                   r.APIReader,
 		ClusterCache:                r.ClusterCache,
 		WatchFilterValue:            r.WatchFilterValue,
+		Sharder:                     r.Sharder,
 		RemoteConnectionGracePeriod: r.RemoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -76,9 +80,14 @@ type MachineReconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	Sharder *sharding.Sharder
+
 	RemoteConditionsGracePeriod time.Duration
 
 	AdditionalSyncMachineLabels      []*regexp.Regexp
@@ -90,7 +99,9 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Client:                           r.Client,
 		APIReader:                        r.APIReader,
 		ClusterCache:                     r.ClusterCache,
+		RuntimeClient:                    r.RuntimeClient,
 		WatchFilterValue:                 r.WatchFilterValue,
+		Sharder:                          r.Sharder,
 		RemoteConditionsGracePeriod:      r.RemoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      r.AdditionalSyncMachineLabels,
 		AdditionalSyncMachineAnnotations: r.AdditionalSyncMachineAnnotations,
@@ -107,6 +118,8 @@ type MachineSetReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	Sharder *sharding.Sharder
 }
 
 func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -116,6 +129,7 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		ClusterCache:     r.ClusterCache,
 		PreflightChecks:  r.PreflightChecks,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -126,6 +140,8 @@ type MachineDeploymentReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	Sharder *sharding.Sharder
 }
 
 func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -133,6 +149,7 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -143,6 +160,8 @@ type MachineHealthCheckReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	Sharder *sharding.Sharder
 }
 
 func (r *MachineHealthCheckReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -150,6 +169,7 @@ func (r *MachineHealthCheckReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		ClusterCache:     r.ClusterCache,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -165,6 +185,8 @@ type ClusterTopologyReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	Sharder *sharding.Sharder
 }
 
 func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -174,6 +196,7 @@ func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ct
 		ClusterCache:     r.ClusterCache,
 		RuntimeClient:    r.RuntimeClient,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -257,6 +280,9 @@ type ClusterResourceSetReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Sharder is used to apply resources only to Clusters in shards owned by this replica.
+	Sharder *sharding.Sharder
 }
 
 func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, partialSecretCache cache.Cache) error {
@@ -264,6 +290,7 @@ func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		ClusterCache:     r.ClusterCache,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options, partialSecretCache)
 }
 
@@ -290,6 +317,8 @@ type MachinePoolReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	Sharder *sharding.Sharder
 }
 
 func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -298,6 +327,7 @@ func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 		APIReader:        r.APIReader,
 		ClusterCache:     r.ClusterCache,
 		WatchFilterValue: r.WatchFilterValue,
+		Sharder:          r.Sharder,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -32,6 +32,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"sigs.k8s.io/cluster-api/util/certs"
 )
@@ -128,6 +129,10 @@ type clusterAccessorClientConfig struct {
 	// Cache is the cache config defining how the clients that clusterAccessor creates
 	// should interact with the underlying cache.
 	Cache clusterAccessorClientCacheConfig
+
+	// Dialer is used to get the func used to dial the workload cluster.
+	// The dial func is used for the rest.Config, which is also used to create the client and the cache.
+	Dialer ClusterDialer
 }
 
 // clusterAccessorClientCacheConfig is the cache config used for the client that the clusterAccessor creates.
@@ -199,6 +204,25 @@ type clusterAccessorLockedConnectionState struct {
 	// watches is used to track the watches that have been added through the Watch method
 	// of the clusterAccessor. This is important to avoid adding duplicate watches.
 	watches sets.Set[string]
+
+	// watchStates holds the state of the watches that have been added through the Watch method
+	// of the clusterAccessor by watch name. It is used to remove the event handlers of a watch in RemoveWatch.
+	watchStates map[string]*watchState
+
+	// newWatchCache is used to create dedicated caches for watches which cannot use the informers of cache
+	// (e.g. metadata-only watches).
+	newWatchCache newWatchCacheFunc
+
+	// watchCaches are the dedicated caches used by watches by informer key.
+	// Dedicated caches are created lazily when a watch needs them and stopped when no watch uses them anymore.
+	watchCaches map[string]*watchCache
+
+	// sharedInformerWatches are the names of the watches using the informers of cache by informer key.
+	// Informers of cache are removed when no watch uses them anymore, unless the cache has indexes for them.
+	sharedInformerWatches map[string]sets.Set[string]
+
+	// cacheObjects maintains the metric with the number of objects in the informers used by watches.
+	cacheObjects *cacheObjectsMetrics
 }
 
 // clusterAccessorLockedHealthCheckingState holds the health checking state (e.g. lastProbeSuccessTime,
@@ -297,11 +321,16 @@ func (ca *clusterAccessor) Connect(ctx context.Context) (retErr error) {
 		consecutiveFailures:  0,
 	}
 	ca.lockedState.connection = &clusterAccessorLockedConnectionState{
-		restConfig:   connection.RESTConfig,
-		restClient:   connection.RESTClient,
-		cachedClient: connection.CachedClient,
-		cache:        connection.Cache,
-		watches:      sets.Set[string]{},
+		restConfig:            connection.RESTConfig,
+		restClient:            connection.RESTClient,
+		cachedClient:          connection.CachedClient,
+		cache:                 connection.Cache,
+		watches:               sets.Set[string]{},
+		watchStates:           map[string]*watchState{},
+		newWatchCache:         connection.NewWatchCache,
+		watchCaches:           map[string]*watchCache{},
+		sharedInformerWatches: map[string]sets.Set[string]{},
+		cacheObjects:          newCacheObjectsMetrics(ca.cluster),
 	}
 
 	return nil
@@ -324,9 +353,11 @@ func (ca *clusterAccessor) Disconnect(ctx context.Context) {
 	log.Info("Disconnecting")
 
 	// Stopping the cache is non-blocking, so it's okay to do it while holding the lock.
-	// Note: Stopping the cache will also trigger shutdown of all informers that have been added to the cache.
+	// Note: Stopping the cache will also trigger shutdown of all informers that have been added to the cache,
+	// and of all the dedicated caches used by watches.
 	log.V(6).Info("Stopping cache")
 	ca.lockedState.connection.cache.Stop()
+	ca.lockedState.connection.cacheObjects.untrackAll()
 
 	log.Info("Disconnected")
 
@@ -427,6 +458,9 @@ func (ca *clusterAccessor) GetClientCertificatePrivateKey(ctx context.Context) *
 
 // Watch watches a workload cluster for events.
 // Each unique watch (by watcher.Name()) is only added once after a Connect (otherwise we return early).
+// Watches which only watch metadata or a subset of the objects of a Kind use a dedicated informer, which
+// is shared by all watches with the same options and started when the first of them is added.
+// All other watches use the informers of the cache which is also used by the client.
 // During a disconnect existing watches (i.e. informers) are shutdown when stopping the cache.
 // After a re-connect watches will be re-added (assuming the Watch method is called again).
 func (ca *clusterAccessor) Watch(ctx context.Context, watcher Watcher) error {
@@ -457,15 +491,190 @@ func (ca *clusterAccessor) Watch(ctx context.Context, watcher Watcher) error {
 		return nil
 	}
 
-	log.Info(fmt.Sprintf("Creating watch %s for %T", watcher.Name(), watcher.Object()))
-	if err := watcher.Watch(ca.lockedState.connection.cache); err != nil {
+	var options watchCacheOptions
+	if w, ok := watcher.(watcherWithCacheOptions); ok {
+		options = w.watchCacheOptions()
+	}
+	gvk, err := apiutil.GVKForObject(watcher.Object(), ca.config.Scheme)
+	if err != nil {
+		return errors.Wrapf(err, "error creating watch %s for %T", watcher.Name(), watcher.Object())
+	}
+	state := &watchState{
+		informerKey: options.informerKey(gvk),
+		dedicated:   options.dedicated(),
+	}
+
+	var watchedCache cache.Cache = ca.lockedState.connection.cache
+	if state.dedicated {
+		wc, err := ca.getOrCreateWatchCache(ctx, state.informerKey, options)
+		if err != nil {
+			return errors.Wrapf(err, "error creating watch %s for %T", watcher.Name(), watcher.Object())
+		}
+		wc.watches.Insert(watcher.Name())
+		watchedCache = wc.cache
+	} else {
+		if _, ok := ca.lockedState.connection.sharedInformerWatches[state.informerKey]; !ok {
+			ca.lockedState.connection.sharedInformerWatches[state.informerKey] = sets.Set[string]{}
+		}
+		ca.lockedState.connection.sharedInformerWatches[state.informerKey].Insert(watcher.Name())
+	}
+
+	log.Info(fmt.Sprintf("Creating watch %s for %T", watcher.Name(), watcher.Object()), "informer", state.informerKey)
+	if err := watcher.Watch(newWatcherCache(watchedCache, gvk, options, watcher.Object(), state, ca.lockedState.connection.cacheObjects)); err != nil {
+		if state.dedicated {
+			ca.releaseWatchCache(ctx, state.informerKey, watcher.Name())
+		} else {
+			ca.releaseSharedInformer(ctx, state.informerKey, watcher)
+		}
 		return errors.Wrapf(err, "error creating watch %s for %T", watcher.Name(), watcher.Object())
 	}
 
 	ca.lockedState.connection.watches.Insert(watcher.Name())
+	ca.lockedState.connection.watchStates[watcher.Name()] = state
+	return nil
+}
+
+// RemoveWatch removes a watch that has been added with Watch (by watcher.Name()).
+// The event handlers of the watch are removed, and informers are stopped when they are not used by any watch anymore.
+// Informers of the cache used by the client are not stopped if the cache has indexes for them, because indexes
+// cannot be added again to the informer that the client would lazily re-create on the next Get or List call.
+func (ca *clusterAccessor) RemoveWatch(ctx context.Context, watcher Watcher) error {
+	if watcher.Name() == "" {
+		return errors.New("watcher.Name() cannot be empty")
+	}
+
+	// Note: Watches are removed anyway on disconnect.
+	if !ca.Connected(ctx) {
+		return nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	ca.lock(ctx)
+	defer ca.unlock(ctx)
+
+	// Checking connection again while holding the lock, because maybe Disconnect was called since checking above.
+	if ca.lockedState.connection == nil || !ca.lockedState.connection.watches.Has(watcher.Name()) {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Removing watch %s for %T", watcher.Name(), watcher.Object()))
+	ca.lockedState.connection.watches.Delete(watcher.Name())
+	state, ok := ca.lockedState.connection.watchStates[watcher.Name()]
+	if !ok {
+		return nil
+	}
+	delete(ca.lockedState.connection.watchStates, watcher.Name())
+
+	if state.dedicated {
+		// Note: Stopping the cache also stops the event handlers of the watch.
+		defer ca.releaseWatchCache(ctx, state.informerKey, watcher.Name())
+	} else {
+		defer ca.releaseSharedInformer(ctx, state.informerKey, watcher)
+	}
+	if err := state.remove(); err != nil {
+		return errors.Wrapf(err, "error removing watch %s for %T", watcher.Name(), watcher.Object())
+	}
 	return nil
 }
 
+// getOrCreateWatchCache returns the dedicated cache for the given informer key, creating it if necessary.
+// Note: This func must be called while holding the lock.
+func (ca *clusterAccessor) getOrCreateWatchCache(ctx context.Context, informerKey string, options watchCacheOptions) (*watchCache, error) {
+	if wc, ok := ca.lockedState.connection.watchCaches[informerKey]; ok {
+		return wc, nil
+	}
+
+	ctrl.LoggerFrom(ctx).V(6).Info(fmt.Sprintf("Starting dedicated cache for informer %s", informerKey))
+	c, err := ca.lockedState.connection.newWatchCache(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating dedicated cache for informer %s", informerKey)
+	}
+	wc := &watchCache{
+		cache:   c,
+		watches: sets.Set[string]{},
+	}
+	ca.lockedState.connection.watchCaches[informerKey] = wc
+	return wc, nil
+}
+
+// releaseWatchCache removes the watch from the users of the dedicated cache for the given informer key,
+// and stops the cache if it is not used by any watch anymore.
+// Note: This func must be called while holding the lock.
+func (ca *clusterAccessor) releaseWatchCache(ctx context.Context, informerKey, watchName string) {
+	wc, ok := ca.lockedState.connection.watchCaches[informerKey]
+	if !ok {
+		return
+	}
+
+	wc.watches.Delete(watchName)
+	if wc.watches.Len() > 0 {
+		return
+	}
+
+	ctrl.LoggerFrom(ctx).V(6).Info(fmt.Sprintf("Stopping dedicated cache for informer %s", informerKey))
+	wc.cache.Stop()
+	delete(ca.lockedState.connection.watchCaches, informerKey)
+	ca.lockedState.connection.cacheObjects.untrack(informerKey)
+}
+
+// releaseSharedInformer removes the watch from the users of the informer of the cache used by the client for the
+// given informer key, and removes the informer if it is not used by any watch anymore and the cache has no indexes for it.
+// Note: This func must be called while holding the lock.
+func (ca *clusterAccessor) releaseSharedInformer(ctx context.Context, informerKey string, watcher Watcher) {
+	log := ctrl.LoggerFrom(ctx)
+
+	watches, ok := ca.lockedState.connection.sharedInformerWatches[informerKey]
+	if !ok {
+		return
+	}
+
+	watches.Delete(watcher.Name())
+	if watches.Len() > 0 {
+		return
+	}
+	delete(ca.lockedState.connection.sharedInformerWatches, informerKey)
+
+	indexed, err := ca.hasCacheIndexes(watcher.Object())
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Failed to stop informer %s", informerKey))
+		return
+	}
+	if indexed {
+		log.V(6).Info(fmt.Sprintf("Skip stopping informer %s because the cache has indexes for it", informerKey))
+		return
+	}
+
+	log.V(6).Info(fmt.Sprintf("Stopping informer %s", informerKey))
+	if err := ca.lockedState.connection.cache.RemoveInformer(ctx, watcher.Object()); err != nil {
+		log.Error(err, fmt.Sprintf("Failed to stop informer %s", informerKey))
+		return
+	}
+	ca.lockedState.connection.cacheObjects.untrack(informerKey)
+}
+
+// hasCacheIndexes returns true if indexes are added to the cache used by the client for the Kind of obj.
+func (ca *clusterAccessor) hasCacheIndexes(obj client.Object) (bool, error) {
+	if ca.config.Cache == nil {
+		return false, nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, ca.config.Scheme)
+	if err != nil {
+		return false, err
+	}
+	for _, index := range ca.config.Cache.Indexes {
+		indexGVK, err := apiutil.GVKForObject(index.Object, ca.config.Scheme)
+		if err != nil {
+			return false, err
+		}
+		if indexGVK == gvk {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (ca *clusterAccessor) GetHealthCheckingState(ctx context.Context) HealthCheckingState {
 	ca.rLock(ctx)
 	defer ca.rUnlock(ctx)
@@ -42,10 +42,11 @@ import (
 )
 
 type createConnectionResult struct {
-	RESTConfig   *rest.Config
-	RESTClient   *rest.RESTClient
-	CachedClient client.Client
-	Cache        *stoppableCache
+	RESTConfig    *rest.Config
+	RESTClient    *rest.RESTClient
+	CachedClient  client.Client
+	Cache         *stoppableCache
+	NewWatchCache newWatchCacheFunc
 }
 
 func (ca *clusterAccessor) createConnection(ctx context.Context) (*createConnectionResult, error) {
@@ -100,16 +101,17 @@ func (ca *clusterAccessor) createConnection(ctx context.Context) (*createConnect
 	}
 
 	log.V(6).Info("Creating cached client and cache")
-	cachedClient, cache, err := createCachedClient(ctx, ca.cacheCtx, ca.config, restConfig, httpClient, mapper)
+	cachedClient, cache, newWatchCache, err := createCachedClient(ctx, ca.cacheCtx, ca.config, restConfig, httpClient, mapper)
 	if err != nil {
 		return nil, err
 	}
 
 	return &createConnectionResult{
-		RESTConfig:   restConfig,
-		RESTClient:   restClient,
-		CachedClient: cachedClient,
-		Cache:        cache,
+		RESTConfig:    restConfig,
+		RESTClient:    restClient,
+		CachedClient:  cachedClient,
+		Cache:         cache,
+		NewWatchCache: newWatchCache,
 	}, nil
 }
 
@@ -130,6 +132,14 @@ func createRESTConfig(ctx context.Context, clientConfig *clusterAccessorClientCo
 	restConfig.QPS = clientConfig.QPS
 	restConfig.Burst = clientConfig.Burst
 
+	if clientConfig.Dialer != nil {
+		dial, err := clientConfig.Dialer(ctx, cluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating REST config: error getting dialer")
+		}
+		restConfig.Dial = dial
+	}
+
 	return restConfig, nil
 }
 
@@ -212,7 +222,8 @@ func createUncachedClient(scheme *runtime.Scheme, config *rest.Config, httpClien
 }
 
 // createCachedClient creates a cached client for the given cluster, based on the rest.Config.
-func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *clusterAccessorConfig, config *rest.Config, httpClient *http.Client, mapper meta.RESTMapper) (client.Client, *stoppableCache, error) {
+// It also returns a func to create dedicated caches for watches, which are stopped when the cache is stopped.
+func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *clusterAccessorConfig, config *rest.Config, httpClient *http.Client, mapper meta.RESTMapper) (client.Client, *stoppableCache, newWatchCacheFunc, error) {
 	// This config will only be used for List and Watch calls of informers
 	// because we don't want these requests to time out after the regular timeout
 	// of Options.Client.Timeout (default 10s).
@@ -224,7 +235,7 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 	configWith11mTimeout.Timeout = 11 * time.Minute
 	httpClientWith11mTimeout, err := rest.HTTPClientFor(configWith11mTimeout)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "error creating cache: error creating HTTP client")
+		return nil, nil, nil, errors.Wrapf(err, "error creating cache: error creating HTTP client")
 	}
 
 	// Create the cache for the cluster.
@@ -237,13 +248,35 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 	}
 	remoteCache, err := cache.New(configWith11mTimeout, cacheOptions)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "error creating cache")
+		return nil, nil, nil, errors.Wrapf(err, "error creating cache")
 	}
 
 	// Use a context that is independent of the passed in context, so the cache doesn't get stopped
 	// when the passed in context is canceled.
 	cacheCtx, cacheCtxCancel := context.WithCancelCause(cacheCtx)
 
+	// Dedicated caches for watches use a context derived from the context of the cache, so they are
+	// stopped when the cache is stopped.
+	newWatchCache := func(options watchCacheOptions) (*stoppableCache, error) {
+		dedicatedCacheOptions := cacheOptions
+		// Note: ByObject is not used for dedicated caches, objects are selected only by the options of the watches.
+		dedicatedCacheOptions.ByObject = nil
+		dedicatedCacheOptions.DefaultLabelSelector = options.LabelSelector
+		dedicatedCacheOptions.DefaultFieldSelector = options.FieldSelector
+		watchRemoteCache, err := cache.New(configWith11mTimeout, dedicatedCacheOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating cache")
+		}
+
+		watchCacheCtx, watchCacheCtxCancel := context.WithCancelCause(cacheCtx)
+		watchCache := &stoppableCache{
+			Cache:      watchRemoteCache,
+			cancelFunc: watchCacheCtxCancel,
+		}
+		go watchCache.Start(watchCacheCtx) //nolint:errcheck
+		return watchCache, nil
+	}
+
 	// We need to be able to stop the cache's shared informers, so wrap this in a stoppableCache.
 	cache := &stoppableCache{
 		Cache:      remoteCache,
@@ -252,7 +285,7 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 
 	for _, index := range clusterAccessorConfig.Cache.Indexes {
 		if err := cache.IndexField(ctx, index.Object, index.Field, index.ExtractValue); err != nil {
-			return nil, nil, errors.Wrapf(err, "error adding index for field %q to cache", index.Field)
+			return nil, nil, nil, errors.Wrapf(err, "error adding index for field %q to cache", index.Field)
 		}
 	}
 
@@ -268,7 +301,7 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 		},
 	})
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "error creating cached client")
+		return nil, nil, nil, errors.Wrapf(err, "error creating cached client")
 	}
 
 	// Start the cache!
@@ -279,7 +312,7 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 	defer cacheSyncCtxCancel()
 	if !cache.WaitForCacheSync(cacheSyncCtx) {
 		cache.Stop()
-		return nil, nil, fmt.Errorf("error when waiting for cache to sync: %w", cacheSyncCtx.Err())
+		return nil, nil, nil, fmt.Errorf("error when waiting for cache to sync: %w", cacheSyncCtx.Err())
 	}
 
 	// Wrap the cached client with a client that sets timeouts on all Get and List calls
@@ -289,7 +322,7 @@ func createCachedClient(ctx, cacheCtx context.Context, clusterAccessorConfig *cl
 	// It should be reasonable to have Get and List calls timeout within the duration configured in the restConfig.
 	cachedClient = newClientWithTimeout(cachedClient, config.Timeout)
 
-	return cachedClient, cache, nil
+	return cachedClient, cache, newWatchCache, nil
 }
 
 // newClientWithTimeout returns a new client which sets the specified timeout on all Get and List calls.
@@ -17,14 +17,19 @@ limitations under the License.
 package clustercache
 
 import (
+	"context"
+	"net"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
 )
 
 func TestRunningOnWorkloadCluster(t *testing.T) {
@@ -144,3 +149,90 @@ func TestRunningOnWorkloadCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateRESTConfig(t *testing.T) {
+	cluster := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}
+	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(cluster, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test-cluster
+  cluster:
+    server: https://test-cluster:6443
+contexts:
+- name: test-cluster
+  context:
+    cluster: test-cluster
+    user: test-user
+current-context: test-cluster
+users:
+- name: test-user
+  user:
+    token: test-token
+`), metav1.OwnerReference{})
+	c := fake.NewClientBuilder().WithObjects(kubeconfigSecret).Build()
+
+	dialFunc := func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("dial func called")
+	}
+
+	tests := []struct {
+		name         string
+		dialer       ClusterDialer
+		wantDialFunc bool
+		wantErr      bool
+	}{
+		{
+			name:         "should not set a dial func without a Dialer",
+			dialer:       nil,
+			wantDialFunc: false,
+		},
+		{
+			name: "should not set a dial func if the Dialer returns nil",
+			dialer: func(context.Context, client.ObjectKey) (func(ctx context.Context, network, address string) (net.Conn, error), error) {
+				return nil, nil
+			},
+			wantDialFunc: false,
+		},
+		{
+			name: "should set the dial func returned by the Dialer",
+			dialer: func(_ context.Context, key client.ObjectKey) (func(ctx context.Context, network, address string) (net.Conn, error), error) {
+				if key != cluster {
+					return nil, errors.Errorf("unexpected cluster %s", key)
+				}
+				return dialFunc, nil
+			},
+			wantDialFunc: true,
+		},
+		{
+			name: "should fail if the Dialer fails",
+			dialer: func(context.Context, client.ObjectKey) (func(ctx context.Context, network, address string) (net.Conn, error), error) {
+				return nil, errors.New("dialer failed")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			restConfig, err := createRESTConfig(ctx, &clusterAccessorClientConfig{
+				UserAgent: "test-user-agent",
+				Dialer:    tt.dialer,
+			}, c, cluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(restConfig.Host).To(Equal("https://test-cluster:6443"))
+			g.Expect(restConfig.UserAgent).To(Equal("test-user-agent"))
+			if !tt.wantDialFunc {
+				g.Expect(restConfig.Dial).To(BeNil())
+				return
+			}
+			g.Expect(restConfig.Dial).ToNot(BeNil())
+			_, err = restConfig.Dial(ctx, "tcp", "test-cluster:6443")
+			g.Expect(err).To(MatchError("dial func called"))
+		})
+	}
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercache
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// watchCacheOptions are the options of a watch which determine the informer used for the watch.
+type watchCacheOptions struct {
+	// MetadataOnly configures the informer to only store the metadata of objects.
+	MetadataOnly bool
+
+	// LabelSelector restricts the informer to objects matching the selector.
+	LabelSelector labels.Selector
+
+	// FieldSelector restricts the informer to objects matching the selector.
+	FieldSelector fields.Selector
+}
+
+// dedicated returns true if the watch requires a dedicated informer, i.e. it cannot use the informers
+// of the cache that is also used by the client of the clusterAccessor.
+func (o watchCacheOptions) dedicated() bool {
+	return o.MetadataOnly ||
+		(o.LabelSelector != nil && !o.LabelSelector.Empty()) ||
+		(o.FieldSelector != nil && !o.FieldSelector.Empty())
+}
+
+// informerKey returns a key identifying the informer used for a watch with these options for the given GVK.
+// Watches with the same informer key share the same informer.
+func (o watchCacheOptions) informerKey(gvk schema.GroupVersionKind) string {
+	parts := []string{gvk.GroupKind().String()}
+	if o.MetadataOnly {
+		parts = append(parts, "metadataOnly")
+	}
+	if o.LabelSelector != nil && !o.LabelSelector.Empty() {
+		parts = append(parts, "labelSelector="+o.LabelSelector.String())
+	}
+	if o.FieldSelector != nil && !o.FieldSelector.Empty() {
+		parts = append(parts, "fieldSelector="+o.FieldSelector.String())
+	}
+	return strings.Join(parts, ";")
+}
+
+// watcherWithCacheOptions is implemented by Watchers which provide options for the informer used for the watch.
+type watcherWithCacheOptions interface {
+	watchCacheOptions() watchCacheOptions
+}
+
+// newWatchCacheFunc creates and starts a dedicated cache for watches with the given options.
+type newWatchCacheFunc func(options watchCacheOptions) (*stoppableCache, error)
+
+// watchCache is a dedicated cache used by watches which cannot use the informers of the cache
+// that is also used by the client of the clusterAccessor (e.g. metadata-only watches).
+type watchCache struct {
+	// cache is the dedicated cache.
+	cache *stoppableCache
+
+	// watches are the names of the watches using the cache.
+	// The cache is stopped when it is not used by any watch anymore.
+	watches sets.Set[string]
+}
+
+// watchState is the state of a watch that has been added through the Watch method of the clusterAccessor.
+type watchState struct {
+	// informerKey is the key of the informer used by the watch.
+	informerKey string
+
+	// dedicated is true if the watch uses a dedicated watchCache.
+	dedicated bool
+
+	// lock is used to synchronize access to removed and registrations.
+	// Note: Event handlers are added asynchronously by the sources of the watch.
+	lock sync.Mutex
+
+	// removed is true if the watch has been removed.
+	removed bool
+
+	// registrations are the event handlers added by the sources of the watch.
+	registrations []informerRegistration
+}
+
+type informerRegistration struct {
+	informer     cache.Informer
+	registration toolscache.ResourceEventHandlerRegistration
+}
+
+// addRegistration records an event handler added by a source of the watch.
+// If the watch has already been removed, the event handler is removed immediately.
+func (s *watchState) addRegistration(informer cache.Informer, registration toolscache.ResourceEventHandlerRegistration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.removed {
+		return informer.RemoveEventHandler(registration)
+	}
+	s.registrations = append(s.registrations, informerRegistration{informer: informer, registration: registration})
+	return nil
+}
+
+// remove removes all the event handlers added by the sources of the watch.
+func (s *watchState) remove() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removed = true
+	errs := []error{}
+	for _, r := range s.registrations {
+		if err := r.informer.RemoveEventHandler(r.registration); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.registrations = nil
+	return kerrors.NewAggregate(errs)
+}
+
+// watcherCache is the cache passed to Watcher.Watch.
+// It records the event handlers added by the sources of the watch, so they can be removed when the watch
+// is removed, and it ensures the objects in the informers used by the watch are counted.
+// For metadata-only watches it gets metadata-only informers, and it converts the PartialObjectMetadata
+// objects passed to event handlers to objects of the watched Kind.
+type watcherCache struct {
+	cache.Cache
+
+	gvk          schema.GroupVersionKind
+	metadataOnly bool
+	// kind is the type of the objects passed to event handlers for metadata-only watches.
+	kind        reflect.Type
+	state       *watchState
+	objectCount *cacheObjectsMetrics
+}
+
+func newWatcherCache(c cache.Cache, gvk schema.GroupVersionKind, options watchCacheOptions, kind client.Object, state *watchState, objectCount *cacheObjectsMetrics) *watcherCache {
+	wc := &watcherCache{
+		Cache:       c,
+		gvk:         gvk,
+		state:       state,
+		objectCount: objectCount,
+	}
+	if _, ok := kind.(*metav1.PartialObjectMetadata); options.MetadataOnly && !ok {
+		wc.metadataOnly = true
+		wc.kind = reflect.TypeOf(kind).Elem()
+	}
+	return wc
+}
+
+// GetInformer gets the informer for obj, which must be of the kind of the watch.
+func (c *watcherCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	if c.metadataOnly {
+		obj = &metav1.PartialObjectMetadata{}
+		obj.GetObjectKind().SetGroupVersionKind(c.gvk)
+	}
+	informer, err := c.Cache.GetInformer(ctx, obj, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.objectCount.track(c.state.informerKey, informer); err != nil {
+		return nil, err
+	}
+	return &watcherInformer{Informer: informer, cache: c}, nil
+}
+
+// watcherInformer is the informer returned by the watcherCache.
+type watcherInformer struct {
+	cache.Informer
+
+	cache *watcherCache
+}
+
+func (i *watcherInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.Informer.AddEventHandler(i.wrap(handler))
+	if err != nil {
+		return nil, err
+	}
+	return registration, i.cache.state.addRegistration(i.Informer, registration)
+}
+
+func (i *watcherInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.Informer.AddEventHandlerWithResyncPeriod(i.wrap(handler), resyncPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return registration, i.cache.state.addRegistration(i.Informer, registration)
+}
+
+func (i *watcherInformer) AddEventHandlerWithOptions(handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.Informer.AddEventHandlerWithOptions(i.wrap(handler), options)
+	if err != nil {
+		return nil, err
+	}
+	return registration, i.cache.state.addRegistration(i.Informer, registration)
+}
+
+func (i *watcherInformer) wrap(handler toolscache.ResourceEventHandler) toolscache.ResourceEventHandler {
+	if !i.cache.metadataOnly {
+		return handler
+	}
+	return &metadataOnlyEventHandler{handler: handler, kind: i.cache.kind}
+}
+
+// metadataOnlyEventHandler converts PartialObjectMetadata objects to objects of the watched Kind, so
+// event handlers and predicates of metadata-only watches can be implemented for the watched Kind.
+type metadataOnlyEventHandler struct {
+	handler toolscache.ResourceEventHandler
+	kind    reflect.Type
+}
+
+func (h *metadataOnlyEventHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.handler.OnAdd(h.convert(obj), isInInitialList)
+}
+
+func (h *metadataOnlyEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.handler.OnUpdate(h.convert(oldObj), h.convert(newObj))
+}
+
+func (h *metadataOnlyEventHandler) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		tombstone.Obj = h.convert(tombstone.Obj)
+		h.handler.OnDelete(tombstone)
+		return
+	}
+	h.handler.OnDelete(h.convert(obj))
+}
+
+// convert returns an object of the watched Kind with the TypeMeta and ObjectMeta of the PartialObjectMetadata.
+// If the conversion fails the object is returned unchanged, which is then handled as an unexpected object type
+// by the source of the watch.
+func (h *metadataOnlyEventHandler) convert(obj interface{}) interface{} {
+	partialObj, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok {
+		return obj
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(partialObj)
+	if err != nil {
+		return obj
+	}
+
+	converted := reflect.New(h.kind).Interface()
+	if u, ok := converted.(runtime.Unstructured); ok {
+		u.SetUnstructuredContent(content)
+		return converted
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, converted); err != nil {
+		return obj
+	}
+	return converted
+}
+
+// cacheObjectsMetrics maintains the metric with the number of objects in the informers used by watches of a Cluster.
+type cacheObjectsMetrics struct {
+	cluster client.ObjectKey
+
+	// lock is used to synchronize access to informers.
+	lock sync.Mutex
+
+	// informers are the keys of the informers for which objects are counted.
+	informers sets.Set[string]
+}
+
+func newCacheObjectsMetrics(cluster client.ObjectKey) *cacheObjectsMetrics {
+	return &cacheObjectsMetrics{
+		cluster:   cluster,
+		informers: sets.Set[string]{},
+	}
+}
+
+// track starts counting the objects of the informer, unless the objects of the informer are already counted.
+func (m *cacheObjectsMetrics) track(informerKey string, informer cache.Informer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.informers.Has(informerKey) {
+		return nil
+	}
+
+	gauge := cacheObjects.WithLabelValues(m.cluster.Name, m.cluster.Namespace, informerKey)
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) {
+			if !informer.IsStopped() {
+				gauge.Inc()
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			if !informer.IsStopped() {
+				gauge.Dec()
+			}
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "error adding event handler to count objects of informer %s", informerKey)
+	}
+	m.informers.Insert(informerKey)
+	return nil
+}
+
+// untrack deletes the metric for the informer, e.g. after the informer has been stopped.
+func (m *cacheObjectsMetrics) untrack(informerKey string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.informers.Delete(informerKey)
+	cacheObjects.DeleteLabelValues(m.cluster.Name, m.cluster.Namespace, informerKey)
+}
+
+// untrackAll deletes the metrics for all informers, e.g. after the connection to the Cluster has been closed.
+func (m *cacheObjectsMetrics) untrackAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.informers = sets.Set[string]{}
+	cacheObjects.DeletePartialMatch(prometheus.Labels{"cluster_name": m.cluster.Name, "cluster_namespace": m.cluster.Namespace})
+}
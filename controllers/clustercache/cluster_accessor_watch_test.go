@@ -0,0 +1,386 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func TestWatchCacheOptionsInformerKey(t *testing.T) {
+	nodeGVK := corev1.SchemeGroupVersion.WithKind("Node")
+
+	tests := []struct {
+		name          string
+		options       watchCacheOptions
+		wantKey       string
+		wantDedicated bool
+	}{
+		{
+			name:          "no options",
+			options:       watchCacheOptions{},
+			wantKey:       "Node",
+			wantDedicated: false,
+		},
+		{
+			name:          "empty selectors",
+			options:       watchCacheOptions{LabelSelector: labels.Everything(), FieldSelector: fields.Everything()},
+			wantKey:       "Node",
+			wantDedicated: false,
+		},
+		{
+			name:          "metadata only",
+			options:       watchCacheOptions{MetadataOnly: true},
+			wantKey:       "Node;metadataOnly",
+			wantDedicated: true,
+		},
+		{
+			name: "all options",
+			options: watchCacheOptions{
+				MetadataOnly:  true,
+				LabelSelector: labels.SelectorFromSet(labels.Set{"foo": "bar"}),
+				FieldSelector: fields.OneTermEqualSelector("metadata.name", "node-1"),
+			},
+			wantKey:       "Node;metadataOnly;labelSelector=foo=bar;fieldSelector=metadata.name=node-1",
+			wantDedicated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(tt.options.informerKey(nodeGVK)).To(Equal(tt.wantKey))
+			g.Expect(tt.options.dedicated()).To(Equal(tt.wantDedicated))
+		})
+	}
+}
+
+func TestWatchAndRemoveWatch(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	clusterKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}
+	sharedCache := newTestInformers()
+	dedicatedCaches := map[string]*testInformers{}
+	var dedicatedStoppableCaches []*stoppableCache
+
+	accessor := newClusterAccessor(context.Background(), clusterKey, &clusterAccessorConfig{Scheme: scheme})
+	accessor.lockedState.connection = &clusterAccessorLockedConnectionState{
+		cache:       &stoppableCache{Cache: sharedCache, cancelFunc: func(error) {}},
+		watches:     sets.Set[string]{},
+		watchStates: map[string]*watchState{},
+		newWatchCache: func(options watchCacheOptions) (*stoppableCache, error) {
+			c := newTestInformers()
+			dedicatedCaches[options.informerKey(corev1.SchemeGroupVersion.WithKind("Node"))] = c
+			sc := &stoppableCache{Cache: c, cancelFunc: func(error) {}}
+			dedicatedStoppableCaches = append(dedicatedStoppableCaches, sc)
+			return sc, nil
+		},
+		watchCaches:           map[string]*watchCache{},
+		sharedInformerWatches: map[string]sets.Set[string]{},
+		cacheObjects:          newCacheObjectsMetrics(clusterKey),
+	}
+
+	mapped := &mappedObjects{}
+	newWatcher := func(name string, metadataOnly bool, labelSelector labels.Selector) Watcher {
+		return NewWatcher(WatcherOptions{
+			Name:          name,
+			Watcher:       &startingWatcher{},
+			Kind:          &corev1.Node{},
+			EventHandler:  handler.EnqueueRequestsFromMapFunc(mapped.mapFunc(name)),
+			MetadataOnly:  metadataOnly,
+			LabelSelector: labelSelector,
+		})
+	}
+
+	// Watches without options use the shared cache.
+	g.Expect(accessor.Watch(ctx, newWatcher("shared", false, nil))).To(Succeed())
+	g.Expect(accessor.lockedState.connection.watchCaches).To(BeEmpty())
+	g.Expect(sharedCache.requested()).To(ConsistOf(BeAssignableToTypeOf(&corev1.Node{})))
+
+	// Metadata-only watches share a dedicated cache.
+	g.Expect(accessor.Watch(ctx, newWatcher("metadata-1", true, nil))).To(Succeed())
+	g.Expect(accessor.Watch(ctx, newWatcher("metadata-2", true, nil))).To(Succeed())
+	g.Expect(accessor.lockedState.connection.watchCaches).To(HaveLen(1))
+	g.Expect(accessor.lockedState.connection.watchCaches["Node;metadataOnly"].watches.UnsortedList()).To(ConsistOf("metadata-1", "metadata-2"))
+	metadataCache := dedicatedCaches["Node;metadataOnly"]
+	g.Expect(metadataCache.requested()).To(HaveEach(BeAssignableToTypeOf(&metav1.PartialObjectMetadata{})))
+
+	// Watches with a label selector use another dedicated cache.
+	g.Expect(accessor.Watch(ctx, newWatcher("selected", false, labels.SelectorFromSet(labels.Set{"foo": "bar"})))).To(Succeed())
+	g.Expect(accessor.lockedState.connection.watchCaches).To(HaveLen(2))
+	g.Expect(accessor.lockedState.connection.watches.UnsortedList()).To(ConsistOf("shared", "metadata-1", "metadata-2", "selected"))
+
+	// Event handlers of metadata-only watches get objects of the watched Kind, and objects are counted.
+	metadataCache.informer.Add(&metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"foo": "bar"}},
+	})
+	g.Expect(mapped.get()).To(ConsistOf(
+		"metadata-1: *v1.Node node-1",
+		"metadata-2: *v1.Node node-1",
+	))
+	g.Expect(testutil.ToFloat64(cacheObjects.WithLabelValues(clusterKey.Name, clusterKey.Namespace, "Node;metadataOnly"))).To(Equal(1.0))
+
+	// Removing a watch removes its event handlers, but keeps the dedicated cache if it is still used.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("metadata-1", true, nil))).To(Succeed())
+	g.Expect(accessor.lockedState.connection.watches.Has("metadata-1")).To(BeFalse())
+	g.Expect(accessor.lockedState.connection.watchCaches).To(HaveKey("Node;metadataOnly"))
+	g.Expect(dedicatedStoppableCaches[0].stopped).To(BeFalse())
+
+	// Removing the last watch of a dedicated cache stops the cache and deletes the metric.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("metadata-2", true, nil))).To(Succeed())
+	g.Expect(accessor.lockedState.connection.watchCaches).ToNot(HaveKey("Node;metadataOnly"))
+	g.Expect(dedicatedStoppableCaches[0].stopped).To(BeTrue())
+	g.Expect(testutil.CollectAndCount(cacheObjects)).To(Equal(2))
+
+	// Removing a watch that doesn't exist is a no-op.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("metadata-1", true, nil))).To(Succeed())
+
+	// Adding the watch again starts a new dedicated cache.
+	g.Expect(accessor.Watch(ctx, newWatcher("metadata-1", true, nil))).To(Succeed())
+	g.Expect(dedicatedStoppableCaches).To(HaveLen(3))
+	g.Expect(dedicatedStoppableCaches[2].stopped).To(BeFalse())
+
+	// Disconnect deletes all the metrics for the cluster.
+	accessor.Disconnect(ctx)
+	g.Expect(testutil.CollectAndCount(cacheObjects)).To(Equal(0))
+}
+
+func TestRemoveWatchSharedInformers(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	clusterKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}
+	sharedCache := newTestInformers()
+
+	accessor := newClusterAccessor(context.Background(), clusterKey, &clusterAccessorConfig{
+		Scheme: scheme,
+		Cache: &clusterAccessorCacheConfig{
+			Indexes: []CacheOptionsIndex{NodeProviderIDIndex},
+		},
+	})
+	accessor.lockedState.connection = &clusterAccessorLockedConnectionState{
+		cache:                 &stoppableCache{Cache: sharedCache, cancelFunc: func(error) {}},
+		watches:               sets.Set[string]{},
+		watchStates:           map[string]*watchState{},
+		watchCaches:           map[string]*watchCache{},
+		sharedInformerWatches: map[string]sets.Set[string]{},
+		cacheObjects:          newCacheObjectsMetrics(clusterKey),
+	}
+
+	newWatcher := func(name string, kind client.Object) Watcher {
+		return NewWatcher(WatcherOptions{
+			Name:         name,
+			Watcher:      &startingWatcher{},
+			Kind:         kind,
+			EventHandler: &handler.EnqueueRequestForObject{},
+		})
+	}
+
+	g.Expect(accessor.Watch(ctx, newWatcher("configmaps-1", &corev1.ConfigMap{}))).To(Succeed())
+	g.Expect(accessor.Watch(ctx, newWatcher("configmaps-2", &corev1.ConfigMap{}))).To(Succeed())
+	g.Expect(accessor.Watch(ctx, newWatcher("nodes", &corev1.Node{}))).To(Succeed())
+
+	// Removing a watch keeps the informer if it is still used by another watch.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("configmaps-1", &corev1.ConfigMap{}))).To(Succeed())
+	g.Expect(sharedCache.removed()).To(BeEmpty())
+
+	// Removing the last watch of an informer removes the informer.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("configmaps-2", &corev1.ConfigMap{}))).To(Succeed())
+	g.Expect(sharedCache.removed()).To(ConsistOf(BeAssignableToTypeOf(&corev1.ConfigMap{})))
+	g.Expect(accessor.lockedState.connection.sharedInformerWatches).ToNot(HaveKey("ConfigMap"))
+
+	// Informers with indexes are never removed.
+	g.Expect(accessor.RemoveWatch(ctx, newWatcher("nodes", &corev1.Node{}))).To(Succeed())
+	g.Expect(sharedCache.removed()).To(HaveLen(1))
+	g.Expect(accessor.lockedState.connection.sharedInformerWatches).To(BeEmpty())
+}
+
+func TestMetadataOnlyEventHandler(t *testing.T) {
+	partialObj := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"foo": "bar"}},
+	}
+
+	tests := []struct {
+		name string
+		kind client.Object
+		obj  interface{}
+		want interface{}
+	}{
+		{
+			name: "convert to typed object",
+			kind: &corev1.Node{},
+			obj:  partialObj,
+			want: &corev1.Node{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"foo": "bar"}},
+			},
+		},
+		{
+			name: "convert to unstructured object",
+			kind: &unstructured.Unstructured{},
+			obj:  partialObj,
+			want: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Node",
+				"metadata": map[string]interface{}{
+					"name":              "node-1",
+					"labels":            map[string]interface{}{"foo": "bar"},
+					"creationTimestamp": nil,
+				},
+			}},
+		},
+		{
+			name: "convert object in tombstone",
+			kind: &corev1.Node{},
+			obj:  toolscache.DeletedFinalStateUnknown{Key: "node-1", Obj: partialObj},
+			want: toolscache.DeletedFinalStateUnknown{Key: "node-1", Obj: &corev1.Node{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"foo": "bar"}},
+			}},
+		},
+		{
+			name: "do not convert other objects",
+			kind: &corev1.Node{},
+			obj:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+			want: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var got interface{}
+			wc := newWatcherCache(nil, corev1.SchemeGroupVersion.WithKind("Node"), watchCacheOptions{MetadataOnly: true}, tt.kind, &watchState{}, nil)
+			h := (&watcherInformer{cache: wc}).wrap(toolscache.ResourceEventHandlerFuncs{
+				DeleteFunc: func(obj interface{}) { got = obj },
+			})
+			h.OnDelete(tt.obj)
+			g.Expect(got).To(BeComparableTo(tt.want))
+		})
+	}
+}
+
+// testInformers is a cache with a single informer, which is returned for all objects.
+type testInformers struct {
+	cache.Cache
+
+	informer *controllertest.FakeInformer
+
+	lock             sync.Mutex
+	requestedObjects []client.Object
+	removedObjects   []client.Object
+}
+
+func newTestInformers() *testInformers {
+	return &testInformers{informer: &controllertest.FakeInformer{Synced: true}}
+}
+
+func (c *testInformers) GetInformer(_ context.Context, obj client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.requestedObjects = append(c.requestedObjects, obj)
+	return c.informer, nil
+}
+
+func (c *testInformers) RemoveInformer(_ context.Context, obj client.Object) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.removedObjects = append(c.removedObjects, obj)
+	return nil
+}
+
+func (c *testInformers) WaitForCacheSync(_ context.Context) bool {
+	return true
+}
+
+func (c *testInformers) requested() []client.Object {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.requestedObjects
+}
+
+func (c *testInformers) removed() []client.Object {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.removedObjects
+}
+
+// startingWatcher starts sources and waits until they are synced, so that event handlers are added
+// when Watch returns.
+type startingWatcher struct{}
+
+func (w *startingWatcher) Watch(src source.Source) error {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	if err := src.Start(ctx, queue); err != nil {
+		return err
+	}
+	return src.(source.SyncingSource).WaitForSync(ctx)
+}
+
+// mappedObjects records the objects passed to map funcs.
+type mappedObjects struct {
+	lock    sync.Mutex
+	objects []string
+}
+
+func (m *mappedObjects) mapFunc(watchName string) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.objects = append(m.objects, fmt.Sprintf("%s: %T %s", watchName, obj, obj.GetName()))
+		return nil
+	}
+}
+
+func (m *mappedObjects) get() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.objects
+}
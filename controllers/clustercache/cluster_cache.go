@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"sync"
@@ -28,6 +29,8 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -45,6 +48,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // Options defines the options to configure a ClusterCache.
@@ -60,6 +64,10 @@ type Options struct {
 	// will never be created.
 	WatchFilterValue string
 
+	// Sharder is used to connect only to Clusters in shards owned by this replica.
+	// If nil, i.e. sharding is disabled, the ClusterCache connects to all Clusters.
+	Sharder *sharding.Sharder
+
 	// Cache are the cache options for the caches that are created per cluster.
 	Cache CacheOptions
 
@@ -115,8 +123,17 @@ type ClientOptions struct {
 
 	// Cache are the cache options defining how clients should interact with the underlying cache.
 	Cache ClientCacheOptions
+
+	// Dialer is used to get the func used to dial the workload cluster, e.g. to access workload
+	// clusters behind NAT through a reverse tunnel.
+	// If Dialer is not set or it returns a nil func, the workload cluster is accessed directly.
+	Dialer ClusterDialer
 }
 
+// ClusterDialer returns the func used to dial the given workload cluster.
+// If a nil func is returned, the workload cluster is accessed directly.
+type ClusterDialer func(ctx context.Context, cluster client.ObjectKey) (func(ctx context.Context, network, address string) (net.Conn, error), error)
+
 // ClientCacheOptions are the cache options for the clients that are created per cluster.
 type ClientCacheOptions struct {
 	// DisableFor is a list of objects that should never be read from the cache.
@@ -152,6 +169,13 @@ type ClusterCache interface {
 	// If there is no connection to the workload cluster ErrClusterNotConnected will be returned.
 	Watch(ctx context.Context, cluster client.ObjectKey, watcher Watcher) error
 
+	// RemoveWatch removes a watch (by input.Name) that has been added with Watch.
+	// The event handlers of the watch are removed, and informers are stopped when they are not used by any watch anymore.
+	// Informers of the cache used by the client are only stopped if no indexes have been configured for their Kind
+	// (see CacheOptions.Indexes); the client re-creates them lazily on the next Get or List call.
+	// If there is no connection to the workload cluster, this is a no-op as watches are removed on disconnect.
+	RemoveWatch(ctx context.Context, cluster client.ObjectKey, watcher Watcher) error
+
 	// GetHealthCheckingState returns the health checking state of a Cluster.
 	GetHealthCheckingState(ctx context.Context, cluster client.ObjectKey) HealthCheckingState
 
@@ -207,6 +231,11 @@ type WatcherOptions = TypedWatcherOptions[client.Object, ctrl.Request]
 // A source.TypedKind source (configured with Kind, TypedEventHandler and Predicates) will be added to the Watcher.
 // To watch for events, the source.TypedKind will create an informer on the Cache that we have created and cached
 // for the given Cluster.
+// If MetadataOnly, LabelSelector or FieldSelector are set, the watch uses a dedicated informer instead of the
+// informer of the cache used by the client returned by GetClient and GetReader. A dedicated informer is shared by
+// all watches with the same Kind, MetadataOnly, LabelSelector and FieldSelector; it is started when the first of
+// these watches is added and stopped when the last one is removed with RemoveWatch.
+// Note: Objects in dedicated informers cannot be read with the client returned by GetClient and GetReader.
 type TypedWatcherOptions[object client.Object, request comparable] struct {
 	// Name represents a unique Watch request for the specified Cluster.
 	// The name is used to track that a specific watch is only added once to a cache.
@@ -225,6 +254,17 @@ type TypedWatcherOptions[object client.Object, request comparable] struct {
 
 	// Predicates is used to filter resource events.
 	Predicates []predicate.TypedPredicate[object]
+
+	// MetadataOnly configures the watch to only watch and cache the metadata of the objects, which significantly
+	// reduces memory usage e.g. for watches on Pods.
+	// EventHandler and Predicates are called with objects of type Kind, which only have TypeMeta and ObjectMeta set.
+	MetadataOnly bool
+
+	// LabelSelector restricts the watch to objects matching the label selector.
+	LabelSelector labels.Selector
+
+	// FieldSelector restricts the watch to objects matching the field selector.
+	FieldSelector fields.Selector
 }
 
 // NewWatcher creates a Watcher for the workload cluster.
@@ -238,6 +278,11 @@ func NewWatcher[object client.Object, request comparable](options TypedWatcherOp
 		eventHandler: options.EventHandler,
 		predicates:   options.Predicates,
 		watcher:      options.Watcher,
+		cacheOptions: watchCacheOptions{
+			MetadataOnly:  options.MetadataOnly,
+			LabelSelector: options.LabelSelector,
+			FieldSelector: options.FieldSelector,
+		},
 	}
 }
 
@@ -247,6 +292,7 @@ type watcher[object client.Object, request comparable] struct {
 	eventHandler handler.TypedEventHandler[object, request]
 	predicates   []predicate.TypedPredicate[object]
 	watcher      SourceWatcher[request]
+	cacheOptions watchCacheOptions
 }
 
 func (tw *watcher[object, request]) Name() string          { return tw.name }
@@ -254,6 +300,7 @@ func (tw *watcher[object, request]) Object() client.Object { return tw.kind }
 func (tw *watcher[object, request]) Watch(cache cache.Cache) error {
 	return tw.watcher.Watch(source.TypedKind[object, request](cache, tw.kind, tw.eventHandler, tw.predicates...))
 }
+func (tw *watcher[object, request]) watchCacheOptions() watchCacheOptions { return tw.cacheOptions }
 
 // GetClusterSourceOption is an option that modifies GetClusterSourceOptions for a GetClusterSource call.
 type GetClusterSourceOption interface {
@@ -319,13 +366,19 @@ func SetupWithManager(ctx context.Context, mgr manager.Manager, options Options,
 		clusterAccessors:      make(map[client.ObjectKey]*clusterAccessor),
 		cacheCtx:              cacheCtx,
 		cacheCtxCancel:        cacheCtxCancel,
+		sharder:               options.Sharder,
 	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
 		Named("clustercache").
 		For(&clusterv1.Cluster{}).
+		// Reconcile Clusters when the ownership of their shard changes, so connections are created and removed accordingly.
+		WatchesRawSource(options.Sharder.GetClusterSource(func(_ context.Context, o client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(o)}}
+		})).
 		WithOptions(controllerOptions).
 		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), log, options.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceIsInOwnedShard(ctx, mgr.GetScheme(), log, options.Sharder)).
 		Complete(cc)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed setting up ClusterCache with a controller manager")
@@ -357,6 +410,9 @@ type clusterCache struct {
 
 	// cacheCtxCancel is used during Shutdown to stop caches.
 	cacheCtxCancel context.CancelCauseFunc
+
+	// sharder is used to connect only to Clusters in shards owned by this replica.
+	sharder *sharding.Sharder
 }
 
 // clusterSource stores the necessary information so we can enqueue reconcile.Requests for reconcilers that
@@ -416,6 +472,14 @@ func (cc *clusterCache) Watch(ctx context.Context, cluster client.ObjectKey, wat
 	return accessor.Watch(ctx, watcher)
 }
 
+func (cc *clusterCache) RemoveWatch(ctx context.Context, cluster client.ObjectKey, watcher Watcher) error {
+	accessor := cc.getClusterAccessor(cluster)
+	if accessor == nil {
+		return nil
+	}
+	return accessor.RemoveWatch(ctx, watcher)
+}
+
 func (cc *clusterCache) GetHealthCheckingState(ctx context.Context, cluster client.ObjectKey) HealthCheckingState {
 	accessor := cc.getClusterAccessor(cluster)
 	if accessor == nil {
@@ -451,6 +515,17 @@ func (cc *clusterCache) Reconcile(ctx context.Context, req reconcile.Request) (r
 		return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
 	}
 
+	// Disconnect if the Cluster is in a shard that is not owned by this replica.
+	// There will be a new reconcile.Request when this replica acquires the shard.
+	if !cc.sharder.OwnsCluster(cluster) {
+		if accessor.Connected(ctx) {
+			log.Info("Cluster is not in an owned shard anymore, disconnecting")
+			accessor.Disconnect(ctx)
+		}
+		cc.deleteClusterAccessor(clusterKey)
+		return ctrl.Result{}, nil
+	}
+
 	// Return if infrastructure is not ready yet to avoid trying to open a connection when it cannot succeed.
 	// Requeue is not needed as there will be a new reconcile.Request when Cluster.status.initialization.infrastructureProvisioned is set.
 	if !ptr.Deref(cluster.Status.Initialization.InfrastructureProvisioned, false) {
@@ -729,6 +804,7 @@ func buildClusterAccessorConfig(scheme *runtime.Scheme, options Options, control
 			Cache: clusterAccessorClientCacheConfig{
 				DisableFor: options.Client.Cache.DisableFor,
 			},
+			Dialer: options.Client.Dialer,
 		},
 		HealthProbe: &clusterAccessorHealthProbeConfig{
 			Timeout:          5 * time.Second,
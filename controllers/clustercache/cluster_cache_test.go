@@ -31,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest/fake"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -47,6 +49,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/sharding"
 	"sigs.k8s.io/cluster-api/util/test/builder"
 )
 
@@ -211,6 +214,52 @@ func TestReconcile(t *testing.T) {
 	g.Expect(clusterQueue.Get()).To(Equal(reconcile.Request{NamespacedName: clusterKey}))
 }
 
+func TestReconcileClusterNotInOwnedShard(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Status: clusterv1.ClusterStatus{
+			Initialization: clusterv1.ClusterInitializationStatus{
+				InfrastructureProvisioned: ptr.To(true),
+			},
+		},
+	}
+	clusterKey := client.ObjectKeyFromObject(testCluster)
+	c := fakeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(testCluster).Build()
+
+	// The sharder is never started, so it doesn't own any shard.
+	sharder, err := sharding.New(c, c, sharding.Options{Namespace: metav1.NamespaceDefault, Name: "test-shard", Shards: 1})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	opts := Options{
+		SecretClient: c,
+		Client: ClientOptions{
+			UserAgent: remote.DefaultClusterAPIUserAgent("test-controller-manager"),
+		},
+	}
+	cc := &clusterCache{
+		client:                c,
+		clusterAccessorConfig: buildClusterAccessorConfig(testScheme, opts, nil),
+		clusterAccessors:      make(map[client.ObjectKey]*clusterAccessor),
+		cacheCtx:              context.Background(),
+		sharder:               sharder,
+	}
+
+	// Reconcile, Cluster is not in an owned shard
+	// => we expect no requeue and no clusterAccessor.
+	res, err := cc.Reconcile(ctx, reconcile.Request{NamespacedName: clusterKey})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.IsZero()).To(BeTrue())
+	g.Expect(cc.getClusterAccessor(clusterKey)).To(BeNil())
+}
+
 func TestShouldRequeue(t *testing.T) {
 	now := time.Now()
 
@@ -26,6 +26,7 @@ func init() {
 	ctrlmetrics.Registry.MustRegister(healthCheck)
 	ctrlmetrics.Registry.MustRegister(connectionUp)
 	ctrlmetrics.Registry.MustRegister(healthChecksTotal)
+	ctrlmetrics.Registry.MustRegister(cacheObjects)
 }
 
 var (
@@ -53,4 +54,12 @@ var (
 			"cluster_name", "cluster_namespace",
 		},
 	)
+	cacheObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_cluster_cache_objects",
+			Help: "Number of objects in the informers used by watches on a cluster.",
+		}, []string{
+			"cluster_name", "cluster_namespace", "informer",
+		},
+	)
 )
@@ -74,6 +74,10 @@ type ControlPlane struct {
 	EtcdMembers                       []*etcd.Member
 	EtcdMembersAndMachinesAreMatching bool
 
+	// EtcdAlarms is the list of alarms read while computing reconcileControlPlaneConditions.
+	// NOTE: This info is used to remediate the etcd NOSPACE alarm.
+	EtcdAlarms []etcd.MemberAlarm
+
 	managementCluster ManagementCluster
 	workloadCluster   WorkloadCluster
 
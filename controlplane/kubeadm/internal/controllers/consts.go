@@ -30,4 +30,25 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdMaintenanceRequeueAfter is how long to wait before checking again
+	// if other etcd maintenance operations are required.
+	etcdMaintenanceRequeueAfter = 15 * time.Second
+
+	// etcdCompactionRetainedRevisions is the number of revisions retained by scheduled compactions of the
+	// etcd keyspace, so watchers lagging behind the current revision can still resume.
+	etcdCompactionRetainedRevisions = 10000
+
+	// etcdMinDefragmentationInterval is the minimum time between two defragmentations of the same etcd member
+	// triggered by the fragmentation threshold; it prevents defragmenting over and over a member whose
+	// fragmentation stays above the threshold.
+	etcdMinDefragmentationInterval = 1 * time.Hour
+
+	// etcdLearnerPromotionRequeueAfter is how long to wait before trying again
+	// to promote an etcd learner which is not yet in sync with the leader.
+	etcdLearnerPromotionRequeueAfter = 10 * time.Second
+
+	// etcdLearnerPromotionTimeout is how long to wait for an etcd learner to be
+	// promoted to voting member before remediating the Machine hosting it.
+	etcdLearnerPromotionTimeout = 10 * time.Minute
 )
@@ -46,6 +46,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
@@ -454,6 +455,11 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{}, err
 	}
 
+	// Promotes etcd learners to voting members, and remediates Machines hosting learners which never catch up with the leader.
+	if result, err := r.reconcileEtcdLearners(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Handle machines in deletion phase; when drain and wait for volume detach completed, forward etcd leadership
 	// and remove the etcd member, then unblock deletion.
 	if result, err := r.reconcilePreTerminateHook(ctx, controlPlane); err != nil || !result.IsZero() {
@@ -466,6 +472,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return result, err
 	}
 
+	// Ensures the Secret with the encryption configuration for the current phase of the key rotation exists;
+	// when the encryption configuration changes, the control plane Machines are rolled out.
+	if result, err := r.reconcileEncryptionConfiguration(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	machinesNeedingRollout, machinesNeedingRolloutLogMessages := controlPlane.MachinesNeedingRollout()
 	switch {
@@ -516,15 +528,36 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Run etcd maintenance operations, if configured.
+	// NOTE: This happens before updating kube-proxy and CoreDNS, because writes to the workload cluster fail
+	// when the etcd NOSPACE alarm is active; in this case the following operations are deferred until the
+	// alarm is remediated, otherwise they run while maintenance operations are still in progress.
+	// Errors in maintenance operations are aggregated and returned at the end, so they don't block the
+	// following operations.
+	var errs []error
+	maintenanceResult, err := r.reconcileEtcdMaintenance(ctx, controlPlane, workloadCluster)
+	if err != nil {
+		errs = append(errs, err)
+		maintenanceResult = ctrl.Result{}
+	}
+	if !maintenanceResult.IsZero() && hasEtcdAlarm(controlPlane.EtcdAlarms, etcd.AlarmNoSpace) {
+		return maintenanceResult, nil
+	}
+
+	// Rotate the encryption key, if requested.
+	if result, err := r.reconcileEncryptionKeyRotation(ctx, controlPlane, workloadCluster); err != nil || !result.IsZero() {
+		return util.LowestNonZeroResult(result, maintenanceResult), kerrors.NewAggregate(append(errs, err))
+	}
+
 	// Update kube-proxy daemonset.
 	if err := workloadCluster.UpdateKubeProxyImageInfo(ctx, controlPlane.KCP); err != nil {
 		log.Error(err, "Failed to update kube-proxy daemonset")
-		return ctrl.Result{}, err
+		return ctrl.Result{}, kerrors.NewAggregate(append(errs, err))
 	}
 
 	// Update CoreDNS deployment.
 	if err := workloadCluster.UpdateCoreDNS(ctx, controlPlane.KCP); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed to update CoreDNS deployment")
+		return ctrl.Result{}, kerrors.NewAggregate(append(errs, errors.Wrap(err, "failed to update CoreDNS deployment")))
 	}
 
 	// Reconcile certificate expiry for Machines that don't have the expiry annotation on KubeadmConfig yet.
@@ -532,9 +565,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	// as nothing in the same reconcile depends on it and to ensure it doesn't block anything else,
 	// especially MHC remediation and rollout of changes to recover the control plane.
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
-		return ctrl.Result{}, err
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
 	}
-	return ctrl.Result{}, nil
+	return maintenanceResult, nil
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
@@ -0,0 +1,405 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// reconcileEncryptionConfiguration ensures the Secret with the encryption configuration for the current phase of the
+// key rotation exists, and sets its name in the KCP status.
+// When the encryption configuration changes, the control plane Machines are rolled out, because they are using a
+// file with the content of a different Secret (see internal.SetEncryptionConfiguration).
+// NOTE: The reconcile is requeued after the name of the Secret changes, so the control plane Machines needing a rollout
+// are re-computed using the new encryption configuration.
+func (r *KubeadmControlPlaneReconciler) reconcileEncryptionConfiguration(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !feature.Gates.Enabled(feature.KubeadmControlPlaneEncryptionAtRest) || !controlPlane.KCP.Spec.Encryption.IsDefined() {
+		return ctrl.Result{}, nil
+	}
+
+	keys, err := r.encryptionKeys(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	config, err := internal.EncryptionConfiguration(controlPlane.KCP.Spec.Encryption, keys)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Each encryption configuration is stored in a different Secret, named after the hash of the configuration.
+	configSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      encryptionConfigurationSecretName(controlPlane.Cluster.Name, config),
+			Namespace: controlPlane.KCP.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: controlPlane.Cluster.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+			},
+		},
+		Immutable: ptr.To(true),
+		Type:      clusterv1.ClusterSecretType,
+		Data: map[string][]byte{
+			internal.EncryptionConfigurationKey: config,
+		},
+	}
+	if err := r.Client.Create(ctx, configSecret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create encryption configuration Secret %s", klog.KObj(configSecret))
+	}
+
+	if controlPlane.KCP.Status.Encryption.ConfigurationSecretName == configSecret.Name {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Encryption configuration changed", "Secret", klog.KObj(configSecret))
+	controlPlane.KCP.Status.Encryption.ConfigurationSecretName = configSecret.Name
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// reconcileEncryptionKeyRotation starts a rotation of the encryption key when requested, and moves the key rotation
+// to the next phase when the control plane Machines are using the encryption configuration for the current phase.
+// NOTE: This func assumes all the control plane Machines are up-to-date, i.e. no Machine is being rolled out.
+func (r *KubeadmControlPlaneReconciler) reconcileEncryptionKeyRotation(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	encryption := controlPlane.KCP.Spec.Encryption
+	if !feature.Gates.Enabled(feature.KubeadmControlPlaneEncryptionAtRest) || !encryption.IsDefined() ||
+		encryption.Provider == controlplanev1.KubeadmControlPlaneEncryptionProviderKMS {
+		return ctrl.Result{}, nil
+	}
+
+	status := &controlPlane.KCP.Status.Encryption
+	keyRotation := status.KeyRotation
+	if keyRotation.Phase == "" && !shouldRotateEncryptionKey(controlPlane.KCP, time.Now()) {
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the control plane to be stable before moving to the next phase of the key rotation.
+	if result, err := r.preflightChecks(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// NOTE: Each step updates the state of the key rotation stored on the Secret with the keys first, together with
+	// the keys, and then it is mirrored in the KCP status; this ensures the state of the key rotation is never lost.
+	switch keyRotation.Phase {
+	case "":
+		key, err := internal.NewEncryptionKey(time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.updateEncryptionKeys(ctx, controlPlane, func(keysSecret *corev1.Secret) {
+			keysSecret.Data[key.Name] = key.Secret
+			keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation] = string(controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase)
+			keysSecret.Annotations[controlplanev1.EncryptionNewKeyAnnotation] = key.Name
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Starting encryption key rotation", "newKey", key.Name)
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "EncryptionKeyRotationStarted", "Started rotation of encryption key %s to %s", status.PrimaryKeyName, key.Name)
+		status.KeyRotation = controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+			Phase:              controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase,
+			NewKeyName:         key.Name,
+			NewKeyCreationTime: metav1.Now().Rfc3339Copy(),
+		}
+	case controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase:
+		// All the API servers can now decrypt data encrypted with the new key, so it is safe to use it for encryption.
+		if err := r.setEncryptionKeyRotationPhase(ctx, controlPlane, controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase); err != nil {
+			return ctrl.Result{}, err
+		}
+	case controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase:
+		// All the API servers are now using the new key for encryption, so it is possible to rewrite Secrets.
+		if err := r.setEncryptionKeyRotationPhase(ctx, controlPlane, controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase); err != nil {
+			return ctrl.Result{}, err
+		}
+	case controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase:
+		log.Info("Rewriting Secrets to encrypt them with the new encryption key", "newKey", keyRotation.NewKeyName)
+		if err := workloadCluster.RewriteSecrets(ctx); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to rewrite Secrets in the workload cluster")
+		}
+		// All the Secrets are now encrypted with the new key, so it is safe to remove the old key.
+		oldKeyName := status.PrimaryKeyName
+		if err := r.updateEncryptionKeys(ctx, controlPlane, func(keysSecret *corev1.Secret) {
+			delete(keysSecret.Data, oldKeyName)
+			keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation] = string(controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		status.KeyRotation.Phase = controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase
+	case controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase:
+		// All the API servers are now using an encryption configuration without the old key, so the rotation is completed.
+		oldKeyName := status.PrimaryKeyName
+		newKeyName := keyRotation.NewKeyName
+		if err := r.updateEncryptionKeys(ctx, controlPlane, func(keysSecret *corev1.Secret) {
+			delete(keysSecret.Data, oldKeyName)
+			keysSecret.Annotations[controlplanev1.EncryptionPrimaryKeyAnnotation] = newKeyName
+			delete(keysSecret.Annotations, controlplanev1.EncryptionKeyRotationPhaseAnnotation)
+			delete(keysSecret.Annotations, controlplanev1.EncryptionNewKeyAnnotation)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		status.PrimaryKeyName = newKeyName
+		status.PrimaryKeyCreationTime = keyRotation.NewKeyCreationTime
+		status.KeyRotation = controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{}
+		if err := r.deleteStaleEncryptionConfigurations(ctx, controlPlane); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Completed encryption key rotation", "primaryKey", status.PrimaryKeyName)
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "EncryptionKeyRotationCompleted", "Completed rotation of encryption key %s to %s", oldKeyName, status.PrimaryKeyName)
+		return ctrl.Result{}, nil
+	default:
+		return ctrl.Result{}, errors.Errorf("unknown encryption key rotation phase %q", keyRotation.Phase)
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// setEncryptionKeyRotationPhase moves the key rotation to the given phase.
+func (r *KubeadmControlPlaneReconciler) setEncryptionKeyRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane, phase controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPhase) error {
+	if err := r.updateEncryptionKeys(ctx, controlPlane, func(keysSecret *corev1.Secret) {
+		keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation] = string(phase)
+	}); err != nil {
+		return err
+	}
+	controlPlane.KCP.Status.Encryption.KeyRotation.Phase = phase
+	return nil
+}
+
+// shouldRotateEncryptionKey returns true if rotateKeyAfter is in the past and the primary key was generated before it.
+func shouldRotateEncryptionKey(kcp *controlplanev1.KubeadmControlPlane, now time.Time) bool {
+	rotateKeyAfter := kcp.Spec.Encryption.RotateKeyAfter
+	if rotateKeyAfter.IsZero() || now.Before(rotateKeyAfter.Time) {
+		return false
+	}
+	return kcp.Status.Encryption.PrimaryKeyCreationTime.Before(&rotateKeyAfter)
+}
+
+// encryptionKeys returns the keys to be used in the encryption configuration for the current phase of the key rotation;
+// the first key is the key used for encryption, while all the keys in the Secret storing the keys are always included,
+// so data encrypted with any of them can be decrypted.
+// If the Secret storing the keys does not exist, it is created with a new primary key.
+// NOTE: The state of the key rotation is read from the Secret storing the keys, and it is mirrored in the KCP status.
+func (r *KubeadmControlPlaneReconciler) encryptionKeys(ctx context.Context, controlPlane *internal.ControlPlane) ([]internal.EncryptionKey, error) {
+	// Keys are managed by the KMS plugin.
+	if controlPlane.KCP.Spec.Encryption.Provider == controlplanev1.KubeadmControlPlaneEncryptionProviderKMS {
+		return nil, nil
+	}
+
+	status := &controlPlane.KCP.Status.Encryption
+	keysSecret := &corev1.Secret{}
+	keysSecretKey := client.ObjectKey{Namespace: controlPlane.KCP.Namespace, Name: secret.Name(controlPlane.Cluster.Name, secret.EncryptionKeys)}
+	if err := r.Client.Get(ctx, keysSecretKey, keysSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get encryption keys Secret %s", keysSecretKey)
+		}
+
+		key, err := internal.NewEncryptionKey(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		keysSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      keysSecretKey.Name,
+				Namespace: keysSecretKey.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: controlPlane.Cluster.Name,
+				},
+				Annotations: map[string]string{
+					controlplanev1.EncryptionPrimaryKeyAnnotation: key.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+				},
+			},
+			Type: clusterv1.ClusterSecretType,
+			Data: map[string][]byte{
+				key.Name: key.Secret,
+			},
+		}
+		if err := r.Client.Create(ctx, keysSecret); err != nil {
+			return nil, errors.Wrapf(err, "failed to create encryption keys Secret %s", keysSecretKey)
+		}
+		status.PrimaryKeyName = key.Name
+		status.PrimaryKeyCreationTime = metav1.Now().Rfc3339Copy()
+	}
+	if len(keysSecret.Data) == 0 {
+		return nil, errors.Errorf("encryption keys Secret %s does not contain any key", keysSecretKey)
+	}
+
+	// If the state of the key rotation is not tracked on the Secret storing the keys yet, initialize it from the KCP status.
+	if _, ok := keysSecret.Annotations[controlplanev1.EncryptionPrimaryKeyAnnotation]; !ok {
+		if err := r.updateEncryptionKeys(ctx, controlPlane, func(s *corev1.Secret) {
+			initializeEncryptionKeysAnnotations(s, status)
+		}); err != nil {
+			return nil, err
+		}
+		initializeEncryptionKeysAnnotations(keysSecret, status)
+	}
+	setEncryptionStatusFromKeysSecret(status, keysSecret)
+
+	encryptionKeyName := status.PrimaryKeyName
+	switch status.KeyRotation.Phase {
+	case controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase,
+		controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase,
+		controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase:
+		encryptionKeyName = status.KeyRotation.NewKeyName
+	}
+	data, ok := keysSecret.Data[encryptionKeyName]
+	if !ok {
+		return nil, errors.Errorf("encryption keys Secret %s does not contain key %s", keysSecretKey, encryptionKeyName)
+	}
+
+	// NOTE: Key names are derived from the time when keys were generated, so other keys are sorted from the newest to the oldest.
+	keys := []internal.EncryptionKey{{Name: encryptionKeyName, Secret: data}}
+	otherKeyNames := make([]string, 0, len(keysSecret.Data))
+	for name := range keysSecret.Data {
+		if name != encryptionKeyName {
+			otherKeyNames = append(otherKeyNames, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(otherKeyNames)))
+	for _, name := range otherKeyNames {
+		keys = append(keys, internal.EncryptionKey{Name: name, Secret: keysSecret.Data[name]})
+	}
+	return keys, nil
+}
+
+// initializeEncryptionKeysAnnotations sets the annotations tracking the state of the key rotation on the Secret storing
+// the keys using the KCP status; if the status has been lost, the most recent key is adopted as primary key.
+func initializeEncryptionKeysAnnotations(keysSecret *corev1.Secret, status *controlplanev1.KubeadmControlPlaneEncryptionStatus) {
+	if keysSecret.Annotations == nil {
+		keysSecret.Annotations = map[string]string{}
+	}
+
+	primaryKeyName := status.PrimaryKeyName
+	if _, ok := keysSecret.Data[primaryKeyName]; !ok {
+		names := make([]string, 0, len(keysSecret.Data))
+		for name := range keysSecret.Data {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		primaryKeyName = names[len(names)-1]
+	}
+	keysSecret.Annotations[controlplanev1.EncryptionPrimaryKeyAnnotation] = primaryKeyName
+
+	if _, ok := keysSecret.Data[status.KeyRotation.NewKeyName]; ok && status.KeyRotation.Phase != "" && primaryKeyName == status.PrimaryKeyName {
+		keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation] = string(status.KeyRotation.Phase)
+		keysSecret.Annotations[controlplanev1.EncryptionNewKeyAnnotation] = status.KeyRotation.NewKeyName
+	}
+}
+
+// setEncryptionStatusFromKeysSecret sets the KCP status using the state of the key rotation tracked on the Secret storing the keys.
+func setEncryptionStatusFromKeysSecret(status *controlplanev1.KubeadmControlPlaneEncryptionStatus, keysSecret *corev1.Secret) {
+	keyCreationTime := func(name string, current metav1.Time) metav1.Time {
+		if !current.IsZero() {
+			return current
+		}
+		if t, ok := internal.EncryptionKeyCreationTime(name); ok {
+			return metav1.NewTime(t)
+		}
+		return metav1.Time{}
+	}
+
+	if primaryKeyName := keysSecret.Annotations[controlplanev1.EncryptionPrimaryKeyAnnotation]; status.PrimaryKeyName != primaryKeyName {
+		status.PrimaryKeyName = primaryKeyName
+		status.PrimaryKeyCreationTime = metav1.Time{}
+	}
+	status.PrimaryKeyCreationTime = keyCreationTime(status.PrimaryKeyName, status.PrimaryKeyCreationTime)
+
+	phase := controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPhase(keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation])
+	newKeyName := keysSecret.Annotations[controlplanev1.EncryptionNewKeyAnnotation]
+	if phase == "" {
+		status.KeyRotation = controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{}
+		return
+	}
+	if status.KeyRotation.NewKeyName != newKeyName {
+		status.KeyRotation.NewKeyName = newKeyName
+		status.KeyRotation.NewKeyCreationTime = metav1.Time{}
+	}
+	status.KeyRotation.Phase = phase
+	status.KeyRotation.NewKeyCreationTime = keyCreationTime(newKeyName, status.KeyRotation.NewKeyCreationTime)
+}
+
+// updateEncryptionKeys updates the Secret storing the encryption keys and the state of the key rotation.
+func (r *KubeadmControlPlaneReconciler) updateEncryptionKeys(ctx context.Context, controlPlane *internal.ControlPlane, mutate func(*corev1.Secret)) error {
+	keysSecret := &corev1.Secret{}
+	keysSecretKey := client.ObjectKey{Namespace: controlPlane.KCP.Namespace, Name: secret.Name(controlPlane.Cluster.Name, secret.EncryptionKeys)}
+	if err := r.Client.Get(ctx, keysSecretKey, keysSecret); err != nil {
+		return errors.Wrapf(err, "failed to get encryption keys Secret %s", keysSecretKey)
+	}
+	if keysSecret.Data == nil {
+		keysSecret.Data = map[string][]byte{}
+	}
+	if keysSecret.Annotations == nil {
+		keysSecret.Annotations = map[string]string{}
+	}
+	mutate(keysSecret)
+	if err := r.Client.Update(ctx, keysSecret); err != nil {
+		return errors.Wrapf(err, "failed to update encryption keys Secret %s", keysSecretKey)
+	}
+	return nil
+}
+
+// deleteStaleEncryptionConfigurations deletes the Secrets with encryption configurations which are not used anymore.
+func (r *KubeadmControlPlaneReconciler) deleteStaleEncryptionConfigurations(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secrets, client.InNamespace(controlPlane.KCP.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: controlPlane.Cluster.Name}); err != nil {
+		return errors.Wrap(err, "failed to list encryption configuration Secrets")
+	}
+
+	prefix := fmt.Sprintf("%s-encryption-", controlPlane.Cluster.Name)
+	for i := range secrets.Items {
+		s := &secrets.Items[i]
+		if !strings.HasPrefix(s.Name, prefix) ||
+			s.Name == secret.Name(controlPlane.Cluster.Name, secret.EncryptionKeys) ||
+			s.Name == controlPlane.KCP.Status.Encryption.ConfigurationSecretName ||
+			!metav1.IsControlledBy(s, controlPlane.KCP) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete encryption configuration Secret %s", klog.KObj(s))
+		}
+	}
+	return nil
+}
+
+// encryptionConfigurationSecretName returns the name of the Secret storing an encryption configuration.
+func encryptionConfigurationSecretName(clusterName string, config []byte) string {
+	hash := sha256.Sum256(config)
+	return fmt.Sprintf("%s-encryption-%x", clusterName, hash[:5])
+}
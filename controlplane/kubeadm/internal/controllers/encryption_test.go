@@ -0,0 +1,503 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileEncryptionConfiguration(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	keysSecret := func(keyNames ...string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secret.Name(cluster.Name, secret.EncryptionKeys),
+				Namespace: metav1.NamespaceDefault,
+			},
+			Data: map[string][]byte{},
+		}
+		for _, name := range keyNames {
+			s.Data[name] = []byte(name)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name            string
+		featureGate     bool
+		encryption      controlplanev1.KubeadmControlPlaneEncryptionSpec
+		status          controlplanev1.KubeadmControlPlaneEncryptionStatus
+		objs            []client.Object
+		wantResult      ctrl.Result
+		wantErr         bool
+		wantKeyNames    []string
+		wantPrimaryKey  bool
+		wantKMSProvider bool
+		wantKeyRotation *controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus
+	}{
+		{
+			name:        "no-op if the feature gate is disabled",
+			featureGate: false,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+		},
+		{
+			name:        "no-op if encryption is not configured",
+			featureGate: true,
+		},
+		{
+			name:           "generate a primary key when encryption is enabled",
+			featureGate:    true,
+			encryption:     controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			wantResult:     ctrl.Result{Requeue: true},
+			wantPrimaryKey: true,
+		},
+		{
+			name:         "use the primary key when no key rotation is in progress",
+			featureGate:  true,
+			encryption:   controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			status:       controlplanev1.KubeadmControlPlaneEncryptionStatus{PrimaryKeyName: "key-1"},
+			objs:         []client.Object{keysSecret("key-1")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-1"},
+		},
+		{
+			name:        "add the new key as secondary key when adding the new key",
+			featureGate: true,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			objs:         []client.Object{keysSecret("key-1", "key-2")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-1", "key-2"},
+		},
+		{
+			name:        "use the new key as primary key when rewriting Secrets",
+			featureGate: true,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			objs:         []client.Object{keysSecret("key-1", "key-2")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-2", "key-1"},
+		},
+		{
+			name:        "use only the new key when the old key has been removed",
+			featureGate: true,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			objs: []client.Object{withKeyRotationAnnotations(keysSecret("key-2"), "key-1",
+				controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase, "key-2")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-2"},
+		},
+		{
+			name:        "keep all the keys and restore the key rotation from the keys Secret if the status has been lost",
+			featureGate: true,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			objs: []client.Object{withKeyRotationAnnotations(keysSecret("key-20250101000000", "key-20250201000000"), "key-20250101000000",
+				controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase, "key-20250201000000")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-20250101000000", "key-20250201000000"},
+			wantKeyRotation: &controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+				Phase:              controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase,
+				NewKeyName:         "key-20250201000000",
+				NewKeyCreationTime: metav1.NewTime(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:         "keep all the keys for decryption if the status has been lost and the keys Secret does not track the primary key",
+			featureGate:  true,
+			encryption:   controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			objs:         []client.Object{keysSecret("key-1", "key-2")},
+			wantResult:   ctrl.Result{Requeue: true},
+			wantKeyNames: []string{"key-2", "key-1"},
+		},
+		{
+			name:        "fail if the key used for encryption is missing",
+			featureGate: true,
+			encryption:  controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			status:      controlplanev1.KubeadmControlPlaneEncryptionStatus{PrimaryKeyName: "key-1"},
+			objs:        []client.Object{withKeyRotationAnnotations(keysSecret("key-2"), "key-1", "", "")},
+			wantErr:     true,
+		},
+		{
+			name:        "do not generate keys with the KMS provider",
+			featureGate: true,
+			encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+				Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+				KMS: controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{
+					Name:     "kms-plugin",
+					Endpoint: "unix:///var/run/kms-provider.sock",
+				},
+			},
+			wantResult:      ctrl.Result{Requeue: true},
+			wantKMSProvider: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, tt.featureGate)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "kcp-uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Encryption: tt.encryption,
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					Encryption: tt.status,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: cluster,
+			}
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(32),
+			}
+			result, err := r.reconcileEncryptionConfiguration(ctx, controlPlane)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+
+			configSecretName := kcp.Status.Encryption.ConfigurationSecretName
+			if result.IsZero() {
+				g.Expect(configSecretName).To(BeEmpty())
+				return
+			}
+
+			// Reconciling again must be a no-op.
+			result, err = r.reconcileEncryptionConfiguration(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.IsZero()).To(BeTrue())
+			g.Expect(kcp.Status.Encryption.ConfigurationSecretName).To(Equal(configSecretName))
+
+			configSecret := &corev1.Secret{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: configSecretName}, configSecret)).To(Succeed())
+			g.Expect(configSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
+			g.Expect(metav1.IsControlledBy(configSecret, kcp)).To(BeTrue())
+
+			config := &apiserverv1.EncryptionConfiguration{}
+			g.Expect(yaml.Unmarshal(configSecret.Data[internal.EncryptionConfigurationKey], config)).To(Succeed())
+			provider := config.Resources[0].Providers[0]
+			if tt.wantKMSProvider {
+				g.Expect(provider.KMS).ToNot(BeNil())
+				g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(keysSecret()), &corev1.Secret{}))).To(BeTrue())
+				return
+			}
+			g.Expect(provider.AESCBC).ToNot(BeNil())
+
+			if tt.wantPrimaryKey {
+				g.Expect(kcp.Status.Encryption.PrimaryKeyName).ToNot(BeEmpty())
+				g.Expect(kcp.Status.Encryption.PrimaryKeyCreationTime.IsZero()).To(BeFalse())
+				tt.wantKeyNames = []string{kcp.Status.Encryption.PrimaryKeyName}
+			}
+			keyNames := []string{}
+			for _, key := range provider.AESCBC.Keys {
+				keyNames = append(keyNames, key.Name)
+			}
+			g.Expect(keyNames).To(Equal(tt.wantKeyNames))
+
+			// The state of the key rotation is tracked on the keys Secret and mirrored in the KCP status.
+			gotKeysSecret := &corev1.Secret{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(keysSecret()), gotKeysSecret)).To(Succeed())
+			g.Expect(gotKeysSecret.Annotations).To(HaveKeyWithValue(controlplanev1.EncryptionPrimaryKeyAnnotation, kcp.Status.Encryption.PrimaryKeyName))
+			if tt.wantKeyRotation != nil {
+				g.Expect(kcp.Status.Encryption.KeyRotation).To(Equal(*tt.wantKeyRotation))
+			}
+		})
+	}
+}
+
+func withKeyRotationAnnotations(keysSecret *corev1.Secret, primaryKeyName string, phase controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPhase, newKeyName string) *corev1.Secret {
+	keysSecret.Annotations = map[string]string{
+		controlplanev1.EncryptionPrimaryKeyAnnotation: primaryKeyName,
+	}
+	if phase != "" {
+		keysSecret.Annotations[controlplanev1.EncryptionKeyRotationPhaseAnnotation] = string(phase)
+		keysSecret.Annotations[controlplanev1.EncryptionNewKeyAnnotation] = newKeyName
+	}
+	return keysSecret
+}
+
+func TestReconcileEncryptionKeyRotation(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name                     string
+		rotateKeyAfter           metav1.Time
+		status                   controlplanev1.KubeadmControlPlaneEncryptionStatus
+		wantResult               ctrl.Result
+		wantStatus               controlplanev1.KubeadmControlPlaneEncryptionStatus
+		wantKeyRotationPhase     controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPhase
+		wantKeyNames             []string
+		wantRewriteSecretsCalled int
+	}{
+		{
+			name: "no-op if rotateKeyAfter is not set",
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName:         "key-1",
+				PrimaryKeyCreationTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			wantKeyNames: []string{"key-1"},
+		},
+		{
+			name:           "no-op if rotateKeyAfter is in the future",
+			rotateKeyAfter: metav1.NewTime(now.Add(time.Hour)),
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName:         "key-1",
+				PrimaryKeyCreationTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			wantKeyNames: []string{"key-1"},
+		},
+		{
+			name:           "no-op if the primary key was generated after rotateKeyAfter",
+			rotateKeyAfter: metav1.NewTime(now.Add(-2 * time.Hour)),
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName:         "key-1",
+				PrimaryKeyCreationTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			wantKeyNames: []string{"key-1"},
+		},
+		{
+			name:           "start the key rotation if the primary key was generated before rotateKeyAfter",
+			rotateKeyAfter: metav1.NewTime(now.Add(-time.Minute)),
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName:         "key-1",
+				PrimaryKeyCreationTime: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			wantResult:           ctrl.Result{Requeue: true},
+			wantKeyRotationPhase: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase,
+		},
+		{
+			name: "promote the new key after adding it",
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			wantResult:           ctrl.Result{Requeue: true},
+			wantKeyRotationPhase: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase,
+			wantKeyNames:         []string{"key-1", "key-2"},
+		},
+		{
+			name: "rewrite Secrets after promoting the new key",
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationPromotingKeyPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			wantResult:           ctrl.Result{Requeue: true},
+			wantKeyRotationPhase: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase,
+			wantKeyNames:         []string{"key-1", "key-2"},
+		},
+		{
+			name: "remove the old key after rewriting Secrets",
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				PrimaryKeyName: "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:      controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRewritingSecretsPhase,
+					NewKeyName: "key-2",
+				},
+			},
+			wantResult:               ctrl.Result{Requeue: true},
+			wantKeyRotationPhase:     controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase,
+			wantKeyNames:             []string{"key-2"},
+			wantRewriteSecretsCalled: 1,
+		},
+		{
+			name:           "complete the key rotation after removing the old key",
+			rotateKeyAfter: metav1.NewTime(now.Add(-time.Minute)),
+			status: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				ConfigurationSecretName: "foo-encryption-new",
+				PrimaryKeyName:          "key-1",
+				KeyRotation: controlplanev1.KubeadmControlPlaneEncryptionKeyRotationStatus{
+					Phase:              controlplanev1.KubeadmControlPlaneEncryptionKeyRotationRemovingKeyPhase,
+					NewKeyName:         "key-2",
+					NewKeyCreationTime: metav1.NewTime(now.Add(-time.Second).Truncate(time.Second)),
+				},
+			},
+			wantStatus: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				ConfigurationSecretName: "foo-encryption-new",
+				PrimaryKeyName:          "key-2",
+				PrimaryKeyCreationTime:  metav1.NewTime(now.Add(-time.Second).Truncate(time.Second)),
+			},
+			wantKeyNames: []string{"key-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "kcp-uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+						Provider:       controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC,
+						RotateKeyAfter: tt.rotateKeyAfter,
+					},
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					Encryption: tt.status,
+				},
+			}
+			controllerRef := *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
+			keysSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secret.Name(cluster.Name, secret.EncryptionKeys),
+					Namespace: metav1.NamespaceDefault,
+				},
+				Data: map[string][]byte{"key-1": []byte("key-1")},
+			}
+			if tt.status.KeyRotation.NewKeyName != "" {
+				keysSecret.Data[tt.status.KeyRotation.NewKeyName] = []byte(tt.status.KeyRotation.NewKeyName)
+			}
+			withKeyRotationAnnotations(keysSecret, tt.status.PrimaryKeyName, tt.status.KeyRotation.Phase, tt.status.KeyRotation.NewKeyName)
+			oldConfigSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "foo-encryption-old",
+					Namespace:       metav1.NamespaceDefault,
+					Labels:          map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+					OwnerReferences: []metav1.OwnerReference{controllerRef},
+				},
+			}
+			newConfigSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "foo-encryption-new",
+					Namespace:       metav1.NamespaceDefault,
+					Labels:          map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+					OwnerReferences: []metav1.OwnerReference{controllerRef},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(keysSecret, oldConfigSecret, newConfigSecret).Build()
+
+			workloadCluster := &fakeWorkloadCluster{}
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: cluster,
+			}
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(32),
+			}
+			result, err := r.reconcileEncryptionKeyRotation(ctx, controlPlane, workloadCluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(workloadCluster.rewriteSecretsCalled).To(Equal(tt.wantRewriteSecretsCalled))
+
+			g.Expect(kcp.Status.Encryption.KeyRotation.Phase).To(Equal(tt.wantKeyRotationPhase))
+			if tt.wantKeyRotationPhase == controlplanev1.KubeadmControlPlaneEncryptionKeyRotationAddingKeyPhase {
+				// A new key has been generated.
+				g.Expect(kcp.Status.Encryption.KeyRotation.NewKeyName).ToNot(BeEmpty())
+				g.Expect(kcp.Status.Encryption.KeyRotation.NewKeyCreationTime.IsZero()).To(BeFalse())
+				tt.wantKeyNames = []string{"key-1", kcp.Status.Encryption.KeyRotation.NewKeyName}
+			}
+			if tt.wantKeyRotationPhase == "" && tt.wantStatus.PrimaryKeyName != "" {
+				g.Expect(kcp.Status.Encryption).To(Equal(tt.wantStatus))
+				// Stale encryption configurations have been deleted.
+				g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(oldConfigSecret), &corev1.Secret{}))).To(BeTrue())
+				g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(newConfigSecret), &corev1.Secret{})).To(Succeed())
+			}
+
+			gotKeysSecret := &corev1.Secret{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(keysSecret), gotKeysSecret)).To(Succeed())
+			keyNames := []string{}
+			for name := range gotKeysSecret.Data {
+				keyNames = append(keyNames, name)
+			}
+			g.Expect(keyNames).To(ConsistOf(tt.wantKeyNames))
+
+			// The state of the key rotation is tracked on the keys Secret.
+			g.Expect(gotKeysSecret.Annotations).To(HaveKeyWithValue(controlplanev1.EncryptionPrimaryKeyAnnotation, kcp.Status.Encryption.PrimaryKeyName))
+			if tt.wantKeyRotationPhase == "" {
+				g.Expect(gotKeysSecret.Annotations).ToNot(HaveKey(controlplanev1.EncryptionKeyRotationPhaseAnnotation))
+				g.Expect(gotKeysSecret.Annotations).ToNot(HaveKey(controlplanev1.EncryptionNewKeyAnnotation))
+			} else {
+				g.Expect(gotKeysSecret.Annotations).To(HaveKeyWithValue(controlplanev1.EncryptionKeyRotationPhaseAnnotation, string(tt.wantKeyRotationPhase)))
+				g.Expect(gotKeysSecret.Annotations).To(HaveKeyWithValue(controlplanev1.EncryptionNewKeyAnnotation, kcp.Status.Encryption.KeyRotation.NewKeyName))
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileEtcdLearners promotes etcd learner members to voting members as soon as they are in sync with the leader.
+// Machines hosting a learner which is not promoted within etcdLearnerPromotionTimeout are remediated by deleting them,
+// honouring the KCP remediation limits; the Machine is then replaced by a new one when scaling up.
+// NOTE: Other operations on the control plane are deferred until learners are promoted.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdLearners(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !feature.Gates.Enabled(feature.KubeadmControlPlaneEtcdLearnerMode) || !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// Collect the Machines hosting a learner.
+	// Note: Members without a name are learners which did not start yet, they are handled as soon as they get a name.
+	// Note: Learners hosted on deleting Machines are removed by the pre-terminate hook.
+	var learnerMachines []*clusterv1.Machine
+	for _, member := range controlPlane.EtcdMembers {
+		if !member.IsLearner || member.Name == "" {
+			continue
+		}
+		for _, machine := range controlPlane.Machines {
+			if machine.Status.NodeRef.IsDefined() && machine.Status.NodeRef.Name == member.Name && machine.DeletionTimestamp.IsZero() {
+				learnerMachines = append(learnerMachines, machine)
+				break
+			}
+		}
+	}
+	if len(learnerMachines) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+	}
+
+	result := ctrl.Result{}
+	for _, machine := range learnerMachines {
+		log := log.WithValues("Machine", klog.KObj(machine))
+
+		err := workloadCluster.PromoteEtcdLearnerForMachine(ctx, machine)
+		if err == nil {
+			log.Info("Promoted etcd learner to voting member", "Node", machine.Status.NodeRef.Name)
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "EtcdLearnerPromoted", "Promoted etcd learner hosted on Machine %s to voting member", machine.Name)
+			continue
+		}
+		if !errors.Is(err, etcd.ErrLearnerNotReady) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to promote etcd learner hosted on Machine %s", machine.Name)
+		}
+
+		// The learner is not yet in sync with the leader; remediate the Machine if this takes too long.
+		// Note: The time is measured from when the EtcdMemberHealthy condition became false, i.e. since when the
+		// Machine has a Node but not a voting etcd member.
+		c := conditions.Get(machine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition)
+		if c != nil && c.Reason == controlplanev1.KubeadmControlPlaneMachineEtcdMemberLearnerReason && time.Since(c.LastTransitionTime.Time) > etcdLearnerPromotionTimeout {
+			deleted, err := r.remediateEtcdLearner(ctx, controlPlane, machine)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if deleted {
+				return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+			}
+		}
+		result = ctrl.Result{RequeueAfter: etcdLearnerPromotionRequeueAfter}
+	}
+	return result, nil
+}
+
+// remediateEtcdLearner deletes a Machine hosting an etcd learner which never caught up with the leader.
+// Removing a learner is always safe because learners are not voting members, so they do not contribute to the etcd quorum.
+// Like for unhealthy Machines, the remediation is tracked with the RemediationInProgressAnnotation on KCP and the
+// RemediationForAnnotation on the replacement Machine, so remediation limits in spec.remediation (MaxRetry,
+// RetryPeriodSeconds, MinHealthyPeriodSeconds) are honoured; it returns true if the Machine has been deleted.
+// NOTE: The etcd member is removed by the pre-terminate hook.
+func (r *KubeadmControlPlaneReconciler) remediateEtcdLearner(ctx context.Context, controlPlane *internal.ControlPlane, machine *clusterv1.Machine) (bool, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("Machine", klog.KObj(machine))
+
+	// Remediate one Machine at a time.
+	if controlPlane.HasDeletingMachine() {
+		return false, nil
+	}
+	remediationInProgress, err := isRemediationInProgress(controlPlane)
+	if err != nil {
+		return false, err
+	}
+	if remediationInProgress {
+		log.Info("Machine hosting an etcd learner needs remediation, but another remediation is already in progress. Skipping remediation")
+		return false, nil
+	}
+
+	// Check if KCP is allowed to remediate considering retry limits.
+	// Note: checkRetryLimits is called on a copy of the Machine because the MachineOwnerRemediated condition is
+	// only surfaced for Machines remediated by KCP on behalf of a MachineHealthCheck.
+	remediationInProgressData, canRemediate, err := r.checkRetryLimits(log, machine.DeepCopy(), controlPlane, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+	if !canRemediate {
+		return false, nil
+	}
+
+	if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to delete Machine %s hosting an etcd learner not promoted to voting member", machine.Name)
+	}
+
+	// Note: We intentionally log after Delete because we want this log line to show up only after DeletionTimestamp has been set.
+	log.Info("Deleting Machine (etcd learner not promoted to voting member)", "timeout", etcdLearnerPromotionTimeout.String(), "retryCount", remediationInProgressData.RetryCount)
+	r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "EtcdLearnerNotPromoted",
+		"Deleting Machine %s, the etcd learner hosted on it was not promoted to voting member within %s", machine.Name, etcdLearnerPromotionTimeout.String())
+
+	// Set annotations tracking remediation details so they can be picked up by the machine
+	// that will be created as part of the scale up action that completes the remediation.
+	remediationInProgressValue, err := remediationInProgressData.Marshal()
+	if err != nil {
+		return true, err
+	}
+	annotations.AddAnnotations(controlPlane.KCP, map[string]string{
+		controlplanev1.RemediationInProgressAnnotation: remediationInProgressValue,
+	})
+	return true, nil
+}
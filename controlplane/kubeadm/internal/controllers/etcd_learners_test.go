@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+func TestReconcileEtcdLearners(t *testing.T) {
+	learnerMachine := func(learnerSince time.Time) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "m2",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Status: clusterv1.MachineStatus{
+				NodeRef: clusterv1.MachineNodeReference{Name: "node-m2"},
+				Conditions: []metav1.Condition{
+					{
+						Type:               controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition,
+						Status:             metav1.ConditionFalse,
+						Reason:             controlplanev1.KubeadmControlPlaneMachineEtcdMemberLearnerReason,
+						LastTransitionTime: metav1.NewTime(learnerSince),
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                         string
+		featureGate                  bool
+		members                      []*etcd.Member
+		machine                      *clusterv1.Machine
+		promoteErr                   error
+		remediation                  controlplanev1.KubeadmControlPlaneRemediationSpec
+		remediationFor               *RemediationData
+		remediationInProgress        bool
+		wantResult                   ctrl.Result
+		wantErr                      bool
+		wantPromoteEtcdLearnerCalled int
+		wantMachineDeleted           bool
+		wantRemediationInProgress    bool
+	}{
+		{
+			name:        "no-op if the feature gate is disabled",
+			featureGate: false,
+			members:     []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:     learnerMachine(time.Now()),
+		},
+		{
+			name:        "no-op if there are no learners",
+			featureGate: true,
+			members:     []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2"}},
+			machine:     learnerMachine(time.Now()),
+		},
+		{
+			name:        "no-op if the learner does not have a name yet",
+			featureGate: true,
+			members:     []*etcd.Member{{Name: "node-m1"}, {IsLearner: true}},
+			machine:     learnerMachine(time.Now()),
+		},
+		{
+			name:                         "promote learners in sync with the leader",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now()),
+			wantPromoteEtcdLearnerCalled: 1,
+		},
+		{
+			name:                         "requeue if the learner is not in sync with the leader",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now()),
+			promoteErr:                   errors.Wrap(etcd.ErrLearnerNotReady, "failed to promote"),
+			wantResult:                   ctrl.Result{RequeueAfter: etcdLearnerPromotionRequeueAfter},
+			wantPromoteEtcdLearnerCalled: 1,
+		},
+		{
+			name:                         "remediate the Machine if the learner is not in sync with the leader after the timeout",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now().Add(-2 * etcdLearnerPromotionTimeout)),
+			promoteErr:                   errors.Wrap(etcd.ErrLearnerNotReady, "failed to promote"),
+			wantResult:                   ctrl.Result{RequeueAfter: deleteRequeueAfter},
+			wantPromoteEtcdLearnerCalled: 1,
+			wantMachineDeleted:           true,
+			wantRemediationInProgress:    true,
+		},
+		{
+			name:                         "do not remediate the Machine if another remediation is in progress",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now().Add(-2 * etcdLearnerPromotionTimeout)),
+			promoteErr:                   errors.Wrap(etcd.ErrLearnerNotReady, "failed to promote"),
+			remediationInProgress:        true,
+			wantResult:                   ctrl.Result{RequeueAfter: etcdLearnerPromotionRequeueAfter},
+			wantPromoteEtcdLearnerCalled: 1,
+			wantRemediationInProgress:    true,
+		},
+		{
+			name:                         "do not remediate the Machine if MaxRetry is reached",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now().Add(-2 * etcdLearnerPromotionTimeout)),
+			promoteErr:                   errors.Wrap(etcd.ErrLearnerNotReady, "failed to promote"),
+			remediation:                  controlplanev1.KubeadmControlPlaneRemediationSpec{MaxRetry: ptr.To[int32](3)},
+			remediationFor:               &RemediationData{Machine: "m0", Timestamp: metav1.Now(), RetryCount: 3},
+			wantResult:                   ctrl.Result{RequeueAfter: etcdLearnerPromotionRequeueAfter},
+			wantPromoteEtcdLearnerCalled: 1,
+		},
+		{
+			name:                         "remediate the Machine if MaxRetry is not reached",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now().Add(-2 * etcdLearnerPromotionTimeout)),
+			promoteErr:                   errors.Wrap(etcd.ErrLearnerNotReady, "failed to promote"),
+			remediation:                  controlplanev1.KubeadmControlPlaneRemediationSpec{MaxRetry: ptr.To[int32](3)},
+			remediationFor:               &RemediationData{Machine: "m0", Timestamp: metav1.Now(), RetryCount: 2},
+			wantResult:                   ctrl.Result{RequeueAfter: deleteRequeueAfter},
+			wantPromoteEtcdLearnerCalled: 1,
+			wantMachineDeleted:           true,
+			wantRemediationInProgress:    true,
+		},
+		{
+			name:                         "return error if promotion fails",
+			featureGate:                  true,
+			members:                      []*etcd.Member{{Name: "node-m1"}, {Name: "node-m2", IsLearner: true}},
+			machine:                      learnerMachine(time.Now()),
+			promoteErr:                   errors.New("failed to promote"),
+			wantErr:                      true,
+			wantPromoteEtcdLearnerCalled: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEtcdLearnerMode, tt.featureGate)
+
+			m1 := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "m1",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Status: clusterv1.MachineStatus{
+					NodeRef: clusterv1.MachineNodeReference{Name: "node-m1"},
+				},
+			}
+			if tt.remediationFor != nil {
+				remediationFor, err := tt.remediationFor.Marshal()
+				g.Expect(err).ToNot(HaveOccurred())
+				tt.machine.Annotations = map[string]string{controlplanev1.RemediationForAnnotation: remediationFor}
+			}
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Remediation: tt.remediation,
+				},
+			}
+			if tt.remediationInProgress {
+				remediationInProgress, err := (&RemediationData{Machine: "m0", Timestamp: metav1.Now()}).Marshal()
+				g.Expect(err).ToNot(HaveOccurred())
+				kcp.Annotations = map[string]string{controlplanev1.RemediationInProgressAnnotation: remediationInProgress}
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(m1, tt.machine).Build()
+
+			workloadCluster := &fakeWorkloadCluster{
+				PromoteEtcdLearnerErr: tt.promoteErr,
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:         kcp,
+				Cluster:     &clusterv1.Cluster{},
+				Machines:    collections.FromMachines(m1, tt.machine),
+				EtcdMembers: tt.members,
+			}
+			controlPlane.InjectTestManagementCluster(&fakeManagementCluster{Workload: workloadCluster})
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(32),
+			}
+			result, err := r.reconcileEtcdLearners(ctx, controlPlane)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(workloadCluster.promoteEtcdLearnerCalled).To(Equal(tt.wantPromoteEtcdLearnerCalled))
+
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.machine), &clusterv1.Machine{})
+			g.Expect(apierrors.IsNotFound(err)).To(Equal(tt.wantMachineDeleted))
+			_, remediationInProgress := kcp.Annotations[controlplanev1.RemediationInProgressAnnotation]
+			g.Expect(remediationInProgress).To(Equal(tt.wantRemediationInProgress))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileEtcdMaintenance reports the status of the etcd members and runs maintenance operations on the etcd cluster.
+// Maintenance operations are run one at a time, one per reconcile; remediation of the NOSPACE alarm takes precedence
+// over compaction, which takes precedence over defragmentation.
+// NOTE: This func assumes the control plane is stable, i.e. no Machine is being rolled out, remediated, created or deleted.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdMaintenance(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !feature.Gates.Enabled(feature.KubeadmControlPlaneEtcdMaintenance) || !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// Maintenance operations require all the etcd members to be hosted on a Machine owned by KCP.
+	if !controlPlane.EtcdMembersAndMachinesAreMatching {
+		log.V(5).Info("Skipping etcd maintenance, etcd members and Machines are not matching")
+		return ctrl.Result{}, nil
+	}
+
+	// Maintenance operations are not run while a learner is joining the etcd cluster.
+	for _, member := range controlPlane.EtcdMembers {
+		if member.IsLearner {
+			log.V(5).Info("Skipping etcd maintenance, etcd has learner members")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	machinesByNodeName := map[string]*clusterv1.Machine{}
+	for _, machine := range controlPlane.Machines.Filter(collections.HasNode()) {
+		machinesByNodeName[machine.Status.NodeRef.Name] = machine
+	}
+	nodeNames := make([]string, 0, len(machinesByNodeName))
+	for nodeName := range machinesByNodeName {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	if len(nodeNames) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	memberStatuses, err := workloadCluster.EtcdMemberDBStatuses(ctx, nodeNames)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to get the status of etcd members")
+	}
+	setEtcdMemberStatuses(controlPlane.KCP, memberStatuses)
+
+	maintenance := controlPlane.KCP.Spec.EtcdMaintenance
+	if maintenance.NoSpaceAlarmRemediation == controlplanev1.KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate && hasEtcdAlarm(controlPlane.EtcdAlarms, etcd.AlarmNoSpace) {
+		return r.remediateEtcdNoSpaceAlarm(ctx, controlPlane, workloadCluster, machinesByNodeName, memberStatuses, nodeNames)
+	}
+
+	if etcdCompactionIsDue(controlPlane.KCP, time.Now()) {
+		log.Info("Compacting etcd")
+		if err := workloadCluster.CompactEtcdHistory(ctx, nodeNames, etcdCompactionRetainedRevisions); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to compact etcd")
+		}
+		controlPlane.KCP.Status.Etcd.LastScheduledCompactionTime = metav1.Now().Rfc3339Copy()
+		return ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter}, nil
+	}
+
+	member := etcdMemberToDefragment(controlPlane.KCP, memberStatuses, time.Now(), func(controlplanev1.KubeadmControlPlaneEtcdMemberStatus) bool { return false })
+	if member == nil {
+		return ctrl.Result{}, nil
+	}
+	log.Info("Defragmenting etcd member", "Node", member.Name)
+	if err := defragmentEtcdMember(ctx, controlPlane, workloadCluster, machinesByNodeName, *member); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter}, nil
+}
+
+// remediateEtcdNoSpaceAlarm remediates the NOSPACE alarm by compacting the etcd keyspace, then defragmenting all
+// the members one at a time, and finally disarming the alarm.
+// Progress is tracked using timestamps in the KCP status, so the remediation can continue across reconciles.
+func (r *KubeadmControlPlaneReconciler) remediateEtcdNoSpaceAlarm(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster, machinesByNodeName map[string]*clusterv1.Machine, memberStatuses []internal.EtcdMemberDBStatus, nodeNames []string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	etcdStatus := &controlPlane.KCP.Status.Etcd
+
+	// Compact the keyspace if this was not done yet for the current alarm, i.e. if the last compaction
+	// happened before the alarm was last disarmed.
+	if etcdStatus.LastCompactionTime.IsZero() || !etcdStatus.LastCompactionTime.After(etcdStatus.LastAlarmDisarmTime.Time) {
+		log.Info("Compacting etcd to remediate the NOSPACE alarm")
+		if err := workloadCluster.CompactEtcd(ctx, nodeNames); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to compact etcd")
+		}
+		etcdStatus.LastCompactionTime = metav1.Now().Rfc3339Copy()
+		return ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter}, nil
+	}
+
+	// Defragment all the members which have not been defragmented after the compaction, to reclaim
+	// the space freed by the compaction.
+	lastCompactionTime := etcdStatus.LastCompactionTime
+	member := etcdMemberToDefragment(controlPlane.KCP, memberStatuses, time.Now(), func(s controlplanev1.KubeadmControlPlaneEtcdMemberStatus) bool {
+		return s.LastDefragmentationTime.Before(&lastCompactionTime)
+	})
+	if member != nil {
+		log.Info("Defragmenting etcd member to remediate the NOSPACE alarm", "Node", member.Name)
+		if err := defragmentEtcdMember(ctx, controlPlane, workloadCluster, machinesByNodeName, *member); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter}, nil
+	}
+
+	log.Info("Disarming the etcd NOSPACE alarm")
+	if err := workloadCluster.DisarmEtcdAlarms(ctx, nodeNames, etcd.AlarmNoSpace); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to disarm the etcd NOSPACE alarm")
+	}
+	etcdStatus.LastAlarmDisarmTime = metav1.Now().Rfc3339Copy()
+	return ctrl.Result{}, nil
+}
+
+// defragmentEtcdMember defragments an etcd member; if the member is the etcd leader, leadership is moved
+// to another member before defragmenting it.
+func defragmentEtcdMember(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster, machinesByNodeName map[string]*clusterv1.Machine, member internal.EtcdMemberDBStatus) error {
+	if member.IsLeader && len(machinesByNodeName) > 1 {
+		// Move leadership to the oldest Machine hosting a healthy etcd member; if there is none, defragmenting
+		// the leader would make the etcd cluster unavailable, so defragmentation is deferred.
+		var leaderCandidate *clusterv1.Machine
+		for _, m := range controlPlane.Machines.Filter(collections.HasNode()).SortedByCreationTimestamp() {
+			if m.Status.NodeRef.Name != member.Name && conditions.IsTrue(m, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition) {
+				leaderCandidate = m
+				break
+			}
+		}
+		if leaderCandidate == nil {
+			return errors.Errorf("failed to defragment the etcd member hosted on Node %s: there is no healthy etcd member to move etcd leadership to", member.Name)
+		}
+		if err := workloadCluster.ForwardEtcdLeadership(ctx, machinesByNodeName[member.Name], leaderCandidate); err != nil {
+			return errors.Wrapf(err, "failed to move etcd leadership away from the member hosted on Node %s", member.Name)
+		}
+	}
+
+	if err := workloadCluster.DefragmentEtcdMember(ctx, member.Name); err != nil {
+		return errors.Wrapf(err, "failed to defragment the etcd member hosted on Node %s", member.Name)
+	}
+
+	now := metav1.Now().Rfc3339Copy()
+	for i := range controlPlane.KCP.Status.Etcd.Members {
+		if controlPlane.KCP.Status.Etcd.Members[i].Name == member.Name {
+			controlPlane.KCP.Status.Etcd.Members[i].LastDefragmentationTime = now
+		}
+	}
+	return nil
+}
+
+// etcdCompactionIsDue returns true if the etcd keyspace must be compacted because the last compaction,
+// including compactions run while remediating the NOSPACE alarm, is older than the configured interval.
+func etcdCompactionIsDue(kcp *controlplanev1.KubeadmControlPlane, now time.Time) bool {
+	intervalSeconds := kcp.Spec.EtcdMaintenance.Compaction.IntervalSeconds
+	if intervalSeconds == nil {
+		return false
+	}
+
+	lastCompactionTime := kcp.Status.Etcd.LastScheduledCompactionTime.Time
+	if kcp.Status.Etcd.LastCompactionTime.After(lastCompactionTime) {
+		lastCompactionTime = kcp.Status.Etcd.LastCompactionTime.Time
+	}
+	return now.Sub(lastCompactionTime) >= time.Duration(*intervalSeconds)*time.Second
+}
+
+// etcdMemberToDefragment returns the next etcd member to defragment, if any.
+// A member is defragmented if forceDefragmentation returns true for it, if its last defragmentation is older than
+// the configured interval, or if its fragmentation exceeds the configured threshold and it has not been defragmented
+// in the last etcdMinDefragmentationInterval.
+// Followers are defragmented first, the leader last.
+func etcdMemberToDefragment(kcp *controlplanev1.KubeadmControlPlane, memberStatuses []internal.EtcdMemberDBStatus, now time.Time, forceDefragmentation func(controlplanev1.KubeadmControlPlaneEtcdMemberStatus) bool) *internal.EtcdMemberDBStatus {
+	defragmentation := kcp.Spec.EtcdMaintenance.Defragmentation
+
+	var candidates []internal.EtcdMemberDBStatus
+	for _, member := range memberStatuses {
+		status := etcdMemberStatus(kcp, member.Name)
+
+		switch {
+		case forceDefragmentation(status):
+			candidates = append(candidates, member)
+		case defragmentation.IntervalSeconds != nil &&
+			now.Sub(status.LastDefragmentationTime.Time) >= time.Duration(*defragmentation.IntervalSeconds)*time.Second:
+			candidates = append(candidates, member)
+		case defragmentation.FragmentationThresholdPercent != nil && member.DBSize > 0 &&
+			(member.DBSize-member.DBSizeInUse)*100/member.DBSize >= int64(*defragmentation.FragmentationThresholdPercent) &&
+			now.Sub(status.LastDefragmentationTime.Time) >= etcdMinDefragmentationInterval:
+			candidates = append(candidates, member)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].IsLeader != candidates[j].IsLeader {
+			return !candidates[i].IsLeader
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return &candidates[0]
+}
+
+// setEtcdMemberStatuses sets the status of the etcd members in the KCP status, preserving the time of the
+// last defragmentation of each member.
+func setEtcdMemberStatuses(kcp *controlplanev1.KubeadmControlPlane, memberStatuses []internal.EtcdMemberDBStatus) {
+	members := make([]controlplanev1.KubeadmControlPlaneEtcdMemberStatus, 0, len(memberStatuses))
+	for _, member := range memberStatuses {
+		status := etcdMemberStatus(kcp, member.Name)
+		status.DBSizeBytes = ptr.To(member.DBSize)
+		status.DBSizeInUseBytes = ptr.To(member.DBSizeInUse)
+		members = append(members, status)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Name < members[j].Name
+	})
+	kcp.Status.Etcd.Members = members
+}
+
+func etcdMemberStatus(kcp *controlplanev1.KubeadmControlPlane, name string) controlplanev1.KubeadmControlPlaneEtcdMemberStatus {
+	for _, m := range kcp.Status.Etcd.Members {
+		if m.Name == name {
+			return m
+		}
+	}
+	return controlplanev1.KubeadmControlPlaneEtcdMemberStatus{Name: name}
+}
+
+func hasEtcdAlarm(alarms []etcd.MemberAlarm, alarmType etcd.AlarmType) bool {
+	for _, alarm := range alarms {
+		if alarm.Type == alarmType {
+			return true
+		}
+	}
+	return false
+}
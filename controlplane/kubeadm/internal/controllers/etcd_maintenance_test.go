@@ -0,0 +1,368 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+func TestReconcileEtcdMaintenance(t *testing.T) {
+	now := metav1.Now().Rfc3339Copy()
+	anHourAgo := metav1.NewTime(now.Add(-time.Hour))
+	twoHoursAgo := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	memberStatuses := []internal.EtcdMemberDBStatus{
+		{Name: "node-m1", ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 40},
+		{Name: "node-m2", ID: 2, DBSize: 100, DBSizeInUse: 90},
+		{Name: "node-m3", ID: 3, DBSize: 100, DBSizeInUse: 40},
+	}
+
+	tests := []struct {
+		name                              string
+		featureGate                       bool
+		etcdMembersAndMachinesAreMatching bool
+		maintenance                       controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec
+		etcdStatus                        controlplanev1.KubeadmControlPlaneEtcdStatus
+		alarms                            []etcd.MemberAlarm
+		unhealthyEtcdMembers              []string
+		wantErr                           bool
+		wantResult                        ctrl.Result
+		wantDefragmented                  []string
+		wantForwardEtcdLeadershipCalled   int
+		wantEtcdLeaderCandidates          []string
+		wantCompactEtcdCalled             int
+		wantCompactEtcdHistoryCalled      int
+		wantDisarmedAlarms                []etcd.AlarmType
+		wantMemberStatuses                bool
+		wantLastCompactionTime            bool
+		wantLastScheduledCompactionTime   bool
+		wantLastAlarmDisarmTime           bool
+	}{
+		{
+			name:                              "no-op if the feature gate is disabled",
+			featureGate:                       false,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{FragmentationThresholdPercent: ptr.To[int32](50)},
+			},
+		},
+		{
+			name:                              "no-op if etcd members and Machines are not matching",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: false,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{FragmentationThresholdPercent: ptr.To[int32](50)},
+			},
+		},
+		{
+			name:                              "report member statuses if no maintenance is required",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			wantMemberStatuses:                true,
+		},
+		{
+			name:                              "defragment members exceeding the fragmentation threshold, followers first",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{FragmentationThresholdPercent: ptr.To[int32](50)},
+			},
+			wantResult:         ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantDefragmented:   []string{"node-m3"},
+			wantMemberStatuses: true,
+		},
+		{
+			name:                              "defragment the leader last, after moving leadership",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{IntervalSeconds: ptr.To[int32](7200)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1"},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+			},
+			wantResult:                      ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantDefragmented:                []string{"node-m1"},
+			wantForwardEtcdLeadershipCalled: 1,
+			wantEtcdLeaderCandidates:        []string{"m2"},
+			wantMemberStatuses:              true,
+		},
+		{
+			name:                              "move leadership to a healthy etcd member before defragmenting the leader",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{IntervalSeconds: ptr.To[int32](7200)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1"},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+			},
+			unhealthyEtcdMembers:            []string{"m2"},
+			wantResult:                      ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantDefragmented:                []string{"node-m1"},
+			wantForwardEtcdLeadershipCalled: 1,
+			wantEtcdLeaderCandidates:        []string{"m3"},
+			wantMemberStatuses:              true,
+		},
+		{
+			name:                              "do not defragment the leader if there is no healthy etcd member to move leadership to",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{IntervalSeconds: ptr.To[int32](7200)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1"},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+			},
+			unhealthyEtcdMembers: []string{"m2", "m3"},
+			wantErr:              true,
+			wantMemberStatuses:   true,
+		},
+		{
+			name:                              "do not defragment members exceeding the fragmentation threshold defragmented recently",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{FragmentationThresholdPercent: ptr.To[int32](50)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1", LastDefragmentationTime: now},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+			},
+			wantMemberStatuses: true,
+		},
+		{
+			name:                              "do not defragment members defragmented within the interval",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{IntervalSeconds: ptr.To[int32](7200)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1", LastDefragmentationTime: anHourAgo},
+					{Name: "node-m2", LastDefragmentationTime: anHourAgo},
+					{Name: "node-m3", LastDefragmentationTime: anHourAgo},
+				},
+			},
+			wantMemberStatuses: true,
+		},
+		{
+			name:                              "compact the keyspace if the last compaction is older than the interval",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Compaction: controlplanev1.KubeadmControlPlaneEtcdCompactionSpec{IntervalSeconds: ptr.To[int32](3600)},
+				Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+					FragmentationThresholdPercent: ptr.To[int32](50),
+				},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				LastScheduledCompactionTime: twoHoursAgo,
+			},
+			wantResult:                      ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantCompactEtcdHistoryCalled:    1,
+			wantMemberStatuses:              true,
+			wantLastScheduledCompactionTime: true,
+		},
+		{
+			name:                              "do not compact the keyspace if it has been compacted within the interval while remediating the NOSPACE alarm",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				Compaction: controlplanev1.KubeadmControlPlaneEtcdCompactionSpec{IntervalSeconds: ptr.To[int32](7200)},
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				LastCompactionTime:          anHourAgo,
+				LastScheduledCompactionTime: twoHoursAgo,
+			},
+			wantMemberStatuses: true,
+		},
+		{
+			name:                              "do not remediate the NOSPACE alarm if remediation is not enabled",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			alarms:                            []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantMemberStatuses:                true,
+		},
+		{
+			name:                              "remediate the NOSPACE alarm: compact",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				NoSpaceAlarmRemediation: controlplanev1.KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate,
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				LastCompactionTime:  twoHoursAgo,
+				LastAlarmDisarmTime: anHourAgo,
+			},
+			alarms:                 []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantResult:             ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantCompactEtcdCalled:  1,
+			wantMemberStatuses:     true,
+			wantLastCompactionTime: true,
+		},
+		{
+			name:                              "remediate the NOSPACE alarm: defragment members after compaction",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				NoSpaceAlarmRemediation: controlplanev1.KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate,
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1", LastDefragmentationTime: twoHoursAgo},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+				LastCompactionTime: anHourAgo,
+			},
+			alarms:                          []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantResult:                      ctrl.Result{RequeueAfter: etcdMaintenanceRequeueAfter},
+			wantDefragmented:                []string{"node-m1"},
+			wantForwardEtcdLeadershipCalled: 1,
+			wantEtcdLeaderCandidates:        []string{"m2"},
+			wantMemberStatuses:              true,
+		},
+		{
+			name:                              "remediate the NOSPACE alarm: disarm after all members have been defragmented",
+			featureGate:                       true,
+			etcdMembersAndMachinesAreMatching: true,
+			maintenance: controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+				NoSpaceAlarmRemediation: controlplanev1.KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate,
+			},
+			etcdStatus: controlplanev1.KubeadmControlPlaneEtcdStatus{
+				Members: []controlplanev1.KubeadmControlPlaneEtcdMemberStatus{
+					{Name: "node-m1", LastDefragmentationTime: now},
+					{Name: "node-m2", LastDefragmentationTime: now},
+					{Name: "node-m3", LastDefragmentationTime: now},
+				},
+				LastCompactionTime: anHourAgo,
+			},
+			alarms:                  []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantDisarmedAlarms:      []etcd.AlarmType{etcd.AlarmNoSpace},
+			wantMemberStatuses:      true,
+			wantLastAlarmDisarmTime: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEtcdMaintenance, tt.featureGate)
+
+			machines := collections.Machines{}
+			for i, name := range []string{"m1", "m2", "m3"} {
+				etcdMemberHealthy := metav1.ConditionTrue
+				if slices.Contains(tt.unhealthyEtcdMembers, name) {
+					etcdMemberHealthy = metav1.ConditionFalse
+				}
+				machines.Insert(&clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+					},
+					Status: clusterv1.MachineStatus{
+						NodeRef: clusterv1.MachineNodeReference{Name: "node-" + name},
+						Conditions: []metav1.Condition{
+							{Type: controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition, Status: etcdMemberHealthy},
+						},
+					},
+				})
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP: &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						EtcdMaintenance: tt.maintenance,
+					},
+					Status: controlplanev1.KubeadmControlPlaneStatus{
+						Etcd: tt.etcdStatus,
+					},
+				},
+				Machines:                          machines,
+				EtcdMembersAndMachinesAreMatching: tt.etcdMembersAndMachinesAreMatching,
+				EtcdAlarms:                        tt.alarms,
+			}
+			workloadCluster := &fakeWorkloadCluster{
+				EtcdMemberDBStatusesResult: memberStatuses,
+			}
+
+			r := &KubeadmControlPlaneReconciler{}
+			result, err := r.reconcileEtcdMaintenance(ctx, controlPlane, workloadCluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal(tt.wantDefragmented))
+			g.Expect(workloadCluster.forwardEtcdLeadershipCalled).To(Equal(tt.wantForwardEtcdLeadershipCalled))
+			g.Expect(workloadCluster.etcdLeaderCandidates).To(Equal(tt.wantEtcdLeaderCandidates))
+			g.Expect(workloadCluster.compactEtcdCalled).To(Equal(tt.wantCompactEtcdCalled))
+			g.Expect(workloadCluster.compactEtcdHistoryCalled).To(Equal(tt.wantCompactEtcdHistoryCalled))
+			g.Expect(workloadCluster.disarmedEtcdAlarms).To(Equal(tt.wantDisarmedAlarms))
+
+			etcdStatus := controlPlane.KCP.Status.Etcd
+			if !tt.wantMemberStatuses {
+				g.Expect(etcdStatus.Members).To(Equal(tt.etcdStatus.Members))
+				return
+			}
+			g.Expect(etcdStatus.Members).To(HaveLen(3))
+			for i, member := range etcdStatus.Members {
+				g.Expect(member.Name).To(Equal(memberStatuses[i].Name))
+				g.Expect(member.DBSizeBytes).To(Equal(ptr.To(memberStatuses[i].DBSize)))
+				g.Expect(member.DBSizeInUseBytes).To(Equal(ptr.To(memberStatuses[i].DBSizeInUse)))
+				if len(tt.wantDefragmented) > 0 && member.Name == tt.wantDefragmented[0] {
+					g.Expect(member.LastDefragmentationTime.Time).To(BeTemporally(">=", now.Time))
+				}
+			}
+			g.Expect(etcdStatus.LastCompactionTime.Time.After(tt.etcdStatus.LastCompactionTime.Time)).To(Equal(tt.wantLastCompactionTime))
+			g.Expect(etcdStatus.LastScheduledCompactionTime.Time.After(tt.etcdStatus.LastScheduledCompactionTime.Time)).To(Equal(tt.wantLastScheduledCompactionTime))
+			g.Expect(etcdStatus.LastAlarmDisarmTime.Time.After(tt.etcdStatus.LastAlarmDisarmTime.Time)).To(Equal(tt.wantLastAlarmDisarmTime))
+		})
+	}
+}
@@ -72,9 +72,18 @@ type fakeWorkloadCluster struct {
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
 	APIServerCertificateExpiry *time.Time
+	EtcdMemberDBStatusesResult []internal.EtcdMemberDBStatus
+	PromoteEtcdLearnerErr      error
 
 	forwardEtcdLeadershipCalled      int
+	etcdLeaderCandidates             []string
 	removeEtcdMemberForMachineCalled int
+	defragmentedEtcdMembers          []string
+	compactEtcdCalled                int
+	compactEtcdHistoryCalled         int
+	disarmedEtcdAlarms               []etcd.AlarmType
+	promoteEtcdLearnerCalled         int
+	rewriteSecretsCalled             int
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
@@ -82,9 +91,15 @@ func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *cluste
 	if leaderCandidate == nil {
 		return errors.New("leaderCandidate is nil")
 	}
+	f.etcdLeaderCandidates = append(f.etcdLeaderCandidates, leaderCandidate.Name)
 	return nil
 }
 
+func (f *fakeWorkloadCluster) PromoteEtcdLearnerForMachine(_ context.Context, _ *clusterv1.Machine) error {
+	f.promoteEtcdLearnerCalled++
+	return f.PromoteEtcdLearnerErr
+}
+
 func (f *fakeWorkloadCluster) ReconcileEtcdMembersAndControlPlaneNodes(_ context.Context, _ []*etcd.Member, _ []string) ([]string, error) {
 	return nil, nil
 }
@@ -131,3 +146,32 @@ func (m *fakeMigrator) Migrate(string, string, string, bool) (string, error) {
 	}
 	return m.migratedCorefile, nil
 }
+
+func (f *fakeWorkloadCluster) EtcdMemberDBStatuses(_ context.Context, _ []string) ([]internal.EtcdMemberDBStatus, error) {
+	return f.EtcdMemberDBStatusesResult, nil
+}
+
+func (f *fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, nodeName string) error {
+	f.defragmentedEtcdMembers = append(f.defragmentedEtcdMembers, nodeName)
+	return nil
+}
+
+func (f *fakeWorkloadCluster) CompactEtcd(_ context.Context, _ []string) error {
+	f.compactEtcdCalled++
+	return nil
+}
+
+func (f *fakeWorkloadCluster) CompactEtcdHistory(_ context.Context, _ []string, _ int64) error {
+	f.compactEtcdHistoryCalled++
+	return nil
+}
+
+func (f *fakeWorkloadCluster) DisarmEtcdAlarms(_ context.Context, _ []string, alarmType etcd.AlarmType) error {
+	f.disarmedEtcdAlarms = append(f.disarmedEtcdAlarms, alarmType)
+	return nil
+}
+
+func (f *fakeWorkloadCluster) RewriteSecrets(_ context.Context) error {
+	f.rewriteSecretsCalled++
+	return nil
+}
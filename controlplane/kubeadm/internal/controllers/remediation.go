@@ -123,29 +123,13 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 	// Returns if another remediation is in progress but the new Machine is not yet created.
 	// Note: This condition is checked after we check for machines to be remediated and if machineToBeRemediated
 	// is not being deleted to avoid unnecessary logs if no further remediation should be done.
-	if v, ok := controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
-		// Check if the annotation is stale; this might happen in case there is a crash in the controller in between
-		// when a new Machine is created and the annotation is eventually removed from KCP via defer patch at the end
-		// of KCP reconcile.
-		remediationData, err := RemediationDataFromAnnotation(v)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		staleAnnotation := false
-		for _, m := range controlPlane.Machines.UnsortedList() {
-			if m.CreationTimestamp.After(remediationData.Timestamp.Time) {
-				// Remove the annotation tracking that a remediation is in progress (the annotation is stale).
-				delete(controlPlane.KCP.Annotations, controlplanev1.RemediationInProgressAnnotation)
-				staleAnnotation = true
-				break
-			}
-		}
-
-		if !staleAnnotation {
-			log.Info("Another remediation is already in progress. Skipping remediation.")
-			return ctrl.Result{}, nil
-		}
+	remediationInProgress, err := isRemediationInProgress(controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if remediationInProgress {
+		log.Info("Another remediation is already in progress. Skipping remediation.")
+		return ctrl.Result{}, nil
 	}
 
 	patchHelper, err := patch.NewHelper(machineToBeRemediated, r.Client)
@@ -371,6 +355,32 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// isRemediationInProgress returns true if another remediation is in progress but the new Machine is not yet created.
+// If the RemediationInProgressAnnotation is stale, it is removed from KCP.
+func isRemediationInProgress(controlPlane *internal.ControlPlane) (bool, error) {
+	v, ok := controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	// Check if the annotation is stale; this might happen in case there is a crash in the controller in between
+	// when a new Machine is created and the annotation is eventually removed from KCP via defer patch at the end
+	// of KCP reconcile.
+	remediationData, err := RemediationDataFromAnnotation(v)
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range controlPlane.Machines.UnsortedList() {
+		if m.CreationTimestamp.After(remediationData.Timestamp.Time) {
+			// Remove the annotation tracking that a remediation is in progress (the annotation is stale).
+			delete(controlPlane.KCP.Annotations, controlplanev1.RemediationInProgressAnnotation)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // Gets the machine to be remediated, which is the "most broken" among the unhealthy machines, determined as the machine
 // having the highest priority issue that other machines have not.
 // The following issues are considered (from highest to lowest priority):
@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
@@ -48,6 +49,7 @@ func (r *KubeadmControlPlaneReconciler) initializeControlPlane(ctx context.Conte
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", controlPlane.KCP.Spec.Version)
 	}
 	internal.DefaultFeatureGates(bootstrapSpec, parsedVersion)
+	internal.SetEncryptionConfiguration(bootstrapSpec, controlPlane.KCP)
 
 	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
 	if err != nil {
@@ -87,6 +89,28 @@ func (r *KubeadmControlPlaneReconciler) scaleUpControlPlane(ctx context.Context,
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", controlPlane.KCP.Spec.Version)
 	}
 	internal.DefaultFeatureGates(bootstrapSpec, parsedVersion)
+	internal.SetEncryptionConfiguration(bootstrapSpec, controlPlane.KCP)
+
+	// kubeadm join reads feature gates and the API server configuration from the kubeadm-config ConfigMap, so ensure
+	// the EtcdLearnerMode feature gate and the encryption configuration are set there before joining a new control plane Machine.
+	etcdLearnerMode := feature.Gates.Enabled(feature.KubeadmControlPlaneEtcdLearnerMode) && controlPlane.IsEtcdManaged()
+	encryption := feature.Gates.Enabled(feature.KubeadmControlPlaneEncryptionAtRest) && controlPlane.KCP.Spec.Encryption.IsDefined()
+	if etcdLearnerMode || encryption {
+		workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+		}
+		kubeadmCMMutators := make([]func(*bootstrapv1.ClusterConfiguration), 0)
+		if etcdLearnerMode {
+			kubeadmCMMutators = append(kubeadmCMMutators, workloadCluster.UpdateFeatureGatesInKubeadmConfigMap(controlPlane.KCP.Spec.KubeadmConfigSpec, parsedVersion))
+		}
+		if encryption {
+			kubeadmCMMutators = append(kubeadmCMMutators, workloadCluster.UpdateEncryptionInKubeadmConfigMap(controlPlane.KCP))
+		}
+		if err := workloadCluster.UpdateClusterConfiguration(ctx, parsedVersion, kubeadmCMMutators...); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update the kubeadm-config ConfigMap")
+		}
+	}
 
 	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
 	if err != nil {
@@ -79,6 +79,9 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 		}
 	}
 
+	// Note: The encryption configuration must be set after the API server configuration, which otherwise overrides it.
+	kubeadmCMMutators = append(kubeadmCMMutators, workloadCluster.UpdateEncryptionInKubeadmConfigMap(controlPlane.KCP))
+
 	// collectively update Kubeadm config map
 	if err = workloadCluster.UpdateClusterConfiguration(ctx, parsedVersion, kubeadmCMMutators...); err != nil {
 		return ctrl.Result{}, err
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+const (
+	// EncryptionConfigurationKey is the key used to store the encryption configuration in the secret's data field.
+	EncryptionConfigurationKey = "encryption-configuration.yaml"
+
+	encryptionConfigurationDir    = "/etc/kubernetes/encryption"
+	encryptionConfigurationPath   = encryptionConfigurationDir + "/" + EncryptionConfigurationKey
+	encryptionConfigurationVolume = "encryption-configuration"
+	encryptionProviderConfigArg   = "encryption-provider-config"
+
+	// encryptionKMSSocketVolume is the name of the volume used to mount the directory of the KMS plugin unix socket.
+	encryptionKMSSocketVolume = "encryption-kms-socket"
+	kmsUnixEndpointPrefix     = "unix://"
+
+	// encryptionKeySize is the size of the keys used by the AESCBC, AESGCM and Secretbox providers.
+	encryptionKeySize = 32
+
+	encryptionKeyNamePrefix     = "key-"
+	encryptionKeyNameTimeLayout = "20060102150405"
+)
+
+// EncryptionKey is a key used to encrypt Secrets at rest.
+type EncryptionKey struct {
+	Name   string
+	Secret []byte
+}
+
+// NewEncryptionKey generates a new random EncryptionKey; the name of the key is derived from the given time.
+func NewEncryptionKey(now time.Time) (EncryptionKey, error) {
+	secret := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return EncryptionKey{}, errors.Wrap(err, "failed to generate encryption key")
+	}
+	return EncryptionKey{
+		Name:   encryptionKeyNamePrefix + now.UTC().Format(encryptionKeyNameTimeLayout),
+		Secret: secret,
+	}, nil
+}
+
+// EncryptionKeyCreationTime returns the time when a key generated by NewEncryptionKey was created, if it can be derived from its name.
+func EncryptionKeyCreationTime(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, encryptionKeyNamePrefix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(encryptionKeyNameTimeLayout, strings.TrimPrefix(name, encryptionKeyNamePrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// EncryptionConfiguration returns the EncryptionConfiguration used by the API server to encrypt Secrets at rest.
+// The first key is used for encryption, while all the keys are used for decryption.
+// NOTE: The identity provider is always added as the last provider, so Secrets which have not been encrypted yet
+// (e.g. Secrets written before enabling encryption) can still be read.
+func EncryptionConfiguration(encryption controlplanev1.KubeadmControlPlaneEncryptionSpec, keys []EncryptionKey) ([]byte, error) {
+	apiKeys := make([]apiserverv1.Key, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, apiserverv1.Key{
+			Name:   key.Name,
+			Secret: base64.StdEncoding.EncodeToString(key.Secret),
+		})
+	}
+	if encryption.Provider != controlplanev1.KubeadmControlPlaneEncryptionProviderKMS && len(apiKeys) == 0 {
+		return nil, errors.Errorf("at least one key is required for the %s encryption provider", encryption.Provider)
+	}
+
+	provider := apiserverv1.ProviderConfiguration{}
+	switch encryption.Provider {
+	case controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC:
+		provider.AESCBC = &apiserverv1.AESConfiguration{Keys: apiKeys}
+	case controlplanev1.KubeadmControlPlaneEncryptionProviderAESGCM:
+		provider.AESGCM = &apiserverv1.AESConfiguration{Keys: apiKeys}
+	case controlplanev1.KubeadmControlPlaneEncryptionProviderSecretbox:
+		provider.Secretbox = &apiserverv1.SecretboxConfiguration{Keys: apiKeys}
+	case controlplanev1.KubeadmControlPlaneEncryptionProviderKMS:
+		provider.KMS = &apiserverv1.KMSConfiguration{
+			APIVersion: "v2",
+			Name:       encryption.KMS.Name,
+			Endpoint:   encryption.KMS.Endpoint,
+		}
+		if encryption.KMS.TimeoutSeconds != nil {
+			provider.KMS.Timeout = &metav1.Duration{Duration: time.Duration(*encryption.KMS.TimeoutSeconds) * time.Second}
+		}
+	default:
+		return nil, errors.Errorf("unknown encryption provider %q", encryption.Provider)
+	}
+
+	config := &apiserverv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1.SchemeGroupVersion.String(),
+			Kind:       "EncryptionConfiguration",
+		},
+		Resources: []apiserverv1.ResourceConfiguration{
+			{
+				Resources: []string{"secrets"},
+				Providers: []apiserverv1.ProviderConfiguration{
+					provider,
+					{Identity: &apiserverv1.IdentityConfiguration{}},
+				},
+			},
+		},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal EncryptionConfiguration")
+	}
+	return data, nil
+}
+
+// SetEncryptionConfiguration adds to a KubeadmConfigSpec the file with the current encryption configuration,
+// as well as the API server flag and volume required to use it.
+// NOTE: The content of the file is read from the Secret with the current encryption configuration; given that
+// each configuration is stored in a different Secret, changing the encryption configuration triggers a rollout
+// of the control plane Machines.
+func SetEncryptionConfiguration(kubeadmConfigSpec *bootstrapv1.KubeadmConfigSpec, kcp *controlplanev1.KubeadmControlPlane) {
+	if !isEncryptionConfigured(kcp) {
+		return
+	}
+
+	kubeadmConfigSpec.Files = append(kubeadmConfigSpec.Files, bootstrapv1.File{
+		Path:        encryptionConfigurationPath,
+		Owner:       "root:root",
+		Permissions: "0600",
+		ContentFrom: bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: kcp.Status.Encryption.ConfigurationSecretName,
+				Key:  EncryptionConfigurationKey,
+			},
+		},
+	})
+	setEncryptionConfigurationInAPIServer(&kubeadmConfigSpec.ClusterConfiguration.APIServer, kcp.Spec.Encryption)
+}
+
+// isEncryptionConfigured returns true if the control plane Machines must use an encryption configuration.
+func isEncryptionConfigured(kcp *controlplanev1.KubeadmControlPlane) bool {
+	return feature.Gates.Enabled(feature.KubeadmControlPlaneEncryptionAtRest) &&
+		kcp.Spec.Encryption.IsDefined() &&
+		kcp.Status.Encryption.ConfigurationSecretName != ""
+}
+
+// setEncryptionConfigurationInAPIServer sets the API server flag and volumes required to use the encryption configuration.
+// With the KMS provider, the directory of the KMS plugin unix socket is mounted too, unless it is already mounted
+// by a user provided volume.
+func setEncryptionConfigurationInAPIServer(apiServer *bootstrapv1.APIServer, encryption controlplanev1.KubeadmControlPlaneEncryptionSpec) {
+	// Note: slices are cloned to avoid modifying slices shared with other objects, e.g. the KCP object.
+	apiServer.ExtraArgs = slices.DeleteFunc(slices.Clone(apiServer.ExtraArgs), func(arg bootstrapv1.Arg) bool {
+		return arg.Name == encryptionProviderConfigArg
+	})
+	apiServer.ExtraArgs = append(apiServer.ExtraArgs, bootstrapv1.Arg{
+		Name:  encryptionProviderConfigArg,
+		Value: ptr.To(encryptionConfigurationPath),
+	})
+
+	apiServer.ExtraVolumes = slices.DeleteFunc(slices.Clone(apiServer.ExtraVolumes), func(volume bootstrapv1.HostPathMount) bool {
+		return volume.Name == encryptionConfigurationVolume || volume.Name == encryptionKMSSocketVolume
+	})
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, bootstrapv1.HostPathMount{
+		Name:      encryptionConfigurationVolume,
+		HostPath:  encryptionConfigurationDir,
+		MountPath: encryptionConfigurationDir,
+		ReadOnly:  ptr.To(true),
+		PathType:  corev1.HostPathDirectoryOrCreate,
+	})
+
+	if encryption.Provider != controlplanev1.KubeadmControlPlaneEncryptionProviderKMS {
+		return
+	}
+	socketDir, ok := kmsSocketDir(encryption.KMS.Endpoint)
+	if !ok || slices.ContainsFunc(apiServer.ExtraVolumes, func(volume bootstrapv1.HostPathMount) bool {
+		return volume.MountPath == socketDir
+	}) {
+		return
+	}
+	// Note: The volume is not read-only, because connecting to a unix socket requires write access to it.
+	apiServer.ExtraVolumes = append(apiServer.ExtraVolumes, bootstrapv1.HostPathMount{
+		Name:      encryptionKMSSocketVolume,
+		HostPath:  socketDir,
+		MountPath: socketDir,
+		PathType:  corev1.HostPathDirectoryOrCreate,
+	})
+}
+
+// kmsSocketDir returns the directory of the unix socket of a KMS plugin endpoint, e.g. /var/run/kms-plugin
+// for unix:///var/run/kms-plugin/socket.sock; it returns false if the endpoint is not a unix socket with an
+// absolute path in a directory other than the root directory.
+func kmsSocketDir(endpoint string) (string, bool) {
+	socketPath, ok := strings.CutPrefix(endpoint, kmsUnixEndpointPrefix)
+	if !ok || !path.IsAbs(socketPath) {
+		return "", false
+	}
+	socketDir := path.Dir(path.Clean(socketPath))
+	if socketDir == "/" {
+		return "", false
+	}
+	return socketDir, true
+}
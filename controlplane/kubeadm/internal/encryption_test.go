@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestNewEncryptionKey(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC)
+	key, err := NewEncryptionKey(now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(key.Name).To(Equal("key-20250309090000"))
+	g.Expect(key.Secret).To(HaveLen(encryptionKeySize))
+
+	otherKey, err := NewEncryptionKey(now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(otherKey.Secret).ToNot(Equal(key.Secret))
+
+	creationTime, ok := EncryptionKeyCreationTime(key.Name)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(creationTime).To(Equal(now))
+
+	_, ok = EncryptionKeyCreationTime("custom-key")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestEncryptionConfiguration(t *testing.T) {
+	keys := []EncryptionKey{
+		{Name: "key-2", Secret: []byte("secret-2")},
+		{Name: "key-1", Secret: []byte("secret-1")},
+	}
+	wantKeys := []apiserverv1.Key{
+		{Name: "key-2", Secret: "c2VjcmV0LTI="},
+		{Name: "key-1", Secret: "c2VjcmV0LTE="},
+	}
+
+	tests := []struct {
+		name         string
+		encryption   controlplanev1.KubeadmControlPlaneEncryptionSpec
+		keys         []EncryptionKey
+		wantProvider apiserverv1.ProviderConfiguration
+		wantErr      bool
+	}{
+		{
+			name:         "AESCBC",
+			encryption:   controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			keys:         keys,
+			wantProvider: apiserverv1.ProviderConfiguration{AESCBC: &apiserverv1.AESConfiguration{Keys: wantKeys}},
+		},
+		{
+			name:         "AESGCM",
+			encryption:   controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESGCM},
+			keys:         keys,
+			wantProvider: apiserverv1.ProviderConfiguration{AESGCM: &apiserverv1.AESConfiguration{Keys: wantKeys}},
+		},
+		{
+			name:         "Secretbox",
+			encryption:   controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderSecretbox},
+			keys:         keys,
+			wantProvider: apiserverv1.ProviderConfiguration{Secretbox: &apiserverv1.SecretboxConfiguration{Keys: wantKeys}},
+		},
+		{
+			name: "KMS",
+			encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+				Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+				KMS: controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{
+					Name:           "kms-plugin",
+					Endpoint:       "unix:///var/run/kms-provider.sock",
+					TimeoutSeconds: ptr.To[int32](5),
+				},
+			},
+			wantProvider: apiserverv1.ProviderConfiguration{KMS: &apiserverv1.KMSConfiguration{
+				APIVersion: "v2",
+				Name:       "kms-plugin",
+				Endpoint:   "unix:///var/run/kms-provider.sock",
+				Timeout:    &metav1.Duration{Duration: 5 * time.Second},
+			}},
+		},
+		{
+			name:       "fails without keys",
+			encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			data, err := EncryptionConfiguration(tt.encryption, tt.keys)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			config := &apiserverv1.EncryptionConfiguration{}
+			g.Expect(yaml.UnmarshalStrict(data, config)).To(Succeed())
+			g.Expect(config.APIVersion).To(Equal("apiserver.config.k8s.io/v1"))
+			g.Expect(config.Kind).To(Equal("EncryptionConfiguration"))
+			g.Expect(config.Resources).To(HaveLen(1))
+			g.Expect(config.Resources[0].Resources).To(Equal([]string{"secrets"}))
+			g.Expect(config.Resources[0].Providers).To(Equal([]apiserverv1.ProviderConfiguration{
+				tt.wantProvider,
+				{Identity: &apiserverv1.IdentityConfiguration{}},
+			}))
+		})
+	}
+}
+
+func TestSetEncryptionConfiguration(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+				Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC,
+			},
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Encryption: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				ConfigurationSecretName: "cluster-encryption-0123456789",
+			},
+		},
+	}
+
+	t.Run("no-op if the feature gate is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, false)
+
+		kubeadmConfigSpec := &bootstrapv1.KubeadmConfigSpec{}
+		SetEncryptionConfiguration(kubeadmConfigSpec, kcp)
+		g.Expect(kubeadmConfigSpec).To(Equal(&bootstrapv1.KubeadmConfigSpec{}))
+	})
+	t.Run("no-op if the encryption configuration Secret is not set yet", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+		kcp := kcp.DeepCopy()
+		kcp.Status.Encryption = controlplanev1.KubeadmControlPlaneEncryptionStatus{}
+
+		kubeadmConfigSpec := &bootstrapv1.KubeadmConfigSpec{}
+		SetEncryptionConfiguration(kubeadmConfigSpec, kcp)
+		g.Expect(kubeadmConfigSpec).To(Equal(&bootstrapv1.KubeadmConfigSpec{}))
+	})
+	t.Run("adds the encryption configuration file, flag and volume", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+		kubeadmConfigSpec := &bootstrapv1.KubeadmConfigSpec{
+			ClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ExtraArgs: []bootstrapv1.Arg{{Name: "v", Value: ptr.To("4")}},
+				},
+			},
+		}
+		SetEncryptionConfiguration(kubeadmConfigSpec, kcp)
+		// Setting the encryption configuration again should not add duplicated flags or volumes.
+		setEncryptionConfigurationInAPIServer(&kubeadmConfigSpec.ClusterConfiguration.APIServer, kcp.Spec.Encryption)
+
+		g.Expect(kubeadmConfigSpec.Files).To(Equal([]bootstrapv1.File{
+			{
+				Path:        "/etc/kubernetes/encryption/encryption-configuration.yaml",
+				Owner:       "root:root",
+				Permissions: "0600",
+				ContentFrom: bootstrapv1.FileSource{
+					Secret: bootstrapv1.SecretFileSource{
+						Name: "cluster-encryption-0123456789",
+						Key:  EncryptionConfigurationKey,
+					},
+				},
+			},
+		}))
+		g.Expect(kubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs).To(Equal([]bootstrapv1.Arg{
+			{Name: "v", Value: ptr.To("4")},
+			{Name: "encryption-provider-config", Value: ptr.To("/etc/kubernetes/encryption/encryption-configuration.yaml")},
+		}))
+		g.Expect(kubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes).To(Equal([]bootstrapv1.HostPathMount{
+			{
+				Name:      "encryption-configuration",
+				HostPath:  "/etc/kubernetes/encryption",
+				MountPath: "/etc/kubernetes/encryption",
+				ReadOnly:  ptr.To(true),
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+		}))
+	})
+	t.Run("adds the KMS plugin socket directory volume with the KMS provider", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+		kcp := kcp.DeepCopy()
+		kcp.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+			Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+			KMS: controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{
+				Name:     "kms-plugin",
+				Endpoint: "unix:///var/run/kms-plugin/socket.sock",
+			},
+		}
+
+		kubeadmConfigSpec := &bootstrapv1.KubeadmConfigSpec{}
+		SetEncryptionConfiguration(kubeadmConfigSpec, kcp)
+		// Setting the encryption configuration again should not add duplicated volumes.
+		setEncryptionConfigurationInAPIServer(&kubeadmConfigSpec.ClusterConfiguration.APIServer, kcp.Spec.Encryption)
+
+		g.Expect(kubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes).To(Equal([]bootstrapv1.HostPathMount{
+			{
+				Name:      "encryption-configuration",
+				HostPath:  "/etc/kubernetes/encryption",
+				MountPath: "/etc/kubernetes/encryption",
+				ReadOnly:  ptr.To(true),
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+			{
+				Name:      "encryption-kms-socket",
+				HostPath:  "/var/run/kms-plugin",
+				MountPath: "/var/run/kms-plugin",
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+		}))
+	})
+	t.Run("does not add the KMS plugin socket directory volume if it is already mounted", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+		kcp := kcp.DeepCopy()
+		kcp.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+			Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+			KMS: controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{
+				Name:     "kms-plugin",
+				Endpoint: "unix:///var/run/kms-plugin/socket.sock",
+			},
+		}
+		userVolume := bootstrapv1.HostPathMount{
+			Name:      "kms",
+			HostPath:  "/var/run/kms-plugin",
+			MountPath: "/var/run/kms-plugin",
+		}
+
+		kubeadmConfigSpec := &bootstrapv1.KubeadmConfigSpec{
+			ClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ExtraVolumes: []bootstrapv1.HostPathMount{userVolume},
+				},
+			},
+		}
+		SetEncryptionConfiguration(kubeadmConfigSpec, kcp)
+
+		g.Expect(kubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraVolumes).To(Equal([]bootstrapv1.HostPathMount{
+			userVolume,
+			{
+				Name:      "encryption-configuration",
+				HostPath:  "/etc/kubernetes/encryption",
+				MountPath: "/etc/kubernetes/encryption",
+				ReadOnly:  ptr.To(true),
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+		}))
+	})
+}
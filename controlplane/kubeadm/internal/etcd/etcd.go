@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -38,10 +39,14 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error)
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
+	MemberPromote(ctx context.Context, id uint64) (*clientv3.MemberPromoteResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
@@ -83,6 +88,14 @@ const (
 // for read and write operations to etcd.
 const DefaultCallTimeout = 15 * time.Second
 
+// DefragmentTimeout represents the duration that the etcd client waits at most
+// for the defragmentation of a member; defragmentation takes longer than other operations
+// because the member rewrites its entire database.
+const DefragmentTimeout = 2 * time.Minute
+
+// ErrLearnerNotReady is returned when promoting a learner member which is not yet in sync with the leader.
+var ErrLearnerNotReady = errors.New("etcd learner member is not yet in sync with the leader")
+
 // AlarmTypeName provides a text translation for AlarmType codes.
 var AlarmTypeName = map[AlarmType]string{
 	AlarmOK:      "NONE",
@@ -125,6 +138,24 @@ func pbMemberToMember(m *etcdserverpb.Member) *Member {
 	}
 }
 
+// MemberStatus describes the status of the etcd member a client is connected to.
+type MemberStatus struct {
+	// MemberID is the ID of the member.
+	MemberID uint64
+
+	// LeaderID is the ID of the member which is the current leader.
+	LeaderID uint64
+
+	// Revision is the current revision of the key-value store.
+	Revision int64
+
+	// DBSize is the size of the backend database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database of the member which is logically in use, in bytes.
+	DBSizeInUse int64
+}
+
 // ClientConfiguration describes the configuration for an etcd client.
 type ClientConfiguration struct {
 	Endpoint    string
@@ -235,6 +266,19 @@ func (c *Client) RemoveMember(ctx context.Context, id uint64) error {
 	return errors.Wrapf(err, "failed to remove etcd member: %v", id)
 }
 
+// PromoteMember promotes a learner member to voting member.
+// ErrLearnerNotReady is returned if the learner is not yet in sync with the leader.
+func (c *Client) PromoteMember(ctx context.Context, id uint64) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.MemberPromote(ctx, id)
+	if err != nil && errors.Is(err, rpctypes.ErrMemberLearnerNotReady) {
+		return errors.Wrapf(ErrLearnerNotReady, "failed to promote etcd member: %v", id)
+	}
+	return errors.Wrapf(err, "failed to promote etcd member: %v", id)
+}
+
 // Alarms retrieves all alarms on a cluster.
 func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
@@ -255,3 +299,63 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// MemberStatus retrieves the status of the member the client is connected to.
+func (c *Client) MemberStatus(ctx context.Context) (*MemberStatus, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd member status")
+	}
+
+	return &MemberStatus{
+		MemberID:    status.Header.GetMemberId(),
+		LeaderID:    status.Leader,
+		Revision:    status.Header.GetRevision(),
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Defragment defragments the backend database of the member the client is connected to.
+// NOTE: The member does not serve requests while it is defragmenting its database.
+func (c *Client) Defragment(ctx context.Context) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, max(c.CallTimeout, DefragmentTimeout), errors.New("defragment timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member %s", c.Endpoint)
+}
+
+// Compact compacts the key-value store history up to the given revision.
+// If physical is true, the call returns only after the compacted entries have been physically removed
+// from the backend database; this is slower, but it ensures that a following defragmentation reclaims the space.
+// Compacting to a revision which has already been compacted is not considered an error.
+func (c *Client) Compact(ctx context.Context, revision int64, physical bool) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	var opts []clientv3.CompactOption
+	if physical {
+		opts = append(opts, clientv3.WithCompactPhysical())
+	}
+	_, err := c.EtcdClient.Compact(ctx, revision, opts...)
+	if err != nil && errors.Is(err, rpctypes.ErrCompacted) {
+		return nil
+	}
+	return errors.Wrapf(err, "failed to compact etcd to revision %d", revision)
+}
+
+// DisarmAlarm disarms an alarm raised by a member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm etcd alarm %s for member %d", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
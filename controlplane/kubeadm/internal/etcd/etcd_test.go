@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -61,6 +62,12 @@ func TestEtcdMembers_WithErrors(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).To(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).To(HaveOccurred())
 }
 
 func TestEtcdMembers_WithSuccess(t *testing.T) {
@@ -93,3 +100,76 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).ToNot(HaveOccurred())
 }
+
+func TestEtcdMaintenance(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &etcdfake.FakeEtcdClient{
+		EtcdEndpoints: []string{"https://etcd-instance:2379"},
+		StatusResponse: &clientv3.StatusResponse{
+			Header:      &etcdserverpb.ResponseHeader{MemberId: 1234, Revision: 42},
+			Leader:      5678,
+			DbSize:      100,
+			DbSizeInUse: 60,
+		},
+		AlarmDisarmResponse: &clientv3.AlarmResponse{},
+		CompactResponse:     &clientv3.CompactResponse{},
+		DefragmentResponse:  &clientv3.DefragmentResponse{},
+	}
+
+	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	status, err := client.MemberStatus(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*status).To(Equal(MemberStatus{
+		MemberID:    1234,
+		LeaderID:    5678,
+		Revision:    42,
+		DBSize:      100,
+		DBSizeInUse: 60,
+	}))
+
+	g.Expect(client.Defragment(ctx)).To(Succeed())
+	g.Expect(fakeEtcdClient.DefragmentedEndpoint).To(Equal("https://etcd-instance:2379"))
+
+	g.Expect(client.Compact(ctx, 42, true)).To(Succeed())
+	g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(int64(42)))
+
+	g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).To(Succeed())
+	g.Expect(fakeEtcdClient.DisarmedAlarm).To(Equal(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
+
+	// Compacting to a revision which has already been compacted is not an error.
+	fakeEtcdClient.ErrorResponse = rpctypes.ErrCompacted
+	g.Expect(client.Compact(ctx, 42, false)).To(Succeed())
+}
+
+func TestEtcdPromoteMember(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &etcdfake.FakeEtcdClient{
+		EtcdEndpoints:         []string{"https://etcd-instance:2379"},
+		MemberPromoteResponse: &clientv3.MemberPromoteResponse{},
+		StatusResponse: &clientv3.StatusResponse{
+			Header: &etcdserverpb.ResponseHeader{},
+		},
+	}
+
+	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(client.PromoteMember(ctx, 1234)).To(Succeed())
+	g.Expect(fakeEtcdClient.PromotedMember).To(Equal(uint64(1234)))
+
+	// Promoting a learner which is not yet in sync with the leader returns ErrLearnerNotReady.
+	fakeEtcdClient.ErrorResponse = rpctypes.ErrMemberLearnerNotReady
+	err = client.PromoteMember(ctx, 1234)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.Is(err, ErrLearnerNotReady)).To(BeTrue())
+
+	// Other errors are returned as is.
+	fakeEtcdClient.ErrorResponse = errors.New("promote failed")
+	err = client.PromoteMember(ctx, 1234)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.Is(err, ErrLearnerNotReady)).To(BeFalse())
+}
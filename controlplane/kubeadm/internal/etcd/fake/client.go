@@ -24,15 +24,23 @@ import (
 )
 
 type FakeEtcdClient struct { //nolint:revive
-	AlarmResponse        *clientv3.AlarmResponse
-	EtcdEndpoints        []string
-	MemberListResponse   *clientv3.MemberListResponse
-	MemberRemoveResponse *clientv3.MemberRemoveResponse
-	MoveLeaderResponse   *clientv3.MoveLeaderResponse
-	StatusResponse       *clientv3.StatusResponse
-	ErrorResponse        error
-	MovedLeader          uint64
-	RemovedMember        uint64
+	AlarmResponse         *clientv3.AlarmResponse
+	AlarmDisarmResponse   *clientv3.AlarmResponse
+	CompactResponse       *clientv3.CompactResponse
+	DefragmentResponse    *clientv3.DefragmentResponse
+	EtcdEndpoints         []string
+	MemberListResponse    *clientv3.MemberListResponse
+	MemberPromoteResponse *clientv3.MemberPromoteResponse
+	MemberRemoveResponse  *clientv3.MemberRemoveResponse
+	MoveLeaderResponse    *clientv3.MoveLeaderResponse
+	StatusResponse        *clientv3.StatusResponse
+	ErrorResponse         error
+	MovedLeader           uint64
+	RemovedMember         uint64
+	PromotedMember        uint64
+	DisarmedAlarm         *clientv3.AlarmMember
+	CompactedRevision     int64
+	DefragmentedEndpoint  string
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -48,6 +56,21 @@ func (c *FakeEtcdClient) Close() error {
 	return nil
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarm = m
+	return c.AlarmDisarmResponse, c.ErrorResponse
+}
+
+func (c *FakeEtcdClient) Compact(_ context.Context, rev int64, _ ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	c.CompactedRevision = rev
+	return c.CompactResponse, c.ErrorResponse
+}
+
+func (c *FakeEtcdClient) Defragment(_ context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	c.DefragmentedEndpoint = endpoint
+	return c.DefragmentResponse, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse, error) {
 	return c.AlarmResponse, c.ErrorResponse
 }
@@ -55,6 +78,10 @@ func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse,
 func (c *FakeEtcdClient) MemberList(_ context.Context) (*clientv3.MemberListResponse, error) {
 	return c.MemberListResponse, c.ErrorResponse
 }
+func (c *FakeEtcdClient) MemberPromote(_ context.Context, i uint64) (*clientv3.MemberPromoteResponse, error) {
+	c.PromotedMember = i
+	return c.MemberPromoteResponse, c.ErrorResponse
+}
 func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.MemberRemoveResponse, error) {
 	c.RemovedMember = i
 	return c.MemberRemoveResponse, c.ErrorResponse
@@ -340,7 +340,8 @@ func matchInitOrJoinConfiguration(machineConfig *bootstrapv1.KubeadmConfig, kcp
 // getAdjustedKcpConfig takes the KubeadmConfigSpec from KCP and applies the transformations required
 // to allow a comparison with the KubeadmConfig referenced from the machine.
 // NOTE: The KCP controller applies a set of transformations when creating a KubeadmConfig referenced from the machine;
-// those transformations are implemented in ControlPlane.InitialControlPlaneConfig() and ControlPlane.JoinControlPlaneConfig().
+// those transformations are implemented in ControlPlane.InitialControlPlaneConfig(), ControlPlane.JoinControlPlaneConfig()
+// and SetEncryptionConfiguration().
 func getAdjustedKcpConfig(kcp *controlplanev1.KubeadmControlPlane, machineConfig *bootstrapv1.KubeadmConfig) *bootstrapv1.KubeadmConfigSpec {
 	kcpConfig := kcp.Spec.KubeadmConfigSpec.DeepCopy()
 
@@ -354,6 +355,9 @@ func getAdjustedKcpConfig(kcp *controlplanev1.KubeadmControlPlane, machineConfig
 		kcpConfig.JoinConfiguration = bootstrapv1.JoinConfiguration{}
 	}
 
+	// Add the encryption configuration, if any, like it is done when creating Machines.
+	SetEncryptionConfiguration(kcpConfig, kcp)
+
 	return kcpConfig
 }
 
@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
@@ -34,6 +35,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstream"
+	"sigs.k8s.io/cluster-api/feature"
 )
 
 func TestClusterConfigurationAnnotation(t *testing.T) {
@@ -395,6 +397,27 @@ func TestGetAdjustedKcpConfig(t *testing.T) {
 		g.Expect(kcpConfig.InitConfiguration.IsDefined()).To(BeFalse())
 		g.Expect(kcpConfig.JoinConfiguration.IsDefined()).To(BeTrue())
 	})
+	t.Run("if encryption is configured, kcp config should get the encryption configuration file", func(t *testing.T) {
+		g := NewWithT(t)
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+					Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC,
+				},
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				Encryption: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+					ConfigurationSecretName: "cluster-encryption-0123456789",
+				},
+			},
+		}
+		machineConfig := &bootstrapv1.KubeadmConfig{}
+		kcpConfig := getAdjustedKcpConfig(kcp, machineConfig)
+		g.Expect(kcpConfig.Files).To(HaveLen(1))
+		g.Expect(kcpConfig.Files[0].ContentFrom.Secret.Name).To(Equal("cluster-encryption-0123456789"))
+	})
 }
 
 func TestCleanupConfigFields(t *testing.T) {
@@ -690,7 +713,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
     },
     JoinConfiguration: {NodeRegistration: {ImagePullPolicy: "IfNotPresent"}},
     Files:             nil,
-    ... // 10 identical fields
+    ... // 12 identical fields
   }`))
 	})
 	t.Run("returns true if JoinConfiguration is equal", func(t *testing.T) {
@@ -816,7 +839,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
     },
     Files:     nil,
     DiskSetup: {},
-    ... // 9 identical fields
+    ... // 11 identical fields
   }`))
 	})
 	t.Run("returns true if returns true if only omittable configurations are not equal", func(t *testing.T) {
@@ -927,9 +950,38 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 +   Files:                []v1beta2.File{{Path: "/tmp/foo"}},
     DiskSetup:            {},
     Mounts:               nil,
-    ... // 8 identical fields
+    ... // 10 identical fields
   }`))
 	})
+	t.Run("returns false if kubeletConfiguration is not equal", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					InitConfiguration: bootstrapv1.InitConfiguration{},
+					KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+						MaxPods: ptr.To[int32](200), // This is a change
+					},
+				},
+			},
+		}
+		machineConfig := &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+			},
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				InitConfiguration: bootstrapv1.InitConfiguration{},
+				KubeletConfiguration: bootstrapv1.KubeletConfiguration{
+					MaxPods: ptr.To[int32](110),
+				},
+			},
+		}
+		match, diff, err := matchInitOrJoinConfiguration(machineConfig, kcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(match).To(BeFalse())
+		g.Expect(diff).To(ContainSubstring("KubeletConfiguration"))
+	})
 }
 
 func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
@@ -1117,7 +1169,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
     },
     JoinConfiguration: {NodeRegistration: {ImagePullPolicy: "IfNotPresent"}},
     Files:             nil,
-    ... // 10 identical fields
+    ... // 12 identical fields
   }`))
 	})
 	t.Run("returns true if JoinConfiguration is equal", func(t *testing.T) {
@@ -1243,7 +1295,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
     },
     Files:     nil,
     DiskSetup: {},
-    ... // 9 identical fields
+    ... // 11 identical fields
   }`))
 	})
 	t.Run("returns true if only omittable configurations are not equal", func(t *testing.T) {
@@ -1354,7 +1406,7 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
 +   Files:                []v1beta2.File{{Path: "/tmp/foo"}},
     DiskSetup:            {},
     Mounts:               nil,
-    ... // 8 identical fields
+    ... // 10 identical fields
   }`))
 	})
 	t.Run("should match on labels and annotations", func(t *testing.T) {
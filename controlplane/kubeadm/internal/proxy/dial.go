@@ -26,7 +26,9 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	apispdy "k8s.io/apimachinery/pkg/util/httpstream/spdy"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 )
@@ -67,7 +69,7 @@ func NewDialer(p Proxy, options ...func(*Dialer) error) (*Dialer, error) {
 	if err != nil {
 		return nil, err
 	}
-	proxyTransport, upgrader, err := spdy.RoundTripperFor(p.KubeConfig)
+	proxyTransport, upgrader, err := roundTripperFor(p.KubeConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +79,35 @@ func NewDialer(p Proxy, options ...func(*Dialer) error) (*Dialer, error) {
 	return dialer, nil
 }
 
+// roundTripperFor returns a SPDY round tripper and upgrader for the given config.
+// NOTE: spdy.RoundTripperFor ignores the dial func of the config, so if a dial func is set (e.g. when the workload cluster
+// is accessed through a reverse tunnel), the round tripper is created with an upgrade transport using the dial func.
+func roundTripperFor(config *rest.Config) (http.RoundTripper, spdy.Upgrader, error) {
+	if config.Dial == nil {
+		return spdy.RoundTripperFor(config)
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	upgradeRoundTripper, err := apispdy.NewRoundTripperWithConfig(apispdy.RoundTripperConfig{
+		PingPeriod: 5 * time.Second,
+		UpgradeTransport: &http.Transport{
+			DialContext:     config.Dial,
+			TLSClientConfig: tlsConfig,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapper, err := rest.HTTPWrappersForConfig(config, upgradeRoundTripper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wrapper, upgradeRoundTripper, nil
+}
+
 // DialContextWithAddr is a GO grpc compliant dialer construct.
 func (d *Dialer) DialContextWithAddr(ctx context.Context, addr string) (net.Conn, error) {
 	return d.DialContext(ctx, scheme, addr)
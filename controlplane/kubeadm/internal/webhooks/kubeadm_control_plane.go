@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
 	"strings"
 
@@ -196,6 +197,8 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, kubeadmConfigSpec, diskSetup, "*"},
 		{spec, kubeadmConfigSpec, "format"},
 		{spec, kubeadmConfigSpec, "mounts"},
+		{spec, kubeadmConfigSpec, "kubeletConfiguration"},
+		{spec, kubeadmConfigSpec, "kubeletConfiguration", "*"},
 		// spec.machineTemplate
 		{spec, "machineTemplate", "metadata"},
 		{spec, "machineTemplate", "metadata", "*"},
@@ -210,6 +213,10 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "machineNaming", "*"},
 		{spec, "rollout"},
 		{spec, "rollout", "*"},
+		{spec, "etcdMaintenance"},
+		{spec, "etcdMaintenance", "*"},
+		{spec, "encryption"},
+		{spec, "encryption", "*"},
 	}
 
 	oldK, ok := oldObj.(*controlplanev1.KubeadmControlPlane)
@@ -261,6 +268,7 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 	allErrs = append(allErrs, webhook.validateVersion(oldK, newK)...)
 	allErrs = append(allErrs, validateClusterConfiguration(&oldK.Spec.KubeadmConfigSpec.ClusterConfiguration, &newK.Spec.KubeadmConfigSpec.ClusterConfiguration, field.NewPath("spec", "kubeadmConfigSpec", "clusterConfiguration"))...)
 	allErrs = append(allErrs, webhook.validateCoreDNSVersion(oldK, newK)...)
+	allErrs = append(allErrs, validateEncryptionUpdate(oldK.Spec.Encryption, newK.Spec.Encryption, field.NewPath("spec", "encryption"))...)
 	allErrs = append(allErrs, newK.Spec.KubeadmConfigSpec.Validate(true, field.NewPath("spec", "kubeadmConfigSpec"))...)
 
 	if len(allErrs) > 0 {
@@ -347,6 +355,7 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, p
 
 	allErrs = append(allErrs, validateRolloutAndCertValidityFields(s.Rollout, s.KubeadmConfigSpec.ClusterConfiguration, s.Replicas, pathPrefix)...)
 	allErrs = append(allErrs, validateNaming(s.MachineNaming, pathPrefix.Child("machineNaming"))...)
+	allErrs = append(allErrs, validateEncryption(s.Encryption, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix)...)
 	return allErrs
 }
 
@@ -462,6 +471,77 @@ func validateNaming(machineNaming controlplanev1.MachineNamingSpec, pathPrefix *
 	return allErrs
 }
 
+func validateEncryption(encryption controlplanev1.KubeadmControlPlaneEncryptionSpec, clusterConfiguration bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !encryption.IsDefined() {
+		return nil
+	}
+
+	if encryption.Provider == controlplanev1.KubeadmControlPlaneEncryptionProviderKMS {
+		if reflect.DeepEqual(encryption.KMS, controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{}) {
+			allErrs = append(allErrs,
+				field.Required(
+					pathPrefix.Child("encryption", "kms"),
+					"must be set when provider is KMS",
+				))
+		}
+		if endpoint, ok := strings.CutPrefix(encryption.KMS.Endpoint, "unix://"); encryption.KMS.Endpoint != "" && (!ok || !path.IsAbs(endpoint)) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					pathPrefix.Child("encryption", "kms", "endpoint"),
+					encryption.KMS.Endpoint,
+					"must be a unix socket with an absolute path, e.g. unix:///var/run/kms-plugin/socket.sock",
+				))
+		}
+		if !encryption.RotateKeyAfter.IsZero() {
+			allErrs = append(allErrs,
+				field.Forbidden(
+					pathPrefix.Child("encryption", "rotateKeyAfter"),
+					"key rotation is not supported when provider is KMS, keys are managed by the KMS plugin",
+				))
+		}
+	} else if !reflect.DeepEqual(encryption.KMS, controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{}) {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				pathPrefix.Child("encryption", "kms"),
+				"can only be set when provider is KMS",
+			))
+	}
+
+	// The encryption configuration is managed by KCP, so it cannot be set by users at the same time.
+	for _, arg := range clusterConfiguration.APIServer.ExtraArgs {
+		if arg.Name == "encryption-provider-config" {
+			allErrs = append(allErrs,
+				field.Forbidden(
+					pathPrefix.Child("kubeadmConfigSpec", "clusterConfiguration", "apiServer", "extraArgs"),
+					"encryption-provider-config cannot be set when encryption is set",
+				))
+		}
+	}
+
+	return allErrs
+}
+
+func validateEncryptionUpdate(oldEncryption, newEncryption controlplanev1.KubeadmControlPlaneEncryptionSpec, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !oldEncryption.IsDefined() {
+		return nil
+	}
+
+	// Secrets encrypted with the current provider would become unreadable if encryption is disabled or the provider
+	// is changed; this is why both operations are not supported.
+	if !newEncryption.IsDefined() {
+		allErrs = append(allErrs, field.Forbidden(pathPrefix, "cannot be removed once set"))
+		return allErrs
+	}
+	if oldEncryption.Provider != newEncryption.Provider {
+		allErrs = append(allErrs, field.Forbidden(pathPrefix.Child("provider"), "cannot be modified"))
+	}
+	return allErrs
+}
+
 func validateClusterConfiguration(oldClusterConfiguration, newClusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -24,14 +24,12 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
-	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 )
 
@@ -188,11 +186,44 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.Rollout.Before.CertificatesExpiryDays = 8
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.KubeadmConfigSpec.ClusterConfiguration.CertificateValidityPeriodDays = 7
 
+	validEncryption := valid.DeepCopy()
+	validEncryption.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+		Provider:       controlplanev1.KubeadmControlPlaneEncryptionProviderAESGCM,
+		RotateKeyAfter: metav1.NewTime(time.Now()),
+	}
+
+	validEncryptionKMS := valid.DeepCopy()
+	validEncryptionKMS.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+		Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+		KMS: controlplanev1.KubeadmControlPlaneEncryptionKMSSpec{
+			Name:     "kms-plugin",
+			Endpoint: "unix:///var/run/kms-provider.sock",
+		},
+	}
+
+	invalidEncryptionKMSMissing := valid.DeepCopy()
+	invalidEncryptionKMSMissing.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+		Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderKMS,
+	}
+
+	invalidEncryptionKMSRotation := validEncryptionKMS.DeepCopy()
+	invalidEncryptionKMSRotation.Spec.Encryption.RotateKeyAfter = metav1.NewTime(time.Now())
+
+	invalidEncryptionKMSEndpoint := validEncryptionKMS.DeepCopy()
+	invalidEncryptionKMSEndpoint.Spec.Encryption.KMS.Endpoint = "tcp://127.0.0.1:8080"
+
+	invalidEncryptionKMSWithoutKMSProvider := validEncryptionKMS.DeepCopy()
+	invalidEncryptionKMSWithoutKMSProvider.Spec.Encryption.Provider = controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC
+
+	invalidEncryptionProviderConfigArg := validEncryption.DeepCopy()
+	invalidEncryptionProviderConfigArg.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs = []bootstrapv1.Arg{
+		{Name: "encryption-provider-config", Value: ptr.To("/etc/kubernetes/encryption.yaml")},
+	}
+
 	tests := []struct {
-		name                  string
-		enableIgnitionFeature bool
-		expectErr             bool
-		kcp                   *controlplanev1.KubeadmControlPlane
+		name      string
+		expectErr bool
+		kcp       *controlplanev1.KubeadmControlPlane
 	}{
 		{
 			name:      "should succeed when given a valid config",
@@ -250,22 +281,19 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			kcp:       stringMaxSurge,
 		},
 		{
-			name:                  "should return error when Ignition configuration is invalid",
-			enableIgnitionFeature: true,
-			expectErr:             true,
-			kcp:                   invalidIgnitionConfiguration,
+			name:      "should return error when Ignition configuration is invalid",
+			expectErr: true,
+			kcp:       invalidIgnitionConfiguration,
 		},
 		{
-			name:                  "should succeed when Ignition configuration is valid",
-			enableIgnitionFeature: true,
-			expectErr:             false,
-			kcp:                   validIgnitionConfiguration,
+			name:      "should succeed when Ignition configuration is valid",
+			expectErr: false,
+			kcp:       validIgnitionConfiguration,
 		},
 		{
-			name:                  "should return error for invalid metadata",
-			enableIgnitionFeature: true,
-			expectErr:             true,
-			kcp:                   invalidMetadata,
+			name:      "should return error for invalid metadata",
+			expectErr: true,
+			kcp:       invalidMetadata,
 		},
 		{
 			name:      "should return error for invalid Timeouts.ControlPlaneComponentHealthCheckSeconds",
@@ -291,15 +319,45 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificatesExpiryDays,
 		},
+		{
+			name:      "should succeed when encryption is valid",
+			expectErr: false,
+			kcp:       validEncryption,
+		},
+		{
+			name:      "should succeed when encryption with KMS is valid",
+			expectErr: false,
+			kcp:       validEncryptionKMS,
+		},
+		{
+			name:      "should return error when encryption provider is KMS and kms is not set",
+			expectErr: true,
+			kcp:       invalidEncryptionKMSMissing,
+		},
+		{
+			name:      "should return error when encryption provider is KMS and rotateKeyAfter is set",
+			expectErr: true,
+			kcp:       invalidEncryptionKMSRotation,
+		},
+		{
+			name:      "should return error when encryption provider is KMS and the endpoint is not a unix socket",
+			expectErr: true,
+			kcp:       invalidEncryptionKMSEndpoint,
+		},
+		{
+			name:      "should return error when encryption provider is not KMS and kms is set",
+			expectErr: true,
+			kcp:       invalidEncryptionKMSWithoutKMSProvider,
+		},
+		{
+			name:      "should return error when encryption is set and encryption-provider-config is set in apiServer extraArgs",
+			expectErr: true,
+			kcp:       invalidEncryptionProviderConfigArg,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.enableIgnitionFeature {
-				// NOTE: KubeadmBootstrapFormatIgnition feature flag is disabled by default.
-				// Enabling the feature flag temporarily for this test.
-				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)
-			}
 
 			g := NewWithT(t)
 
@@ -430,8 +488,8 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 
 	beforeKubeadmConfigFormatSet := before.DeepCopy()
 	beforeKubeadmConfigFormatSet.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
-	invalidUpdateKubeadmConfigFormat := beforeKubeadmConfigFormatSet.DeepCopy()
-	invalidUpdateKubeadmConfigFormat.Spec.KubeadmConfigSpec.Format = bootstrapv1.Ignition
+	validUpdateKubeadmConfigFormat := beforeKubeadmConfigFormatSet.DeepCopy()
+	validUpdateKubeadmConfigFormat.Spec.KubeadmConfigSpec.Format = bootstrapv1.Ignition
 
 	validUpdate := before.DeepCopy()
 	validUpdate.Labels = map[string]string{"blue": "green"}
@@ -477,6 +535,15 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		RetryPeriodSeconds:      ptr.To[int32](10 * 60),
 	}
 	validUpdate.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
+	validUpdate.Spec.EtcdMaintenance = controlplanev1.KubeadmControlPlaneEtcdMaintenanceSpec{
+		Compaction: controlplanev1.KubeadmControlPlaneEtcdCompactionSpec{
+			IntervalSeconds: ptr.To[int32](24 * 60 * 60),
+		},
+		Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+			IntervalSeconds: ptr.To[int32](24 * 60 * 60),
+		},
+		NoSpaceAlarmRemediation: controlplanev1.KubeadmControlPlaneEtcdNoSpaceAlarmRemediationRemediate,
+	}
 
 	scaleToZero := before.DeepCopy()
 	scaleToZero.Spec.Replicas = ptr.To[int32](0)
@@ -703,6 +770,14 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		Directory: "/tmp/patches",
 	}
 
+	updateKubeletConfiguration := before.DeepCopy()
+	updateKubeletConfiguration.Spec.KubeadmConfigSpec.KubeletConfiguration = bootstrapv1.KubeletConfiguration{
+		MaxPods: ptr.To[int32](200),
+		KubeReserved: []bootstrapv1.KubeletResourceReservation{
+			{Name: "memory", Quantity: "1Gi"},
+		},
+	}
+
 	updateInitConfigurationSkipPhases := before.DeepCopy()
 	updateInitConfigurationSkipPhases.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = []string{"addon/kube-proxy"}
 
@@ -747,6 +822,17 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	unsetTimeouts.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = bootstrapv1.Timeouts{}
 	unsetTimeouts.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = bootstrapv1.Timeouts{}
 
+	beforeEncryption := before.DeepCopy()
+	beforeEncryption.Spec.Encryption = controlplanev1.KubeadmControlPlaneEncryptionSpec{
+		Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC,
+	}
+
+	rotateEncryptionKey := beforeEncryption.DeepCopy()
+	rotateEncryptionKey.Spec.Encryption.RotateKeyAfter = metav1.NewTime(time.Now())
+
+	changeEncryptionProvider := beforeEncryption.DeepCopy()
+	changeEncryptionProvider.Spec.Encryption.Provider = controlplanev1.KubeadmControlPlaneEncryptionProviderSecretbox
+
 	validUpdateCertificateValidityPeriod := before.DeepCopy()
 	validUpdateCertificateValidityPeriod.Spec.KubeadmConfigSpec.ClusterConfiguration.CertificateValidityPeriodDays = 150
 
@@ -756,11 +842,10 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                  string
-		enableIgnitionFeature bool
-		expectErr             bool
-		before                *controlplanev1.KubeadmControlPlane
-		kcp                   *controlplanev1.KubeadmControlPlane
+		name      string
+		expectErr bool
+		before    *controlplanev1.KubeadmControlPlane
+		kcp       *controlplanev1.KubeadmControlPlane
 	}{
 		{
 			name:      "should succeed when given a valid config",
@@ -787,10 +872,10 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			kcp:       validUpdateKubeadmConfigJoin,
 		},
 		{
-			name:      "should return error when trying to mutate the kubeadmconfigspec format from cloud-config to ignition",
-			expectErr: true,
+			name:      "should succeed when trying to mutate the kubeadmconfigspec format from cloud-config to ignition",
+			expectErr: false,
 			before:    beforeKubeadmConfigFormatSet,
-			kcp:       invalidUpdateKubeadmConfigFormat,
+			kcp:       validUpdateKubeadmConfigFormat,
 		},
 		{
 			name:      "should return error when trying to scale to zero",
@@ -1048,6 +1133,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       updateJoinConfigurationSkipPhases,
 		},
+		{
+			name:      "should allow changes to kubeletConfiguration",
+			expectErr: false,
+			before:    before,
+			kcp:       updateKubeletConfiguration,
+		},
 		{
 			name:      "should allow changes to diskSetup",
 			expectErr: false,
@@ -1061,32 +1152,28 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			kcp:       unsetRolloutBefore,
 		},
 		{
-			name:                  "should return error when Ignition configuration is invalid",
-			enableIgnitionFeature: true,
-			expectErr:             true,
-			before:                invalidIgnitionConfiguration,
-			kcp:                   invalidIgnitionConfiguration,
+			name:      "should return error when Ignition configuration is invalid",
+			expectErr: true,
+			before:    invalidIgnitionConfiguration,
+			kcp:       invalidIgnitionConfiguration,
 		},
 		{
-			name:                  "should succeed when Ignition configuration is modified",
-			enableIgnitionFeature: true,
-			expectErr:             false,
-			before:                validIgnitionConfigurationBefore,
-			kcp:                   validIgnitionConfigurationAfter,
+			name:      "should succeed when Ignition configuration is modified",
+			expectErr: false,
+			before:    validIgnitionConfigurationBefore,
+			kcp:       validIgnitionConfigurationAfter,
 		},
 		{
-			name:                  "should succeed when CloudInit was used before",
-			enableIgnitionFeature: true,
-			expectErr:             false,
-			before:                before,
-			kcp:                   switchFromCloudInitToIgnition,
+			name:      "should succeed when CloudInit was used before",
+			expectErr: false,
+			before:    before,
+			kcp:       switchFromCloudInitToIgnition,
 		},
 		{
-			name:                  "should return error for invalid metadata",
-			enableIgnitionFeature: true,
-			expectErr:             true,
-			before:                before,
-			kcp:                   invalidMetadata,
+			name:      "should return error for invalid metadata",
+			expectErr: true,
+			before:    before,
+			kcp:       invalidMetadata,
 		},
 		{
 			name:      "should succeed when changing timeouts",
@@ -1100,6 +1187,30 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       unsetTimeouts,
 		},
+		{
+			name:      "should succeed when enabling encryption",
+			expectErr: false,
+			before:    before,
+			kcp:       beforeEncryption,
+		},
+		{
+			name:      "should succeed when rotating the encryption key",
+			expectErr: false,
+			before:    beforeEncryption,
+			kcp:       rotateEncryptionKey,
+		},
+		{
+			name:      "should return error when removing encryption",
+			expectErr: true,
+			before:    beforeEncryption,
+			kcp:       before,
+		},
+		{
+			name:      "should return error when changing the encryption provider",
+			expectErr: true,
+			before:    beforeEncryption,
+			kcp:       changeEncryptionProvider,
+		},
 		{
 			name:      "should succeed when setting timeouts",
 			expectErr: false,
@@ -1122,11 +1233,6 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.enableIgnitionFeature {
-				// NOTE: KubeadmBootstrapFormatIgnition feature flag is disabled by default.
-				// Enabling the feature flag temporarily for this test.
-				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)
-			}
 
 			g := NewWithT(t)
 
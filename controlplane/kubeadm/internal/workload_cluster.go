@@ -47,6 +47,7 @@ import (
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
 	containerutil "sigs.k8s.io/cluster-api/util/container"
@@ -70,6 +71,14 @@ var (
 	// the spec.clusterIP field selector that is only implemented in kube-apiserver >= 1.31.0).
 	minKubernetesVersionControlPlaneKubeletLocalMode = semver.MustParse("1.31.0")
 
+	// minKubernetesVersionEtcdLearnerMode is the min version from which
+	// the EtcdLearnerMode kubeadm feature gate exists.
+	minKubernetesVersionEtcdLearnerMode = semver.MustParse("1.27.0")
+
+	// minKubernetesVersionEtcdLearnerModeEnabledByDefault is the min version from which
+	// the EtcdLearnerMode kubeadm feature gate is enabled by default.
+	minKubernetesVersionEtcdLearnerModeEnabledByDefault = semver.MustParse("1.29.0")
+
 	// ErrControlPlaneMinNodes signals that a cluster doesn't meet the minimum required nodes
 	// to remove an etcd member.
 	ErrControlPlaneMinNodes = errors.New("cluster has fewer than 2 control plane nodes; removing an etcd member is not supported")
@@ -95,15 +104,27 @@ type WorkloadCluster interface {
 	UpdateControllerManagerInKubeadmConfigMap(controllerManager bootstrapv1.ControllerManager) func(*bootstrapv1.ClusterConfiguration)
 	UpdateSchedulerInKubeadmConfigMap(scheduler bootstrapv1.Scheduler) func(*bootstrapv1.ClusterConfiguration)
 	UpdateCertificateValidityPeriodDays(certificateValidityPeriodDays int32) func(*bootstrapv1.ClusterConfiguration)
+	UpdateEncryptionInKubeadmConfigMap(kcp *controlplanev1.KubeadmControlPlane) func(*bootstrapv1.ClusterConfiguration)
 	UpdateKubeProxyImageInfo(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	UpdateCoreDNS(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	RemoveEtcdMemberForMachine(ctx context.Context, machine *clusterv1.Machine) error
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error
+	PromoteEtcdLearnerForMachine(ctx context.Context, machine *clusterv1.Machine) error
 	AllowClusterAdminPermissions(ctx context.Context, version semver.Version) error
 	UpdateClusterConfiguration(ctx context.Context, version semver.Version, mutators ...func(*bootstrapv1.ClusterConfiguration)) error
 
 	// State recovery tasks.
 	ReconcileEtcdMembersAndControlPlaneNodes(ctx context.Context, members []*etcd.Member, nodeNames []string) ([]string, error)
+
+	// Etcd maintenance tasks.
+	EtcdMemberDBStatuses(ctx context.Context, nodeNames []string) ([]EtcdMemberDBStatus, error)
+	DefragmentEtcdMember(ctx context.Context, nodeName string) error
+	CompactEtcd(ctx context.Context, nodeNames []string) error
+	CompactEtcdHistory(ctx context.Context, nodeNames []string, retainedRevisions int64) error
+	DisarmEtcdAlarms(ctx context.Context, nodeNames []string, alarmType etcd.AlarmType) error
+
+	// Encryption at rest tasks.
+	RewriteSecrets(ctx context.Context) error
 }
 
 // Workload defines operations on workload clusters.
@@ -178,20 +199,35 @@ const (
 	// ControlPlaneKubeletLocalMode is a feature gate of kubeadm that ensures
 	// kubelets only communicate with the local apiserver.
 	ControlPlaneKubeletLocalMode = "ControlPlaneKubeletLocalMode"
+
+	// EtcdLearnerMode is a feature gate of kubeadm that ensures
+	// control plane nodes join the etcd cluster as learners.
+	EtcdLearnerMode = "EtcdLearnerMode"
 )
 
 // DefaultFeatureGates defaults the feature gates field.
 func DefaultFeatureGates(kubeadmConfigSpec *bootstrapv1.KubeadmConfigSpec, kubernetesVersion semver.Version) {
-	if version.Compare(kubernetesVersion, minKubernetesVersionControlPlaneKubeletLocalMode, version.WithoutPreReleases()) < 0 {
-		return
+	if version.Compare(kubernetesVersion, minKubernetesVersionControlPlaneKubeletLocalMode, version.WithoutPreReleases()) >= 0 {
+		defaultFeatureGate(kubeadmConfigSpec, ControlPlaneKubeletLocalMode)
+	}
+
+	// Note: EtcdLearnerMode is enabled by default in kubeadm starting from v1.29, so it is only required
+	// to explicitly enable it for older versions.
+	if feature.Gates.Enabled(feature.KubeadmControlPlaneEtcdLearnerMode) &&
+		version.Compare(kubernetesVersion, minKubernetesVersionEtcdLearnerMode, version.WithoutPreReleases()) >= 0 &&
+		version.Compare(kubernetesVersion, minKubernetesVersionEtcdLearnerModeEnabledByDefault, version.WithoutPreReleases()) < 0 {
+		defaultFeatureGate(kubeadmConfigSpec, EtcdLearnerMode)
 	}
+}
 
+// defaultFeatureGate enables a feature gate, if not already set.
+func defaultFeatureGate(kubeadmConfigSpec *bootstrapv1.KubeadmConfigSpec, featureGate string) {
 	if kubeadmConfigSpec.ClusterConfiguration.FeatureGates == nil {
 		kubeadmConfigSpec.ClusterConfiguration.FeatureGates = map[string]bool{}
 	}
 
-	if _, ok := kubeadmConfigSpec.ClusterConfiguration.FeatureGates[ControlPlaneKubeletLocalMode]; !ok {
-		kubeadmConfigSpec.ClusterConfiguration.FeatureGates[ControlPlaneKubeletLocalMode] = true
+	if _, ok := kubeadmConfigSpec.ClusterConfiguration.FeatureGates[featureGate]; !ok {
+		kubeadmConfigSpec.ClusterConfiguration.FeatureGates[featureGate] = true
 	}
 }
 
@@ -130,6 +130,7 @@ func (w *Workload) updateManagedEtcdConditions(ctx context.Context, controlPlane
 	currentMembers, alarms, err := w.getCurrentEtcdMembersAndAlarms(ctx, machinesNotProvisioningOrDeleting, controlPlaneNodes)
 	if err == nil {
 		controlPlane.EtcdMembers = currentMembers
+		controlPlane.EtcdAlarms = alarms
 
 		for _, machine := range machinesNotProvisioningOrDeleting {
 			// Retrieve the member hosted on the machine.
@@ -395,14 +396,27 @@ func compareMachinesAndMembers(controlPlane *ControlPlane, nodes *corev1.NodeLis
 		if !machine.Status.NodeRef.IsDefined() {
 			continue
 		}
-		found := false
+		var machineMember *etcd.Member
 		for _, member := range members {
 			if machine.Status.NodeRef.Name == member.Name {
-				found = true
+				machineMember = member
 				break
 			}
 		}
-		if !found {
+		if machineMember != nil && machineMember.IsLearner {
+			// Surface the etcd member hosted on the machine is a learner on machine's EtcdMemberHealthy condition;
+			// learners are not voting members, so they do not contribute to the etcd cluster fault tolerance.
+			// Note: learners are usually promoted to voting members by kubeadm join, or by KCP.
+			v1beta1conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyV1Beta1Condition, controlplanev1.EtcdMemberUnhealthyV1Beta1Reason, clusterv1.ConditionSeverityInfo, "Etcd member is a learner")
+
+			conditions.Set(machine, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1.KubeadmControlPlaneMachineEtcdMemberLearnerReason,
+				Message: fmt.Sprintf("Etcd member for Node %s is a learner, waiting for it to be promoted to voting member", machine.Status.NodeRef.Name),
+			})
+		}
+		if machineMember == nil {
 			// Surface there is a machine without etcd member on machine's EtcdMemberHealthy condition.
 			// The same info will also surface into the EtcdClusterHealthy condition on kcp.
 			v1beta1conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyV1Beta1Condition, controlplanev1.EtcdMemberUnhealthyV1Beta1Reason, clusterv1.ConditionSeverityError, "Missing etcd member")
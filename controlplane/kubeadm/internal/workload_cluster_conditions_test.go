@@ -1929,3 +1929,29 @@ func TestCompareMachinesAndMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareMachinesAndMembersWithLearners(t *testing.T) {
+	g := NewWithT(t)
+
+	m1 := fakeMachine("m1", withNodeRef("m1"))
+	m2 := fakeMachine("m2", withNodeRef("m2"))
+	controlPlane := &ControlPlane{
+		KCP:      &controlplanev1.KubeadmControlPlane{},
+		Machines: collections.FromMachines(m1, m2),
+	}
+	members := []*etcd.Member{
+		{Name: "m1"},
+		{Name: "m2", IsLearner: true},
+	}
+
+	got, gotErrors := compareMachinesAndMembers(controlPlane, nil, members)
+	g.Expect(got).To(BeTrue())
+	g.Expect(gotErrors).To(BeEmpty())
+
+	// The machine hosting the learner reports the etcd member is not yet a voting member.
+	g.Expect(conditions.Get(m1, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition)).To(BeNil())
+	c := conditions.Get(m2, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition)
+	g.Expect(c).ToNot(BeNil())
+	g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).To(Equal(controlplanev1.KubeadmControlPlaneMachineEtcdMemberLearnerReason))
+}
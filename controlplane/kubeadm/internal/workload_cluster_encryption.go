@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+// rewriteSecretsPageSize is the number of Secrets listed at once when rewriting Secrets.
+const rewriteSecretsPageSize = 500
+
+// UpdateEncryptionInKubeadmConfigMap updates the API server configuration in the kubeadm config map, so the API server
+// of joining control plane Machines uses the encryption configuration.
+// NOTE: This mutator must run after UpdateAPIServerInKubeadmConfigMap.
+func (w *Workload) UpdateEncryptionInKubeadmConfigMap(kcp *controlplanev1.KubeadmControlPlane) func(*bootstrapv1.ClusterConfiguration) {
+	return func(c *bootstrapv1.ClusterConfiguration) {
+		if !isEncryptionConfigured(kcp) {
+			return
+		}
+		setEncryptionConfigurationInAPIServer(&c.APIServer, kcp.Spec.Encryption)
+	}
+}
+
+// RewriteSecrets rewrites all the Secrets in the workload cluster, so they get encrypted with the current primary
+// encryption key.
+func (w *Workload) RewriteSecrets(ctx context.Context) error {
+	secrets := &corev1.SecretList{}
+	continueToken := ""
+	for {
+		if err := w.Client.List(ctx, secrets, ctrlclient.Limit(rewriteSecretsPageSize), ctrlclient.Continue(continueToken)); err != nil {
+			return errors.Wrap(err, "failed to list Secrets")
+		}
+
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			// Note: An update without changes is enough to get the Secret stored using the current primary key.
+			// Conflict and NotFound errors are ignored, because in both cases the Secret has been written or deleted
+			// after the current primary key has been promoted.
+			if err := w.Client.Update(ctx, secret); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to rewrite Secret %s", klog.KObj(secret))
+			}
+		}
+
+		continueToken = secrets.Continue
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestUpdateEncryptionInKubeadmConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEncryptionAtRest, true)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ExtraArgs: []bootstrapv1.Arg{{Name: "v", Value: ptr.To("4")}},
+					},
+				},
+			},
+			Encryption: controlplanev1.KubeadmControlPlaneEncryptionSpec{
+				Provider: controlplanev1.KubeadmControlPlaneEncryptionProviderAESCBC,
+			},
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Encryption: controlplanev1.KubeadmControlPlaneEncryptionStatus{
+				ConfigurationSecretName: "cluster-encryption-0123456789",
+			},
+		},
+	}
+
+	w := &Workload{}
+	clusterConfiguration := &bootstrapv1.ClusterConfiguration{}
+	w.UpdateAPIServerInKubeadmConfigMap(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer)(clusterConfiguration)
+	w.UpdateEncryptionInKubeadmConfigMap(kcp)(clusterConfiguration)
+
+	g.Expect(clusterConfiguration.APIServer.ExtraArgs).To(Equal([]bootstrapv1.Arg{
+		{Name: "v", Value: ptr.To("4")},
+		{Name: "encryption-provider-config", Value: ptr.To("/etc/kubernetes/encryption/encryption-configuration.yaml")},
+	}))
+	g.Expect(clusterConfiguration.APIServer.ExtraVolumes).To(HaveLen(1))
+	// The KCP object must not be modified.
+	g.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs).To(HaveLen(1))
+}
+
+func TestRewriteSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	objs := []ctrlclient.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s1", Namespace: metav1.NamespaceSystem}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s2", Namespace: metav1.NamespaceDefault}},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+	resourceVersions := map[string]string{}
+	for _, obj := range objs {
+		s := &corev1.Secret{}
+		g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), s)).To(Succeed())
+		resourceVersions[s.Name] = s.ResourceVersion
+	}
+
+	w := &Workload{Client: fakeClient}
+	g.Expect(w.RewriteSecrets(ctx)).To(Succeed())
+
+	for _, obj := range objs {
+		s := &corev1.Secret{}
+		g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), s)).To(Succeed())
+		g.Expect(s.ResourceVersion).ToNot(Equal(resourceVersions[s.Name]), "Secret %s was not rewritten", s.Name)
+	}
+}
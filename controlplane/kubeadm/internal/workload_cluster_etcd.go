@@ -182,6 +182,49 @@ func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1
 	return nil
 }
 
+// PromoteEtcdLearnerForMachine promotes the etcd learner member hosted on a Machine to voting member.
+// If the learner is not yet in sync with the leader, an error wrapping etcd.ErrLearnerNotReady is returned.
+func (w *Workload) PromoteEtcdLearnerForMachine(ctx context.Context, machine *clusterv1.Machine) error {
+	if machine == nil || !machine.Status.NodeRef.IsDefined() {
+		// Nothing to do, no node for Machine
+		return nil
+	}
+
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	// Exclude the node hosting the learner from the etcd client node list, because learners do not serve client requests.
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if node.Name != machine.Status.NodeRef.Name {
+			nodeNames = append(nodeNames, node.Name)
+		}
+	}
+	etcdClient, err := w.etcdClientGenerator.forLeader(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	members, err := etcdClient.Members(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd members using etcd client")
+	}
+
+	member := etcdutil.MemberForName(members, machine.Status.NodeRef.Name)
+	if member == nil {
+		return errors.Errorf("failed to get etcd member from node %q", machine.Status.NodeRef.Name)
+	}
+	if !member.IsLearner {
+		// Nothing to do, the member is already a voting member.
+		return nil
+	}
+
+	return etcdClient.PromoteMember(ctx, member.ID)
+}
+
 // EtcdMemberStatus contains status information for a single etcd member.
 type EtcdMemberStatus struct {
 	Name       string
@@ -219,3 +262,131 @@ func (w *Workload) EtcdMembers(ctx context.Context) ([]string, error) {
 	}
 	return names, nil
 }
+
+// EtcdMemberDBStatus contains the status of the database of a single etcd member.
+type EtcdMemberDBStatus struct {
+	// Name is the name of the member, which is the name of the Node hosting the member.
+	Name string
+
+	// ID is the ID of the member.
+	ID uint64
+
+	// IsLeader is true if the member is the etcd leader.
+	IsLeader bool
+
+	// DBSize is the size of the database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the database of the member which is logically in use, in bytes.
+	DBSizeInUse int64
+}
+
+// EtcdMemberDBStatuses returns the status of the database of the etcd members hosted on the given nodes.
+// NOTE: The status of a member can only be read by connecting to the member itself, so this func
+// opens a connection to every member.
+func (w *Workload) EtcdMemberDBStatuses(ctx context.Context, nodeNames []string) ([]EtcdMemberDBStatus, error) {
+	statuses := make([]EtcdMemberDBStatus, 0, len(nodeNames))
+	errs := []error{}
+	for _, nodeName := range nodeNames {
+		status, err := w.etcdMemberDBStatus(ctx, nodeName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, kerrors.NewAggregate(errs)
+}
+
+func (w *Workload) etcdMemberDBStatus(ctx context.Context, nodeName string) (*EtcdMemberDBStatus, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create etcd client for Node %s", nodeName)
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of the etcd member hosted on Node %s", nodeName)
+	}
+	return &EtcdMemberDBStatus{
+		Name:        nodeName,
+		ID:          status.MemberID,
+		IsLeader:    status.MemberID == status.LeaderID,
+		DBSize:      status.DBSize,
+		DBSizeInUse: status.DBSizeInUse,
+	}, nil
+}
+
+// DefragmentEtcdMember defragments the database of the etcd member hosted on the given node.
+// NOTE: The member does not serve requests while it is defragmenting its database; callers are responsible
+// to defragment one member at a time and to move leadership away from the member before defragmenting it.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, nodeName string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create etcd client for Node %s", nodeName)
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Defragment(ctx)
+}
+
+// CompactEtcd compacts the etcd keyspace to the current revision, waiting for the compacted entries to be
+// physically removed; this is used to free as much space as possible e.g. when remediating a NOSPACE alarm.
+func (w *Workload) CompactEtcd(ctx context.Context, nodeNames []string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the current etcd revision")
+	}
+	return etcdClient.Compact(ctx, status.Revision, true)
+}
+
+// CompactEtcdHistory compacts the etcd keyspace to the current revision minus the given number of retained
+// revisions, so watchers which are lagging behind by less than the retained revisions can still resume.
+// Compaction is a no-op if the current revision is not greater than the retained revisions.
+func (w *Workload) CompactEtcdHistory(ctx context.Context, nodeNames []string, retainedRevisions int64) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the current etcd revision")
+	}
+	revision := status.Revision - retainedRevisions
+	if revision <= 0 {
+		return nil
+	}
+	return etcdClient.Compact(ctx, revision, false)
+}
+
+// DisarmEtcdAlarms disarms all the etcd alarms of the given type.
+func (w *Workload) DisarmEtcdAlarms(ctx context.Context, nodeNames []string, alarmType etcd.AlarmType) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	alarms, err := etcdClient.Alarms(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd alarms")
+	}
+	for _, alarm := range alarms {
+		if alarm.Type != alarmType {
+			continue
+		}
+		if err := etcdClient.DisarmAlarm(ctx, alarm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
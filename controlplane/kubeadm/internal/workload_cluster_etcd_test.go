@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -553,6 +554,143 @@ func TestForwardEtcdLeadership(t *testing.T) {
 	})
 }
 
+func TestPromoteEtcdLearnerForMachine(t *testing.T) {
+	tests := []struct {
+		name                string
+		machine             *clusterv1.Machine
+		members             []*pb.Member
+		promoteErr          error
+		expectPromoted      uint64
+		expectErr           bool
+		expectNotReadyError bool
+	}{
+		{
+			name:    "does nothing if machine's NodeRef is nil",
+			machine: defaultMachine(func(m *clusterv1.Machine) { m.Status.NodeRef = clusterv1.MachineNodeReference{} }),
+		},
+		{
+			name:    "does nothing if the member is already a voting member",
+			machine: defaultMachine(),
+			members: []*pb.Member{
+				{Name: "machine-node", ID: uint64(101)},
+				{Name: "other-node", ID: uint64(102)},
+			},
+		},
+		{
+			name:    "returns an error if the member does not exist",
+			machine: defaultMachine(),
+			members: []*pb.Member{
+				{Name: "other-node", ID: uint64(102)},
+			},
+			expectErr: true,
+		},
+		{
+			name:    "promotes the learner",
+			machine: defaultMachine(),
+			members: []*pb.Member{
+				{Name: "machine-node", ID: uint64(101), IsLearner: true},
+				{Name: "other-node", ID: uint64(102)},
+			},
+			expectPromoted: 101,
+		},
+		{
+			name:    "returns ErrLearnerNotReady if the learner is not in sync with the leader",
+			machine: defaultMachine(),
+			members: []*pb.Member{
+				{Name: "machine-node", ID: uint64(101), IsLearner: true},
+				{Name: "other-node", ID: uint64(102)},
+			},
+			promoteErr:          rpctypes.ErrMemberLearnerNotReady,
+			expectPromoted:      101,
+			expectErr:           true,
+			expectNotReadyError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeEtcdClient := &fake2.FakeEtcdClient{
+				MemberListResponse: &clientv3.MemberListResponse{
+					Members: tt.members,
+				},
+				MemberPromoteResponse: &clientv3.MemberPromoteResponse{},
+			}
+			etcdClientGenerator := &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &promoteErrEtcdClient{FakeEtcdClient: fakeEtcdClient, promoteErr: tt.promoteErr},
+				},
+			}
+
+			w := &Workload{
+				Client: &fakeClient{list: &corev1.NodeList{
+					Items: []corev1.Node{nodeNamed("machine-node"), nodeNamed("other-node")},
+				}},
+				etcdClientGenerator: etcdClientGenerator,
+			}
+			err := w.PromoteEtcdLearnerForMachine(ctx, tt.machine)
+			g.Expect(fakeEtcdClient.PromotedMember).To(Equal(tt.expectPromoted))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, etcd.ErrLearnerNotReady)).To(Equal(tt.expectNotReadyError))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+// promoteErrEtcdClient is a FakeEtcdClient returning an error only for MemberPromote calls.
+type promoteErrEtcdClient struct {
+	*fake2.FakeEtcdClient
+	promoteErr error
+}
+
+func (c *promoteErrEtcdClient) MemberPromote(ctx context.Context, id uint64) (*clientv3.MemberPromoteResponse, error) {
+	resp, _ := c.FakeEtcdClient.MemberPromote(ctx, id)
+	return resp, c.promoteErr
+}
+
+func TestCompactEtcdHistory(t *testing.T) {
+	tests := []struct {
+		name              string
+		revision          int64
+		retainedRevisions int64
+		expectCompacted   int64
+	}{
+		{
+			name:              "compacts to the current revision minus the retained revisions",
+			revision:          15000,
+			retainedRevisions: 10000,
+			expectCompacted:   5000,
+		},
+		{
+			name:              "does nothing if the current revision is not greater than the retained revisions",
+			revision:          10000,
+			retainedRevisions: 10000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeEtcdClient := &fake2.FakeEtcdClient{
+				StatusResponse: &clientv3.StatusResponse{
+					Header: &pb.ResponseHeader{Revision: tt.revision},
+				},
+				CompactResponse: &clientv3.CompactResponse{},
+			}
+			w := &Workload{
+				etcdClientGenerator: &fakeEtcdClientGenerator{
+					forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+				},
+			}
+			g.Expect(w.CompactEtcdHistory(ctx, []string{"machine-node"}, tt.retainedRevisions)).To(Succeed())
+			g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(tt.expectCompacted))
+		})
+	}
+}
+
 func TestReconcileEtcdMembersAndControlPlaneNodes(t *testing.T) {
 	node1 := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
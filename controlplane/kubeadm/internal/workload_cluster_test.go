@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -35,6 +36,7 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
 
@@ -887,6 +889,7 @@ func TestUpdateFeatureGatesInKubeadmConfigMap(t *testing.T) {
 		name                     string
 		clusterConfigurationData string
 		kubernetesVersion        semver.Version
+		etcdLearnerMode          bool
 		newClusterConfiguration  bootstrapv1.ClusterConfiguration
 		wantClusterConfiguration bootstrapv1.ClusterConfiguration
 	}{
@@ -988,11 +991,75 @@ func TestUpdateFeatureGatesInKubeadmConfigMap(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "it should add EtcdLearnerMode feature gate for 1.28 if the KubeadmControlPlaneEtcdLearnerMode feature gate is enabled",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration`),
+			kubernetesVersion: semver.MustParse("1.28.0"),
+			etcdLearnerMode:   true,
+			newClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: nil,
+			},
+			wantClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: map[string]bool{
+					EtcdLearnerMode: true,
+				},
+			},
+		},
+		{
+			name: "it should not add EtcdLearnerMode feature gate for 1.28 if the KubeadmControlPlaneEtcdLearnerMode feature gate is disabled",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration`),
+			kubernetesVersion: semver.MustParse("1.28.0"),
+			newClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: nil,
+			},
+			wantClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: nil,
+			},
+		},
+		{
+			name: "it should not add EtcdLearnerMode feature gate for 1.29, it is enabled by default",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration`),
+			kubernetesVersion: semver.MustParse("1.29.0"),
+			etcdLearnerMode:   true,
+			newClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: nil,
+			},
+			wantClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: nil,
+			},
+		},
+		{
+			name: "it should preserve EtcdLearnerMode false feature gate for 1.28",
+			clusterConfigurationData: utilyaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration`),
+			kubernetesVersion: semver.MustParse("1.28.0"),
+			etcdLearnerMode:   true,
+			newClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: map[string]bool{
+					EtcdLearnerMode: false,
+				},
+			},
+			wantClusterConfiguration: bootstrapv1.ClusterConfiguration{
+				FeatureGates: map[string]bool{
+					EtcdLearnerMode: false,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmControlPlaneEtcdLearnerMode, tt.etcdLearnerMode)
+
 			fakeClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      kubeadmConfigKey,
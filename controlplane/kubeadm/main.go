@@ -68,6 +68,7 @@ import (
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/util/apiwarnings"
 	"sigs.k8s.io/cluster-api/util/flags"
+	"sigs.k8s.io/cluster-api/util/tunnel"
 	"sigs.k8s.io/cluster-api/version"
 )
 
@@ -95,6 +96,8 @@ var (
 	webhookCertName             string
 	webhookKeyName              string
 	healthAddr                  string
+	reverseTunnelGatewayAddress string
+	reverseTunnelProxyTokenFile string
 	managerOptions              = flags.ManagerOptions{}
 	logOptions                  = logs.NewOptions()
 	// KCP specific flags.
@@ -198,6 +201,15 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&etcdLogLevel, "etcd-client-log-level", zapcore.InfoLevel.String(),
 		"Logging level for etcd client. Possible values are: debug, info, warn, error, dpanic, panic, fatal.")
 
+	fs.StringVar(&reverseTunnelGatewayAddress, "reverse-tunnel-gateway-address", "",
+		"Address (host:port) of the proxy endpoint of the reverse tunnel gateway, used to access workload clusters with the 'cluster.x-k8s.io/reverse-tunnel' annotation. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelProxyTokenFile, "reverse-tunnel-proxy-token-file", "",
+		"Path of the file containing the token used to authenticate with the proxy endpoint of the reverse tunnel gateway; "+
+			"it is required if --reverse-tunnel-gateway-address is set. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -372,6 +384,16 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		must(labels.NewRequirement("component", selection.In, []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "etcd"})),
 	)
 
+	var clusterDialer clustercache.ClusterDialer
+	if feature.Gates.Enabled(feature.ClusterReverseTunnel) && reverseTunnelGatewayAddress != "" {
+		proxyToken, err := tunnel.ReadTokenFile(reverseTunnelProxyTokenFile)
+		if err != nil {
+			setupLog.Error(err, "Unable to read reverse tunnel proxy token")
+			os.Exit(1)
+		}
+		clusterDialer = tunnel.NewDialer(mgr.GetClient(), reverseTunnelGatewayAddress, proxyToken).DialContextForCluster
+	}
+
 	clusterCache, err := clustercache.SetupWithManager(ctx, mgr, clustercache.Options{
 		SecretClient: secretCachingClient,
 		Cache: clustercache.CacheOptions{
@@ -390,6 +412,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			QPS:       clusterCacheClientQPS,
 			Burst:     clusterCacheClientBurst,
 			UserAgent: remote.DefaultClusterAPIUserAgent(controllerName),
+			Dialer:    clusterDialer,
 			Cache: clustercache.ClientCacheOptions{
 				DisableFor: []client.Object{
 					&corev1.ConfigMap{},
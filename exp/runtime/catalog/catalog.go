@@ -89,6 +89,11 @@ type HookMeta struct {
 	// Singleton signals if the hook can only be implemented once on a
 	// Runtime Extension, e.g. like the Discovery hook.
 	Singleton bool
+
+	// Serial signals if the ExtensionHandlers for the hook must be called one after the
+	// other in order of their names, e.g. because the order of the calls matters.
+	// By default the ExtensionHandlers for a hook are called in parallel.
+	Serial bool
 }
 
 // OpenAPIDefinitionsGetter defines a func which returns OpenAPI definitions for all
@@ -320,6 +325,12 @@ func (c *Catalog) IsHookRegistered(gvh GroupVersionHook) bool {
 	return found
 }
 
+// IsHookSerial returns true if the ExtensionHandlers for the GroupVersionHook must be called serially.
+func (c *Catalog) IsHookSerial(gvh GroupVersionHook) bool {
+	descriptor, found := c.gvhToHookDescriptor[gvh]
+	return found && descriptor.metadata.Serial
+}
+
 // GroupVersionHook unambiguously identifies a Hook.
 type GroupVersionHook struct {
 	Group   string
@@ -19,8 +19,10 @@ package client
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
@@ -66,6 +68,19 @@ type CallExtensionOptions struct {
 	CacheKeyFunc func(extensionName, extensionConfigResourceVersion string, request runtimehooksv1.RequestObject) string
 }
 
+// CircuitBreakerState is the state of the circuit breaker for calls to the Extension of an ExtensionConfig.
+type CircuitBreakerState struct {
+	// Open is true if the circuit is open, which means calls to the Extension fail immediately.
+	Open bool
+
+	// OpenUntil is the time until which the circuit stays open before a call is sent to the Extension
+	// to probe if it recovered.
+	OpenUntil time.Time
+
+	// ConsecutiveFailures is the number of consecutive failed calls to the Extension.
+	ConsecutiveFailures int32
+}
+
 // Client is the runtime client to interact with extensions.
 type Client interface {
 	// WarmUp can be used to initialize a "cold" RuntimeClient with all
@@ -91,4 +106,11 @@ type Client interface {
 
 	// CallExtension calls the ExtensionHandler with the given name.
 	CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject, opts ...CallExtensionOption) error
+
+	// GetCircuitBreakerState returns the state of the circuit breaker for calls to the Extension of the ExtensionConfig.
+	GetCircuitBreakerState(extensionConfigName string) CircuitBreakerState
+
+	// GetCircuitBreakerSource returns a Source of events for ExtensionConfigs, which is triggered every time
+	// the circuit breaker for calls to the corresponding Extension opens or closes.
+	GetCircuitBreakerSource() source.Source
 }
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrUnauthenticated is returned by an Authorizer if the caller could not be authenticated.
+// Requests failing with this error are answered with 401 Unauthorized, all other errors
+// returned by an Authorizer are answered with 403 Forbidden.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authorizer authorizes calls to the extension handlers of a Server.
+type Authorizer interface {
+	// Authorize returns an error if the caller of the request is not authorized.
+	Authorize(ctx context.Context, r *http.Request) error
+}
+
+// AuthorizerFunc is a func implementing Authorizer.
+type AuthorizerFunc func(ctx context.Context, r *http.Request) error
+
+// Authorize implements Authorizer.
+func (f AuthorizerFunc) Authorize(ctx context.Context, r *http.Request) error {
+	return f(ctx, r)
+}
+
+// ClientCertificateAuthorizer authorizes callers presenting a client certificate verified by the Server.
+// Note: This requires Options.ClientCAName to be set, so the Server verifies client certificates.
+type ClientCertificateAuthorizer struct {
+	// AllowedNames are the names allowed for the client certificate; the name of a client certificate
+	// is its common name or any of its DNS names.
+	// If empty, all client certificates verified by the Server are allowed.
+	AllowedNames []string
+}
+
+// Authorize implements Authorizer.
+func (a *ClientCertificateAuthorizer) Authorize(_ context.Context, r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return errors.Wrap(ErrUnauthenticated, "no verified client certificate")
+	}
+	if len(a.AllowedNames) == 0 {
+		return nil
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+	if slices.Contains(a.AllowedNames, cert.Subject.CommonName) {
+		return nil
+	}
+	for _, name := range cert.DNSNames {
+		if slices.Contains(a.AllowedNames, name) {
+			return nil
+		}
+	}
+	return errors.Errorf("client certificate %q is not allowed", cert.Subject.CommonName)
+}
+
+// ServiceAccountTokenAuthorizer authorizes callers presenting a ServiceAccount token as bearer token,
+// using a TokenReview to verify the token is valid and bound to the expected audience.
+// Note: The client must be allowed to create tokenreviews.authentication.k8s.io.
+type ServiceAccountTokenAuthorizer struct {
+	// Client is the client used to create TokenReviews.
+	Client client.Client
+
+	// Audience is the audience the token must be bound to; it should match
+	// spec.clientConfig.authentication.serviceAccountToken.audience of the ExtensionConfig.
+	Audience string
+
+	// AllowedUsernames are the usernames allowed to call the Server,
+	// e.g. system:serviceaccount:capi-system:capi-manager.
+	// If empty, all ServiceAccount tokens bound to the audience are allowed.
+	AllowedUsernames []string
+}
+
+// Authorize implements Authorizer.
+func (a *ServiceAccountTokenAuthorizer) Authorize(ctx context.Context, r *http.Request) error {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return errors.Wrap(ErrUnauthenticated, "no bearer token")
+	}
+
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{a.Audience},
+		},
+	}
+	if err := a.Client.Create(ctx, tokenReview); err != nil {
+		return errors.Wrap(err, "failed to create TokenReview")
+	}
+
+	if !tokenReview.Status.Authenticated {
+		if tokenReview.Status.Error != "" {
+			return errors.Wrap(ErrUnauthenticated, tokenReview.Status.Error)
+		}
+		return errors.Wrap(ErrUnauthenticated, "invalid bearer token")
+	}
+	if !slices.Contains(tokenReview.Status.Audiences, a.Audience) {
+		return errors.Wrapf(ErrUnauthenticated, "bearer token is not bound to audience %q", a.Audience)
+	}
+	if len(a.AllowedUsernames) > 0 && !slices.Contains(a.AllowedUsernames, tokenReview.Status.User.Username) {
+		return errors.Errorf("user %q is not allowed", tokenReview.Status.User.Username)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestClientCertificateAuthorizer(t *testing.T) {
+	requestWithCert := func(cert *x509.Certificate) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+		r.TLS = &tls.ConnectionState{}
+		if cert != nil {
+			r.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name                string
+		authorizer          *ClientCertificateAuthorizer
+		request             *http.Request
+		wantErr             bool
+		wantUnauthenticated bool
+	}{
+		{
+			name:                "fails without verified client certificate",
+			authorizer:          &ClientCertificateAuthorizer{},
+			request:             requestWithCert(nil),
+			wantErr:             true,
+			wantUnauthenticated: true,
+		},
+		{
+			name:       "allows any verified client certificate without allowed names",
+			authorizer: &ClientCertificateAuthorizer{},
+			request:    requestWithCert(&x509.Certificate{Subject: pkix.Name{CommonName: "foo"}}),
+		},
+		{
+			name:       "allows client certificate with allowed common name",
+			authorizer: &ClientCertificateAuthorizer{AllowedNames: []string{"foo"}},
+			request:    requestWithCert(&x509.Certificate{Subject: pkix.Name{CommonName: "foo"}}),
+		},
+		{
+			name:       "allows client certificate with allowed DNS name",
+			authorizer: &ClientCertificateAuthorizer{AllowedNames: []string{"foo.example.com"}},
+			request:    requestWithCert(&x509.Certificate{Subject: pkix.Name{CommonName: "foo"}, DNSNames: []string{"foo.example.com"}}),
+		},
+		{
+			name:       "rejects client certificate with other names",
+			authorizer: &ClientCertificateAuthorizer{AllowedNames: []string{"bar"}},
+			request:    requestWithCert(&x509.Certificate{Subject: pkix.Name{CommonName: "foo"}, DNSNames: []string{"foo.example.com"}}),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := tt.authorizer.Authorize(context.Background(), tt.request)
+			if !tt.wantErr {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(errors.Is(err, ErrUnauthenticated)).To(Equal(tt.wantUnauthenticated))
+		})
+	}
+}
+
+func TestServiceAccountTokenAuthorizer(t *testing.T) {
+	const audience = "foo.example.com"
+
+	// fakeTokenReviewClient returns a client answering TokenReviews for the "valid-token" token
+	// with the given username and audiences.
+	fakeTokenReviewClient := func(username string, audiences ...string) client.Client {
+		return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				tokenReview := obj.(*authenticationv1.TokenReview)
+				if tokenReview.Spec.Token != "valid-token" {
+					tokenReview.Status.Error = "invalid token"
+					return nil
+				}
+				tokenReview.Status.Authenticated = true
+				tokenReview.Status.Audiences = audiences
+				tokenReview.Status.User.Username = username
+				return nil
+			},
+		}).Build()
+	}
+	requestWithToken := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name                string
+		authorizer          *ServiceAccountTokenAuthorizer
+		request             *http.Request
+		wantErr             bool
+		wantUnauthenticated bool
+	}{
+		{
+			name:                "fails without bearer token",
+			authorizer:          &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("capi", audience), Audience: audience},
+			request:             requestWithToken(""),
+			wantErr:             true,
+			wantUnauthenticated: true,
+		},
+		{
+			name:                "fails with invalid bearer token",
+			authorizer:          &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("capi", audience), Audience: audience},
+			request:             requestWithToken("invalid-token"),
+			wantErr:             true,
+			wantUnauthenticated: true,
+		},
+		{
+			name:                "fails with bearer token bound to another audience",
+			authorizer:          &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("capi", "bar.example.com"), Audience: audience},
+			request:             requestWithToken("valid-token"),
+			wantErr:             true,
+			wantUnauthenticated: true,
+		},
+		{
+			name:       "allows valid bearer token without allowed usernames",
+			authorizer: &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("capi", audience), Audience: audience},
+			request:    requestWithToken("valid-token"),
+		},
+		{
+			name:       "allows valid bearer token with allowed username",
+			authorizer: &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("capi", audience), Audience: audience, AllowedUsernames: []string{"capi"}},
+			request:    requestWithToken("valid-token"),
+		},
+		{
+			name:       "rejects valid bearer token with other username",
+			authorizer: &ServiceAccountTokenAuthorizer{Client: fakeTokenReviewClient("other", audience), Audience: audience, AllowedUsernames: []string{"capi"}},
+			request:    requestWithToken("valid-token"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := tt.authorizer.Authorize(context.Background(), tt.request)
+			if !tt.wantErr {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(errors.Is(err, ErrUnauthenticated)).To(Equal(tt.wantUnauthenticated))
+		})
+	}
+}
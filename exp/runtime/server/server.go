@@ -44,8 +44,9 @@ var DefaultPort = 9443
 // Server is a runtime webhook server.
 type Server struct {
 	webhook.Server
-	catalog  *runtimecatalog.Catalog
-	handlers map[string]ExtensionHandler
+	catalog    *runtimecatalog.Catalog
+	authorizer Authorizer
+	handlers   map[string]ExtensionHandler
 }
 
 // Options are the options for the Server.
@@ -87,6 +88,12 @@ type Options struct {
 	// TLSOpts is used to allow configuring the TLS config used for the server.
 	// This also allows providing a certificate via GetCertificate.
 	TLSOpts []func(*tls.Config)
+
+	// Authorizer is used to authorize calls to the extension handlers, including discovery.
+	// Defaults to nil, which means all calls are allowed.
+	// See ClientCertificateAuthorizer and ServiceAccountTokenAuthorizer for authorizers
+	// matching the authentication options of ExtensionConfigs.
+	Authorizer Authorizer
 }
 
 // New creates a new runtime webhook server based on the given Options.
@@ -121,9 +128,10 @@ func New(options Options) (*Server, error) {
 	)
 
 	return &Server{
-		Server:   webhookServer,
-		catalog:  options.Catalog,
-		handlers: map[string]ExtensionHandler{},
+		Server:     webhookServer,
+		catalog:    options.Catalog,
+		authorizer: options.Authorizer,
+		handlers:   map[string]ExtensionHandler{},
 	}, nil
 }
 
@@ -284,6 +292,18 @@ func discoveryHandler(handlers map[string]ExtensionHandler) func(context.Context
 
 func (s *Server) wrapHandler(handler ExtensionHandler) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if s.authorizer != nil {
+			if err := s.authorizer.Authorize(r.Context(), r); err != nil {
+				log.Log.V(4).Info("Rejected unauthorized call to extension handler", "path", r.URL.Path, "reason", err.Error())
+				status := http.StatusForbidden
+				if errors.Is(err, ErrUnauthenticated) {
+					status = http.StatusUnauthorized
+				}
+				http.Error(w, http.StatusText(status), status)
+				return
+			}
+		}
+
 		response := s.callHandler(handler, r)
 
 		responseBody, err := json.Marshal(response)
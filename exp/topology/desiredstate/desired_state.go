@@ -198,6 +198,15 @@ func (g *generator) Generate(ctx context.Context, s *scope.Scope) (*scope.Cluste
 		}
 	}
 
+	// If required, compute the desired state of the add-on objects in the management cluster.
+	if len(s.Blueprint.Addons) > 0 {
+		desiredState.Addons, err = computeAddons(ctx, s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute add-ons")
+		}
+		desiredState.WorkloadClusterAddons = computeWorkloadClusterAddons(ctx, s)
+	}
+
 	// Apply patches the desired state according to the patches from the ClusterClass, variables from the Cluster
 	// and builtin variables.
 	// NOTE: We have to make sure all spec fields that were explicitly set in desired objects during the computation above
@@ -249,6 +258,59 @@ func computeInfrastructureCluster(_ context.Context, s *scope.Scope) (*unstructu
 	return infrastructureCluster, nil
 }
 
+// computeAddons computes the desired state of the add-on objects in the management cluster starting from the
+// corresponding templates defined in the blueprint.
+func computeAddons(_ context.Context, s *scope.Scope) (map[string]*unstructured.Unstructured, error) {
+	addons := map[string]*unstructured.Unstructured{}
+	for _, addonClass := range s.Blueprint.ClusterClass.Spec.Addons {
+		addonBlueprint, ok := s.Blueprint.Addons[addonClass.Name]
+		if !ok || addonBlueprint.Template == nil {
+			continue
+		}
+
+		// NOTE: Add-on objects have a deterministic name, so they can be found without a reference.
+		name := topologynames.AddonName(s.Current.Cluster.Name, addonClass.Name)
+		addon, err := templateToObject(templateToInput{
+			template:              addonBlueprint.Template,
+			templateClonedFromRef: addonClass.TemplateRef.ToObjectReference(s.Blueprint.ClusterClass.Namespace),
+			cluster:               s.Current.Cluster,
+			nameGenerator:         topologynames.SimpleNameGenerator(name),
+			currentObjectName:     name,
+			labels: map[string]string{
+				clusterv1.ClusterTopologyAddonNameLabel: addonClass.Name,
+			},
+			// Note: we are adding an ownerRef to Cluster so the add-on object will be automatically garbage collected
+			// when the Cluster is deleted.
+			ownerRef: ownerrefs.OwnerReferenceTo(s.Current.Cluster, clusterv1.GroupVersion.WithKind("Cluster")),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate the object for add-on %q from the %s", addonClass.Name, addonBlueprint.Template.GetKind())
+		}
+		addons[addonClass.Name] = addon
+	}
+	return addons, nil
+}
+
+// computeWorkloadClusterAddons computes the desired state of the manifests applied to the workload cluster
+// starting from the corresponding manifests defined in the blueprint.
+// NOTE: Manifests are copied, so they can be patched for the Cluster without modifying the blueprint.
+func computeWorkloadClusterAddons(_ context.Context, s *scope.Scope) map[string][]*unstructured.Unstructured {
+	addons := map[string][]*unstructured.Unstructured{}
+	for _, addonClass := range s.Blueprint.ClusterClass.Spec.Addons {
+		addonBlueprint, ok := s.Blueprint.Addons[addonClass.Name]
+		if !ok || len(addonBlueprint.WorkloadClusterManifests) == 0 {
+			continue
+		}
+
+		manifests := make([]*unstructured.Unstructured, 0, len(addonBlueprint.WorkloadClusterManifests))
+		for _, manifest := range addonBlueprint.WorkloadClusterManifests {
+			manifests = append(manifests, manifest.DeepCopy())
+		}
+		addons[addonClass.Name] = manifests
+	}
+	return addons
+}
+
 // computeControlPlaneInfrastructureMachineTemplate computes the desired state for InfrastructureMachineTemplate
 // that should be referenced by the ControlPlane object.
 func (g *generator) computeControlPlaneInfrastructureMachineTemplate(ctx context.Context, s *scope.Scope) (*unstructured.Unstructured, error) {
@@ -985,6 +1047,14 @@ func (g *generator) computeMachineDeployment(ctx context.Context, s *scope.Scope
 			s.Current.Cluster,
 			checks, remediation)
 	}
+
+	// If the ClusterClass defines MachineDrainRules for the MachineDeployment add them to the desired state.
+	desiredMachineDeployment.MachineDrainRules = computeMachineDrainRules(
+		desiredMachineDeploymentObj,
+		selectors.ForMachineDeploymentMachineDrainRule(desiredMachineDeploymentObj),
+		s.Current.Cluster,
+		map[string]string{clusterv1.ClusterTopologyMachineDeploymentNameLabel: machineDeploymentTopology.Name},
+		machineDeploymentBlueprint.MachineDrainRules)
 	return desiredMachineDeployment, nil
 }
 
@@ -1097,7 +1167,7 @@ func (g *generator) computeMachinePools(ctx context.Context, s *scope.Scope) (sc
 // computeMachinePool computes the desired state for a MachinePoolTopology.
 // The generated machinePool object is calculated using the values from the machinePoolTopology and
 // the machinePool class.
-func (g *generator) computeMachinePool(_ context.Context, s *scope.Scope, machinePoolTopology clusterv1.MachinePoolTopology) (*scope.MachinePoolState, error) {
+func (g *generator) computeMachinePool(ctx context.Context, s *scope.Scope, machinePoolTopology clusterv1.MachinePoolTopology) (*scope.MachinePoolState, error) {
 	desiredMachinePool := &scope.MachinePoolState{}
 
 	// Gets the blueprint for the MachinePool class.
@@ -1282,6 +1352,26 @@ func (g *generator) computeMachinePool(_ context.Context, s *scope.Scope, machin
 
 	desiredMachinePool.Object = desiredMachinePoolObj
 
+	// If the ClusterClass defines a MachineHealthCheck for the MachinePool add it to the desired state.
+	if s.Blueprint.IsMachinePoolMachineHealthCheckEnabled(&machinePoolTopology) {
+		// Note: The MHC is going to use a selector that provides a minimal set of labels which are common to all Machines belonging to the MachinePool.
+		checks, remediation := s.Blueprint.MachinePoolMachineHealthCheckClass(&machinePoolTopology)
+		desiredMachinePool.MachineHealthCheck = computeMachineHealthCheck(
+			ctx,
+			desiredMachinePoolObj,
+			selectors.ForMachinePoolMHC(desiredMachinePoolObj),
+			s.Current.Cluster,
+			checks, remediation)
+	}
+
+	// If the ClusterClass defines MachineDrainRules for the MachinePool add them to the desired state.
+	desiredMachinePool.MachineDrainRules = computeMachineDrainRules(
+		desiredMachinePoolObj,
+		selectors.ForMachinePoolMachineDrainRule(desiredMachinePoolObj),
+		s.Current.Cluster,
+		map[string]string{clusterv1.ClusterTopologyMachinePoolNameLabel: machinePoolTopology.Name},
+		machinePoolBlueprint.MachineDrainRules)
+
 	return desiredMachinePool, nil
 }
 
@@ -1535,6 +1625,48 @@ func computeMachineHealthCheck(ctx context.Context, healthCheckTarget client.Obj
 	return mhc
 }
 
+// computeMachineDrainRules computes the desired MachineDrainRules for the Machines of a MachineDeployment or MachinePool.
+func computeMachineDrainRules(drainTarget client.Object, machineSelector *metav1.LabelSelector, cluster *clusterv1.Cluster, topologyLabels map[string]string, machineDrainRuleClasses []clusterv1.MachineDrainRuleClass) map[string]*clusterv1.MachineDrainRule {
+	if len(machineDrainRuleClasses) == 0 {
+		return nil
+	}
+
+	machineDrainRules := make(map[string]*clusterv1.MachineDrainRule, len(machineDrainRuleClasses))
+	for _, machineDrainRuleClass := range machineDrainRuleClasses {
+		labels := map[string]string{
+			clusterv1.ClusterNameLabel:          cluster.Name,
+			clusterv1.ClusterTopologyOwnedLabel: "",
+		}
+		maps.Copy(labels, topologyLabels)
+
+		mdr := &clusterv1.MachineDrainRule{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "MachineDrainRule",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      topologynames.MachineDrainRuleName(drainTarget.GetName(), machineDrainRuleClass.Name),
+				Namespace: drainTarget.GetNamespace(),
+				Labels:    labels,
+				// Note: we are adding an ownerRef to Cluster so the MachineDrainRule will be automatically garbage collected
+				// in case deletion is triggered before an object reconcile happens.
+				OwnerReferences: []metav1.OwnerReference{
+					*ownerrefs.OwnerReferenceTo(cluster, clusterv1.GroupVersion.WithKind("Cluster")),
+				},
+			},
+			Spec: clusterv1.MachineDrainRuleSpec{
+				Drain: machineDrainRuleClass.Drain,
+				Machines: []clusterv1.MachineDrainRuleMachineSelector{
+					{Selector: machineSelector},
+				},
+				Pods: machineDrainRuleClass.Pods,
+			},
+		}
+		machineDrainRules[mdr.Name] = mdr
+	}
+	return machineDrainRules
+}
+
 func getOwnerReferenceFrom(obj, owner client.Object) *metav1.OwnerReference {
 	for _, o := range obj.GetOwnerReferences() {
 		if o.Kind == owner.GetObjectKind().GroupVersionKind().Kind && o.Name == owner.GetName() {
@@ -185,6 +185,88 @@ func TestComputeInfrastructureCluster(t *testing.T) {
 	})
 }
 
+func TestComputeAddons(t *testing.T) {
+	g := NewWithT(t)
+
+	// templates and ClusterClass
+	addonTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "addon-template1").
+		Build()
+	manifest := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "cni",
+				"namespace": metav1.NamespaceSystem,
+			},
+		},
+	}
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithAddons(
+			clusterv1.AddonClass{
+				Name: "addon1",
+				TemplateRef: clusterv1.ClusterClassTemplateReference{
+					APIVersion: addonTemplate.GetAPIVersion(),
+					Kind:       addonTemplate.GetKind(),
+					Name:       addonTemplate.GetName(),
+				},
+			},
+			clusterv1.AddonClass{
+				Name: "addon2",
+				WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{
+					ConfigMapName: "manifests",
+				},
+			},
+		).
+		Build()
+
+	// aggregating templates and cluster class into a blueprint (simulating getBlueprint)
+	blueprint := &scope.ClusterBlueprint{
+		ClusterClass: clusterClass,
+		Addons: map[string]*scope.AddonBlueprint{
+			"addon1": {Template: addonTemplate},
+			"addon2": {WorkloadClusterManifests: []*unstructured.Unstructured{manifest}},
+		},
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	s := scope.New(cluster)
+	s.Blueprint = blueprint
+
+	addons, err := computeAddons(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Only add-ons defined by a template are created in the management cluster.
+	g.Expect(addons).To(HaveLen(1))
+	g.Expect(addons).To(HaveKey("addon1"))
+
+	assertTemplateToObject(g, assertTemplateInput{
+		cluster:           s.Current.Cluster,
+		templateRef:       clusterClass.Spec.Addons[0].TemplateRef,
+		template:          addonTemplate,
+		labels:            map[string]string{clusterv1.ClusterTopologyAddonNameLabel: "addon1"},
+		annotations:       nil,
+		currentObjectName: "cluster1-addon1",
+		obj:               addons["addon1"],
+	})
+
+	// Ensure the add-on is owned by the Cluster.
+	g.Expect(addons["addon1"].GetOwnerReferences()).To(ConsistOf(*ownerrefs.OwnerReferenceTo(cluster, clusterv1.GroupVersion.WithKind("Cluster"))))
+
+	// Manifests applied to the workload cluster are copied from the blueprint, so they can be patched.
+	workloadClusterAddons := computeWorkloadClusterAddons(ctx, s)
+	g.Expect(workloadClusterAddons).To(HaveLen(1))
+	g.Expect(workloadClusterAddons["addon2"]).To(HaveLen(1))
+	g.Expect(workloadClusterAddons["addon2"][0]).To(BeComparableTo(manifest))
+	g.Expect(workloadClusterAddons["addon2"][0]).ToNot(BeIdenticalTo(manifest))
+}
+
 func TestComputeControlPlaneInfrastructureMachineTemplate(t *testing.T) {
 	// templates and ClusterClass
 	labels := map[string]string{"l1": ""}
@@ -2632,6 +2714,79 @@ func TestComputeMachinePool(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Should correctly generate a MachineHealthCheck and MachineDrainRules for the MachinePool", func(t *testing.T) {
+		g := NewWithT(t)
+
+		nodeStartupTimeout := ptr.To(int32(60))
+		blueprintWithHealthCheck := &scope.ClusterBlueprint{
+			Topology:     cluster.Spec.Topology,
+			ClusterClass: fakeClass,
+			MachinePools: map[string]*scope.MachinePoolBlueprint{
+				"linux-worker": {
+					BootstrapTemplate:                 workerBootstrapTemplate,
+					InfrastructureMachinePoolTemplate: workerInfrastructureMachinePoolTemplate,
+					HealthCheck: clusterv1.MachinePoolClassHealthCheck{
+						Checks: clusterv1.MachinePoolClassHealthCheckChecks{
+							NodeStartupTimeoutSeconds: nodeStartupTimeout,
+						},
+					},
+					MachineDrainRules: []clusterv1.MachineDrainRuleClass{
+						{
+							Name: "skip-logging",
+							Drain: clusterv1.MachineDrainRuleDrainConfig{
+								Behavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+							},
+							Pods: []clusterv1.MachineDrainRulePodSelector{
+								{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "logging"}}},
+							},
+						},
+					},
+				},
+			},
+		}
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprintWithHealthCheck
+		mpTopology := clusterv1.MachinePoolTopology{
+			Class: "linux-worker",
+			Name:  "big-pool-of-machines",
+		}
+
+		e := generator{}
+
+		actual, err := e.computeMachinePool(ctx, scope, mpTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// Check that the ClusterName and selector are set properly for the MachineHealthCheck.
+		g.Expect(actual.MachineHealthCheck.Name).To(Equal(actual.Object.Name))
+		g.Expect(actual.MachineHealthCheck.Spec.ClusterName).To(Equal(cluster.Name))
+		g.Expect(actual.MachineHealthCheck.Spec.Selector).To(BeComparableTo(metav1.LabelSelector{MatchLabels: map[string]string{
+			clusterv1.ClusterTopologyOwnedLabel:           "",
+			clusterv1.ClusterTopologyMachinePoolNameLabel: "big-pool-of-machines",
+		}}))
+		g.Expect(actual.MachineHealthCheck.Spec.Checks.NodeStartupTimeoutSeconds).To(Equal(nodeStartupTimeout))
+
+		// Check that the MachineDrainRule only selects Machines of the MachinePool.
+		g.Expect(actual.MachineDrainRules).To(HaveLen(1))
+		mdr := actual.MachineDrainRules[actual.Object.Name+"-skip-logging"]
+		g.Expect(mdr).ToNot(BeNil())
+		g.Expect(mdr.Labels).To(Equal(map[string]string{
+			clusterv1.ClusterNameLabel:                    cluster.Name,
+			clusterv1.ClusterTopologyOwnedLabel:           "",
+			clusterv1.ClusterTopologyMachinePoolNameLabel: "big-pool-of-machines",
+		}))
+		g.Expect(mdr.OwnerReferences).To(HaveLen(1))
+		g.Expect(mdr.OwnerReferences[0].Kind).To(Equal("Cluster"))
+		g.Expect(mdr.Spec.Drain.Behavior).To(Equal(clusterv1.MachineDrainRuleDrainBehaviorSkip))
+		g.Expect(mdr.Spec.Machines).To(BeComparableTo([]clusterv1.MachineDrainRuleMachineSelector{{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				clusterv1.ClusterNameLabel:                    cluster.Name,
+				clusterv1.ClusterTopologyOwnedLabel:           "",
+				clusterv1.ClusterTopologyMachinePoolNameLabel: "big-pool-of-machines",
+			}},
+		}}))
+		g.Expect(mdr.Spec.Pods).To(BeComparableTo(blueprintWithHealthCheck.MachinePools["linux-worker"].MachineDrainRules[0].Pods))
+	})
 }
 
 func TestComputeMachineDeploymentVersion(t *testing.T) {
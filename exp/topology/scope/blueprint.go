@@ -42,6 +42,9 @@ type ClusterBlueprint struct {
 
 	// MachinePools holds the MachinePoolBlueprints derived from ClusterClass.
 	MachinePools map[string]*MachinePoolBlueprint
+
+	// Addons holds the AddonBlueprints derived from ClusterClass, keyed by add-on name.
+	Addons map[string]*AddonBlueprint
 }
 
 // ControlPlaneBlueprint holds the templates required for computing the desired state of a managed control plane.
@@ -74,6 +77,10 @@ type MachineDeploymentBlueprint struct {
 	// HealthCheck holds the MachineHealthCheckClass for this MachineDeployment.
 	// +optional
 	HealthCheck clusterv1.MachineDeploymentClassHealthCheck
+
+	// MachineDrainRules holds the MachineDrainRuleClasses for this MachineDeployment.
+	// +optional
+	MachineDrainRules []clusterv1.MachineDrainRuleClass
 }
 
 // MachinePoolBlueprint holds the templates required for computing the desired state of a managed MachinePool;
@@ -89,6 +96,36 @@ type MachinePoolBlueprint struct {
 
 	// InfrastructureMachinePoolTemplate holds the infrastructure machine pool template for a MachinePool referenced from ClusterClass.
 	InfrastructureMachinePoolTemplate *unstructured.Unstructured
+
+	// HealthCheck holds the MachineHealthCheckClass for this MachinePool.
+	// +optional
+	HealthCheck clusterv1.MachinePoolClassHealthCheck
+
+	// MachineDrainRules holds the MachineDrainRuleClasses for this MachinePool.
+	// +optional
+	MachineDrainRules []clusterv1.MachineDrainRuleClass
+}
+
+// AddonBlueprint holds the template and the manifests required for computing the desired state of an add-on.
+type AddonBlueprint struct {
+	// Template holds the template of the add-on object in the management cluster, if defined in the ClusterClass.
+	Template *unstructured.Unstructured
+
+	// WorkloadClusterManifests holds the manifests to be applied to the workload cluster, if defined in the ClusterClass.
+	WorkloadClusterManifests []*unstructured.Unstructured
+}
+
+// ClusterClassFromRevision returns the ClusterClass as captured by a ClusterClassRevision, so a blueprint
+// can be computed for a Cluster pinning the revision. The returned ClusterClass has the metadata and the status
+// of the given ClusterClass, while spec and variables are taken from the ClusterClassRevision.
+func ClusterClassFromRevision(clusterClass *clusterv1.ClusterClass, revision *clusterv1.ClusterClassRevision) *clusterv1.ClusterClass {
+	c := clusterClass.DeepCopy()
+	c.Spec = *revision.Spec.ClusterClassSpec.DeepCopy()
+	c.Status.Variables = nil
+	for _, v := range revision.Spec.Variables {
+		c.Status.Variables = append(c.Status.Variables, *v.DeepCopy())
+	}
+	return c
 }
 
 // HasControlPlaneInfrastructureMachine checks whether the clusterClass mandates the controlPlane has infrastructureMachines.
@@ -188,6 +225,47 @@ func (b *ClusterBlueprint) MachineDeploymentMachineHealthCheckClass(md *clusterv
 		}
 }
 
+// IsMachinePoolMachineHealthCheckEnabled returns true if a MachineHealthCheck should be created for the MachinePool.
+// Returns false otherwise.
+func (b *ClusterBlueprint) IsMachinePoolMachineHealthCheckEnabled(mp *clusterv1.MachinePoolTopology) bool {
+	// If no MachineHealthCheck is defined in the ClusterClass or in the Cluster Topology then return false.
+	if !b.MachinePools[mp.Class].HealthCheck.IsDefined() && !mp.HealthCheck.IsDefined() {
+		return false
+	}
+	// If `enable` is not set then consider it as true. A MachineHealthCheck will be created from either ClusterClass or Cluster Topology.
+	if mp.HealthCheck.Enabled == nil {
+		return true
+	}
+	// If `enable` is explicitly set, use the value.
+	return *mp.HealthCheck.Enabled
+}
+
+// MachinePoolMachineHealthCheckClass return the MachineHealthCheckClass that should be used to create the MachineHealthCheck object.
+func (b *ClusterBlueprint) MachinePoolMachineHealthCheckClass(mp *clusterv1.MachinePoolTopology) (clusterv1.MachineHealthCheckChecks, clusterv1.MachineHealthCheckRemediation) {
+	if mp.HealthCheck.IsDefined() {
+		return clusterv1.MachineHealthCheckChecks{
+				NodeStartupTimeoutSeconds: mp.HealthCheck.Checks.NodeStartupTimeoutSeconds,
+				UnhealthyNodeConditions:   mp.HealthCheck.Checks.UnhealthyNodeConditions,
+			}, clusterv1.MachineHealthCheckRemediation{
+				TriggerIf: clusterv1.MachineHealthCheckRemediationTriggerIf{
+					UnhealthyLessThanOrEqualTo: mp.HealthCheck.Remediation.TriggerIf.UnhealthyLessThanOrEqualTo,
+					UnhealthyInRange:           mp.HealthCheck.Remediation.TriggerIf.UnhealthyInRange,
+				},
+				TemplateRef: mp.HealthCheck.Remediation.TemplateRef,
+			}
+	}
+	return clusterv1.MachineHealthCheckChecks{
+			NodeStartupTimeoutSeconds: b.MachinePools[mp.Class].HealthCheck.Checks.NodeStartupTimeoutSeconds,
+			UnhealthyNodeConditions:   b.MachinePools[mp.Class].HealthCheck.Checks.UnhealthyNodeConditions,
+		}, clusterv1.MachineHealthCheckRemediation{
+			TriggerIf: clusterv1.MachineHealthCheckRemediationTriggerIf{
+				UnhealthyLessThanOrEqualTo: b.MachinePools[mp.Class].HealthCheck.Remediation.TriggerIf.UnhealthyLessThanOrEqualTo,
+				UnhealthyInRange:           b.MachinePools[mp.Class].HealthCheck.Remediation.TriggerIf.UnhealthyInRange,
+			},
+			TemplateRef: b.MachinePools[mp.Class].HealthCheck.Remediation.TemplateRef,
+		}
+}
+
 // HasMachineDeployments checks whether the topology has MachineDeployments.
 func (b *ClusterBlueprint) HasMachineDeployments() bool {
 	return len(b.Topology.Workers.MachineDeployments) > 0
@@ -197,3 +275,13 @@ func (b *ClusterBlueprint) HasMachineDeployments() bool {
 func (b *ClusterBlueprint) HasMachinePools() bool {
 	return len(b.Topology.Workers.MachinePools) > 0
 }
+
+// HasWorkloadClusterAddons checks if the ClusterBlueprint has add-ons with manifests for the workload cluster.
+func (b *ClusterBlueprint) HasWorkloadClusterAddons() bool {
+	for _, addon := range b.Addons {
+		if len(addon.WorkloadClusterManifests) > 0 {
+			return true
+		}
+	}
+	return false
+}
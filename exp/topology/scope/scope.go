@@ -39,6 +39,14 @@ type Scope struct {
 	// HookResponseTracker holds the hook responses that will be used to
 	// calculate a combined reconcile result.
 	HookResponseTracker *HookResponseTracker
+
+	// ClusterClassRolloutPending is true if the current generation of the ClusterClass
+	// has not been released to the Cluster yet.
+	ClusterClassRolloutPending bool
+
+	// ClusterClassRolloutAborted is true if the rollout of the current generation of the ClusterClass
+	// has been aborted, and the Cluster is reconciled with the revision of the ClusterClass before it.
+	ClusterClassRolloutAborted bool
 }
 
 // New returns a new Scope with only the cluster; while processing a request in the topology/ClusterReconciler controller
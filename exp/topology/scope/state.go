@@ -45,6 +45,13 @@ type ClusterState struct {
 
 	// MachinePools holds the MachinePools in the Cluster.
 	MachinePools MachinePoolsStateMap
+
+	// Addons holds the add-on objects in the management cluster, keyed by the name of the add-on in the ClusterClass.
+	Addons map[string]*unstructured.Unstructured
+
+	// WorkloadClusterAddons holds the manifests applied to the workload cluster, keyed by the name of the add-on in the ClusterClass.
+	// NOTE: This is used only for the desired state; manifests are computed from the ClusterClass and patched like templates.
+	WorkloadClusterAddons map[string][]*unstructured.Unstructured
 }
 
 // ControlPlaneState holds all the objects representing the state of a managed control plane.
@@ -93,6 +100,10 @@ type MachineDeploymentState struct {
 	// MachineHealthCheck holds a MachineHealthCheck linked to the MachineDeployment object.
 	// +optional
 	MachineHealthCheck *clusterv1.MachineHealthCheck
+
+	// MachineDrainRules holds the MachineDrainRules linked to the MachineDeployment object, keyed by name.
+	// +optional
+	MachineDrainRules map[string]*clusterv1.MachineDrainRule
 }
 
 // IsUpgrading determines if the MachineDeployment is upgrading.
@@ -131,6 +142,14 @@ type MachinePoolState struct {
 
 	// InfrastructureMachinePoolObject holds the infrastructure machine template referenced by the MachinePool object.
 	InfrastructureMachinePoolObject *unstructured.Unstructured
+
+	// MachineHealthCheck holds a MachineHealthCheck linked to the MachinePool object.
+	// +optional
+	MachineHealthCheck *clusterv1.MachineHealthCheck
+
+	// MachineDrainRules holds the MachineDrainRules linked to the MachinePool object, keyed by name.
+	// +optional
+	MachineDrainRules map[string]*clusterv1.MachineDrainRule
 }
 
 // IsUpgrading determines if the MachinePool is upgrading.
@@ -54,12 +54,74 @@ const (
 	// alpha: v1.2
 	RuntimeSDK featuregate.Feature = "RuntimeSDK"
 
+	// ClusterClassRollout is a feature gate for the progressive rollout of ClusterClass changes
+	// to the Clusters using the ClusterClass.
+	//
+	// alpha: v1.12
+	ClusterClassRollout featuregate.Feature = "ClusterClassRollout"
+
+	// ClusterClassRevisions is a feature gate for immutable revisions of ClusterClasses, which
+	// can be pinned by Clusters.
+	//
+	// alpha: v1.12
+	ClusterClassRevisions featuregate.Feature = "ClusterClassRevisions"
+
+	// ClusterClassAddons is a feature gate for add-ons, which are created by the topology controller
+	// for every Cluster using a ClusterClass.
+	//
+	// alpha: v1.12
+	ClusterClassAddons featuregate.Feature = "ClusterClassAddons"
+
 	// KubeadmBootstrapFormatIgnition is a feature gate for the Ignition bootstrap format
 	// functionality.
 	//
 	// alpha: v1.1
+	// GA: v1.12
+	//
+	// Deprecated: The Ignition bootstrap format is now GA and the corresponding feature flag will be removed in 1.14 release.
 	KubeadmBootstrapFormatIgnition featuregate.Feature = "KubeadmBootstrapFormatIgnition"
 
+	// KubeadmBootstrapDataTemplating is a feature gate for rendering KubeadmConfig files and commands
+	// as templates with data from the Machine, the InfrastructureMachine, the Cluster and IPAddresses.
+	//
+	// alpha: v1.12
+	KubeadmBootstrapDataTemplating featuregate.Feature = "KubeadmBootstrapDataTemplating"
+
+	// KubeadmBootstrapFormatShellScript is a feature gate for the shell-script bootstrap format.
+	//
+	// alpha: v1.12
+	KubeadmBootstrapFormatShellScript featuregate.Feature = "KubeadmBootstrapFormatShellScript"
+
+	// KubeadmControlPlaneEtcdMaintenance is a feature gate for the etcd maintenance operations run by
+	// the KubeadmControlPlane controller, e.g. defragmentation and remediation of the NOSPACE alarm.
+	//
+	// alpha: v1.12
+	KubeadmControlPlaneEtcdMaintenance featuregate.Feature = "KubeadmControlPlaneEtcdMaintenance"
+
+	// KubeadmControlPlaneEtcdLearnerMode is a feature gate for joining new control plane Machines
+	// as etcd learners, which are promoted to voting members by the KubeadmControlPlane controller.
+	//
+	// alpha: v1.12
+	KubeadmControlPlaneEtcdLearnerMode featuregate.Feature = "KubeadmControlPlaneEtcdLearnerMode"
+
+	// KubeadmControlPlaneEncryptionAtRest is a feature gate for encryption at rest of Secrets in workload clusters,
+	// including generation and rotation of the encryption keys by the KubeadmControlPlane controller.
+	//
+	// alpha: v1.12
+	KubeadmControlPlaneEncryptionAtRest featuregate.Feature = "KubeadmControlPlaneEncryptionAtRest"
+
+	// ClusterReverseTunnel is a feature gate for accessing workload clusters through reverse tunnels opened
+	// by agents running in the workload clusters.
+	//
+	// alpha: v1.12
+	ClusterReverseTunnel featuregate.Feature = "ClusterReverseTunnel"
+
+	// ControllerSharding is a feature gate for sharding the core controllers across multiple replicas,
+	// with each replica reconciling only the Clusters of the shards it owns.
+	//
+	// alpha: v1.12
+	ControllerSharding featuregate.Feature = "ControllerSharding"
+
 	// MachineSetPreflightChecks is a feature gate for the MachineSet preflight checks functionality.
 	//
 	// alpha: v1.5
@@ -91,8 +153,18 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	MachinePool:               {Default: true, PreRelease: featuregate.Beta},
 	MachineSetPreflightChecks: {Default: true, PreRelease: featuregate.Beta},
 	MachineWaitForVolumeDetachConsiderVolumeAttachments: {Default: true, PreRelease: featuregate.Beta},
-	PriorityQueue:                  {Default: false, PreRelease: featuregate.Alpha},
-	ClusterTopology:                {Default: false, PreRelease: featuregate.Alpha},
-	KubeadmBootstrapFormatIgnition: {Default: false, PreRelease: featuregate.Alpha},
-	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
+	PriorityQueue:                       {Default: false, PreRelease: featuregate.Alpha},
+	ClusterTopology:                     {Default: false, PreRelease: featuregate.Alpha},
+	ClusterClassRollout:                 {Default: false, PreRelease: featuregate.Alpha},
+	ClusterClassRevisions:               {Default: false, PreRelease: featuregate.Alpha},
+	ClusterClassAddons:                  {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmBootstrapFormatIgnition:      {Default: true, PreRelease: featuregate.GA, LockToDefault: true},
+	KubeadmBootstrapDataTemplating:      {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmBootstrapFormatShellScript:   {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmControlPlaneEtcdMaintenance:  {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmControlPlaneEtcdLearnerMode:  {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmControlPlaneEncryptionAtRest: {Default: false, PreRelease: featuregate.Alpha},
+	ClusterReverseTunnel:                {Default: false, PreRelease: featuregate.Alpha},
+	ControllerSharding:                  {Default: false, PreRelease: featuregate.Alpha},
+	RuntimeSDK:                          {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -1068,6 +1068,8 @@ func autoConvert_v1beta2_KubeadmConfigSpec_To_v1alpha3_KubeadmConfigSpec(in *v1b
 	out.Format = Format(in.Format)
 	out.Verbosity = (*int32)(unsafe.Pointer(in.Verbosity))
 	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapDataTemplating requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfiguration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
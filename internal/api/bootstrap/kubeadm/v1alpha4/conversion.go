@@ -94,6 +94,8 @@ func RestoreKubeadmConfigSpec(dst *bootstrapv1.KubeadmConfigSpec, restored *boot
 
 	dst.BootCommands = restored.BootCommands
 	dst.Ignition = restored.Ignition
+	dst.BootstrapDataTemplating = restored.BootstrapDataTemplating
+	dst.KubeletConfiguration = restored.KubeletConfiguration
 
 	dst.ClusterConfiguration.APIServer.ExtraEnvs = restored.ClusterConfiguration.APIServer.ExtraEnvs
 	dst.ClusterConfiguration.ControllerManager.ExtraEnvs = restored.ClusterConfiguration.ControllerManager.ExtraEnvs
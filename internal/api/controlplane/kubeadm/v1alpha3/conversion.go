@@ -104,6 +104,10 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.LastRemediation = restored.Status.LastRemediation
 
 		dst.Spec.MachineNaming = restored.Spec.MachineNaming
+		dst.Spec.EtcdMaintenance = restored.Spec.EtcdMaintenance
+		dst.Status.Etcd = restored.Status.Etcd
+		dst.Spec.Encryption = restored.Spec.Encryption
+		dst.Status.Encryption = restored.Status.Encryption
 
 		bootstrapv1alpha3.RestoreKubeadmConfigSpec(&dst.Spec.KubeadmConfigSpec, &restored.Spec.KubeadmConfigSpec)
 
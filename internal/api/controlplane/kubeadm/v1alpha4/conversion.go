@@ -103,6 +103,10 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.LastRemediation = restored.Status.LastRemediation
 
 		dst.Spec.MachineNaming = restored.Spec.MachineNaming
+		dst.Spec.EtcdMaintenance = restored.Spec.EtcdMaintenance
+		dst.Status.Etcd = restored.Status.Etcd
+		dst.Spec.Encryption = restored.Spec.Encryption
+		dst.Status.Encryption = restored.Status.Encryption
 
 		bootstrapv1alpha4.RestoreKubeadmConfigSpec(&dst.Spec.KubeadmConfigSpec, &restored.Spec.KubeadmConfigSpec)
 		dst.Status.Conditions = restored.Status.Conditions
@@ -193,6 +197,8 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.Remediation = restored.Spec.Template.Spec.Remediation
 
 		dst.Spec.Template.Spec.MachineNaming = restored.Spec.Template.Spec.MachineNaming
+		dst.Spec.Template.Spec.EtcdMaintenance = restored.Spec.Template.Spec.EtcdMaintenance
+		dst.Spec.Template.Spec.Encryption = restored.Spec.Template.Spec.Encryption
 
 		bootstrapv1alpha4.RestoreKubeadmConfigSpec(&dst.Spec.Template.Spec.KubeadmConfigSpec, &restored.Spec.Template.Spec.KubeadmConfigSpec)
 	}
@@ -134,6 +134,7 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.Conditions = restored.Status.Conditions
 		dst.Status.ControlPlane = restored.Status.ControlPlane
 		dst.Status.Workers = restored.Status.Workers
+		dst.Status.Topology = restored.Status.Topology
 	}
 
 	return nil
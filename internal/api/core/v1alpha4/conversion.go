@@ -124,6 +124,7 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 	if ok {
 		dst.Spec.AvailabilityGates = restored.Spec.AvailabilityGates
 		dst.Spec.Topology.ClassRef.Namespace = restored.Spec.Topology.ClassRef.Namespace
+		dst.Spec.Topology.ClassRef.Revision = restored.Spec.Topology.ClassRef.Revision
 		dst.Spec.Topology.Variables = restored.Spec.Topology.Variables
 		dst.Spec.Topology.ControlPlane.Variables = restored.Spec.Topology.ControlPlane.Variables
 
@@ -160,6 +161,7 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.Conditions = restored.Status.Conditions
 		dst.Status.ControlPlane = restored.Status.ControlPlane
 		dst.Status.Workers = restored.Status.Workers
+		dst.Status.Topology = restored.Status.Topology
 	}
 
 	return nil
@@ -248,6 +250,8 @@ func (src *ClusterClass) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.ControlPlane.Deletion.NodeVolumeDetachTimeoutSeconds = restored.Spec.ControlPlane.Deletion.NodeVolumeDetachTimeoutSeconds
 	dst.Spec.ControlPlane.Deletion.NodeDeletionTimeoutSeconds = restored.Spec.ControlPlane.Deletion.NodeDeletionTimeoutSeconds
 	dst.Spec.Workers.MachinePools = restored.Spec.Workers.MachinePools
+	dst.Spec.Rollout = restored.Spec.Rollout
+	dst.Spec.Addons = restored.Spec.Addons
 
 	for i := range restored.Spec.Workers.MachineDeployments {
 		dst.Spec.Workers.MachineDeployments[i].HealthCheck = restored.Spec.Workers.MachineDeployments[i].HealthCheck
@@ -260,6 +264,7 @@ func (src *ClusterClass) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Workers.MachineDeployments[i].Deletion.NodeDeletionTimeoutSeconds = restored.Spec.Workers.MachineDeployments[i].Deletion.NodeDeletionTimeoutSeconds
 		dst.Spec.Workers.MachineDeployments[i].MinReadySeconds = restored.Spec.Workers.MachineDeployments[i].MinReadySeconds
 		dst.Spec.Workers.MachineDeployments[i].Rollout.Strategy = restored.Spec.Workers.MachineDeployments[i].Rollout.Strategy
+		dst.Spec.Workers.MachineDeployments[i].MachineDrainRules = restored.Spec.Workers.MachineDeployments[i].MachineDrainRules
 	}
 	dst.Status = restored.Status
 
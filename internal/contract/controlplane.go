@@ -146,6 +146,13 @@ func (c *ControlPlaneContract) UpToDateReplicas(contractVersion string) *Int32 {
 	}
 }
 
+// ObservedGeneration provide access to the status.observedGeneration field in a ControlPlane object, if any.
+func (c *ControlPlaneContract) ObservedGeneration() *Int64 {
+	return &Int64{
+		path: []string{"status", "observedGeneration"},
+	}
+}
+
 // AvailableConditionType returns the type of the available condition.
 func (c *ControlPlaneContract) AvailableConditionType() string {
 	return "Available"
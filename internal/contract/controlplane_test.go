@@ -125,6 +125,19 @@ func TestControlPlane(t *testing.T) {
 		g.Expect(got).ToNot(BeNil())
 		g.Expect(*got).To(Equal(int32(3)))
 	})
+	t.Run("Manages status.observedGeneration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(ControlPlane().ObservedGeneration().Path()).To(Equal(Path{"status", "observedGeneration"}))
+
+		err := ControlPlane().ObservedGeneration().Set(obj, int64(3))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		got, err := ControlPlane().ObservedGeneration().Get(obj)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).ToNot(BeNil())
+		g.Expect(*got).To(Equal(int64(3)))
+	})
 	t.Run("Manages status.upToDateReplicas", func(t *testing.T) {
 		g := NewWithT(t)
 
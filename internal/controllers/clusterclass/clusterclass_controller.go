@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,7 @@ import (
 
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/external"
@@ -51,6 +53,8 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/topology/revision"
+	"sigs.k8s.io/cluster-api/internal/topology/rollout"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/cache"
@@ -61,8 +65,23 @@ import (
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses;clusterclasses/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments;machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclassrevisions,verbs=get;list;watch;create;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
+// rolloutRequeueAfter is the interval used to check the progress of the rollout of a ClusterClass.
+const rolloutRequeueAfter = 30 * time.Second
+
+const (
+	// revisionHistoryLimit is the number of ClusterClassRevisions which are not current and not pinned by any Cluster
+	// kept for each ClusterClass.
+	revisionHistoryLimit = 10
+
+	// revisionDeletionGracePeriod is the time a ClusterClassRevision must be unused before it can be deleted.
+	revisionDeletionGracePeriod = 10 * time.Minute
+)
+
 // Reconciler reconciles the ClusterClass object.
 type Reconciler struct {
 	Client client.Client
@@ -87,7 +106,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "clusterclass")
-	err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.ClusterClass{}).
 		WithOptions(options).
 		Watches(
@@ -95,8 +114,17 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			handler.EnqueueRequestsFromMapFunc(r.extensionConfigToClusterClass),
 			builder.WithPredicates(predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog)),
 		).
-		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
-		Complete(r)
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue))
+
+	if feature.Gates.Enabled(feature.ClusterClassRollout) || feature.Gates.Enabled(feature.ClusterClassRevisions) {
+		b = b.Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterClass),
+			builder.WithPredicates(predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog)),
+		)
+	}
+
+	err := b.Complete(r)
 
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
@@ -145,6 +173,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 				clusterv1.PausedCondition,
 				clusterv1.ClusterClassRefVersionsUpToDateCondition,
 				clusterv1.ClusterClassVariablesReadyCondition,
+				clusterv1.ClusterClassRollingOutCondition,
 			}},
 		}
 
@@ -165,6 +194,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 	reconcileNormal := []clusterClassReconcileFunc{
 		r.reconcileExternalReferences,
 		r.reconcileVariables,
+		r.reconcileRevision,
+		r.reconcileRollout,
 	}
 	return doReconcile(ctx, reconcileNormal, s)
 }
@@ -203,6 +234,11 @@ type scope struct {
 	outdatedExternalReferences       []outdatedRef
 
 	variableDiscoveryError error
+
+	// previousRevision is the revision of the ClusterClass before the current spec and variables, if they changed.
+	previousRevision string
+
+	reconcileRolloutError error
 }
 
 type outdatedRef struct {
@@ -271,6 +307,29 @@ func (r *Reconciler) reconcileExternalReferences(ctx context.Context, s *scope)
 			})
 		}
 	}
+
+	// Ensure all templates of add-ons are owned by the ClusterClass.
+	// NOTE: Templates of add-ons are not required to implement a Cluster API contract, so we are not
+	// checking if their references are outdated.
+	if feature.Gates.Enabled(feature.ClusterClassAddons) {
+		for _, addonClass := range clusterClass.Spec.Addons {
+			if !addonClass.TemplateRef.IsDefined() {
+				continue
+			}
+
+			ref := addonClass.TemplateRef.ToObjectReference(s.clusterClass.Namespace)
+			uniqueKey := uniqueObjectRefKey(ref)
+			if reconciledRefs.Has(uniqueKey) {
+				continue
+			}
+			reconciledRefs.Insert(uniqueKey)
+
+			if err := r.reconcileExternal(ctx, clusterClass, ref); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
 	if len(errs) > 0 {
 		err := kerrors.NewAggregate(errs)
 		s.reconcileExternalReferencesError = err
@@ -464,6 +523,291 @@ func dropFalsePtrBool(in *clusterv1.JSONSchemaProps) *clusterv1.JSONSchemaProps
 	return ret
 }
 
+// reconcileRevision creates a ClusterClassRevision for the current spec and variables of the ClusterClass
+// and garbage collects the ClusterClassRevisions which are not current anymore and not used by any Cluster,
+// i.e. not pinned by a Cluster and not the revision a Cluster last reconciled its topology with.
+// Unused revisions are kept up to revisionHistoryLimit, so Clusters can pin a recent revision after the ClusterClass
+// changed, and older unused revisions are deleted only after being unused for revisionDeletionGracePeriod, so
+// revisions are not deleted while a Cluster pinning them is being created or updated.
+func (r *Reconciler) reconcileRevision(ctx context.Context, s *scope) (ctrl.Result, error) {
+	clusterClass := s.clusterClass
+
+	// Note: Revisions are also used by the progressive rollout of the ClusterClass to reconcile Clusters
+	// the current generation of the ClusterClass has not been released to yet.
+	if !feature.Gates.Enabled(feature.ClusterClassRevisions) && !feature.Gates.Enabled(feature.ClusterClassRollout) {
+		clusterClass.Status.Revision = ""
+		return ctrl.Result{}, nil
+	}
+
+	// Do not create a revision if variables could not be discovered, given that the revision
+	// would not capture variables from external patches.
+	if s.variableDiscoveryError != nil {
+		return ctrl.Result{}, nil
+	}
+
+	currentRevision, err := revision.Compute(clusterClass)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterClassRevision := revision.New(clusterClass, currentRevision)
+	if err := r.Client.Create(ctx, clusterClassRevision); err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create ClusterClassRevision %s", klog.KObj(clusterClassRevision))
+	}
+	if clusterClass.Status.Revision != currentRevision {
+		s.previousRevision = clusterClass.Status.Revision
+	}
+	clusterClass.Status.Revision = currentRevision
+
+	clusterClassRevisionList := &clusterv1.ClusterClassRevisionList{}
+	if err := r.Client.List(ctx, clusterClassRevisionList, client.InNamespace(clusterClass.Namespace)); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list ClusterClassRevisions of ClusterClass %s", clusterClass.Name)
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList,
+		client.InNamespace(clusterClass.Namespace),
+		client.MatchingFields{index.ClusterClassRefPath: index.ClusterClassRef(clusterClass)},
+	); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list Clusters using ClusterClass %s", clusterClass.Name)
+	}
+	usedRevisions := sets.New(currentRevision)
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.Topology.IsDefined() && cluster.Spec.Topology.ClassRef.Revision != "" {
+			usedRevisions.Insert(cluster.Spec.Topology.ClassRef.Revision)
+		}
+		if cluster.Status.Topology != nil && cluster.Status.Topology.ClassRevision != "" {
+			usedRevisions.Insert(cluster.Status.Topology.ClassRevision)
+		}
+	}
+
+	// Mark unused revisions with the time they have been found unused for the first time, and unmark
+	// revisions which are used again.
+	now := time.Now()
+	errs := []error{}
+	unusedRevisions := []*clusterv1.ClusterClassRevision{}
+	for i := range clusterClassRevisionList.Items {
+		clusterClassRevision := &clusterClassRevisionList.Items[i]
+		if clusterClassRevision.Spec.ClusterClassName != clusterClass.Name {
+			continue
+		}
+
+		_, marked := clusterClassRevision.Annotations[revision.UnusedSinceAnnotation]
+		used := usedRevisions.Has(clusterClassRevision.Spec.Revision)
+		if !used {
+			unusedRevisions = append(unusedRevisions, clusterClassRevision)
+		}
+		if used != marked {
+			continue
+		}
+
+		original := clusterClassRevision.DeepCopy()
+		if used {
+			delete(clusterClassRevision.Annotations, revision.UnusedSinceAnnotation)
+		} else {
+			if clusterClassRevision.Annotations == nil {
+				clusterClassRevision.Annotations = map[string]string{}
+			}
+			clusterClassRevision.Annotations[revision.UnusedSinceAnnotation] = now.UTC().Format(time.RFC3339)
+		}
+		if err := r.Client.Patch(ctx, clusterClassRevision, client.MergeFrom(original)); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to patch ClusterClassRevision %s", klog.KObj(clusterClassRevision)))
+		}
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+
+	// Keep the most recent unused revisions up to revisionHistoryLimit, and delete older revisions
+	// once they have been unused for revisionDeletionGracePeriod.
+	sort.SliceStable(unusedRevisions, func(i, j int) bool {
+		return unusedRevisions[j].CreationTimestamp.Before(&unusedRevisions[i].CreationTimestamp)
+	})
+	var requeueAfter time.Duration
+	for i := revisionHistoryLimit; i < len(unusedRevisions); i++ {
+		clusterClassRevision := unusedRevisions[i]
+
+		unusedSince, err := time.Parse(time.RFC3339, clusterClassRevision.Annotations[revision.UnusedSinceAnnotation])
+		if err != nil {
+			// Start the grace period again if the annotation is not valid.
+			unusedSince = now
+		}
+		if remaining := unusedSince.Add(revisionDeletionGracePeriod).Sub(now); remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, clusterClassRevision); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete ClusterClassRevision %s", klog.KObj(clusterClassRevision)))
+		}
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileRollout releases the current generation of the ClusterClass to the waves defined in spec.rollout.
+// A wave is released once all the Clusters of the previous waves adopted the current generation and are healthy.
+func (r *Reconciler) reconcileRollout(ctx context.Context, s *scope) (ctrl.Result, error) {
+	clusterClass := s.clusterClass
+
+	if !rollout.IsEnabled(clusterClass) {
+		clusterClass.Status.Rollout = nil
+		return ctrl.Result{}, nil
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList,
+		client.InNamespace(clusterClass.Namespace),
+		client.MatchingFields{index.ClusterClassRefPath: index.ClusterClassRef(clusterClass)},
+	); err != nil {
+		s.reconcileRolloutError = err
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list Clusters using ClusterClass %s", clusterClass.Name)
+	}
+	clusters := make([]*clusterv1.Cluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		// Clusters pinning a revision of the ClusterClass are not part of the rollout.
+		if revision.IsPinned(&clusterList.Items[i]) {
+			continue
+		}
+		clusters = append(clusters, &clusterList.Items[i])
+	}
+
+	// Start the rollout of a new generation of the ClusterClass.
+	// Note: The assignment of the Clusters to waves is computed once when the rollout starts and persisted in
+	// the status, so changes to the Clusters during the rollout, e.g. to their labels or to the number of Clusters,
+	// do not move Clusters across waves.
+	// Note: The revision of the ClusterClass before the new generation is persisted in the status as well, so Clusters
+	// can be reverted to it if the rollout is aborted.
+	status := clusterClass.Status.Rollout
+	if status == nil || status.Generation != clusterClass.Generation || status.Waves == nil {
+		waves, err := rollout.ComputeWaves(clusterClass.Spec.Rollout, clusters)
+		if err != nil {
+			s.reconcileRolloutError = err
+			return ctrl.Result{}, errors.Wrapf(err, "failed to compute rollout waves for ClusterClass %s", clusterClass.Name)
+		}
+		releasedWaves := ptr.To[int32](0)
+		previousRevision := s.previousRevision
+		if status != nil && status.Revision != "" {
+			previousRevision = status.Revision
+		}
+		if status != nil && status.Generation == clusterClass.Generation {
+			releasedWaves = status.ReleasedWaves
+			previousRevision = status.PreviousRevision
+		}
+		status = &clusterv1.ClusterClassRolloutStatus{
+			Generation:       clusterClass.Generation,
+			PreviousRevision: previousRevision,
+			ReleasedWaves:    releasedWaves,
+			Waves:            rollout.WavesStatus(clusterClass.Spec.Rollout, waves),
+		}
+		clusterClass.Status.Rollout = status
+	}
+	status.Revision = clusterClass.Status.Revision
+	waves := rollout.AssignedWaves(clusterClass, clusters)
+
+	// Release waves as long as the Clusters of the released waves are healthy.
+	releasedWaves := int(ptr.Deref(status.ReleasedWaves, 0))
+	if !rollout.IsPaused(clusterClass) && !rollout.IsAborted(clusterClass) {
+		for releasedWaves < len(waves) {
+			if releasedWaves > 0 {
+				healthy, err := r.wavesHealthy(ctx, clusterClass, waves[:releasedWaves])
+				if err != nil {
+					s.reconcileRolloutError = err
+					return ctrl.Result{}, errors.Wrapf(err, "failed to check health of the Clusters of released waves for ClusterClass %s", clusterClass.Name)
+				}
+				if !healthy {
+					break
+				}
+			}
+			releasedWaves++
+		}
+	}
+
+	upToDateClusters := 0
+	for _, cluster := range clusters {
+		if rollout.IsUpToDate(clusterClass, cluster) {
+			upToDateClusters++
+		}
+	}
+
+	status.ReleasedWaves = ptr.To(int32(releasedWaves))
+	status.Clusters = ptr.To(int32(len(clusters)))
+	status.UpToDateClusters = ptr.To(int32(upToDateClusters))
+	clusterClass.Status.Rollout = status
+
+	// Requeue while the rollout is in progress; Clusters also trigger a reconcile when they change,
+	// but changes to the health of a Cluster might not be immediately reflected in its conditions.
+	if upToDateClusters < len(clusters) {
+		return ctrl.Result{RequeueAfter: rolloutRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// wavesHealthy returns true if all the Clusters of the given waves adopted the current generation
+// of the ClusterClass and are healthy.
+func (r *Reconciler) wavesHealthy(ctx context.Context, clusterClass *clusterv1.ClusterClass, waves rollout.Waves) (bool, error) {
+	for _, wave := range waves {
+		for _, cluster := range wave {
+			objects, err := r.getRolloutObjects(ctx, cluster)
+			if err != nil {
+				return false, err
+			}
+			healthy, err := rollout.IsHealthy(clusterClass, cluster, objects)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to check health of Cluster %s", klog.KObj(cluster))
+			}
+			if !healthy {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// getRolloutObjects returns the control plane, the MachineDeployments and the MachinePools of a Cluster,
+// which are considered to determine if the Cluster is healthy.
+func (r *Reconciler) getRolloutObjects(ctx context.Context, cluster *clusterv1.Cluster) (rollout.Objects, error) {
+	objects := rollout.Objects{}
+
+	if cluster.Spec.ControlPlaneRef.IsDefined() {
+		controlPlane, err := external.GetObjectFromContractVersionedRef(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+		if err != nil {
+			return objects, errors.Wrapf(err, "failed to get control plane of Cluster %s", klog.KObj(cluster))
+		}
+		contractVersion, err := contract.GetContractVersion(ctx, r.Client, controlPlane.GroupVersionKind().GroupKind())
+		if err != nil {
+			return objects, err
+		}
+		objects.ControlPlane = controlPlane
+		objects.ControlPlaneContractVersion = contractVersion
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return objects, errors.Wrapf(err, "failed to list MachineDeployments of Cluster %s", klog.KObj(cluster))
+	}
+	objects.MachineDeployments = machineDeployments.Items
+
+	if feature.Gates.Enabled(feature.MachinePool) {
+		machinePools := &clusterv1.MachinePoolList{}
+		if err := r.Client.List(ctx, machinePools,
+			client.InNamespace(cluster.Namespace),
+			client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+		); err != nil {
+			return objects, errors.Wrapf(err, "failed to list MachinePools of Cluster %s", klog.KObj(cluster))
+		}
+		objects.MachinePools = machinePools.Items
+	}
+	return objects, nil
+}
+
 func (r *Reconciler) reconcileExternal(ctx context.Context, clusterClass *clusterv1.ClusterClass, ref *corev1.ObjectReference) error {
 	obj, err := external.Get(ctx, r.Client, ref)
 	if err != nil {
@@ -540,6 +884,18 @@ func (r *Reconciler) extensionConfigToClusterClass(ctx context.Context, o client
 	return res
 }
 
+// clusterToClusterClass maps a Cluster to the ClusterClass it is using.
+func (r *Reconciler) clusterToClusterClass(_ context.Context, o client.Object) []reconcile.Request {
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Cluster but got a %T", o))
+	}
+	if !cluster.Spec.Topology.IsDefined() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: cluster.GetClassKey()}}
+}
+
 // matchNamespace returns true if the passed namespace matches the selector.
 func matchNamespace(ctx context.Context, c client.Client, selector labels.Selector, namespace string) bool {
 	// Return early if the selector is empty.
@@ -22,8 +22,10 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/topology/rollout"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
 )
@@ -31,6 +33,7 @@ import (
 func updateStatus(ctx context.Context, s *scope) {
 	setRefVersionsUpToDateCondition(ctx, s.clusterClass, s.outdatedExternalReferences, s.reconcileExternalReferencesError)
 	setVariablesReconciledCondition(ctx, s.clusterClass, s.variableDiscoveryError)
+	setRollingOutCondition(ctx, s.clusterClass, s.reconcileRolloutError)
 }
 
 func setRefVersionsUpToDateCondition(_ context.Context, clusterClass *clusterv1.ClusterClass, outdatedRefs []outdatedRef, reconcileExternalReferencesError error) {
@@ -108,3 +111,52 @@ func setVariablesReconciledCondition(_ context.Context, clusterClass *clusterv1.
 		Reason: clusterv1.ClusterClassVariablesReadyReason,
 	})
 }
+
+func setRollingOutCondition(_ context.Context, clusterClass *clusterv1.ClusterClass, reconcileRolloutError error) {
+	if !rollout.IsEnabled(clusterClass) {
+		conditions.Delete(clusterClass, clusterv1.ClusterClassRollingOutCondition)
+		return
+	}
+
+	if reconcileRolloutError != nil {
+		conditions.Set(clusterClass, metav1.Condition{
+			Type:    clusterv1.ClusterClassRollingOutCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.ClusterClassRollingOutInternalErrorReason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	status := clusterClass.Status.Rollout
+	if status == nil || status.Generation != clusterClass.Generation {
+		// Note: This should never happen, the rollout status is always set when the rollout is reconciled without errors.
+		return
+	}
+
+	clusters := ptr.Deref(status.Clusters, 0)
+	upToDateClusters := ptr.Deref(status.UpToDateClusters, 0)
+	if upToDateClusters >= clusters {
+		conditions.Set(clusterClass, metav1.Condition{
+			Type:   clusterv1.ClusterClassRollingOutCondition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.ClusterClassNotRollingOutReason,
+		})
+		return
+	}
+
+	reason := clusterv1.ClusterClassRollingOutReason
+	switch {
+	case rollout.IsAborted(clusterClass):
+		reason = clusterv1.ClusterClassRolloutAbortedReason
+	case rollout.IsPaused(clusterClass):
+		reason = clusterv1.ClusterClassRolloutPausedReason
+	}
+	conditions.Set(clusterClass, metav1.Condition{
+		Type:   clusterv1.ClusterClassRollingOutCondition,
+		Status: metav1.ConditionTrue,
+		Reason: reason,
+		Message: fmt.Sprintf("Rolling out generation %d: %d of %d waves released, %d of %d Clusters up-to-date",
+			status.Generation, ptr.Deref(status.ReleasedWaves, 0), len(clusterClass.Spec.Rollout.Waves)+1, upToDateClusters, clusters),
+	})
+}
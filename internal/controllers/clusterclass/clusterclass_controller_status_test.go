@@ -23,8 +23,11 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
@@ -154,3 +157,102 @@ func TestSetVariablesReconciledCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRollingOutCondition(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassRollout, true)
+
+	testCases := []struct {
+		name                  string
+		annotations           map[string]string
+		rolloutStatus         *clusterv1.ClusterClassRolloutStatus
+		reconcileRolloutError error
+		expectCondition       metav1.Condition
+	}{
+		{
+			name:                  "error occurred",
+			reconcileRolloutError: errors.New("failed to list Clusters"),
+			expectCondition: metav1.Condition{
+				Type:               clusterv1.ClusterClassRollingOutCondition,
+				ObservedGeneration: 2,
+				Status:             metav1.ConditionUnknown,
+				Reason:             clusterv1.ClusterClassRollingOutInternalErrorReason,
+				Message:            "Please check controller logs for errors",
+			},
+		},
+		{
+			name: "rollout in progress",
+			rolloutStatus: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](5),
+				UpToDateClusters: ptr.To[int32](1),
+			},
+			expectCondition: metav1.Condition{
+				Type:               clusterv1.ClusterClassRollingOutCondition,
+				ObservedGeneration: 2,
+				Status:             metav1.ConditionTrue,
+				Reason:             clusterv1.ClusterClassRollingOutReason,
+				Message:            "Rolling out generation 2: 1 of 2 waves released, 1 of 5 Clusters up-to-date",
+			},
+		},
+		{
+			name:        "rollout paused",
+			annotations: map[string]string{clusterv1.ClusterClassRolloutPausedAnnotation: ""},
+			rolloutStatus: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](0),
+				Clusters:         ptr.To[int32](5),
+				UpToDateClusters: ptr.To[int32](0),
+			},
+			expectCondition: metav1.Condition{
+				Type:               clusterv1.ClusterClassRollingOutCondition,
+				ObservedGeneration: 2,
+				Status:             metav1.ConditionTrue,
+				Reason:             clusterv1.ClusterClassRolloutPausedReason,
+				Message:            "Rolling out generation 2: 0 of 2 waves released, 0 of 5 Clusters up-to-date",
+			},
+		},
+		{
+			name: "rollout completed",
+			rolloutStatus: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](2),
+				Clusters:         ptr.To[int32](5),
+				UpToDateClusters: ptr.To[int32](5),
+			},
+			expectCondition: metav1.Condition{
+				Type:               clusterv1.ClusterClassRollingOutCondition,
+				ObservedGeneration: 2,
+				Status:             metav1.ConditionFalse,
+				Reason:             clusterv1.ClusterClassNotRollingOutReason,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cc := &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation:  2,
+					Annotations: tc.annotations,
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Rollout: clusterv1.ClusterClassRollout{
+						Waves: []clusterv1.ClusterClassRolloutWave{{Name: "canary"}},
+					},
+				},
+				Status: clusterv1.ClusterClassStatus{
+					Rollout: tc.rolloutStatus,
+				},
+			}
+
+			setRollingOutCondition(ctx, cc, tc.reconcileRolloutError)
+
+			condition := conditions.Get(cc, clusterv1.ClusterClassRollingOutCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(tc.expectCondition, conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
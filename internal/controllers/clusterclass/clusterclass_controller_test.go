@@ -39,12 +39,14 @@ import (
 
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/internal/topology/revision"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
 	"sigs.k8s.io/cluster-api/util/cache"
 	"sigs.k8s.io/cluster-api/util/test/builder"
@@ -1214,6 +1216,419 @@ func TestReconciler_reconcileVariables(t *testing.T) {
 	}
 }
 
+func TestReconciler_reconcileRollout(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassRollout, true)
+
+	newClusterClass := func(generation int64, annotations map[string]string, status *clusterv1.ClusterClassRolloutStatus) *clusterv1.ClusterClass {
+		clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()
+		clusterClass.Generation = generation
+		clusterClass.Annotations = annotations
+		clusterClass.Spec.Rollout = clusterv1.ClusterClassRollout{
+			Waves: []clusterv1.ClusterClassRolloutWave{
+				{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}},
+			},
+		}
+		clusterClass.Status.Rollout = status
+		return clusterClass
+	}
+	newCluster := func(name string, canary bool, classGeneration int64, healthy bool) *clusterv1.Cluster {
+		cluster := builder.Cluster(metav1.NamespaceDefault, name).
+			WithTopology(builder.ClusterTopology().WithClass("class1").Build()).
+			Build()
+		if canary {
+			cluster.Labels = map[string]string{"canary": "true"}
+		}
+		cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{ClassGeneration: classGeneration}
+		status := metav1.ConditionFalse
+		if healthy {
+			status = metav1.ConditionTrue
+		}
+		cluster.Status.Conditions = []metav1.Condition{
+			{Type: clusterv1.ClusterTopologyReconciledCondition, Status: status},
+			{Type: clusterv1.ClusterAvailableCondition, Status: status},
+			{Type: clusterv1.ClusterRollingOutCondition, Status: metav1.ConditionFalse},
+		}
+		return cluster
+	}
+	canaryWaves := []clusterv1.ClusterClassRolloutWaveStatus{{Name: "canary", Clusters: []string{"canary"}}}
+
+	tests := []struct {
+		name         string
+		clusterClass *clusterv1.ClusterClass
+		clusters     []client.Object
+		objs         []client.Object
+		want         *clusterv1.ClusterClassRolloutStatus
+		wantRequeue  bool
+	}{
+		{
+			name:         "Release the first wave of a new generation",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 1, ReleasedWaves: ptr.To[int32](2)}),
+			clusters: []client.Object{
+				newCluster("canary", true, 1, true),
+				newCluster("other", false, 1, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](0),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:         "Do not release the next wave while the Clusters of the previous waves are not healthy",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](1), Waves: canaryWaves}),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, false),
+				newCluster("other", false, 1, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](1),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:         "Release the next wave when the Clusters of the previous waves are healthy",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](1), Waves: canaryWaves}),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, true),
+				newCluster("other", false, 1, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](2),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](1),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:         "Do not release the next wave while a MachineDeployment of the Clusters of the previous waves is rolling out",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](1), Waves: canaryWaves}),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, true),
+				newCluster("other", false, 1, true),
+			},
+			objs: []client.Object{
+				&clusterv1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:  metav1.NamespaceDefault,
+						Name:       "canary-md",
+						Labels:     map[string]string{clusterv1.ClusterNameLabel: "canary"},
+						Generation: 2,
+					},
+					Spec:   clusterv1.MachineDeploymentSpec{Replicas: ptr.To[int32](3)},
+					Status: clusterv1.MachineDeploymentStatus{ObservedGeneration: 2, UpToDateReplicas: ptr.To[int32](1)},
+				},
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](1),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name: "Keep the assignment of the Clusters to waves computed when the rollout started",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](1),
+				Waves: []clusterv1.ClusterClassRolloutWaveStatus{{Name: "canary", Clusters: []string{"other"}}}}),
+			clusters: []client.Object{
+				newCluster("canary", true, 1, true),
+				newCluster("other", false, 2, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](2),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](1),
+				Waves:            []clusterv1.ClusterClassRolloutWaveStatus{{Name: "canary", Clusters: []string{"other"}}},
+			},
+			wantRequeue: true,
+		},
+		{
+			name: "Do not release waves if the rollout is paused",
+			clusterClass: newClusterClass(2, map[string]string{clusterv1.ClusterClassRolloutPausedAnnotation: ""},
+				&clusterv1.ClusterClassRolloutStatus{Generation: 1, ReleasedWaves: ptr.To[int32](2)}),
+			clusters: []client.Object{
+				newCluster("canary", true, 1, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](0),
+				Clusters:         ptr.To[int32](1),
+				UpToDateClusters: ptr.To[int32](0),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name: "Do not release waves if the rollout of the generation is aborted",
+			clusterClass: newClusterClass(2, map[string]string{clusterv1.ClusterClassRolloutAbortedAnnotation: "2"},
+				&clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](1), Waves: canaryWaves}),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, true),
+				newCluster("other", false, 1, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](1),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name: "Record the revision before the new generation when the rollout starts",
+			clusterClass: func() *clusterv1.ClusterClass {
+				clusterClass := newClusterClass(3, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, Revision: "rev2", ReleasedWaves: ptr.To[int32](2)})
+				clusterClass.Status.Revision = "rev3"
+				return clusterClass
+			}(),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       3,
+				Revision:         "rev3",
+				PreviousRevision: "rev2",
+				ReleasedWaves:    ptr.To[int32](1),
+				Clusters:         ptr.To[int32](1),
+				UpToDateClusters: ptr.To[int32](0),
+				Waves:            canaryWaves,
+			},
+			wantRequeue: true,
+		},
+		{
+			name:         "Rollout completed",
+			clusterClass: newClusterClass(2, nil, &clusterv1.ClusterClassRolloutStatus{Generation: 2, ReleasedWaves: ptr.To[int32](2), Waves: canaryWaves}),
+			clusters: []client.Object{
+				newCluster("canary", true, 2, true),
+				newCluster("other", false, 2, true),
+			},
+			want: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       2,
+				ReleasedWaves:    ptr.To[int32](2),
+				Clusters:         ptr.To[int32](2),
+				UpToDateClusters: ptr.To[int32](2),
+				Waves:            canaryWaves,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(tt.clusters...).
+				WithObjects(tt.objs...).
+				WithIndex(&clusterv1.Cluster{}, index.ClusterClassRefPath, index.ClusterByClusterClassRef).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+
+			s := &scope{
+				clusterClass: tt.clusterClass,
+			}
+			res, err := r.reconcileRollout(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+			g.Expect(tt.clusterClass.Status.Rollout).To(BeComparableTo(tt.want))
+		})
+	}
+}
+
+func TestReconciler_reconcileRevision(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassRevisions, true)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()
+	clusterClass.UID = "uid1"
+	currentRevision, err := revision.Compute(clusterClass)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	now := time.Now()
+	newClusterClassRevision := func(clusterClassName, rev string, age time.Duration, unusedFor *time.Duration) *clusterv1.ClusterClassRevision {
+		ccr := &clusterv1.ClusterClassRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              revision.Name(clusterClassName, rev),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: clusterv1.ClusterClassRevisionSpec{
+				ClusterClassName: clusterClassName,
+				Revision:         rev,
+			},
+		}
+		if unusedFor != nil {
+			ccr.Annotations = map[string]string{
+				revision.UnusedSinceAnnotation: now.Add(-*unusedFor).UTC().Format(time.RFC3339),
+			}
+		}
+		return ccr
+	}
+	newCluster := func(name, rev string) *clusterv1.Cluster {
+		cluster := builder.Cluster(metav1.NamespaceDefault, name).
+			WithTopology(builder.ClusterTopology().WithClass("class1").Build()).
+			Build()
+		cluster.Spec.Topology.ClassRef.Revision = rev
+		return cluster
+	}
+	// historyRevisions returns unused revisions filling the history, all of them more recent than the revisions
+	// created by the test cases.
+	historyRevisions := func() []client.Object {
+		objs := []client.Object{}
+		for i := range revisionHistoryLimit {
+			objs = append(objs, newClusterClassRevision("class1", fmt.Sprintf("history%d", i), time.Duration(i)*time.Minute, ptr.To(time.Hour)))
+		}
+		return objs
+	}
+	historyRevisionNames := func() []string {
+		names := []string{}
+		for i := range revisionHistoryLimit {
+			names = append(names, revision.Name("class1", fmt.Sprintf("history%d", i)))
+		}
+		return names
+	}
+
+	tests := []struct {
+		name                   string
+		variableDiscoveryError error
+		objs                   []client.Object
+		wantStatusRevision     string
+		wantRevisions          []string
+		wantUnused             []string
+		wantRequeue            bool
+	}{
+		{
+			name:               "create the current revision",
+			wantStatusRevision: currentRevision,
+			wantRevisions:      []string{revision.Name("class1", currentRevision)},
+			wantUnused:         []string{},
+		},
+		{
+			name:                   "do not create a revision if variables could not be discovered",
+			variableDiscoveryError: errors.New("failed to discover variables"),
+			wantStatusRevision:     "",
+			wantRevisions:          []string{},
+			wantUnused:             []string{},
+		},
+		{
+			name: "keep unused revisions up to the history limit and mark them as unused",
+			objs: []client.Object{
+				newClusterClassRevision("class1", "old1", time.Hour, nil),
+				newClusterClassRevision("class1", "old2", time.Hour, nil),
+				newClusterClassRevision("class2", "old1", time.Hour, nil),
+				newCluster("cluster1", "old2"),
+				newCluster("cluster2", ""),
+			},
+			wantStatusRevision: currentRevision,
+			wantRevisions: []string{
+				revision.Name("class1", currentRevision),
+				revision.Name("class1", "old1"),
+				revision.Name("class1", "old2"),
+				revision.Name("class2", "old1"),
+			},
+			wantUnused: []string{
+				revision.Name("class1", "old1"),
+			},
+		},
+		{
+			name: "unmark revisions which are pinned again",
+			objs: []client.Object{
+				newClusterClassRevision("class1", "old1", time.Hour, ptr.To(time.Hour)),
+				newCluster("cluster1", "old1"),
+			},
+			wantStatusRevision: currentRevision,
+			wantRevisions: []string{
+				revision.Name("class1", currentRevision),
+				revision.Name("class1", "old1"),
+			},
+			wantUnused: []string{},
+		},
+		{
+			name: "do not mark revisions Clusters last reconciled their topology with as unused",
+			objs: []client.Object{
+				newClusterClassRevision("class1", "old1", time.Hour, ptr.To(time.Hour)),
+				func() client.Object {
+					cluster := newCluster("cluster1", "")
+					cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{ClassGeneration: 1, ClassRevision: "old1"}
+					return cluster
+				}(),
+			},
+			wantStatusRevision: currentRevision,
+			wantRevisions: []string{
+				revision.Name("class1", currentRevision),
+				revision.Name("class1", "old1"),
+			},
+			wantUnused: []string{},
+		},
+		{
+			name: "delete unused revisions beyond the history limit after the grace period",
+			objs: append(historyRevisions(),
+				newClusterClassRevision("class1", "old1", 24*time.Hour, ptr.To(time.Hour)),
+				newClusterClassRevision("class1", "old2", 24*time.Hour, ptr.To(time.Minute)),
+				newClusterClassRevision("class1", "old3", 24*time.Hour, nil),
+				newClusterClassRevision("class1", "old4", 24*time.Hour, ptr.To(time.Hour)),
+				newCluster("cluster1", "old4"),
+			),
+			wantStatusRevision: currentRevision,
+			wantRevisions: append(historyRevisionNames(),
+				revision.Name("class1", currentRevision),
+				revision.Name("class1", "old2"),
+				revision.Name("class1", "old3"),
+				revision.Name("class1", "old4"),
+			),
+			wantUnused: append(historyRevisionNames(),
+				revision.Name("class1", "old2"),
+				revision.Name("class1", "old3"),
+			),
+			wantRequeue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(tt.objs...).
+				WithIndex(&clusterv1.Cluster{}, index.ClusterClassRefPath, index.ClusterByClusterClassRef).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+
+			s := &scope{
+				clusterClass:           clusterClass.DeepCopy(),
+				variableDiscoveryError: tt.variableDiscoveryError,
+			}
+			res, err := r.reconcileRevision(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+			g.Expect(s.clusterClass.Status.Revision).To(Equal(tt.wantStatusRevision))
+
+			clusterClassRevisionList := &clusterv1.ClusterClassRevisionList{}
+			g.Expect(fakeClient.List(ctx, clusterClassRevisionList)).To(Succeed())
+			gotRevisions := []string{}
+			gotUnused := []string{}
+			for _, ccr := range clusterClassRevisionList.Items {
+				gotRevisions = append(gotRevisions, ccr.Name)
+				if _, ok := ccr.Annotations[revision.UnusedSinceAnnotation]; ok {
+					gotUnused = append(gotUnused, ccr.Name)
+				}
+			}
+			g.Expect(gotRevisions).To(ConsistOf(tt.wantRevisions))
+			g.Expect(gotUnused).To(ConsistOf(tt.wantUnused))
+		})
+	}
+}
+
 func TestReconciler_extensionConfigToClusterClass(t *testing.T) {
 	firstExtConfig := &runtimev1.ExtensionConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -52,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // ErrSecretTypeNotSupported signals that a Secret is not supported.
@@ -69,6 +70,12 @@ type Reconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Sharder is used to apply resources only to Clusters in shards owned by this replica.
+	// If nil, i.e. sharding is disabled, resources are applied to all the matching Clusters.
+	// NOTE: ClusterResourceSets are not sharded, because they can match Clusters in any shard; all the
+	// replicas reconcile all the ClusterResourceSets, each of them for the Clusters in its own shards.
+	Sharder *sharding.Sharder
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, partialSecretCache cache.Cache) error {
@@ -82,9 +89,15 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterResourceSet),
-			builder.WithPredicates(predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog)),
+			builder.WithPredicates(
+				predicates.All(mgr.GetScheme(), predicateLog,
+					predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog),
+					predicates.ResourceIsInOwnedShard(ctx, mgr.GetScheme(), predicateLog, r.Sharder),
+				),
+			),
 		).
 		WatchesRawSource(r.ClusterCache.GetClusterSource("clusterresourceset", r.clusterToClusterResourceSet)).
+		WatchesRawSource(r.Sharder.GetClusterSource(r.clusterToClusterResourceSet)).
 		WatchesMetadata(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(
@@ -184,12 +197,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	// Handle deletion reconciliation loop.
+	// NOTE: Deletion only updates ClusterResourceSetBindings in the management cluster, so it is done for all the
+	// matching Clusters also when sharding is enabled.
 	if !clusterResourceSet.DeletionTimestamp.IsZero() {
 		return ctrl.Result{}, r.reconcileDelete(ctx, clusters, clusterResourceSet)
 	}
 
 	errs := []error{}
 	for _, cluster := range clusters {
+		// Apply resources only to Clusters in shards owned by this replica; other Clusters are handled by other replicas.
+		if !r.Sharder.OwnsCluster(cluster) {
+			continue
+		}
 		if err := r.ApplyClusterResourceSet(ctx, cluster, clusterResourceSet); err != nil {
 			errs = append(errs, err)
 		}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensionconfig
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// circuitBreakerStatusReconciler updates the CircuitBreakerOpen condition of ExtensionConfigs every time the circuit
+// breaker for calls to their Extension opens or closes. Differently from the Reconciler, it never runs discovery,
+// so circuit breaker transitions don't result in additional calls to an Extension which is failing.
+type circuitBreakerStatusReconciler struct {
+	Client        client.Client
+	RuntimeClient runtimeclient.Client
+}
+
+func (r *circuitBreakerStatusReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, watchFilterValue string) error {
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "extensionconfig-circuitbreaker")
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("extensionconfig-circuitbreaker").
+		WatchesRawSource(r.RuntimeClient.GetCircuitBreakerSource()).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, watchFilterValue)).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+	return nil
+}
+
+func (r *circuitBreakerStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	extensionConfig := &runtimev1.ExtensionConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, extensionConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Deleted and paused ExtensionConfigs are not updated, consistently with the Reconciler.
+	if !extensionConfig.DeletionTimestamp.IsZero() || annotations.HasPaused(extensionConfig) {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(extensionConfig, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	setCircuitBreakerOpenCondition(extensionConfig, r.RuntimeClient.GetCircuitBreakerState(extensionConfig.Name))
+	return ctrl.Result{}, patchHelper.Patch(ctx, extensionConfig, patch.WithOwnedConditions{Conditions: []string{
+		runtimev1.ExtensionConfigCircuitBreakerOpenCondition,
+	}})
+}
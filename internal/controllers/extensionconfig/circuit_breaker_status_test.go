@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensionconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_circuitBreakerStatusReconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(runtimev1.AddToScheme(scheme)).To(Succeed())
+
+	extensionConfig := fakeExtensionConfigForURL("default", "ext1", "https://ext1.example.com")
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(extensionConfig).WithStatusSubresource(extensionConfig).Build()
+
+	// Note: The fake runtime client panics if Discover is called, so this also verifies that discovery is not run.
+	runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+		WithCircuitBreakerStates(map[string]runtimeclient.CircuitBreakerState{
+			extensionConfig.Name: {Open: true, ConsecutiveFailures: 5},
+		}).
+		MarkReady(true).
+		Build()
+
+	r := &circuitBreakerStatusReconciler{
+		Client:        fakeClient,
+		RuntimeClient: runtimeClient,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: extensionConfig.Namespace, Name: extensionConfig.Name}}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got := &runtimev1.ExtensionConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(extensionConfig), got)).To(Succeed())
+	condition := conditions.Get(got, runtimev1.ExtensionConfigCircuitBreakerOpenCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(runtimev1.ExtensionConfigCircuitBreakerOpenReason))
+	g.Expect(condition.Message).To(ContainSubstring("5 consecutive failed calls"))
+	// Only the CircuitBreakerOpen condition is updated.
+	g.Expect(got.Status.Conditions).To(HaveLen(1))
+
+	// Reconciling ExtensionConfigs which do not exist anymore is a no-op.
+	g.Expect(fakeClient.Delete(ctx, extensionConfig)).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+}
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -95,6 +96,10 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
+	if err := indexByExtensionClientCertificateSecretName(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
 	// warmupRunnable will attempt to sync the RuntimeSDK registry with existing ExtensionConfig objects to ensure extensions
 	// are discovered before controllers begin reconciling.
 	err = mgr.Add(&warmupRunnable{
@@ -105,6 +110,26 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	if err != nil {
 		return errors.Wrap(err, "failed adding warmupRunnable to controller manager")
 	}
+
+	// Circuit breaker transitions only update the CircuitBreakerOpen condition; they are handled by a separate
+	// reconciler so they don't trigger discovery against an Extension which is already failing.
+	circuitBreakerReconciler := &circuitBreakerStatusReconciler{
+		Client:        r.Client,
+		RuntimeClient: r.RuntimeClient,
+	}
+	if err := circuitBreakerReconciler.SetupWithManager(ctx, mgr, options, r.WatchFilterValue); err != nil {
+		return err
+	}
+
+	// The Reconciler and the warmupRunnable run only on the leader; registrySyncReconciler makes the RuntimeSDK registry
+	// available on all the replicas by syncing it with the handlers reported in the status of ExtensionConfigs.
+	syncReconciler := &registrySyncReconciler{
+		Client:        r.Client,
+		RuntimeClient: r.RuntimeClient,
+	}
+	if err := syncReconciler.SetupWithManager(ctx, mgr, options, r.WatchFilterValue); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -155,6 +180,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		errs = append(errs, err)
 	}
 
+	setCircuitBreakerOpenCondition(discoveredExtensionConfig, r.RuntimeClient.GetCircuitBreakerState(extensionConfig.Name))
+
 	// Always patch the ExtensionConfig as it may contain updates in conditions or clientConfig.caBundle.
 	if err = patchExtensionConfig(ctx, r.Client, original, discoveredExtensionConfig); err != nil {
 		errs = append(errs, err)
@@ -185,6 +212,7 @@ func patchExtensionConfig(ctx context.Context, client client.Client, original, m
 		patch.WithOwnedConditions{Conditions: []string{
 			clusterv1.PausedCondition,
 			runtimev1.ExtensionConfigDiscoveredCondition,
+			runtimev1.ExtensionConfigCircuitBreakerOpenCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, modified, options...)
@@ -202,23 +230,29 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, extensionConfig *runti
 }
 
 // secretToExtensionConfig maps a secret to ExtensionConfigs with the corresponding InjectCAFromSecretAnnotation
-// to reconcile them on updates of the secrets.
+// or using the secret as client certificate to reconcile them on updates of the secrets.
 func (r *Reconciler) secretToExtensionConfig(ctx context.Context, secret *metav1.PartialObjectMetadata) []reconcile.Request {
 	result := []ctrl.Request{}
+	seen := sets.Set[string]{}
 
-	extensionConfigs := runtimev1.ExtensionConfigList{}
 	indexKey := secret.GetNamespace() + "/" + secret.GetName()
+	for _, field := range []string{injectCAFromSecretAnnotationField, clientCertificateSecretField} {
+		extensionConfigs := runtimev1.ExtensionConfigList{}
+		if err := r.Client.List(
+			ctx,
+			&extensionConfigs,
+			client.MatchingFields{field: indexKey},
+		); err != nil {
+			return nil
+		}
 
-	if err := r.Client.List(
-		ctx,
-		&extensionConfigs,
-		client.MatchingFields{injectCAFromSecretAnnotationField: indexKey},
-	); err != nil {
-		return nil
-	}
-
-	for _, ext := range extensionConfigs.Items {
-		result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Name: ext.Name}})
+		for _, ext := range extensionConfigs.Items {
+			if seen.Has(ext.Name) {
+				continue
+			}
+			seen.Insert(ext.Name)
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Name: ext.Name}})
+		}
 	}
 
 	return result
@@ -250,6 +284,25 @@ func discoverExtensionConfig(ctx context.Context, runtimeClient runtimeclient.Cl
 	return discoveredExtension, nil
 }
 
+// setCircuitBreakerOpenCondition sets the CircuitBreakerOpen condition on the ExtensionConfig.
+func setCircuitBreakerOpenCondition(extensionConfig *runtimev1.ExtensionConfig, state runtimeclient.CircuitBreakerState) {
+	if !state.Open {
+		conditions.Set(extensionConfig, metav1.Condition{
+			Type:   runtimev1.ExtensionConfigCircuitBreakerOpenCondition,
+			Status: metav1.ConditionFalse,
+			Reason: runtimev1.ExtensionConfigCircuitBreakerNotOpenReason,
+		})
+		return
+	}
+
+	conditions.Set(extensionConfig, metav1.Condition{
+		Type:    runtimev1.ExtensionConfigCircuitBreakerOpenCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  runtimev1.ExtensionConfigCircuitBreakerOpenReason,
+		Message: fmt.Sprintf("Calls to the Extension are failing immediately after %d consecutive failed calls", state.ConsecutiveFailures),
+	})
+}
+
 // reconcileCABundle reconciles the CA bundle for the ExtensionConfig.
 // Note: This was implemented to behave similar to the cert-manager cainjector.
 // We couldn't use the cert-manager cainjector because it doesn't work with CustomResources.
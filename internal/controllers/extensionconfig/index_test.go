@@ -58,3 +58,58 @@ func TestExtensionConfigByInjectCAFromSecretName(t *testing.T) {
 		})
 	}
 }
+
+func TestExtensionConfigByClientCertificateSecretName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{
+			name:     "when extensionConfig has no authentication",
+			object:   &runtimev1.ExtensionConfig{},
+			expected: nil,
+		},
+		{
+			name: "when extensionConfig uses ServiceAccount token authentication",
+			object: &runtimev1.ExtensionConfig{
+				Spec: runtimev1.ExtensionConfigSpec{
+					ClientConfig: runtimev1.ClientConfig{
+						Authentication: runtimev1.ClientAuthentication{
+							ServiceAccountToken: runtimev1.ServiceAccountTokenAuthentication{
+								Audience: "foo.example.com",
+							},
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "when extensionConfig uses client certificate authentication",
+			object: &runtimev1.ExtensionConfig{
+				Spec: runtimev1.ExtensionConfigSpec{
+					ClientConfig: runtimev1.ClientConfig{
+						Authentication: runtimev1.ClientAuthentication{
+							ClientCertificate: runtimev1.ClientCertificateAuthentication{
+								SecretRef: runtimev1.SecretReference{
+									Namespace: "foo",
+									Name:      "bar",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"foo/bar"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := extensionConfigByClientCertificateSecretName(test.object)
+			g.Expect(got).To(Equal(test.expected))
+		})
+	}
+}
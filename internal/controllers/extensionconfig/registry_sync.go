@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensionconfig
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// registrySyncReconciler keeps the RuntimeSDK registry in sync with the handlers reported in the status of
+// ExtensionConfigs. It runs on all the replicas, so the registry is available also on replicas which are not the
+// leader, e.g. to webhooks and to sharded controllers; discovery and status updates are done only by the Reconciler,
+// which runs on the leader.
+type registrySyncReconciler struct {
+	Client        client.Client
+	RuntimeClient runtimeclient.Client
+}
+
+func (r *registrySyncReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, watchFilterValue string) error {
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "extensionconfig-registry")
+	options.NeedLeaderElection = ptr.To(false)
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("extensionconfig-registry").
+		For(&runtimev1.ExtensionConfig{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, watchFilterValue)).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	// registryWarmupRunnable warms up the RuntimeSDK registry with the handlers reported in the status of
+	// existing ExtensionConfigs, so the registry becomes ready without waiting for the leader to run discovery.
+	err = mgr.Add(&registryWarmupRunnable{
+		APIReader:     mgr.GetAPIReader(),
+		RuntimeClient: r.RuntimeClient,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed adding registryWarmupRunnable to controller manager")
+	}
+	return nil
+}
+
+func (r *registrySyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Requeue events when the registry is not ready.
+	if !r.RuntimeClient.IsReady() {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	extensionConfig := &runtimev1.ExtensionConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, extensionConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			extensionConfig.Name = req.Name
+			extensionConfig.Namespace = req.Namespace
+			return ctrl.Result{}, r.RuntimeClient.Unregister(extensionConfig)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !extensionConfig.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.RuntimeClient.Unregister(extensionConfig)
+	}
+
+	// Paused ExtensionConfigs are not updated until they are unpaused, consistently with the Reconciler.
+	if annotations.HasPaused(extensionConfig) {
+		return ctrl.Result{}, nil
+	}
+
+	log.V(4).Info("Registering ExtensionConfig information into registry")
+	if err := r.RuntimeClient.Register(extensionConfig); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to register ExtensionConfig %s", klog.KObj(extensionConfig))
+	}
+	return ctrl.Result{}, nil
+}
+
+var _ manager.LeaderElectionRunnable = &registryWarmupRunnable{}
+
+// registryWarmupRunnable warms up the registry with the handlers reported in the status of existing ExtensionConfigs.
+type registryWarmupRunnable struct {
+	APIReader     client.Reader
+	RuntimeClient runtimeclient.Client
+}
+
+// NeedLeaderElection satisfies the controller runtime LeaderElectionRunnable interface.
+// The registry is warmed up on all the replicas.
+func (r *registryWarmupRunnable) NeedLeaderElection() bool {
+	return false
+}
+
+// Start warms up the registry, retrying until it succeeds or the context is done.
+// NOTE: The registry could be already warmed up by the warmupRunnable on the leader; in this case there is nothing to do.
+func (r *registryWarmupRunnable) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	return wait.PollUntilContextCancel(ctx, defaultWarmupInterval, true, func(ctx context.Context) (bool, error) {
+		if r.RuntimeClient.IsReady() {
+			return true, nil
+		}
+
+		extensionConfigList := &runtimev1.ExtensionConfigList{}
+		if err := r.APIReader.List(ctx, extensionConfigList); err != nil {
+			log.Error(err, "ExtensionConfig registry warmup failed: failed to list ExtensionConfigs")
+			return false, nil
+		}
+		if err := r.RuntimeClient.WarmUp(extensionConfigList); err != nil {
+			if r.RuntimeClient.IsReady() {
+				return true, nil
+			}
+			log.Error(err, "ExtensionConfig registry warmup failed")
+			return false, nil
+		}
+		log.Info("The extension registry is warmed up with the handlers from the ExtensionConfig status")
+		return true, nil
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensionconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	internalruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
+)
+
+func Test_registrySync(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(runtimev1.AddToScheme(scheme)).To(Succeed())
+
+	cat := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+
+	extensionConfig := fakeExtensionConfigForURL("default", "ext1", "https://ext1.example.com")
+	extensionConfig.Status.Handlers = []runtimev1.ExtensionHandler{
+		{
+			Name: "first.ext1",
+			RequestHook: runtimev1.GroupVersionHook{
+				APIVersion: runtimehooksv1.GroupVersion.String(),
+				Hook:       "BeforeClusterUpgrade",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(extensionConfig).WithStatusSubresource(extensionConfig).Build()
+
+	registry := runtimeregistry.New()
+	runtimeClient := internalruntimeclient.New(internalruntimeclient.Options{
+		Catalog:  cat,
+		Registry: registry,
+		Client:   fakeClient,
+	})
+
+	// Warm up the registry from the ExtensionConfig status, without running discovery.
+	warmup := &registryWarmupRunnable{
+		APIReader:     fakeClient,
+		RuntimeClient: runtimeClient,
+	}
+	g.Expect(warmup.Start(ctx)).To(Succeed())
+	g.Expect(registry.IsReady()).To(BeTrue())
+	g.Expect(registry.Get("first.ext1")).ToNot(BeNil())
+
+	// A second warmup, e.g. run by the warmupRunnable on the leader, is a no-op.
+	g.Expect(warmup.Start(ctx)).To(Succeed())
+
+	r := &registrySyncReconciler{
+		Client:        fakeClient,
+		RuntimeClient: runtimeClient,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: extensionConfig.Namespace, Name: extensionConfig.Name}}
+
+	// Changes to the handlers in the ExtensionConfig status are synced into the registry.
+	extensionConfig.Status.Handlers = append(extensionConfig.Status.Handlers, runtimev1.ExtensionHandler{
+		Name: "second.ext1",
+		RequestHook: runtimev1.GroupVersionHook{
+			APIVersion: runtimehooksv1.GroupVersion.String(),
+			Hook:       "BeforeClusterUpgrade",
+		},
+	})
+	g.Expect(fakeClient.Status().Update(ctx, extensionConfig)).To(Succeed())
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	handlers, err := registry.List(runtimecatalog.GroupHook{Group: runtimehooksv1.GroupVersion.Group, Hook: "BeforeClusterUpgrade"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(handlers).To(HaveLen(2))
+
+	// Deleted ExtensionConfigs are removed from the registry.
+	g.Expect(fakeClient.Delete(ctx, extensionConfig)).To(Succeed())
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	handlers, err = registry.List(runtimecatalog.GroupHook{Group: runtimehooksv1.GroupVersion.Group, Hook: "BeforeClusterUpgrade"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(handlers).To(BeEmpty())
+}
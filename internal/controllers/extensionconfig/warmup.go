@@ -130,6 +130,21 @@ func warmupRegistry(ctx context.Context, client client.Client, reader client.Rea
 		return kerrors.NewAggregate(errs)
 	}
 
+	// The registry could be already warmed up from the ExtensionConfig status by the registryWarmupRunnable;
+	// in this case register the discovered ExtensionConfigs, given that the registry can only be warmed up once.
+	if runtimeClient.IsReady() {
+		for i := range extensionConfigList.Items {
+			if err := runtimeClient.Register(&extensionConfigList.Items[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) != 0 {
+			return kerrors.NewAggregate(errs)
+		}
+		log.Info("The extension registry is updated with discovered handlers")
+		return nil
+	}
+
 	if err := runtimeClient.WarmUp(&extensionConfigList); err != nil {
 		return err
 	}
@@ -49,9 +49,12 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
@@ -66,6 +69,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 const (
@@ -97,9 +101,15 @@ type Reconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// If nil, i.e. sharding is disabled, all objects are reconciled.
+	Sharder *sharding.Sharder
+
 	RemoteConditionsGracePeriod time.Duration
 
 	AdditionalSyncMachineLabels      []*regexp.Regexp
@@ -129,6 +139,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		// to have some buffer.
 		return errors.New("Client, APIReader and ClusterCache must not be nil and RemoteConditionsGracePeriod must not be < 2m")
 	}
+	if feature.Gates.Enabled(feature.RuntimeSDK) && r.RuntimeClient == nil {
+		return errors.New("RuntimeClient must not be nil")
+	}
 
 	r.predicateLog = ptr.To(ctrl.LoggerFrom(ctx).WithValues("controller", "machine"))
 	clusterToMachines, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &clusterv1.MachineList{}, mgr.GetScheme())
@@ -151,6 +164,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		For(&clusterv1.Machine{}).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), *r.predicateLog, r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceIsInOwnedShard(ctx, mgr.GetScheme(), *r.predicateLog, r.Sharder)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(clusterToMachines),
@@ -163,6 +177,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 				),
 			)).
 		WatchesRawSource(r.ClusterCache.GetClusterSource("machine", clusterToMachines, clustercache.WatchForProbeFailure(r.RemoteConditionsGracePeriod))).
+		WatchesRawSource(r.Sharder.GetClusterSource(clusterToMachines)).
 		Watches(
 			&clusterv1.MachineSet{},
 			handler.EnqueueRequestsFromMapFunc(msToMachines),
@@ -204,6 +219,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	if !r.Sharder.OwnsObject(ctx, m) {
+		return ctrl.Result{}, nil
+	}
+
 	ctx = ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues("Cluster", klog.KRef(m.Namespace, m.Spec.ClusterName)))
 
 	// Add finalizer first if not set to avoid the race condition between init and delete.
@@ -282,7 +301,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	// Handle normal reconciliation loop.
-	return doReconcile(ctx, alwaysReconcile, s)
+	reconcileNormal := alwaysReconcile
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		reconcileNormal = append([]machineReconcileFunc{r.callBeforeMachineCreate}, alwaysReconcile...)
+		reconcileNormal = append(reconcileNormal, r.callAfterMachineReady)
+	}
+	return doReconcile(ctx, reconcileNormal, s)
 }
 
 func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clusterv1.Machine, options ...patch.Option) error {
@@ -454,6 +478,20 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 		}
 	}
 
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		result, err := r.callBeforeMachineDelete(ctx, s)
+		if err != nil {
+			s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
+			s.deletingMessage = fmt.Sprintf("Error calling %s hook, please check controller logs for errors", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete))
+			return ctrl.Result{}, err
+		}
+		if !result.IsZero() {
+			s.deletingReason = clusterv1.MachineDeletingWaitingForPreDrainHookReason
+			s.deletingMessage = fmt.Sprintf("Waiting for %s hook to succeed", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete))
+			return result, nil
+		}
+	}
+
 	if isDeleteNodeAllowed {
 		// pre-drain.delete lifecycle hook
 		// Return early without error, will requeue if/when the hook owner removes the annotation.
@@ -552,6 +590,20 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 		}
 	}
 
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		result, err := r.callAfterMachineDrain(ctx, s)
+		if err != nil {
+			s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
+			s.deletingMessage = fmt.Sprintf("Error calling %s hook, please check controller logs for errors", runtimecatalog.HookName(runtimehooksv1.AfterMachineDrain))
+			return ctrl.Result{}, err
+		}
+		if !result.IsZero() {
+			s.deletingReason = clusterv1.MachineDeletingWaitingForPreTerminateHookReason
+			s.deletingMessage = fmt.Sprintf("Waiting for %s hook to succeed", runtimecatalog.HookName(runtimehooksv1.AfterMachineDrain))
+			return result, nil
+		}
+	}
+
 	// pre-term.delete lifecycle hook
 	// Return early without error, will requeue if/when the hook owner removes the annotation.
 	if annotations.HasWithPrefix(clusterv1.PreTerminateDeleteHookAnnotationPrefix, m.Annotations) {
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/conversion"
+)
+
+// callBeforeMachineCreate calls the BeforeMachineCreate hook for Machines that are not provisioned yet.
+// Until the hook returns a non-blocking response the Machine controller does not reconcile the bootstrap config
+// and the infrastructure machine; as a consequence they are not provisioned, given that providers wait for the
+// owner reference to the Machine to be set before starting provisioning.
+// The intent to call the AfterMachineReady hook is tracked after receiving a non-blocking response; this is also used
+// to ensure the BeforeMachineCreate hook is called only once.
+func (r *Reconciler) callBeforeMachineCreate(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	if !isMachineCreationBlocked(m) {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, machine, err := hookRequestObjects(s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hookRequest := &runtimehooksv1.BeforeMachineCreateRequest{
+		Cluster: *cluster,
+		Machine: *machine,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineCreateResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineCreate, s.cluster, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+	if hookResponse.RetryAfterSeconds != 0 {
+		log.Info(fmt.Sprintf("Machine creation is blocked by %q hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineCreate)))
+		return ctrl.Result{RequeueAfter: time.Duration(hookResponse.RetryAfterSeconds) * time.Second}, nil
+	}
+
+	// The BeforeMachineCreate hook returned a non-blocking response. Track the intent to call the AfterMachineReady hook.
+	if err := hooks.MarkAsPending(ctx, r.Client, m, runtimehooksv1.AfterMachineReady); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// isMachineCreationBlocked returns true if the Machine is not provisioned yet and the BeforeMachineCreate hook
+// did not return a non-blocking response yet.
+func isMachineCreationBlocked(m *clusterv1.Machine) bool {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || !m.DeletionTimestamp.IsZero() {
+		return false
+	}
+	return !hooks.IsPending(runtimehooksv1.AfterMachineReady, m) &&
+		!ptr.Deref(m.Status.Initialization.InfrastructureProvisioned, false) && !m.Status.NodeRef.IsDefined()
+}
+
+// callAfterMachineReady calls the AfterMachineReady hook if there is an intent to do so and the Machine is ready.
+func (r *Reconciler) callAfterMachineReady(ctx context.Context, s *scope) (ctrl.Result, error) {
+	m := s.machine
+
+	if !hooks.IsPending(runtimehooksv1.AfterMachineReady, m) || !conditions.IsTrue(m, clusterv1.MachineReadyCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, machine, err := hookRequestObjects(s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hookRequest := &runtimehooksv1.AfterMachineReadyRequest{
+		Cluster: *cluster,
+		Machine: *machine,
+	}
+	hookResponse := &runtimehooksv1.AfterMachineReadyResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterMachineReady, s.cluster, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, m, runtimehooksv1.AfterMachineReady); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// callBeforeMachineDelete calls the BeforeMachineDelete hook for a Machine being deleted.
+// The intent to call the AfterMachineDrain hook is tracked after receiving a non-blocking response; this is also used
+// to ensure the BeforeMachineDelete hook is called only once.
+func (r *Reconciler) callBeforeMachineDelete(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	if hooks.IsPending(runtimehooksv1.AfterMachineDrain, m) || hooks.IsOkToDelete(m) {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, machine, err := hookRequestObjects(s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hookRequest := &runtimehooksv1.BeforeMachineDeleteRequest{
+		Cluster: *cluster,
+		Machine: *machine,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDeleteResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineDelete, s.cluster, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+	if hookResponse.RetryAfterSeconds != 0 {
+		log.Info(fmt.Sprintf("Machine deletion is blocked by %q hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDelete)))
+		return ctrl.Result{RequeueAfter: time.Duration(hookResponse.RetryAfterSeconds) * time.Second}, nil
+	}
+
+	// The BeforeMachineDelete hook returned a non-blocking response. Track the intent to call the AfterMachineDrain hook.
+	if err := hooks.MarkAsPending(ctx, r.Client, m, runtimehooksv1.AfterMachineDrain); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// callAfterMachineDrain calls the AfterMachineDrain hook if there is an intent to do so.
+// After receiving a non-blocking response the Machine is marked as `ok-to-delete`.
+func (r *Reconciler) callAfterMachineDrain(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	if !hooks.IsPending(runtimehooksv1.AfterMachineDrain, m) {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, machine, err := hookRequestObjects(s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	hookRequest := &runtimehooksv1.AfterMachineDrainRequest{
+		Cluster: *cluster,
+		Machine: *machine,
+	}
+	hookResponse := &runtimehooksv1.AfterMachineDrainResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterMachineDrain, s.cluster, hookRequest, hookResponse); err != nil {
+		return ctrl.Result{}, err
+	}
+	if hookResponse.RetryAfterSeconds != 0 {
+		log.Info(fmt.Sprintf("Machine deletion is blocked by %q hook", runtimecatalog.HookName(runtimehooksv1.AfterMachineDrain)))
+		return ctrl.Result{RequeueAfter: time.Duration(hookResponse.RetryAfterSeconds) * time.Second}, nil
+	}
+
+	// The AfterMachineDrain hook returned a non-blocking response. Now the Machine is ready to be deleted.
+	if err := hooks.MarkAsOkToDelete(ctx, r.Client, m); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, m, runtimehooksv1.AfterMachineDrain); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// hookRequestObjects returns the v1beta1 Cluster and Machine to be sent to Runtime Extensions.
+func hookRequestObjects(s *scope) (*clusterv1beta1.Cluster, *clusterv1beta1.Machine, error) {
+	cluster := &clusterv1beta1.Cluster{}
+	// DeepCopy cluster because ConvertFrom has side effects like adding the conversion annotation.
+	if err := cluster.ConvertFrom(s.cluster.DeepCopy()); err != nil {
+		return nil, nil, errors.Wrap(err, "error converting Cluster to v1beta1 Cluster")
+	}
+	// Optimize size of Cluster by not sending status.
+	cluster.Status = clusterv1beta1.ClusterStatus{}
+	cleanupObjectMeta(cluster)
+
+	machine := &clusterv1beta1.Machine{}
+	// DeepCopy machine because ConvertFrom has side effects like adding the conversion annotation.
+	if err := machine.ConvertFrom(s.machine.DeepCopy()); err != nil {
+		return nil, nil, errors.Wrap(err, "error converting Machine to v1beta1 Machine")
+	}
+	cleanupObjectMeta(machine)
+
+	return cluster, machine, nil
+}
+
+// cleanupObjectMeta optimizes the size of an object by not sending the managedFields and some specific annotations.
+func cleanupObjectMeta(obj metav1.Object) {
+	obj.SetManagedFields(nil)
+
+	// The conversion does not clone annotations, so we have to do it here to not modify the original object.
+	if obj.GetAnnotations() != nil {
+		annotations := maps.Clone(obj.GetAnnotations())
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		delete(annotations, conversion.DataAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+}
@@ -0,0 +1,510 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/conversion"
+)
+
+func TestCallBeforeMachineCreate(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	gvh, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineCreate)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name               string
+		machine            *clusterv1.Machine
+		retryAfterSeconds  int32
+		status             runtimehooksv1.ResponseStatus
+		wantHookToBeCalled bool
+		wantResult         ctrl.Result
+		wantPending        bool
+		wantErr            bool
+	}{
+		{
+			name:               "should track the intent to call AfterMachineReady if the hook returns a non-blocking response",
+			machine:            newHooksTestMachine(),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{},
+			wantPending:        true,
+		},
+		{
+			name:               "should requeue if the hook returns a blocking response",
+			machine:            newHooksTestMachine(),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantPending:        false,
+		},
+		{
+			name:               "should fail if the hook returns a failure response",
+			machine:            newHooksTestMachine(),
+			status:             runtimehooksv1.ResponseStatusFailure,
+			wantHookToBeCalled: true,
+			wantErr:            true,
+		},
+		{
+			name: "should not call the hook if AfterMachineReady is already pending",
+			machine: newHooksTestMachine(func(m *clusterv1.Machine) {
+				m.Annotations[runtimev1.PendingHooksAnnotation] = "AfterMachineReady"
+			}),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        true,
+		},
+		{
+			name: "should not call the hook if the infrastructure is already provisioned",
+			machine: newHooksTestMachine(func(m *clusterv1.Machine) {
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+			}),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+		},
+		{
+			name: "should not call the hook if the Machine already has a Node",
+			machine: newHooksTestMachine(func(m *clusterv1.Machine) {
+				m.Status.NodeRef = clusterv1.MachineNodeReference{Name: "test-node"}
+			}),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hookResponse := &runtimehooksv1.BeforeMachineCreateResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					RetryAfterSeconds: tt.retryAfterSeconds,
+					CommonResponse:    runtimehooksv1.CommonResponse{Status: tt.status},
+				},
+			}
+			r, fakeRuntimeClient := newHooksTestReconciler(catalog, gvh, hookResponse, tt.machine)
+
+			res, err := r.callBeforeMachineCreate(ctx, &scope{cluster: newHooksTestCluster(), machine: tt.machine})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res).To(BeComparableTo(tt.wantResult))
+			}
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineReady, tt.machine)).To(Equal(tt.wantPending))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineCreate)).To(Equal(boolToCount(tt.wantHookToBeCalled)))
+		})
+	}
+}
+
+func TestCallAfterMachineReady(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	gvh, err := catalog.GroupVersionHook(runtimehooksv1.AfterMachineReady)
+	if err != nil {
+		panic(err)
+	}
+
+	ready := func(m *clusterv1.Machine) {
+		m.Status.Conditions = []metav1.Condition{{Type: clusterv1.MachineReadyCondition, Status: metav1.ConditionTrue}}
+	}
+	pending := func(m *clusterv1.Machine) {
+		m.Annotations[runtimev1.PendingHooksAnnotation] = "AfterMachineReady"
+	}
+
+	tests := []struct {
+		name               string
+		machine            *clusterv1.Machine
+		status             runtimehooksv1.ResponseStatus
+		wantHookToBeCalled bool
+		wantPending        bool
+		wantErr            bool
+	}{
+		{
+			name:               "should call the hook and remove the intent if the Machine is ready",
+			machine:            newHooksTestMachine(pending, ready),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantPending:        false,
+		},
+		{
+			name:               "should not call the hook if the Machine is not ready",
+			machine:            newHooksTestMachine(pending),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantPending:        true,
+		},
+		{
+			name:               "should not call the hook if there is no intent to call it",
+			machine:            newHooksTestMachine(ready),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantPending:        false,
+		},
+		{
+			name:               "should fail and preserve the intent if the hook returns a failure response",
+			machine:            newHooksTestMachine(pending, ready),
+			status:             runtimehooksv1.ResponseStatusFailure,
+			wantHookToBeCalled: true,
+			wantPending:        true,
+			wantErr:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hookResponse := &runtimehooksv1.AfterMachineReadyResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: tt.status},
+			}
+			r, fakeRuntimeClient := newHooksTestReconciler(catalog, gvh, hookResponse, tt.machine)
+
+			res, err := r.callAfterMachineReady(ctx, &scope{cluster: newHooksTestCluster(), machine: tt.machine})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res).To(BeComparableTo(ctrl.Result{}))
+			}
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineReady, tt.machine)).To(Equal(tt.wantPending))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterMachineReady)).To(Equal(boolToCount(tt.wantHookToBeCalled)))
+		})
+	}
+}
+
+func TestCallBeforeMachineDelete(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	gvh, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDelete)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name               string
+		machine            *clusterv1.Machine
+		retryAfterSeconds  int32
+		status             runtimehooksv1.ResponseStatus
+		wantHookToBeCalled bool
+		wantResult         ctrl.Result
+		wantPending        bool
+		wantErr            bool
+	}{
+		{
+			name:               "should track the intent to call AfterMachineDrain if the hook returns a non-blocking response",
+			machine:            newHooksTestMachine(),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{},
+			wantPending:        true,
+		},
+		{
+			name:               "should requeue if the hook returns a blocking response",
+			machine:            newHooksTestMachine(),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantPending:        false,
+		},
+		{
+			name:               "should fail if the hook returns a failure response",
+			machine:            newHooksTestMachine(),
+			status:             runtimehooksv1.ResponseStatusFailure,
+			wantHookToBeCalled: true,
+			wantErr:            true,
+		},
+		{
+			name: "should not call the hook if AfterMachineDrain is already pending",
+			machine: newHooksTestMachine(func(m *clusterv1.Machine) {
+				m.Annotations[runtimev1.PendingHooksAnnotation] = "AfterMachineDrain"
+			}),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        true,
+		},
+		{
+			name: "should not call the hook if the Machine is already ok-to-delete",
+			machine: newHooksTestMachine(func(m *clusterv1.Machine) {
+				m.Annotations[runtimev1.OkToDeleteAnnotation] = ""
+			}),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hookResponse := &runtimehooksv1.BeforeMachineDeleteResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					RetryAfterSeconds: tt.retryAfterSeconds,
+					CommonResponse:    runtimehooksv1.CommonResponse{Status: tt.status},
+				},
+			}
+			r, fakeRuntimeClient := newHooksTestReconciler(catalog, gvh, hookResponse, tt.machine)
+
+			res, err := r.callBeforeMachineDelete(ctx, &scope{cluster: newHooksTestCluster(), machine: tt.machine})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res).To(BeComparableTo(tt.wantResult))
+			}
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineDrain, tt.machine)).To(Equal(tt.wantPending))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineDelete)).To(Equal(boolToCount(tt.wantHookToBeCalled)))
+		})
+	}
+}
+
+func TestCallAfterMachineDrain(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	gvh, err := catalog.GroupVersionHook(runtimehooksv1.AfterMachineDrain)
+	if err != nil {
+		panic(err)
+	}
+
+	pending := func(m *clusterv1.Machine) {
+		m.Annotations[runtimev1.PendingHooksAnnotation] = "AfterMachineDrain"
+	}
+
+	tests := []struct {
+		name               string
+		machine            *clusterv1.Machine
+		retryAfterSeconds  int32
+		status             runtimehooksv1.ResponseStatus
+		wantHookToBeCalled bool
+		wantResult         ctrl.Result
+		wantPending        bool
+		wantOkToDelete     bool
+		wantErr            bool
+	}{
+		{
+			name:               "should apply the ok-to-delete annotation if the hook returns a non-blocking response",
+			machine:            newHooksTestMachine(pending),
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+			wantOkToDelete:     true,
+		},
+		{
+			name:               "should requeue if the hook returns a blocking response",
+			machine:            newHooksTestMachine(pending),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: true,
+			wantResult:         ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantPending:        true,
+			wantOkToDelete:     false,
+		},
+		{
+			name:               "should fail if the hook returns a failure response",
+			machine:            newHooksTestMachine(pending),
+			status:             runtimehooksv1.ResponseStatusFailure,
+			wantHookToBeCalled: true,
+			wantPending:        true,
+			wantErr:            true,
+		},
+		{
+			name:               "should not call the hook if there is no intent to call it",
+			machine:            newHooksTestMachine(),
+			retryAfterSeconds:  10,
+			status:             runtimehooksv1.ResponseStatusSuccess,
+			wantHookToBeCalled: false,
+			wantResult:         ctrl.Result{},
+			wantPending:        false,
+			wantOkToDelete:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hookResponse := &runtimehooksv1.AfterMachineDrainResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					RetryAfterSeconds: tt.retryAfterSeconds,
+					CommonResponse:    runtimehooksv1.CommonResponse{Status: tt.status},
+				},
+			}
+			r, fakeRuntimeClient := newHooksTestReconciler(catalog, gvh, hookResponse, tt.machine)
+
+			res, err := r.callAfterMachineDrain(ctx, &scope{cluster: newHooksTestCluster(), machine: tt.machine})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res).To(BeComparableTo(tt.wantResult))
+			}
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterMachineDrain, tt.machine)).To(Equal(tt.wantPending))
+			g.Expect(hooks.IsOkToDelete(tt.machine)).To(Equal(tt.wantOkToDelete))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterMachineDrain)).To(Equal(boolToCount(tt.wantHookToBeCalled)))
+		})
+	}
+}
+
+func newHooksTestCluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+}
+
+func newHooksTestMachine(opts ...func(m *clusterv1.Machine)) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: metav1.NamespaceDefault,
+			// Add managedFields and annotations that should be cleaned up before the Machine is sent to the RuntimeExtension.
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Manager:    "manager",
+					Operation:  "op",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{},
+				},
+			},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: "should be cleaned up",
+				conversion.DataAnnotation:          "should be cleaned up",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func newHooksTestReconciler(catalog *runtimecatalog.Catalog, gvh runtimecatalog.GroupVersionHook, hookResponse runtimehooksv1.ResponseObject, machine *clusterv1.Machine) (*Reconciler, *fakeruntimeclient.RuntimeClient) {
+	fakeClient := fake.NewClientBuilder().WithObjects(machine).Build()
+	fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+		WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+			gvh: hookResponse,
+		}).
+		WithCallAllExtensionValidations(validateMachineParameter(machine)).
+		WithCatalog(catalog).
+		Build()
+
+	return &Reconciler{
+		Client:        fakeClient,
+		RuntimeClient: fakeRuntimeClient,
+	}, fakeRuntimeClient
+}
+
+func validateMachineParameter(originalMachine *clusterv1.Machine) func(req runtimehooksv1.RequestObject) error {
+	// return a func that allows to check if expected transformations are applied to the Machine parameter which is
+	// included in the payload for lifecycle hooks calls.
+	return func(req runtimehooksv1.RequestObject) error {
+		var machine clusterv1beta1.Machine
+		switch req := req.(type) {
+		case *runtimehooksv1.BeforeMachineCreateRequest:
+			machine = req.Machine
+		case *runtimehooksv1.AfterMachineReadyRequest:
+			machine = req.Machine
+		case *runtimehooksv1.BeforeMachineDeleteRequest:
+			machine = req.Machine
+		case *runtimehooksv1.AfterMachineDrainRequest:
+			machine = req.Machine
+		default:
+			return fmt.Errorf("unhandled request type %T", req)
+		}
+
+		// check if managed fields and well know annotations have been removed from the Machine parameter included in the payload lifecycle hooks calls.
+		if machine.GetManagedFields() != nil {
+			return errors.New("managedFields should have been cleaned up")
+		}
+		if _, ok := machine.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+			return errors.New("last-applied-configuration annotation should have been cleaned up")
+		}
+		if _, ok := machine.Annotations[conversion.DataAnnotation]; ok {
+			return errors.New("conversion annotation should have been cleaned up")
+		}
+
+		// check the Machine parameter included in the payload lifecycle hooks calls has not been otherwise modified.
+		if machine.Name != originalMachine.Name || machine.Namespace != originalMachine.Namespace {
+			return errors.New("Machine in the payload should not be changed")
+		}
+		return nil
+	}
+}
+
+func boolToCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
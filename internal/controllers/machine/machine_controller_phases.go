@@ -97,6 +97,12 @@ func (r *Reconciler) ensureExternalOwnershipAndWatch(ctx context.Context, cluste
 		return nil, err
 	}
 
+	// Do not set the Machine as the owner of the external object until the BeforeMachineCreate hook returned
+	// a non-blocking response; providers wait for the owner reference before provisioning.
+	if isMachineCreationBlocked(m) {
+		return obj, nil
+	}
+
 	desiredOwnerRef := metav1.OwnerReference{
 		APIVersion: clusterv1.GroupVersion.String(),
 		Kind:       "Machine",
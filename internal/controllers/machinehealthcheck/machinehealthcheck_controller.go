@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -56,6 +57,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 const (
@@ -88,10 +90,19 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// If nil, i.e. sharding is disabled, all objects are reconciled.
+	Sharder *sharding.Sharder
+
 	controller controller.Controller
 	recorder   record.EventRecorder
 
 	predicateLog *logr.Logger
+
+	// nodeWatchesLock protects nodeWatches.
+	nodeWatchesLock sync.Mutex
+	// nodeWatches are the Clusters whose Nodes are watched by MachineHealthCheck.
+	// It is used to remove the Node watch of a Cluster when its last MachineHealthCheck is deleted.
+	nodeWatches map[types.NamespacedName]types.NamespacedName
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -109,6 +120,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), *r.predicateLog, r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceIsInOwnedShard(ctx, mgr.GetScheme(), *r.predicateLog, r.Sharder)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToMachineHealthCheck),
@@ -122,6 +134,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			),
 		).
 		WatchesRawSource(r.ClusterCache.GetClusterSource("machinehealthcheck", r.clusterToMachineHealthCheck)).
+		WatchesRawSource(r.Sharder.GetClusterSource(r.clusterToMachineHealthCheck)).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
@@ -139,15 +152,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	mhc := &clusterv1.MachineHealthCheck{}
 	if err := r.Client.Get(ctx, req.NamespacedName, mhc); err != nil {
 		if apierrors.IsNotFound(err) {
-			// Object not found, return.  Created objects are automatically garbage collected.
+			// Object not found, remove the Node watch if it is not used by other MachineHealthChecks and return.
+			// Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
-			return ctrl.Result{}, nil
+			return ctrl.Result{}, r.removeClusterNodesWatch(ctx, req.NamespacedName)
 		}
 
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	if !r.Sharder.OwnsObject(ctx, mhc) {
+		return ctrl.Result{}, nil
+	}
+
 	log = log.WithValues("Cluster", klog.KRef(mhc.Namespace, mhc.Spec.ClusterName))
 	ctx = ctrl.LoggerInto(ctx, log)
 
@@ -215,7 +233,7 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 			return ctrl.Result{}, err
 		}
 
-		if err := r.watchClusterNodes(ctx, cluster); err != nil {
+		if err := r.watchClusterNodes(ctx, cluster, m); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
@@ -613,14 +631,56 @@ func (r *Reconciler) nodeToMachineHealthCheck(ctx context.Context, o client.Obje
 	return r.machineToMachineHealthCheck(ctx, machine)
 }
 
-func (r *Reconciler) watchClusterNodes(ctx context.Context, cluster *clusterv1.Cluster) error {
-	return r.ClusterCache.Watch(ctx, util.ObjectKey(cluster), clustercache.NewWatcher(clustercache.WatcherOptions{
-		Name:         "machinehealthcheck-watchClusterNodes",
-		Watcher:      r.controller,
-		Kind:         &corev1.Node{},
+func (r *Reconciler) watchClusterNodes(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) error {
+	r.nodeWatchesLock.Lock()
+	defer r.nodeWatchesLock.Unlock()
+
+	if err := r.ClusterCache.Watch(ctx, util.ObjectKey(cluster), r.clusterNodesWatcher()); err != nil {
+		return err
+	}
+
+	if r.nodeWatches == nil {
+		r.nodeWatches = map[types.NamespacedName]types.NamespacedName{}
+	}
+	r.nodeWatches[util.ObjectKey(m)] = util.ObjectKey(cluster)
+	return nil
+}
+
+// removeClusterNodesWatch removes the Node watch of the Cluster of a deleted MachineHealthCheck,
+// if there are no other MachineHealthChecks for the same Cluster.
+func (r *Reconciler) removeClusterNodesWatch(ctx context.Context, mhcKey types.NamespacedName) error {
+	r.nodeWatchesLock.Lock()
+	defer r.nodeWatchesLock.Unlock()
+
+	clusterKey, ok := r.nodeWatches[mhcKey]
+	if !ok {
+		return nil
+	}
+	for otherMHCKey, otherClusterKey := range r.nodeWatches {
+		if otherMHCKey != mhcKey && otherClusterKey == clusterKey {
+			delete(r.nodeWatches, mhcKey)
+			return nil
+		}
+	}
+
+	if err := r.ClusterCache.RemoveWatch(ctx, clusterKey, r.clusterNodesWatcher()); err != nil {
+		return err
+	}
+	delete(r.nodeWatches, mhcKey)
+	return nil
+}
+
+func (r *Reconciler) clusterNodesWatcher() clustercache.Watcher {
+	return clustercache.NewWatcher(clustercache.WatcherOptions{
+		Name:    "machinehealthcheck-watchClusterNodes",
+		Watcher: r.controller,
+		Kind:    &corev1.Node{},
+		// Only the metadata of the Nodes is required to map them to MachineHealthChecks; status changes
+		// are still surfaced as the resourceVersion of the Nodes changes.
+		MetadataOnly: true,
 		EventHandler: handler.EnqueueRequestsFromMapFunc(r.nodeToMachineHealthCheck),
 		Predicates:   []predicate.TypedPredicate[client.Object]{predicates.TypedResourceIsChanged[client.Object](r.Client.Scheme(), *r.predicateLog)},
-	}))
+	})
 }
 
 // getMachineFromNode retrieves the machine with a nodeRef to nodeName
@@ -2265,6 +2265,37 @@ func TestNodeToMachineHealthCheck(t *testing.T) {
 	}
 }
 
+func TestRemoveClusterNodesWatch(t *testing.T) {
+	g := NewWithT(t)
+
+	cl := fake.NewClientBuilder().Build()
+	clusterKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}
+	otherClusterKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "other-cluster"}
+	r := &Reconciler{
+		Client:       cl,
+		ClusterCache: clustercache.NewFakeClusterCache(cl, clusterKey, "machinehealthcheck-watchClusterNodes"),
+		predicateLog: ptr.To(logr.Discard()),
+		nodeWatches: map[types.NamespacedName]types.NamespacedName{
+			{Namespace: metav1.NamespaceDefault, Name: "mhc-1"}:       clusterKey,
+			{Namespace: metav1.NamespaceDefault, Name: "mhc-2"}:       clusterKey,
+			{Namespace: metav1.NamespaceDefault, Name: "other-mhc-1"}: otherClusterKey,
+		},
+	}
+
+	// Removing a MachineHealthCheck that doesn't watch Nodes is a no-op.
+	g.Expect(r.removeClusterNodesWatch(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "unknown"})).To(Succeed())
+	g.Expect(r.nodeWatches).To(HaveLen(3))
+
+	// The watch is kept while other MachineHealthChecks of the Cluster exist.
+	g.Expect(r.removeClusterNodesWatch(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "mhc-1"})).To(Succeed())
+	g.Expect(r.nodeWatches).To(HaveLen(2))
+	g.Expect(r.ClusterCache.Watch(ctx, clusterKey, r.clusterNodesWatcher())).To(Succeed())
+
+	// The watch is removed with the last MachineHealthCheck of the Cluster.
+	g.Expect(r.removeClusterNodesWatch(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "mhc-2"})).To(Succeed())
+	g.Expect(r.nodeWatches).To(ConsistOf(otherClusterKey))
+}
+
 func TestIsAllowedRemediation(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -18,12 +18,20 @@ package cluster
 
 import (
 	"context"
+	"maps"
+	"slices"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/feature"
+	utilresource "sigs.k8s.io/cluster-api/util/resource"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
 
 // getBlueprint gets a ClusterBlueprint with the ClusterClass and the referenced templates to be used for a managed Cluster topology.
@@ -87,6 +95,7 @@ func (r *Reconciler) getBlueprint(ctx context.Context, cluster *clusterv1.Cluste
 		}
 
 		machineDeploymentBlueprint.HealthCheck = machineDeploymentClass.HealthCheck
+		machineDeploymentBlueprint.MachineDrainRules = machineDeploymentClass.MachineDrainRules
 		blueprint.MachineDeployments[machineDeploymentClass.Class] = machineDeploymentBlueprint
 	}
 
@@ -112,8 +121,60 @@ func (r *Reconciler) getBlueprint(ctx context.Context, cluster *clusterv1.Cluste
 			return nil, errors.Wrapf(err, "failed to get bootstrap config for ClusterClass %s, MachinePool class %q", klog.KObj(blueprint.ClusterClass), machinePoolClass.Class)
 		}
 
+		machinePoolBlueprint.HealthCheck = machinePoolClass.HealthCheck
+		machinePoolBlueprint.MachineDrainRules = machinePoolClass.MachineDrainRules
 		blueprint.MachinePools[machinePoolClass.Class] = machinePoolBlueprint
 	}
 
+	// Loop over the add-ons in ClusterClass and fetch the related templates and manifests.
+	if feature.Gates.Enabled(feature.ClusterClassAddons) {
+		for _, addonClass := range blueprint.ClusterClass.Spec.Addons {
+			addonBlueprint := &scope.AddonBlueprint{}
+
+			// Get the add-on template.
+			if addonClass.TemplateRef.IsDefined() {
+				addonBlueprint.Template, err = r.getReference(ctx, addonClass.TemplateRef.ToObjectReference(clusterClass.Namespace))
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to get template for ClusterClass %s, add-on %q", klog.KObj(blueprint.ClusterClass), addonClass.Name)
+				}
+			}
+
+			// Get the manifests for the workload cluster.
+			if addonClass.WorkloadClusterManifests.IsDefined() {
+				addonBlueprint.WorkloadClusterManifests, err = r.getWorkloadClusterManifests(ctx, clusterClass.Namespace, addonClass.WorkloadClusterManifests.ConfigMapName)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to get workload cluster manifests for ClusterClass %s, add-on %q", klog.KObj(blueprint.ClusterClass), addonClass.Name)
+				}
+			}
+
+			if blueprint.Addons == nil {
+				blueprint.Addons = map[string]*scope.AddonBlueprint{}
+			}
+			blueprint.Addons[addonClass.Name] = addonBlueprint
+		}
+	}
+
 	return blueprint, nil
 }
+
+// getWorkloadClusterManifests gets the manifests from the data of a ConfigMap.
+// NOTE: Keys of the ConfigMap data are processed in alphabetical order, and the objects defined
+// in each key are sorted for creation.
+func (r *Reconciler) getWorkloadClusterManifests(ctx context.Context, namespace, configMapName string) ([]*unstructured.Unstructured, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, configMap); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ConfigMap %s", klog.KRef(namespace, configMapName))
+	}
+
+	manifests := []*unstructured.Unstructured{}
+	for _, key := range slices.Sorted(maps.Keys(configMap.Data)) {
+		objs, err := utilyaml.ToUnstructured([]byte(configMap.Data[key]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse manifests from key %q of ConfigMap %s", key, klog.KObj(configMap))
+		}
+		for _, obj := range utilresource.SortForCreate(objs) {
+			manifests = append(manifests, obj.DeepCopy())
+		}
+	}
+	return manifests, nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -55,6 +56,8 @@ import (
 	"sigs.k8s.io/cluster-api/exp/topology/scope"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/internal/topology/revision"
+	"sigs.k8s.io/cluster-api/internal/topology/rollout"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util"
@@ -63,16 +66,24 @@ import (
 	"sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclassrevisions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinehealthchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedrainrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// clusterClassRolloutPendingRequeueAfter is the interval used to check if the current generation of the ClusterClass
+// has been released to a Cluster which can't be reconciled until then.
+const clusterClassRolloutPendingRequeueAfter = 30 * time.Second
 
 // Reconciler reconciles a managed topology for a Cluster object.
 type Reconciler struct {
@@ -87,6 +98,9 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// If nil, i.e. sharding is disabled, all objects are reconciled.
+	Sharder *sharding.Sharder
+
 	externalTracker external.ObjectTracker
 	recorder        record.EventRecorder
 
@@ -106,7 +120,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "topology/cluster")
-	c, err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.Cluster{}, builder.WithPredicates(
 			// Only reconcile Cluster with topology and with changes relevant for this controller.
 			predicates.All(mgr.GetScheme(), predicateLog,
@@ -118,6 +132,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		WatchesRawSource(r.ClusterCache.GetClusterSource("topology/cluster", func(_ context.Context, o client.Object) []ctrl.Request {
 			return []ctrl.Request{{NamespacedName: client.ObjectKeyFromObject(o)}}
 		})).
+		WatchesRawSource(r.Sharder.GetClusterSource(func(_ context.Context, o client.Object) []ctrl.Request {
+			return []ctrl.Request{{NamespacedName: client.ObjectKeyFromObject(o)}}
+		})).
 		Watches(
 			&clusterv1.ClusterClass{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterClassToCluster),
@@ -141,9 +158,20 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 				predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog),
 				predicates.ResourceIsTopologyOwned(mgr.GetScheme(), predicateLog),
 			)),
-		).
+		)
+	if feature.Gates.Enabled(feature.ClusterClassAddons) {
+		// Watch the metadata of ConfigMaps, so changes to the workload cluster manifests of add-ons are picked up.
+		// NOTE: ConfigMaps are not cached by the client, so they are read from the API server when computing the blueprint.
+		b = b.WatchesMetadata(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.configMapToCluster),
+			builder.WithPredicates(predicates.ResourceIsChanged(mgr.GetScheme(), predicateLog)),
+		)
+	}
+	c, err := b.
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceIsInOwnedShard(ctx, mgr.GetScheme(), predicateLog, r.Sharder)).
 		Build(r)
 
 	if err != nil {
@@ -267,6 +295,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
+
+	if !r.Sharder.OwnsObject(ctx, cluster) {
+		return ctrl.Result{}, nil
+	}
 	cluster.APIVersion = clusterv1.GroupVersion.String()
 	cluster.Kind = "Cluster"
 
@@ -345,10 +377,48 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 		return ctrl.Result{}, errors.Errorf("ClusterClass is not successfully reconciled: ClusterClass.status.observedGeneration must be %d, but is %d", clusterClass.GetGeneration(), clusterClass.Status.ObservedGeneration)
 	}
 
+	switch {
+	case revision.IsPinned(s.Current.Cluster):
+		// If the Cluster pins a revision of the ClusterClass, use the ClusterClass as captured by the revision.
+		// Note: Clusters pinning a revision of the ClusterClass are not part of the rollout.
+		s.Blueprint.ClusterClass, err = revision.Get(ctx, r.Client, clusterClass, s.Current.Cluster.Spec.Topology.ClassRef.Revision)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	case rollout.IsEnabled(clusterClass) && rollout.RevertRevision(clusterClass) != "":
+		// If the rollout of the current generation of the ClusterClass has been aborted, reconcile the topology with
+		// the revision of the ClusterClass before the current generation; this reverts Clusters which already adopted
+		// the current generation, and holds back the current generation for all the other Clusters.
+		s.ClusterClassRolloutAborted = true
+		s.Blueprint.ClusterClass, err = revision.Get(ctx, r.Client, clusterClass, rollout.RevertRevision(clusterClass))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	case rollout.IsEnabled(clusterClass) && !rollout.IsReleased(clusterClass, s.Current.Cluster, rollout.AssignedWave(clusterClass, s.Current.Cluster)):
+		// If the current generation of the ClusterClass is rolled out in waves and it has not been released to the
+		// wave of this Cluster yet, reconcile the topology with the revision of the ClusterClass the Cluster adopted
+		// last; this holds back changes to the ClusterClass while changes to the Cluster, e.g. to the version or to
+		// the replicas, are still rolled out.
+		// Note: This doesn't require requeue as releasing a wave changes the ClusterClass status, which will cause
+		// an additional reconcile in the Cluster.
+		s.ClusterClassRolloutPending = true
+		adoptedRevision := s.Current.Cluster.Status.Topology.ClassRevision
+		if adoptedRevision == "" {
+			// The revision of the ClusterClass the Cluster adopted last is not known, so the topology can't be
+			// reconciled until the current generation of the ClusterClass is released to the Cluster.
+			// Note: Requeue to pick up the release of the wave even if the corresponding ClusterClass event is missed.
+			return ctrl.Result{RequeueAfter: clusterClassRolloutPendingRequeueAfter}, nil
+		}
+		s.Blueprint.ClusterClass, err = revision.Get(ctx, r.Client, clusterClass, adoptedRevision)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Default and Validate the Cluster variables based on information from the ClusterClass.
 	// This step is needed as if the ClusterClass does not exist at Cluster creation some fields may not be defaulted or
 	// validated in the webhook.
-	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, nil, clusterClass); len(errs) > 0 {
+	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, nil, s.Blueprint.ClusterClass); len(errs) > 0 {
 		return ctrl.Result{}, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
 	}
 
@@ -392,6 +462,23 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 		return ctrl.Result{}, errors.Wrap(err, "error reconciling the Cluster topology")
 	}
 
+	// Track the generation and the revision of the ClusterClass the topology has been reconciled with, so the progressive
+	// rollout of the next generation of the ClusterClass can determine if the Cluster adopted it, and Clusters the
+	// next generation has not been released to yet can be reconciled with the revision they adopted.
+	// Note: Clusters pinning a revision of the ClusterClass do not adopt the current generation of the ClusterClass.
+	// Note: Clusters reverted because the rollout of the current generation of the ClusterClass has been aborted track
+	// the revision they have been reverted to, so they are not considered up-to-date with the current generation.
+	if feature.Gates.Enabled(feature.ClusterClassRollout) && !revision.IsPinned(s.Current.Cluster) && !s.ClusterClassRolloutPending {
+		if s.Current.Cluster.Status.Topology == nil {
+			s.Current.Cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{}
+		}
+		s.Current.Cluster.Status.Topology.ClassGeneration = clusterClass.Generation
+		s.Current.Cluster.Status.Topology.ClassRevision = clusterClass.Status.Revision
+		if s.ClusterClassRolloutAborted {
+			s.Current.Cluster.Status.Topology.ClassRevision = rollout.RevertRevision(clusterClass)
+		}
+	}
+
 	// requeueAfter will not be 0 if any of the runtime hooks returns a blocking response.
 	requeueAfter := s.HookResponseTracker.AggregateRetryAfter()
 	if requeueAfter != 0 {
@@ -401,7 +488,7 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 	return ctrl.Result{}, nil
 }
 
-// setupDynamicWatches create watches for InfrastructureCluster and ControlPlane CRs when they exist.
+// setupDynamicWatches create watches for InfrastructureCluster, ControlPlane and add-on CRs when they exist.
 func (r *Reconciler) setupDynamicWatches(ctx context.Context, s *scope.Scope) error {
 	scheme := r.Client.Scheme()
 	if s.Current.InfrastructureCluster != nil {
@@ -426,6 +513,17 @@ func (r *Reconciler) setupDynamicWatches(ctx context.Context, s *scope.Scope) er
 			return errors.Wrap(err, "error watching ControlPlane CR")
 		}
 	}
+	for _, addonName := range slices.Sorted(maps.Keys(s.Current.Addons)) {
+		if err := r.externalTracker.Watch(ctrl.LoggerFrom(ctx), s.Current.Addons[addonName],
+			handler.EnqueueRequestForOwner(scheme, r.Client.RESTMapper(), &clusterv1.Cluster{}),
+			// Only trigger Cluster reconciliation if the add-on object is topology owned.
+			predicates.All(scheme, *r.externalTracker.PredicateLogger,
+				predicates.ResourceIsChanged(scheme, *r.externalTracker.PredicateLogger),
+				predicates.ResourceIsTopologyOwned(scheme, *r.externalTracker.PredicateLogger),
+			)); err != nil {
+			return errors.Wrapf(err, "error watching add-on %q", addonName)
+		}
+	}
 	return nil
 }
 
@@ -485,6 +583,27 @@ func (r *Reconciler) clusterClassToCluster(ctx context.Context, o client.Object)
 	return requests
 }
 
+// configMapToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Clusters using a ClusterClass with add-ons whose workload cluster manifests are defined in the ConfigMap.
+func (r *Reconciler) configMapToCluster(ctx context.Context, o client.Object) []ctrl.Request {
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := r.Client.List(ctx, clusterClassList, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for i := range clusterClassList.Items {
+		clusterClass := &clusterClassList.Items[i]
+		if !slices.ContainsFunc(clusterClass.Spec.Addons, func(addon clusterv1.AddonClass) bool {
+			return addon.WorkloadClusterManifests.ConfigMapName == o.GetName()
+		}) {
+			continue
+		}
+		requests = append(requests, r.clusterClassToCluster(ctx, clusterClass)...)
+	}
+	return requests
+}
+
 // machineDeploymentToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
 // for Cluster to update when one of its own MachineDeployments gets updated.
 func (r *Reconciler) machineDeploymentToCluster(_ context.Context, o client.Object) []ctrl.Request {
@@ -39,6 +39,7 @@ import (
 
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/api/core/v1beta2/index"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/external"
@@ -1719,6 +1720,65 @@ func TestClusterClassToCluster(t *testing.T) {
 	}
 }
 
+func TestConfigMapToCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClassWithAddon := builder.ClusterClass(metav1.NamespaceDefault, "class-with-addon").
+		WithAddons(clusterv1.AddonClass{
+			Name: "cni",
+			WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{
+				ConfigMapName: "cni-manifests",
+			},
+		}).
+		Build()
+	clusterClassWithoutAddon := builder.ClusterClass(metav1.NamespaceDefault, "class-without-addon").Build()
+	clusterWithAddon := builder.Cluster(metav1.NamespaceDefault, "cluster-with-addon").
+		WithTopology(builder.ClusterTopology().WithClass(clusterClassWithAddon.Name).Build()).
+		Build()
+	clusterWithoutAddon := builder.Cluster(metav1.NamespaceDefault, "cluster-without-addon").
+		WithTopology(builder.ClusterTopology().WithClass(clusterClassWithoutAddon.Name).Build()).
+		Build()
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(clusterClassWithAddon, clusterClassWithoutAddon, clusterWithAddon, clusterWithoutAddon).
+		WithIndex(&clusterv1.Cluster{}, index.ClusterClassRefPath, index.ClusterByClusterClassRef).
+		Build()
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		expected  []reconcile.Request
+	}{
+		{
+			name:      "ConfigMap with add-on manifests should request reconcile for Clusters using the ClusterClass",
+			configMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cni-manifests"}},
+			expected: []reconcile.Request{
+				{NamespacedName: client.ObjectKeyFromObject(clusterWithAddon)},
+			},
+		},
+		{
+			name:      "ConfigMap not referenced by any ClusterClass should not trigger reconcile",
+			configMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "other"}},
+			expected:  []reconcile.Request{},
+		},
+		{
+			name:      "ConfigMap with matching name in a different namespace should not trigger reconcile",
+			configMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "cni-manifests"}},
+			expected:  []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(*testing.T) {
+			r := &Reconciler{Client: fakeClient}
+
+			requests := r.configMapToCluster(ctx, tt.configMap)
+			g.Expect(requests).To(ConsistOf(tt.expected))
+		})
+	}
+}
+
 func validateClusterParameter(originalCluster *clusterv1.Cluster) func(req runtimehooksv1.RequestObject) error {
 	// return a func that allows to check if expected transformations are applied to the Cluster parameter which is
 	// included in the payload for lifecycle hooks calls.
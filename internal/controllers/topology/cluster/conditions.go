@@ -43,6 +43,7 @@ func (r *Reconciler) reconcileConditions(s *scope.Scope, cluster *clusterv1.Clus
 // - The cluster is paused.
 // - An error occurred during the reconcile process of the cluster topology.
 // - The ClusterClass has not been successfully reconciled with its current spec.
+// - The current generation of the ClusterClass has not been released to the Cluster yet.
 // - The cluster upgrade has not yet propagated to all the components of the cluster.
 //   - For a managed topology cluster the version upgrade is propagated one component at a time.
 //     In such a case, since some of the component's spec would be adrift from the topology the
@@ -138,6 +139,50 @@ func (r *Reconciler) reconcileTopologyReconciledCondition(s *scope.Scope, cluste
 		return nil
 	}
 
+	// If the current generation of the ClusterClass has not been released to the wave of the Cluster yet,
+	// set the TopologyReconciled condition to false.
+	if s.ClusterClassRolloutPending {
+		message := fmt.Sprintf("ClusterClass generation %d has not been released to the Cluster yet, please check the RollingOut condition on the ClusterClass",
+			s.Blueprint.ClusterClass.GetGeneration())
+		v1beta1conditions.Set(cluster,
+			v1beta1conditions.FalseCondition(
+				clusterv1.TopologyReconciledV1Beta1Condition,
+				clusterv1.TopologyReconciledClusterClassRolloutPendingV1Beta1Reason,
+				clusterv1.ConditionSeverityInfo,
+				"%s", message,
+			),
+		)
+		conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterTopologyReconciledCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterTopologyReconciledClusterClassRolloutPendingReason,
+			Message: message,
+		})
+		return nil
+	}
+
+	// If the rollout of the current generation of the ClusterClass has been aborted,
+	// set the TopologyReconciled condition to false.
+	if s.ClusterClassRolloutAborted {
+		message := fmt.Sprintf("Rollout of ClusterClass generation %d has been aborted, the topology is reconciled with the previous revision of the ClusterClass",
+			s.Blueprint.ClusterClass.GetGeneration())
+		v1beta1conditions.Set(cluster,
+			v1beta1conditions.FalseCondition(
+				clusterv1.TopologyReconciledV1Beta1Condition,
+				clusterv1.TopologyReconciledClusterClassRolloutAbortedV1Beta1Reason,
+				clusterv1.ConditionSeverityInfo,
+				"%s", message,
+			),
+		)
+		conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterTopologyReconciledCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterTopologyReconciledClusterClassRolloutAbortedReason,
+			Message: message,
+		})
+		return nil
+	}
+
 	// If any of the lifecycle hooks are blocking any part of the reconciliation then topology
 	// is not considered as fully reconciled.
 	if s.HookResponseTracker.AggregateRetryAfter() != 0 {
@@ -97,6 +97,56 @@ func TestReconcileTopologyReconciledCondition(t *testing.T) {
 				".status.observedGeneration == .metadata.generation is true. If this is not the case either ClusterClass reconciliation failed or the ClusterClass is paused",
 			wantErr: false,
 		},
+		{
+			name:    "should set the condition to false if the ClusterClass rollout is pending",
+			cluster: &clusterv1.Cluster{},
+			s: &scope.Scope{
+				Blueprint: &scope.ClusterBlueprint{
+					ClusterClass: &clusterv1.ClusterClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "class1",
+							Generation: 10,
+						},
+						Status: clusterv1.ClusterClassStatus{
+							ObservedGeneration: 10,
+						},
+					},
+				},
+				ClusterClassRolloutPending: true,
+			},
+			wantConditionStatus:         corev1.ConditionFalse,
+			wantConditionReason:         clusterv1.TopologyReconciledClusterClassRolloutPendingV1Beta1Reason,
+			wantConditionMessage:        "ClusterClass generation 10 has not been released to the Cluster yet, please check the RollingOut condition on the ClusterClass",
+			wantV1Beta2ConditionStatus:  metav1.ConditionFalse,
+			wantV1Beta2ConditionReason:  clusterv1.ClusterTopologyReconciledClusterClassRolloutPendingReason,
+			wantV1Beta2ConditionMessage: "ClusterClass generation 10 has not been released to the Cluster yet, please check the RollingOut condition on the ClusterClass",
+			wantErr:                     false,
+		},
+		{
+			name:    "should set the condition to false if the ClusterClass rollout is aborted",
+			cluster: &clusterv1.Cluster{},
+			s: &scope.Scope{
+				Blueprint: &scope.ClusterBlueprint{
+					ClusterClass: &clusterv1.ClusterClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "class1",
+							Generation: 10,
+						},
+						Status: clusterv1.ClusterClassStatus{
+							ObservedGeneration: 10,
+						},
+					},
+				},
+				ClusterClassRolloutAborted: true,
+			},
+			wantConditionStatus:         corev1.ConditionFalse,
+			wantConditionReason:         clusterv1.TopologyReconciledClusterClassRolloutAbortedV1Beta1Reason,
+			wantConditionMessage:        "Rollout of ClusterClass generation 10 has been aborted, the topology is reconciled with the previous revision of the ClusterClass",
+			wantV1Beta2ConditionStatus:  metav1.ConditionFalse,
+			wantV1Beta2ConditionReason:  clusterv1.ClusterTopologyReconciledClusterClassRolloutAbortedReason,
+			wantV1Beta2ConditionMessage: "Rollout of ClusterClass generation 10 has been aborted, the topology is reconciled with the previous revision of the ClusterClass",
+			wantErr:                     false,
+		},
 		{
 			name:         "should set the condition to false if the there is a blocking annotation hook",
 			reconcileErr: nil,
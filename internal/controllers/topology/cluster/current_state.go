@@ -31,6 +31,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/exp/topology/scope"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	topologynames "sigs.k8s.io/cluster-api/internal/topology/names"
 	"sigs.k8s.io/cluster-api/util/labels"
 )
 
@@ -77,9 +78,54 @@ func (r *Reconciler) getCurrentState(ctx context.Context, s *scope.Scope) (*scop
 	}
 	currentState.MachinePools = mp
 
+	// A Cluster may have zero or more add-on objects, and a Cluster is expected to have zero add-on objects on
+	// first reconcile.
+	addons, err := r.getCurrentAddonsState(ctx, s.Blueprint.Addons, currentState.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	currentState.Addons = addons
+
 	return currentState, nil
 }
 
+// getCurrentAddonsState gets the current state of the add-on objects in the management cluster.
+// NOTE: Only the add-ons currently defined in the ClusterClass are considered; add-on objects are
+// looked up by the kind derived from their template and by the name computed for the Cluster.
+func (r *Reconciler) getCurrentAddonsState(ctx context.Context, blueprintAddons map[string]*scope.AddonBlueprint, cluster *clusterv1.Cluster) (map[string]*unstructured.Unstructured, error) {
+	var state map[string]*unstructured.Unstructured
+	for addonName, addonBlueprint := range blueprintAddons {
+		if addonBlueprint.Template == nil {
+			continue
+		}
+
+		ref := &corev1.ObjectReference{
+			APIVersion: addonBlueprint.Template.GetAPIVersion(),
+			Kind:       strings.TrimSuffix(addonBlueprint.Template.GetKind(), clusterv1.TemplateSuffix),
+			Namespace:  cluster.Namespace,
+			Name:       topologynames.AddonName(cluster.Name, addonName),
+		}
+		addon, err := r.getReference(ctx, ref)
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to read add-on %q", addonName)
+		}
+		// Check that the add-on object has the ClusterTopologyOwnedLabel label, so we never
+		// take over an object which has not been created by the topology controller.
+		if !labels.IsTopologyOwned(addon) {
+			return nil, fmt.Errorf("%s %s for add-on %q is not topology owned", addon.GetKind(), klog.KObj(addon), addonName)
+		}
+
+		if state == nil {
+			state = map[string]*unstructured.Unstructured{}
+		}
+		state[addonName] = addon
+	}
+	return state, nil
+}
+
 // getCurrentInfrastructureClusterState looks for the state of the InfrastructureCluster. If a reference is set but not
 // found, either from an error or the object not being found, an error is thrown.
 func (r *Reconciler) getCurrentInfrastructureClusterState(ctx context.Context, blueprintInfrastructureClusterTemplate *unstructured.Unstructured, cluster *clusterv1.Cluster) (*unstructured.Unstructured, error) {
@@ -299,11 +345,18 @@ func (r *Reconciler) getCurrentMachineDeploymentState(ctx context.Context, bluep
 			}
 		}
 
+		// Gets the MachineDrainRules.
+		machineDrainRules, err := r.getCurrentMachineDrainRules(ctx, cluster, clusterv1.ClusterTopologyMachineDeploymentNameLabel, mdTopologyName)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to get MachineDrainRules for MachineDeployment %s", klog.KObj(m)))
+		}
+
 		state[mdTopologyName] = &scope.MachineDeploymentState{
 			Object:                        m,
 			BootstrapTemplate:             bootstrapTemplate,
 			InfrastructureMachineTemplate: infraMachineTemplate,
 			MachineHealthCheck:            mhc,
+			MachineDrainRules:             machineDrainRules,
 		}
 	}
 	return state, nil
@@ -409,15 +462,62 @@ func (r *Reconciler) getCurrentMachinePoolState(ctx context.Context, blueprintMa
 			return nil, fmt.Errorf("%s %s referenced from MachinePool %s is not topology owned", infraMachinePoolObject.GetKind(), klog.KObj(infraMachinePoolObject), klog.KObj(m))
 		}
 
+		// Gets the MachineHealthCheck.
+		mhc := &clusterv1.MachineHealthCheck{}
+		// MachineHealthCheck always has the same name and namespace as the MachinePool it belongs to.
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: m.Name}, mhc); err != nil {
+			// reset the machineHealthCheck to nil if there is an error.
+			mhc = nil
+
+			// Each MachinePool isn't required to have a MachineHealthCheck. Ignore the error if it's of the type not found, but return any other error.
+			if !apierrors.IsNotFound(err) {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to get MachineHealthCheck for MachinePool %s", klog.KObj(m)))
+			}
+		}
+
+		// Gets the MachineDrainRules.
+		machineDrainRules, err := r.getCurrentMachineDrainRules(ctx, cluster, clusterv1.ClusterTopologyMachinePoolNameLabel, mpTopologyName)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to get MachineDrainRules for MachinePool %s", klog.KObj(m)))
+		}
+
 		state[mpTopologyName] = &scope.MachinePoolState{
 			Object:                          m,
 			BootstrapObject:                 bootstrapObject,
 			InfrastructureMachinePoolObject: infraMachinePoolObject,
+			MachineHealthCheck:              mhc,
+			MachineDrainRules:               machineDrainRules,
 		}
 	}
 	return state, nil
 }
 
+// getCurrentMachineDrainRules returns the MachineDrainRules generated from the ClusterClass for the Machines
+// of a MachineDeployment or a MachinePool, identified by the topology name label and value.
+func (r *Reconciler) getCurrentMachineDrainRules(ctx context.Context, cluster *clusterv1.Cluster, topologyNameLabel, topologyName string) (map[string]*clusterv1.MachineDrainRule, error) {
+	// Note: This is a cached list call.
+	mdrList := &clusterv1.MachineDrainRuleList{}
+	if err := r.Client.List(ctx, mdrList,
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel:          cluster.Name,
+			clusterv1.ClusterTopologyOwnedLabel: "",
+			topologyNameLabel:                   topologyName,
+		},
+		client.InNamespace(cluster.Namespace),
+	); err != nil {
+		return nil, err
+	}
+	if len(mdrList.Items) == 0 {
+		return nil, nil
+	}
+
+	machineDrainRules := make(map[string]*clusterv1.MachineDrainRule, len(mdrList.Items))
+	for i := range mdrList.Items {
+		machineDrainRules[mdrList.Items[i].Name] = &mdrList.Items[i]
+	}
+	return machineDrainRules, nil
+}
+
 // alignRefAPIVersion returns a full reference to the object referenced in currentRef.
 // If Group and Kind of currentRef is matching the corresponding ref in the ClusterClass, apiVersion from the ClusterClass is used.
 // This is required so the topology controller can diff current and desired state objects of the same version during reconcile.
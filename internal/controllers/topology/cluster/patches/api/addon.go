@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	addonHolderFieldPathPrefix = "addons["
+	addonHolderFieldPathSuffix = "]"
+
+	addonWorkloadClusterManifestFieldPathPrefix = ".workloadClusterManifests["
+	addonWorkloadClusterManifestFieldPathSuffix = "]"
+)
+
+// AddonHolderFieldPath returns the field path used in the holder reference of the template of an add-on.
+// NOTE: Add-on objects are not referenced by the Cluster, so the holder reference points to the Cluster
+// and the field path identifies the add-on in the ClusterClass, e.g. "addons[ip-pool]".
+func AddonHolderFieldPath(addonName string) string {
+	return fmt.Sprintf("%s%s%s", addonHolderFieldPathPrefix, addonName, addonHolderFieldPathSuffix)
+}
+
+// AddonWorkloadClusterManifestHolderFieldPath returns the field path used in the holder reference of a manifest
+// applied to the workload cluster by an add-on; the field path identifies the add-on in the ClusterClass and
+// the index of the manifest, e.g. "addons[cni].workloadClusterManifests[0]".
+func AddonWorkloadClusterManifestHolderFieldPath(addonName string, index int) string {
+	return fmt.Sprintf("%s%s%d%s", AddonHolderFieldPath(addonName), addonWorkloadClusterManifestFieldPathPrefix, index, addonWorkloadClusterManifestFieldPathSuffix)
+}
+
+// AddonNameFromHolderFieldPath returns the name of the add-on from the field path of a holder reference,
+// both for the template of an add-on and for its manifests applied to the workload cluster.
+// It returns false if the field path does not identify an add-on.
+func AddonNameFromHolderFieldPath(fieldPath string) (string, bool) {
+	if !strings.HasPrefix(fieldPath, addonHolderFieldPathPrefix) {
+		return "", false
+	}
+	name, rest, ok := strings.Cut(strings.TrimPrefix(fieldPath, addonHolderFieldPathPrefix), addonHolderFieldPathSuffix)
+	if !ok || name == "" {
+		return "", false
+	}
+	if rest != "" {
+		if _, ok := addonWorkloadClusterManifestIndex(rest); !ok {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// IsAddonWorkloadClusterManifestHolderFieldPath returns true if the field path of a holder reference identifies
+// a manifest applied to the workload cluster by an add-on.
+func IsAddonWorkloadClusterManifestHolderFieldPath(fieldPath string) bool {
+	if _, ok := AddonNameFromHolderFieldPath(fieldPath); !ok {
+		return false
+	}
+	return strings.Contains(fieldPath, addonWorkloadClusterManifestFieldPathPrefix)
+}
+
+// addonWorkloadClusterManifestIndex returns the index of a manifest from the part of a field path
+// following the add-on name, e.g. ".workloadClusterManifests[0]".
+func addonWorkloadClusterManifestIndex(s string) (int, bool) {
+	if !strings.HasPrefix(s, addonWorkloadClusterManifestFieldPathPrefix) || !strings.HasSuffix(s, addonWorkloadClusterManifestFieldPathSuffix) {
+		return 0, false
+	}
+	index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(s, addonWorkloadClusterManifestFieldPathPrefix), addonWorkloadClusterManifestFieldPathSuffix))
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
@@ -106,7 +106,7 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		log.V(5).Info("Applying patch to templates")
 
 		// Create patch generator for the current patch.
-		generator, err := createPatchGenerator(e.runtimeClient, &clusterClassPatch)
+		generator, err := createPatchGenerator(e.runtimeClient, &clusterClassPatch, blueprint.ClusterClass.Spec.Variables)
 		if err != nil {
 			return err
 		}
@@ -386,6 +386,37 @@ func createRequest(blueprint *scope.ClusterBlueprint, desired *scope.ClusterStat
 		req.Items = append(req.Items, *t)
 	}
 
+	// Add the templates for all add-on objects in the management cluster.
+	for addonName := range desired.Addons {
+		addonBlueprint, ok := blueprint.Addons[addonName]
+		if !ok || addonBlueprint.Template == nil {
+			return nil, errors.Errorf("failed to lookup template for add-on %q in ClusterClass", addonName)
+		}
+
+		t, err := newRequestItemBuilder(addonBlueprint.Template).
+			WithHolder(desired.Cluster, clusterv1.GroupVersion.WithKind("Cluster"), api.AddonHolderFieldPath(addonName)).
+			Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to prepare %s %s for add-on %q for patching",
+				addonBlueprint.Template.GetKind(), klog.KObj(addonBlueprint.Template), addonName)
+		}
+		req.Items = append(req.Items, *t)
+	}
+
+	// Add the manifests of all add-ons applied to the workload cluster.
+	for addonName, manifests := range desired.WorkloadClusterAddons {
+		for i, manifest := range manifests {
+			t, err := newRequestItemBuilder(manifest).
+				WithHolder(desired.Cluster, clusterv1.GroupVersion.WithKind("Cluster"), api.AddonWorkloadClusterManifestHolderFieldPath(addonName, i)).
+				Build()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to prepare %s %s for add-on %q for patching",
+					manifest.GetKind(), klog.KObj(manifest), addonName)
+			}
+			req.Items = append(req.Items, *t)
+		}
+	}
+
 	return req, nil
 }
 
@@ -412,10 +443,10 @@ func lookupMPTopology(topology clusterv1.Topology, mpTopologyName string) (*clus
 // createPatchGenerator creates a patch generator for the given patch.
 // NOTE: Currently only inline JSON patches are supported; in the future we will add
 // external patches as well.
-func createPatchGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
+func createPatchGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch, variableDefinitions []clusterv1.ClusterClassVariable) (api.Generator, error) {
 	// Return a jsonPatchGenerator if there are PatchDefinitions in the patch.
 	if len(patch.Definitions) > 0 {
-		return inline.NewGenerator(patch), nil
+		return inline.NewGenerator(patch, variableDefinitions), nil
 	}
 	// Return an externalPatchGenerator if there is an external configuration in the patch.
 	if patch.External != nil && patch.External.GeneratePatchesExtension != "" {
@@ -502,6 +533,16 @@ func applyPatchToRequest(ctx context.Context, req *runtimehooksv1.GeneratePatche
 		}
 	}
 
+	// Manifests applied to the workload cluster by add-ons are not templates, so all the changes
+	// are picked up, except for changes to the fields identifying the object.
+	if api.IsAddonWorkloadClusterManifestHolderFieldPath(requestItem.HolderReference.FieldPath) {
+		if err := patchManifest(&requestItem.Object, patchedTemplate); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to apply patch to manifest with uid %q", requestItem.UID))
+			return errors.Wrap(err, "failed to apply patch to manifest")
+		}
+		return nil
+	}
+
 	// Overwrite the spec of template.Template with the spec of the patchedTemplate,
 	// to ensure that we only pick up changes to the spec.
 	if err := patchTemplateSpec(&requestItem.Object, patchedTemplate); err != nil {
@@ -625,6 +666,28 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 		}
 	}
 
+	// Update all add-on objects.
+	for addonName, addon := range desired.Addons {
+		addonTemplate, err := getTemplateAsUnstructured(req, "Cluster", api.AddonHolderFieldPath(addonName), requestTopologyName{})
+		if err != nil {
+			return err
+		}
+		if err := patchObject(ctx, addon, addonTemplate); err != nil {
+			return err
+		}
+	}
+
+	// Update all the manifests of add-ons applied to the workload cluster.
+	for addonName, manifests := range desired.WorkloadClusterAddons {
+		for i := range manifests {
+			manifest, err := getTemplateAsUnstructured(req, "Cluster", api.AddonWorkloadClusterManifestHolderFieldPath(addonName, i), requestTopologyName{})
+			if err != nil {
+				return err
+			}
+			manifests[i] = manifest
+		}
+	}
+
 	return nil
 }
 
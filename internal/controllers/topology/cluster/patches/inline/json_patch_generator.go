@@ -26,9 +26,12 @@ import (
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	celgo "github.com/google/cel-go/cel"
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/cel/environment"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -39,17 +42,24 @@ import (
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/api"
 	patchvariables "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/variables"
+	topologypatches "sigs.k8s.io/cluster-api/internal/topology/patches"
 )
 
 // jsonPatchGenerator generates JSON patches for a GeneratePatchesRequest based on a ClusterClassPatch.
 type jsonPatchGenerator struct {
 	patch *clusterv1.ClusterClassPatch
+
+	// variableDefinitions are the variables defined in the ClusterClass.
+	// They are used to type the variables in CEL expressions.
+	variableDefinitions []clusterv1.ClusterClassVariable
 }
 
-// NewGenerator returns a new inline Generator from a given ClusterClassPatch object.
-func NewGenerator(patch *clusterv1.ClusterClassPatch) api.Generator {
+// NewGenerator returns a new inline Generator from a given ClusterClassPatch object
+// and the variables defined in the ClusterClass.
+func NewGenerator(patch *clusterv1.ClusterClassPatch, variableDefinitions []clusterv1.ClusterClassVariable) api.Generator {
 	return &jsonPatchGenerator{
-		patch: patch,
+		patch:               patch,
+		variableDefinitions: variableDefinitions,
 	}
 }
 
@@ -59,6 +69,16 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 
 	globalVariables := topologymutation.ToMap(req.Variables)
 
+	// Create the evaluator for CEL expressions, if the patch uses them.
+	var cel *celEvaluator
+	if usesCEL(j.patch) {
+		var err error
+		cel, err = newCELEvaluator(j.variableDefinitions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Loop over all templates.
 	errs := []error{}
 	for i := range req.Items {
@@ -88,7 +108,7 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 			continue
 		}
 
-		enabled, err := patchIsEnabled(j.patch.EnabledIf, variables)
+		enabled, err := patchIsEnabled(j.patch, variables, cel)
 		if err != nil {
 			errs = append(errs, errors.Wrapf(err, "failed to calculate if patch is enabled for %q", objectKind))
 			continue
@@ -101,7 +121,7 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 		// Loop over all PatchDefinitions.
 		for _, patch := range matchingPatches {
 			// Generate JSON patches.
-			jsonPatches, err := generateJSONPatches(patch.JSONPatches, variables)
+			jsonPatches, err := generateJSONPatches(patch.JSONPatches, variables, item, cel)
 			if err != nil {
 				errs = append(errs, errors.Wrapf(err, "failed to generate JSON patches for %q", objectKind))
 				continue
@@ -222,17 +242,50 @@ func matchesSelector(req *runtimehooksv1.GeneratePatchesRequestItem, templateVar
 		}
 	}
 
+	// Check if the request is for the template of one of the configured AddonClasses.
+	if selector.MatchResources.AddonClass != nil {
+		// The Cluster holds the template of an add-on in a field path identifying the add-on.
+		if req.HolderReference.Kind == "Cluster" {
+			if addonName, ok := api.AddonNameFromHolderFieldPath(req.HolderReference.FieldPath); ok {
+				for _, name := range selector.MatchResources.AddonClass.Names {
+					if name == "*" || name == addonName {
+						return true
+					}
+					if strings.HasPrefix(name, "*") && strings.HasSuffix(addonName, strings.TrimPrefix(name, "*")) {
+						return true
+					}
+					if strings.HasSuffix(name, "*") && strings.HasPrefix(addonName, strings.TrimSuffix(name, "*")) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
 	return false
 }
 
-func patchIsEnabled(enabledIf string, variables map[string]apiextensionsv1.JSON) (bool, error) {
+func patchIsEnabled(patch *clusterv1.ClusterClassPatch, variables map[string]apiextensionsv1.JSON, cel *celEvaluator) (bool, error) {
+	if patch.EnabledIf != "" && patch.EnabledIfExpression != "" {
+		return false, errors.Errorf("failed to calculate if patch is enabled: both .enabledIf and .enabledIfExpression are set")
+	}
+
+	// Patch is enabled if the CEL expression evaluates to true.
+	if patch.EnabledIfExpression != "" {
+		enabled, err := cel.evaluateCondition(patch.EnabledIfExpression, variables)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to calculate value for enabledIfExpression")
+		}
+		return enabled, nil
+	}
+
 	// If enabledIf is not set, patch is enabled.
-	if enabledIf == "" {
+	if patch.EnabledIf == "" {
 		return true, nil
 	}
 
 	// Rendered template.
-	value, err := renderValueTemplate(enabledIf, variables)
+	value, err := renderValueTemplate(patch.EnabledIf, variables)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to calculate value for enabledIf")
 	}
@@ -249,14 +302,14 @@ type jsonPatchRFC6902 struct {
 }
 
 // generateJSONPatches generates JSON patches based on the given JSONPatches and variables.
-func generateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON) ([]byte, error) {
+func generateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON, item *runtimehooksv1.GeneratePatchesRequestItem, cel *celEvaluator) ([]byte, error) {
 	res := []jsonPatchRFC6902{}
 
 	for _, jsonPatch := range jsonPatches {
 		var value *apiextensionsv1.JSON
 		if jsonPatch.Op == "add" || jsonPatch.Op == "replace" {
 			var err error
-			value, err = calculateValue(jsonPatch, variables)
+			value, err = calculateValue(jsonPatch, variables, item, cel)
 			if err != nil {
 				return nil, err
 			}
@@ -279,7 +332,7 @@ func generateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables map[string
 }
 
 // calculateValue calculates a value for a JSON patch.
-func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON, item *runtimehooksv1.GeneratePatchesRequestItem, cel *celEvaluator) (*apiextensionsv1.JSON, error) {
 	// Return if values are set incorrectly.
 	if patch.Value == nil && patch.ValueFrom == nil {
 		return nil, errors.Errorf("failed to calculate value: neither .value nor .valueFrom are set")
@@ -287,12 +340,15 @@ func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextension
 	if patch.Value != nil && patch.ValueFrom != nil {
 		return nil, errors.Errorf("failed to calculate value: both .value and .valueFrom are set")
 	}
-	if patch.ValueFrom != nil && patch.ValueFrom.Variable == "" && patch.ValueFrom.Template == "" {
-		return nil, errors.Errorf("failed to calculate value: .valueFrom is set, but neither .valueFrom.variable nor .valueFrom.template are set")
+	if patch.ValueFrom != nil && patch.ValueFrom.Variable == "" && patch.ValueFrom.Template == "" && patch.ValueFrom.Expression == "" {
+		return nil, errors.Errorf("failed to calculate value: .valueFrom is set, but neither .valueFrom.variable, .valueFrom.template nor .valueFrom.expression are set")
 	}
 	if patch.ValueFrom != nil && patch.ValueFrom.Variable != "" && patch.ValueFrom.Template != "" {
 		return nil, errors.Errorf("failed to calculate value: .valueFrom is set, but both .valueFrom.variable and .valueFrom.template are set")
 	}
+	if patch.ValueFrom != nil && patch.ValueFrom.Expression != "" && (patch.ValueFrom.Variable != "" || patch.ValueFrom.Template != "") {
+		return nil, errors.Errorf("failed to calculate value: .valueFrom is set, but .valueFrom.expression is set together with .valueFrom.variable or .valueFrom.template")
+	}
 
 	// Return raw value.
 	if patch.Value != nil {
@@ -308,6 +364,23 @@ func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextension
 		return value, nil
 	}
 
+	// Return value calculated by the CEL expression.
+	if patch.ValueFrom.Expression != "" {
+		var template map[string]interface{}
+		if item != nil && item.Object.Object != nil {
+			var err error
+			template, err = runtime.DefaultUnstructuredConverter.ToUnstructured(item.Object.Object)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert template to unstructured")
+			}
+		}
+		value, err := cel.evaluateValue(patch.ValueFrom.Expression, variables, template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate value for expression")
+		}
+		return value, nil
+	}
+
 	// Return rendered value template.
 	value, err := renderValueTemplate(patch.ValueFrom.Template, variables)
 	if err != nil {
@@ -388,3 +461,66 @@ func calculateTemplateData(variables map[string]apiextensionsv1.JSON) (map[strin
 
 	return res, nil
 }
+
+// usesCEL returns true if the patch uses CEL expressions.
+func usesCEL(patch *clusterv1.ClusterClassPatch) bool {
+	if patch.EnabledIfExpression != "" {
+		return true
+	}
+	for _, definition := range patch.Definitions {
+		for _, jsonPatch := range definition.JSONPatches {
+			if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Expression != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// celEvaluator evaluates CEL expressions; compiled programs are cached, so every expression
+// is only compiled once for all the templates.
+type celEvaluator struct {
+	env               *topologypatches.CELEnvironment
+	conditionPrograms map[string]celgo.Program
+	valuePrograms     map[string]celgo.Program
+}
+
+func newCELEvaluator(variableDefinitions []clusterv1.ClusterClassVariable) (*celEvaluator, error) {
+	env, err := topologypatches.NewCELEnvironment(variableDefinitions, environment.StoredExpressions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CEL environment")
+	}
+	return &celEvaluator{
+		env:               env,
+		conditionPrograms: map[string]celgo.Program{},
+		valuePrograms:     map[string]celgo.Program{},
+	}, nil
+}
+
+// evaluateCondition evaluates a CEL expression which must return a bool.
+func (c *celEvaluator) evaluateCondition(expression string, variables map[string]apiextensionsv1.JSON) (bool, error) {
+	prg, ok := c.conditionPrograms[expression]
+	if !ok {
+		var err error
+		prg, err = c.env.CompileCondition(expression)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to compile expression: %q", expression)
+		}
+		c.conditionPrograms[expression] = prg
+	}
+	return c.env.EvaluateCondition(prg, variables)
+}
+
+// evaluateValue evaluates a CEL expression which returns a value.
+func (c *celEvaluator) evaluateValue(expression string, variables map[string]apiextensionsv1.JSON, template map[string]interface{}) (*apiextensionsv1.JSON, error) {
+	prg, ok := c.valuePrograms[expression]
+	if !ok {
+		var err error
+		prg, err = c.env.CompileValue(expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile expression: %q", expression)
+		}
+		c.valuePrograms[expression] = prg
+	}
+	return c.env.EvaluateValue(prg, variables, template)
+}
@@ -415,7 +415,7 @@ func TestGenerate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := NewGenerator(tt.patch).Generate(context.Background(), &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, tt.req)
+			got, err := NewGenerator(tt.patch, nil).Generate(context.Background(), &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, tt.req)
 
 			g.Expect(got).To(BeComparableTo(tt.want))
 			g.Expect(err).ToNot(HaveOccurred())
@@ -1269,6 +1269,126 @@ func TestMatchesSelector(t *testing.T) {
 			},
 			match: true,
 		},
+		{
+			name: "Match add-on template",
+			req: &runtimehooksv1.GeneratePatchesRequestItem{
+				Object: runtime.RawExtension{
+					Object: &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "ipam.cluster.x-k8s.io/v1beta2",
+							"kind":       "IPPoolTemplate",
+						},
+					},
+				},
+				HolderReference: runtimehooksv1.HolderReference{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+					Namespace:  "default",
+					FieldPath:  "addons[ippool]",
+				},
+			},
+			selector: clusterv1.PatchSelector{
+				APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+				Kind:       "IPPoolTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"ip*"},
+					},
+				},
+			},
+			match: true,
+		},
+		{
+			name: "Match add-on manifest applied to the workload cluster",
+			req: &runtimehooksv1.GeneratePatchesRequestItem{
+				Object: runtime.RawExtension{
+					Object: &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "v1",
+							"kind":       "ConfigMap",
+						},
+					},
+				},
+				HolderReference: runtimehooksv1.HolderReference{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+					Namespace:  "default",
+					FieldPath:  "addons[cni].workloadClusterManifests[0]",
+				},
+			},
+			selector: clusterv1.PatchSelector{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"cni"},
+					},
+				},
+			},
+			match: true,
+		},
+		{
+			name: "Don't match add-on template, .matchResources.addonClass does not match",
+			req: &runtimehooksv1.GeneratePatchesRequestItem{
+				Object: runtime.RawExtension{
+					Object: &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "ipam.cluster.x-k8s.io/v1beta2",
+							"kind":       "IPPoolTemplate",
+						},
+					},
+				},
+				HolderReference: runtimehooksv1.HolderReference{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+					Namespace:  "default",
+					FieldPath:  "addons[ippool]",
+				},
+			},
+			selector: clusterv1.PatchSelector{
+				APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+				Kind:       "IPPoolTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"identity"},
+					},
+				},
+			},
+			match: false,
+		},
+		{
+			name: "Don't match InfrastructureClusterTemplate with .matchResources.addonClass",
+			req: &runtimehooksv1.GeneratePatchesRequestItem{
+				Object: runtime.RawExtension{
+					Object: &unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": clusterv1.GroupVersionInfrastructure.String(),
+							"kind":       "AzureClusterTemplate",
+						},
+					},
+				},
+				HolderReference: runtimehooksv1.HolderReference{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+					Namespace:  "default",
+					FieldPath:  "spec.infrastructureRef",
+				},
+			},
+			selector: clusterv1.PatchSelector{
+				APIVersion: clusterv1.GroupVersionInfrastructure.String(),
+				Kind:       "AzureClusterTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"*"},
+					},
+				},
+			},
+			match: false,
+		},
 		{
 			name: "Don't match: unknown field path",
 			req: &runtimehooksv1.GeneratePatchesRequestItem{
@@ -1461,7 +1581,7 @@ true
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := patchIsEnabled(tt.enabledIf, tt.variables)
+			got, err := patchIsEnabled(&clusterv1.ClusterClassPatch{EnabledIf: tt.enabledIf}, tt.variables, nil)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -1902,7 +2022,221 @@ func TestCalculateValue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := calculateValue(tt.patch, tt.variables)
+			got, err := calculateValue(tt.patch, tt.variables, nil, nil)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(got).To(BeComparableTo(tt.want))
+		})
+	}
+}
+
+// celTestVariableDefinitions are the variable definitions used in the tests for CEL expressions.
+var celTestVariableDefinitions = []clusterv1.ClusterClassVariable{
+	{
+		Name: "replicas",
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type: "integer",
+		}},
+	},
+	{
+		Name: "cpu",
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type: "number",
+		}},
+	},
+	{
+		Name: "httpProxy",
+		Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]clusterv1.JSONSchemaProps{
+				"enabled": {Type: "boolean"},
+				"url":     {Type: "string"},
+			},
+		}},
+	},
+}
+
+func TestPatchIsEnabledWithCEL(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     *clusterv1.ClusterClassPatch
+		variables map[string]apiextensionsv1.JSON
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:  "Enabled if expression evaluates to true",
+			patch: &clusterv1.ClusterClassPatch{EnabledIfExpression: `variables.httpProxy.enabled`},
+			variables: map[string]apiextensionsv1.JSON{
+				"httpProxy": {Raw: []byte(`{"enabled": true, "url": "localhost:3128"}`)},
+			},
+			want: true,
+		},
+		{
+			name:  "Disabled if expression evaluates to false",
+			patch: &clusterv1.ClusterClassPatch{EnabledIfExpression: `variables.httpProxy.enabled`},
+			variables: map[string]apiextensionsv1.JSON{
+				"httpProxy": {Raw: []byte(`{"enabled": false, "url": "localhost:3128"}`)},
+			},
+			want: false,
+		},
+		{
+			name:  "Enabled if expression with builtin variable evaluates to true",
+			patch: &clusterv1.ClusterClassPatch{EnabledIfExpression: `builtin.cluster.topology.version == "v1.21.1" && variables.replicas > 2`},
+			variables: map[string]apiextensionsv1.JSON{
+				"builtin":  {Raw: []byte(`{"cluster":{"name":"cluster-name","namespace":"default","topology":{"class":"clusterClass1","version":"v1.21.1"}}}`)},
+				"replicas": {Raw: []byte(`3`)},
+			},
+			want: true,
+		},
+		{
+			name:  "Disabled if expression checks for a variable which is not set",
+			patch: &clusterv1.ClusterClassPatch{EnabledIfExpression: `has(variables.httpProxy)`},
+			want:  false,
+		},
+		{
+			name:    "Fails if the expression does not evaluate to a bool",
+			patch:   &clusterv1.ClusterClassPatch{EnabledIfExpression: `variables.replicas`},
+			wantErr: true,
+		},
+		{
+			name:    "Fails if both enabledIf and enabledIfExpression are set",
+			patch:   &clusterv1.ClusterClassPatch{EnabledIf: `true`, EnabledIfExpression: `true`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cel, err := newCELEvaluator(celTestVariableDefinitions)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := patchIsEnabled(tt.patch, tt.variables, cel)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCalculateValueWithCEL(t *testing.T) {
+	item := &runtimehooksv1.GeneratePatchesRequestItem{
+		Object: runtime.RawExtension{
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": clusterv1.GroupVersionInfrastructure.String(),
+				"kind":       "InfrastructureMachineTemplate",
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"tags": []interface{}{"a", "b"},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		patch     clusterv1.JSONPatch
+		variables map[string]apiextensionsv1.JSON
+		want      *apiextensionsv1.JSON
+		wantErr   bool
+	}{
+		{
+			name: "Should calculate a string",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `builtin.cluster.name + "-" + variables.httpProxy.url`},
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"builtin":   {Raw: []byte(`{"cluster":{"name":"cluster-name"}}`)},
+				"httpProxy": {Raw: []byte(`{"enabled": true, "url": "localhost:3128"}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"cluster-name-localhost:3128"`)},
+		},
+		{
+			name: "Should calculate an integer value according to the variable schema",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `variables.replicas * 2`},
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`3`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`6`)},
+		},
+		{
+			name: "Should calculate a number value according to the variable schema",
+			patch: clusterv1.JSONPatch{
+				// Note: cpu is a number variable, so it is a double even if its value is an integer.
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `variables.cpu * 1.5`},
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"cpu": {Raw: []byte(`2`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`3`)},
+		},
+		{
+			name: "Should calculate a value from the template",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `template.spec.template.spec.tags + [builtin.cluster.name]`},
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"builtin": {Raw: []byte(`{"cluster":{"name":"cluster-name"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`["a","b","cluster-name"]`)},
+		},
+		{
+			name: "Should return an object variable",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `variables.httpProxy`},
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"httpProxy": {Raw: []byte(`{"enabled": true, "url": "localhost:3128"}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`{"enabled":true,"url":"localhost:3128"}`)},
+		},
+		{
+			name: "Fails if the expression does not type check",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `variables.replicas + "a"`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails if the expression references a variable which is not set",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{Expression: `variables.replicas`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails if .valueFrom.expression and .valueFrom.variable are set",
+			patch: clusterv1.JSONPatch{
+				ValueFrom: &clusterv1.JSONPatchValue{
+					Variable:   "replicas",
+					Expression: `variables.replicas`,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cel, err := newCELEvaluator(celTestVariableDefinitions)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := calculateValue(tt.patch, tt.variables, item, cel)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -168,6 +168,35 @@ func patchTemplateSpec(templateJSON *runtime.RawExtension, patchedTemplateBytes
 	return nil
 }
 
+// patchManifest overwrites the manifest in manifestJSON with the patched manifest, while preserving
+// apiVersion, kind, namespace and name, which identify the object applied to the workload cluster.
+func patchManifest(manifestJSON *runtime.RawExtension, patchedManifestBytes []byte) error {
+	// Convert manifests to Unstructured.
+	manifest, err := bytesToUnstructured(manifestJSON.Raw)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert manifest to Unstructured")
+	}
+	patchedManifest, err := bytesToUnstructured(patchedManifestBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert patched manifest to Unstructured")
+	}
+
+	// Restore the fields identifying the object.
+	patchedManifest.SetAPIVersion(manifest.GetAPIVersion())
+	patchedManifest.SetKind(manifest.GetKind())
+	patchedManifest.SetNamespace(manifest.GetNamespace())
+	patchedManifest.SetName(manifest.GetName())
+
+	// Marshal the patched manifest and store it in manifestJSON.
+	manifestBytes, err := patchedManifest.MarshalJSON()
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal patched manifest")
+	}
+	manifestJSON.Object = patchedManifest
+	manifestJSON.Raw = manifestBytes
+	return nil
+}
+
 type copySpecInput struct {
 	src              *unstructured.Unstructured
 	dest             *unstructured.Unstructured
@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/cluster-api/internal/contract"
 )
@@ -396,3 +397,32 @@ func TestCopySpec(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchManifest(t *testing.T) {
+	g := NewWithT(t)
+
+	manifestJSON := &runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cni","namespace":"kube-system"},"data":{"mtu":"1500"}}`),
+	}
+	// Patches can change all the fields of a manifest, but not the fields identifying the object.
+	patchedManifest := []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"other","namespace":"default","labels":{"a":"b"}},"data":{"mtu":"1450"}}`)
+
+	g.Expect(patchManifest(manifestJSON, patchedManifest)).To(Succeed())
+	g.Expect(manifestJSON.Object).To(BeComparableTo(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "cni",
+				"namespace": "kube-system",
+				"labels": map[string]interface{}{
+					"a": "b",
+				},
+			},
+			"data": map[string]interface{}{
+				"mtu": "1450",
+			},
+		},
+	}))
+	g.Expect(manifestJSON.Raw).To(MatchJSON(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cni","namespace":"kube-system","labels":{"a":"b"}},"data":{"mtu":"1450"}}`))
+}
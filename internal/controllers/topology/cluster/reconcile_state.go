@@ -19,12 +19,15 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -33,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -48,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api/internal/topology/clustershim"
 	topologynames "sigs.k8s.io/cluster-api/internal/topology/names"
 	"sigs.k8s.io/cluster-api/internal/topology/ownerrefs"
+	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/util"
 )
 
@@ -75,6 +80,13 @@ func (r *Reconciler) reconcileState(ctx context.Context, s *scope.Scope) error {
 		}
 	}
 
+	// Reconcile desired state of the add-on objects in the management cluster.
+	// NOTE: add-on objects are reconciled first, because they might be required e.g. by the InfrastructureCluster
+	// or by the Machines of the Cluster (e.g. identities or IPPools).
+	if err := r.reconcileAddons(ctx, s); err != nil {
+		return err
+	}
+
 	// Reconcile desired state of the InfrastructureCluster object.
 	createdInfraCluster, errInfraCluster := r.reconcileInfrastructureCluster(ctx, s)
 	if errInfraCluster != nil {
@@ -108,8 +120,13 @@ func (r *Reconciler) reconcileState(ctx context.Context, s *scope.Scope) error {
 		return err
 	}
 
-	// Reconcile desired state of the MachinePool object and return.
-	return r.reconcileMachinePools(ctx, s)
+	// Reconcile desired state of the MachinePool object.
+	if err := r.reconcileMachinePools(ctx, s); err != nil {
+		return err
+	}
+
+	// Apply the add-on manifests to the workload cluster and return.
+	return r.reconcileWorkloadClusterAddons(ctx, s)
 }
 
 // Reconcile the Cluster shim, a temporary object used a mean to collect objects/templates
@@ -276,6 +293,193 @@ func (r *Reconciler) callAfterClusterUpgrade(ctx context.Context, s *scope.Scope
 	return nil
 }
 
+// reconcileAddons reconciles the desired state of the add-on objects in the management cluster.
+// The add-on objects are tracked in the Cluster status, so add-on objects of add-ons which have been removed from the
+// ClusterClass (or which are not defined by the same template kind anymore) can be deleted.
+// NOTE: add-on objects are also garbage collected when the Cluster is deleted.
+func (r *Reconciler) reconcileAddons(ctx context.Context, s *scope.Scope) error {
+	if !feature.Gates.Enabled(feature.ClusterClassAddons) {
+		return nil
+	}
+
+	// Delete add-on objects which are not desired anymore.
+	if s.Current.Cluster.Status.Topology != nil {
+		for i := range s.Current.Cluster.Status.Topology.Addons {
+			addonStatus := &s.Current.Cluster.Status.Topology.Addons[i]
+			if addonStatus.Object == nil {
+				continue
+			}
+			if desired, ok := s.Desired.Addons[addonStatus.Name]; ok && addonObjectReference(desired) == *addonStatus.Object {
+				continue
+			}
+
+			if err := r.deleteAddonObject(ctx, r.Client, *addonStatus.Object); err != nil {
+				return errors.Wrapf(err, "failed to delete add-on %q", addonStatus.Name)
+			}
+			r.recorder.Eventf(s.Current.Cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %s %q for add-on %q",
+				addonStatus.Object.Kind, klog.KRef(addonStatus.Object.Namespace, addonStatus.Object.Name), addonStatus.Name)
+			addonStatus.Object = nil
+		}
+	}
+
+	for _, addonName := range slices.Sorted(maps.Keys(s.Desired.Addons)) {
+		desired := s.Desired.Addons[addonName]
+		log := ctrl.LoggerFrom(ctx).WithValues("addon", addonName, desired.GetKind(), klog.KObj(desired))
+		ctx := ctrl.LoggerInto(ctx, log)
+
+		if _, err := r.reconcileReferencedObject(ctx, reconcileReferencedObjectInput{
+			cluster: s.Current.Cluster,
+			current: s.Current.Addons[addonName],
+			desired: desired,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to reconcile add-on %q", addonName)
+		}
+		getOrAddAddonStatus(s.Current.Cluster, addonName).Object = ptr.To(addonObjectReference(desired))
+	}
+
+	pruneAddonsStatus(s.Current.Cluster)
+	return nil
+}
+
+// reconcileWorkloadClusterAddons applies the add-on manifests to the workload cluster using server side apply.
+// The applied objects are tracked in the Cluster status, so objects of add-ons which have been removed from the
+// ClusterClass or which have been removed from the manifests of an add-on can be deleted.
+// NOTE: Manifests are applied only after the control plane is initialized; in order to avoid unnecessary calls to
+// the workload cluster, a manifest is re-applied only if it changed or after the SSA cache entry expired.
+// NOTE: Manifests are taken from the desired state, so they include the changes applied by patches.
+// NOTE: Objects in the workload cluster are not watched; drift in the workload cluster is corrected
+// when the SSA cache entry expired.
+func (r *Reconciler) reconcileWorkloadClusterAddons(ctx context.Context, s *scope.Scope) error {
+	if !feature.Gates.Enabled(feature.ClusterClassAddons) || !isControlPlaneInitialized(s.Current.Cluster) {
+		return nil
+	}
+
+	var c client.Client
+	getClient := func() (client.Client, error) {
+		if c == nil {
+			var err error
+			c, err = r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(s.Current.Cluster))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+	}
+
+	desiredObjects := map[string][]clusterv1.ClusterTopologyAddonObjectReference{}
+	allDesiredObjects := sets.Set[clusterv1.ClusterTopologyAddonObjectReference]{}
+	for _, addonClass := range s.Blueprint.ClusterClass.Spec.Addons {
+		for _, manifest := range s.Desired.WorkloadClusterAddons[addonClass.Name] {
+			ref := addonObjectReference(manifest)
+			desiredObjects[addonClass.Name] = append(desiredObjects[addonClass.Name], ref)
+			allDesiredObjects.Insert(ref)
+
+			manifestHash, err := hash.Compute(manifest)
+			if err != nil {
+				return errors.Wrapf(err, "failed to compute hash for %s %s of add-on %q", manifest.GetKind(), klog.KObj(manifest), addonClass.Name)
+			}
+			requestIdentifier := fmt.Sprintf("%s.%s.%s.%d", s.Current.Cluster.UID, manifest.GroupVersionKind().String(), klog.KObj(manifest), manifestHash)
+			if r.ssaCache.Has(requestIdentifier, manifest.GetKind()) {
+				continue
+			}
+
+			c, err := getClient()
+			if err != nil {
+				return errors.Wrapf(err, "failed to apply manifests of add-on %q", addonClass.Name)
+			}
+
+			obj := manifest.DeepCopy()
+			if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(structuredmerge.TopologyManagerName), client.ForceOwnership); err != nil {
+				return errors.Wrapf(err, "failed to apply %s %s of add-on %q", manifest.GetKind(), klog.KObj(manifest), addonClass.Name)
+			}
+			r.ssaCache.Add(requestIdentifier)
+		}
+	}
+
+	// Delete objects which are not desired anymore.
+	// NOTE: Objects moved from one add-on to another are not deleted.
+	if s.Current.Cluster.Status.Topology != nil {
+		for i := range s.Current.Cluster.Status.Topology.Addons {
+			addonStatus := &s.Current.Cluster.Status.Topology.Addons[i]
+			for _, ref := range addonStatus.WorkloadClusterObjects {
+				if allDesiredObjects.Has(ref) {
+					continue
+				}
+
+				c, err := getClient()
+				if err != nil {
+					return errors.Wrapf(err, "failed to delete objects of add-on %q", addonStatus.Name)
+				}
+				if err := r.deleteAddonObject(ctx, c, ref); err != nil {
+					return errors.Wrapf(err, "failed to delete objects of add-on %q", addonStatus.Name)
+				}
+			}
+			addonStatus.WorkloadClusterObjects = nil
+		}
+	}
+
+	for addonName, refs := range desiredObjects {
+		getOrAddAddonStatus(s.Current.Cluster, addonName).WorkloadClusterObjects = refs
+	}
+	pruneAddonsStatus(s.Current.Cluster)
+	return nil
+}
+
+// deleteAddonObject deletes an object of an add-on; objects which do not exist anymore are ignored.
+func (r *Reconciler) deleteAddonObject(ctx context.Context, c client.Client, ref clusterv1.ClusterTopologyAddonObjectReference) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetName(ref.Name)
+
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Deleting %s", ref.Kind), ref.Kind, klog.KObj(obj))
+	if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return errors.Wrapf(err, "failed to delete %s %s", ref.Kind, klog.KObj(obj))
+	}
+	return nil
+}
+
+// addonObjectReference returns the reference to an object of an add-on used in the Cluster status.
+func addonObjectReference(obj *unstructured.Unstructured) clusterv1.ClusterTopologyAddonObjectReference {
+	return clusterv1.ClusterTopologyAddonObjectReference{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// getOrAddAddonStatus returns the status of the add-on with the given name, adding it to the Cluster status if necessary.
+func getOrAddAddonStatus(cluster *clusterv1.Cluster, addonName string) *clusterv1.ClusterTopologyAddonStatus {
+	if cluster.Status.Topology == nil {
+		cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{}
+	}
+	for i := range cluster.Status.Topology.Addons {
+		if cluster.Status.Topology.Addons[i].Name == addonName {
+			return &cluster.Status.Topology.Addons[i]
+		}
+	}
+	cluster.Status.Topology.Addons = append(cluster.Status.Topology.Addons, clusterv1.ClusterTopologyAddonStatus{Name: addonName})
+	return &cluster.Status.Topology.Addons[len(cluster.Status.Topology.Addons)-1]
+}
+
+// pruneAddonsStatus removes add-ons without objects from the Cluster status and sorts the remaining add-ons by name.
+func pruneAddonsStatus(cluster *clusterv1.Cluster) {
+	if cluster.Status.Topology == nil {
+		return
+	}
+	cluster.Status.Topology.Addons = slices.DeleteFunc(cluster.Status.Topology.Addons, func(addon clusterv1.ClusterTopologyAddonStatus) bool {
+		return addon.Object == nil && len(addon.WorkloadClusterObjects) == 0
+	})
+	slices.SortFunc(cluster.Status.Topology.Addons, func(a, b clusterv1.ClusterTopologyAddonStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(cluster.Status.Topology.Addons) == 0 {
+		cluster.Status.Topology.Addons = nil
+	}
+}
+
 // reconcileInfrastructureCluster reconciles the desired state of the InfrastructureCluster object.
 func (r *Reconciler) reconcileInfrastructureCluster(ctx context.Context, s *scope.Scope) (bool, error) {
 	log := ctrl.LoggerFrom(ctx).WithValues(s.Desired.InfrastructureCluster.GetKind(), klog.KObj(s.Desired.InfrastructureCluster))
@@ -470,6 +674,63 @@ func (r *Reconciler) reconcileMachineHealthCheck(ctx context.Context, current, d
 	return nil
 }
 
+// reconcileMachineDrainRules creates, updates or deletes MachineDrainRules depending on the difference between the
+// current state and the desired state.
+func (r *Reconciler) reconcileMachineDrainRules(ctx context.Context, current, desired map[string]*clusterv1.MachineDrainRule) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Create or patch desired MachineDrainRules.
+	// NOTE: we want to be authoritative on the entire spec because the users are
+	// expected to change MachineDrainRule fields from the ClusterClass only.
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		desiredMDR := desired[name]
+		currentMDR := current[name]
+		mdrLog := log.WithValues("MachineDrainRule", klog.KObj(desiredMDR))
+		mdrCtx := ctrl.LoggerInto(ctx, mdrLog)
+
+		if currentMDR == nil {
+			mdrLog.Info("Creating MachineDrainRule")
+			helper, err := structuredmerge.NewServerSidePatchHelper(mdrCtx, nil, desiredMDR, r.Client, r.ssaCache)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create patch helper for MachineDrainRule %s", klog.KObj(desiredMDR))
+			}
+			if err := helper.Patch(mdrCtx); err != nil {
+				return errors.Wrapf(err, "failed to create MachineDrainRule %s", klog.KObj(desiredMDR))
+			}
+			r.recorder.Eventf(desiredMDR, corev1.EventTypeNormal, createEventReason, "Created MachineDrainRule %q", klog.KObj(desiredMDR))
+			continue
+		}
+
+		patchHelper, err := structuredmerge.NewServerSidePatchHelper(mdrCtx, currentMDR, desiredMDR, r.Client, r.ssaCache)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create patch helper for MachineDrainRule %s", klog.KObj(currentMDR))
+		}
+		if !patchHelper.HasChanges() {
+			mdrLog.V(3).Info("No changes for MachineDrainRule")
+			continue
+		}
+		mdrLog.Info("Patching MachineDrainRule")
+		if err := patchHelper.Patch(mdrCtx); err != nil {
+			return errors.Wrapf(err, "failed to patch MachineDrainRule %s", klog.KObj(currentMDR))
+		}
+		r.recorder.Eventf(currentMDR, corev1.EventTypeNormal, updateEventReason, "Updated MachineDrainRule %q", klog.KObj(currentMDR))
+	}
+
+	// Delete MachineDrainRules which are not desired anymore.
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		currentMDR := current[name]
+		log.Info("Deleting MachineDrainRule", "MachineDrainRule", klog.KObj(currentMDR))
+		if err := r.Client.Delete(ctx, currentMDR); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete MachineDrainRule %s", klog.KObj(currentMDR))
+		}
+		r.recorder.Eventf(currentMDR, corev1.EventTypeNormal, deleteEventReason, "Deleted MachineDrainRule %q", klog.KObj(currentMDR))
+	}
+	return nil
+}
+
 // reconcileCluster reconciles the desired state of the Cluster object.
 // NOTE: this assumes reconcileInfrastructureCluster and reconcileControlPlane being already completed;
 // most specifically, after a Cluster is created it is assumed that the reference to the InfrastructureCluster /
@@ -697,7 +958,9 @@ func (r *Reconciler) createMachineDeployment(ctx context.Context, s *scope.Scope
 			return err
 		}
 	}
-	return nil
+
+	// If the MachineDeployment has defined MachineDrainRules reconcile them.
+	return r.reconcileMachineDrainRules(ctx, nil, md.MachineDrainRules)
 }
 
 // updateMachineDeployment updates a MachineDeployment. Also rotates the corresponding Templates if necessary.
@@ -714,6 +977,12 @@ func (r *Reconciler) updateMachineDeployment(ctx context.Context, s *scope.Scope
 		}
 	}
 
+	// Patch MachineDrainRules for the MachineDeployment.
+	// Same as for MHCs, MachineDrainRule changes are not Kubernetes version dependent.
+	if err := r.reconcileMachineDrainRules(ctx, currentMD.MachineDrainRules, desiredMD.MachineDrainRules); err != nil {
+		return err
+	}
+
 	if !currentMD.Object.DeletionTimestamp.IsZero() {
 		return nil
 	}
@@ -850,6 +1119,10 @@ func (r *Reconciler) deleteMachineDeployment(ctx context.Context, cluster *clust
 			return err
 		}
 	}
+	// delete MachineDrainRules for the MachineDeployment.
+	if err := r.reconcileMachineDrainRules(ctx, md.MachineDrainRules, nil); err != nil {
+		return err
+	}
 	if md.Object.DeletionTimestamp.IsZero() {
 		log.Info("Deleting MachineDeployment")
 		if err := r.Client.Delete(ctx, md.Object); err != nil && !apierrors.IsNotFound(err) {
@@ -1032,7 +1305,15 @@ func (r *Reconciler) createMachinePool(ctx context.Context, s *scope.Scope, mp *
 		return errors.Wrapf(err, "failed waiting for MachinePool %s to be visible in the cache after create", mp.Object.Kind)
 	}
 
-	return nil
+	// If the MachinePool has defined a MachineHealthCheck reconcile it.
+	if mp.MachineHealthCheck != nil {
+		if err := r.reconcileMachineHealthCheck(ctx, nil, mp.MachineHealthCheck); err != nil {
+			return err
+		}
+	}
+
+	// If the MachinePool has defined MachineDrainRules reconcile them.
+	return r.reconcileMachineDrainRules(ctx, nil, mp.MachineDrainRules)
 }
 
 // updateMachinePool updates a MachinePool. Also updates the corresponding objects if necessary.
@@ -1040,6 +1321,18 @@ func (r *Reconciler) updateMachinePool(ctx context.Context, s *scope.Scope, mpTo
 	log := ctrl.LoggerFrom(ctx).WithValues("MachinePool", klog.KObj(desiredMP.Object),
 		"machinePoolTopology", mpTopologyName)
 
+	// Patch MachineHealthCheck and MachineDrainRules for the MachinePool.
+	// MHC and MachineDrainRule changes are not Kubernetes version dependent, therefore proceed with
+	// their reconciliation even if the MachinePool is pending an upgrade.
+	if desiredMP.MachineHealthCheck != nil || currentMP.MachineHealthCheck != nil {
+		if err := r.reconcileMachineHealthCheck(ctx, currentMP.MachineHealthCheck, desiredMP.MachineHealthCheck); err != nil {
+			return err
+		}
+	}
+	if err := r.reconcileMachineDrainRules(ctx, currentMP.MachineDrainRules, desiredMP.MachineDrainRules); err != nil {
+		return err
+	}
+
 	// Return early if the MachinePool is pending an upgrade.
 	// Do not reconcile the MachinePool yet to avoid updating the MachinePool while it is still pending a
 	// version upgrade. This will prevent the MachinePool from performing a double rollout.
@@ -1126,6 +1419,16 @@ func (r *Reconciler) deleteMachinePool(ctx context.Context, cluster *clusterv1.C
 		"MachinePool", klog.KObj(mp.Object),
 		"machinePoolTopology", mp.Object.Labels[clusterv1.ClusterTopologyMachinePoolNameLabel])
 
+	// delete MachineHealthCheck and MachineDrainRules for the MachinePool.
+	if mp.MachineHealthCheck != nil {
+		if err := r.reconcileMachineHealthCheck(ctx, mp.MachineHealthCheck, nil); err != nil {
+			return err
+		}
+	}
+	if err := r.reconcileMachineDrainRules(ctx, mp.MachineDrainRules, nil); err != nil {
+		return err
+	}
+
 	log.Info("Deleting MachinePool")
 	if err := r.Client.Delete(ctx, mp.Object); err != nil && !apierrors.IsNotFound(err) {
 		return errors.Wrapf(err, "failed to delete MachinePool %s", klog.KObj(mp.Object))
@@ -36,7 +36,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -61,6 +63,7 @@ import (
 	topologynames "sigs.k8s.io/cluster-api/internal/topology/names"
 	"sigs.k8s.io/cluster-api/internal/topology/ownerrefs"
 	"sigs.k8s.io/cluster-api/internal/topology/selectors"
+	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util/conversion"
@@ -1895,6 +1898,97 @@ func TestReconcileControlPlaneCleanup(t *testing.T) {
 	})
 }
 
+func TestReconcileAddonsCleanup(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassAddons, true)
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	cluster.UID = "cluster1-uid"
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+
+	configMap := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	removedAddon := configMap(metav1.NamespaceDefault, "cluster1-removed")
+	removedManifest := configMap(metav1.NamespaceSystem, "removed")
+	keptManifest := configMap(metav1.NamespaceSystem, "kept")
+
+	cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{
+		Addons: []clusterv1.ClusterTopologyAddonStatus{
+			{
+				Name:   "removed",
+				Object: ptr.To(addonObjectReference(removedAddon)),
+			},
+			{
+				Name: "manifests",
+				WorkloadClusterObjects: []clusterv1.ClusterTopologyAddonObjectReference{
+					addonObjectReference(keptManifest),
+					addonObjectReference(removedManifest),
+				},
+			},
+		},
+	}
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithAddons(clusterv1.AddonClass{
+			Name:                     "manifests",
+			WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{ConfigMapName: "manifests"},
+		}).
+		Build()
+
+	s := scope.New(cluster)
+	s.Blueprint = &scope.ClusterBlueprint{
+		ClusterClass: clusterClass,
+		Addons: map[string]*scope.AddonBlueprint{
+			"manifests": {WorkloadClusterManifests: []*unstructured.Unstructured{keptManifest}},
+		},
+	}
+	s.Desired = &scope.ClusterState{
+		WorkloadClusterAddons: map[string][]*unstructured.Unstructured{
+			"manifests": {keptManifest},
+		},
+	}
+
+	managementClient := fake.NewClientBuilder().WithObjects(removedAddon.DeepCopy()).Build()
+	workloadClient := fake.NewClientBuilder().WithObjects(keptManifest.DeepCopy(), removedManifest.DeepCopy()).Build()
+
+	// Simulate that the kept manifest has been applied already.
+	ssaCache := ssa.NewCache("topology/cluster")
+	keptManifestHash, err := hash.Compute(keptManifest)
+	g.Expect(err).ToNot(HaveOccurred())
+	ssaCache.Add(fmt.Sprintf("%s.%s.%s.%d", cluster.UID, keptManifest.GroupVersionKind().String(), klog.KObj(keptManifest), keptManifestHash))
+
+	r := Reconciler{
+		Client:       managementClient,
+		ClusterCache: clustercache.NewFakeClusterCache(workloadClient, client.ObjectKeyFromObject(cluster)),
+		recorder:     record.NewFakeRecorder(32),
+		ssaCache:     ssaCache,
+	}
+	g.Expect(r.reconcileAddons(ctx, s)).To(Succeed())
+	g.Expect(r.reconcileWorkloadClusterAddons(ctx, s)).To(Succeed())
+
+	// The add-on object of the add-on removed from the ClusterClass has been deleted.
+	err = managementClient.Get(ctx, client.ObjectKeyFromObject(removedAddon), configMap("", ""))
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// The object removed from the manifests has been deleted, while the other object has been kept.
+	err = workloadClient.Get(ctx, client.ObjectKeyFromObject(removedManifest), configMap("", ""))
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(workloadClient.Get(ctx, client.ObjectKeyFromObject(keptManifest), configMap("", ""))).To(Succeed())
+
+	g.Expect(cluster.Status.Topology.Addons).To(Equal([]clusterv1.ClusterTopologyAddonStatus{
+		{
+			Name:                   "manifests",
+			WorkloadClusterObjects: []clusterv1.ClusterTopologyAddonObjectReference{addonObjectReference(keptManifest)},
+		},
+	}))
+}
+
 func TestReconcileControlPlaneMachineHealthCheck(t *testing.T) {
 	// Create InfrastructureMachineTemplates for test cases
 	infrastructureMachineTemplate := builder.TestInfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()
@@ -3979,6 +4073,102 @@ func TestReconciler_reconcileMachineHealthCheck(t *testing.T) {
 	}
 }
 
+func TestReconciler_reconcileMachineDrainRules(t *testing.T) {
+	newMachineDrainRule := func(name string, behavior clusterv1.MachineDrainRuleDrainBehavior) *clusterv1.MachineDrainRule {
+		return &clusterv1.MachineDrainRule{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "MachineDrainRule",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:                          "cluster1",
+					clusterv1.ClusterTopologyOwnedLabel:                 "",
+					clusterv1.ClusterTopologyMachineDeploymentNameLabel: "md1",
+				},
+			},
+			Spec: clusterv1.MachineDrainRuleSpec{
+				Drain: clusterv1.MachineDrainRuleDrainConfig{
+					Behavior: behavior,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		current []*clusterv1.MachineDrainRule
+		desired []*clusterv1.MachineDrainRule
+		want    []*clusterv1.MachineDrainRule
+	}{
+		{
+			name:    "Create MachineDrainRules",
+			desired: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+			want:    []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+		},
+		{
+			name:    "Update MachineDrainRules with changes",
+			current: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+			desired: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted)},
+			want:    []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted)},
+		},
+		{
+			name:    "Delete MachineDrainRules which are not desired anymore",
+			current: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorSkip), newMachineDrainRule("md1-b", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+			desired: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-b", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+			want:    []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-b", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+		},
+		{
+			name:    "Delete all MachineDrainRules",
+			current: []*clusterv1.MachineDrainRule{newMachineDrainRule("md1-a", clusterv1.MachineDrainRuleDrainBehaviorSkip)},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			namespace, err := env.CreateNamespace(ctx, "reconcile-mdr")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			toMap := func(machineDrainRules []*clusterv1.MachineDrainRule) map[string]*clusterv1.MachineDrainRule {
+				if machineDrainRules == nil {
+					return nil
+				}
+				m := map[string]*clusterv1.MachineDrainRule{}
+				for _, mdr := range machineDrainRules {
+					mdr = mdr.DeepCopy()
+					mdr.SetNamespace(namespace.GetName())
+					m[mdr.Name] = mdr
+				}
+				return m
+			}
+			current := toMap(tt.current)
+			desired := toMap(tt.desired)
+			for _, mdr := range current {
+				g.Expect(env.CreateAndWait(ctx, mdr)).To(Succeed())
+			}
+
+			r := Reconciler{
+				Client:   env,
+				recorder: env.GetEventRecorderFor("test"),
+				ssaCache: ssa.NewCache("topology/cluster"),
+			}
+			g.Expect(r.reconcileMachineDrainRules(ctx, current, desired)).To(Succeed())
+
+			got := &clusterv1.MachineDrainRuleList{}
+			g.Expect(env.GetAPIReader().List(ctx, got, client.InNamespace(namespace.GetName()))).To(Succeed())
+			g.Expect(got.Items).To(HaveLen(len(tt.want)))
+			for _, want := range tt.want {
+				gotMDR := &clusterv1.MachineDrainRule{}
+				g.Expect(env.GetAPIReader().Get(ctx, client.ObjectKey{Namespace: namespace.GetName(), Name: want.Name}, gotMDR)).To(Succeed())
+				g.Expect(gotMDR.Spec).To(BeComparableTo(want.Spec))
+			}
+		})
+	}
+}
+
 // prepareControlPlaneBluePrint deep-copies and returns the input scope and sets
 // the given namespace to all relevant objects.
 func prepareControlPlaneBluePrint(in *scope.ControlPlaneBlueprint, namespace string) *scope.ControlPlaneBlueprint {
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
+	runtimemetrics "sigs.k8s.io/cluster-api/internal/runtime/metrics"
+)
+
+const (
+	defaultCircuitBreakerOpenSeconds    = 10
+	defaultCircuitBreakerMaxOpenSeconds = 300
+
+	// circuitBreakerSourceBufferSize is the number of events buffered for each source returned by GetCircuitBreakerSource.
+	circuitBreakerSourceBufferSize = 100
+)
+
+// circuitBreaker tracks the results of calls to an Extension.
+type circuitBreaker struct {
+	// consecutiveFailures is the number of consecutive failed calls.
+	consecutiveFailures int32
+	// openDuration is the duration the circuit has been opened for the last time.
+	openDuration time.Duration
+	// openUntil is the time until the circuit is open; it is zero if the circuit is closed.
+	openUntil time.Time
+	// probing is true while a call probing if the Extension recovered is in progress.
+	probing bool
+}
+
+// circuitBreakers are the circuit breakers for calls to Extensions by ExtensionConfig name.
+// The circuit of an ExtensionConfig is opened after failureThreshold consecutive failed calls; while the circuit
+// is open calls fail immediately. After openSeconds a single call is allowed to probe if the Extension recovered;
+// if the probe succeeds the circuit is closed, otherwise it is opened again for twice the previous duration,
+// up to maxOpenSeconds.
+type circuitBreakers struct {
+	now func() time.Time
+
+	// lock is used to synchronize access to items and sources.
+	lock sync.Mutex
+	// items contains the circuit breakers by ExtensionConfig name.
+	items map[string]*circuitBreaker
+	// sources contains the channels of the sources returned by GetCircuitBreakerSource.
+	sources []chan event.GenericEvent
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{
+		now:   time.Now,
+		items: map[string]*circuitBreaker{},
+	}
+}
+
+// Allow returns true if a call to the Extension of an ExtensionConfig is allowed, and if the call
+// is probing if the Extension recovered. If the call is allowed, its result must be reported using Record.
+func (b *circuitBreakers) Allow(extensionConfigName string, config runtimev1.CircuitBreaker) (allowed, probe bool) {
+	if !config.IsDefined() {
+		return true, false
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	cb, ok := b.items[extensionConfigName]
+	if !ok || cb.openUntil.IsZero() {
+		return true, false
+	}
+	if b.now().Before(cb.openUntil) || cb.probing {
+		return false, false
+	}
+
+	// The circuit is open, but openUntil expired; allow a single call to probe if the Extension recovered.
+	cb.probing = true
+	return true, true
+}
+
+// Record records the result of a call to the Extension of an ExtensionConfig.
+func (b *circuitBreakers) Record(extensionConfigName string, config runtimev1.CircuitBreaker, probe, failed bool) {
+	if !config.IsDefined() {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	cb, ok := b.items[extensionConfigName]
+	if !ok {
+		cb = &circuitBreaker{}
+		b.items[extensionConfigName] = cb
+	}
+	wasOpen := !cb.openUntil.IsZero()
+	if probe {
+		cb.probing = false
+	}
+
+	if !failed {
+		cb.consecutiveFailures = 0
+		cb.openDuration = 0
+		cb.openUntil = time.Time{}
+		if wasOpen {
+			runtimemetrics.CircuitBreakerOpen.Observe(extensionConfigName, false)
+			b.notify(extensionConfigName)
+		}
+		return
+	}
+
+	cb.consecutiveFailures++
+	switch {
+	case wasOpen && probe:
+		// The probe failed, open the circuit again for twice the previous duration.
+		maxOpenDuration := time.Duration(ptr.Deref(config.MaxOpenSeconds, defaultCircuitBreakerMaxOpenSeconds)) * time.Second
+		cb.openDuration = min(2*cb.openDuration, maxOpenDuration)
+		cb.openUntil = b.now().Add(cb.openDuration)
+	case !wasOpen && cb.consecutiveFailures >= config.FailureThreshold:
+		cb.openDuration = time.Duration(ptr.Deref(config.OpenSeconds, defaultCircuitBreakerOpenSeconds)) * time.Second
+		cb.openUntil = b.now().Add(cb.openDuration)
+		runtimemetrics.CircuitBreakerOpen.Observe(extensionConfigName, true)
+		b.notify(extensionConfigName)
+	}
+}
+
+// Skip must be called instead of Record if a call allowed by Allow has not been performed, e.g. because
+// there was no concurrency slot available; if the call was probing if the Extension recovered, another call
+// is allowed to probe.
+func (b *circuitBreakers) Skip(extensionConfigName string, probe bool) {
+	if !probe {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if cb, ok := b.items[extensionConfigName]; ok {
+		cb.probing = false
+	}
+}
+
+// Remove removes the circuit breaker of an ExtensionConfig.
+func (b *circuitBreakers) Remove(extensionConfigName string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.items, extensionConfigName)
+	runtimemetrics.CircuitBreakerOpen.Delete(extensionConfigName)
+}
+
+// State returns the state of the circuit breaker of an ExtensionConfig.
+func (b *circuitBreakers) State(extensionConfigName string) runtimeclient.CircuitBreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	cb, ok := b.items[extensionConfigName]
+	if !ok {
+		return runtimeclient.CircuitBreakerState{}
+	}
+	return runtimeclient.CircuitBreakerState{
+		Open:                !cb.openUntil.IsZero(),
+		OpenUntil:           cb.openUntil,
+		ConsecutiveFailures: cb.consecutiveFailures,
+	}
+}
+
+// Source returns a Source of events for ExtensionConfigs, which is triggered every time
+// the circuit breaker of an ExtensionConfig opens or closes.
+func (b *circuitBreakers) Source() source.Source {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ch := make(chan event.GenericEvent, circuitBreakerSourceBufferSize)
+	b.sources = append(b.sources, ch)
+	return source.Channel(ch, &handler.EnqueueRequestForObject{})
+}
+
+// notify sends an event for the ExtensionConfig to all sources.
+// Note: Events are dropped if the buffer of a source is full, e.g. because the source has not been started on
+// replicas where the ExtensionConfig controller is not running, so calls to Extensions are never blocked.
+func (b *circuitBreakers) notify(extensionConfigName string) {
+	for _, ch := range b.sources {
+		select {
+		case ch <- event.GenericEvent{Object: &runtimev1.ExtensionConfig{ObjectMeta: metav1.ObjectMeta{Name: extensionConfigName}}}:
+		default:
+		}
+	}
+}
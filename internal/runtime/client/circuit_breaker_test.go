@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	config := runtimev1.CircuitBreaker{
+		FailureThreshold: 2,
+		OpenSeconds:      ptr.To[int32](10),
+		MaxOpenSeconds:   ptr.To[int32](15),
+	}
+
+	t.Run("always allows calls if the circuit breaker is not configured", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b := newCircuitBreakers()
+		for range 5 {
+			allowed, _ := b.Allow("extension", runtimev1.CircuitBreaker{})
+			g.Expect(allowed).To(BeTrue())
+			b.Record("extension", runtimev1.CircuitBreaker{}, false, true)
+		}
+		g.Expect(b.State("extension").Open).To(BeFalse())
+	})
+
+	t.Run("opens the circuit after consecutive failures and closes it after a successful probe", func(t *testing.T) {
+		g := NewWithT(t)
+
+		now := time.Now()
+		b := newCircuitBreakers()
+		b.now = func() time.Time { return now }
+
+		// A success resets the number of consecutive failures.
+		b.Record("extension", config, false, true)
+		b.Record("extension", config, false, false)
+		b.Record("extension", config, false, true)
+		g.Expect(b.State("extension").Open).To(BeFalse())
+
+		// The second consecutive failure opens the circuit.
+		b.Record("extension", config, false, true)
+		state := b.State("extension")
+		g.Expect(state.Open).To(BeTrue())
+		g.Expect(state.ConsecutiveFailures).To(Equal(int32(2)))
+		g.Expect(state.OpenUntil).To(Equal(now.Add(10 * time.Second)))
+
+		allowed, _ := b.Allow("extension", config)
+		g.Expect(allowed).To(BeFalse())
+
+		// After openSeconds a single probe is allowed.
+		now = now.Add(10 * time.Second)
+		allowed, probe := b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+		g.Expect(probe).To(BeTrue())
+		allowed, _ = b.Allow("extension", config)
+		g.Expect(allowed).To(BeFalse())
+
+		// A failed probe opens the circuit again for twice the duration, capped to maxOpenSeconds.
+		b.Record("extension", config, true, true)
+		g.Expect(b.State("extension").OpenUntil).To(Equal(now.Add(15 * time.Second)))
+
+		// A call started before the circuit was opened does not extend the open duration.
+		b.Record("extension", config, false, true)
+		g.Expect(b.State("extension").OpenUntil).To(Equal(now.Add(15 * time.Second)))
+
+		// A successful probe closes the circuit.
+		now = now.Add(15 * time.Second)
+		allowed, probe = b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+		g.Expect(probe).To(BeTrue())
+		b.Record("extension", config, true, false)
+		state = b.State("extension")
+		g.Expect(state.Open).To(BeFalse())
+		g.Expect(state.ConsecutiveFailures).To(Equal(int32(0)))
+
+		allowed, probe = b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+		g.Expect(probe).To(BeFalse())
+	})
+
+	t.Run("allows another probe if a probe has been skipped", func(t *testing.T) {
+		g := NewWithT(t)
+
+		now := time.Now()
+		b := newCircuitBreakers()
+		b.now = func() time.Time { return now }
+		b.Record("extension", config, false, true)
+		b.Record("extension", config, false, true)
+
+		now = now.Add(10 * time.Second)
+		allowed, probe := b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+		g.Expect(probe).To(BeTrue())
+
+		b.Skip("extension", probe)
+		g.Expect(b.State("extension").ConsecutiveFailures).To(Equal(int32(2)))
+		allowed, probe = b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+		g.Expect(probe).To(BeTrue())
+	})
+
+	t.Run("does not block if events are not consumed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b := newCircuitBreakers()
+		_ = b.Source()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 2 * circuitBreakerSourceBufferSize {
+				b.Record("extension", config, false, true)
+				b.Record("extension", config, false, true)
+				b.Record("extension", config, false, false)
+			}
+		}()
+		g.Eventually(done).Should(BeClosed())
+	})
+
+	t.Run("removes the circuit breaker", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b := newCircuitBreakers()
+		b.Record("extension", config, false, true)
+		b.Record("extension", config, false, true)
+		g.Expect(b.State("extension").Open).To(BeTrue())
+
+		b.Remove("extension")
+		g.Expect(b.State("extension").Open).To(BeFalse())
+		allowed, _ := b.Allow("extension", config)
+		g.Expect(allowed).To(BeTrue())
+	})
+}
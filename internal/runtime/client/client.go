@@ -28,8 +28,10 @@ import (
 	"net/url"
 	"path"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -44,6 +46,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
@@ -56,36 +59,57 @@ import (
 
 type errCallingExtensionHandler error
 
-const defaultDiscoveryTimeout = 10 * time.Second
+const (
+	defaultDiscoveryTimeout = 10 * time.Second
+
+	// DefaultMaxParallelCalls is the default maximum number of ExtensionHandlers called in parallel by CallAllExtensions.
+	DefaultMaxParallelCalls = 10
+)
 
 // Options are creation options for a Client.
 type Options struct {
 	CertFile string // Path of the PEM-encoded client certificate.
 	KeyFile  string // Path of the PEM-encoded client key.
-	Catalog  *runtimecatalog.Catalog
-	Registry runtimeregistry.ExtensionRegistry
-	Client   ctrlclient.Client
+	TokenDir string // Path of the directory containing projected ServiceAccount tokens, one file per audience.
+	// MaxParallelCalls is the maximum number of ExtensionHandlers called in parallel by CallAllExtensions.
+	// Defaults to DefaultMaxParallelCalls.
+	MaxParallelCalls int
+	Catalog          *runtimecatalog.Catalog
+	Registry         runtimeregistry.ExtensionRegistry
+	Client           ctrlclient.Client
 }
 
 // New returns a new Client.
 func New(options Options) runtimeclient.Client {
+	if options.MaxParallelCalls <= 0 {
+		options.MaxParallelCalls = DefaultMaxParallelCalls
+	}
 	return &client{
-		certFile: options.CertFile,
-		keyFile:  options.KeyFile,
-		catalog:  options.Catalog,
-		registry: options.Registry,
-		client:   options.Client,
+		certFile:            options.CertFile,
+		keyFile:             options.KeyFile,
+		maxParallelCalls:    options.MaxParallelCalls,
+		catalog:             options.Catalog,
+		registry:            options.Registry,
+		client:              options.Client,
+		credentials:         newCredentialsCache(options.Client, options.TokenDir),
+		circuitBreakers:     newCircuitBreakers(),
+		concurrencyLimiters: newConcurrencyLimiters(),
 	}
 }
 
 var _ runtimeclient.Client = &client{}
 
 type client struct {
-	certFile string
-	keyFile  string
-	catalog  *runtimecatalog.Catalog
-	registry runtimeregistry.ExtensionRegistry
-	client   ctrlclient.Client
+	certFile    string
+	keyFile     string
+	catalog     *runtimecatalog.Catalog
+	registry    runtimeregistry.ExtensionRegistry
+	client      ctrlclient.Client
+	credentials *credentialsCache
+
+	maxParallelCalls    int
+	circuitBreakers     *circuitBreakers
+	concurrencyLimiters *concurrencyLimiters
 }
 
 func (c *client) WarmUp(extensionConfigList *runtimev1.ExtensionConfigList) error {
@@ -105,11 +129,18 @@ func (c *client) Discover(ctx context.Context, extensionConfig *runtimev1.Extens
 		return nil, errors.Wrapf(err, "failed to discover extension %q: failed to compute GVH of hook", extensionConfig.Name)
 	}
 
+	// Always reload credentials on discovery, so changes to the client certificate Secret are picked up.
+	creds, err := c.credentials.Reload(ctx, extensionConfig.Name, extensionConfig.Spec.ClientConfig.Authentication)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover extension %q", extensionConfig.Name)
+	}
+
 	request := &runtimehooksv1.DiscoveryRequest{}
 	response := &runtimehooksv1.DiscoveryResponse{}
 	opts := &httpCallOptions{
 		certFile:        c.certFile,
 		keyFile:         c.keyFile,
+		credentials:     creds,
 		catalog:         c.catalog,
 		config:          extensionConfig.Spec.ClientConfig,
 		registrationGVH: hookGVH,
@@ -162,6 +193,9 @@ func (c *client) Register(extensionConfig *runtimev1.ExtensionConfig) error {
 	if err := c.registry.Add(extensionConfig); err != nil {
 		return errors.Wrapf(err, "failed to register ExtensionConfig %q", extensionConfig.Name)
 	}
+	if !extensionConfig.Spec.CircuitBreaker.IsDefined() {
+		c.circuitBreakers.Remove(extensionConfig.Name)
+	}
 	return nil
 }
 
@@ -169,6 +203,9 @@ func (c *client) Unregister(extensionConfig *runtimev1.ExtensionConfig) error {
 	if err := c.registry.Remove(extensionConfig); err != nil {
 		return errors.Wrapf(err, "failed to unregister ExtensionConfig %q", extensionConfig.Name)
 	}
+	c.credentials.Remove(extensionConfig.Name)
+	c.circuitBreakers.Remove(extensionConfig.Name)
+	c.concurrencyLimiters.Remove(extensionConfig.Name)
 	return nil
 }
 
@@ -199,16 +236,15 @@ func (c *client) CallAllExtensions(ctx context.Context, hook runtimecatalog.Hook
 		return errors.Wrapf(err, "failed to call extension handlers for hook %q", gvh.GroupHook())
 	}
 
-	log.V(4).Info(fmt.Sprintf("Calling all extensions of hook %q", hookName))
-	responses := []runtimehooksv1.ResponseObject{}
-	for _, registration := range registrations {
-		// Creates a new instance of the response parameter.
-		responseObject, err := c.catalog.NewResponse(gvh)
-		if err != nil {
-			return errors.Wrapf(err, "failed to call extension handlers for hook %q: failed to call extension handler %q", gvh.GroupHook(), registration.Name)
-		}
-		tmpResponse := responseObject.(runtimehooksv1.ResponseObject)
+	// Sort registrations by name, so the order of the calls for serial hooks and the aggregation of
+	// the responses are deterministic.
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
 
+	// Filter out registrations for which the object namespace isn't matched by the NamespaceSelector.
+	matchingRegistrations := []*runtimeregistry.ExtensionRegistration{}
+	for _, registration := range registrations {
 		// Compute whether the object the call is being made for matches the namespaceSelector
 		namespaceMatches, err := c.matchNamespace(ctx, registration.NamespaceSelector, forObject.GetNamespace())
 		if err != nil {
@@ -219,14 +255,53 @@ func (c *client) CallAllExtensions(ctx context.Context, hook runtimecatalog.Hook
 			log.V(5).Info(fmt.Sprintf("skipping extension handler %q as object '%s/%s' does not match selector %q of ExtensionConfig", registration.Name, forObject.GetNamespace(), forObject.GetName(), registration.NamespaceSelector))
 			continue
 		}
+		matchingRegistrations = append(matchingRegistrations, registration)
+	}
 
-		err = c.CallExtension(ctx, hook, forObject, registration.Name, request, tmpResponse)
-		// If one of the extension handlers fails lets short-circuit here and return early.
+	// Creates a new instance of the response parameter for each call.
+	responses := make([]runtimehooksv1.ResponseObject, len(matchingRegistrations))
+	for i, registration := range matchingRegistrations {
+		responseObject, err := c.catalog.NewResponse(gvh)
 		if err != nil {
-			log.Error(err, "failed to call extension handlers")
-			return errors.Wrapf(err, "failed to call extension handlers for hook %q", gvh.GroupHook())
+			return errors.Wrapf(err, "failed to call extension handlers for hook %q: failed to call extension handler %q", gvh.GroupHook(), registration.Name)
+		}
+		responses[i] = responseObject.(runtimehooksv1.ResponseObject)
+	}
+
+	if c.catalog.IsHookSerial(gvh) {
+		log.V(4).Info(fmt.Sprintf("Calling all extensions of hook %q serially", hookName))
+		for i, registration := range matchingRegistrations {
+			// If one of the extension handlers fails lets short-circuit here and return early.
+			if err := c.CallExtension(ctx, hook, forObject, registration.Name, request, responses[i]); err != nil {
+				log.Error(err, "failed to call extension handlers")
+				return errors.Wrapf(err, "failed to call extension handlers for hook %q", gvh.GroupHook())
+			}
+		}
+	} else {
+		log.V(4).Info(fmt.Sprintf("Calling all extensions of hook %q", hookName))
+		errs := make([]error, len(matchingRegistrations))
+		slots := make(chan struct{}, c.maxParallelCalls)
+		wg := sync.WaitGroup{}
+		for i, registration := range matchingRegistrations {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+				errs[i] = c.CallExtension(ctx, hook, forObject, registration.Name, request, responses[i])
+			}()
+		}
+		wg.Wait()
+
+		// If one of the extension handlers failed return the error of the first one in order of names.
+		for _, err := range errs {
+			if err != nil {
+				log.Error(err, "failed to call extension handlers")
+				return errors.Wrapf(err, "failed to call extension handlers for hook %q", gvh.GroupHook())
+			}
 		}
-		responses = append(responses, tmpResponse)
 	}
 
 	// Aggregate all responses into a single response.
@@ -237,6 +312,7 @@ func (c *client) CallAllExtensions(ctx context.Context, hook runtimecatalog.Hook
 }
 
 // aggregateSuccessfulResponses aggregates all successful responses into a single response.
+// RetryAfterSeconds is set to the lowest non-zero value, messages are joined in the order of the responses.
 func aggregateSuccessfulResponses(aggregatedResponse runtimehooksv1.ResponseObject, responses []runtimehooksv1.ResponseObject) {
 	// At this point the Status should always be ResponseStatusSuccess.
 	aggregatedResponse.SetStatus(runtimehooksv1.ResponseStatusSuccess)
@@ -336,9 +412,16 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 		}
 	}
 
+	creds, err := c.credentials.Get(ctx, registration.ExtensionConfigName, registration.ClientConfig.Authentication)
+	if err != nil {
+		log.Error(err, "Failed to get credentials for extension handler")
+		return errors.Wrapf(err, "failed to call extension handler %q", name)
+	}
+
 	httpOpts := &httpCallOptions{
 		certFile:        c.certFile,
 		keyFile:         c.keyFile,
+		credentials:     creds,
 		catalog:         c.catalog,
 		config:          registration.ClientConfig,
 		registrationGVH: registration.GroupVersionHook,
@@ -346,7 +429,10 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 		name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
 		timeout:         timeoutDuration,
 	}
-	err = httpCall(ctx, request, response, httpOpts)
+	err = c.callWithLimits(ctx, registration, hookGVH, timeoutDuration, func(timeout time.Duration) error {
+		httpOpts.timeout = timeout
+		return httpCall(ctx, request, response, httpOpts)
+	})
 	if err != nil {
 		// If the error is errCallingExtensionHandler then apply failure policy to calculate
 		// the effective result of the operation.
@@ -388,6 +474,47 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 	return nil
 }
 
+// callWithLimits calls an Extension honouring the circuit breaker and the concurrency limit of the corresponding ExtensionConfig.
+// The time spent waiting for a concurrency slot is taken out of timeout, and call is called with the remaining timeout.
+// Note: Only the result of the call is recorded by the circuit breaker; timing out while waiting for a concurrency slot
+// means the Extension is saturated, not that it is failing.
+func (c *client) callWithLimits(ctx context.Context, registration *runtimeregistry.ExtensionRegistration, hookGVH runtimecatalog.GroupVersionHook, timeout time.Duration, call func(timeout time.Duration) error) error {
+	allowed, probe := c.circuitBreakers.Allow(registration.ExtensionConfigName, registration.CircuitBreaker)
+	if !allowed {
+		runtimemetrics.ShortCircuitedRequestsTotal.Observe(registration.ExtensionConfigName, hookGVH)
+		return errCallingExtensionHandler(errors.Errorf("http call failed: circuit breaker for ExtensionConfig %q is open", registration.ExtensionConfigName))
+	}
+
+	start := time.Now()
+	release, err := c.concurrencyLimiters.Acquire(ctx, registration.ExtensionConfigName, registration.MaxConcurrentCalls, timeout)
+	if err != nil {
+		c.circuitBreakers.Skip(registration.ExtensionConfigName, probe)
+		return errCallingExtensionHandler(errors.Wrap(err, "http call failed"))
+	}
+	defer release()
+
+	remaining := timeout - time.Since(start)
+	if remaining <= 0 {
+		c.circuitBreakers.Skip(registration.ExtensionConfigName, probe)
+		return errCallingExtensionHandler(errors.Errorf("http call failed: timed out waiting for a concurrent call to ExtensionConfig %q to complete", registration.ExtensionConfigName))
+	}
+
+	err = call(remaining)
+	c.circuitBreakers.Record(registration.ExtensionConfigName, registration.CircuitBreaker, probe, err != nil)
+	return err
+}
+
+// GetCircuitBreakerState returns the state of the circuit breaker for calls to the Extension of the ExtensionConfig.
+func (c *client) GetCircuitBreakerState(extensionConfigName string) runtimeclient.CircuitBreakerState {
+	return c.circuitBreakers.State(extensionConfigName)
+}
+
+// GetCircuitBreakerSource returns a Source of events for ExtensionConfigs, which is triggered every time
+// the circuit breaker for calls to the corresponding Extension opens or closes.
+func (c *client) GetCircuitBreakerSource() source.Source {
+	return c.circuitBreakers.Source()
+}
+
 // cloneAndAddSettings creates a new request object and adds settings to it.
 func cloneAndAddSettings(request runtimehooksv1.RequestObject, registrationSettings map[string]string) runtimehooksv1.RequestObject {
 	// Merge the settings from registration with the settings in the request.
@@ -408,6 +535,7 @@ func cloneAndAddSettings(request runtimehooksv1.RequestObject, registrationSetti
 type httpCallOptions struct {
 	certFile        string
 	keyFile         string
+	credentials     *credentials
 	catalog         *runtimecatalog.Catalog
 	config          runtimev1.ClientConfig
 	registrationGVH runtimecatalog.GroupVersionHook
@@ -492,15 +620,27 @@ func httpCall(ctx context.Context, request, response runtime.Object, opts *httpC
 		return errors.Wrap(err, "http call failed: failed to create http request")
 	}
 
+	tlsOpts := transport.TLSConfig{
+		CertFile:   opts.certFile,
+		KeyFile:    opts.keyFile,
+		CAData:     opts.config.CABundle,
+		ServerName: extensionURL.Hostname(),
+	}
+	// Credentials defined for the ExtensionConfig take precedence over the client certificate of the controller.
+	if opts.credentials != nil {
+		if len(opts.credentials.certData) > 0 {
+			tlsOpts.CertFile, tlsOpts.KeyFile = "", ""
+			tlsOpts.CertData, tlsOpts.KeyData = opts.credentials.certData, opts.credentials.keyData
+		}
+		if opts.credentials.token != "" {
+			httpRequest.Header.Set("Authorization", "Bearer "+opts.credentials.token)
+		}
+	}
+
 	// Use client-go's transport.TLSConfigureFor to ensure good defaults for tls
 	client := http.DefaultClient
 	tlsConfig, err := transport.TLSConfigFor(&transport.Config{
-		TLS: transport.TLSConfig{
-			CertFile:   opts.certFile,
-			KeyFile:    opts.keyFile,
-			CAData:     opts.config.CABundle,
-			ServerName: extensionURL.Hostname(),
-		},
+		TLS: tlsOpts,
 	})
 	if err != nil {
 		return errors.Wrap(err, "http call failed: failed to create tls config")
@@ -25,12 +25,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -939,6 +944,313 @@ func TestClient_CallExtensionWithClientAuthentication(t *testing.T) {
 	g.Expect(serverCallCount).To(Equal(1))
 }
 
+func TestClient_CallExtensionWithExtensionConfigAuthentication(t *testing.T) {
+	ns := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	clientCertSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "client-cert",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       testcerts.ClientCert,
+			corev1.TLSPrivateKeyKey: testcerts.ClientKey,
+		},
+	}
+
+	extensionConfig := runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "extension",
+			ResourceVersion: "15",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				// Set a fake URL, in test cases where we start the test server the URL will be overridden.
+				URL:      "https://127.0.0.1/",
+				CABundle: testcerts.CACert,
+			},
+			NamespaceSelector: &metav1.LabelSelector{},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{
+				{
+					Name: "valid-extension.extension",
+					RequestHook: runtimev1.GroupVersionHook{
+						APIVersion: fakev1alpha1.GroupVersion.String(),
+						Hook:       "FakeHook",
+					},
+					TimeoutSeconds: 1,
+					FailurePolicy:  runtimev1.FailurePolicyFail,
+				},
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(filepath.Join(tmpDir, "extension.example.com"), []byte("test-token\n"), 0600)).To(Succeed())
+
+	tests := []struct {
+		name              string
+		authentication    runtimev1.ClientAuthentication
+		requireClientCert bool
+		wantToken         string
+		wantErr           bool
+	}{
+		{
+			name: "should use the client certificate from the Secret",
+			authentication: runtimev1.ClientAuthentication{
+				ClientCertificate: runtimev1.ClientCertificateAuthentication{
+					SecretRef: runtimev1.SecretReference{Namespace: "foo", Name: "client-cert"},
+				},
+			},
+			requireClientCert: true,
+		},
+		{
+			name: "should fail if the client certificate Secret does not exist",
+			authentication: runtimev1.ClientAuthentication{
+				ClientCertificate: runtimev1.ClientCertificateAuthentication{
+					SecretRef: runtimev1.SecretReference{Namespace: "foo", Name: "does-not-exist"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should send the ServiceAccount token for the audience",
+			authentication: runtimev1.ClientAuthentication{
+				ServiceAccountToken: runtimev1.ServiceAccountTokenAuthentication{
+					Audience: "extension.example.com",
+				},
+			},
+			wantToken: "test-token",
+		},
+		{
+			name: "should fail if there is no ServiceAccount token for the audience",
+			authentication: runtimev1.ClientAuthentication{
+				ServiceAccountToken: runtimev1.ServiceAccountTokenAuthentication{
+					Audience: "other.example.com",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var gotToken string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				gotToken = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+				respBody, err := json.Marshal(response(runtimehooksv1.ResponseStatusSuccess).response)
+				if err != nil {
+					panic(err)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(respBody)
+			})
+			srv := newUnstartedTLSServer(mux)
+			if tt.requireClientCert {
+				// Setup the runtime extension server so it requires client authentication with certificates signed by a given CA.
+				certpool := x509.NewCertPool()
+				certpool.AppendCertsFromPEM(testcerts.CACert)
+				srv.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+				srv.TLS.ClientCAs = certpool
+			}
+			srv.StartTLS()
+			defer srv.Close()
+
+			extensionConfig := extensionConfig.DeepCopy()
+			extensionConfig.Spec.ClientConfig.URL = fmt.Sprintf("https://%s/", srv.Listener.Addr().String())
+			extensionConfig.Spec.ClientConfig.Authentication = tt.authentication
+
+			cat := runtimecatalog.New()
+			_ = fakev1alpha1.AddToCatalog(cat)
+			c := New(Options{
+				TokenDir: tmpDir,
+				Catalog:  cat,
+				Registry: registry([]runtimev1.ExtensionConfig{*extensionConfig}),
+				Client:   fake.NewClientBuilder().WithObjects(ns, clientCertSecret).Build(),
+			})
+
+			obj := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: "foo",
+				},
+			}
+			err := c.CallExtension(context.Background(), fakev1alpha1.FakeHook, obj, "valid-extension.extension", &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(gotToken).To(Equal(tt.wantToken))
+		})
+	}
+}
+
+func TestClient_CallExtensionWithCircuitBreaker(t *testing.T) {
+	g := NewWithT(t)
+
+	ns := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	var serverCallCount int
+	srv := createSecureTestServer(testServerConfig{
+		responses: map[string]testServerResponse{
+			"/*": {
+				response:           &fakev1alpha1.FakeResponse{},
+				responseStatusCode: http.StatusInternalServerError,
+			},
+		},
+	}, func() {
+		serverCallCount++
+	})
+	srv.StartTLS()
+	defer srv.Close()
+
+	extensionConfig := runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "extension",
+			ResourceVersion: "15",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				URL:      fmt.Sprintf("https://%s/", srv.Listener.Addr().String()),
+				CABundle: testcerts.CACert,
+			},
+			NamespaceSelector:  &metav1.LabelSelector{},
+			MaxConcurrentCalls: 1,
+			CircuitBreaker: runtimev1.CircuitBreaker{
+				FailureThreshold: 2,
+				OpenSeconds:      ptr.To[int32](300),
+			},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{
+				{
+					Name: "fail-extension.extension",
+					RequestHook: runtimev1.GroupVersionHook{
+						APIVersion: fakev1alpha1.GroupVersion.String(),
+						Hook:       "FakeHook",
+					},
+					TimeoutSeconds: 1,
+					FailurePolicy:  runtimev1.FailurePolicyFail,
+				},
+				{
+					Name: "ignore-extension.extension",
+					RequestHook: runtimev1.GroupVersionHook{
+						APIVersion: fakev1alpha1.GroupVersion.String(),
+						Hook:       "FakeHook",
+					},
+					TimeoutSeconds: 1,
+					FailurePolicy:  runtimev1.FailurePolicyIgnore,
+				},
+			},
+		},
+	}
+
+	cat := runtimecatalog.New()
+	_ = fakev1alpha1.AddToCatalog(cat)
+	c := New(Options{
+		Catalog:  cat,
+		Registry: registry([]runtimev1.ExtensionConfig{extensionConfig}),
+		Client:   fake.NewClientBuilder().WithObjects(ns).Build(),
+	})
+
+	obj := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "foo",
+		},
+	}
+
+	// Calls fail until the circuit is opened after two consecutive failures.
+	for range 2 {
+		err := c.CallExtension(context.Background(), fakev1alpha1.FakeHook, obj, "fail-extension.extension", &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{})
+		g.Expect(err).To(HaveOccurred())
+	}
+	g.Expect(serverCallCount).To(Equal(2))
+	g.Expect(c.GetCircuitBreakerState("extension").Open).To(BeTrue())
+
+	// Calls are not sent to the Extension while the circuit is open, and the failure policy applies.
+	err := c.CallExtension(context.Background(), fakev1alpha1.FakeHook, obj, "fail-extension.extension", &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{})
+	g.Expect(err).To(HaveOccurred())
+	err = c.CallExtension(context.Background(), fakev1alpha1.FakeHook, obj, "ignore-extension.extension", &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(serverCallCount).To(Equal(2))
+
+	// Unregistering the ExtensionConfig resets the circuit breaker.
+	g.Expect(c.Unregister(&extensionConfig)).To(Succeed())
+	g.Expect(c.GetCircuitBreakerState("extension").Open).To(BeFalse())
+}
+
+func TestClient_callWithLimits(t *testing.T) {
+	registration := &runtimeregistry.ExtensionRegistration{
+		ExtensionConfigName: "extension",
+		MaxConcurrentCalls:  1,
+		CircuitBreaker: runtimev1.CircuitBreaker{
+			FailureThreshold: 1,
+		},
+	}
+
+	t.Run("does not record a timeout waiting for a concurrency slot as a failure", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := New(Options{}).(*client)
+		release, err := c.concurrencyLimiters.Acquire(context.Background(), "extension", 1, time.Second)
+		g.Expect(err).ToNot(HaveOccurred())
+		defer release()
+
+		called := false
+		err = c.callWithLimits(context.Background(), registration, runtimecatalog.GroupVersionHook{}, 100*time.Millisecond, func(time.Duration) error {
+			called = true
+			return nil
+		})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(called).To(BeFalse())
+		g.Expect(c.GetCircuitBreakerState("extension").ConsecutiveFailures).To(Equal(int32(0)))
+		g.Expect(c.GetCircuitBreakerState("extension").Open).To(BeFalse())
+	})
+
+	t.Run("takes the time waiting for a concurrency slot out of the timeout", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := New(Options{}).(*client)
+		release, err := c.concurrencyLimiters.Acquire(context.Background(), "extension", 1, time.Second)
+		g.Expect(err).ToNot(HaveOccurred())
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			release()
+		}()
+
+		var gotTimeout time.Duration
+		err = c.callWithLimits(context.Background(), registration, runtimecatalog.GroupVersionHook{}, time.Second, func(timeout time.Duration) error {
+			gotTimeout = timeout
+			return errors.New("call failed")
+		})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(gotTimeout).To(BeNumerically("<=", 800*time.Millisecond))
+		g.Expect(gotTimeout).To(BeNumerically(">", 0))
+		g.Expect(c.GetCircuitBreakerState("extension").Open).To(BeTrue())
+	})
+}
+
 func cacheKeyFunc(extensionName, extensionConfigResourceVersion string, request runtimehooksv1.RequestObject) string {
 	// Note: extensionName is identical to the value of the name parameter passed into CallExtension.
 	s := fmt.Sprintf("%s-%s", extensionName, extensionConfigResourceVersion)
@@ -1231,6 +1543,194 @@ func TestClient_CallAllExtensions(t *testing.T) {
 	}
 }
 
+func TestClient_CallAllExtensionsInParallel(t *testing.T) {
+	ns := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	handler := func(name string) runtimev1.ExtensionHandler {
+		return runtimev1.ExtensionHandler{
+			Name: name,
+			RequestHook: runtimev1.GroupVersionHook{
+				APIVersion: fakev1alpha1.GroupVersion.String(),
+				Hook:       "RetryableFakeHook",
+			},
+			TimeoutSeconds: 5,
+			FailurePolicy:  runtimev1.FailurePolicyFail,
+		}
+	}
+	extensionConfig := runtimev1.ExtensionConfig{
+		Spec: runtimev1.ExtensionConfigSpec{
+			ClientConfig: runtimev1.ClientConfig{
+				// Set a fake URL, the URL will be overridden with the address of the test server.
+				URL:      "https://127.0.0.1/",
+				CABundle: testcerts.CACert,
+			},
+			NamespaceSelector: &metav1.LabelSelector{},
+		},
+		Status: runtimev1.ExtensionConfigStatus{
+			// Note: Handlers are intentionally not sorted by name.
+			Handlers: []runtimev1.ExtensionHandler{
+				handler("c-extension"),
+				handler("a-extension"),
+				handler("d-extension"),
+				handler("b-extension"),
+			},
+		},
+	}
+
+	// Responses of the test server by handler name; the handlers answering first get the highest retryAfterSeconds.
+	responses := map[string]struct {
+		delay             time.Duration
+		status            runtimehooksv1.ResponseStatus
+		retryAfterSeconds int32
+	}{
+		"a-extension": {delay: 400 * time.Millisecond, status: runtimehooksv1.ResponseStatusSuccess, retryAfterSeconds: 5},
+		"b-extension": {delay: 350 * time.Millisecond, status: runtimehooksv1.ResponseStatusSuccess, retryAfterSeconds: 10},
+		"c-extension": {delay: 300 * time.Millisecond, status: runtimehooksv1.ResponseStatusFailure, retryAfterSeconds: 0},
+		"d-extension": {delay: 250 * time.Millisecond, status: runtimehooksv1.ResponseStatusSuccess, retryAfterSeconds: 20},
+	}
+
+	tests := []struct {
+		name              string
+		serial            bool
+		maxParallelCalls  int
+		failing           bool
+		wantMaxInFlight   int
+		wantCalls         []string
+		wantMessage       string
+		wantRetryAfter    int32
+		wantErrorContains string
+	}{
+		{
+			name:             "calls all ExtensionHandlers in parallel and aggregates responses in order of names",
+			maxParallelCalls: 2,
+			wantMaxInFlight:  2,
+			wantCalls:        []string{"a-extension", "b-extension", "c-extension", "d-extension"},
+			wantMessage:      "a-extension, b-extension, c-extension, d-extension",
+			wantRetryAfter:   5,
+		},
+		{
+			name:              "calls all ExtensionHandlers in parallel and returns the error of the first failing ExtensionHandler in order of names",
+			maxParallelCalls:  4,
+			failing:           true,
+			wantMaxInFlight:   4,
+			wantCalls:         []string{"a-extension", "b-extension", "c-extension", "d-extension"},
+			wantErrorContains: "c-extension",
+		},
+		{
+			name:            "calls all ExtensionHandlers serially in order of names for serial hooks",
+			serial:          true,
+			wantMaxInFlight: 1,
+			wantCalls:       []string{"a-extension", "b-extension", "c-extension", "d-extension"},
+			wantMessage:     "a-extension, b-extension, c-extension, d-extension",
+			wantRetryAfter:  5,
+		},
+		{
+			name:              "stops calling ExtensionHandlers after the first failure for serial hooks",
+			serial:            true,
+			failing:           true,
+			wantMaxInFlight:   1,
+			wantCalls:         []string{"a-extension", "b-extension", "c-extension"},
+			wantErrorContains: "c-extension",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			lock := sync.Mutex{}
+			inFlight, maxInFlight := 0, 0
+			calls := []string{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				name := strings.TrimSuffix(path.Base(r.URL.Path), ".")
+				lock.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				calls = append(calls, name)
+				lock.Unlock()
+				defer func() {
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+				}()
+
+				resp := responses[name]
+				time.Sleep(resp.delay)
+				status := resp.status
+				if !tt.failing {
+					status = runtimehooksv1.ResponseStatusSuccess
+				}
+				respBody, err := json.Marshal(&fakev1alpha1.RetryableFakeResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{
+						Status:  status,
+						Message: name,
+					},
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						RetryAfterSeconds: resp.retryAfterSeconds,
+					},
+				})
+				if err != nil {
+					panic(err)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(respBody)
+			})
+			srv := newUnstartedTLSServer(mux)
+			srv.StartTLS()
+			defer srv.Close()
+
+			config := extensionConfig.DeepCopy()
+			config.Spec.ClientConfig.URL = fmt.Sprintf("https://%s/", srv.Listener.Addr().String())
+
+			cat := runtimecatalog.New()
+			cat.AddHook(fakev1alpha1.GroupVersion, fakev1alpha1.RetryableFakeHook, &runtimecatalog.HookMeta{
+				Serial: tt.serial,
+			})
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(ns).
+				Build()
+			c := New(Options{
+				MaxParallelCalls: tt.maxParallelCalls,
+				Catalog:          cat,
+				Registry:         registry([]runtimev1.ExtensionConfig{*config}),
+				Client:           fakeClient,
+			})
+
+			obj := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: "foo",
+				},
+			}
+			response := &fakev1alpha1.RetryableFakeResponse{}
+			err := c.CallAllExtensions(context.Background(), fakev1alpha1.RetryableFakeHook, obj, &fakev1alpha1.RetryableFakeRequest{}, response)
+			if tt.wantErrorContains != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErrorContains))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(response.GetMessage()).To(Equal(tt.wantMessage))
+				g.Expect(response.GetRetryAfterSeconds()).To(Equal(tt.wantRetryAfter))
+			}
+
+			g.Expect(maxInFlight).To(Equal(tt.wantMaxInFlight))
+			if tt.serial {
+				g.Expect(calls).To(Equal(tt.wantCalls))
+			} else {
+				g.Expect(calls).To(ConsistOf(tt.wantCalls))
+			}
+		})
+	}
+}
+
 func Test_client_matchNamespace(t *testing.T) {
 	g := NewWithT(t)
 	foo := &corev1.Namespace{
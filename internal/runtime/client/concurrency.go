@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runtimemetrics "sigs.k8s.io/cluster-api/internal/runtime/metrics"
+)
+
+// concurrencyLimiter limits the number of concurrent calls to an Extension.
+type concurrencyLimiter struct {
+	limit int32
+	slots chan struct{}
+}
+
+// concurrencyLimiters are the concurrency limiters for calls to Extensions by ExtensionConfig name.
+type concurrencyLimiters struct {
+	// lock is used to synchronize access to items.
+	lock sync.Mutex
+	// items contains the concurrency limiters by ExtensionConfig name.
+	items map[string]*concurrencyLimiter
+}
+
+func newConcurrencyLimiters() *concurrencyLimiters {
+	return &concurrencyLimiters{
+		items: map[string]*concurrencyLimiter{},
+	}
+}
+
+// Acquire waits until a call to the Extension of an ExtensionConfig is allowed by the concurrency limit,
+// or until timeout expires. It returns a func to be called once the call is completed.
+func (l *concurrencyLimiters) Acquire(ctx context.Context, extensionConfigName string, limit int32, timeout time.Duration) (func(), error) {
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	slots := l.slots(extensionConfigName, limit)
+
+	start := time.Now()
+	defer func() {
+		runtimemetrics.ConcurrencyLimitWaitDuration.Observe(extensionConfigName, time.Since(start))
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, errors.Errorf("timed out waiting for one of the %d concurrent calls to ExtensionConfig %q to complete", limit, extensionConfigName)
+	}
+}
+
+// Remove removes the concurrency limiter of an ExtensionConfig.
+func (l *concurrencyLimiters) Remove(extensionConfigName string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.items, extensionConfigName)
+}
+
+// slots returns the channel used to track ongoing calls to the Extension of an ExtensionConfig.
+// Note: If the limit changes, a new channel is created; ongoing calls release their slot in the previous channel.
+func (l *concurrencyLimiters) slots(extensionConfigName string, limit int32) chan struct{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.items[extensionConfigName]
+	if !ok || limiter.limit != limit {
+		limiter = &concurrencyLimiter{
+			limit: limit,
+			slots: make(chan struct{}, limit),
+		}
+		l.items[extensionConfigName] = limiter
+	}
+	return limiter.slots
+}
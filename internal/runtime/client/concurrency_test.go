@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestConcurrencyLimiters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("does not limit calls without limit", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l := newConcurrencyLimiters()
+		for range 10 {
+			_, err := l.Acquire(ctx, "extension", 0, time.Millisecond)
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	t.Run("limits concurrent calls", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l := newConcurrencyLimiters()
+		release1, err := l.Acquire(ctx, "extension", 2, time.Millisecond)
+		g.Expect(err).ToNot(HaveOccurred())
+		release2, err := l.Acquire(ctx, "extension", 2, time.Millisecond)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// The limit is reached.
+		_, err = l.Acquire(ctx, "extension", 2, 10*time.Millisecond)
+		g.Expect(err).To(HaveOccurred())
+
+		// The limit is per ExtensionConfig.
+		_, err = l.Acquire(ctx, "other-extension", 2, time.Millisecond)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// Calls are allowed once ongoing calls complete.
+		release1()
+		release3, err := l.Acquire(ctx, "extension", 2, time.Millisecond)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// Waiting calls are allowed once ongoing calls complete.
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			release2()
+		}()
+		_, err = l.Acquire(ctx, "extension", 2, 5*time.Second)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		release3()
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+)
+
+// credentials are the credentials used to authenticate calls to an Extension.
+type credentials struct {
+	// certData and keyData are the PEM encoded client certificate and key, if any.
+	certData []byte
+	keyData  []byte
+
+	// token is the bearer token, if any.
+	token string
+}
+
+// clientCertificate is a client certificate loaded from a Secret.
+type clientCertificate struct {
+	secretRef runtimev1.SecretReference
+	certData  []byte
+	keyData   []byte
+}
+
+// credentialsCache provides the credentials for ExtensionConfigs with authentication.
+// Client certificates are cached per ExtensionConfig and reloaded from the corresponding Secret
+// on every discovery; given that the ExtensionConfig controller triggers discovery when the Secret changes,
+// this ensures that rotated client certificates are picked up.
+// ServiceAccount tokens are read from the token directory on every call, so token rotation done by the kubelet
+// for projected volumes is picked up automatically.
+type credentialsCache struct {
+	client   ctrlclient.Client
+	tokenDir string
+
+	// lock is used to synchronize access to clientCertificates.
+	lock sync.RWMutex
+	// clientCertificates contains the client certificates by ExtensionConfig name.
+	clientCertificates map[string]*clientCertificate
+}
+
+func newCredentialsCache(client ctrlclient.Client, tokenDir string) *credentialsCache {
+	return &credentialsCache{
+		client:             client,
+		tokenDir:           tokenDir,
+		clientCertificates: map[string]*clientCertificate{},
+	}
+}
+
+// Get returns the credentials for an ExtensionConfig, using cached client certificates if possible.
+// Nil is returned if the ExtensionConfig does not define authentication.
+func (c *credentialsCache) Get(ctx context.Context, extensionConfigName string, authentication runtimev1.ClientAuthentication) (*credentials, error) {
+	if authentication.ClientCertificate.IsDefined() {
+		c.lock.RLock()
+		cert, ok := c.clientCertificates[extensionConfigName]
+		c.lock.RUnlock()
+		if ok && cert.secretRef == authentication.ClientCertificate.SecretRef {
+			return &credentials{certData: cert.certData, keyData: cert.keyData}, nil
+		}
+	}
+	return c.Reload(ctx, extensionConfigName, authentication)
+}
+
+// Reload returns the credentials for an ExtensionConfig, reloading client certificates from the corresponding Secret.
+// Nil is returned if the ExtensionConfig does not define authentication.
+func (c *credentialsCache) Reload(ctx context.Context, extensionConfigName string, authentication runtimev1.ClientAuthentication) (*credentials, error) {
+	switch {
+	case authentication.ClientCertificate.IsDefined():
+		cert, err := c.loadClientCertificate(ctx, authentication.ClientCertificate.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		c.lock.Lock()
+		c.clientCertificates[extensionConfigName] = cert
+		c.lock.Unlock()
+		return &credentials{certData: cert.certData, keyData: cert.keyData}, nil
+	case authentication.ServiceAccountToken.IsDefined():
+		c.Remove(extensionConfigName)
+		token, err := c.loadServiceAccountToken(authentication.ServiceAccountToken.Audience)
+		if err != nil {
+			return nil, err
+		}
+		return &credentials{token: token}, nil
+	default:
+		c.Remove(extensionConfigName)
+		return nil, nil
+	}
+}
+
+// Remove removes cached credentials for an ExtensionConfig.
+func (c *credentialsCache) Remove(extensionConfigName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.clientCertificates, extensionConfigName)
+}
+
+func (c *credentialsCache) loadClientCertificate(ctx context.Context, secretRef runtimev1.SecretReference) (*clientCertificate, error) {
+	secret := &corev1.Secret{}
+	key := ctrlclient.ObjectKey{Namespace: secretRef.Namespace, Name: secretRef.Name}
+	if err := c.client.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to load client certificate: failed to get Secret %s", key)
+	}
+
+	certData, keyData := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certData) == 0 || len(keyData) == 0 {
+		return nil, errors.Errorf("failed to load client certificate: Secret %s must contain %q and %q", key, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	if _, err := tls.X509KeyPair(certData, keyData); err != nil {
+		return nil, errors.Wrapf(err, "failed to load client certificate: invalid certificate or key in Secret %s", key)
+	}
+
+	return &clientCertificate{
+		secretRef: secretRef,
+		certData:  certData,
+		keyData:   keyData,
+	}, nil
+}
+
+func (c *credentialsCache) loadServiceAccountToken(audience string) (string, error) {
+	if c.tokenDir == "" {
+		return "", errors.Errorf("failed to load ServiceAccount token for audience %q: token directory is not configured", audience)
+	}
+
+	// Note: the audience must be a DNS subdomain, so it is not possible to read files outside the token directory.
+	if errs := validation.IsDNS1123Subdomain(audience); len(errs) > 0 {
+		return "", errors.Errorf("failed to load ServiceAccount token for audience %q: audience must be a valid DNS subdomain: %s", audience, strings.Join(errs, ", "))
+	}
+	token, err := os.ReadFile(filepath.Join(c.tokenDir, audience))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load ServiceAccount token for audience %q", audience)
+	}
+	token = bytes.TrimSpace(token)
+	if len(token) == 0 {
+		return "", errors.Errorf("failed to load ServiceAccount token for audience %q: token is empty", audience)
+	}
+	return string(token), nil
+}
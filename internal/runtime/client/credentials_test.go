@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/testcerts"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+)
+
+func TestCredentialsCache(t *testing.T) {
+	ctx := context.Background()
+
+	clientCertAuthentication := runtimev1.ClientAuthentication{
+		ClientCertificate: runtimev1.ClientCertificateAuthentication{
+			SecretRef: runtimev1.SecretReference{Namespace: "foo", Name: "client-cert"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "client-cert",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       testcerts.ClientCert,
+			corev1.TLSPrivateKeyKey: testcerts.ClientKey,
+		},
+	}
+
+	t.Run("returns no credentials without authentication", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCredentialsCache(fake.NewClientBuilder().Build(), "")
+		creds, err := c.Get(ctx, "extension", runtimev1.ClientAuthentication{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds).To(BeNil())
+	})
+
+	t.Run("caches client certificates until reload", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build()
+		c := newCredentialsCache(fakeClient, "")
+
+		creds, err := c.Get(ctx, "extension", clientCertAuthentication)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds.certData).To(Equal(testcerts.ClientCert))
+		g.Expect(creds.keyData).To(Equal(testcerts.ClientKey))
+
+		// Rotate the client certificate.
+		rotated := secret.DeepCopy()
+		rotated.Data[corev1.TLSCertKey] = testcerts.ServerCert
+		rotated.Data[corev1.TLSPrivateKeyKey] = testcerts.ServerKey
+		g.Expect(fakeClient.Update(ctx, rotated)).To(Succeed())
+
+		// Get returns the cached client certificate.
+		creds, err = c.Get(ctx, "extension", clientCertAuthentication)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds.certData).To(Equal(testcerts.ClientCert))
+
+		// Reload picks up the rotated client certificate.
+		creds, err = c.Reload(ctx, "extension", clientCertAuthentication)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds.certData).To(Equal(testcerts.ServerCert))
+		g.Expect(creds.keyData).To(Equal(testcerts.ServerKey))
+
+		c.Remove("extension")
+		g.Expect(c.clientCertificates).To(BeEmpty())
+	})
+
+	t.Run("fails for invalid client certificates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		invalid := secret.DeepCopy()
+		invalid.Data[corev1.TLSPrivateKeyKey] = testcerts.ServerKey
+		c := newCredentialsCache(fake.NewClientBuilder().WithObjects(invalid).Build(), "")
+
+		_, err := c.Get(ctx, "extension", clientCertAuthentication)
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("reads ServiceAccount tokens on every call", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tokenDir := t.TempDir()
+		authentication := runtimev1.ClientAuthentication{
+			ServiceAccountToken: runtimev1.ServiceAccountTokenAuthentication{
+				Audience: "extension.example.com",
+			},
+		}
+		c := newCredentialsCache(fake.NewClientBuilder().Build(), tokenDir)
+
+		_, err := c.Get(ctx, "extension", authentication)
+		g.Expect(err).To(HaveOccurred())
+
+		g.Expect(os.WriteFile(filepath.Join(tokenDir, "extension.example.com"), []byte("token-1"), 0600)).To(Succeed())
+		creds, err := c.Get(ctx, "extension", authentication)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds.token).To(Equal("token-1"))
+
+		// Rotate the token.
+		g.Expect(os.WriteFile(filepath.Join(tokenDir, "extension.example.com"), []byte("token-2"), 0600)).To(Succeed())
+		creds, err = c.Get(ctx, "extension", authentication)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(creds.token).To(Equal("token-2"))
+	})
+
+	t.Run("rejects audiences which are not valid file names", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newCredentialsCache(fake.NewClientBuilder().Build(), t.TempDir())
+		_, err := c.Get(ctx, "extension", runtimev1.ClientAuthentication{
+			ServiceAccountToken: runtimev1.ServiceAccountTokenAuthentication{
+				Audience: "../token",
+			},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
@@ -38,6 +39,7 @@ type RuntimeClientBuilder struct {
 	callAllValidations func(object runtimehooksv1.RequestObject) error
 	callResponses      map[string]runtimehooksv1.ResponseObject
 	callValidations    func(object runtimehooksv1.RequestObject) error
+	circuitBreakers    map[string]runtimeclient.CircuitBreakerState
 }
 
 // NewRuntimeClientBuilder returns a new builder for the fake runtime client.
@@ -75,6 +77,12 @@ func (f *RuntimeClientBuilder) WithCallExtensionValidations(callValidations func
 	return f
 }
 
+// WithCircuitBreakerStates can be used to dictate the circuit breaker states by ExtensionConfig name.
+func (f *RuntimeClientBuilder) WithCircuitBreakerStates(states map[string]runtimeclient.CircuitBreakerState) *RuntimeClientBuilder {
+	f.circuitBreakers = states
+	return f
+}
+
 // MarkReady can be used to mark the fake runtime client as either ready or not ready.
 func (f *RuntimeClientBuilder) MarkReady(ready bool) *RuntimeClientBuilder {
 	f.ready = ready
@@ -90,6 +98,7 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 		callResponses:      f.callResponses,
 		callValidations:    f.callValidations,
 		catalog:            f.catalog,
+		circuitBreakers:    f.circuitBreakers,
 		callAllTracker:     map[string]int{},
 	}
 }
@@ -104,6 +113,7 @@ type RuntimeClient struct {
 	callAllValidations func(object runtimehooksv1.RequestObject) error
 	callResponses      map[string]runtimehooksv1.ResponseObject
 	callValidations    func(object runtimehooksv1.RequestObject) error
+	circuitBreakers    map[string]runtimeclient.CircuitBreakerState
 
 	callAllTracker map[string]int
 }
@@ -193,6 +203,16 @@ func (fc *RuntimeClient) WarmUp(_ *runtimev1.ExtensionConfigList) error {
 	panic("unimplemented")
 }
 
+// GetCircuitBreakerState implements Client.
+func (fc *RuntimeClient) GetCircuitBreakerState(extensionConfigName string) runtimeclient.CircuitBreakerState {
+	return fc.circuitBreakers[extensionConfigName]
+}
+
+// GetCircuitBreakerSource implements Client.
+func (fc *RuntimeClient) GetCircuitBreakerSource() source.Source {
+	panic("unimplemented")
+}
+
 // CallAllCount return the number of times a hook was called.
 func (fc *RuntimeClient) CallAllCount(hook runtimecatalog.Hook) int {
 	return fc.callAllTracker[runtimecatalog.HookName(hook)]
@@ -35,6 +35,9 @@ func init() {
 	// Register the metrics at the controller-runtime metrics registry.
 	ctrlmetrics.Registry.MustRegister(RequestsTotal.metric)
 	ctrlmetrics.Registry.MustRegister(RequestDuration.metric)
+	ctrlmetrics.Registry.MustRegister(CircuitBreakerOpen.metric)
+	ctrlmetrics.Registry.MustRegister(ShortCircuitedRequestsTotal.metric)
+	ctrlmetrics.Registry.MustRegister(ConcurrencyLimitWaitDuration.metric)
 }
 
 // Metrics subsystem and all of the keys used by the Runtime SDK.
@@ -62,6 +65,31 @@ var (
 				4, 5, 6, 8, 10, 15, 20, 30, 45, 60},
 		}, []string{"host", "group", "version", "hook"}),
 	}
+	// CircuitBreakerOpen reports if the circuit breaker for an ExtensionConfig is open.
+	CircuitBreakerOpen = circuitBreakerOpenObserver{
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: runtimeSDKSubsystem,
+			Name:      "circuit_breaker_open",
+			Help:      "Whether the circuit breaker is open (1) or not (0), broken down by ExtensionConfig.",
+		}, []string{"extension_config"}),
+	}
+	// ShortCircuitedRequestsTotal reports requests which have not been sent because the circuit breaker was open.
+	ShortCircuitedRequestsTotal = shortCircuitedRequestsTotalObserver{
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: runtimeSDKSubsystem,
+			Name:      "short_circuited_requests_total",
+			Help:      "Number of requests not sent because the circuit breaker was open, broken down by ExtensionConfig and hook.",
+		}, []string{"extension_config", "group", "version", "hook"}),
+	}
+	// ConcurrencyLimitWaitDuration reports the time requests waited because of the concurrency limit of an ExtensionConfig.
+	ConcurrencyLimitWaitDuration = concurrencyLimitWaitDurationObserver{
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: runtimeSDKSubsystem,
+			Name:      "concurrency_limit_wait_duration_seconds",
+			Help:      "Time in seconds requests waited because of the concurrency limit, broken down by ExtensionConfig.",
+			Buckets:   []float64{0.001, 0.005, 0.025, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+		}, []string{"extension_config"}),
+	}
 )
 
 type requestsTotalObserver struct {
@@ -96,3 +124,39 @@ type requestDurationObserver struct {
 func (m *requestDurationObserver) Observe(gvh runtimecatalog.GroupVersionHook, u url.URL, latency time.Duration) {
 	m.metric.WithLabelValues(u.Host, gvh.Group, gvh.Version, gvh.Hook).Observe(latency.Seconds())
 }
+
+type circuitBreakerOpenObserver struct {
+	metric *prometheus.GaugeVec
+}
+
+// Observe sets the metric for the given ExtensionConfig depending on the circuit breaker being open or not.
+func (m *circuitBreakerOpenObserver) Observe(extensionConfigName string, open bool) {
+	value := 0.0
+	if open {
+		value = 1.0
+	}
+	m.metric.WithLabelValues(extensionConfigName).Set(value)
+}
+
+// Delete deletes the metric for the given ExtensionConfig.
+func (m *circuitBreakerOpenObserver) Delete(extensionConfigName string) {
+	m.metric.DeleteLabelValues(extensionConfigName)
+}
+
+type shortCircuitedRequestsTotalObserver struct {
+	metric *prometheus.CounterVec
+}
+
+// Observe increments the metric for the given ExtensionConfig and gvh.
+func (m *shortCircuitedRequestsTotalObserver) Observe(extensionConfigName string, gvh runtimecatalog.GroupVersionHook) {
+	m.metric.WithLabelValues(extensionConfigName, gvh.Group, gvh.Version, gvh.Hook).Inc()
+}
+
+type concurrencyLimitWaitDurationObserver struct {
+	metric *prometheus.HistogramVec
+}
+
+// Observe observes the time a request waited because of the concurrency limit of the given ExtensionConfig.
+func (m *concurrencyLimitWaitDurationObserver) Observe(extensionConfigName string, wait time.Duration) {
+	m.metric.WithLabelValues(extensionConfigName).Observe(wait.Seconds())
+}
@@ -84,6 +84,12 @@ type ExtensionRegistration struct {
 
 	// Settings captures additional information sent in call to the RuntimeExtensions.
 	Settings map[string]string
+
+	// MaxConcurrentCalls is the maximum number of concurrent calls to the RuntimeExtensions of the ExtensionConfig.
+	MaxConcurrentCalls int32
+
+	// CircuitBreaker is the circuit breaker configuration for calls to the RuntimeExtensions of the ExtensionConfig.
+	CircuitBreaker runtimev1.CircuitBreaker
 }
 
 // extensionRegistry is an implementation of ExtensionRegistry.
@@ -258,11 +264,13 @@ func (r *extensionRegistry) add(extensionConfig *runtimev1.ExtensionConfig) erro
 				Version: gv.Version,
 				Hook:    e.RequestHook.Hook,
 			},
-			NamespaceSelector: selector,
-			ClientConfig:      extensionConfig.Spec.ClientConfig,
-			TimeoutSeconds:    e.TimeoutSeconds,
-			FailurePolicy:     e.FailurePolicy,
-			Settings:          extensionConfig.Spec.Settings,
+			NamespaceSelector:  selector,
+			ClientConfig:       extensionConfig.Spec.ClientConfig,
+			TimeoutSeconds:     e.TimeoutSeconds,
+			FailurePolicy:      e.FailurePolicy,
+			Settings:           extensionConfig.Spec.Settings,
+			MaxConcurrentCalls: extensionConfig.Spec.MaxConcurrentCalls,
+			CircuitBreaker:     extensionConfig.Spec.CircuitBreaker,
 		})
 	}
 
@@ -163,6 +163,7 @@ func ClusterClassTemplateIsValid(templateRef clusterv1.ClusterClassTemplateRefer
 // 3) ControlPlane InfrastructureMachineTemplates are compatible.
 // 4) MachineDeploymentClasses are compatible.
 // 5) MachinePoolClasses are compatible.
+// 6) AddonClasses are compatible.
 func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 	if current == nil {
@@ -190,6 +191,9 @@ func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field
 	// Validate changes to MachinePools.
 	allErrs = append(allErrs, MachinePoolClassesAreCompatible(current, desired)...)
 
+	// Validate changes to add-ons.
+	allErrs = append(allErrs, AddonClassesAreCompatible(current, desired)...)
+
 	return allErrs
 }
 
@@ -275,6 +279,42 @@ func MachinePoolClassesAreUnique(clusterClass *clusterv1.ClusterClass) field.Err
 	return allErrs
 }
 
+// AddonClassesAreCompatible checks if each AddonClass in the new ClusterClass is a compatible change from the previous ClusterClass.
+// It checks if the AddonClass template reference has changed its Group or Kind, because this would change the
+// kind of the add-on objects created for the Clusters.
+func AddonClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, class := range desired.Spec.Addons {
+		for _, oldClass := range current.Spec.Addons {
+			if class.Name == oldClass.Name && class.TemplateRef.IsDefined() && oldClass.TemplateRef.IsDefined() {
+				allErrs = append(allErrs, ClusterClassTemplateAreCompatible(oldClass.TemplateRef, class.TemplateRef,
+					field.NewPath("spec", "addons").Index(i).Child("templateRef"))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// AddonClassesAreUnique checks that no two AddonClasses in a ClusterClass share a name.
+func AddonClassesAreUnique(clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.Set[string]{}
+	for i, class := range clusterClass.Spec.Addons {
+		if names.Has(class.Name) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "addons").Index(i).Child("name"),
+					class.Name,
+					fmt.Sprintf("add-on name must be unique. Add-on with name %q is defined more than once", class.Name),
+				),
+			)
+		}
+		names.Insert(class.Name)
+	}
+	return allErrs
+}
+
 // MachineDeploymentTopologiesAreValidAndDefinedInClusterClass checks that each MachineDeploymentTopology name is not empty
 // and unique, and each class in use is defined in ClusterClass.spec.Workers.MachineDeployments.
 func MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(desired *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
@@ -393,6 +433,13 @@ func ClusterClassTemplatesAreValid(clusterClass *clusterv1.ClusterClass) field.E
 		allErrs = append(allErrs, ClusterClassTemplateIsValid(mpc.Infrastructure.TemplateRef, field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "infrastructure"))...)
 	}
 
+	for i := range clusterClass.Spec.Addons {
+		addon := clusterClass.Spec.Addons[i]
+		if addon.TemplateRef.IsDefined() {
+			allErrs = append(allErrs, ClusterClassTemplateIsValid(addon.TemplateRef, field.NewPath("spec", "addons").Index(i))...)
+		}
+	}
+
 	return allErrs
 }
 
@@ -1140,6 +1140,73 @@ func TestMachinePoolClassesAreUnique(t *testing.T) {
 	}
 }
 
+func TestAddonClassesAreCompatible(t *testing.T) {
+	ref := clusterv1.ClusterClassTemplateReference{
+		APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+		Kind:       "IPPoolTemplate",
+		Name:       "ippool1",
+	}
+	compatibleRef := clusterv1.ClusterClassTemplateReference{
+		APIVersion: "ipam.cluster.x-k8s.io/v1beta3",
+		Kind:       "IPPoolTemplate",
+		Name:       "ippool2",
+	}
+	incompatibleRef := clusterv1.ClusterClassTemplateReference{
+		APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+		Kind:       "GlobalIPPoolTemplate",
+		Name:       "ippool1",
+	}
+
+	tests := []struct {
+		name    string
+		current *clusterv1.ClusterClass
+		desired *clusterv1.ClusterClass
+		wantErr bool
+	}{
+		{
+			name: "pass if the template of an add-on changes name and version",
+			current: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "ippool", TemplateRef: ref}).
+				Build(),
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "ippool", TemplateRef: compatibleRef}).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name: "pass if an add-on is added or removed",
+			current: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "ippool", TemplateRef: ref}).
+				Build(),
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "identity", TemplateRef: incompatibleRef}).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name: "fail if the template of an add-on changes kind",
+			current: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "ippool", TemplateRef: ref}).
+				Build(),
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{Name: "ippool", TemplateRef: incompatibleRef}).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			allErrs := AddonClassesAreCompatible(tt.current, tt.desired)
+			if tt.wantErr {
+				g.Expect(allErrs).ToNot(BeEmpty())
+				return
+			}
+			g.Expect(allErrs).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachineDeploymentTopologiesAreUniqueAndDefinedInClusterClass(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // This is a copy of the constants at k8s.io/apiserver/pkg/storage/names.
@@ -177,3 +178,22 @@ func InfrastructureMachinePoolNamePrefix(clusterName, machinePoolTopologyName st
 func ControlPlaneInfrastructureMachineTemplateNamePrefix(clusterName string) string {
 	return fmt.Sprintf("%s-", clusterName)
 }
+
+// MachineDrainRuleName returns the name of a MachineDrainRule generated from a ClusterClass
+// for the Machines of a MachineDeployment or a MachinePool.
+func MachineDrainRuleName(ownerName, machineDrainRuleClassName string) string {
+	suffix := "-" + machineDrainRuleClassName
+	if len(ownerName)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		ownerName = ownerName[:validation.DNS1123SubdomainMaxLength-len(suffix)]
+	}
+	return ownerName + suffix
+}
+
+// AddonName returns the name of an add-on object generated from a ClusterClass for a Cluster.
+func AddonName(clusterName, addonName string) string {
+	suffix := "-" + addonName
+	if len(clusterName)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		clusterName = clusterName[:validation.DNS1123SubdomainMaxLength-len(suffix)]
+	}
+	return clusterName + suffix
+}
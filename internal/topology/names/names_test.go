@@ -18,6 +18,7 @@ package names
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -90,3 +91,23 @@ func Test_templateGenerator_GenerateName(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineDrainRuleName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(MachineDrainRuleName("md1", "skip-logging")).To(Equal("md1-skip-logging"))
+
+	name := MachineDrainRuleName(strings.Repeat("a", 253), "skip-logging")
+	g.Expect(name).To(HaveLen(253))
+	g.Expect(name).To(HaveSuffix("-skip-logging"))
+}
+
+func TestAddonName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(AddonName("cluster1", "ip-pool")).To(Equal("cluster1-ip-pool"))
+
+	name := AddonName(strings.Repeat("a", 253), "ip-pool")
+	g.Expect(name).To(HaveLen(253))
+	g.Expect(name).To(HaveSuffix("-ip-pool"))
+}
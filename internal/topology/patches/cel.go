@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patches implements CEL support for ClusterClass inline patches.
+package patches
+
+import (
+	"encoding/json"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel/model"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/version"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/topology/variables"
+)
+
+const (
+	// VariablesCELVariableName is the name of the CEL variable holding the variables defined in the ClusterClass.
+	VariablesCELVariableName = "variables"
+
+	// BuiltinCELVariableName is the name of the CEL variable holding the builtin variables.
+	BuiltinCELVariableName = "builtin"
+
+	// TemplateCELVariableName is the name of the CEL variable holding the template the patch is applied to.
+	// Note: The template can only be used in valueFrom.expression.
+	TemplateCELVariableName = "template"
+)
+
+// CELEnvironment is used to compile and evaluate CEL expressions in ClusterClass inline patches.
+// The `variables` CEL variable is typed using the schemas of the ClusterClass variables, while
+// the `builtin` and `template` CEL variables are dynamically typed.
+type CELEnvironment struct {
+	envType         environment.Type
+	variablesSchema *structuralschema.Structural
+	conditionEnvSet *environment.EnvSet
+	valueEnvSet     *environment.EnvSet
+}
+
+// NewCELEnvironment returns a CELEnvironment for the given ClusterClass variables.
+// environment.NewExpressions should be used to validate new expressions, environment.StoredExpressions
+// to evaluate expressions which have already been validated.
+func NewCELEnvironment(clusterClassVariables []clusterv1.ClusterClassVariable, envType environment.Type) (*CELEnvironment, error) {
+	variablesSchema, err := variables.ToStructuralSchema(clusterClassVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	variablesType := celgo.DynType
+	var declTypes []*apiservercel.DeclType
+	if declType := model.SchemaDeclType(variablesSchema, false); declType != nil {
+		declType = declType.MaybeAssignTypeName("__variables_type__")
+		variablesType = declType.CelType()
+		declTypes = append(declTypes, declType)
+	}
+
+	baseEnvSet := environment.MustBaseEnvSet(variables.GetEnvSetVersion(), true)
+	conditionEnvSet, err := baseEnvSet.Extend(environment.VersionedOptions{
+		IntroducedVersion: version.MajorMinor(1, 0),
+		EnvOptions: []celgo.EnvOption{
+			celgo.Variable(VariablesCELVariableName, variablesType),
+			celgo.Variable(BuiltinCELVariableName, celgo.DynType),
+		},
+		DeclTypes: declTypes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CEL environment")
+	}
+	valueEnvSet, err := conditionEnvSet.Extend(environment.VersionedOptions{
+		IntroducedVersion: version.MajorMinor(1, 0),
+		EnvOptions: []celgo.EnvOption{
+			celgo.Variable(TemplateCELVariableName, celgo.DynType),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CEL environment")
+	}
+
+	return &CELEnvironment{
+		envType:         envType,
+		variablesSchema: variablesSchema,
+		conditionEnvSet: conditionEnvSet,
+		valueEnvSet:     valueEnvSet,
+	}, nil
+}
+
+// CompileCondition compiles and type checks a CEL expression used in enabledIfExpression.
+// The expression must evaluate to a bool.
+func (e *CELEnvironment) CompileCondition(expression string) (celgo.Program, error) {
+	env, err := e.conditionEnvSet.Env(e.envType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get CEL environment")
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, errors.Errorf("compilation failed: %s", issues.String())
+	}
+	if ast.OutputType() != celgo.BoolType && ast.OutputType() != celgo.DynType {
+		return nil, errors.Errorf("expression must evaluate to a bool, but evaluates to %s", ast.OutputType())
+	}
+	return program(env, ast)
+}
+
+// CompileValue compiles and type checks a CEL expression used in valueFrom.expression.
+func (e *CELEnvironment) CompileValue(expression string) (celgo.Program, error) {
+	env, err := e.valueEnvSet.Env(e.envType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get CEL environment")
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, errors.Errorf("compilation failed: %s", issues.String())
+	}
+	return program(env, ast)
+}
+
+func program(env *celgo.Env, ast *celgo.Ast) (celgo.Program, error) {
+	prg, err := env.Program(ast,
+		celgo.CostLimit(celconfig.PerCallLimit),
+		celgo.InterruptCheckFrequency(celconfig.CheckFrequency),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "program instantiation failed")
+	}
+	return prg, nil
+}
+
+// EvaluateCondition evaluates a program compiled with CompileCondition.
+func (e *CELEnvironment) EvaluateCondition(prg celgo.Program, vars map[string]apiextensionsv1.JSON) (bool, error) {
+	activation, err := e.activation(vars, nil)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(activation)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to evaluate expression")
+	}
+	enabled, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf("expression must evaluate to a bool, but evaluates to %s", out.Type().TypeName())
+	}
+	return enabled, nil
+}
+
+// EvaluateValue evaluates a program compiled with CompileValue and returns the result as JSON.
+func (e *CELEnvironment) EvaluateValue(prg celgo.Program, vars map[string]apiextensionsv1.JSON, template map[string]interface{}) (*apiextensionsv1.JSON, error) {
+	activation, err := e.activation(vars, template)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := prg.Eval(activation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate expression")
+	}
+	value, err := toUnstructured(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert result of expression")
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal result of expression")
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// activation returns the values for the CEL variables.
+// Note: Variables are converted using their schemas, so e.g. integer and number variables
+// are consistently represented as int and double.
+func (e *CELEnvironment) activation(vars map[string]apiextensionsv1.JSON, template map[string]interface{}) (map[string]interface{}, error) {
+	variableValues := map[string]interface{}{}
+	var builtinValue interface{} = map[string]interface{}{}
+	for name, value := range vars {
+		var v interface{}
+		if err := utiljson.Unmarshal(value.Raw, &v); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal variable %q", name)
+		}
+		if name == BuiltinCELVariableName {
+			builtinValue = v
+			continue
+		}
+		if _, ok := e.variablesSchema.Properties[name]; ok {
+			variableValues[name] = v
+		}
+	}
+
+	activation := map[string]interface{}{
+		VariablesCELVariableName: cel.UnstructuredToVal(variableValues, e.variablesSchema),
+		BuiltinCELVariableName:   builtinValue,
+	}
+	if template != nil {
+		activation[TemplateCELVariableName] = template
+	}
+	return activation, nil
+}
+
+// toUnstructured converts a CEL value to its unstructured representation.
+func toUnstructured(val ref.Val) (interface{}, error) {
+	// Values backed by unstructured data, e.g. variables or the template, are returned as is.
+	switch v := val.Value().(type) {
+	case map[string]interface{}, []interface{}:
+		return v, nil
+	}
+
+	switch v := val.(type) {
+	case types.Null:
+		return nil, nil
+	case traits.Mapper:
+		res := map[string]interface{}{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			k, ok := key.Value().(string)
+			if !ok {
+				return nil, errors.Errorf("map keys must be strings, got %s", key.Type().TypeName())
+			}
+			value, err := toUnstructured(v.Get(key))
+			if err != nil {
+				return nil, err
+			}
+			res[k] = value
+		}
+		return res, nil
+	case traits.Lister:
+		res := []interface{}{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			value, err := toUnstructured(it.Next())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, value)
+		}
+		return res, nil
+	case types.Bool, types.Int, types.Uint, types.Double, types.String:
+		return v.Value(), nil
+	}
+	return nil, errors.Errorf("unsupported type %s", val.Type().TypeName())
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package revision implements utils for ClusterClassRevisions.
+package revision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+// UnusedSinceAnnotation is set on ClusterClassRevisions which are not current and not pinned by any Cluster;
+// its value is the time at which the ClusterClassRevision has been found unused for the first time.
+const UnusedSinceAnnotation = "topology.cluster.x-k8s.io/revision-unused-since"
+
+// Compute returns the revision of a ClusterClass, i.e. a hash of its spec and of its variables.
+// Note: variables are read from the ClusterClass status so the revision includes variables discovered
+// from external patches.
+func Compute(clusterClass *clusterv1.ClusterClass) (string, error) {
+	content := struct {
+		Spec      clusterv1.ClusterClassSpec             `json:"spec"`
+		Variables []clusterv1.ClusterClassStatusVariable `json:"variables"`
+	}{
+		Spec:      clusterClass.Spec,
+		Variables: clusterClass.Status.Variables,
+	}
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute revision of ClusterClass %s", clusterClass.Name)
+	}
+
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(b)
+	return fmt.Sprintf("%08x", hasher.Sum32()), nil
+}
+
+// Name returns the name of the ClusterClassRevision for a revision of a ClusterClass.
+func Name(clusterClassName, revision string) string {
+	suffix := "-" + revision
+	if len(clusterClassName)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		clusterClassName = clusterClassName[:validation.DNS1123SubdomainMaxLength-len(suffix)]
+	}
+	return clusterClassName + suffix
+}
+
+// New returns a ClusterClassRevision capturing the current spec and variables of a ClusterClass.
+func New(clusterClass *clusterv1.ClusterClass, revision string) *clusterv1.ClusterClassRevision {
+	variables := make([]clusterv1.ClusterClassStatusVariable, 0, len(clusterClass.Status.Variables))
+	for _, v := range clusterClass.Status.Variables {
+		variables = append(variables, *v.DeepCopy())
+	}
+
+	return &clusterv1.ClusterClassRevision{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "ClusterClassRevision",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterClass.Namespace,
+			Name:      Name(clusterClass.Name, revision),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(clusterClass, clusterv1.GroupVersion.WithKind("ClusterClass")),
+			},
+		},
+		Spec: clusterv1.ClusterClassRevisionSpec{
+			ClusterClassName: clusterClass.Name,
+			Revision:         revision,
+			ClusterClassSpec: *clusterClass.Spec.DeepCopy(),
+			Variables:        variables,
+		},
+	}
+}
+
+// IsPinned returns true if the Cluster pins a revision of its ClusterClass.
+func IsPinned(cluster *clusterv1.Cluster) bool {
+	return feature.Gates.Enabled(feature.ClusterClassRevisions) &&
+		cluster.Spec.Topology.IsDefined() && cluster.Spec.Topology.ClassRef.Revision != ""
+}
+
+// Get returns the ClusterClass as captured by a revision.
+// If the revision matches the current spec and variables of the ClusterClass, the ClusterClass is returned as is.
+func Get(ctx context.Context, c client.Reader, clusterClass *clusterv1.ClusterClass, revision string) (*clusterv1.ClusterClass, error) {
+	current, err := Compute(clusterClass)
+	if err != nil {
+		return nil, err
+	}
+	if revision == current {
+		return clusterClass, nil
+	}
+
+	clusterClassRevision := &clusterv1.ClusterClassRevision{}
+	key := client.ObjectKey{Namespace: clusterClass.Namespace, Name: Name(clusterClass.Name, revision)}
+	if err := c.Get(ctx, key, clusterClassRevision); err != nil {
+		return nil, errors.Wrapf(err, "failed to get revision %s of ClusterClass %s", revision, clusterClass.Name)
+	}
+	if clusterClassRevision.Spec.ClusterClassName != clusterClass.Name || clusterClassRevision.Spec.Revision != revision {
+		return nil, errors.Errorf("ClusterClassRevision %s is not revision %s of ClusterClass %s", clusterClassRevision.Name, revision, clusterClass.Name)
+	}
+	return scope.ClusterClassFromRevision(clusterClass, clusterClassRevision), nil
+}
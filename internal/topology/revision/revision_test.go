@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestCompute(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := newClusterClass()
+	rev, err := Compute(clusterClass)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rev).To(HaveLen(8))
+
+	// The revision does not depend on metadata.
+	clusterClassWithLabels := clusterClass.DeepCopy()
+	clusterClassWithLabels.Labels = map[string]string{"foo": "bar"}
+	g.Expect(Compute(clusterClassWithLabels)).To(Equal(rev))
+
+	// The revision changes if the spec changes.
+	clusterClassWithChangedSpec := clusterClass.DeepCopy()
+	clusterClassWithChangedSpec.Spec.Infrastructure.TemplateRef.Name = "infra2"
+	g.Expect(Compute(clusterClassWithChangedSpec)).ToNot(Equal(rev))
+
+	// The revision changes if the variables change.
+	clusterClassWithChangedVariables := clusterClass.DeepCopy()
+	clusterClassWithChangedVariables.Status.Variables = append(clusterClassWithChangedVariables.Status.Variables, clusterv1.ClusterClassStatusVariable{Name: "var2"})
+	g.Expect(Compute(clusterClassWithChangedVariables)).ToNot(Equal(rev))
+}
+
+func TestName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Name("class1", "0a1b2c3d")).To(Equal("class1-0a1b2c3d"))
+
+	name := Name(strings.Repeat("a", 253), "0a1b2c3d")
+	g.Expect(name).To(HaveLen(253))
+	g.Expect(name).To(HaveSuffix("-0a1b2c3d"))
+}
+
+func TestGet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	clusterClass := newClusterClass()
+	currentRevision, err := Compute(clusterClass)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	oldClusterClass := clusterClass.DeepCopy()
+	oldClusterClass.Spec.Infrastructure.TemplateRef.Name = "infra-old"
+	oldRevision, err := Compute(oldClusterClass)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name            string
+		revision        string
+		objs            []*clusterv1.ClusterClassRevision
+		wantInfraName   string
+		wantErr         bool
+		wantNotFoundErr bool
+	}{
+		{
+			name:          "current revision is returned without reading the ClusterClassRevision",
+			revision:      currentRevision,
+			wantInfraName: "infra1",
+		},
+		{
+			name:          "previous revision is read from the ClusterClassRevision",
+			revision:      oldRevision,
+			objs:          []*clusterv1.ClusterClassRevision{New(oldClusterClass, oldRevision)},
+			wantInfraName: "infra-old",
+		},
+		{
+			name:            "error if the ClusterClassRevision does not exist",
+			revision:        oldRevision,
+			wantErr:         true,
+			wantNotFoundErr: true,
+		},
+		{
+			name:     "error if the ClusterClassRevision is for a different revision",
+			revision: oldRevision,
+			objs: []*clusterv1.ClusterClassRevision{func() *clusterv1.ClusterClassRevision {
+				r := New(oldClusterClass, oldRevision)
+				r.Spec.Revision = "other"
+				return r
+			}()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme)
+			for _, obj := range tt.objs {
+				c = c.WithObjects(obj)
+			}
+
+			got, err := Get(context.Background(), c.Build(), clusterClass, tt.revision)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(apierrors.IsNotFound(err)).To(Equal(tt.wantNotFoundErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Name).To(Equal(clusterClass.Name))
+			g.Expect(got.Spec.Infrastructure.TemplateRef.Name).To(Equal(tt.wantInfraName))
+		})
+	}
+}
+
+func newClusterClass() *clusterv1.ClusterClass {
+	return &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "class1",
+			UID:       "uid1",
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.InfrastructureClass{
+				TemplateRef: clusterv1.ClusterClassTemplateReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+					Kind:       "GenericInfrastructureClusterTemplate",
+					Name:       "infra1",
+				},
+			},
+		},
+		Status: clusterv1.ClusterClassStatus{
+			Variables: []clusterv1.ClusterClassStatusVariable{{Name: "var1"}},
+		},
+	}
+}
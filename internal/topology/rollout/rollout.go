@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rollout implements the progressive rollout of ClusterClass changes in waves.
+package rollout
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// Waves groups the Clusters using a ClusterClass into the waves defined in spec.rollout of the ClusterClass.
+// Note: Waves always contains one more element than spec.rollout.waves, the implicit last wave
+// with all the Clusters not selected by any wave.
+type Waves [][]*clusterv1.Cluster
+
+// ComputeWaves groups the Clusters into the waves defined in rollout.
+// A Cluster is part of the first wave selecting it; the percentage of a wave is applied to
+// the candidate Clusters, i.e. the Clusters matching the selector of the wave and not already part
+// of a previous wave, in order of namespace and name.
+func ComputeWaves(rollout clusterv1.ClusterClassRollout, clusters []*clusterv1.Cluster) (Waves, error) {
+	candidates := make([]*clusterv1.Cluster, len(clusters))
+	copy(candidates, clusters)
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	waves := make(Waves, 0, len(rollout.Waves)+1)
+	for _, wave := range rollout.Waves {
+		selector := labels.Everything()
+		if wave.Selector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(wave.Selector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse selector of wave %q", wave.Name)
+			}
+		}
+
+		matching := 0
+		for _, cluster := range candidates {
+			if selector.Matches(labels.Set(cluster.Labels)) {
+				matching++
+			}
+		}
+		size := matching
+		if wave.Percentage != nil {
+			// Round up so a wave with a percentage always contains at least one of the matching Clusters.
+			size = (matching*int(*wave.Percentage) + 99) / 100
+		}
+
+		selected := []*clusterv1.Cluster{}
+		remaining := []*clusterv1.Cluster{}
+		for _, cluster := range candidates {
+			if len(selected) < size && selector.Matches(labels.Set(cluster.Labels)) {
+				selected = append(selected, cluster)
+				continue
+			}
+			remaining = append(remaining, cluster)
+		}
+
+		waves = append(waves, selected)
+		candidates = remaining
+	}
+	return append(waves, candidates), nil
+}
+
+// WavesStatus returns the assignment of the Clusters to the waves defined in rollout, to be persisted in the
+// ClusterClass status when the rollout of a generation starts.
+// Note: Clusters of the implicit last wave are not persisted.
+func WavesStatus(rollout clusterv1.ClusterClassRollout, waves Waves) []clusterv1.ClusterClassRolloutWaveStatus {
+	status := make([]clusterv1.ClusterClassRolloutWaveStatus, 0, len(rollout.Waves))
+	for i, wave := range rollout.Waves {
+		waveStatus := clusterv1.ClusterClassRolloutWaveStatus{Name: wave.Name}
+		if i < len(waves) {
+			for _, cluster := range waves[i] {
+				waveStatus.Clusters = append(waveStatus.Clusters, cluster.Name)
+			}
+		}
+		status = append(status, waveStatus)
+	}
+	return status
+}
+
+// AssignedWave returns the index of the wave the Cluster has been assigned to when the rollout of the current
+// generation of the ClusterClass started.
+// Note: Clusters not assigned to any of the waves defined in spec.rollout, e.g. Clusters created after the rollout
+// started, are part of the implicit last wave.
+func AssignedWave(clusterClass *clusterv1.ClusterClass, cluster *clusterv1.Cluster) int {
+	if status := clusterClass.Status.Rollout; status != nil && status.Generation == clusterClass.Generation {
+		for i, wave := range status.Waves {
+			if slices.Contains(wave.Clusters, cluster.Name) {
+				return i
+			}
+		}
+	}
+	return len(clusterClass.Spec.Rollout.Waves)
+}
+
+// AssignedWaves groups the Clusters into the waves they have been assigned to when the rollout of the current
+// generation of the ClusterClass started.
+func AssignedWaves(clusterClass *clusterv1.ClusterClass, clusters []*clusterv1.Cluster) Waves {
+	waves := make(Waves, len(clusterClass.Spec.Rollout.Waves)+1)
+	for _, cluster := range clusters {
+		wave := AssignedWave(clusterClass, cluster)
+		waves[wave] = append(waves[wave], cluster)
+	}
+	return waves
+}
+
+// IsEnabled returns true if the rollout of changes to the ClusterClass happens in waves.
+func IsEnabled(clusterClass *clusterv1.ClusterClass) bool {
+	return feature.Gates.Enabled(feature.ClusterClassRollout) && len(clusterClass.Spec.Rollout.Waves) > 0
+}
+
+// IsUpToDate returns true if the Cluster adopted the current generation of the ClusterClass.
+// Note: Clusters reverted to the previous revision of the ClusterClass because the rollout of the current generation
+// has been aborted are not up-to-date.
+func IsUpToDate(clusterClass *clusterv1.ClusterClass, cluster *clusterv1.Cluster) bool {
+	if cluster.Status.Topology == nil || cluster.Status.Topology.ClassGeneration != clusterClass.Generation {
+		return false
+	}
+	revertRevision := RevertRevision(clusterClass)
+	return revertRevision == "" || cluster.Status.Topology.ClassRevision != revertRevision
+}
+
+// Objects are the objects of a Cluster considered to determine if the Cluster is healthy.
+type Objects struct {
+	// ControlPlane is the control plane of the Cluster, if any.
+	ControlPlane *unstructured.Unstructured
+	// ControlPlaneContractVersion is the contract version of the control plane.
+	ControlPlaneContractVersion string
+	// MachineDeployments are the MachineDeployments of the Cluster.
+	MachineDeployments []clusterv1.MachineDeployment
+	// MachinePools are the MachinePools of the Cluster.
+	MachinePools []clusterv1.MachinePool
+}
+
+// IsHealthy returns true if the Cluster adopted the current generation of the ClusterClass, it is Available and
+// not RollingOut, and the control plane, the MachineDeployments and the MachinePools of the Cluster observed their
+// latest generation and all their replicas are up-to-date.
+// Note: Checking objects is required because the conditions of the Cluster might not reflect changes to the
+// topology yet, e.g. if a MachineDeployment has been changed but its controller did not start the rollout yet.
+func IsHealthy(clusterClass *clusterv1.ClusterClass, cluster *clusterv1.Cluster, objects Objects) (bool, error) {
+	if !IsUpToDate(clusterClass, cluster) ||
+		!conditions.IsTrue(cluster, clusterv1.ClusterTopologyReconciledCondition) ||
+		!conditions.IsTrue(cluster, clusterv1.ClusterAvailableCondition) ||
+		conditions.IsTrue(cluster, clusterv1.ClusterRollingOutCondition) {
+		return false, nil
+	}
+
+	if objects.ControlPlane != nil {
+		observedGeneration, err := contract.ControlPlane().ObservedGeneration().Get(objects.ControlPlane)
+		if err != nil {
+			if errors.Is(err, contract.ErrFieldNotFound) {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "failed to get control plane observedGeneration")
+		}
+		if *observedGeneration < objects.ControlPlane.GetGeneration() {
+			return false, nil
+		}
+
+		// Note: Control planes not implementing replicas are only required to observe their latest generation.
+		replicas, err := contract.ControlPlane().Replicas().Get(objects.ControlPlane)
+		if err != nil && !errors.Is(err, contract.ErrFieldNotFound) {
+			return false, errors.Wrap(err, "failed to get control plane replicas")
+		}
+		if replicas != nil {
+			upToDateReplicas, err := contract.ControlPlane().UpToDateReplicas(objects.ControlPlaneContractVersion).Get(objects.ControlPlane)
+			if err != nil {
+				if errors.Is(err, contract.ErrFieldNotFound) {
+					return false, nil
+				}
+				return false, errors.Wrap(err, "failed to get control plane upToDateReplicas")
+			}
+			if *upToDateReplicas != *replicas {
+				return false, nil
+			}
+		}
+	}
+
+	for _, md := range objects.MachineDeployments {
+		if md.Status.ObservedGeneration < md.Generation ||
+			ptr.Deref(md.Status.UpToDateReplicas, 0) != ptr.Deref(md.Spec.Replicas, 0) {
+			return false, nil
+		}
+	}
+
+	for _, mp := range objects.MachinePools {
+		if mp.Status.ObservedGeneration < mp.Generation ||
+			ptr.Deref(mp.Status.UpToDateReplicas, 0) != ptr.Deref(mp.Spec.Replicas, 0) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// IsReleased returns true if the current generation of the ClusterClass can be adopted by the Cluster.
+// This is the case if:
+//   - the rollout of changes to the ClusterClass does not happen in waves.
+//   - the Cluster did not adopt any generation of the ClusterClass yet, e.g. it is a new Cluster.
+//   - the Cluster already adopted the current generation of the ClusterClass.
+//   - the wave the Cluster is part of has been released.
+func IsReleased(clusterClass *clusterv1.ClusterClass, cluster *clusterv1.Cluster, wave int) bool {
+	if !IsEnabled(clusterClass) {
+		return true
+	}
+	if cluster.Status.Topology == nil || cluster.Status.Topology.ClassGeneration == 0 {
+		return true
+	}
+	if IsUpToDate(clusterClass, cluster) {
+		return true
+	}
+	status := clusterClass.Status.Rollout
+	return status != nil && status.Generation == clusterClass.Generation &&
+		status.ReleasedWaves != nil && int(*status.ReleasedWaves) > wave
+}
+
+// IsPaused returns true if the rollout of the ClusterClass is paused.
+func IsPaused(clusterClass *clusterv1.ClusterClass) bool {
+	_, ok := clusterClass.Annotations[clusterv1.ClusterClassRolloutPausedAnnotation]
+	return ok
+}
+
+// IsAborted returns true if the rollout of the current generation of the ClusterClass has been aborted.
+func IsAborted(clusterClass *clusterv1.ClusterClass) bool {
+	abortedGeneration, ok := clusterClass.Annotations[clusterv1.ClusterClassRolloutAbortedAnnotation]
+	return ok && abortedGeneration == strconv.FormatInt(clusterClass.Generation, 10)
+}
+
+// RevertRevision returns the revision Clusters must be reverted to because the rollout of the current generation
+// of the ClusterClass has been aborted, i.e. the revision of the ClusterClass before the current generation.
+// An empty string is returned if the rollout has not been aborted, or if there is nothing to revert.
+func RevertRevision(clusterClass *clusterv1.ClusterClass) string {
+	if !IsAborted(clusterClass) {
+		return ""
+	}
+	status := clusterClass.Status.Rollout
+	if status == nil || status.Generation != clusterClass.Generation || status.PreviousRevision == status.Revision {
+		return ""
+	}
+	return status.PreviousRevision
+}
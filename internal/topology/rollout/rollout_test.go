@@ -0,0 +1,432 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestComputeWaves(t *testing.T) {
+	clusters := []*clusterv1.Cluster{
+		newCluster("ns2", "c1", map[string]string{"env": "prod"}),
+		newCluster("ns1", "c2", map[string]string{"env": "prod"}),
+		newCluster("ns1", "c1", map[string]string{"env": "dev"}),
+		newCluster("ns1", "c3", map[string]string{"env": "prod"}),
+		newCluster("ns1", "c4", nil),
+	}
+
+	tests := []struct {
+		name      string
+		rollout   clusterv1.ClusterClassRollout
+		wantWaves [][]string
+		wantErr   bool
+	}{
+		{
+			name:      "no waves",
+			rollout:   clusterv1.ClusterClassRollout{},
+			wantWaves: [][]string{{"ns1/c1", "ns1/c2", "ns1/c3", "ns1/c4", "ns2/c1"}},
+		},
+		{
+			name: "waves with selectors",
+			rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{
+					{Name: "dev", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+					{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+			},
+			wantWaves: [][]string{{"ns1/c1"}, {"ns1/c2", "ns1/c3", "ns2/c1"}, {"ns1/c4"}},
+		},
+		{
+			name: "waves with percentages",
+			rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{
+					{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}, Percentage: ptr.To[int32](10)},
+					{Name: "half", Percentage: ptr.To[int32](50)},
+				},
+			},
+			wantWaves: [][]string{{"ns1/c2"}, {"ns1/c1", "ns1/c3"}, {"ns1/c4", "ns2/c1"}},
+		},
+		{
+			name: "a Cluster is part of the first wave selecting it",
+			rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{
+					{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+					{Name: "all"},
+				},
+			},
+			wantWaves: [][]string{{"ns1/c2", "ns1/c3", "ns2/c1"}, {"ns1/c1", "ns1/c4"}, {}},
+		},
+		{
+			name: "invalid selector",
+			rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{
+					{Name: "invalid", Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "invalid"}}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			waves, err := ComputeWaves(tt.rollout, clusters)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotWaves := [][]string{}
+			for _, wave := range waves {
+				gotWave := []string{}
+				for _, cluster := range wave {
+					gotWave = append(gotWave, cluster.Namespace+"/"+cluster.Name)
+				}
+				gotWaves = append(gotWaves, gotWave)
+			}
+			g.Expect(gotWaves).To(Equal(tt.wantWaves))
+		})
+	}
+}
+
+func TestAssignedWaves(t *testing.T) {
+	g := NewWithT(t)
+
+	c1 := newCluster("ns1", "c1", map[string]string{"env": "dev"})
+	c2 := newCluster("ns1", "c2", map[string]string{"env": "prod"})
+	c3 := newCluster("ns1", "c3", nil)
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec: clusterv1.ClusterClassSpec{
+			Rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{
+					{Name: "dev", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}},
+					{Name: "prod", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+			},
+		},
+	}
+
+	waves, err := ComputeWaves(clusterClass.Spec.Rollout, []*clusterv1.Cluster{c1, c2, c3})
+	g.Expect(err).ToNot(HaveOccurred())
+	clusterClass.Status.Rollout = &clusterv1.ClusterClassRolloutStatus{
+		Generation: 3,
+		Waves:      WavesStatus(clusterClass.Spec.Rollout, waves),
+	}
+	g.Expect(clusterClass.Status.Rollout.Waves).To(Equal([]clusterv1.ClusterClassRolloutWaveStatus{
+		{Name: "dev", Clusters: []string{"c1"}},
+		{Name: "prod", Clusters: []string{"c2"}},
+	}))
+
+	// Changes to the Clusters after the rollout started do not change the assignment to waves, and
+	// Clusters created after the rollout started are part of the implicit last wave.
+	c1.Labels = map[string]string{"env": "prod"}
+	c4 := newCluster("ns1", "c4", map[string]string{"env": "dev"})
+	g.Expect(AssignedWaves(clusterClass, []*clusterv1.Cluster{c1, c2, c3, c4})).To(Equal(Waves{{c1}, {c2}, {c3, c4}}))
+
+	// The assignment to waves is ignored if it was computed for a previous generation.
+	clusterClass.Generation = 4
+	g.Expect(AssignedWave(clusterClass, c1)).To(Equal(2))
+}
+
+func TestIsReleased(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassRollout, true)
+
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec: clusterv1.ClusterClassSpec{
+			Rollout: clusterv1.ClusterClassRollout{
+				Waves: []clusterv1.ClusterClassRolloutWave{{Name: "canary"}},
+			},
+		},
+		Status: clusterv1.ClusterClassStatus{
+			Rollout: &clusterv1.ClusterClassRolloutStatus{
+				Generation:    3,
+				ReleasedWaves: ptr.To[int32](1),
+			},
+		},
+	}
+	clusterClassWithoutWaves := clusterClass.DeepCopy()
+	clusterClassWithoutWaves.Spec.Rollout = clusterv1.ClusterClassRollout{}
+	clusterClassWithStaleStatus := clusterClass.DeepCopy()
+	clusterClassWithStaleStatus.Status.Rollout.Generation = 2
+
+	tests := []struct {
+		name         string
+		clusterClass *clusterv1.ClusterClass
+		cluster      *clusterv1.Cluster
+		wave         int
+		want         bool
+	}{
+		{
+			name:         "released if there are no waves",
+			clusterClass: clusterClassWithoutWaves,
+			cluster:      withClassGeneration(newCluster("ns1", "c1", nil), 2),
+			wave:         1,
+			want:         true,
+		},
+		{
+			name:         "released if the Cluster did not adopt any generation yet",
+			clusterClass: clusterClass,
+			cluster:      newCluster("ns1", "c1", nil),
+			wave:         1,
+			want:         true,
+		},
+		{
+			name:         "released if the Cluster already adopted the current generation",
+			clusterClass: clusterClass,
+			cluster:      withClassGeneration(newCluster("ns1", "c1", nil), 3),
+			wave:         1,
+			want:         true,
+		},
+		{
+			name:         "released if the wave of the Cluster is released",
+			clusterClass: clusterClass,
+			cluster:      withClassGeneration(newCluster("ns1", "c1", nil), 2),
+			wave:         0,
+			want:         true,
+		},
+		{
+			name:         "not released if the wave of the Cluster is not released",
+			clusterClass: clusterClass,
+			cluster:      withClassGeneration(newCluster("ns1", "c1", nil), 2),
+			wave:         1,
+			want:         false,
+		},
+		{
+			name:         "not released if the rollout status is for a previous generation",
+			clusterClass: clusterClassWithStaleStatus,
+			cluster:      withClassGeneration(newCluster("ns1", "c1", nil), 2),
+			wave:         0,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(IsReleased(tt.clusterClass, tt.cluster, tt.wave)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Status: clusterv1.ClusterClassStatus{
+			Revision: "rev3",
+			Rollout: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       3,
+				Revision:         "rev3",
+				PreviousRevision: "rev2",
+			},
+		},
+	}
+	g.Expect(IsUpToDate(clusterClass, newCluster("ns1", "c1", nil))).To(BeFalse())
+	g.Expect(IsUpToDate(clusterClass, withClassGeneration(newCluster("ns1", "c1", nil), 2))).To(BeFalse())
+	g.Expect(IsUpToDate(clusterClass, withClassGeneration(newCluster("ns1", "c1", nil), 3))).To(BeTrue())
+
+	// Clusters reverted to the previous revision because the rollout has been aborted are not up-to-date.
+	reverted := withClassGeneration(newCluster("ns1", "c1", nil), 3)
+	reverted.Status.Topology.ClassRevision = "rev2"
+	g.Expect(IsUpToDate(clusterClass, reverted)).To(BeTrue())
+	clusterClass.Annotations = map[string]string{clusterv1.ClusterClassRolloutAbortedAnnotation: "3"}
+	g.Expect(IsUpToDate(clusterClass, reverted)).To(BeFalse())
+}
+
+func TestIsHealthy(t *testing.T) {
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+	}
+	healthyCluster := func() *clusterv1.Cluster {
+		cluster := withClassGeneration(newCluster("ns1", "c1", nil), 3)
+		cluster.Status.Conditions = []metav1.Condition{
+			{Type: clusterv1.ClusterTopologyReconciledCondition, Status: metav1.ConditionTrue},
+			{Type: clusterv1.ClusterAvailableCondition, Status: metav1.ConditionTrue},
+			{Type: clusterv1.ClusterRollingOutCondition, Status: metav1.ConditionFalse},
+		}
+		return cluster
+	}
+	controlPlane := func(generation, observedGeneration int64, replicas, upToDateReplicas int64) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": replicas},
+			"status": map[string]interface{}{"observedGeneration": observedGeneration, "upToDateReplicas": upToDateReplicas},
+		}}
+		obj.SetGeneration(generation)
+		return obj
+	}
+	machineDeployment := func(generation, observedGeneration int64, replicas, upToDateReplicas int32) clusterv1.MachineDeployment {
+		return clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       clusterv1.MachineDeploymentSpec{Replicas: ptr.To(replicas)},
+			Status:     clusterv1.MachineDeploymentStatus{ObservedGeneration: observedGeneration, UpToDateReplicas: ptr.To(upToDateReplicas)},
+		}
+	}
+	machinePool := func(generation, observedGeneration int64, replicas, upToDateReplicas int32) clusterv1.MachinePool {
+		return clusterv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       clusterv1.MachinePoolSpec{Replicas: ptr.To(replicas)},
+			Status:     clusterv1.MachinePoolStatus{ObservedGeneration: observedGeneration, UpToDateReplicas: ptr.To(upToDateReplicas)},
+		}
+	}
+	notAvailableCluster := healthyCluster()
+	notAvailableCluster.Status.Conditions[1].Status = metav1.ConditionFalse
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		objects Objects
+		want    bool
+	}{
+		{
+			name:    "healthy if the Cluster and all its objects are healthy",
+			cluster: healthyCluster(),
+			objects: Objects{
+				ControlPlane:                controlPlane(2, 2, 3, 3),
+				ControlPlaneContractVersion: "v1beta2",
+				MachineDeployments:          []clusterv1.MachineDeployment{machineDeployment(2, 2, 3, 3)},
+				MachinePools:                []clusterv1.MachinePool{machinePool(2, 2, 3, 3)},
+			},
+			want: true,
+		},
+		{
+			name:    "not healthy if the Cluster did not adopt the current generation",
+			cluster: withClassGeneration(healthyCluster(), 2),
+			want:    false,
+		},
+		{
+			name:    "not healthy if the Cluster is not available",
+			cluster: notAvailableCluster,
+			want:    false,
+		},
+		{
+			name:    "not healthy if the control plane did not observe its latest generation",
+			cluster: healthyCluster(),
+			objects: Objects{ControlPlane: controlPlane(2, 1, 3, 3), ControlPlaneContractVersion: "v1beta2"},
+			want:    false,
+		},
+		{
+			name:    "not healthy if not all the replicas of the control plane are up-to-date",
+			cluster: healthyCluster(),
+			objects: Objects{ControlPlane: controlPlane(2, 2, 3, 2), ControlPlaneContractVersion: "v1beta2"},
+			want:    false,
+		},
+		{
+			name:    "not healthy if a MachineDeployment did not observe its latest generation",
+			cluster: healthyCluster(),
+			objects: Objects{MachineDeployments: []clusterv1.MachineDeployment{machineDeployment(2, 2, 3, 3), machineDeployment(2, 1, 3, 3)}},
+			want:    false,
+		},
+		{
+			name:    "not healthy if not all the replicas of a MachineDeployment are up-to-date",
+			cluster: healthyCluster(),
+			objects: Objects{MachineDeployments: []clusterv1.MachineDeployment{machineDeployment(2, 2, 3, 1)}},
+			want:    false,
+		},
+		{
+			name:    "not healthy if a MachinePool did not observe its latest generation",
+			cluster: healthyCluster(),
+			objects: Objects{MachinePools: []clusterv1.MachinePool{machinePool(2, 1, 3, 3)}},
+			want:    false,
+		},
+		{
+			name:    "not healthy if not all the replicas of a MachinePool are up-to-date",
+			cluster: healthyCluster(),
+			objects: Objects{MachinePools: []clusterv1.MachinePool{machinePool(2, 2, 3, 2)}},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			healthy, err := IsHealthy(clusterClass, tt.cluster, tt.objects)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(healthy).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsAborted(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation:  3,
+			Annotations: map[string]string{clusterv1.ClusterClassRolloutAbortedAnnotation: "2"},
+		},
+	}
+	g.Expect(IsAborted(clusterClass)).To(BeFalse())
+
+	clusterClass.Annotations[clusterv1.ClusterClassRolloutAbortedAnnotation] = "3"
+	g.Expect(IsAborted(clusterClass)).To(BeTrue())
+}
+
+func newCluster(namespace, name string, labels map[string]string) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+}
+
+func withClassGeneration(cluster *clusterv1.Cluster, generation int64) *clusterv1.Cluster {
+	cluster.Status.Topology = &clusterv1.ClusterTopologyStatus{ClassGeneration: generation}
+	return cluster
+}
+
+func TestRevertRevision(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Status: clusterv1.ClusterClassStatus{
+			Rollout: &clusterv1.ClusterClassRolloutStatus{
+				Generation:       3,
+				Revision:         "rev3",
+				PreviousRevision: "rev2",
+			},
+		},
+	}
+	g.Expect(RevertRevision(clusterClass)).To(BeEmpty())
+
+	clusterClass.Annotations = map[string]string{clusterv1.ClusterClassRolloutAbortedAnnotation: "3"}
+	g.Expect(RevertRevision(clusterClass)).To(Equal("rev2"))
+
+	// Nothing to revert if the revision did not change.
+	clusterClass.Status.Rollout.PreviousRevision = "rev3"
+	g.Expect(RevertRevision(clusterClass)).To(BeEmpty())
+
+	// Nothing to revert if the rollout status is for a previous generation.
+	clusterClass.Status.Rollout.PreviousRevision = "rev2"
+	clusterClass.Status.Rollout.Generation = 2
+	g.Expect(RevertRevision(clusterClass)).To(BeEmpty())
+}
@@ -34,6 +34,41 @@ func ForMachineDeploymentMHC(md *clusterv1.MachineDeployment) *metav1.LabelSelec
 	}
 }
 
+// ForMachinePoolMHC generates a selector for MachinePool MHCs.
+func ForMachinePoolMHC(mp *clusterv1.MachinePool) *metav1.LabelSelector {
+	// The selector returned here is the minimal common selector for all Machines belonging to a MachinePool.
+	// It does not include any labels set in ClusterClass, Cluster Topology or elsewhere.
+	return &metav1.LabelSelector{MatchLabels: map[string]string{
+		clusterv1.ClusterTopologyOwnedLabel:           "",
+		clusterv1.ClusterTopologyMachinePoolNameLabel: mp.Spec.Template.Labels[clusterv1.ClusterTopologyMachinePoolNameLabel],
+	},
+	}
+}
+
+// ForMachineDeploymentMachineDrainRule generates a Machine selector for MachineDeployment MachineDrainRules.
+func ForMachineDeploymentMachineDrainRule(md *clusterv1.MachineDeployment) *metav1.LabelSelector {
+	// Contrary to MachineHealthChecks, MachineDrainRules are not scoped to a Cluster, so the selector
+	// must also include the cluster name label.
+	return &metav1.LabelSelector{MatchLabels: map[string]string{
+		clusterv1.ClusterNameLabel:                          md.Spec.ClusterName,
+		clusterv1.ClusterTopologyOwnedLabel:                 "",
+		clusterv1.ClusterTopologyMachineDeploymentNameLabel: md.Spec.Selector.MatchLabels[clusterv1.ClusterTopologyMachineDeploymentNameLabel],
+	},
+	}
+}
+
+// ForMachinePoolMachineDrainRule generates a Machine selector for MachinePool MachineDrainRules.
+func ForMachinePoolMachineDrainRule(mp *clusterv1.MachinePool) *metav1.LabelSelector {
+	// Contrary to MachineHealthChecks, MachineDrainRules are not scoped to a Cluster, so the selector
+	// must also include the cluster name label.
+	return &metav1.LabelSelector{MatchLabels: map[string]string{
+		clusterv1.ClusterNameLabel:                    mp.Spec.ClusterName,
+		clusterv1.ClusterTopologyOwnedLabel:           "",
+		clusterv1.ClusterTopologyMachinePoolNameLabel: mp.Spec.Template.Labels[clusterv1.ClusterTopologyMachinePoolNameLabel],
+	},
+	}
+}
+
 // ForControlPlaneMHC generates a selector for control plane MHCs.
 func ForControlPlaneMHC() *metav1.LabelSelector {
 	// The selector returned here is the minimal common selector for all Machines belonging to the ControlPlane.
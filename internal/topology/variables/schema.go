@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -238,3 +240,26 @@ func convertToAPIExtensionsXValidations(validationRules []clusterv1.ValidationRu
 
 	return apiExtValidationRules
 }
+
+// ToStructuralSchema returns a structural schema of type object, which has a property with the schema
+// of each of the given ClusterClass variables.
+// NOTE: This is used to type check CEL expressions in ClusterClass patches.
+func ToStructuralSchema(clusterClassVariables []clusterv1.ClusterClassVariable) (*structuralschema.Structural, error) {
+	wrappedSchema := &apiextensions.JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{},
+	}
+	for _, variable := range clusterClassVariables {
+		apiExtensionsSchema, errs := convertToAPIExtensionsJSONSchemaProps(&variable.Schema.OpenAPIV3Schema, field.NewPath("schema"))
+		if len(errs) > 0 {
+			return nil, errors.Wrapf(errs.ToAggregate(), "failed to convert schema definition for variable %q", variable.Name)
+		}
+		wrappedSchema.Properties[variable.Name] = *apiExtensionsSchema
+	}
+
+	structural, err := structuralschema.NewStructural(wrappedSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create structural schema for variables")
+	}
+	return structural, nil
+}
@@ -20,30 +20,40 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/internal/topology/revision"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
@@ -61,7 +71,7 @@ func (webhook *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:webhook:verbs=create;update;delete,path=/validate-cluster-x-k8s-io-v1beta2-cluster,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters,versions=v1beta2,name=validation.cluster.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
-// +kubebuilder:webhook:verbs=create;update,path=/mutate-cluster-x-k8s-io-v1beta2-cluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters,versions=v1beta2,name=default.cluster.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-cluster-x-k8s-io-v1beta2-cluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters,versions=v1beta2,name=default.cluster.cluster.x-k8s.io,sideEffects=NoneOnDryRun,admissionReviewVersions=v1;v1beta1
 
 // ClusterCacheReader is a scoped-down interface from ClusterCacheTracker that only allows to get a reader client.
 type ClusterCacheReader interface {
@@ -73,6 +83,9 @@ type Cluster struct {
 	Client             client.Reader
 	ClusterCacheReader ClusterCacheReader
 
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
+
 	decoder admission.Decoder
 }
 
@@ -81,6 +94,10 @@ var _ webhook.CustomValidator = &Cluster{}
 
 var errClusterClassNotReconciled = errors.New("ClusterClass is not successfully reconciled")
 
+// defaultClusterVariablesTimeout is the maximum time the defaulting webhook waits for the DefaultClusterVariables hook;
+// it is lower than the timeout of the webhook, so the webhook can return a meaningful error.
+const defaultClusterVariablesTimeout = 5 * time.Second
+
 // Default satisfies the defaulting webhook interface.
 func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 	// We gather all defaulting errors and return them together.
@@ -130,6 +147,25 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 			}
 		}
 
+		// Call the DefaultClusterVariables hook of the Runtime Extensions referenced in the ClusterClass to compute
+		// values of variables dynamically; the values are then defaulted and validated like any other variable value.
+		// NOTE: The hook is not called for Clusters being deleted, so deletion is never blocked by Runtime Extensions.
+		// NOTE: The hook can have side effects, e.g. allocating an IP address; dry-run requests are forwarded to
+		// Runtime Extensions, which must not have side effects for them.
+		// NOTE: If the registry is not ready yet, e.g. when the controller is starting, the hook is not called and
+		// variables are defaulted only using the schema; the hook will be called on the next change of the Cluster.
+		if feature.Gates.Enabled(feature.RuntimeSDK) && webhook.RuntimeClient != nil && cluster.DeletionTimestamp.IsZero() &&
+			shouldCallDefaultClusterVariablesExtensions(cluster, oldCluster) {
+			if !webhook.RuntimeClient.IsReady() {
+				ctrl.LoggerFrom(ctx).Info("Skipping DefaultClusterVariables hook: the Runtime SDK registry is not ready yet")
+			} else {
+				dryRun := err == nil && ptr.Deref(req.DryRun, false)
+				if err := webhook.callDefaultClusterVariablesExtensions(ctx, cluster, oldCluster, clusterClass, dryRun); err != nil {
+					return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be defaulted", cluster.Name))
+				}
+			}
+		}
+
 		// Doing both defaulting and validating here prevents a race condition where the ClusterClass could be
 		// different in the defaulting and validating webhook.
 		allErrs = append(allErrs, DefaultAndValidateVariables(ctx, cluster, oldCluster, clusterClass)...)
@@ -140,6 +176,110 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 	return nil
 }
 
+// callDefaultClusterVariablesExtensions calls the DefaultClusterVariables hook of the external patches of the
+// ClusterClass, respecting the order in which they are defined, and sets the values of the variables returned
+// by the hook in the Cluster.
+// On update, the hook of an external patch is called only if a variable defined by the patch is not set yet; values
+// already set in the Cluster are never overwritten, and the hook of an external patch can only set variables defined
+// by the same patch.
+func (webhook *Cluster) callDefaultClusterVariablesExtensions(ctx context.Context, cluster, oldCluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass, dryRun bool) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultClusterVariablesTimeout)
+	defer cancel()
+
+	for _, patch := range clusterClass.Spec.Patches {
+		if patch.External == nil || patch.External.DefaultClusterVariablesExtension == "" {
+			continue
+		}
+		if oldCluster != nil && !hasUnsetVariables(cluster, clusterClass, patch.Name) {
+			continue
+		}
+
+		v1beta1Cluster := &clusterv1beta1.Cluster{}
+		// DeepCopy cluster because ConvertFrom has side effects like adding the conversion annotation.
+		if err := v1beta1Cluster.ConvertFrom(cluster.DeepCopy()); err != nil {
+			return errors.Wrap(err, "error converting Cluster to v1beta1 Cluster")
+		}
+		// Optimize size of Cluster by not sending status, the managedFields and the conversion annotation.
+		v1beta1Cluster.SetManagedFields(nil)
+		delete(v1beta1Cluster.Annotations, conversion.DataAnnotation)
+		v1beta1Cluster.Status = clusterv1beta1.ClusterStatus{}
+
+		req := &runtimehooksv1.DefaultClusterVariablesRequest{
+			Cluster: *v1beta1Cluster,
+			DryRun:  dryRun,
+		}
+		// Set the settings defined in external patch definition on the request object.
+		// These settings will override overlapping keys defined in ExtensionConfig settings.
+		req.Settings = patch.External.Settings
+		for _, variable := range cluster.Spec.Topology.Variables {
+			req.Variables = append(req.Variables, runtimehooksv1.Variable{Name: variable.Name, Value: variable.Value})
+		}
+
+		resp := &runtimehooksv1.DefaultClusterVariablesResponse{}
+		if err := webhook.RuntimeClient.CallExtension(ctx, runtimehooksv1.DefaultClusterVariables, cluster, patch.External.DefaultClusterVariablesExtension, req, resp); err != nil {
+			return errors.Wrapf(err, "failed to default variables for patch %q", patch.Name)
+		}
+
+		patchVariables := variablesDefinedByPatch(clusterClass, patch.Name)
+		for _, variable := range resp.Variables {
+			if !patchVariables.Has(variable.Name) {
+				return errors.Errorf("failed to default variables for patch %q: variable %q is not defined by the patch", patch.Name, variable.Name)
+			}
+		}
+		for _, variable := range resp.Variables {
+			setClusterVariableIfUnset(cluster, variable.Name, variable.Value)
+		}
+	}
+	return nil
+}
+
+// shouldCallDefaultClusterVariablesExtensions returns true if the DefaultClusterVariables hook should be called, i.e.
+// when the Cluster is created, or when the ClusterClass or the variables of the Cluster are changed.
+// NOTE: Other updates, e.g. to labels or to the version, do not call the hook, so Runtime Extensions are not
+// called on every update of the Cluster.
+func shouldCallDefaultClusterVariablesExtensions(cluster, oldCluster *clusterv1.Cluster) bool {
+	if oldCluster == nil {
+		return true
+	}
+	if cluster.GetClassKey() != oldCluster.GetClassKey() {
+		return true
+	}
+	return !apiequality.Semantic.DeepEqual(cluster.Spec.Topology.Variables, oldCluster.Spec.Topology.Variables)
+}
+
+// variablesDefinedByPatch returns the names of the variables defined by the given patch.
+func variablesDefinedByPatch(clusterClass *clusterv1.ClusterClass, patchName string) sets.Set[string] {
+	names := sets.Set[string]{}
+	for _, variable := range clusterClass.Status.Variables {
+		for _, definition := range variable.Definitions {
+			if definition.From == patchName {
+				names.Insert(variable.Name)
+			}
+		}
+	}
+	return names
+}
+
+// hasUnsetVariables returns true if a variable defined by the given patch is not set in Cluster.spec.topology.variables.
+func hasUnsetVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass, patchName string) bool {
+	for name := range variablesDefinedByPatch(clusterClass, patchName) {
+		if !slices.ContainsFunc(cluster.Spec.Topology.Variables, func(v clusterv1.ClusterVariable) bool { return v.Name == name }) {
+			return true
+		}
+	}
+	return false
+}
+
+// setClusterVariableIfUnset sets the value of a variable in Cluster.spec.topology.variables, if not already set.
+func setClusterVariableIfUnset(cluster *clusterv1.Cluster, name string, value apiextensionsv1.JSON) {
+	for i := range cluster.Spec.Topology.Variables {
+		if cluster.Spec.Topology.Variables[i].Name == name {
+			return
+		}
+	}
+	cluster.Spec.Topology.Variables = append(cluster.Spec.Topology.Variables, clusterv1.ClusterVariable{Name: name, Value: value})
+}
+
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
 func (webhook *Cluster) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	cluster, ok := obj.(*clusterv1.Cluster)
@@ -277,6 +417,18 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		return allWarnings, allErrs
 	}
 
+	// NOTE: ClusterClassRevisions are behind the ClusterClassRevisions feature gate flag; the web hook
+	// must prevent pinning a revision in case the feature flag is disabled.
+	if newCluster.Spec.Topology.ClassRef.Revision != "" && !feature.Gates.Enabled(feature.ClusterClassRevisions) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				fldPath.Child("classRef", "revision"),
+				"can be set only if the ClusterClassRevisions feature flag is enabled",
+			),
+		)
+	}
+
 	// version should be valid.
 	if !version.KubeSemver.MatchString(newCluster.Spec.Topology.Version) {
 		allErrs = append(
@@ -396,8 +548,9 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 			}
 		}
 
-		// If the ClusterClass referenced in the Topology has changed compatibility checks are needed.
-		if oldCluster.GetClassKey() != newCluster.GetClassKey() {
+		// If the ClusterClass or the revision of the ClusterClass referenced in the Topology has changed compatibility checks are needed.
+		if oldCluster.GetClassKey() != newCluster.GetClassKey() ||
+			oldCluster.Spec.Topology.ClassRef.Revision != newCluster.Spec.Topology.ClassRef.Revision {
 			if clusterClassPollErr != nil {
 				allErrs = append(
 					allErrs, field.Forbidden(
@@ -706,9 +859,48 @@ func validateMachineHealthChecks(cluster *clusterv1.Cluster, clusterClass *clust
 		}
 	}
 
+	for i := range cluster.Spec.Topology.Workers.MachinePools {
+		mp := cluster.Spec.Topology.Workers.MachinePools[i]
+		fldPath := field.NewPath("spec", "topology", "workers", "machinePools").Key(mp.Name).Child("healthCheck")
+
+		// Validate the MachinePool MachineHealthCheck if defined.
+		if mp.HealthCheck.IsDefined() {
+			allErrs = append(allErrs, validateMachineHealthCheckNodeStartupTimeoutSeconds(fldPath, mp.HealthCheck.Checks.NodeStartupTimeoutSeconds)...)
+			allErrs = append(allErrs, validateMachineHealthCheckUnhealthyLessThanOrEqualTo(fldPath, mp.HealthCheck.Remediation.TriggerIf.UnhealthyLessThanOrEqualTo)...)
+		}
+
+		// If MachineHealthCheck is explicitly enabled then make sure that a MachineHealthCheck definition is
+		// available either in the Cluster topology or in the ClusterClass.
+		// (One of these definitions will be used in the controller to create the MachineHealthCheck)
+		mpClass := machinePoolClassOfName(clusterClass, mp.Class)
+		if mpClass != nil { // Note: we skip handling the nil case here as it is already handled in previous validations.
+			// Check if the machineHealthCheck is explicitly enabled in the machinePoolTopology.
+			if mp.HealthCheck.Enabled != nil && *mp.HealthCheck.Enabled {
+				// Ensure the MHC is defined in at least one of the MachinePoolTopology of the Cluster or the MachinePoolClass of the ClusterClass.
+				if !mp.HealthCheck.IsDefined() && !mpClass.HealthCheck.IsDefined() {
+					allErrs = append(allErrs, field.Forbidden(
+						fldPath.Child("enable"),
+						fmt.Sprintf("cannot be set to %t as healthCheck definition is not available in the Cluster topology or the ClusterClass", *mp.HealthCheck.Enabled),
+					))
+				}
+			}
+		}
+	}
+
 	return allErrs
 }
 
+// machinePoolClassOfName find a MachinePoolClass of the given name in the provided ClusterClass.
+// Returns nil if it can not find one.
+func machinePoolClassOfName(clusterClass *clusterv1.ClusterClass, name string) *clusterv1.MachinePoolClass {
+	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
+		if mpClass.Class == name {
+			return &mpClass
+		}
+	}
+	return nil
+}
+
 // machineDeploymentClassOfName find a MachineDeploymentClass of the given name in the provided ClusterClass.
 // Returns nil if it can not find one.
 // TODO: Check if there is already a helper function that can do this.
@@ -951,6 +1143,11 @@ func (webhook *Cluster) pollClusterClassForCluster(ctx context.Context, cluster
 	if clusterClassPollErr != nil {
 		return nil, clusterClassPollErr
 	}
+
+	// If the Cluster pins a revision of the ClusterClass, return the ClusterClass as captured by the revision.
+	if revision.IsPinned(cluster) {
+		return revision.Get(ctx, webhook.Client, clusterClass, cluster.Spec.Topology.ClassRef.Revision)
+	}
 	return clusterClass, nil
 }
 
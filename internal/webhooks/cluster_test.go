@@ -40,7 +40,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/test/builder"
@@ -1366,6 +1369,332 @@ func TestClusterDefaultTopologyVersion(t *testing.T) {
 	g.Expect(c.Spec.Topology.Version).To(HavePrefix("v"))
 }
 
+func TestClusterDefaultWithDefaultClusterVariablesExtension(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	stringVariable := func(name string, defaultValue *apiextensionsv1.JSON) clusterv1.ClusterClassStatusVariable {
+		return clusterv1.ClusterClassStatusVariable{
+			Name: name,
+			Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+				{
+					From: "patch1",
+					Schema: clusterv1.VariableSchema{
+						OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+							Type:    "string",
+							Default: defaultValue,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		variables     []clusterv1.ClusterVariable
+		update        bool
+		oldVariables  []clusterv1.ClusterVariable
+		oldClass      string
+		dryRun        bool
+		deleting      bool
+		notReady      bool
+		response      *runtimehooksv1.DefaultClusterVariablesResponse
+		expect        []clusterv1.ClusterVariable
+		wantNotCalled bool
+		wantErr       bool
+	}{
+		{
+			name: "set variables returned by the extension and default the other variables using the schema",
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}},
+			},
+		},
+		{
+			name: "do not overwrite variables set in the Cluster with the values returned by the extension",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+		},
+		{
+			name: "call the extension on update if the variables are changed and a variable is not set",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			update: true,
+			oldVariables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}},
+			},
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+			},
+		},
+		{
+			name: "call the extension on update if the class is changed and a variable is not set",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			update:   true,
+			oldClass: "class0",
+			oldVariables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+			},
+		},
+		{
+			name: "do not call the extension on update if the class and the variables are not changed",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			update: true,
+			oldVariables: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "extension not available"},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			wantNotCalled: true,
+		},
+		{
+			name: "do not call the extension on update if all the variables are set",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			update: true,
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "extension not available"},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west"`)}},
+			},
+			wantNotCalled: true,
+		},
+		{
+			name: "do not call the extension if the Cluster is being deleted",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+			},
+			deleting: true,
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "extension not available"},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}},
+			},
+			wantNotCalled: true,
+		},
+		{
+			name: "do not call the extension if the registry is not ready",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+			},
+			notReady: true,
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "extension not available"},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-0000"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}},
+			},
+			wantNotCalled: true,
+		},
+		{
+			name:   "pass dry-run to the extension",
+			dryRun: true,
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				},
+			},
+			expect: []clusterv1.ClusterVariable{
+				{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+				{Name: "location", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}},
+			},
+		},
+		{
+			name: "fail if the extension returns a variable not defined by its patch",
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`"ami-1234"`)}},
+					{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"eu-central"`)}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the values returned by the extension are not valid",
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Variables: []runtimehooksv1.Variable{
+					{Name: "imageID", Value: apiextensionsv1.JSON{Raw: []byte(`1`)}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the extension fails",
+			response: &runtimehooksv1.DefaultClusterVariablesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "no free IP"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithPatches([]clusterv1.ClusterClassPatch{
+					{
+						Name: "patch1",
+						External: &clusterv1.ExternalPatchDefinition{
+							GeneratePatchesExtension:         "generate-patches.ext1",
+							DefaultClusterVariablesExtension: "default-cluster-variables.ext1",
+						},
+					},
+				}).
+				WithStatusVariables(
+					stringVariable("imageID", nil),
+					stringVariable("location", &apiextensionsv1.JSON{Raw: []byte(`"us-east"`)}),
+					clusterv1.ClusterClassStatusVariable{
+						Name: "region",
+						Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+							{
+								From: clusterv1.VariableDefinitionFromInline,
+								Schema: clusterv1.VariableSchema{
+									OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"},
+								},
+							},
+						},
+					},
+				).
+				Build()
+			conditions.Set(clusterClass, metav1.Condition{
+				Type:   clusterv1.ClusterClassVariablesReadyCondition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.ClusterClassVariablesReadyReason,
+			})
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(clusterClass).
+				WithScheme(fakeScheme).
+				Build()
+
+			var request *runtimehooksv1.DefaultClusterVariablesRequest
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+					"default-cluster-variables.ext1": tt.response,
+				}).
+				WithCallExtensionValidations(func(req runtimehooksv1.RequestObject) error {
+					request = req.(*runtimehooksv1.DefaultClusterVariablesRequest)
+					return nil
+				}).
+				MarkReady(!tt.notReady).
+				Build()
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class1").
+					WithVersion("v1.22.2").
+					WithVariables(tt.variables...).
+					Build()).
+				Build()
+
+			if tt.deleting {
+				cluster.DeletionTimestamp = ptr.To(metav1.Now())
+				cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+			}
+
+			webhookCtx := ctx
+			if tt.update || tt.dryRun {
+				admissionRequest := admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+						DryRun:    ptr.To(tt.dryRun),
+					},
+				}
+				if tt.update {
+					oldCluster := cluster.DeepCopy()
+					oldCluster.Spec.Topology.Variables = tt.oldVariables
+					if tt.oldClass != "" {
+						oldCluster.Spec.Topology.ClassRef.Name = tt.oldClass
+					}
+					jsonObj, err := json.Marshal(oldCluster)
+					g.Expect(err).ToNot(HaveOccurred())
+					admissionRequest.Operation = admissionv1.Update
+					admissionRequest.OldObject = runtime.RawExtension{
+						Raw:    jsonObj,
+						Object: oldCluster,
+					}
+				}
+				webhookCtx = admission.NewContextWithRequest(ctx, admissionRequest)
+			}
+
+			webhook := &Cluster{Client: fakeClient, RuntimeClient: runtimeClient, decoder: admission.NewDecoder(fakeScheme)}
+			err := webhook.Default(webhookCtx, cluster)
+			if tt.wantNotCalled {
+				g.Expect(request).To(BeNil())
+			} else {
+				g.Expect(request).ToNot(BeNil())
+				g.Expect(request.Cluster.Name).To(Equal("cluster1"))
+				g.Expect(request.Variables).To(HaveLen(len(tt.variables)))
+				g.Expect(request.DryRun).To(Equal(tt.dryRun))
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cluster.Spec.Topology.Variables).To(BeComparableTo(tt.expect))
+		})
+	}
+}
+
 func TestClusterFailOnMissingClassField(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to set Cluster.Topologies.
 	// Enabling the feature flag temporarily for this test.
@@ -1698,6 +2027,20 @@ func TestClusterTopologyValidation(t *testing.T) {
 					WithVersion("invalid").Build()).
 				Build(),
 		},
+		{
+			name:      "should return error when topology pins a ClusterClass revision and the ClusterClassRevisions feature gate is disabled",
+			expectErr: true,
+			in: func() *clusterv1.Cluster {
+				cluster := builder.Cluster("fooboo", "cluster1").
+					WithTopology(builder.ClusterTopology().
+						WithClass("foo").
+						WithVersion("v1.19.1").
+						Build()).
+					Build()
+				cluster.Spec.Topology.ClassRef.Revision = "0a1b2c3d"
+				return cluster
+			}(),
+		},
 		{
 			name:      "should return error when downgrading topology version - major",
 			expectErr: true,
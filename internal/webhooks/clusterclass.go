@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -122,11 +123,20 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	// Ensure all MachinePool classes are unique.
 	allErrs = append(allErrs, check.MachinePoolClassesAreUnique(newClusterClass)...)
 
+	// Ensure all add-ons are unique.
+	allErrs = append(allErrs, check.AddonClassesAreUnique(newClusterClass)...)
+
 	allErrs = append(allErrs, validateClusterClassRollout(newClusterClass)...)
 
 	// Ensure MachineHealthChecks are valid.
 	allErrs = append(allErrs, validateMachineHealthCheckClasses(newClusterClass)...)
 
+	// Ensure MachineDrainRules are valid.
+	allErrs = append(allErrs, validateMachineDrainRuleClasses(newClusterClass)...)
+
+	// Ensure add-ons are valid.
+	allErrs = append(allErrs, validateAddonClasses(newClusterClass)...)
+
 	// Ensure NamingStrategies are valid.
 	allErrs = append(allErrs, validateNamingStrategies(newClusterClass)...)
 
@@ -238,6 +248,37 @@ func validateUpdatesToMachineHealthCheckClasses(clusters []clusterv1.Cluster, ol
 		}
 	}
 
+	// For each MachinePoolClass check if the MachineHealthCheck definition is dropped.
+	for _, newMpClass := range newClusterClass.Spec.Workers.MachinePools {
+		oldMpClass := machinePoolClassOfName(oldClusterClass, newMpClass.Class)
+		if oldMpClass == nil {
+			// This is a new MachinePoolClass. Nothing to do here.
+			continue
+		}
+		// If the MachineHealthCheck is dropped then check that no cluster is using it.
+		if oldMpClass.HealthCheck.IsDefined() && !newMpClass.HealthCheck.IsDefined() {
+			clustersUsingMHC := []string{}
+			for _, cluster := range clusters {
+				for _, mpTopology := range cluster.Spec.Topology.Workers.MachinePools {
+					if mpTopology.Class == newMpClass.Class {
+						if mpTopology.HealthCheck.Enabled != nil &&
+							*mpTopology.HealthCheck.Enabled &&
+							!mpTopology.HealthCheck.IsDefined() {
+							clustersUsingMHC = append(clustersUsingMHC, cluster.Name)
+							break
+						}
+					}
+				}
+			}
+			if len(clustersUsingMHC) != 0 {
+				allErrs = append(allErrs, field.Forbidden(
+					field.NewPath("spec", "workers", "machinePools").Key(newMpClass.Class).Child("healthCheck"),
+					fmt.Sprintf("healthCheck cannot be deleted because it is used by Cluster(s) %q", strings.Join(clustersUsingMHC, ",")),
+				))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -364,6 +405,16 @@ func validateClusterClassRollout(clusterClass *clusterv1.ClusterClass) field.Err
 		allErrs = append(allErrs, validateRolloutStrategy(fldPath.Child("strategy"), md.Rollout.Strategy.RollingUpdate.MaxUnavailable, md.Rollout.Strategy.RollingUpdate.MaxSurge)...)
 	}
 
+	for _, wave := range clusterClass.Spec.Rollout.Waves {
+		if wave.Selector == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "rollout", "waves").Key(wave.Name).Child("selector")
+		if _, err := metav1.LabelSelectorAsSelector(wave.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, wave.Selector, err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
@@ -397,6 +448,90 @@ func validateMachineHealthCheckClasses(clusterClass *clusterv1.ClusterClass) fie
 		allErrs = append(allErrs, validateMachineHealthCheckUnhealthyLessThanOrEqualTo(fldPath, md.HealthCheck.Remediation.TriggerIf.UnhealthyLessThanOrEqualTo)...)
 		allErrs = append(allErrs, validateRemediationMaxInFlight(fldPath.Child("remediation"), md.HealthCheck.Remediation.MaxInFlight)...)
 	}
+
+	// Validate MachinePool MachineHealthChecks.
+	for _, mp := range clusterClass.Spec.Workers.MachinePools {
+		if !mp.HealthCheck.IsDefined() {
+			continue
+		}
+		fldPath := field.NewPath("spec", "workers", "machinePools").Key(mp.Class).Child("healthCheck")
+
+		allErrs = append(allErrs, validateMachineHealthCheckNodeStartupTimeoutSeconds(fldPath, mp.HealthCheck.Checks.NodeStartupTimeoutSeconds)...)
+		allErrs = append(allErrs, validateMachineHealthCheckUnhealthyLessThanOrEqualTo(fldPath, mp.HealthCheck.Remediation.TriggerIf.UnhealthyLessThanOrEqualTo)...)
+	}
+	return allErrs
+}
+
+func validateAddonClasses(clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, addon := range clusterClass.Spec.Addons {
+		if addon.TemplateRef.IsDefined() == addon.WorkloadClusterManifests.IsDefined() {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec", "addons").Index(i),
+				addon.Name,
+				"exactly one of templateRef or workloadClusterManifests must be set"))
+		}
+	}
+	return allErrs
+}
+
+func validateMachineDrainRuleClasses(clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		fldPath := field.NewPath("spec", "workers", "machineDeployments").Key(md.Class).Child("machineDrainRules")
+		allErrs = append(allErrs, validateMachineDrainRuleClassList(fldPath, md.MachineDrainRules)...)
+	}
+	for _, mp := range clusterClass.Spec.Workers.MachinePools {
+		fldPath := field.NewPath("spec", "workers", "machinePools").Key(mp.Class).Child("machineDrainRules")
+		allErrs = append(allErrs, validateMachineDrainRuleClassList(fldPath, mp.MachineDrainRules)...)
+	}
+	return allErrs
+}
+
+func validateMachineDrainRuleClassList(fldPath *field.Path, machineDrainRuleClasses []clusterv1.MachineDrainRuleClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := sets.Set[string]{}
+	for _, machineDrainRuleClass := range machineDrainRuleClasses {
+		ruleFldPath := fldPath.Key(machineDrainRuleClass.Name)
+		if names.Has(machineDrainRuleClass.Name) {
+			allErrs = append(allErrs, field.Duplicate(ruleFldPath.Child("name"), machineDrainRuleClass.Name))
+		}
+		names.Insert(machineDrainRuleClass.Name)
+
+		// Note: The following validations are the same as the ones implemented in the MachineDrainRule webhook,
+		// so invalid MachineDrainRules are rejected before they are generated by the topology controller.
+		if machineDrainRuleClass.Drain.Behavior == clusterv1.MachineDrainRuleDrainBehaviorSkip ||
+			machineDrainRuleClass.Drain.Behavior == clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted {
+			if machineDrainRuleClass.Drain.Order != nil {
+				allErrs = append(allErrs,
+					field.Invalid(ruleFldPath.Child("drain", "order"),
+						*machineDrainRuleClass.Drain.Order,
+						fmt.Sprintf("order must not be set if drain behavior is %q or %q",
+							clusterv1.MachineDrainRuleDrainBehaviorSkip, clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted),
+					),
+				)
+			}
+		}
+		for i, podSelector := range machineDrainRuleClass.Pods {
+			if podSelector.Selector != nil {
+				if _, err := metav1.LabelSelectorAsSelector(podSelector.Selector); err != nil {
+					allErrs = append(allErrs,
+						field.Invalid(ruleFldPath.Child("pods").Index(i).Child("selector"), podSelector.Selector, err.Error()),
+					)
+				}
+			}
+			if podSelector.NamespaceSelector != nil {
+				if _, err := metav1.LabelSelectorAsSelector(podSelector.NamespaceSelector); err != nil {
+					allErrs = append(allErrs,
+						field.Invalid(ruleFldPath.Child("pods").Index(i).Child("namespaceSelector"), podSelector.NamespaceSelector, err.Error()),
+					)
+				}
+			}
+		}
+	}
 	return allErrs
 }
 
@@ -823,6 +823,133 @@ func TestClusterClassValidation(t *testing.T) {
 				Build(),
 			expectErr: false,
 		},
+		{
+			name: "create fail if MachinePool MachineHealthCheck NodeStartUpTimeout is too short",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithWorkerMachinePoolClasses(
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithMachineHealthCheckClass(clusterv1.MachinePoolClassHealthCheck{
+							Checks: clusterv1.MachinePoolClassHealthCheckChecks{
+								// nodeStartupTimeout is too short here.
+								NodeStartupTimeoutSeconds: ptr.To(int32(10)),
+							},
+						}).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "create pass if MachinePool MachineHealthCheck is valid",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithWorkerMachinePoolClasses(
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithMachineHealthCheckClass(clusterv1.MachinePoolClassHealthCheck{
+							Checks: clusterv1.MachinePoolClassHealthCheckChecks{
+								NodeStartupTimeoutSeconds: ptr.To(int32(60)),
+							},
+						}).
+						Build()).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "create pass if MachineDeployment MachineDrainRules are valid",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithMachineDrainRules(clusterv1.MachineDrainRuleClass{
+							Name: "skip-logging",
+							Drain: clusterv1.MachineDrainRuleDrainConfig{
+								Behavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+							},
+							Pods: []clusterv1.MachineDrainRulePodSelector{{
+								Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "logging"}},
+							}},
+						}).
+						Build()).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "create fail if MachinePool MachineDrainRules set order with behavior Skip",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithWorkerMachinePoolClasses(
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithMachineDrainRules(clusterv1.MachineDrainRuleClass{
+							Name: "skip-logging",
+							Drain: clusterv1.MachineDrainRuleDrainConfig{
+								Behavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+								Order:    ptr.To[int32](5),
+							},
+						}).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "create fail if MachineDeployment MachineDrainRules have duplicate names",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithMachineDrainRules(
+							clusterv1.MachineDrainRuleClass{
+								Name:  "skip-logging",
+								Drain: clusterv1.MachineDrainRuleDrainConfig{Behavior: clusterv1.MachineDrainRuleDrainBehaviorSkip},
+							},
+							clusterv1.MachineDrainRuleClass{
+								Name:  "skip-logging",
+								Drain: clusterv1.MachineDrainRuleDrainConfig{Behavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted},
+							},
+						).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
 
 		// update tests
 		{
@@ -1686,6 +1813,88 @@ func TestClusterClassValidation(t *testing.T) {
 				Build(),
 			expectErr: false,
 		},
+		{
+			name: "create pass with add-ons",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *ref},
+					clusterv1.AddonClass{Name: "cni", WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{ConfigMapName: "cni"}},
+				).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "create fail if an add-on sets both templateRef and workloadClusterManifests",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *ref, WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{ConfigMapName: "cni"}},
+				).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "create fail if an add-on sets neither templateRef nor workloadClusterManifests",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool"},
+				).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "create fail if add-on names are not unique",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *ref},
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *compatibleRef},
+				).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "update fail if the kind of an add-on template changes",
+			old: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *ref},
+				).
+				Build(),
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithAddons(
+					clusterv1.AddonClass{Name: "ippool", TemplateRef: *incompatibleRef},
+				).
+				Build(),
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+// SetupWebhookWithManager sets up ClusterClassRevision webhooks.
+func (webhook *ClusterClassRevision) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&clusterv1.ClusterClassRevision{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta2-clusterclassrevision,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusterclassrevisions,versions=v1beta2,name=validation.clusterclassrevision.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ClusterClassRevision implements a validation webhook for ClusterClassRevision.
+type ClusterClassRevision struct{}
+
+var _ webhook.CustomValidator = &ClusterClassRevision{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterClassRevision) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	revision, ok := obj.(*clusterv1.ClusterClassRevision)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClassRevision but got a %T", obj))
+	}
+
+	return nil, webhook.validate(nil, revision)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterClassRevision) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRevision, ok := oldObj.(*clusterv1.ClusterClassRevision)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClassRevision but got a %T", oldObj))
+	}
+	newRevision, ok := newObj.(*clusterv1.ClusterClassRevision)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClassRevision but got a %T", newObj))
+	}
+
+	return nil, webhook.validate(oldRevision, newRevision)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterClassRevision) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (webhook *ClusterClassRevision) validate(oldRevision, newRevision *clusterv1.ClusterClassRevision) error {
+	// NOTE: ClusterClassRevision is behind ClusterClassRevisions feature gate flag; the web hook
+	// must prevent creating new objects in case the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.ClusterClassRevisions) {
+		return field.Forbidden(
+			field.NewPath("spec"),
+			"can be set only if the ClusterClassRevisions feature flag is enabled",
+		)
+	}
+
+	var allErrs field.ErrorList
+	if oldRevision != nil && !reflect.DeepEqual(oldRevision.Spec, newRevision.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "field is immutable"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClassRevision").GroupKind(), newRevision.Name, allErrs)
+}
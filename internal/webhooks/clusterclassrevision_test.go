@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestClusterClassRevisionValidate(t *testing.T) {
+	revision := &clusterv1.ClusterClassRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "class1-0a1b2c3d",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterClassRevisionSpec{
+			ClusterClassName: "class1",
+			Revision:         "0a1b2c3d",
+			ClusterClassSpec: clusterv1.ClusterClassSpec{
+				Infrastructure: clusterv1.InfrastructureClass{
+					TemplateRef: clusterv1.ClusterClassTemplateReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+						Kind:       "GenericInfrastructureClusterTemplate",
+						Name:       "infra1",
+					},
+				},
+			},
+		},
+	}
+	revisionWithChangedSpec := revision.DeepCopy()
+	revisionWithChangedSpec.Spec.ClusterClassSpec.Infrastructure.TemplateRef.Name = "infra2"
+	revisionWithChangedMetadata := revision.DeepCopy()
+	revisionWithChangedMetadata.Labels = map[string]string{"foo": "bar"}
+
+	tests := []struct {
+		name        string
+		featureGate bool
+		oldRevision *clusterv1.ClusterClassRevision
+		newRevision *clusterv1.ClusterClassRevision
+		expectErr   bool
+	}{
+		{
+			name:        "should fail on create if the feature gate is disabled",
+			featureGate: false,
+			newRevision: revision,
+			expectErr:   true,
+		},
+		{
+			name:        "should succeed on create if the feature gate is enabled",
+			featureGate: true,
+			newRevision: revision,
+			expectErr:   false,
+		},
+		{
+			name:        "should succeed on update of metadata",
+			featureGate: true,
+			oldRevision: revision,
+			newRevision: revisionWithChangedMetadata,
+			expectErr:   false,
+		},
+		{
+			name:        "should fail on update of spec",
+			featureGate: true,
+			oldRevision: revision,
+			newRevision: revisionWithChangedSpec,
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassRevisions, tt.featureGate)
+
+			webhook := &ClusterClassRevision{}
+			var err error
+			if tt.oldRevision == nil {
+				_, err = webhook.ValidateCreate(ctx, tt.newRevision)
+			} else {
+				_, err = webhook.ValidateUpdate(ctx, tt.oldRevision, tt.newRevision)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -28,33 +28,52 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/cel/environment"
 	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/feature"
+	topologypatches "sigs.k8s.io/cluster-api/internal/topology/patches"
 )
 
 // validatePatches returns errors if the Patches in the ClusterClass violate any validation rules.
 func validatePatches(clusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 	names := sets.Set[string]{}
+	celEnv := &patchCELEnvironment{variables: clusterClass.Spec.Variables}
 	for i, patch := range clusterClass.Spec.Patches {
 		allErrs = append(
 			allErrs,
-			validatePatch(patch, names, clusterClass, field.NewPath("spec", "patches").Index(i))...,
+			validatePatch(patch, names, clusterClass, celEnv, field.NewPath("spec", "patches").Index(i))...,
 		)
 		names.Insert(patch.Name)
 	}
 	return allErrs
 }
 
-func validatePatch(patch clusterv1.ClusterClassPatch, names sets.Set[string], clusterClass *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
+// patchCELEnvironment provides the environment used to type check CEL expressions in patches.
+// Note: The environment is only created if there are CEL expressions to validate.
+type patchCELEnvironment struct {
+	variables []clusterv1.ClusterClassVariable
+
+	env *topologypatches.CELEnvironment
+	err error
+}
+
+func (p *patchCELEnvironment) get() (*topologypatches.CELEnvironment, error) {
+	if p.env == nil && p.err == nil {
+		p.env, p.err = topologypatches.NewCELEnvironment(p.variables, environment.NewExpressions)
+	}
+	return p.env, p.err
+}
+
+func validatePatch(patch clusterv1.ClusterClassPatch, names sets.Set[string], clusterClass *clusterv1.ClusterClass, celEnv *patchCELEnvironment, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs,
 		validatePatchName(patch, names, path)...,
 	)
 	allErrs = append(allErrs,
-		validatePatchDefinitions(patch, clusterClass, path)...,
+		validatePatchDefinitions(patch, clusterClass, celEnv, path)...,
 	)
 	return allErrs
 }
@@ -90,10 +109,20 @@ func validatePatchName(patch clusterv1.ClusterClassPatch, names sets.Set[string]
 	return allErrs
 }
 
-func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
+func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *clusterv1.ClusterClass, celEnv *patchCELEnvironment, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, validateEnabledIf(patch.EnabledIf, path.Child("enabledIf"))...)
+	allErrs = append(allErrs, validateEnabledIfExpression(patch.EnabledIfExpression, celEnv, path.Child("enabledIfExpression"))...)
+
+	if patch.EnabledIf != "" && patch.EnabledIfExpression != "" {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path,
+				prettyPrint(patch),
+				"only one of enabledIf or enabledIfExpression can be defined",
+			))
+	}
 
 	if patch.Definitions == nil && patch.External == nil {
 		allErrs = append(allErrs,
@@ -115,7 +144,7 @@ func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *c
 	if patch.Definitions != nil {
 		for i, definition := range patch.Definitions {
 			allErrs = append(allErrs,
-				validateJSONPatches(definition.JSONPatches, clusterClass.Spec.Variables, path.Child("definitions").Index(i).Child("jsonPatches"))...)
+				validateJSONPatches(definition.JSONPatches, clusterClass.Spec.Variables, selectsOnlyAddons(definition.Selector), celEnv, path.Child("definitions").Index(i).Child("jsonPatches"))...)
 			allErrs = append(allErrs,
 				validateSelectors(definition.Selector, clusterClass, path.Child("definitions").Index(i).Child("selector"))...)
 		}
@@ -160,6 +189,34 @@ func validateEnabledIf(enabledIf string, path *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateEnabledIfExpression validates if enabledIfExpression is a valid CEL expression evaluating to a bool if it is set.
+func validateEnabledIfExpression(expression string, celEnv *patchCELEnvironment, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if expression != "" {
+		env, err := celEnv.get()
+		if err != nil {
+			return append(allErrs,
+				field.Invalid(
+					path,
+					expression,
+					fmt.Sprintf("expression can not be type checked: %v", err),
+				))
+		}
+		// Error if the expression can not be compiled or does not evaluate to a bool.
+		if _, err := env.CompileCondition(expression); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					path,
+					expression,
+					fmt.Sprintf("expression is invalid: %v", err),
+				))
+		}
+	}
+
+	return allErrs
+}
+
 // validateSelectors tests to see if the selector matches any template in the ClusterClass.
 // It returns nil as soon as it finds any matching template and an error if there is no match.
 func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
@@ -168,7 +225,8 @@ func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.Cluste
 	// Return an error if none of the possible selectors are enabled.
 	if !ptr.Deref(selector.MatchResources.InfrastructureCluster, false) && !ptr.Deref(selector.MatchResources.ControlPlane, false) &&
 		(selector.MatchResources.MachineDeploymentClass == nil || len(selector.MatchResources.MachineDeploymentClass.Names) == 0) &&
-		(selector.MatchResources.MachinePoolClass == nil || len(selector.MatchResources.MachinePoolClass.Names) == 0) {
+		(selector.MatchResources.MachinePoolClass == nil || len(selector.MatchResources.MachinePoolClass.Names) == 0) &&
+		(selector.MatchResources.AddonClass == nil || len(selector.MatchResources.AddonClass.Names) == 0) {
 		return append(allErrs,
 			field.Invalid(
 				path,
@@ -277,6 +335,41 @@ func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.Cluste
 		}
 	}
 
+	if selector.MatchResources.AddonClass != nil && len(selector.MatchResources.AddonClass.Names) > 0 {
+		for i, name := range selector.MatchResources.AddonClass.Names {
+			match := false
+			err := validateSelectorName(name, path, "addonClass", i)
+			if err != nil {
+				allErrs = append(allErrs, err)
+				break
+			}
+			for _, addon := range class.Spec.Addons {
+				var matches bool
+				if addon.Name == name || name == "*" {
+					matches = true
+				} else if strings.HasPrefix(name, "*") && strings.HasSuffix(addon.Name, strings.TrimPrefix(name, "*")) {
+					matches = true
+				} else if strings.HasSuffix(name, "*") && strings.HasPrefix(addon.Name, strings.TrimSuffix(name, "*")) {
+					matches = true
+				}
+
+				// NOTE: The kinds of the workload cluster manifests of an add-on are not known until the
+				// ConfigMap with the manifests is read, so selectors match all the add-ons with manifests.
+				if matches && (addon.WorkloadClusterManifests.IsDefined() || (addon.TemplateRef.IsDefined() && selectorMatchTemplate(selector, addon.TemplateRef))) {
+					match = true
+					break
+				}
+			}
+			if !match {
+				allErrs = append(allErrs, field.Invalid(
+					path.Child("matchResources", "addonClass", "names").Index(i),
+					name,
+					"selector is enabled but matches neither the template ref nor the workload cluster manifests of an add-on",
+				))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -317,7 +410,21 @@ func selectorMatchTemplate(selector clusterv1.PatchSelector, reference clusterv1
 
 var validOps = sets.Set[string]{}.Insert("add", "replace", "remove")
 
-func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv1.ClusterClassVariable, path *field.Path) field.ErrorList {
+// selectsOnlyAddons returns true if a selector matches only templates or manifests of add-ons.
+func selectsOnlyAddons(selector clusterv1.PatchSelector) bool {
+	return !ptr.Deref(selector.MatchResources.InfrastructureCluster, false) && !ptr.Deref(selector.MatchResources.ControlPlane, false) &&
+		(selector.MatchResources.MachineDeploymentClass == nil || len(selector.MatchResources.MachineDeploymentClass.Names) == 0) &&
+		(selector.MatchResources.MachinePoolClass == nil || len(selector.MatchResources.MachinePoolClass.Names) == 0) &&
+		(selector.MatchResources.AddonClass != nil && len(selector.MatchResources.AddonClass.Names) > 0)
+}
+
+// identityJSONPatchPaths are the paths of the fields identifying an object, which can't be patched.
+var identityJSONPatchPaths = sets.Set[string]{}.Insert("/apiVersion", "/kind", "/metadata", "/metadata/name", "/metadata/namespace")
+
+// validateJSONPatches validates JSON patches; if addonsOnly is true, the patches are applied only to add-ons, so
+// they can patch any field of the workload cluster manifests, e.g. the data of a ConfigMap, except for the fields
+// identifying the object. Otherwise, patches can only patch the spec.
+func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv1.ClusterClassVariable, addonsOnly bool, celEnv *patchCELEnvironment, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	variableSet, _ := getClusterClassVariablesMapWithReverseIndex(variables)
 
@@ -331,7 +438,16 @@ func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv
 				))
 		}
 
-		if !strings.HasPrefix(jsonPatch.Path, "/spec/") {
+		if addonsOnly {
+			if jsonPatch.Path == "" || jsonPatch.Path == "/" || identityJSONPatchPaths.Has(jsonPatch.Path) {
+				allErrs = append(allErrs,
+					field.Invalid(
+						path.Index(i).Child("path"),
+						prettyPrint(jsonPatch),
+						"jsonPatch path must not target apiVersion, kind, metadata.name or metadata.namespace",
+					))
+			}
+		} else if !strings.HasPrefix(jsonPatch.Path, "/spec/") {
 			allErrs = append(allErrs,
 				field.Invalid(
 					path.Index(i).Child("path"),
@@ -347,13 +463,13 @@ func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv
 
 		// Validate the value and valueFrom fields for the patch.
 		allErrs = append(allErrs,
-			validateJSONPatchValues(jsonPatch, variableSet, path.Index(i))...,
+			validateJSONPatchValues(jsonPatch, variableSet, celEnv, path.Index(i))...,
 		)
 	}
 	return allErrs
 }
 
-func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[string]*clusterv1.ClusterClassVariable, path *field.Path) field.ErrorList {
+func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[string]*clusterv1.ClusterClassVariable, celEnv *patchCELEnvironment, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	// move to the next variable if the jsonPatch does not have "replace" or "add" op. Additional validation is not needed.
@@ -392,12 +508,20 @@ func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[stri
 				))
 		}
 	}
-	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Template == "" && jsonPatch.ValueFrom.Variable == "" {
+	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Template == "" && jsonPatch.ValueFrom.Variable == "" && jsonPatch.ValueFrom.Expression == "" {
 		allErrs = append(allErrs,
 			field.Invalid(
 				path.Child("valueFrom"),
 				prettyPrint(jsonPatch.ValueFrom),
-				"valueFrom must set either template or variable",
+				"valueFrom must set one of template, variable or expression",
+			))
+	}
+	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Expression != "" && (jsonPatch.ValueFrom.Template != "" || jsonPatch.ValueFrom.Variable != "") {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path.Child("valueFrom"),
+				prettyPrint(jsonPatch.ValueFrom),
+				"valueFrom can not set expression together with template or variable",
 			))
 	}
 	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Template != "" && jsonPatch.ValueFrom.Variable != "" {
@@ -422,6 +546,27 @@ func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[stri
 		}
 	}
 
+	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Expression != "" {
+		env, err := celEnv.get()
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					path.Child("valueFrom", "expression"),
+					jsonPatch.ValueFrom.Expression,
+					fmt.Sprintf("expression can not be type checked: %v", err),
+				))
+		} else if _, err := env.CompileValue(jsonPatch.ValueFrom.Expression); err != nil {
+			// Error if the expression can not be compiled, e.g. because it references undefined variables
+			// or because of type errors.
+			allErrs = append(allErrs,
+				field.Invalid(
+					path.Child("valueFrom", "expression"),
+					jsonPatch.ValueFrom.Expression,
+					fmt.Sprintf("expression is invalid: %v", err),
+				))
+		}
+	}
+
 	// If set validate that the variable is valid.
 	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Variable != "" {
 		// If the variable is one of the list of builtin variables it's valid.
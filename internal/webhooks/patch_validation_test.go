@@ -300,6 +300,135 @@ func TestValidatePatches(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "pass if enabledIfExpression is a valid CEL expression",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:                "patch1",
+							EnabledIfExpression: `variables.variableB == "a" && builtin.cluster.name != ""`,
+							Definitions:         []clusterv1.PatchDefinition{},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error if enabledIfExpression does not evaluate to a bool",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:                "patch1",
+							EnabledIfExpression: `variables.variableB`,
+							Definitions:         []clusterv1.PatchDefinition{},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error if enabledIfExpression uses a variable which is not defined",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:                "patch1",
+							EnabledIfExpression: `variables.variableC == "a"`,
+							Definitions:         []clusterv1.PatchDefinition{},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error if both enabledIf and enabledIfExpression are set",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:                "patch1",
+							EnabledIf:           `true`,
+							EnabledIfExpression: `true`,
+							Definitions:         []clusterv1.PatchDefinition{},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		// Patch "op" (operation) validation
 		{
 			name: "error if patch op is not \"add\" \"remove\" or \"replace\"",
@@ -379,6 +508,89 @@ func TestValidatePatches(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "pass if jsonPatch path of a patch for add-ons does not start with /spec/",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					Addons: []clusterv1.AddonClass{
+						{
+							Name: "cni",
+							WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{
+								ConfigMapName: "cni",
+							},
+						},
+					},
+
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: "v1",
+										Kind:       "ConfigMap",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+												Names: []string{"cni"},
+											},
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:    "replace",
+											Path:  "/data/mtu",
+											Value: &apiextensionsv1.JSON{Raw: []byte(`"1450"`)},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "error if jsonPatch path of a patch for add-ons targets the name of the object",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					Addons: []clusterv1.AddonClass{
+						{
+							Name: "cni",
+							WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{
+								ConfigMapName: "cni",
+							},
+						},
+					},
+
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: "v1",
+										Kind:       "ConfigMap",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+												Names: []string{"cni"},
+											},
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:    "replace",
+											Path:  "/metadata/name",
+											Value: &apiextensionsv1.JSON{Raw: []byte(`"1450"`)},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "pass if jsonPatch path uses a valid index for add i.e. 0",
 			clusterClass: clusterv1.ClusterClass{
@@ -1314,6 +1526,258 @@ func TestValidatePatches(t *testing.T) {
 		},
 
 		// Patch with External
+		// CEL expression validation
+		{
+			name: "pass if jsonPatch defines a valid ValueFrom.Expression",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: clusterv1.GroupVersionControlPlane.String(),
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: ptr.To(true),
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:   "add",
+											Path: "/spec/template/spec/",
+											ValueFrom: &clusterv1.JSONPatchValue{
+												Expression: `variables.variableB + "-" + builtin.cluster.name + "-" + template.spec.name`,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error if jsonPatch defines a ValueFrom.Expression with type errors",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: clusterv1.GroupVersionControlPlane.String(),
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: ptr.To(true),
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:   "add",
+											Path: "/spec/template/spec/",
+											ValueFrom: &clusterv1.JSONPatchValue{
+												Expression: `variables.variableB + 1`,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error if jsonPatch defines a ValueFrom.Expression which uses a variable which is not defined",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: clusterv1.GroupVersionControlPlane.String(),
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: ptr.To(true),
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:   "add",
+											Path: "/spec/template/spec/",
+											ValueFrom: &clusterv1.JSONPatchValue{
+												Expression: `variables.variableC`,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error if jsonPatch defines an invalid ValueFrom.Expression",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: clusterv1.GroupVersionControlPlane.String(),
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: ptr.To(true),
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:   "add",
+											Path: "/spec/template/spec/",
+											ValueFrom: &clusterv1.JSONPatchValue{
+												Expression: `variables.variableB +`,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error if jsonPatch has both ValueFrom.Expression and ValueFrom.Variable",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						TemplateRef: clusterv1.ClusterClassTemplateReference{
+							APIVersion: clusterv1.GroupVersionControlPlane.String(),
+							Kind:       "ControlPlaneTemplate",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: clusterv1.GroupVersionControlPlane.String(),
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: ptr.To(true),
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:   "add",
+											Path: "/spec/template/spec/",
+											ValueFrom: &clusterv1.JSONPatchValue{
+												Expression: `variables.variableB`,
+												Variable:   "variableB",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name:     "variableB",
+							Required: ptr.To(true),
+							Schema: clusterv1.VariableSchema{
+								OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+									Type: "string",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "pass if patch defines both external.generatePatchesExtension and external.validateTopologyExtension",
 			clusterClass: clusterv1.ClusterClass{
@@ -2355,6 +2819,71 @@ func Test_validateSelectors(t *testing.T) {
 				Build(),
 			wantErr: true,
 		},
+		{
+			name: "pass if selector targets an existing add-on",
+			selector: clusterv1.PatchSelector{
+				APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+				Kind:       "IPPoolTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"ippool", "ip*", "*pool", "*"},
+					},
+				},
+			},
+			clusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{
+					Name: "ippool",
+					TemplateRef: clusterv1.ClusterClassTemplateReference{
+						APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+						Kind:       "IPPoolTemplate",
+						Name:       "ippool",
+					},
+				}).
+				Build(),
+		},
+		{
+			name: "error if selector targets a non-existing add-on",
+			selector: clusterv1.PatchSelector{
+				APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+				Kind:       "IPPoolTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"identity"},
+					},
+				},
+			},
+			clusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{
+					Name: "ippool",
+					TemplateRef: clusterv1.ClusterClassTemplateReference{
+						APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+						Kind:       "IPPoolTemplate",
+						Name:       "ippool",
+					},
+				}).
+				Build(),
+			wantErr: true,
+		},
+		{
+			name: "pass if selector targets an add-on defined by workload cluster manifests",
+			selector: clusterv1.PatchSelector{
+				APIVersion: "ipam.cluster.x-k8s.io/v1beta2",
+				Kind:       "IPPoolTemplate",
+				MatchResources: clusterv1.PatchSelectorMatch{
+					AddonClass: &clusterv1.PatchSelectorMatchAddonClass{
+						Names: []string{"cni"},
+					},
+				},
+			},
+			clusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithAddons(clusterv1.AddonClass{
+					Name: "cni",
+					WorkloadClusterManifests: clusterv1.AddonClassWorkloadClusterManifests{
+						ConfigMapName: "cni",
+					},
+				}).
+				Build(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -221,6 +221,59 @@ func validateExtensionConfigSpec(e *runtimev1.ExtensionConfig) field.ErrorList {
 			}
 		}
 	}
+	// Validate Authentication if defined
+	if e.Spec.ClientConfig.Authentication.IsDefined() {
+		authentication := e.Spec.ClientConfig.Authentication
+		authenticationPath := specPath.Child("clientConfig", "authentication")
+		if authentication.ClientCertificate.IsDefined() == authentication.ServiceAccountToken.IsDefined() {
+			allErrs = append(allErrs, field.Invalid(
+				authenticationPath,
+				authentication,
+				"exactly one of clientCertificate or serviceAccountToken must be defined",
+			))
+		}
+
+		if authentication.ClientCertificate.IsDefined() {
+			secretRef := authentication.ClientCertificate.SecretRef
+			for _, msg := range validation.IsDNS1123Label(secretRef.Namespace) {
+				allErrs = append(allErrs, field.Invalid(
+					authenticationPath.Child("clientCertificate", "secretRef", "namespace"),
+					secretRef.Namespace,
+					msg,
+				))
+			}
+			for _, msg := range validation.IsDNS1123Subdomain(secretRef.Name) {
+				allErrs = append(allErrs, field.Invalid(
+					authenticationPath.Child("clientCertificate", "secretRef", "name"),
+					secretRef.Name,
+					msg,
+				))
+			}
+		}
+
+		if authentication.ServiceAccountToken.IsDefined() {
+			for _, msg := range validation.IsDNS1123Subdomain(authentication.ServiceAccountToken.Audience) {
+				allErrs = append(allErrs, field.Invalid(
+					authenticationPath.Child("serviceAccountToken", "audience"),
+					authentication.ServiceAccountToken.Audience,
+					msg,
+				))
+			}
+		}
+	}
+
+	// Validate CircuitBreaker if defined
+	if e.Spec.CircuitBreaker.IsDefined() {
+		circuitBreaker := e.Spec.CircuitBreaker
+		if circuitBreaker.OpenSeconds != nil && circuitBreaker.MaxOpenSeconds != nil && *circuitBreaker.MaxOpenSeconds < *circuitBreaker.OpenSeconds {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("circuitBreaker", "maxOpenSeconds"),
+				*circuitBreaker.MaxOpenSeconds,
+				"must be greater than or equal to openSeconds",
+			))
+		}
+	}
+
 	if e.Spec.NamespaceSelector == nil {
 		allErrs = append(allErrs, field.Required(
 			specPath.Child("namespaceSelector"),
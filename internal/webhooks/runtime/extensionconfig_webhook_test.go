@@ -217,6 +217,34 @@ func TestExtensionConfigValidate(t *testing.T) {
 		},
 	}
 
+	extensionWithClientCertificate := extensionWithService.DeepCopy()
+	extensionWithClientCertificate.Spec.ClientConfig.Authentication.ClientCertificate.SecretRef = runtimev1.SecretReference{
+		Namespace: "bar",
+		Name:      "foo-client-cert",
+	}
+
+	extensionWithInvalidClientCertificateSecretRef := extensionWithClientCertificate.DeepCopy()
+	extensionWithInvalidClientCertificateSecretRef.Spec.ClientConfig.Authentication.ClientCertificate.SecretRef.Namespace = "INVALID"
+
+	extensionWithServiceAccountToken := extensionWithService.DeepCopy()
+	extensionWithServiceAccountToken.Spec.ClientConfig.Authentication.ServiceAccountToken.Audience = "foo.extensions.example.com"
+
+	extensionWithInvalidServiceAccountTokenAudience := extensionWithServiceAccountToken.DeepCopy()
+	extensionWithInvalidServiceAccountTokenAudience.Spec.ClientConfig.Authentication.ServiceAccountToken.Audience = "https://kubernetes.default.svc"
+
+	extensionWithClientCertificateAndServiceAccountToken := extensionWithClientCertificate.DeepCopy()
+	extensionWithClientCertificateAndServiceAccountToken.Spec.ClientConfig.Authentication.ServiceAccountToken = extensionWithServiceAccountToken.Spec.ClientConfig.Authentication.ServiceAccountToken
+
+	extensionWithCircuitBreaker := extensionWithService.DeepCopy()
+	extensionWithCircuitBreaker.Spec.CircuitBreaker = runtimev1.CircuitBreaker{
+		FailureThreshold: 5,
+		OpenSeconds:      ptr.To[int32](10),
+		MaxOpenSeconds:   ptr.To[int32](300),
+	}
+
+	extensionWithInvalidCircuitBreaker := extensionWithCircuitBreaker.DeepCopy()
+	extensionWithInvalidCircuitBreaker.Spec.CircuitBreaker.MaxOpenSeconds = ptr.To[int32](5)
+
 	tests := []struct {
 		name        string
 		in          *runtimev1.ExtensionConfig
@@ -322,6 +350,55 @@ func TestExtensionConfigValidate(t *testing.T) {
 			featureGate: true,
 			expectErr:   true,
 		},
+		{
+			name:        "update should pass if client certificate authentication is valid",
+			old:         extensionWithService,
+			in:          extensionWithClientCertificate,
+			featureGate: true,
+			expectErr:   false,
+		},
+		{
+			name:        "update should fail if client certificate Secret reference is invalid",
+			old:         extensionWithService,
+			in:          extensionWithInvalidClientCertificateSecretRef,
+			featureGate: true,
+			expectErr:   true,
+		},
+		{
+			name:        "update should pass if ServiceAccount token authentication is valid",
+			old:         extensionWithService,
+			in:          extensionWithServiceAccountToken,
+			featureGate: true,
+			expectErr:   false,
+		},
+		{
+			name:        "update should fail if ServiceAccount token audience is invalid",
+			old:         extensionWithService,
+			in:          extensionWithInvalidServiceAccountTokenAudience,
+			featureGate: true,
+			expectErr:   true,
+		},
+		{
+			name:        "update should fail if both client certificate and ServiceAccount token authentication are defined",
+			old:         extensionWithService,
+			in:          extensionWithClientCertificateAndServiceAccountToken,
+			featureGate: true,
+			expectErr:   true,
+		},
+		{
+			name:        "update should pass if circuit breaker is valid",
+			old:         extensionWithService,
+			in:          extensionWithCircuitBreaker,
+			featureGate: true,
+			expectErr:   false,
+		},
+		{
+			name:        "update should fail if circuit breaker maxOpenSeconds is lower than openSeconds",
+			old:         extensionWithService,
+			in:          extensionWithInvalidCircuitBreaker,
+			featureGate: true,
+			expectErr:   true,
+		},
 		{
 			name:        "update should pass if updated Extension is valid",
 			old:         extensionWithService,
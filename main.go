@@ -19,9 +19,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	goruntime "runtime"
 	"time"
@@ -81,6 +83,8 @@ import (
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	"sigs.k8s.io/cluster-api/util/apiwarnings"
 	"sigs.k8s.io/cluster-api/util/flags"
+	"sigs.k8s.io/cluster-api/util/sharding"
+	"sigs.k8s.io/cluster-api/util/tunnel"
 	"sigs.k8s.io/cluster-api/version"
 	"sigs.k8s.io/cluster-api/webhooks"
 )
@@ -92,28 +96,38 @@ var (
 	controllerName = "cluster-api-controller-manager"
 
 	// flags.
-	enableLeaderElection        bool
-	leaderElectionLeaseDuration time.Duration
-	leaderElectionRenewDeadline time.Duration
-	leaderElectionRetryPeriod   time.Duration
-	watchFilterValue            string
-	watchNamespace              string
-	profilerAddress             string
-	enableContentionProfiling   bool
-	syncPeriod                  time.Duration
-	restConfigQPS               float32
-	restConfigBurst             int
-	clusterCacheClientQPS       float32
-	clusterCacheClientBurst     int
-	webhookPort                 int
-	webhookCertDir              string
-	webhookCertName             string
-	webhookKeyName              string
-	runtimeExtensionCertFile    string
-	runtimeExtensionKeyFile     string
-	healthAddr                  string
-	managerOptions              = flags.ManagerOptions{}
-	logOptions                  = logs.NewOptions()
+	enableLeaderElection             bool
+	leaderElectionLeaseDuration      time.Duration
+	leaderElectionRenewDeadline      time.Duration
+	leaderElectionRetryPeriod        time.Duration
+	watchFilterValue                 string
+	watchNamespace                   string
+	profilerAddress                  string
+	enableContentionProfiling        bool
+	syncPeriod                       time.Duration
+	restConfigQPS                    float32
+	restConfigBurst                  int
+	clusterCacheClientQPS            float32
+	clusterCacheClientBurst          int
+	webhookPort                      int
+	webhookCertDir                   string
+	webhookCertName                  string
+	webhookKeyName                   string
+	runtimeExtensionCertFile         string
+	runtimeExtensionKeyFile          string
+	runtimeExtensionTokenDir         string
+	runtimeExtensionMaxParallelCalls int
+	healthAddr                       string
+	reverseTunnelGatewayAddress      string
+	reverseTunnelProxyTokenFile      string
+	reverseTunnelAgentBindAddress    string
+	reverseTunnelProxyBindAddress    string
+	reverseTunnelCertDir             string
+	shardingShardCount               int
+	shardingNamespace                string
+	shardingDrainPeriod              time.Duration
+	managerOptions                   = flags.ManagerOptions{}
+	logOptions                       = logs.NewOptions()
 	// core Cluster API specific flags.
 	remoteConnectionGracePeriod      time.Duration
 	remoteConditionsGracePeriod      time.Duration
@@ -272,6 +286,12 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&runtimeExtensionKeyFile, "runtime-extension-client-key-file", "",
 		"Path of the PEM-encoded client key to be used when calling runtime extensions.")
 
+	fs.StringVar(&runtimeExtensionTokenDir, "runtime-extension-token-dir", "/var/run/secrets/runtime.cluster.x-k8s.io/tokens",
+		"Path of the directory containing projected ServiceAccount tokens to be used when calling runtime extensions with serviceAccountToken authentication; tokens must be stored in a file named after the audience.")
+
+	fs.IntVar(&runtimeExtensionMaxParallelCalls, "runtime-extension-max-parallel-calls", internalruntimeclient.DefaultMaxParallelCalls,
+		"Maximum number of runtime extensions called in parallel for the same hook and object.")
+
 	fs.StringVar(&healthAddr, "health-addr", ":9440",
 		"The address the health endpoint binds to.")
 
@@ -281,6 +301,40 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&additionalSyncMachineAnnotations, "additional-sync-machine-annotations", []string{},
 		"List of regexes to select an additional set of labels to sync from a Machine to its associated Node. An annotation will be synced as long as it matches at least one of the regexes.")
 
+	fs.StringVar(&reverseTunnelGatewayAddress, "reverse-tunnel-gateway-address", "",
+		"Address (host:port) of the proxy endpoint of the reverse tunnel gateway, used to access workload clusters with the 'cluster.x-k8s.io/reverse-tunnel' annotation. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelProxyTokenFile, "reverse-tunnel-proxy-token-file", "",
+		"Path of the file containing the token used to authenticate with the proxy endpoint of the reverse tunnel gateway; "+
+			"it is required if --reverse-tunnel-gateway-address or --reverse-tunnel-agent-bind-address is set. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelAgentBindAddress, "reverse-tunnel-agent-bind-address", "",
+		"The address the agent endpoint of the reverse tunnel gateway binds to; if unspecified, the reverse tunnel gateway is not started. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelProxyBindAddress, "reverse-tunnel-proxy-bind-address", ":9446",
+		"The address the proxy endpoint of the reverse tunnel gateway binds to. "+
+			"Controllers must authenticate with the token set with --reverse-tunnel-proxy-token-file. "+
+			"This flag is considered only if the ClusterReverseTunnel feature flag is enabled.")
+
+	fs.StringVar(&reverseTunnelCertDir, "reverse-tunnel-cert-dir", "/tmp/k8s-reverse-tunnel-server/serving-certs/",
+		"Directory containing the certificate (tls.crt) and key (tls.key) used to serve the agent endpoint of the reverse tunnel gateway.")
+
+	fs.IntVar(&shardingShardCount, "sharding-shard-count", 0,
+		"Number of shards Clusters are split into when sharding controllers across replicas; if 0, sharding is disabled. "+
+			"All the replicas must use the same number of shards. "+
+			"This flag is considered only if the ControllerSharding feature flag is enabled.")
+
+	fs.StringVar(&shardingNamespace, "sharding-namespace", "",
+		"Namespace of the Leases used for sharding; if unspecified, the namespace of the controller Pod is used. "+
+			"This flag is considered only if the ControllerSharding feature flag is enabled.")
+
+	fs.DurationVar(&shardingDrainPeriod, "sharding-drain-period", 10*time.Second,
+		"Duration a replica waits after it stopped reconciling a shard before releasing it to another replica. "+
+			"This flag is considered only if the ControllerSharding feature flag is enabled.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -426,8 +480,10 @@ func main() {
 
 	setupChecks(mgr)
 	setupIndexes(ctx, mgr)
-	clusterCache := setupReconcilers(ctx, mgr, watchNamespaces, &syncPeriod)
-	setupWebhooks(ctx, mgr, clusterCache)
+	secretCachingClient := setupSecretCachingClient(mgr)
+	clusterCache, runtimeClient := setupReconcilers(ctx, mgr, secretCachingClient, watchNamespaces, &syncPeriod)
+	setupWebhooks(ctx, mgr, clusterCache, runtimeClient)
+	setupReverseTunnelGateway(mgr, secretCachingClient, tlsOptions)
 
 	setupLog.Info("Starting manager", "version", version.Get().String())
 	if err := mgr.Start(ctx); err != nil {
@@ -455,7 +511,7 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager) {
 	}
 }
 
-func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map[string]cache.Config, syncPeriod *time.Duration) clustercache.ClusterCache {
+func setupSecretCachingClient(mgr ctrl.Manager) client.Client {
 	secretCachingClient, err := client.New(mgr.GetConfig(), client.Options{
 		HTTPClient: mgr.GetHTTPClient(),
 		Cache: &client.CacheOptions{
@@ -466,6 +522,21 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		setupLog.Error(err, "Unable to create secret caching client")
 		os.Exit(1)
 	}
+	return secretCachingClient
+}
+
+func setupReconcilers(ctx context.Context, mgr ctrl.Manager, secretCachingClient client.Client, watchNamespaces map[string]cache.Config, syncPeriod *time.Duration) (clustercache.ClusterCache, runtimeclient.Client) {
+	sharder := setupSharder(mgr)
+
+	var clusterDialer clustercache.ClusterDialer
+	if feature.Gates.Enabled(feature.ClusterReverseTunnel) && reverseTunnelGatewayAddress != "" {
+		proxyToken, err := tunnel.ReadTokenFile(reverseTunnelProxyTokenFile)
+		if err != nil {
+			setupLog.Error(err, "Unable to read reverse tunnel proxy token")
+			os.Exit(1)
+		}
+		clusterDialer = tunnel.NewDialer(mgr.GetClient(), reverseTunnelGatewayAddress, proxyToken).DialContextForCluster
+	}
 
 	clusterCache, err := clustercache.SetupWithManager(ctx, mgr, clustercache.Options{
 		SecretClient: secretCachingClient,
@@ -476,6 +547,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 			QPS:       clusterCacheClientQPS,
 			Burst:     clusterCacheClientBurst,
 			UserAgent: remote.DefaultClusterAPIUserAgent(controllerName),
+			Dialer:    clusterDialer,
 			Cache: clustercache.ClientCacheOptions{
 				DisableFor: []client.Object{
 					// Don't cache ConfigMaps & Secrets.
@@ -491,7 +563,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 			},
 		},
 		WatchFilterValue: watchFilterValue,
-	}, concurrency(clusterCacheConcurrency))
+		Sharder:          sharder,
+	}, shardedConcurrency(clusterCacheConcurrency, sharder))
 	if err != nil {
 		setupLog.Error(err, "Unable to create ClusterCache")
 		os.Exit(1)
@@ -540,11 +613,13 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 	if feature.Gates.Enabled(feature.RuntimeSDK) {
 		// This is the creation of the runtimeClient for the controllers, embedding a shared catalog and registry instance.
 		runtimeClient = internalruntimeclient.New(internalruntimeclient.Options{
-			CertFile: runtimeExtensionCertFile,
-			KeyFile:  runtimeExtensionKeyFile,
-			Catalog:  catalog,
-			Registry: runtimeregistry.New(),
-			Client:   mgr.GetClient(),
+			CertFile:         runtimeExtensionCertFile,
+			KeyFile:          runtimeExtensionKeyFile,
+			TokenDir:         runtimeExtensionTokenDir,
+			MaxParallelCalls: runtimeExtensionMaxParallelCalls,
+			Catalog:          catalog,
+			Registry:         runtimeregistry.New(),
+			Client:           mgr.GetClient(),
 		})
 	}
 
@@ -594,7 +669,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 			RuntimeClient:    runtimeClient,
 			ClusterCache:     clusterCache,
 			WatchFilterValue: watchFilterValue,
-		}).SetupWithManager(ctx, mgr, concurrency(clusterTopologyConcurrency)); err != nil {
+			Sharder:          sharder,
+		}).SetupWithManager(ctx, mgr, shardedConcurrency(clusterTopologyConcurrency, sharder)); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "ClusterTopology")
 			os.Exit(1)
 		}
@@ -635,8 +711,9 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		APIReader:                   mgr.GetAPIReader(),
 		ClusterCache:                clusterCache,
 		WatchFilterValue:            watchFilterValue,
+		Sharder:                     sharder,
 		RemoteConnectionGracePeriod: remoteConnectionGracePeriod,
-	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
+	}).SetupWithManager(ctx, mgr, shardedConcurrency(clusterConcurrency, sharder)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Cluster")
 		os.Exit(1)
 	}
@@ -671,11 +748,13 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:                           mgr.GetClient(),
 		APIReader:                        mgr.GetAPIReader(),
 		ClusterCache:                     clusterCache,
+		RuntimeClient:                    runtimeClient,
 		WatchFilterValue:                 watchFilterValue,
+		Sharder:                          sharder,
 		RemoteConditionsGracePeriod:      remoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      additionalSyncMachineLabelRegexes,
 		AdditionalSyncMachineAnnotations: additionalSyncMachineAnnotationRegexes,
-	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
+	}).SetupWithManager(ctx, mgr, shardedConcurrency(machineConcurrency, sharder)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Machine")
 		os.Exit(1)
 	}
@@ -704,7 +783,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		ClusterCache:     clusterCache,
 		PreflightChecks:  machineSetPreflightChecksSet,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineSetConcurrency)); err != nil {
+		Sharder:          sharder,
+	}).SetupWithManager(ctx, mgr, shardedConcurrency(machineSetConcurrency, sharder)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineSet")
 		os.Exit(1)
 	}
@@ -712,7 +792,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:           mgr.GetClient(),
 		APIReader:        mgr.GetAPIReader(),
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
+		Sharder:          sharder,
+	}).SetupWithManager(ctx, mgr, shardedConcurrency(machineDeploymentConcurrency, sharder)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineDeployment")
 		os.Exit(1)
 	}
@@ -723,7 +804,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 			APIReader:        mgr.GetAPIReader(),
 			ClusterCache:     clusterCache,
 			WatchFilterValue: watchFilterValue,
-		}).SetupWithManager(ctx, mgr, concurrency(machinePoolConcurrency)); err != nil {
+			Sharder:          sharder,
+		}).SetupWithManager(ctx, mgr, shardedConcurrency(machinePoolConcurrency, sharder)); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "MachinePool")
 			os.Exit(1)
 		}
@@ -734,7 +816,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 			Client:           mgr.GetClient(),
 			ClusterCache:     clusterCache,
 			WatchFilterValue: watchFilterValue,
-		}).SetupWithManager(ctx, mgr, concurrency(clusterResourceSetConcurrency), partialSecretCache); err != nil {
+			Sharder:          sharder,
+		}).SetupWithManager(ctx, mgr, shardedConcurrency(clusterResourceSetConcurrency, sharder), partialSecretCache); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "ClusterResourceSet")
 			os.Exit(1)
 		}
@@ -751,15 +834,70 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:           mgr.GetClient(),
 		ClusterCache:     clusterCache,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineHealthCheckConcurrency)); err != nil {
+		Sharder:          sharder,
+	}).SetupWithManager(ctx, mgr, shardedConcurrency(machineHealthCheckConcurrency, sharder)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "MachineHealthCheck")
 		os.Exit(1)
 	}
 
-	return clusterCache
+	return clusterCache, runtimeClient
 }
 
-func setupWebhooks(ctx context.Context, mgr ctrl.Manager, clusterCacheReader webhooks.ClusterCacheReader) {
+func setupSharder(mgr ctrl.Manager) *sharding.Sharder {
+	if !feature.Gates.Enabled(feature.ControllerSharding) || shardingShardCount == 0 {
+		return nil
+	}
+
+	namespace := shardingNamespace
+	if namespace == "" {
+		namespace = os.Getenv("POD_NAMESPACE")
+	}
+	sharder, err := sharding.New(mgr.GetClient(), mgr.GetAPIReader(), sharding.Options{
+		Namespace:     namespace,
+		Name:          "controller-sharding-capi",
+		Shards:        shardingShardCount,
+		LeaseDuration: leaderElectionLeaseDuration,
+		RenewDeadline: leaderElectionRenewDeadline,
+		RetryPeriod:   leaderElectionRetryPeriod,
+		DrainPeriod:   shardingDrainPeriod,
+	})
+	if err != nil {
+		setupLog.Error(err, "Unable to create sharder")
+		os.Exit(1)
+	}
+	if err := mgr.Add(sharder); err != nil {
+		setupLog.Error(err, "Unable to add sharder to manager")
+		os.Exit(1)
+	}
+	setupLog.Info("Sharding controllers across replicas", "shards", shardingShardCount, "identity", sharder.Identity())
+	return sharder
+}
+
+func setupReverseTunnelGateway(mgr ctrl.Manager, secretCachingClient client.Client, tlsOptions []func(*tls.Config)) {
+	if !feature.Gates.Enabled(feature.ClusterReverseTunnel) || reverseTunnelAgentBindAddress == "" {
+		return
+	}
+
+	proxyToken, err := tunnel.ReadTokenFile(reverseTunnelProxyTokenFile)
+	if err != nil {
+		setupLog.Error(err, "Unable to read reverse tunnel proxy token")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&tunnel.GatewayServer{
+		Gateway:          tunnel.NewGateway(secretCachingClient, proxyToken),
+		AgentBindAddress: reverseTunnelAgentBindAddress,
+		ProxyBindAddress: reverseTunnelProxyBindAddress,
+		CertFile:         filepath.Join(reverseTunnelCertDir, "tls.crt"),
+		KeyFile:          filepath.Join(reverseTunnelCertDir, "tls.key"),
+		TLSOpts:          tlsOptions,
+	}); err != nil {
+		setupLog.Error(err, "Unable to create reverse tunnel gateway")
+		os.Exit(1)
+	}
+}
+
+func setupWebhooks(ctx context.Context, mgr ctrl.Manager, clusterCacheReader webhooks.ClusterCacheReader, runtimeClient runtimeclient.Client) {
 	// Setup the func to retrieve apiVersion for a GroupKind for conversion webhooks.
 	apiVersionGetter := func(gk schema.GroupKind) (string, error) {
 		return contract.GetAPIVersion(ctx, mgr.GetClient(), gk)
@@ -775,9 +913,16 @@ func setupWebhooks(ctx context.Context, mgr ctrl.Manager, clusterCacheReader web
 		os.Exit(1)
 	}
 
+	// NOTE: ClusterClassRevision is behind ClusterClassRevisions feature gate flag; the webhook
+	// is going to prevent creating new objects in case the feature flag is disabled.
+	if err := (&webhooks.ClusterClassRevision{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create webhook", "webhook", "ClusterClassRevision")
+		os.Exit(1)
+	}
+
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the webhook
 	// is going to prevent usage of Cluster.Topology in case the feature flag is disabled.
-	if err := (&webhooks.Cluster{Client: mgr.GetClient(), ClusterCacheReader: clusterCacheReader}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.Cluster{Client: mgr.GetClient(), ClusterCacheReader: clusterCacheReader, RuntimeClient: runtimeClient}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", "Cluster")
 		os.Exit(1)
 	}
@@ -850,3 +995,13 @@ func setupWebhooks(ctx context.Context, mgr ctrl.Manager, clusterCacheReader web
 func concurrency(c int) controller.Options {
 	return controller.Options{MaxConcurrentReconciles: c}
 }
+
+// shardedConcurrency returns the options for controllers which are sharded across replicas;
+// if sharding is enabled, these controllers run on all the replicas instead of only on the leader.
+func shardedConcurrency(c int, sharder *sharding.Sharder) controller.Options {
+	options := concurrency(c)
+	if sharder != nil {
+		options.NeedLeaderElection = ptr.To(false)
+	}
+	return options
+}
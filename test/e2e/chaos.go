@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// ChaosDisruption is a disruption injected by ChaosSpec.
+type ChaosDisruption string
+
+const (
+	// KillControlPlaneMachineDisruption kills a control plane machine and checks it is remediated by KCP.
+	KillControlPlaneMachineDisruption ChaosDisruption = "KillControlPlaneMachine"
+
+	// PartitionWorkerMachineDisruption partitions a worker machine from the control plane endpoint and checks
+	// it is remediated by the MachineHealthCheck.
+	PartitionWorkerMachineDisruption ChaosDisruption = "PartitionWorkerMachine"
+
+	// DeleteInfrastructureMachineDisruption deletes the InfrastructureMachine of a worker machine out of band and
+	// checks the Machine is remediated by the MachineHealthCheck.
+	DeleteInfrastructureMachineDisruption ChaosDisruption = "DeleteInfrastructureMachine"
+
+	// FillEtcdDisruption fills etcd until the NOSPACE alarm is raised and checks the alarm is remediated by KCP.
+	FillEtcdDisruption ChaosDisruption = "FillEtcd"
+
+	// PartitionManagementClusterDisruption partitions the management cluster from the workload cluster and checks
+	// ClusterCache reconnects when the partition is healed.
+	PartitionManagementClusterDisruption ChaosDisruption = "PartitionManagementCluster"
+
+	// RestartControllersDuringRolloutDisruption restarts all the controllers while the machines of the
+	// workload cluster are rolled out, and checks the rollout completes.
+	RestartControllersDuringRolloutDisruption ChaosDisruption = "RestartControllersDuringRollout"
+)
+
+// AllChaosDisruptions is the list of all the disruptions injected by ChaosSpec, in the order they are injected.
+var AllChaosDisruptions = []ChaosDisruption{
+	KillControlPlaneMachineDisruption,
+	PartitionWorkerMachineDisruption,
+	DeleteInfrastructureMachineDisruption,
+	FillEtcdDisruption,
+	PartitionManagementClusterDisruption,
+	RestartControllersDuringRolloutDisruption,
+}
+
+// ChaosSpecInput is the input for ChaosSpec.
+type ChaosSpecInput struct {
+	// This spec requires following intervals to be defined in order to work:
+	// - wait-cluster, used when waiting for the cluster infrastructure to be provisioned.
+	// - wait-control-plane, used when waiting for the control plane to be provisioned.
+	// - wait-worker-nodes, used when waiting for the worker nodes to be provisioned.
+	// - wait-machine-remediation, used when waiting for an unhealthy machine to be remediated.
+	// - wait-cluster-converged, used when waiting for the cluster to converge after a disruption.
+	// - wait-etcd-alarm, used when waiting for KCP to report the etcd NOSPACE alarm.
+	// - wait-etcd-alarm-remediation, used when waiting for KCP to remediate the etcd NOSPACE alarm.
+	// - wait-cluster-disconnected, used when waiting for the cluster to be disconnected from the management cluster.
+	// - wait-cluster-reconnected, used when waiting for the cluster to be reconnected to the management cluster.
+	// - wait-controllers, used when waiting for the controllers to be available after a restart.
+	// - wait-rollout-started, used when waiting for a rollout to start.
+	// - wait-rollout, used when waiting for a rollout to complete.
+	E2EConfig             *clusterctl.E2EConfig
+	ClusterctlConfigPath  string
+	BootstrapClusterProxy framework.ClusterProxy
+	ArtifactFolder        string
+	SkipCleanup           bool
+	ControlPlaneWaiters   clusterctl.ControlPlaneWaiters
+
+	// InfrastructureProviders specifies the infrastructure to use for clusterctl
+	// operations (Example: get cluster templates).
+	// Note: In most cases this need not be specified. It only needs to be specified when
+	// multiple infrastructure providers are installed on the cluster as clusterctl will not be
+	// able to identify the default.
+	InfrastructureProvider *string
+
+	// Flavor, if specified, must refer to a template that has
+	// - a KubeadmControlPlane (with a small etcd backend quota and spec.etcdMaintenance.noSpaceAlarmRemediation
+	//   set to Remediate if FillEtcdDisruption is injected) and one MachineDeployment.
+	// - MachineHealthChecks targeting both control plane and worker machines, treating Ready Unknown/False
+	//   as unhealthy conditions with a short timeout.
+	// If not specified, "chaos" is used.
+	Flavor *string
+
+	// Disruptor is used to disrupt the infrastructure hosting the workload cluster.
+	// If not specified, framework.DockerInfrastructureDisruptor is used.
+	Disruptor framework.InfrastructureDisruptor
+
+	// Disruptions to be injected, in order. If not specified, AllChaosDisruptions is used.
+	Disruptions []ChaosDisruption
+
+	// Allows to inject a function to be run after test namespace is created.
+	// If not specified, this is a no-op.
+	PostNamespaceCreated func(managementClusterProxy framework.ClusterProxy, workloadClusterNamespace string)
+}
+
+// ChaosSpec implements a test that injects disruptions into a workload cluster, its infrastructure and the management cluster,
+// and verifies that after each disruption the system converges back to the desired state.
+func ChaosSpec(ctx context.Context, inputGetter func() ChaosSpecInput) {
+	var (
+		specName         = "chaos"
+		input            ChaosSpecInput
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		input = inputGetter()
+		Expect(input.E2EConfig).ToNot(BeNil(), "Invalid argument. input.E2EConfig can't be nil when calling %s spec", specName)
+		Expect(input.ClusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. input.ClusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(input.BootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. input.BootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(input.ArtifactFolder, 0750)).To(Succeed(), "Invalid argument. input.ArtifactFolder can't be created for %s spec", specName)
+		Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersion))
+		for _, d := range input.Disruptions {
+			Expect(AllChaosDisruptions).To(ContainElement(d), "Invalid argument. input.Disruptions contains an unknown disruption %q when calling %s spec", d, specName)
+		}
+
+		if input.Disruptor == nil {
+			input.Disruptor = framework.DockerInfrastructureDisruptor{}
+		}
+		if len(input.Disruptions) == 0 {
+			input.Disruptions = AllChaosDisruptions
+		}
+
+		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
+		namespace, cancelWatches = framework.SetupSpecNamespace(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, input.PostNamespaceCreated)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	It("Should converge after disruptions", func() {
+		By("Creating a workload cluster")
+
+		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		if input.InfrastructureProvider != nil {
+			infrastructureProvider = *input.InfrastructureProvider
+		}
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: input.BootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     input.ClusterctlConfigPath,
+				KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   infrastructureProvider,
+				Flavor:                   ptr.Deref(input.Flavor, "chaos"),
+				Namespace:                namespace.Name,
+				ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+				KubernetesVersion:        input.E2EConfig.MustGetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: ptr.To[int64](3),
+				WorkerMachineCount:       ptr.To[int64](2),
+			},
+			ControlPlaneWaiters:          input.ControlPlaneWaiters,
+			WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		cluster := clusterResources.Cluster
+		mgmtClient := input.BootstrapClusterProxy.GetClient()
+		waitForClusterToConverge := func() {
+			framework.WaitForClusterToConverge(ctx, framework.WaitForClusterToConvergeInput{
+				Getter:  mgmtClient,
+				Cluster: cluster,
+			}, input.E2EConfig.GetIntervals(specName, "wait-cluster-converged")...)
+		}
+		controlPlaneMachine := func() *clusterv1.Machine {
+			machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+				Lister:      mgmtClient,
+				ClusterName: cluster.Name,
+				Namespace:   cluster.Namespace,
+			})
+			Expect(machines).ToNot(BeEmpty(), "Cluster %s should have control plane Machines", cluster.Name)
+			return &machines[0]
+		}
+		workerMachine := func() *clusterv1.Machine {
+			machines := framework.GetMachinesByMachineDeployments(ctx, framework.GetMachinesByMachineDeploymentsInput{
+				Lister:            mgmtClient,
+				ClusterName:       cluster.Name,
+				Namespace:         cluster.Namespace,
+				MachineDeployment: *clusterResources.MachineDeployments[0],
+			})
+			Expect(machines).ToNot(BeEmpty(), "MachineDeployment %s should have Machines", clusterResources.MachineDeployments[0].Name)
+			return &machines[0]
+		}
+
+		for _, disruption := range input.Disruptions {
+			switch disruption {
+			case KillControlPlaneMachineDisruption:
+				By("Killing a control plane Machine and waiting for KCP remediation")
+				framework.KillMachineAndWaitForRemediation(ctx, framework.KillMachineAndWaitForRemediationInput{
+					ClusterProxy:              input.BootstrapClusterProxy,
+					Cluster:                   cluster,
+					Machine:                   controlPlaneMachine(),
+					Disruptor:                 input.Disruptor,
+					WaitForMachineRemediation: input.E2EConfig.GetIntervals(specName, "wait-machine-remediation"),
+				})
+
+			case PartitionWorkerMachineDisruption:
+				By("Partitioning a worker Machine from the control plane endpoint and waiting for MHC remediation")
+				framework.PartitionMachineAndWaitForRemediation(ctx, framework.PartitionMachineAndWaitForRemediationInput{
+					ClusterProxy:              input.BootstrapClusterProxy,
+					Cluster:                   cluster,
+					Machine:                   workerMachine(),
+					Disruptor:                 input.Disruptor,
+					WaitForMachineRemediation: input.E2EConfig.GetIntervals(specName, "wait-machine-remediation"),
+				})
+
+			case DeleteInfrastructureMachineDisruption:
+				By("Deleting the InfrastructureMachine of a worker Machine out of band and waiting for MHC remediation")
+				framework.DeleteInfrastructureMachineAndWaitForRemediation(ctx, framework.DeleteInfrastructureMachineAndWaitForRemediationInput{
+					ClusterProxy:              input.BootstrapClusterProxy,
+					Machine:                   workerMachine(),
+					WaitForMachineRemediation: input.E2EConfig.GetIntervals(specName, "wait-machine-remediation"),
+				})
+
+			case FillEtcdDisruption:
+				By("Filling etcd and waiting for KCP to remediate the NOSPACE alarm")
+				framework.FillEtcdAndWaitForAlarmRemediation(ctx, framework.FillEtcdAndWaitForAlarmRemediationInput{
+					ClusterProxy:                input.BootstrapClusterProxy,
+					Cluster:                     cluster,
+					WaitForEtcdAlarm:            input.E2EConfig.GetIntervals(specName, "wait-etcd-alarm"),
+					WaitForEtcdAlarmRemediation: input.E2EConfig.GetIntervals(specName, "wait-etcd-alarm-remediation"),
+				})
+
+			case PartitionManagementClusterDisruption:
+				By("Partitioning the management cluster from the workload cluster and waiting for ClusterCache to reconnect")
+				framework.PartitionManagementClusterAndWaitForReconnect(ctx, framework.PartitionManagementClusterAndWaitForReconnectInput{
+					ClusterProxy:              input.BootstrapClusterProxy,
+					Cluster:                   cluster,
+					Disruptor:                 input.Disruptor,
+					WaitForClusterDisconnect:  input.E2EConfig.GetIntervals(specName, "wait-cluster-disconnected"),
+					WaitForClusterReconnected: input.E2EConfig.GetIntervals(specName, "wait-cluster-reconnected"),
+				})
+
+			case RestartControllersDuringRolloutDisruption:
+				By("Restarting controllers during a rollout and waiting for the rollout to complete")
+				rolloutAfter := framework.TriggerRollout(ctx, framework.TriggerRolloutInput{
+					ClusterProxy: input.BootstrapClusterProxy,
+					Cluster:      cluster,
+				})
+				rolloutInput := framework.WaitForRolloutInput{
+					Lister:       mgmtClient,
+					Cluster:      cluster,
+					RolloutAfter: rolloutAfter,
+				}
+				framework.WaitForRolloutToStart(ctx, rolloutInput, input.E2EConfig.GetIntervals(specName, "wait-rollout-started")...)
+				framework.RestartControllersAndWait(ctx, framework.RestartControllersAndWaitInput{
+					ClusterProxy: input.BootstrapClusterProxy,
+					Deployments: framework.GetControllerDeployments(ctx, framework.GetControllerDeploymentsInput{
+						Lister: mgmtClient,
+					}),
+				}, input.E2EConfig.GetIntervals(specName, "wait-controllers")...)
+				framework.WaitForRolloutToComplete(ctx, rolloutInput, input.E2EConfig.GetIntervals(specName, "wait-rollout")...)
+			}
+
+			Byf("Waiting for the Cluster to converge after the %s disruption", disruption)
+			waitForClusterToConverge()
+		}
+
+		By("PASSED!")
+	})
+
+	AfterEach(func() {
+		// Dumps all the resources in the spec namespace, then cleanups the cluster object and the spec namespace itself.
+		framework.DumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ClusterctlConfigPath, input.ArtifactFolder, namespace, cancelWatches, clusterResources.Cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
+	})
+}
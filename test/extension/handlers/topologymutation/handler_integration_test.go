@@ -44,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -499,3 +500,11 @@ func (i injectRuntimeClient) Unregister(_ *runtimev1.ExtensionConfig) error {
 func (i injectRuntimeClient) CallAllExtensions(_ context.Context, _ runtimecatalog.Hook, _ metav1.Object, _ runtimehooksv1.RequestObject, _ runtimehooksv1.ResponseObject) error {
 	panic("implement me")
 }
+
+func (i injectRuntimeClient) GetCircuitBreakerState(_ string) runtimeclient.CircuitBreakerState {
+	panic("implement me")
+}
+
+func (i injectRuntimeClient) GetCircuitBreakerSource() source.Source {
+	panic("implement me")
+}
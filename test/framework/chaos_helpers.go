@@ -0,0 +1,571 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// InfrastructureDisruptor disrupts the infrastructure hosting workload clusters behind the back of Cluster API
+// and of the infrastructure provider, thus allowing to test that the system converges back to the desired state.
+// Infrastructure providers can implement this interface in order to re-use chaos tests; see DockerInfrastructureDisruptor
+// for the implementation used with CAPD.
+type InfrastructureDisruptor interface {
+	// KillMachine abruptly stops the infrastructure hosting a Machine, e.g. by killing a container or powering off a VM.
+	KillMachine(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error
+
+	// PartitionMachine drops the traffic from a Machine to the control plane endpoint of the workload cluster.
+	PartitionMachine(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error
+
+	// HealMachinePartition restores the traffic from a Machine to the control plane endpoint of the workload cluster.
+	HealMachinePartition(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error
+
+	// PartitionManagementCluster drops the traffic from the management cluster to the control plane endpoint of the workload cluster.
+	PartitionManagementCluster(ctx context.Context, managementClusterProxy ClusterProxy, cluster *clusterv1.Cluster) error
+
+	// HealManagementClusterPartition restores the traffic from the management cluster to the control plane endpoint of the workload cluster.
+	HealManagementClusterPartition(ctx context.Context, managementClusterProxy ClusterProxy, cluster *clusterv1.Cluster) error
+}
+
+// etcdFillerLabel is the label applied to the ConfigMaps created by FillEtcdAndWaitForAlarmRemediation.
+const etcdFillerLabel = "chaos.cluster.x-k8s.io/etcd-filler"
+
+// KillMachineAndWaitForRemediationInput is the input for KillMachineAndWaitForRemediation.
+type KillMachineAndWaitForRemediationInput struct {
+	ClusterProxy              ClusterProxy
+	Cluster                   *clusterv1.Cluster
+	Machine                   *clusterv1.Machine
+	Disruptor                 InfrastructureDisruptor
+	WaitForMachineRemediation []interface{}
+}
+
+// KillMachineAndWaitForRemediation kills the infrastructure hosting a Machine and waits for the Machine to be remediated,
+// e.g. by KCP for control plane machines or by the MachineSet for worker machines.
+// NOTE: This requires a MachineHealthCheck matching the Machine and detecting the Node not being Ready.
+func KillMachineAndWaitForRemediation(ctx context.Context, input KillMachineAndWaitForRemediationInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for KillMachineAndWaitForRemediation")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling KillMachineAndWaitForRemediation")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling KillMachineAndWaitForRemediation")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling KillMachineAndWaitForRemediation")
+	Expect(input.Disruptor).ToNot(BeNil(), "Invalid argument. input.Disruptor can't be nil when calling KillMachineAndWaitForRemediation")
+
+	log.Logf("Killing Machine %s", klog.KObj(input.Machine))
+	Expect(input.Disruptor.KillMachine(ctx, input.Cluster, input.Machine)).To(Succeed(), "Failed to kill Machine %s", klog.KObj(input.Machine))
+
+	WaitForMachineRemediation(ctx, WaitForMachineRemediationInput{
+		Getter:  input.ClusterProxy.GetClient(),
+		Machine: input.Machine,
+	}, input.WaitForMachineRemediation...)
+}
+
+// PartitionMachineAndWaitForRemediationInput is the input for PartitionMachineAndWaitForRemediation.
+type PartitionMachineAndWaitForRemediationInput struct {
+	ClusterProxy              ClusterProxy
+	Cluster                   *clusterv1.Cluster
+	Machine                   *clusterv1.Machine
+	Disruptor                 InfrastructureDisruptor
+	WaitForMachineRemediation []interface{}
+}
+
+// PartitionMachineAndWaitForRemediation partitions a Machine from the control plane endpoint of the workload cluster,
+// and waits for the Machine to be remediated because its Node stops reporting its status.
+// NOTE: This requires a MachineHealthCheck matching the Machine and detecting the Node not being Ready.
+func PartitionMachineAndWaitForRemediation(ctx context.Context, input PartitionMachineAndWaitForRemediationInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for PartitionMachineAndWaitForRemediation")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling PartitionMachineAndWaitForRemediation")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling PartitionMachineAndWaitForRemediation")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling PartitionMachineAndWaitForRemediation")
+	Expect(input.Disruptor).ToNot(BeNil(), "Invalid argument. input.Disruptor can't be nil when calling PartitionMachineAndWaitForRemediation")
+
+	log.Logf("Partitioning Machine %s from the control plane endpoint", klog.KObj(input.Machine))
+	Expect(input.Disruptor.PartitionMachine(ctx, input.Cluster, input.Machine)).To(Succeed(), "Failed to partition Machine %s", klog.KObj(input.Machine))
+
+	// NOTE: there is no need to heal the partition, because the infrastructure of the Machine is deleted during remediation.
+	WaitForMachineRemediation(ctx, WaitForMachineRemediationInput{
+		Getter:  input.ClusterProxy.GetClient(),
+		Machine: input.Machine,
+	}, input.WaitForMachineRemediation...)
+}
+
+// DeleteInfrastructureMachineAndWaitForRemediationInput is the input for DeleteInfrastructureMachineAndWaitForRemediation.
+type DeleteInfrastructureMachineAndWaitForRemediationInput struct {
+	ClusterProxy              ClusterProxy
+	Machine                   *clusterv1.Machine
+	WaitForMachineRemediation []interface{}
+}
+
+// DeleteInfrastructureMachineAndWaitForRemediation deletes the InfrastructureMachine of a Machine without going
+// through the Machine, e.g. like a user or another controller deleting it by mistake, and waits for the Machine to be remediated.
+// NOTE: This requires an infrastructure provider deleting the infrastructure when the InfrastructureMachine is deleted,
+// and a MachineHealthCheck matching the Machine and detecting the Node not being Ready.
+func DeleteInfrastructureMachineAndWaitForRemediation(ctx context.Context, input DeleteInfrastructureMachineAndWaitForRemediationInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for DeleteInfrastructureMachineAndWaitForRemediation")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling DeleteInfrastructureMachineAndWaitForRemediation")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling DeleteInfrastructureMachineAndWaitForRemediation")
+
+	mgmtClient := input.ClusterProxy.GetClient()
+	infraRef := input.Machine.Spec.InfrastructureRef
+	log.Logf("Deleting %s %s of Machine %s out of band", infraRef.Kind, klog.KRef(input.Machine.Namespace, infraRef.Name), klog.KObj(input.Machine))
+	Eventually(func() error {
+		infraMachine, err := external.GetObjectFromContractVersionedRef(ctx, mgmtClient, infraRef, input.Machine.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		return client.IgnoreNotFound(mgmtClient.Delete(ctx, infraMachine))
+	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to delete %s %s", infraRef.Kind, klog.KRef(input.Machine.Namespace, infraRef.Name))
+
+	WaitForMachineRemediation(ctx, WaitForMachineRemediationInput{
+		Getter:  mgmtClient,
+		Machine: input.Machine,
+	}, input.WaitForMachineRemediation...)
+}
+
+// WaitForMachineRemediationInput is the input for WaitForMachineRemediation.
+type WaitForMachineRemediationInput struct {
+	Getter  Getter
+	Machine *clusterv1.Machine
+}
+
+// WaitForMachineRemediation waits for an unhealthy Machine to be remediated, i.e. to be deleted.
+// NOTE: Use WaitForClusterToConverge to check that the Machine has been replaced.
+func WaitForMachineRemediation(ctx context.Context, input WaitForMachineRemediationInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForMachineRemediation")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForMachineRemediation")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling WaitForMachineRemediation")
+
+	log.Logf("Waiting for Machine %s to be remediated", klog.KObj(input.Machine))
+	Eventually(func(g Gomega) {
+		machine := &clusterv1.Machine{}
+		err := input.Getter.Get(ctx, client.ObjectKeyFromObject(input.Machine), machine)
+		if apierrors.IsNotFound(err) {
+			return
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		// Guard against a Machine with the same name being re-created.
+		g.Expect(machine.UID).ToNot(Equal(input.Machine.UID), "Machine %s has not been remediated yet", klog.KObj(input.Machine))
+	}, intervals...).Should(Succeed(), "Failed waiting for Machine %s to be remediated", klog.KObj(input.Machine))
+}
+
+// FillEtcdAndWaitForAlarmRemediationInput is the input for FillEtcdAndWaitForAlarmRemediation.
+type FillEtcdAndWaitForAlarmRemediationInput struct {
+	ClusterProxy ClusterProxy
+	Cluster      *clusterv1.Cluster
+
+	// Namespace in the workload cluster where ConfigMaps filling etcd are created. If not set, default is used.
+	Namespace string
+
+	// MaxObjects is the maximum number of ConfigMaps created to fill etcd. If not set, 1000 is used.
+	MaxObjects int
+
+	WaitForEtcdAlarm            []interface{}
+	WaitForEtcdAlarmRemediation []interface{}
+}
+
+// FillEtcdAndWaitForAlarmRemediation fills etcd in a workload cluster with ConfigMaps until the backend quota is exceeded,
+// waits for KCP to report the NOSPACE alarm, deletes the ConfigMaps and then waits for KCP to remediate the alarm,
+// i.e. for the etcd cluster to be healthy and for the workload cluster to accept writes again.
+// NOTE: This requires a KubeadmControlPlane with a small etcd backend quota (the etcd quota-backend-bytes flag)
+// and with spec.etcdMaintenance.noSpaceAlarmRemediation set to Remediate.
+func FillEtcdAndWaitForAlarmRemediation(ctx context.Context, input FillEtcdAndWaitForAlarmRemediationInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for FillEtcdAndWaitForAlarmRemediation")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling FillEtcdAndWaitForAlarmRemediation")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling FillEtcdAndWaitForAlarmRemediation")
+
+	namespace := input.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	maxObjects := input.MaxObjects
+	if maxObjects == 0 {
+		maxObjects = 1000
+	}
+
+	mgmtClient := input.ClusterProxy.GetClient()
+	workloadClient := input.ClusterProxy.GetWorkloadCluster(ctx, input.Cluster.Namespace, input.Cluster.Name).GetClient()
+	controlPlane := GetKubeadmControlPlaneByCluster(ctx, GetKubeadmControlPlaneByClusterInput{
+		Lister:      mgmtClient,
+		ClusterName: input.Cluster.Name,
+		Namespace:   input.Cluster.Namespace,
+	})
+	Expect(controlPlane).ToNot(BeNil(), "Failed to get the KubeadmControlPlane for Cluster %s", klog.KObj(input.Cluster))
+
+	// Each ConfigMap stores ~900KiB, which is below the max size of an etcd request (1.5MiB).
+	data := strings.Repeat("x", 900*1024)
+	log.Logf("Filling etcd of Cluster %s", klog.KObj(input.Cluster))
+	quotaExceeded := false
+	for i := 0; i < maxObjects && !quotaExceeded; i++ {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("etcd-filler-%s", util.RandomString(6)),
+				Namespace: namespace,
+				Labels:    map[string]string{etcdFillerLabel: ""},
+			},
+			Data: map[string]string{"data": data},
+		}
+		Eventually(func() error {
+			err := workloadClient.Create(ctx, cm)
+			if err != nil && isEtcdQuotaExceeded(err) {
+				quotaExceeded = true
+				return nil
+			}
+			return err
+		}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to create ConfigMap %s", klog.KObj(cm))
+	}
+	Expect(quotaExceeded).To(BeTrue(), "Failed to fill etcd with %d ConfigMaps; check the etcd quota-backend-bytes flag", maxObjects)
+
+	log.Logf("Waiting for KubeadmControlPlane %s to report the NOSPACE alarm", klog.KObj(controlPlane))
+	Eventually(func(g Gomega) {
+		machines := &clusterv1.MachineList{}
+		g.Expect(mgmtClient.List(ctx, machines, client.InNamespace(input.Cluster.Namespace), client.MatchingLabels{
+			clusterv1.ClusterNameLabel:         input.Cluster.Name,
+			clusterv1.MachineControlPlaneLabel: "",
+		})).To(Succeed())
+
+		alarm := false
+		for i := range machines.Items {
+			c := conditions.Get(&machines.Items[i], controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition)
+			if c != nil && c.Status == metav1.ConditionFalse && strings.Contains(c.Message, "NOSPACE") {
+				alarm = true
+			}
+		}
+		g.Expect(alarm).To(BeTrue(), "No control plane Machine reports the NOSPACE alarm")
+	}, input.WaitForEtcdAlarm...).Should(Succeed(), "Failed waiting for KubeadmControlPlane %s to report the NOSPACE alarm", klog.KObj(controlPlane))
+
+	// NOTE: etcd accepts deletes while the NOSPACE alarm is active.
+	log.Logf("Deleting the ConfigMaps filling etcd of Cluster %s", klog.KObj(input.Cluster))
+	Eventually(func() error {
+		return workloadClient.DeleteAllOf(ctx, &corev1.ConfigMap{}, client.InNamespace(namespace), client.MatchingLabels{etcdFillerLabel: ""})
+	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to delete the ConfigMaps filling etcd")
+
+	log.Logf("Waiting for KubeadmControlPlane %s to remediate the NOSPACE alarm", klog.KObj(controlPlane))
+	Eventually(func(g Gomega) {
+		kcp := &controlplanev1.KubeadmControlPlane{}
+		g.Expect(mgmtClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), kcp)).To(Succeed())
+		g.Expect(conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition)).To(BeTrue(),
+			"The EtcdClusterHealthy condition on KubeadmControlPlane %s should be true", klog.KObj(kcp))
+
+		// Check the workload cluster accepts writes again.
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("etcd-filler-%s", util.RandomString(6)),
+				Namespace: namespace,
+			},
+		}
+		g.Expect(workloadClient.Create(ctx, cm)).To(Succeed())
+		g.Expect(workloadClient.Delete(ctx, cm)).To(Succeed())
+	}, input.WaitForEtcdAlarmRemediation...).Should(Succeed(), "Failed waiting for KubeadmControlPlane %s to remediate the NOSPACE alarm", klog.KObj(controlPlane))
+}
+
+// isEtcdQuotaExceeded returns true if the error is returned by the API server because etcd exceeded its backend quota.
+func isEtcdQuotaExceeded(err error) bool {
+	return strings.Contains(err.Error(), "database space exceeded")
+}
+
+// PartitionManagementClusterAndWaitForReconnectInput is the input for PartitionManagementClusterAndWaitForReconnect.
+type PartitionManagementClusterAndWaitForReconnectInput struct {
+	ClusterProxy              ClusterProxy
+	Cluster                   *clusterv1.Cluster
+	Disruptor                 InfrastructureDisruptor
+	WaitForClusterDisconnect  []interface{}
+	WaitForClusterReconnected []interface{}
+}
+
+// PartitionManagementClusterAndWaitForReconnect partitions the management cluster from a workload cluster, waits for
+// the RemoteConnectionProbe condition of the Cluster to become false, heals the partition and then waits for
+// ClusterCache to reconnect to the workload cluster, i.e. for the RemoteConnectionProbe condition to become true again.
+func PartitionManagementClusterAndWaitForReconnect(ctx context.Context, input PartitionManagementClusterAndWaitForReconnectInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for PartitionManagementClusterAndWaitForReconnect")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling PartitionManagementClusterAndWaitForReconnect")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling PartitionManagementClusterAndWaitForReconnect")
+	Expect(input.Disruptor).ToNot(BeNil(), "Invalid argument. input.Disruptor can't be nil when calling PartitionManagementClusterAndWaitForReconnect")
+
+	mgmtClient := input.ClusterProxy.GetClient()
+	remoteConnectionProbe := func(status metav1.ConditionStatus) func(g Gomega) {
+		return func(g Gomega) {
+			cluster := &clusterv1.Cluster{}
+			g.Expect(mgmtClient.Get(ctx, client.ObjectKeyFromObject(input.Cluster), cluster)).To(Succeed())
+			c := conditions.Get(cluster, clusterv1.ClusterRemoteConnectionProbeCondition)
+			g.Expect(c).ToNot(BeNil(), "Cluster %s should have a RemoteConnectionProbe condition", klog.KObj(cluster))
+			g.Expect(c.Status).To(Equal(status), "The RemoteConnectionProbe condition on Cluster %s should be %s", klog.KObj(cluster), status)
+		}
+	}
+
+	log.Logf("Partitioning the management cluster from Cluster %s", klog.KObj(input.Cluster))
+	Expect(input.Disruptor.PartitionManagementCluster(ctx, input.ClusterProxy, input.Cluster)).To(Succeed(), "Failed to partition the management cluster from Cluster %s", klog.KObj(input.Cluster))
+	healed := false
+	defer func() {
+		// Make sure the partition is healed even if the test fails.
+		if !healed {
+			_ = input.Disruptor.HealManagementClusterPartition(ctx, input.ClusterProxy, input.Cluster)
+		}
+	}()
+
+	log.Logf("Waiting for Cluster %s to be disconnected", klog.KObj(input.Cluster))
+	Eventually(remoteConnectionProbe(metav1.ConditionFalse), input.WaitForClusterDisconnect...).Should(Succeed(), "Failed waiting for Cluster %s to be disconnected", klog.KObj(input.Cluster))
+
+	log.Logf("Healing the partition between the management cluster and Cluster %s", klog.KObj(input.Cluster))
+	Expect(input.Disruptor.HealManagementClusterPartition(ctx, input.ClusterProxy, input.Cluster)).To(Succeed(), "Failed to heal the partition between the management cluster and Cluster %s", klog.KObj(input.Cluster))
+	healed = true
+
+	log.Logf("Waiting for Cluster %s to be reconnected", klog.KObj(input.Cluster))
+	Eventually(remoteConnectionProbe(metav1.ConditionTrue), input.WaitForClusterReconnected...).Should(Succeed(), "Failed waiting for Cluster %s to be reconnected", klog.KObj(input.Cluster))
+}
+
+// TriggerRolloutInput is the input for TriggerRollout.
+type TriggerRolloutInput struct {
+	ClusterProxy ClusterProxy
+	Cluster      *clusterv1.Cluster
+}
+
+// TriggerRollout triggers a rollout of all the Machines of a Cluster by setting spec.rollout.after on the KubeadmControlPlane
+// and on the MachineDeployments of the Cluster; it returns the time used for spec.rollout.after.
+// NOTE: This func does not support Clusters with a managed topology.
+func TriggerRollout(ctx context.Context, input TriggerRolloutInput) time.Time {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for TriggerRollout")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling TriggerRollout")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling TriggerRollout")
+	Expect(input.Cluster.Spec.Topology.IsDefined()).To(BeFalse(), "Invalid argument. input.Cluster can't have a managed topology when calling TriggerRollout")
+
+	mgmtClient := input.ClusterProxy.GetClient()
+	rolloutAfter := metav1.Now().Rfc3339Copy()
+
+	controlPlane := GetKubeadmControlPlaneByCluster(ctx, GetKubeadmControlPlaneByClusterInput{
+		Lister:      mgmtClient,
+		ClusterName: input.Cluster.Name,
+		Namespace:   input.Cluster.Namespace,
+	})
+	if controlPlane != nil {
+		log.Logf("Triggering a rollout of KubeadmControlPlane %s", klog.KObj(controlPlane))
+		patchHelper, err := patch.NewHelper(controlPlane, mgmtClient)
+		Expect(err).ToNot(HaveOccurred())
+		controlPlane.Spec.Rollout.After = rolloutAfter
+		Eventually(func() error {
+			return patchHelper.Patch(ctx, controlPlane)
+		}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to patch KubeadmControlPlane %s", klog.KObj(controlPlane))
+	}
+
+	for _, md := range GetMachineDeploymentsByCluster(ctx, GetMachineDeploymentsByClusterInput{
+		Lister:      mgmtClient,
+		ClusterName: input.Cluster.Name,
+		Namespace:   input.Cluster.Namespace,
+	}) {
+		log.Logf("Triggering a rollout of MachineDeployment %s", klog.KObj(md))
+		patchHelper, err := patch.NewHelper(md, mgmtClient)
+		Expect(err).ToNot(HaveOccurred())
+		md.Spec.Rollout.After = rolloutAfter
+		Eventually(func() error {
+			return patchHelper.Patch(ctx, md)
+		}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to patch MachineDeployment %s", klog.KObj(md))
+	}
+	return rolloutAfter.Time
+}
+
+// WaitForRolloutInput is the input for WaitForRolloutToStart and WaitForRolloutToComplete.
+type WaitForRolloutInput struct {
+	Lister  Lister
+	Cluster *clusterv1.Cluster
+
+	// RolloutAfter is the time returned by TriggerRollout.
+	RolloutAfter time.Time
+}
+
+// WaitForRolloutToStart waits for at least one Machine of a Cluster to be created after the rollout has been triggered.
+func WaitForRolloutToStart(ctx context.Context, input WaitForRolloutInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForRolloutToStart")
+	Expect(input.Lister).ToNot(BeNil(), "Invalid argument. input.Lister can't be nil when calling WaitForRolloutToStart")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling WaitForRolloutToStart")
+
+	log.Logf("Waiting for the rollout of Cluster %s to start", klog.KObj(input.Cluster))
+	Eventually(func(g Gomega) {
+		newMachines, _ := rolloutMachines(ctx, g, input)
+		g.Expect(newMachines).ToNot(BeEmpty(), "No Machines created after the rollout has been triggered")
+	}, intervals...).Should(Succeed(), "Failed waiting for the rollout of Cluster %s to start", klog.KObj(input.Cluster))
+}
+
+// WaitForRolloutToComplete waits for all the Machines of a Cluster created before the rollout has been triggered to be replaced.
+func WaitForRolloutToComplete(ctx context.Context, input WaitForRolloutInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForRolloutToComplete")
+	Expect(input.Lister).ToNot(BeNil(), "Invalid argument. input.Lister can't be nil when calling WaitForRolloutToComplete")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling WaitForRolloutToComplete")
+
+	log.Logf("Waiting for the rollout of Cluster %s to complete", klog.KObj(input.Cluster))
+	Eventually(func(g Gomega) {
+		_, oldMachines := rolloutMachines(ctx, g, input)
+		g.Expect(oldMachines).To(BeEmpty(), "Machines %s have not been rolled out yet", strings.Join(sets.List(oldMachines), ", "))
+	}, intervals...).Should(Succeed(), "Failed waiting for the rollout of Cluster %s to complete", klog.KObj(input.Cluster))
+}
+
+// rolloutMachines returns the names of the Machines of a Cluster created after and before the rollout has been triggered.
+func rolloutMachines(ctx context.Context, g Gomega, input WaitForRolloutInput) (newMachines, oldMachines sets.Set[string]) {
+	machines := &clusterv1.MachineList{}
+	g.Expect(input.Lister.List(ctx, machines, byClusterOptions(input.Cluster.Name, input.Cluster.Namespace)...)).To(Succeed())
+
+	newMachines, oldMachines = sets.Set[string]{}, sets.Set[string]{}
+	for _, m := range machines.Items {
+		if m.CreationTimestamp.Time.Before(input.RolloutAfter) {
+			oldMachines.Insert(m.Name)
+			continue
+		}
+		newMachines.Insert(m.Name)
+	}
+	return newMachines, oldMachines
+}
+
+// RestartControllersAndWaitInput is the input for RestartControllersAndWait.
+type RestartControllersAndWaitInput struct {
+	ClusterProxy ClusterProxy
+	Deployments  []*appsv1.Deployment
+}
+
+// RestartControllersAndWait abruptly restarts controllers by deleting their Pods without a grace period, and then
+// waits for new Pods to be running and for the Deployments to be available.
+func RestartControllersAndWait(ctx context.Context, input RestartControllersAndWaitInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for RestartControllersAndWait")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling RestartControllersAndWait")
+	Expect(input.Deployments).ToNot(BeEmpty(), "Invalid argument. input.Deployments can't be empty when calling RestartControllersAndWait")
+
+	mgmtClient := input.ClusterProxy.GetClient()
+	oldPods := sets.Set[types.UID]{}
+	for _, deployment := range input.Deployments {
+		pods := deploymentPods(ctx, mgmtClient, deployment)
+		log.Logf("Restarting controller %s", klog.KObj(deployment))
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			oldPods.Insert(pod.UID)
+			Eventually(func() error {
+				return client.IgnoreNotFound(mgmtClient.Delete(ctx, pod, client.GracePeriodSeconds(0)))
+			}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to delete Pod %s", klog.KObj(pod))
+		}
+	}
+
+	for _, deployment := range input.Deployments {
+		log.Logf("Waiting for controller %s to be available", klog.KObj(deployment))
+		Eventually(func(g Gomega) {
+			d := &appsv1.Deployment{}
+			g.Expect(mgmtClient.Get(ctx, client.ObjectKeyFromObject(deployment), d)).To(Succeed())
+			g.Expect(d.Status.AvailableReplicas).To(Equal(ptr.Deref(d.Spec.Replicas, 1)), "Deployment %s is not available", klog.KObj(d))
+
+			pods := deploymentPods(ctx, mgmtClient, d)
+			g.Expect(pods.Items).ToNot(BeEmpty())
+			for _, pod := range pods.Items {
+				g.Expect(oldPods.Has(pod.UID)).To(BeFalse(), "Pod %s has not been deleted yet", klog.KObj(&pod))
+				g.Expect(isPodReady(&pod)).To(BeTrue(), "Pod %s is not ready", klog.KObj(&pod))
+			}
+		}, intervals...).Should(Succeed(), "Failed waiting for controller %s to be available", klog.KObj(deployment))
+	}
+}
+
+func deploymentPods(ctx context.Context, c client.Client, deployment *appsv1.Deployment) *corev1.PodList {
+	selector, err := metav1.LabelSelectorAsMap(deployment.Spec.Selector)
+	Expect(err).ToNot(HaveOccurred(), "Failed to parse the selector of Deployment %s", klog.KObj(deployment))
+
+	pods := &corev1.PodList{}
+	Eventually(func() error {
+		return c.List(ctx, pods, client.InNamespace(deployment.Namespace), client.MatchingLabels(selector))
+	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to list Pods for Deployment %s", klog.KObj(deployment))
+	return pods
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if !pod.DeletionTimestamp.IsZero() {
+		return false
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// WaitForClusterToConvergeInput is the input for WaitForClusterToConverge.
+type WaitForClusterToConvergeInput struct {
+	Getter  GetLister
+	Cluster *clusterv1.Cluster
+}
+
+// WaitForClusterToConverge waits for a Cluster to converge to its desired state after a disruption, i.e. for:
+// - the Cluster to be available, and ClusterCache to be connected to the workload cluster.
+// - the KubeadmControlPlane, if any, and the MachineDeployments to have all the desired replicas ready, available and up-to-date.
+// - all the Machines of the Cluster to be ready, and none of them to be deleting.
+func WaitForClusterToConverge(ctx context.Context, input WaitForClusterToConvergeInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForClusterToConverge")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForClusterToConverge")
+	Expect(input.Cluster).ToNot(BeNil(), "Invalid argument. input.Cluster can't be nil when calling WaitForClusterToConverge")
+
+	log.Logf("Waiting for Cluster %s to converge", klog.KObj(input.Cluster))
+	Eventually(func(g Gomega) {
+		cluster := &clusterv1.Cluster{}
+		g.Expect(input.Getter.Get(ctx, client.ObjectKeyFromObject(input.Cluster), cluster)).To(Succeed())
+		g.Expect(conditions.IsTrue(cluster, clusterv1.ClusterRemoteConnectionProbeCondition)).To(BeTrue(),
+			"The RemoteConnectionProbe condition on Cluster %s should be true", klog.KObj(cluster))
+		g.Expect(conditions.IsTrue(cluster, clusterv1.ClusterAvailableCondition)).To(BeTrue(),
+			"The Available condition on Cluster %s should be true", klog.KObj(cluster))
+
+		if cluster.Spec.ControlPlaneRef.Kind == "KubeadmControlPlane" {
+			kcp := &controlplanev1.KubeadmControlPlane{}
+			g.Expect(input.Getter.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, kcp)).To(Succeed())
+			replicas := ptr.Deref(kcp.Spec.Replicas, 0)
+			g.Expect(kcp.Status.Replicas).To(HaveValue(Equal(replicas)), "KubeadmControlPlane %s should have %d replicas", klog.KObj(kcp), replicas)
+			g.Expect(kcp.Status.ReadyReplicas).To(HaveValue(Equal(replicas)), "KubeadmControlPlane %s should have %d ready replicas", klog.KObj(kcp), replicas)
+			g.Expect(kcp.Status.AvailableReplicas).To(HaveValue(Equal(replicas)), "KubeadmControlPlane %s should have %d available replicas", klog.KObj(kcp), replicas)
+			g.Expect(kcp.Status.UpToDateReplicas).To(HaveValue(Equal(replicas)), "KubeadmControlPlane %s should have %d up-to-date replicas", klog.KObj(kcp), replicas)
+		}
+
+		mdList := &clusterv1.MachineDeploymentList{}
+		g.Expect(input.Getter.List(ctx, mdList, byClusterOptions(cluster.Name, cluster.Namespace)...)).To(Succeed())
+		for _, md := range mdList.Items {
+			replicas := ptr.Deref(md.Spec.Replicas, 0)
+			g.Expect(md.Status.Replicas).To(HaveValue(Equal(replicas)), "MachineDeployment %s should have %d replicas", klog.KObj(&md), replicas)
+			g.Expect(md.Status.ReadyReplicas).To(HaveValue(Equal(replicas)), "MachineDeployment %s should have %d ready replicas", klog.KObj(&md), replicas)
+			g.Expect(md.Status.AvailableReplicas).To(HaveValue(Equal(replicas)), "MachineDeployment %s should have %d available replicas", klog.KObj(&md), replicas)
+			g.Expect(md.Status.UpToDateReplicas).To(HaveValue(Equal(replicas)), "MachineDeployment %s should have %d up-to-date replicas", klog.KObj(&md), replicas)
+		}
+
+		machineList := &clusterv1.MachineList{}
+		g.Expect(input.Getter.List(ctx, machineList, byClusterOptions(cluster.Name, cluster.Namespace)...)).To(Succeed())
+		for _, m := range machineList.Items {
+			g.Expect(m.DeletionTimestamp.IsZero()).To(BeTrue(), "Machine %s should not be deleting", klog.KObj(&m))
+			g.Expect(conditions.IsTrue(&m, clusterv1.ReadyCondition)).To(BeTrue(), "The Ready condition on Machine %s should be true", klog.KObj(&m))
+		}
+	}, intervals...).Should(Succeed(), "Failed waiting for Cluster %s to converge", klog.KObj(input.Cluster))
+}
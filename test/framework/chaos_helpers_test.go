@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func Test_rolloutMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	rolloutAfter := time.Now().Truncate(time.Second)
+	machine := func(name, clusterName string, creationTimestamp time.Time) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         metav1.NamespaceDefault,
+				Labels:            map[string]string{clusterv1.ClusterNameLabel: clusterName},
+				CreationTimestamp: metav1.NewTime(creationTimestamp),
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		machine("old", "cluster", rolloutAfter.Add(-time.Hour)),
+		machine("new", "cluster", rolloutAfter),
+		machine("other", "other-cluster", rolloutAfter.Add(-time.Hour)),
+	).Build()
+
+	newMachines, oldMachines := rolloutMachines(context.Background(), g, WaitForRolloutInput{
+		Lister: c,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: metav1.NamespaceDefault},
+		},
+		RolloutAfter: rolloutAfter,
+	})
+	g.Expect(newMachines).To(Equal(sets.New("new")))
+	g.Expect(oldMachines).To(Equal(sets.New("old")))
+}
+
+func Test_isEtcdQuotaExceeded(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(isEtcdQuotaExceeded(apierrors.NewInternalError(errors.New("etcdserver: mvcc: database space exceeded")))).To(BeTrue())
+	g.Expect(isEtcdQuotaExceeded(apierrors.NewTimeoutError("request timed out", 1))).To(BeFalse())
+}
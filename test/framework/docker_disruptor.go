@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/test/infrastructure/container"
+)
+
+// DockerInfrastructureDisruptor implements InfrastructureDisruptor for CAPD with the docker backend.
+// Machines are killed by killing the corresponding container, while partitions are implemented with iptables rules
+// dropping the traffic to the control plane endpoint (the load balancer) of the workload cluster; partitions of the
+// management cluster assume the management cluster is a kind cluster, with kind nodes running on the same docker host.
+type DockerInfrastructureDisruptor struct{}
+
+var _ InfrastructureDisruptor = DockerInfrastructureDisruptor{}
+
+// KillMachine kills the container hosting a Machine.
+func (d DockerInfrastructureDisruptor) KillMachine(ctx context.Context, _ *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	containerRuntime, err := container.NewDockerClient()
+	if err != nil {
+		return err
+	}
+	ctx = container.RuntimeInto(ctx, containerRuntime)
+
+	containerName := machineContainerName(machine.Spec.ClusterName, machine.Name)
+	if err := containerRuntime.KillContainer(ctx, containerName, "SIGKILL"); err != nil {
+		return errors.Wrapf(err, "failed to kill container %s", containerName)
+	}
+	return nil
+}
+
+// PartitionMachine adds iptables rules dropping the traffic from the container hosting a Machine to the control plane endpoint.
+func (d DockerInfrastructureDisruptor) PartitionMachine(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	return d.iptables(ctx, "-I", cluster, []string{machineContainerName(machine.Spec.ClusterName, machine.Name)}, "OUTPUT")
+}
+
+// HealMachinePartition removes the iptables rules added by PartitionMachine.
+func (d DockerInfrastructureDisruptor) HealMachinePartition(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	return d.iptables(ctx, "-D", cluster, []string{machineContainerName(machine.Spec.ClusterName, machine.Name)}, "OUTPUT")
+}
+
+// PartitionManagementCluster adds iptables rules dropping the traffic from the kind nodes of the management cluster to the
+// control plane endpoint; rules are added both to the OUTPUT chain (for host network pods) and to the FORWARD chain (for all the other pods).
+func (d DockerInfrastructureDisruptor) PartitionManagementCluster(ctx context.Context, managementClusterProxy ClusterProxy, cluster *clusterv1.Cluster) error {
+	nodes, err := managementClusterNodes(ctx, managementClusterProxy)
+	if err != nil {
+		return err
+	}
+	return d.iptables(ctx, "-I", cluster, nodes, "OUTPUT", "FORWARD")
+}
+
+// HealManagementClusterPartition removes the iptables rules added by PartitionManagementCluster.
+func (d DockerInfrastructureDisruptor) HealManagementClusterPartition(ctx context.Context, managementClusterProxy ClusterProxy, cluster *clusterv1.Cluster) error {
+	nodes, err := managementClusterNodes(ctx, managementClusterProxy)
+	if err != nil {
+		return err
+	}
+	return d.iptables(ctx, "-D", cluster, nodes, "OUTPUT", "FORWARD")
+}
+
+// iptables inserts (-I) or deletes (-D) rules dropping the traffic to the control plane endpoint of a Cluster
+// in the given chains of the given containers.
+func (d DockerInfrastructureDisruptor) iptables(ctx context.Context, op string, cluster *clusterv1.Cluster, containerNames []string, chains ...string) error {
+	if !cluster.Spec.ControlPlaneEndpoint.IsValid() {
+		return errors.Errorf("Cluster %s does not have a control plane endpoint", cluster.Name)
+	}
+
+	containerRuntime, err := container.NewDockerClient()
+	if err != nil {
+		return err
+	}
+	ctx = container.RuntimeInto(ctx, containerRuntime)
+
+	command := "iptables"
+	if ip := net.ParseIP(cluster.Spec.ControlPlaneEndpoint.Host); ip != nil && ip.To4() == nil {
+		command = "ip6tables"
+	}
+
+	var errs []error
+	for _, containerName := range containerNames {
+		for _, chain := range chains {
+			var stderr bytes.Buffer
+			args := []string{op, chain,
+				"-d", cluster.Spec.ControlPlaneEndpoint.Host,
+				"-p", "tcp", "--dport", strconv.Itoa(int(cluster.Spec.ControlPlaneEndpoint.Port)),
+				"-j", "DROP",
+			}
+			if err := containerRuntime.ExecContainer(ctx, containerName, &container.ExecContainerInput{ErrorBuffer: &stderr}, command, args...); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to run %s %v in container %s: %s", command, args, containerName, stderr.String()))
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// managementClusterNodes returns the names of the nodes of the management cluster; with kind,
+// node names are the same as the names of the containers hosting them.
+func managementClusterNodes(ctx context.Context, managementClusterProxy ClusterProxy) ([]string, error) {
+	nodes := &corev1.NodeList{}
+	if err := managementClusterProxy.GetClient().List(ctx, nodes); err != nil {
+		return nil, errors.Wrap(err, "failed to list management cluster nodes")
+	}
+	names := make([]string, 0, len(nodes.Items))
+	for _, n := range nodes.Items {
+		names = append(names, n.Name)
+	}
+	return names, nil
+}
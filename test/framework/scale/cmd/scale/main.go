@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// main is the main package for the scale test harness.
+// It can be used to run a scale scenario against a management cluster with Cluster API and CAPD installed,
+// e.g. a kind cluster, and to compare the resulting reports across commits.
+//
+// Usage:
+//
+//	scale run --scenario scenario.yaml --kubeconfig ~/.kube/config --report report.json
+//	scale compare --baseline baseline.json --current report.json
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/test/framework/scale"
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = bootstrapv1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: scale <run|compare> [flags]")
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(ctx, os.Args[2:])
+	case "compare":
+		err = compareCmd(os.Args[2:])
+	default:
+		err = errors.Errorf("unknown command %q, must be one of run, compare", os.Args[1])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runCmd(ctx context.Context, args []string) error {
+	fs := pflag.NewFlagSet("run", pflag.ExitOnError)
+	scenarioPath := fs.String("scenario", "", "Path to the scenario file.")
+	kubeconfig := fs.String("kubeconfig", "", "Path to the kubeconfig of the management cluster. If not set, the default loading rules are used.")
+	reportPath := fs.String("report", "scale-report.json", "Path where the report is written.")
+	metricsNamespaces := fs.StringSlice("metrics-namespaces", []string{"capi-system", "capi-kubeadm-bootstrap-system", "capi-kubeadm-control-plane-system", "capd-system"},
+		"Namespaces of the Deployments to collect metrics from.")
+	metricsPort := fs.Int32("metrics-port", 8080, "Port serving metrics over HTTP in the Pods of the Deployments to collect metrics from.")
+	metadata := fs.StringToString("metadata", nil, "Metadata to be added to the report, e.g. commit=<sha>.")
+	verbosity := fs.Int("v", 0, "Log verbosity.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *scenarioPath == "" {
+		return errors.New("--scenario is required")
+	}
+
+	log := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(*verbosity)))
+
+	scenario, err := scale.LoadScenario(*scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load kubeconfig")
+	}
+	restConfig.QPS = 50
+	restConfig.Burst = 100
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "failed to create client")
+	}
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to create client set")
+	}
+
+	sources, err := scale.DiscoverPodMetricsSources(ctx, c, clientSet, *metricsNamespaces, *metricsPort)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Collecting metrics from %d Pods", len(sources)))
+
+	report, runErr := scale.Run(ctx, scale.RunInput{
+		Client:         c,
+		Scenario:       scenario,
+		MetricsSources: sources,
+		Metadata:       *metadata,
+		Log:            log,
+	})
+	if report != nil {
+		if err := scale.WriteReport(*reportPath, report); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Report written to %s", *reportPath))
+	}
+	return runErr
+}
+
+func compareCmd(args []string) error {
+	fs := pflag.NewFlagSet("compare", pflag.ExitOnError)
+	baselinePath := fs.String("baseline", "", "Path to the baseline report.")
+	currentPath := fs.String("current", "", "Path to the report to compare with the baseline.")
+	tolerance := fs.Float64("tolerance", 0.2, "Relative increase over the baseline which is considered a regression.")
+	minSeconds := fs.Float64("min-seconds", 1, "Minimum increase of a duration or latency over the baseline, in seconds, which is considered a regression.")
+	minBytes := fs.Float64("min-bytes", 10*1024*1024, "Minimum increase of memory over the baseline, in bytes, which is considered a regression.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *baselinePath == "" || *currentPath == "" {
+		return errors.New("--baseline and --current are required")
+	}
+
+	baseline, err := scale.LoadReport(*baselinePath)
+	if err != nil {
+		return err
+	}
+	current, err := scale.LoadReport(*currentPath)
+	if err != nil {
+		return err
+	}
+
+	regressions, err := scale.Compare(baseline, current, scale.CompareOptions{
+		Tolerance:  *tolerance,
+		MinSeconds: *minSeconds,
+		MinBytes:   *minBytes,
+	})
+	if err != nil {
+		return err
+	}
+	if len(regressions) == 0 {
+		fmt.Println("No regressions found")
+		return nil
+	}
+	for _, r := range regressions {
+		fmt.Println(r.String())
+	}
+	return errors.Errorf("found %d regressions", len(regressions))
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scale implements a harness for running scale and soak tests against a management cluster
+// using CAPD with the in memory backend.
+//
+// A test is defined by a declarative Scenario, e.g. the number of clusters and machines, upgrade waves,
+// cluster churn and fault profiles; while the Scenario runs, metrics of the Cluster API controllers
+// (reconcile latency, queue depth, memory) are collected and then summarized into a Report, which
+// can be compared with reports from previous runs of the same Scenario in order to detect regressions.
+//
+// The harness can be used as a Go package, e.g. against an envtest management cluster with the
+// controllers running in the same process, or via the binary in cmd/scale, e.g. against a kind
+// management cluster with Cluster API and CAPD installed.
+package scale
@@ -0,0 +1,640 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// RunInput is the input for Run.
+type RunInput struct {
+	// Client for the management cluster.
+	// The client's scheme must include Cluster API, KubeadmControlPlane, KubeadmBootstrap and CAPD types.
+	Client client.Client
+
+	// Scenario to run.
+	Scenario *Scenario
+
+	// MetricsSources are the sources metrics are collected from.
+	// If not set, the report won't contain metrics.
+	MetricsSources []MetricsSource
+
+	// Metadata to be added to the report, e.g. the commit being tested.
+	Metadata map[string]string
+
+	// Log is used to log the progress of the scenario.
+	Log logr.Logger
+}
+
+// Run runs a Scenario and returns a Report.
+// If a phase of the scenario fails, the following phases are skipped and the clusters are deleted
+// (unless the scenario has SkipCleanup set); in this case Run returns both the report, with the errors
+// that occurred in the phase, and an error.
+func Run(ctx context.Context, input RunInput) (*Report, error) {
+	if input.Client == nil {
+		return nil, errors.New("input.Client is required")
+	}
+	if input.Scenario == nil {
+		return nil, errors.New("input.Scenario is required")
+	}
+
+	// Deep copy the scenario, so defaulting doesn't change the input.
+	s := &Scenario{}
+	data, err := json.Marshal(input.Scenario)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to copy scenario")
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "failed to copy scenario")
+	}
+	s.Default()
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid scenario")
+	}
+	hash, err := s.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &runner{
+		client:    input.Client,
+		scenario:  s,
+		log:       input.Log,
+		collector: &metricsCollector{log: input.Log, sources: input.MetricsSources},
+		clusters:  map[string]*clusterState{},
+	}
+
+	report := &Report{
+		Version:      ReportVersion,
+		Scenario:     *s,
+		ScenarioHash: hash,
+		Metadata:     input.Metadata,
+		StartTime:    time.Now(),
+	}
+
+	metricsCtx, cancelMetrics := context.WithCancel(ctx)
+	defer cancelMetrics()
+	go r.collector.run(metricsCtx, s.MetricsInterval.Duration)
+
+	if err := r.setup(ctx); err != nil {
+		return nil, err
+	}
+
+	phases := []phase{{name: "create", run: r.create}}
+	for i, w := range s.UpgradeWaves {
+		phases = append(phases, phase{
+			name: fmt.Sprintf("upgrade-%d", i+1),
+			run:  func(ctx context.Context) (phaseResult, error) { return r.upgrade(ctx, w) },
+		})
+	}
+	if s.Churn != nil {
+		phases = append(phases, phase{name: "churn", run: r.churn})
+	}
+
+	var errs []error
+	for _, p := range phases {
+		phaseReport, err := r.runPhase(ctx, p)
+		report.Phases = append(report.Phases, phaseReport)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "phase %s failed", p.name))
+			break
+		}
+	}
+
+	if !s.SkipCleanup {
+		phaseReport, err := r.runPhase(ctx, phase{name: "delete", run: r.delete})
+		report.Phases = append(report.Phases, phaseReport)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "phase delete failed"))
+		}
+	}
+
+	r.collector.collect(ctx)
+	report.DurationSeconds = time.Since(report.StartTime).Seconds()
+	report.Metrics = r.collector.summarize(report.StartTime, time.Now())
+	return report, kerrors.NewAggregate(errs)
+}
+
+// phase is a phase of the scenario.
+type phase struct {
+	name string
+	run  func(ctx context.Context) (phaseResult, error)
+}
+
+// phaseResult is the result of a phase; clusters is the number of clusters without a fault profile involved
+// in the phase and durations is the time it took for each of them to complete the phase.
+type phaseResult struct {
+	clusters             int
+	durations            []time.Duration
+	faultProfileClusters *FaultProfileClustersReport
+}
+
+// clusterState tracks a cluster created by the harness.
+type clusterState struct {
+	index   int
+	name    string
+	profile *FaultProfile
+}
+
+type runner struct {
+	client    client.Client
+	scenario  *Scenario
+	log       logr.Logger
+	collector *metricsCollector
+
+	// clusters tracks the clusters which currently exist, and nextIndex is the index of the next cluster to be created.
+	clusters  map[string]*clusterState
+	nextIndex int
+}
+
+// runPhase runs a phase and reports about it.
+func (r *runner) runPhase(ctx context.Context, p phase) (PhaseReport, error) {
+	r.log.Info(fmt.Sprintf("Starting phase %s", p.name))
+	r.collector.collect(ctx)
+	start := time.Now()
+
+	result, err := p.run(ctx)
+
+	r.collector.collect(ctx)
+	end := time.Now()
+	phaseReport := PhaseReport{
+		Name:                   p.name,
+		StartTime:              start,
+		DurationSeconds:        end.Sub(start).Seconds(),
+		Clusters:               result.clusters,
+		ClusterDurationSeconds: durationsSummary(result.durations),
+		FaultProfileClusters:   result.faultProfileClusters,
+		Metrics:                r.collector.summarize(start, end),
+	}
+	if err != nil {
+		phaseReport.Errors = errorStrings(err)
+		r.log.Error(err, fmt.Sprintf("Phase %s failed", p.name))
+	} else {
+		r.log.Info(fmt.Sprintf("Phase %s completed", p.name), "duration", end.Sub(start).Round(time.Second).String())
+	}
+	return phaseReport, err
+}
+
+// setup creates the namespace and the ClusterClasses for the scenario.
+func (r *runner) setup(ctx context.Context) error {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: r.scenario.Namespace}}
+	if err := r.client.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create namespace %s", namespace.Name)
+	}
+
+	profiles := r.profiles()
+	for _, profile := range profiles {
+		for _, obj := range clusterClassObjects(r.scenario, profile) {
+			if err := r.client.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "failed to create %T %s", obj, client.ObjectKeyFromObject(obj))
+			}
+		}
+	}
+
+	// Wait for ClusterClasses to be reconciled, so Clusters using them can be created.
+	err := wait.PollUntilContextTimeout(ctx, time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		for _, profile := range profiles {
+			clusterClass := &clusterv1.ClusterClass{}
+			key := client.ObjectKey{Namespace: r.scenario.Namespace, Name: clusterClassName(r.scenario, profile)}
+			if err := r.client.Get(ctx, key, clusterClass); err != nil {
+				return false, nil //nolint:nilerr // Retry on errors.
+			}
+			if !conditions.IsTrue(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	return errors.Wrap(err, "failed waiting for ClusterClasses to be reconciled")
+}
+
+// profiles returns all the fault profiles of the scenario, including nil for clusters without faults.
+func (r *runner) profiles() []*FaultProfile {
+	profiles := []*FaultProfile{nil}
+	for i := range r.scenario.FaultProfiles {
+		profiles = append(profiles, &r.scenario.FaultProfiles[i])
+	}
+	return profiles
+}
+
+// create creates all the clusters and waits for them to be provisioned.
+func (r *runner) create(ctx context.Context) (phaseResult, error) {
+	names := make([]string, 0, r.scenario.ClusterCount)
+	for range r.scenario.ClusterCount {
+		names = append(names, r.newCluster().name)
+	}
+
+	started, err := r.forEach(ctx, names, r.createCluster)
+	if err != nil {
+		return phaseResult{clusters: len(names)}, err
+	}
+	return r.waitForClustersProvisioned(ctx, started, r.scenario.Timeouts.Create.Duration)
+}
+
+// upgrade upgrades a wave of clusters and waits for the upgrade to complete.
+func (r *runner) upgrade(ctx context.Context, wave UpgradeWave) (phaseResult, error) {
+	clusters := r.sortedClusters()
+	count := (len(clusters)*int(wave.ClusterPercentage) + 99) / 100
+
+	names := []string{}
+	for _, c := range clusters[:count] {
+		cluster := &clusterv1.Cluster{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: r.scenario.Namespace, Name: c.name}, cluster); err != nil {
+			return phaseResult{}, errors.Wrapf(err, "failed to get Cluster %s", c.name)
+		}
+		if cluster.Spec.Topology.Version != wave.KubernetesVersion {
+			names = append(names, c.name)
+		}
+	}
+
+	started, err := r.forEach(ctx, names, func(ctx context.Context, name string) error {
+		cluster := &clusterv1.Cluster{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: r.scenario.Namespace, Name: name}, cluster); err != nil {
+			return errors.Wrapf(err, "failed to get Cluster %s", name)
+		}
+		original := cluster.DeepCopy()
+		cluster.Spec.Topology.Version = wave.KubernetesVersion
+		if err := r.client.Patch(ctx, cluster, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "failed to upgrade Cluster %s", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return phaseResult{clusters: len(names)}, err
+	}
+	return r.waitForClustersProvisioned(ctx, started, r.scenario.Timeouts.Upgrade.Duration)
+}
+
+// churn deletes and re-creates clusters, oldest first, at the rate defined in the scenario;
+// then it waits for all the clusters to be provisioned.
+func (r *runner) churn(ctx context.Context) (phaseResult, error) {
+	churn := r.scenario.Churn
+	ticker := time.NewTicker(time.Minute / time.Duration(churn.ClustersPerMinute))
+	defer ticker.Stop()
+	deadline := time.Now().Add(churn.Duration.Duration)
+
+	started := map[string]time.Time{}
+	deleted := map[string]time.Time{}
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return phaseResult{clusters: len(started)}, ctx.Err()
+		case <-ticker.C:
+		}
+
+		clusters := r.sortedClusters()
+		if len(clusters) == 0 {
+			break
+		}
+		oldest := clusters[0]
+		if err := r.deleteCluster(ctx, oldest.name); err != nil {
+			return phaseResult{clusters: len(started)}, err
+		}
+		deleted[oldest.name] = time.Now()
+		delete(r.clusters, oldest.name)
+
+		c := r.newClusterWithProfile(oldest.profile)
+		if err := r.createCluster(ctx, c.name); err != nil {
+			return phaseResult{clusters: len(started)}, err
+		}
+		started[c.name] = time.Now()
+	}
+
+	// Clusters created during the churn phase might have been deleted before being provisioned.
+	for name := range started {
+		if _, ok := r.clusters[name]; !ok {
+			delete(started, name)
+		}
+	}
+	result, err := r.waitForClustersProvisioned(ctx, started, r.scenario.Timeouts.Churn.Duration)
+	if err != nil {
+		return result, err
+	}
+	if _, err := r.waitForClustersDeleted(ctx, deleted, r.scenario.Timeouts.Churn.Duration); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// delete deletes all the clusters and waits for them to be gone; then it deletes the ClusterClasses.
+func (r *runner) delete(ctx context.Context) (phaseResult, error) {
+	names := []string{}
+	for _, c := range r.sortedClusters() {
+		names = append(names, c.name)
+	}
+
+	started, err := r.forEach(ctx, names, r.deleteCluster)
+	if err != nil {
+		return phaseResult{clusters: len(names)}, err
+	}
+	durations, err := r.waitForClustersDeleted(ctx, started, r.scenario.Timeouts.Delete.Duration)
+	if err != nil {
+		return phaseResult{clusters: len(names), durations: durations}, err
+	}
+	r.clusters = map[string]*clusterState{}
+
+	profiles := r.profiles()
+	var errs []error
+	for _, profile := range profiles {
+		for _, obj := range clusterClassObjects(r.scenario, profile) {
+			if err := r.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, errors.Wrapf(err, "failed to delete %T %s", obj, client.ObjectKeyFromObject(obj)))
+			}
+		}
+	}
+	return phaseResult{clusters: len(names), durations: durations}, kerrors.NewAggregate(errs)
+}
+
+// newCluster tracks a new cluster, assigning a fault profile according to the cluster index.
+func (r *runner) newCluster() *clusterState {
+	return r.newClusterWithProfile(r.scenario.faultProfileFor(r.nextIndex))
+}
+
+// newClusterWithProfile tracks a new cluster with the given fault profile.
+func (r *runner) newClusterWithProfile(profile *FaultProfile) *clusterState {
+	c := &clusterState{
+		index:   r.nextIndex,
+		name:    clusterName(r.scenario, r.nextIndex),
+		profile: profile,
+	}
+	r.nextIndex++
+	r.clusters[c.name] = c
+	return c
+}
+
+// sortedClusters returns the clusters which currently exist, oldest first.
+func (r *runner) sortedClusters() []*clusterState {
+	clusters := make([]*clusterState, 0, len(r.clusters))
+	for _, c := range r.clusters {
+		clusters = append(clusters, c)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].index < clusters[j].index })
+	return clusters
+}
+
+func (r *runner) createCluster(ctx context.Context, name string) error {
+	c := r.clusters[name]
+	if err := r.client.Create(ctx, clusterObject(r.scenario, c.name, c.profile)); err != nil {
+		return errors.Wrapf(err, "failed to create Cluster %s", c.name)
+	}
+	r.log.V(4).Info(fmt.Sprintf("Created Cluster %s", c.name))
+	return nil
+}
+
+func (r *runner) deleteCluster(ctx context.Context, name string) error {
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: r.scenario.Namespace, Name: name}}
+	if err := r.client.Delete(ctx, cluster); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Cluster %s", name)
+	}
+	r.log.V(4).Info(fmt.Sprintf("Deleted Cluster %s", name))
+	return nil
+}
+
+// forEach calls fn for each cluster, with the concurrency defined in the scenario, and returns the time
+// when fn has been called for each cluster.
+func (r *runner) forEach(ctx context.Context, names []string, fn func(ctx context.Context, name string) error) (map[string]time.Time, error) {
+	var (
+		lock    sync.Mutex
+		wg      sync.WaitGroup
+		started = map[string]time.Time{}
+		errs    []error
+	)
+
+	sem := make(chan struct{}, r.scenario.Concurrency)
+	for _, name := range names {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return started, ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			start := time.Now()
+			err := fn(ctx, name)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			started[name] = start
+		}()
+	}
+	wg.Wait()
+	return started, kerrors.NewAggregate(errs)
+}
+
+// waitForClustersProvisioned waits for clusters to be provisioned, and returns the time it took for each
+// cluster to be provisioned since the corresponding start time.
+// NOTE: Clusters with a fault profile are not required to be provisioned, because faults can prevent them from
+// converging; they are tracked until the other clusters are provisioned, and reported separately.
+func (r *runner) waitForClustersProvisioned(ctx context.Context, started map[string]time.Time, timeout time.Duration) (phaseResult, error) {
+	required := map[string]time.Time{}
+	optional := map[string]time.Time{}
+	for name, t := range started {
+		if c, ok := r.clusters[name]; ok && c.profile != nil {
+			optional[name] = t
+			continue
+		}
+		required[name] = t
+	}
+
+	durations, optionalDurations, err := r.waitForClusters(ctx, required, optional, timeout, "provisioned", func(cluster *clusterv1.Cluster, machines []clusterv1.Machine) bool {
+		return cluster != nil && isProvisioned(cluster, machines)
+	})
+	result := phaseResult{clusters: len(required), durations: durations}
+	if len(optional) > 0 {
+		result.faultProfileClusters = &FaultProfileClustersReport{
+			Clusters:               len(optional),
+			Converged:              len(optionalDurations),
+			ClusterDurationSeconds: durationsSummary(optionalDurations),
+		}
+		r.log.Info("Clusters with a fault profile provisioned", "converged", len(optionalDurations), "total", len(optional))
+	}
+	return result, err
+}
+
+// waitForClustersDeleted waits for clusters to be deleted, and returns the time it took for each
+// cluster to be deleted since the corresponding start time.
+func (r *runner) waitForClustersDeleted(ctx context.Context, started map[string]time.Time, timeout time.Duration) ([]time.Duration, error) {
+	durations, _, err := r.waitForClusters(ctx, started, nil, timeout, "deleted", func(cluster *clusterv1.Cluster, _ []clusterv1.Machine) bool {
+		return cluster == nil
+	})
+	return durations, err
+}
+
+// waitForClusters waits for a set of clusters to satisfy a condition, and returns the time it took for each
+// cluster to satisfy the condition since the corresponding start time.
+// Optional clusters are tracked until all the other clusters satisfy the condition, or until the timeout
+// if there are only optional clusters, but they never make waitForClusters fail.
+// NOTE: Clusters and Machines are listed once for every poll, instead of once for every cluster, in order
+// to reduce the load on the management cluster when running with many clusters.
+func (r *runner) waitForClusters(ctx context.Context, started, optional map[string]time.Time, timeout time.Duration, what string, done func(*clusterv1.Cluster, []clusterv1.Machine) bool) ([]time.Duration, []time.Duration, error) {
+	pending := map[string]time.Time{}
+	for name, t := range started {
+		pending[name] = t
+	}
+	pendingOptional := map[string]time.Time{}
+	for name, t := range optional {
+		pendingOptional[name] = t
+	}
+	durations := []time.Duration{}
+	optionalDurations := []time.Duration{}
+
+	err := wait.PollUntilContextTimeout(ctx, r.scenario.PollInterval.Duration, timeout, true, func(ctx context.Context) (bool, error) {
+		clusterList := &clusterv1.ClusterList{}
+		if err := r.client.List(ctx, clusterList, client.InNamespace(r.scenario.Namespace), client.MatchingLabels{ScenarioLabel: r.scenario.Name}); err != nil {
+			r.log.V(4).Info("Failed to list Clusters", "error", err.Error())
+			return false, nil
+		}
+		machineList := &clusterv1.MachineList{}
+		if err := r.client.List(ctx, machineList, client.InNamespace(r.scenario.Namespace)); err != nil {
+			r.log.V(4).Info("Failed to list Machines", "error", err.Error())
+			return false, nil
+		}
+
+		clusters := map[string]*clusterv1.Cluster{}
+		for i := range clusterList.Items {
+			clusters[clusterList.Items[i].Name] = &clusterList.Items[i]
+		}
+		machines := map[string][]clusterv1.Machine{}
+		for _, m := range machineList.Items {
+			clusterName := m.Labels[clusterv1.ClusterNameLabel]
+			machines[clusterName] = append(machines[clusterName], m)
+		}
+
+		now := time.Now()
+		for name, start := range pending {
+			if done(clusters[name], machines[name]) {
+				durations = append(durations, now.Sub(start))
+				delete(pending, name)
+			}
+		}
+		for name, start := range pendingOptional {
+			if done(clusters[name], machines[name]) {
+				optionalDurations = append(optionalDurations, now.Sub(start))
+				delete(pendingOptional, name)
+			}
+		}
+		r.log.V(2).Info(fmt.Sprintf("Waiting for Clusters to be %s", what), "done", len(started)-len(pending), "total", len(started),
+			"optionalDone", len(optional)-len(pendingOptional), "optionalTotal", len(optional))
+		return len(pending) == 0 && (len(started) > 0 || len(pendingOptional) == 0), nil
+	})
+	if err != nil && len(pending) == 0 && ctx.Err() == nil {
+		// Only optional clusters are pending, which is not an error.
+		return durations, optionalDurations, nil
+	}
+	if err != nil {
+		names := make([]string, 0, len(pending))
+		for name := range pending {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) > 10 {
+			names = append(names[:10], "...")
+		}
+		return durations, optionalDurations, errors.Wrapf(err, "%d Clusters are not %s: %s", len(pending), what, strings.Join(names, ", "))
+	}
+	return durations, optionalDurations, nil
+}
+
+// isProvisioned returns true if all the machines of a cluster exist, are available and at the desired version.
+func isProvisioned(cluster *clusterv1.Cluster, machines []clusterv1.Machine) bool {
+	if !cluster.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if !conditions.IsTrue(cluster, clusterv1.ClusterAvailableCondition) {
+		return false
+	}
+
+	desired := ptr.Deref(cluster.Spec.Topology.ControlPlane.Replicas, 0)
+	for _, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		desired += ptr.Deref(md.Replicas, 0)
+	}
+	if int32(len(machines)) != desired {
+		return false
+	}
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() ||
+			m.Spec.Version != cluster.Spec.Topology.Version ||
+			!conditions.IsTrue(&m, clusterv1.MachineAvailableCondition) {
+			return false
+		}
+	}
+	return true
+}
+
+func durationsSummary(durations []time.Duration) LatencySummary {
+	if len(durations) == 0 {
+		return LatencySummary{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	sum := time.Duration(0)
+	for _, d := range durations {
+		sum += d
+	}
+	percentile := func(p float64) float64 {
+		i := int(p*float64(len(durations))+0.5) - 1
+		return durations[min(max(i, 0), len(durations)-1)].Seconds()
+	}
+	return LatencySummary{
+		Mean: (sum / time.Duration(len(durations))).Seconds(),
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+		P99:  percentile(0.99),
+	}
+}
+
+func errorStrings(err error) []string {
+	var agg kerrors.Aggregate
+	if errors.As(err, &agg) {
+		ret := []string{}
+		for _, e := range agg.Errors() {
+			ret = append(ret, e.Error())
+		}
+		return ret
+	}
+	return []string{err.Error()}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestIsProvisioned(t *testing.T) {
+	s := &Scenario{Name: "scale", ClusterCount: 1, KubernetesVersion: "v1.33.0"}
+	s.Default()
+
+	availableCluster := func() *clusterv1.Cluster {
+		c := clusterObject(s, "scale-1", nil)
+		c.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterAvailableCondition, Status: metav1.ConditionTrue}}
+		return c
+	}
+	availableMachine := func(version string) clusterv1.Machine {
+		return clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{Version: version},
+			Status: clusterv1.MachineStatus{
+				Conditions: []metav1.Condition{{Type: clusterv1.MachineAvailableCondition, Status: metav1.ConditionTrue}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cluster  func() *clusterv1.Cluster
+		machines []clusterv1.Machine
+		want     bool
+	}{
+		{
+			name:     "provisioned",
+			cluster:  availableCluster,
+			machines: []clusterv1.Machine{availableMachine("v1.33.0"), availableMachine("v1.33.0")},
+			want:     true,
+		},
+		{
+			name: "cluster not available",
+			cluster: func() *clusterv1.Cluster {
+				c := availableCluster()
+				c.Status.Conditions[0].Status = metav1.ConditionFalse
+				return c
+			},
+			machines: []clusterv1.Machine{availableMachine("v1.33.0"), availableMachine("v1.33.0")},
+			want:     false,
+		},
+		{
+			name:     "missing machines",
+			cluster:  availableCluster,
+			machines: []clusterv1.Machine{availableMachine("v1.33.0")},
+			want:     false,
+		},
+		{
+			name:     "machine not at the desired version",
+			cluster:  availableCluster,
+			machines: []clusterv1.Machine{availableMachine("v1.33.0"), availableMachine("v1.32.0")},
+			want:     false,
+		},
+		{
+			name:    "machine not available",
+			cluster: availableCluster,
+			machines: []clusterv1.Machine{availableMachine("v1.33.0"), {
+				Spec: clusterv1.MachineSpec{Version: "v1.33.0"},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(isProvisioned(tt.cluster(), tt.machines)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDurationsSummary(t *testing.T) {
+	g := NewWithT(t)
+
+	durations := []time.Duration{}
+	for i := 10; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+
+	g.Expect(durationsSummary(durations)).To(Equal(LatencySummary{Mean: 5.5, P50: 5, P90: 9, P99: 10}))
+	g.Expect(durationsSummary(nil)).To(Equal(LatencySummary{}))
+}
+
+func TestWaitForClustersProvisioned(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &Scenario{
+		Name:               "scale",
+		ClusterCount:       2,
+		KubernetesVersion:  "v1.33.0",
+		WorkerMachineCount: ptr.To[int32](0),
+		FaultProfiles:      []FaultProfile{{Name: "flaky-nodes", ClusterCount: 1}},
+		PollInterval:       metav1.Duration{Duration: 10 * time.Millisecond},
+	}
+	s.Default()
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	// scale-1 is provisioned, while scale-2, which has a fault profile, never converges.
+	provisionedCluster := clusterObject(s, "scale-1", nil)
+	provisionedCluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterAvailableCondition, Status: metav1.ConditionTrue}}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "scale-1-cp", Namespace: s.Namespace, Labels: map[string]string{clusterv1.ClusterNameLabel: "scale-1"}},
+		Spec:       clusterv1.MachineSpec{ClusterName: "scale-1", Version: "v1.33.0"},
+		Status: clusterv1.MachineStatus{
+			Conditions: []metav1.Condition{{Type: clusterv1.MachineAvailableCondition, Status: metav1.ConditionTrue}},
+		},
+	}
+	faultProfileCluster := clusterObject(s, "scale-2", &s.FaultProfiles[0])
+
+	r := &runner{
+		client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(provisionedCluster, machine, faultProfileCluster).Build(),
+		scenario: s,
+		log:      logr.Discard(),
+		clusters: map[string]*clusterState{},
+	}
+	r.newClusterWithProfile(nil)
+	r.newClusterWithProfile(&s.FaultProfiles[0])
+
+	// Clusters with a fault profile are not required to be provisioned.
+	now := time.Now()
+	result, err := r.waitForClustersProvisioned(ctx, map[string]time.Time{"scale-1": now, "scale-2": now}, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.clusters).To(Equal(1))
+	g.Expect(result.durations).To(HaveLen(1))
+	g.Expect(result.faultProfileClusters).To(Equal(&FaultProfileClustersReport{Clusters: 1, Converged: 0}))
+
+	// If there are only clusters with a fault profile, they are tracked until the timeout.
+	result, err = r.waitForClustersProvisioned(ctx, map[string]time.Time{"scale-2": now}, 100*time.Millisecond)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.clusters).To(Equal(0))
+	g.Expect(result.faultProfileClusters).To(Equal(&FaultProfileClustersReport{Clusters: 1, Converged: 0}))
+}
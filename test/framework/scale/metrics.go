@@ -0,0 +1,430 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	reconcileTimeMetric   = "controller_runtime_reconcile_time_seconds"
+	reconcileTotalMetric  = "controller_runtime_reconcile_total"
+	reconcileErrorsMetric = "controller_runtime_reconcile_errors_total"
+	queueDepthMetric      = "workqueue_depth"
+	residentMemoryMetric  = "process_resident_memory_bytes"
+	heapInuseMetric       = "go_memstats_heap_inuse_bytes"
+)
+
+// MetricsSource is a source of Prometheus metrics for a component, e.g. a Cluster API controller manager.
+type MetricsSource interface {
+	// Component returns the name of the component.
+	// Metrics from sources with the same component are aggregated, e.g. metrics from all the replicas of a Deployment.
+	Component() string
+
+	// Gather returns the current value of the metrics.
+	Gather(ctx context.Context) ([]*dto.MetricFamily, error)
+}
+
+// NewGathererMetricsSource returns a MetricsSource for a prometheus.Gatherer, e.g. the controller-runtime metrics
+// registry when controllers are running in the same process of the harness like with envtest.
+func NewGathererMetricsSource(component string, gatherer prometheus.Gatherer) MetricsSource {
+	return &gathererMetricsSource{component: component, gatherer: gatherer}
+}
+
+type gathererMetricsSource struct {
+	component string
+	gatherer  prometheus.Gatherer
+}
+
+func (s *gathererMetricsSource) Component() string { return s.component }
+
+func (s *gathererMetricsSource) Gather(_ context.Context) ([]*dto.MetricFamily, error) {
+	return s.gatherer.Gather()
+}
+
+// NewPodMetricsSource returns a MetricsSource that reads metrics from a Pod via the API server proxy.
+// NOTE: The metrics endpoint must be served over HTTP on the given port, like e.g. in the Cluster API E2E tests.
+func NewPodMetricsSource(clientSet kubernetes.Interface, component string, pod types.NamespacedName, port int32) MetricsSource {
+	return &podMetricsSource{clientSet: clientSet, component: component, pod: pod, port: port}
+}
+
+type podMetricsSource struct {
+	clientSet kubernetes.Interface
+	component string
+	pod       types.NamespacedName
+	port      int32
+}
+
+func (s *podMetricsSource) Component() string { return s.component }
+
+func (s *podMetricsSource) Gather(ctx context.Context) ([]*dto.MetricFamily, error) {
+	data, err := s.clientSet.CoreV1().RESTClient().Get().
+		Namespace(s.pod.Namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:%d", s.pod.Name, s.port)).
+		SubResource("proxy").
+		Suffix("metrics").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get metrics from Pod %s", s.pod)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse metrics from Pod %s", s.pod)
+	}
+	ret := make([]*dto.MetricFamily, 0, len(families))
+	for _, f := range families {
+		ret = append(ret, f)
+	}
+	return ret, nil
+}
+
+// DiscoverPodMetricsSources returns a MetricsSource for each Pod of the Deployments in the given namespaces;
+// the name of the Deployment is used as a component name.
+// NOTE: Pods are discovered once, so metrics of Pods created later, e.g. after a controller restart, are not collected.
+func DiscoverPodMetricsSources(ctx context.Context, c client.Client, clientSet kubernetes.Interface, namespaces []string, port int32) ([]MetricsSource, error) {
+	sources := []MetricsSource{}
+	for _, namespace := range namespaces {
+		deployments := &appsv1.DeploymentList{}
+		if err := c.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrapf(err, "failed to list Deployments in namespace %s", namespace)
+		}
+		for _, deployment := range deployments.Items {
+			selector, err := metav1.LabelSelectorAsMap(deployment.Spec.Selector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get Pods selector for Deployment %s/%s", deployment.Namespace, deployment.Name)
+			}
+			pods := &corev1.PodList{}
+			if err := c.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+				return nil, errors.Wrapf(err, "failed to list Pods for Deployment %s/%s", deployment.Namespace, deployment.Name)
+			}
+			for _, pod := range pods.Items {
+				sources = append(sources, NewPodMetricsSource(clientSet, deployment.Name, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, port))
+			}
+		}
+	}
+	return sources, nil
+}
+
+// histogram is a cumulative histogram.
+type histogram struct {
+	upperBounds []float64
+	counts      []float64
+	count       float64
+	sum         float64
+}
+
+// componentSample is a sample of the metrics of a component.
+type componentSample struct {
+	reconcileTime   map[string]*histogram
+	reconcileTotal  map[string]float64
+	reconcileErrors map[string]float64
+	queueDepth      map[string]float64
+	residentMemory  float64
+	heapInuse       float64
+}
+
+func newComponentSample() *componentSample {
+	return &componentSample{
+		reconcileTime:   map[string]*histogram{},
+		reconcileTotal:  map[string]float64{},
+		reconcileErrors: map[string]float64{},
+		queueDepth:      map[string]float64{},
+	}
+}
+
+// add adds metric families to a sample; values of the same metric are summed.
+func (s *componentSample) add(families []*dto.MetricFamily) {
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			switch f.GetName() {
+			case reconcileTimeMetric:
+				controller := labelValue(m, "controller")
+				h, ok := s.reconcileTime[controller]
+				if !ok {
+					h = &histogram{}
+					for _, b := range m.GetHistogram().GetBucket() {
+						h.upperBounds = append(h.upperBounds, b.GetUpperBound())
+					}
+					h.counts = make([]float64, len(h.upperBounds))
+					s.reconcileTime[controller] = h
+				}
+				for i, b := range m.GetHistogram().GetBucket() {
+					if i < len(h.counts) {
+						h.counts[i] += float64(b.GetCumulativeCount())
+					}
+				}
+				h.count += float64(m.GetHistogram().GetSampleCount())
+				h.sum += m.GetHistogram().GetSampleSum()
+			case reconcileTotalMetric:
+				s.reconcileTotal[labelValue(m, "controller")] += m.GetCounter().GetValue()
+			case reconcileErrorsMetric:
+				s.reconcileErrors[labelValue(m, "controller")] += m.GetCounter().GetValue()
+			case queueDepthMetric:
+				s.queueDepth[labelValue(m, "name")] += m.GetGauge().GetValue()
+			case residentMemoryMetric:
+				s.residentMemory += m.GetGauge().GetValue()
+			case heapInuseMetric:
+				s.heapInuse += m.GetGauge().GetValue()
+			}
+		}
+	}
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// metricsSample is a sample of the metrics of all the components.
+type metricsSample struct {
+	time       time.Time
+	components map[string]*componentSample
+}
+
+// metricsCollector periodically collects metrics from a set of sources.
+type metricsCollector struct {
+	log     logr.Logger
+	sources []MetricsSource
+
+	lock    sync.Mutex
+	samples []metricsSample
+}
+
+// run collects metrics at the given interval until the context is done.
+func (c *metricsCollector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.collect(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.collect(ctx)
+		}
+	}
+}
+
+// collect collects a metrics sample from all the sources.
+func (c *metricsCollector) collect(ctx context.Context) {
+	if len(c.sources) == 0 {
+		return
+	}
+
+	sample := metricsSample{time: time.Now(), components: map[string]*componentSample{}}
+	for _, source := range c.sources {
+		families, err := source.Gather(ctx)
+		if err != nil {
+			// Failing to collect metrics should not cause the scenario to fail.
+			c.log.V(4).Info("Failed to collect metrics", "component", source.Component(), "error", err.Error())
+			continue
+		}
+		if _, ok := sample.components[source.Component()]; !ok {
+			sample.components[source.Component()] = newComponentSample()
+		}
+		sample.components[source.Component()].add(families)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.samples = append(c.samples, sample)
+}
+
+// summarize returns a summary of the metrics collected between start and end.
+func (c *metricsCollector) summarize(start, end time.Time) MetricsSummary {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	samples := []metricsSample{}
+	for _, s := range c.samples {
+		if !s.time.Before(start) && !s.time.After(end) {
+			samples = append(samples, s)
+		}
+	}
+	return summarize(samples)
+}
+
+// summarize returns a summary of a set of samples.
+// Counters and histograms are computed as the difference between the last and the first sample,
+// while gauges are computed across all the samples.
+func summarize(samples []metricsSample) MetricsSummary {
+	summary := MetricsSummary{
+		Controllers: map[string]ControllerMetrics{},
+		Components:  map[string]ComponentMetrics{},
+	}
+	if len(samples) == 0 {
+		return summary
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	for component, lastSample := range last.components {
+		firstSample, ok := first.components[component]
+		if !ok {
+			firstSample = newComponentSample()
+		}
+
+		controllers := map[string]bool{}
+		for controller := range lastSample.reconcileTotal {
+			controllers[controller] = true
+		}
+		for controller := range lastSample.reconcileTime {
+			controllers[controller] = true
+		}
+		for controller := range lastSample.queueDepth {
+			controllers[controller] = true
+		}
+
+		for controller := range controllers {
+			cm := ControllerMetrics{
+				ReconcileTotal:  counterDelta(firstSample.reconcileTotal[controller], lastSample.reconcileTotal[controller]),
+				ReconcileErrors: counterDelta(firstSample.reconcileErrors[controller], lastSample.reconcileErrors[controller]),
+			}
+			if h, ok := lastSample.reconcileTime[controller]; ok {
+				cm.ReconcileLatency = latencySummary(histogramDelta(firstSample.reconcileTime[controller], h))
+			}
+			cm.QueueDepth = gaugeSummary(samples, func(s *componentSample) (float64, bool) {
+				v, ok := s.queueDepth[controller]
+				return v, ok
+			}, component)
+			summary.Controllers[fmt.Sprintf("%s/%s", component, controller)] = cm
+		}
+
+		summary.Components[component] = ComponentMetrics{
+			ResidentMemoryBytes: gaugeSummary(samples, func(s *componentSample) (float64, bool) { return s.residentMemory, true }, component),
+			HeapInuseBytes:      gaugeSummary(samples, func(s *componentSample) (float64, bool) { return s.heapInuse, true }, component),
+		}
+	}
+	return summary
+}
+
+// counterDelta returns the increase of a counter; if the counter has been reset, e.g. because the
+// controller restarted, the last value is used.
+func counterDelta(first, last float64) float64 {
+	if last < first {
+		return last
+	}
+	return last - first
+}
+
+// histogramDelta returns the observations of a histogram between two samples.
+func histogramDelta(first, last *histogram) *histogram {
+	if first == nil || last.count < first.count || len(first.counts) != len(last.counts) {
+		return last
+	}
+	delta := &histogram{
+		upperBounds: last.upperBounds,
+		counts:      make([]float64, len(last.counts)),
+		count:       last.count - first.count,
+		sum:         last.sum - first.sum,
+	}
+	for i := range last.counts {
+		delta.counts[i] = last.counts[i] - first.counts[i]
+	}
+	return delta
+}
+
+func latencySummary(h *histogram) LatencySummary {
+	if h == nil || h.count == 0 {
+		return LatencySummary{}
+	}
+	return LatencySummary{
+		Mean: h.sum / h.count,
+		P50:  histogramQuantile(0.5, h),
+		P90:  histogramQuantile(0.9, h),
+		P99:  histogramQuantile(0.99, h),
+	}
+}
+
+// histogramQuantile computes a quantile from a cumulative histogram, using linear interpolation
+// within buckets like the histogram_quantile function in Prometheus.
+func histogramQuantile(q float64, h *histogram) float64 {
+	if h.count == 0 || len(h.upperBounds) == 0 {
+		return 0
+	}
+
+	rank := q * h.count
+	i := sort.Search(len(h.counts), func(i int) bool { return h.counts[i] >= rank })
+	if i == len(h.counts) {
+		// The quantile falls in the implicit +Inf bucket; return the highest finite upper bound.
+		return h.upperBounds[len(h.upperBounds)-1]
+	}
+	if math.IsInf(h.upperBounds[i], +1) {
+		if i == 0 {
+			return 0
+		}
+		return h.upperBounds[i-1]
+	}
+
+	lowerBound, lowerCount := 0.0, 0.0
+	if i > 0 {
+		lowerBound, lowerCount = h.upperBounds[i-1], h.counts[i-1]
+	}
+	if h.counts[i] == lowerCount {
+		return h.upperBounds[i]
+	}
+	return lowerBound + (h.upperBounds[i]-lowerBound)*(rank-lowerCount)/(h.counts[i]-lowerCount)
+}
+
+// gaugeSummary returns the mean and the max value of a gauge across samples.
+func gaugeSummary(samples []metricsSample, value func(*componentSample) (float64, bool), component string) GaugeSummary {
+	summary := GaugeSummary{}
+	n := 0
+	for _, s := range samples {
+		cs, ok := s.components[component]
+		if !ok {
+			continue
+		}
+		v, ok := value(cs)
+		if !ok {
+			continue
+		}
+		summary.Mean += v
+		summary.Max = max(summary.Max, v)
+		n++
+	}
+	if n > 0 {
+		summary.Mean /= float64(n)
+	}
+	return summary
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	h := &histogram{
+		upperBounds: []float64{0.1, 1, 10, math.Inf(+1)},
+		counts:      []float64{50, 90, 100, 100},
+		count:       100,
+	}
+
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{q: 0.5, want: 0.1},
+		{q: 0.25, want: 0.05},
+		{q: 0.7, want: 0.55},
+		{q: 0.9, want: 1},
+		{q: 0.99, want: 9.1},
+	}
+	for _, tt := range tests {
+		g := NewWithT(t)
+		g.Expect(histogramQuantile(tt.q, h)).To(BeNumerically("~", tt.want, 1e-9), "quantile %v", tt.q)
+	}
+
+	t.Run("observations in the +Inf bucket", func(t *testing.T) {
+		g := NewWithT(t)
+
+		h := &histogram{
+			upperBounds: []float64{0.1, 1, math.Inf(+1)},
+			counts:      []float64{10, 10, 100},
+			count:       100,
+		}
+		g.Expect(histogramQuantile(0.99, h)).To(Equal(1.0))
+	})
+
+	t.Run("empty histogram", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(histogramQuantile(0.99, &histogram{})).To(Equal(0.0))
+	})
+}
+
+func TestMetricsCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := prometheus.NewRegistry()
+	reconcileTime := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    reconcileTimeMetric,
+		Buckets: []float64{0.1, 1, 10},
+	}, []string{"controller"})
+	reconcileTotal := prometheus.NewCounterVec(prometheus.CounterOpts{Name: reconcileTotalMetric}, []string{"controller", "result"})
+	reconcileErrors := prometheus.NewCounterVec(prometheus.CounterOpts{Name: reconcileErrorsMetric}, []string{"controller"})
+	queueDepth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: queueDepthMetric}, []string{"name"})
+	residentMemory := prometheus.NewGauge(prometheus.GaugeOpts{Name: residentMemoryMetric})
+	registry.MustRegister(reconcileTime, reconcileTotal, reconcileErrors, queueDepth, residentMemory)
+
+	// Observations before the first sample are not reported.
+	reconcileTime.WithLabelValues("cluster").Observe(5)
+	reconcileTotal.WithLabelValues("cluster", "success").Add(10)
+	queueDepth.WithLabelValues("cluster").Set(4)
+	residentMemory.Set(100)
+
+	collector := &metricsCollector{
+		log:     logr.Discard(),
+		sources: []MetricsSource{NewGathererMetricsSource("capi", registry)},
+	}
+	start := time.Now()
+	collector.collect(context.Background())
+
+	for range 9 {
+		reconcileTime.WithLabelValues("cluster").Observe(0.05)
+	}
+	reconcileTime.WithLabelValues("cluster").Observe(2)
+	reconcileTotal.WithLabelValues("cluster", "success").Add(8)
+	reconcileTotal.WithLabelValues("cluster", "error").Add(2)
+	reconcileErrors.WithLabelValues("cluster").Add(2)
+	queueDepth.WithLabelValues("cluster").Set(10)
+	residentMemory.Set(300)
+	collector.collect(context.Background())
+	end := time.Now()
+
+	summary := collector.summarize(start, end)
+	g.Expect(summary.Controllers).To(HaveKey("capi/cluster"))
+	controller := summary.Controllers["capi/cluster"]
+	g.Expect(controller.ReconcileTotal).To(Equal(10.0))
+	g.Expect(controller.ReconcileErrors).To(Equal(2.0))
+	g.Expect(controller.ReconcileLatency.Mean).To(BeNumerically("~", 0.245, 1e-9))
+	g.Expect(controller.ReconcileLatency.P50).To(BeNumerically("~", 0.1*5/9, 1e-9))
+	g.Expect(controller.ReconcileLatency.P99).To(BeNumerically("~", 1+9*0.9, 1e-9))
+	g.Expect(controller.QueueDepth).To(Equal(GaugeSummary{Mean: 7, Max: 10}))
+	g.Expect(summary.Components["capi"].ResidentMemoryBytes).To(Equal(GaugeSummary{Mean: 200, Max: 300}))
+
+	// Samples outside of the interval are ignored.
+	summary = collector.summarize(end.Add(time.Second), end.Add(2*time.Second))
+	g.Expect(summary.Controllers).To(BeEmpty())
+	g.Expect(summary.Components).To(BeEmpty())
+}
+
+func TestCounterDelta(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(counterDelta(10, 25)).To(Equal(15.0))
+	// If the counter has been reset, the last value is used.
+	g.Expect(counterDelta(10, 4)).To(Equal(4.0))
+}
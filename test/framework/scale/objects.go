@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+)
+
+const (
+	// ScenarioLabel is set on all the objects created by the harness, with the name of the scenario as a value.
+	ScenarioLabel = "scale.cluster.x-k8s.io/scenario"
+
+	// FaultProfileLabel is set on clusters with a fault profile, with the name of the fault profile as a value.
+	FaultProfileLabel = "scale.cluster.x-k8s.io/fault-profile"
+
+	workerClass = "default-worker"
+)
+
+// clusterClassName returns the name of the ClusterClass for a fault profile (nil means no faults).
+func clusterClassName(s *Scenario, profile *FaultProfile) string {
+	if profile == nil {
+		return s.Name
+	}
+	return fmt.Sprintf("%s-%s", s.Name, profile.Name)
+}
+
+// clusterClassObjects returns the ClusterClass and the templates for a fault profile (nil means no faults).
+// Faults are injected by adding fault annotations to the DevClusterTemplate and DevMachineTemplates of the
+// ClusterClass, which are then propagated by Cluster API to DevClusters and DevMachines.
+func clusterClassObjects(s *Scenario, profile *FaultProfile) []client.Object {
+	name := clusterClassName(s, profile)
+	labels := map[string]string{ScenarioLabel: s.Name}
+
+	var clusterAnnotations, machineAnnotations map[string]string
+	if profile != nil {
+		clusterAnnotations = profile.ClusterAnnotations
+		machineAnnotations = profile.MachineAnnotations
+	}
+
+	provisioning := func(d metav1.Duration) infrav1.CommonProvisioningSettings {
+		return infrav1.CommonProvisioningSettings{
+			StartupDuration: d,
+			StartupJitter:   s.Provisioning.StartupJitter,
+		}
+	}
+	machineTemplate := func(name string) *infrav1.DevMachineTemplate {
+		return &infrav1.DevMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.Namespace, Labels: labels},
+			Spec: infrav1.DevMachineTemplateSpec{
+				Template: infrav1.DevMachineTemplateResource{
+					ObjectMeta: clusterv1.ObjectMeta{Annotations: machineAnnotations},
+					Spec: infrav1.DevMachineSpec{
+						Backend: infrav1.DevMachineBackendSpec{
+							InMemory: &infrav1.InMemoryMachineBackendSpec{
+								VM:        &infrav1.InMemoryVMSpec{Provisioning: provisioning(s.Provisioning.VMStartupDuration)},
+								Node:      &infrav1.InMemoryNodeSpec{Provisioning: provisioning(s.Provisioning.ComponentStartupDuration)},
+								APIServer: &infrav1.InMemoryAPIServerSpec{Provisioning: provisioning(s.Provisioning.ComponentStartupDuration)},
+								Etcd:      &infrav1.InMemoryEtcdSpec{Provisioning: provisioning(s.Provisioning.ComponentStartupDuration)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	unhealthyNodeConditions := []clusterv1.UnhealthyNodeCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, TimeoutSeconds: ptr.To[int32](300)},
+		{Type: corev1.NodeReady, Status: corev1.ConditionFalse, TimeoutSeconds: ptr.To[int32](300)},
+	}
+
+	devClusterTemplate := &infrav1.DevClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-cluster", Namespace: s.Namespace, Labels: labels},
+		Spec: infrav1.DevClusterTemplateSpec{
+			Template: infrav1.DevClusterTemplateResource{
+				ObjectMeta: clusterv1.ObjectMeta{Annotations: clusterAnnotations},
+				Spec: infrav1.DevClusterSpec{
+					Backend: infrav1.DevClusterBackendSpec{
+						InMemory: &infrav1.InMemoryClusterBackendSpec{},
+					},
+				},
+			},
+		},
+	}
+	kubeadmControlPlaneTemplate := &controlplanev1.KubeadmControlPlaneTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-control-plane", Namespace: s.Namespace, Labels: labels},
+	}
+	controlPlaneMachineTemplate := machineTemplate(name + "-control-plane")
+	workerMachineTemplate := machineTemplate(name + "-worker")
+	workerBootstrapTemplate := &bootstrapv1.KubeadmConfigTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-worker", Namespace: s.Namespace, Labels: labels},
+	}
+
+	templateRef := func(apiVersion, kind, name string) clusterv1.ClusterClassTemplateReference {
+		return clusterv1.ClusterClassTemplateReference{APIVersion: apiVersion, Kind: kind, Name: name}
+	}
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.Namespace, Labels: labels},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.InfrastructureClass{
+				TemplateRef: templateRef(infrav1.GroupVersion.String(), "DevClusterTemplate", devClusterTemplate.Name),
+			},
+			ControlPlane: clusterv1.ControlPlaneClass{
+				TemplateRef: templateRef(controlplanev1.GroupVersion.String(), "KubeadmControlPlaneTemplate", kubeadmControlPlaneTemplate.Name),
+				MachineInfrastructure: clusterv1.ControlPlaneClassMachineInfrastructureTemplate{
+					TemplateRef: templateRef(infrav1.GroupVersion.String(), "DevMachineTemplate", controlPlaneMachineTemplate.Name),
+				},
+				HealthCheck: clusterv1.ControlPlaneClassHealthCheck{
+					Checks: clusterv1.ControlPlaneClassHealthCheckChecks{
+						UnhealthyNodeConditions: unhealthyNodeConditions,
+					},
+				},
+			},
+			Workers: clusterv1.WorkersClass{
+				MachineDeployments: []clusterv1.MachineDeploymentClass{
+					{
+						Class: workerClass,
+						Bootstrap: clusterv1.MachineDeploymentClassBootstrapTemplate{
+							TemplateRef: templateRef(bootstrapv1.GroupVersion.String(), "KubeadmConfigTemplate", workerBootstrapTemplate.Name),
+						},
+						Infrastructure: clusterv1.MachineDeploymentClassInfrastructureTemplate{
+							TemplateRef: templateRef(infrav1.GroupVersion.String(), "DevMachineTemplate", workerMachineTemplate.Name),
+						},
+						HealthCheck: clusterv1.MachineDeploymentClassHealthCheck{
+							Checks: clusterv1.MachineDeploymentClassHealthCheckChecks{
+								UnhealthyNodeConditions: unhealthyNodeConditions,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return []client.Object{
+		devClusterTemplate,
+		kubeadmControlPlaneTemplate,
+		controlPlaneMachineTemplate,
+		workerMachineTemplate,
+		workerBootstrapTemplate,
+		clusterClass,
+	}
+}
+
+// clusterName returns the name of the cluster with the given index.
+// Names have leading zeros, e.g. with 1000 clusters names are scale-0001, scale-0002, etc., so they are sorted
+// correctly e.g. in Grafana.
+func clusterName(s *Scenario, index int) string {
+	digits := 1 + int(math.Log10(float64(s.ClusterCount)))
+	return fmt.Sprintf("%s-%0*d", s.Name, digits, index+1)
+}
+
+// clusterObject returns a Cluster for the scenario.
+func clusterObject(s *Scenario, name string, profile *FaultProfile) *clusterv1.Cluster {
+	labels := map[string]string{ScenarioLabel: s.Name}
+	if profile != nil {
+		labels[FaultProfileLabel] = profile.Name
+	}
+
+	machineDeploymentDigits := 1 + int(math.Log10(float64(max(*s.MachineDeploymentCount, 1))))
+	machineDeployments := make([]clusterv1.MachineDeploymentTopology, 0, *s.MachineDeploymentCount)
+	for i := range *s.MachineDeploymentCount {
+		machineDeployments = append(machineDeployments, clusterv1.MachineDeploymentTopology{
+			Class:    workerClass,
+			Name:     fmt.Sprintf("md-%0*d", machineDeploymentDigits, i+1),
+			Replicas: ptr.To(*s.WorkerMachineCount),
+		})
+	}
+
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.Namespace, Labels: labels},
+		Spec: clusterv1.ClusterSpec{
+			Topology: clusterv1.Topology{
+				ClassRef: clusterv1.ClusterClassRef{Name: clusterClassName(s, profile)},
+				Version:  s.KubernetesVersion,
+				ControlPlane: clusterv1.ControlPlaneTopology{
+					Replicas: ptr.To(s.ControlPlaneMachineCount),
+				},
+				Workers: clusterv1.WorkersTopology{
+					MachineDeployments: machineDeployments,
+				},
+			},
+		},
+	}
+}
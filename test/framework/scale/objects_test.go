@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+)
+
+func TestClusterClassObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &Scenario{Name: "scale", ClusterCount: 10, KubernetesVersion: "v1.33.0"}
+	s.Default()
+	profile := &FaultProfile{
+		Name:               "flaky",
+		ClusterCount:       1,
+		ClusterAnnotations: map[string]string{infrav1.APIServerErrorRateFaultAnnotationName: "0.1"},
+		MachineAnnotations: map[string]string{infrav1.NodeNeverReadyRateFaultAnnotationName: "0.5"},
+	}
+
+	objs := clusterClassObjects(s, profile)
+	g.Expect(objs).To(HaveLen(6))
+	for _, obj := range objs {
+		g.Expect(obj.GetNamespace()).To(Equal("scale"))
+		g.Expect(obj.GetLabels()).To(HaveKeyWithValue(ScenarioLabel, "scale"))
+
+		switch o := obj.(type) {
+		case *infrav1.DevClusterTemplate:
+			g.Expect(o.Spec.Template.ObjectMeta.Annotations).To(Equal(profile.ClusterAnnotations))
+		case *infrav1.DevMachineTemplate:
+			g.Expect(o.Spec.Template.ObjectMeta.Annotations).To(Equal(profile.MachineAnnotations))
+			g.Expect(o.Spec.Template.Spec.Backend.InMemory.VM.Provisioning.StartupDuration).To(Equal(s.Provisioning.VMStartupDuration))
+		case *clusterv1.ClusterClass:
+			g.Expect(o.Name).To(Equal("scale-flaky"))
+		}
+	}
+}
+
+func TestClusterObject(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &Scenario{Name: "scale", ClusterCount: 100, KubernetesVersion: "v1.33.0", ControlPlaneMachineCount: 3}
+	s.Default()
+	*s.MachineDeploymentCount = 2
+	*s.WorkerMachineCount = 5
+
+	name := clusterName(s, 0)
+	g.Expect(name).To(Equal("scale-001"))
+
+	cluster := clusterObject(s, name, nil)
+	g.Expect(cluster.Labels).ToNot(HaveKey(FaultProfileLabel))
+	g.Expect(cluster.Spec.Topology.ClassRef.Name).To(Equal("scale"))
+	g.Expect(cluster.Spec.Topology.Version).To(Equal("v1.33.0"))
+	g.Expect(*cluster.Spec.Topology.ControlPlane.Replicas).To(Equal(int32(3)))
+	g.Expect(cluster.Spec.Topology.Workers.MachineDeployments).To(HaveLen(2))
+	g.Expect(cluster.Spec.Topology.Workers.MachineDeployments[1].Name).To(Equal("md-2"))
+	g.Expect(*cluster.Spec.Topology.Workers.MachineDeployments[1].Replicas).To(Equal(int32(5)))
+
+	cluster = clusterObject(s, name, &FaultProfile{Name: "flaky"})
+	g.Expect(cluster.Labels).To(HaveKeyWithValue(FaultProfileLabel, "flaky"))
+	g.Expect(cluster.Spec.Topology.ClassRef.Name).To(Equal("scale-flaky"))
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReportVersion is the version of the Report format.
+const ReportVersion = "v1"
+
+// Report is the result of running a Scenario.
+// All durations and latencies are expressed in seconds.
+type Report struct {
+	// Version of the Report format.
+	Version string `json:"version"`
+
+	// Scenario that generated the report, with defaults applied.
+	Scenario Scenario `json:"scenario"`
+
+	// ScenarioHash is the hash of the Scenario; only reports with the same hash can be compared.
+	ScenarioHash string `json:"scenarioHash"`
+
+	// Metadata provided by the caller, e.g. the commit being tested.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// StartTime is the time when the scenario started.
+	StartTime time.Time `json:"startTime"`
+
+	// DurationSeconds is the duration of the whole scenario.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// Phases of the scenario, in the order they have been executed.
+	Phases []PhaseReport `json:"phases"`
+
+	// Metrics collected during the whole scenario.
+	Metrics MetricsSummary `json:"metrics"`
+}
+
+// PhaseReport is the result of a phase of the Scenario, e.g. create or upgrade.
+type PhaseReport struct {
+	// Name of the phase, e.g. create, upgrade-1, churn or delete.
+	Name string `json:"name"`
+
+	// StartTime is the time when the phase started.
+	StartTime time.Time `json:"startTime"`
+
+	// DurationSeconds is the duration of the phase.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// Clusters is the number of clusters involved in the phase.
+	Clusters int `json:"clusters"`
+
+	// ClusterDurationSeconds summarizes the time it took for each cluster to complete the phase,
+	// e.g. the time from the creation of the cluster to the cluster being fully provisioned.
+	ClusterDurationSeconds LatencySummary `json:"clusterDurationSeconds"`
+
+	// FaultProfileClusters reports about the clusters with a fault profile involved in the phase, if any.
+	// NOTE: In phases waiting for clusters to be provisioned, clusters with a fault profile are not included in
+	// Clusters and ClusterDurationSeconds, and they are not required to complete the phase, because faults can
+	// prevent them from converging, e.g. when Nodes are flapping more frequently than the MachineHealthCheck timeouts.
+	FaultProfileClusters *FaultProfileClustersReport `json:"faultProfileClusters,omitempty"`
+
+	// Errors that occurred during the phase, if any.
+	Errors []string `json:"errors,omitempty"`
+
+	// Metrics collected during the phase.
+	Metrics MetricsSummary `json:"metrics"`
+}
+
+// FaultProfileClustersReport is the result of the clusters with a fault profile in a phase of the Scenario.
+type FaultProfileClustersReport struct {
+	// Clusters is the number of clusters with a fault profile involved in the phase.
+	Clusters int `json:"clusters"`
+
+	// Converged is the number of clusters with a fault profile which completed the phase
+	// before all the other clusters completed it.
+	Converged int `json:"converged"`
+
+	// ClusterDurationSeconds summarizes the time it took for each converged cluster to complete the phase.
+	ClusterDurationSeconds LatencySummary `json:"clusterDurationSeconds"`
+}
+
+// MetricsSummary is a summary of the metrics collected during a Scenario or during one of its phases.
+type MetricsSummary struct {
+	// Controllers has metrics for each controller, keyed by <component>/<controller>.
+	Controllers map[string]ControllerMetrics `json:"controllers,omitempty"`
+
+	// Components has metrics for each component, e.g. for each controller manager.
+	Components map[string]ComponentMetrics `json:"components,omitempty"`
+}
+
+// ControllerMetrics are metrics of a controller.
+type ControllerMetrics struct {
+	// ReconcileTotal is the number of reconciles.
+	ReconcileTotal float64 `json:"reconcileTotal"`
+
+	// ReconcileErrors is the number of reconciles that returned an error.
+	ReconcileErrors float64 `json:"reconcileErrors"`
+
+	// ReconcileLatency summarizes the time spent in reconciles.
+	ReconcileLatency LatencySummary `json:"reconcileLatency"`
+
+	// QueueDepth summarizes the depth of the controller's work queue.
+	QueueDepth GaugeSummary `json:"queueDepth"`
+}
+
+// ComponentMetrics are metrics of a component.
+type ComponentMetrics struct {
+	// ResidentMemoryBytes summarizes the resident memory of the component.
+	ResidentMemoryBytes GaugeSummary `json:"residentMemoryBytes"`
+
+	// HeapInuseBytes summarizes the heap in use by the component.
+	HeapInuseBytes GaugeSummary `json:"heapInuseBytes"`
+}
+
+// LatencySummary summarizes a distribution of latencies.
+type LatencySummary struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+}
+
+// GaugeSummary summarizes the values of a gauge over time.
+type GaugeSummary struct {
+	Mean float64 `json:"mean"`
+	Max  float64 `json:"max"`
+}
+
+// WriteReport writes a Report to a JSON file.
+func WriteReport(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal report")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create folder for report %s", path)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write report to %s", path)
+	}
+	return nil
+}
+
+// LoadReport reads a Report from a JSON file.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Reading a file provided by the user is the intended behavior.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read report from %s", path)
+	}
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse report from %s", path)
+	}
+	if report.Version != ReportVersion {
+		return nil, errors.Errorf("report %s has version %q, expected %q", path, report.Version, ReportVersion)
+	}
+	return report, nil
+}
+
+// CompareOptions are options for Compare.
+type CompareOptions struct {
+	// Tolerance is the relative increase of a value over the baseline which is considered a regression,
+	// e.g. 0.2 means that values more than 20% higher than the baseline are regressions.
+	Tolerance float64
+
+	// MinSeconds is the minimum increase of a duration or latency over the baseline which is considered
+	// a regression; it allows to ignore noise on very small values.
+	MinSeconds float64
+
+	// MinBytes is the minimum increase of memory over the baseline which is considered a regression;
+	// it allows to ignore noise on very small values.
+	MinBytes float64
+}
+
+// Regression is a value which increased over the baseline more than the tolerance.
+type Regression struct {
+	// Name of the value, e.g. phases[create].durationSeconds.
+	Name string `json:"name"`
+
+	// Baseline is the value in the baseline report.
+	Baseline float64 `json:"baseline"`
+
+	// Current is the value in the current report.
+	Current float64 `json:"current"`
+}
+
+// String returns a human readable representation of the Regression.
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.3f -> %.3f (+%.1f%%)", r.Name, r.Baseline, r.Current, (r.Current-r.Baseline)/r.Baseline*100)
+}
+
+// Compare compares a report with a baseline report generated by the same scenario, e.g. on a previous commit,
+// and returns the values which regressed.
+// Compared values are: phase durations, the p90 of the cluster durations in each phase, the p99 of
+// reconcile latency and the max queue depth for each controller, and the max memory for each component.
+func Compare(baseline, current *Report, opts CompareOptions) ([]Regression, error) {
+	if baseline.ScenarioHash != current.ScenarioHash {
+		return nil, errors.Errorf("reports are not comparable: they have been generated by different scenarios (%s and %s)", baseline.ScenarioHash, current.ScenarioHash)
+	}
+
+	regressions := []Regression{}
+	check := func(name string, baselineValue, currentValue, minDelta float64) {
+		if baselineValue <= 0 {
+			return
+		}
+		if currentValue > baselineValue*(1+opts.Tolerance) && currentValue-baselineValue > minDelta {
+			regressions = append(regressions, Regression{Name: name, Baseline: baselineValue, Current: currentValue})
+		}
+	}
+
+	currentPhases := map[string]PhaseReport{}
+	for _, p := range current.Phases {
+		currentPhases[p.Name] = p
+	}
+	for _, b := range baseline.Phases {
+		c, ok := currentPhases[b.Name]
+		if !ok {
+			continue
+		}
+		check(fmt.Sprintf("phases[%s].durationSeconds", b.Name), b.DurationSeconds, c.DurationSeconds, opts.MinSeconds)
+		check(fmt.Sprintf("phases[%s].clusterDurationSeconds.p90", b.Name), b.ClusterDurationSeconds.P90, c.ClusterDurationSeconds.P90, opts.MinSeconds)
+	}
+
+	for name, b := range baseline.Metrics.Controllers {
+		c, ok := current.Metrics.Controllers[name]
+		if !ok {
+			continue
+		}
+		check(fmt.Sprintf("metrics.controllers[%s].reconcileLatency.p99", name), b.ReconcileLatency.P99, c.ReconcileLatency.P99, opts.MinSeconds)
+		check(fmt.Sprintf("metrics.controllers[%s].queueDepth.max", name), b.QueueDepth.Max, c.QueueDepth.Max, 0)
+	}
+
+	for name, b := range baseline.Metrics.Components {
+		c, ok := current.Metrics.Components[name]
+		if !ok {
+			continue
+		}
+		check(fmt.Sprintf("metrics.components[%s].residentMemoryBytes.max", name), b.ResidentMemoryBytes.Max, c.ResidentMemoryBytes.Max, opts.MinBytes)
+		check(fmt.Sprintf("metrics.components[%s].heapInuseBytes.max", name), b.HeapInuseBytes.Max, c.HeapInuseBytes.Max, opts.MinBytes)
+	}
+
+	sort.Slice(regressions, func(i, j int) bool { return regressions[i].Name < regressions[j].Name })
+	return regressions, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteAndLoadReport(t *testing.T) {
+	g := NewWithT(t)
+
+	report := testReport()
+	path := filepath.Join(t.TempDir(), "reports", "report.json")
+	g.Expect(WriteReport(path, report)).To(Succeed())
+
+	loaded, err := LoadReport(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loaded.ScenarioHash).To(Equal(report.ScenarioHash))
+	g.Expect(loaded.Phases).To(Equal(report.Phases))
+	g.Expect(loaded.Metrics).To(Equal(report.Metrics))
+
+	t.Run("reports with a different version are rejected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		path := filepath.Join(t.TempDir(), "report.json")
+		g.Expect(os.WriteFile(path, []byte(`{"version": "v0"}`), 0600)).To(Succeed())
+		_, err := LoadReport(path)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestCompare(t *testing.T) {
+	opts := CompareOptions{Tolerance: 0.2, MinSeconds: 1, MinBytes: 10}
+
+	t.Run("no regressions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		current := testReport()
+		// Increases within the tolerance or below the minimum delta are not regressions.
+		current.Phases[0].DurationSeconds = 110
+		current.Metrics.Controllers["capi/cluster"] = ControllerMetrics{
+			ReconcileLatency: LatencySummary{P99: 0.5},
+			QueueDepth:       GaugeSummary{Max: 12},
+		}
+
+		regressions, err := Compare(testReport(), current, opts)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(regressions).To(BeEmpty())
+	})
+
+	t.Run("regressions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		current := testReport()
+		current.Phases[0].DurationSeconds = 150
+		current.Phases[0].ClusterDurationSeconds.P90 = 60
+		current.Metrics.Controllers["capi/cluster"] = ControllerMetrics{
+			ReconcileLatency: LatencySummary{P99: 2},
+			QueueDepth:       GaugeSummary{Max: 20},
+		}
+		current.Metrics.Components["capi"] = ComponentMetrics{
+			ResidentMemoryBytes: GaugeSummary{Max: 200},
+			HeapInuseBytes:      GaugeSummary{Max: 50},
+		}
+
+		regressions, err := Compare(testReport(), current, opts)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(regressions).To(Equal([]Regression{
+			{Name: "metrics.components[capi].residentMemoryBytes.max", Baseline: 100, Current: 200},
+			{Name: "metrics.controllers[capi/cluster].queueDepth.max", Baseline: 10, Current: 20},
+			{Name: "metrics.controllers[capi/cluster].reconcileLatency.p99", Baseline: 0.1, Current: 2},
+			{Name: "phases[create].clusterDurationSeconds.p90", Baseline: 30, Current: 60},
+			{Name: "phases[create].durationSeconds", Baseline: 100, Current: 150},
+		}))
+		g.Expect(regressions[4].String()).To(Equal("phases[create].durationSeconds: 100.000 -> 150.000 (+50.0%)"))
+	})
+
+	t.Run("reports from different scenarios are not comparable", func(t *testing.T) {
+		g := NewWithT(t)
+
+		current := testReport()
+		current.ScenarioHash = "other"
+
+		_, err := Compare(testReport(), current, opts)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func testReport() *Report {
+	return &Report{
+		Version:      ReportVersion,
+		ScenarioHash: "hash",
+		Phases: []PhaseReport{
+			{
+				Name:                   "create",
+				DurationSeconds:        100,
+				Clusters:               10,
+				ClusterDurationSeconds: LatencySummary{Mean: 20, P50: 20, P90: 30, P99: 40},
+			},
+		},
+		Metrics: MetricsSummary{
+			Controllers: map[string]ControllerMetrics{
+				"capi/cluster": {
+					ReconcileTotal:   1000,
+					ReconcileLatency: LatencySummary{P99: 0.1},
+					QueueDepth:       GaugeSummary{Max: 10},
+				},
+			},
+			Components: map[string]ComponentMetrics{
+				"capi": {
+					ResidentMemoryBytes: GaugeSummary{Max: 100},
+					HeapInuseBytes:      GaugeSummary{Max: 50},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// Scenario defines a scale test.
+type Scenario struct {
+	// Name of the scenario; it is used as a prefix for the name of the clusters.
+	Name string `json:"name"`
+
+	// Namespace where clusters are created; if not set, the name of the scenario is used.
+	Namespace string `json:"namespace,omitempty"`
+
+	// ClusterCount is the number of clusters to be created.
+	ClusterCount int32 `json:"clusterCount"`
+
+	// ControlPlaneMachineCount is the number of control plane machines of each cluster.
+	// If not set, 1 is used.
+	ControlPlaneMachineCount int32 `json:"controlPlaneMachineCount,omitempty"`
+
+	// MachineDeploymentCount is the number of MachineDeployments of each cluster.
+	// If not set, 1 is used.
+	MachineDeploymentCount *int32 `json:"machineDeploymentCount,omitempty"`
+
+	// WorkerMachineCount is the number of worker machines of each MachineDeployment.
+	// If not set, 1 is used.
+	WorkerMachineCount *int32 `json:"workerMachineCount,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version clusters are created with.
+	KubernetesVersion string `json:"kubernetesVersion"`
+
+	// Concurrency is the maximum number of concurrent create, upgrade or delete operations.
+	// If not set, 5 is used.
+	Concurrency int32 `json:"concurrency,omitempty"`
+
+	// UpgradeWaves defines the upgrades to be performed after all the clusters are created.
+	// Waves are executed in order, and each wave waits for the upgraded clusters to be up to date.
+	UpgradeWaves []UpgradeWave `json:"upgradeWaves,omitempty"`
+
+	// Churn defines how clusters are deleted and re-created after the upgrade waves.
+	Churn *Churn `json:"churn,omitempty"`
+
+	// FaultProfiles defines faults to be injected in clusters.
+	// Clusters are assigned to fault profiles in order, e.g. with two fault profiles with 2 clusters each,
+	// the first two clusters get the first fault profile, the next two clusters get the second one,
+	// and the remaining clusters do not get any fault.
+	FaultProfiles []FaultProfile `json:"faultProfiles,omitempty"`
+
+	// Provisioning defines how long it takes to provision in memory machines.
+	Provisioning Provisioning `json:"provisioning,omitempty"`
+
+	// Timeouts for each phase of the scenario.
+	Timeouts Timeouts `json:"timeouts,omitempty"`
+
+	// PollInterval is the interval used when waiting for clusters. If not set, 10s is used.
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+
+	// MetricsInterval is the interval used when collecting metrics. If not set, 15s is used.
+	MetricsInterval metav1.Duration `json:"metricsInterval,omitempty"`
+
+	// SkipCleanup if set to true skips deleting the clusters at the end of the scenario.
+	SkipCleanup bool `json:"skipCleanup,omitempty"`
+}
+
+// UpgradeWave defines an upgrade of a subset of the clusters.
+type UpgradeWave struct {
+	// KubernetesVersion is the Kubernetes version clusters are upgraded to.
+	KubernetesVersion string `json:"kubernetesVersion"`
+
+	// ClusterPercentage is the percentage of clusters to be upgraded, starting from the first one.
+	// Clusters already at the target version are not upgraded again, so e.g. a wave with 10% followed by a wave
+	// with 100% to the same version upgrades first 10% of the clusters and then the remaining clusters.
+	// If not set, 100 is used.
+	ClusterPercentage int32 `json:"clusterPercentage,omitempty"`
+}
+
+// Churn defines how clusters are deleted and re-created.
+type Churn struct {
+	// Duration of the churn phase.
+	Duration metav1.Duration `json:"duration"`
+
+	// ClustersPerMinute is the number of clusters to be deleted and re-created every minute.
+	// Clusters are churned oldest first.
+	ClustersPerMinute int32 `json:"clustersPerMinute"`
+}
+
+// FaultProfile defines faults to be injected in clusters.
+// See test/infrastructure/docker/api/v1beta2/inmemory_faults.go for the supported fault annotations.
+// NOTE: Clusters with a fault profile are not required to complete the phases of the scenario, and they are
+// reported separately.
+type FaultProfile struct {
+	// Name of the fault profile.
+	Name string `json:"name"`
+
+	// ClusterCount is the number of clusters with this fault profile.
+	ClusterCount int32 `json:"clusterCount"`
+
+	// ClusterAnnotations are fault annotations set on the DevClusters.
+	ClusterAnnotations map[string]string `json:"clusterAnnotations,omitempty"`
+
+	// MachineAnnotations are fault annotations set on the DevMachines.
+	MachineAnnotations map[string]string `json:"machineAnnotations,omitempty"`
+}
+
+// Provisioning defines how long it takes to provision in memory machines.
+type Provisioning struct {
+	// VMStartupDuration is the time it takes to provision the VM. If not set, 10s is used.
+	VMStartupDuration metav1.Duration `json:"vmStartupDuration,omitempty"`
+
+	// ComponentStartupDuration is the time it takes to provision each component, e.g. the Node or
+	// the API server, after the VM is provisioned. If not set, 2s is used.
+	ComponentStartupDuration metav1.Duration `json:"componentStartupDuration,omitempty"`
+
+	// StartupJitter is the jitter added to startup durations, e.g. "0.2". If not set, "0.2" is used.
+	StartupJitter string `json:"startupJitter,omitempty"`
+}
+
+// Timeouts for each phase of the scenario.
+type Timeouts struct {
+	// Create is the timeout for all the clusters to be provisioned. If not set, 30m is used.
+	Create metav1.Duration `json:"create,omitempty"`
+
+	// Upgrade is the timeout for the clusters in each upgrade wave to be upgraded. If not set, 30m is used.
+	Upgrade metav1.Duration `json:"upgrade,omitempty"`
+
+	// Churn is the timeout for the clusters to be provisioned after the churn phase. If not set, 30m is used.
+	Churn metav1.Duration `json:"churn,omitempty"`
+
+	// Delete is the timeout for all the clusters to be deleted. If not set, 30m is used.
+	Delete metav1.Duration `json:"delete,omitempty"`
+}
+
+// LoadScenario reads a Scenario from a YAML or JSON file, and then it sets defaults and validates it.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Reading a file provided by the user is the intended behavior.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read scenario from %s", path)
+	}
+
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse scenario from %s", path)
+	}
+	s.Default()
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid scenario in %s", path)
+	}
+	return s, nil
+}
+
+// Default sets default values for a Scenario.
+func (s *Scenario) Default() {
+	if s.Namespace == "" {
+		s.Namespace = s.Name
+	}
+	if s.ControlPlaneMachineCount == 0 {
+		s.ControlPlaneMachineCount = 1
+	}
+	if s.MachineDeploymentCount == nil {
+		s.MachineDeploymentCount = ptr.To[int32](1)
+	}
+	if s.WorkerMachineCount == nil {
+		s.WorkerMachineCount = ptr.To[int32](1)
+	}
+	if s.Concurrency == 0 {
+		s.Concurrency = 5
+	}
+	for i := range s.UpgradeWaves {
+		if s.UpgradeWaves[i].ClusterPercentage == 0 {
+			s.UpgradeWaves[i].ClusterPercentage = 100
+		}
+	}
+	defaultDuration(&s.Provisioning.VMStartupDuration, 10*time.Second)
+	defaultDuration(&s.Provisioning.ComponentStartupDuration, 2*time.Second)
+	if s.Provisioning.StartupJitter == "" {
+		s.Provisioning.StartupJitter = "0.2"
+	}
+	defaultDuration(&s.Timeouts.Create, 30*time.Minute)
+	defaultDuration(&s.Timeouts.Upgrade, 30*time.Minute)
+	defaultDuration(&s.Timeouts.Churn, 30*time.Minute)
+	defaultDuration(&s.Timeouts.Delete, 30*time.Minute)
+	defaultDuration(&s.PollInterval, 10*time.Second)
+	defaultDuration(&s.MetricsInterval, 15*time.Second)
+}
+
+// Validate validates a Scenario.
+func (s *Scenario) Validate() error {
+	if errs := validation.IsDNS1123Label(s.Name); len(errs) > 0 {
+		return errors.Errorf("name %q is invalid: %v", s.Name, errs)
+	}
+	if s.ClusterCount <= 0 {
+		return errors.New("clusterCount must be greater than 0")
+	}
+	if s.ControlPlaneMachineCount <= 0 || s.ControlPlaneMachineCount%2 == 0 {
+		return errors.New("controlPlaneMachineCount must be an odd number greater than 0")
+	}
+	if s.MachineDeploymentCount != nil && *s.MachineDeploymentCount < 0 {
+		return errors.New("machineDeploymentCount must not be negative")
+	}
+	if s.WorkerMachineCount != nil && *s.WorkerMachineCount < 0 {
+		return errors.New("workerMachineCount must not be negative")
+	}
+	if s.KubernetesVersion == "" {
+		return errors.New("kubernetesVersion must be set")
+	}
+	if s.Concurrency <= 0 {
+		return errors.New("concurrency must be greater than 0")
+	}
+	for i, w := range s.UpgradeWaves {
+		if w.KubernetesVersion == "" {
+			return errors.Errorf("upgradeWaves[%d].kubernetesVersion must be set", i)
+		}
+		if w.ClusterPercentage <= 0 || w.ClusterPercentage > 100 {
+			return errors.Errorf("upgradeWaves[%d].clusterPercentage must be in the (0, 100] interval", i)
+		}
+	}
+	if s.Churn != nil {
+		if s.Churn.Duration.Duration <= 0 {
+			return errors.New("churn.duration must be greater than 0")
+		}
+		if s.Churn.ClustersPerMinute <= 0 {
+			return errors.New("churn.clustersPerMinute must be greater than 0")
+		}
+	}
+	faultClusters := int32(0)
+	names := map[string]bool{}
+	for i, p := range s.FaultProfiles {
+		if errs := validation.IsDNS1123Label(p.Name); len(errs) > 0 {
+			return errors.Errorf("faultProfiles[%d].name %q is invalid: %v", i, p.Name, errs)
+		}
+		if names[p.Name] {
+			return errors.Errorf("faultProfiles[%d].name %q is duplicated", i, p.Name)
+		}
+		names[p.Name] = true
+		if p.ClusterCount <= 0 {
+			return errors.Errorf("faultProfiles[%d].clusterCount must be greater than 0", i)
+		}
+		faultClusters += p.ClusterCount
+	}
+	if faultClusters > s.ClusterCount {
+		return errors.Errorf("the sum of faultProfiles[].clusterCount must be less than or equal to clusterCount")
+	}
+	return nil
+}
+
+// Hash returns a hash of the Scenario; reports are comparable only if they have been generated by
+// scenarios with the same hash.
+func (s *Scenario) Hash() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal scenario")
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// faultProfileFor returns the fault profile for the cluster with the given index, if any.
+func (s *Scenario) faultProfileFor(clusterIndex int) *FaultProfile {
+	start := 0
+	for i := range s.FaultProfiles {
+		end := start + int(s.FaultProfiles[i].ClusterCount)
+		if clusterIndex >= start && clusterIndex < end {
+			return &s.FaultProfiles[i]
+		}
+		start = end
+	}
+	return nil
+}
+
+func defaultDuration(d *metav1.Duration, value time.Duration) {
+	if d.Duration == 0 {
+		d.Duration = value
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestLoadScenario(t *testing.T) {
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	g.Expect(os.WriteFile(path, []byte(`
+name: soak
+clusterCount: 10
+kubernetesVersion: v1.33.0
+upgradeWaves:
+- kubernetesVersion: v1.34.0
+  clusterPercentage: 10
+- kubernetesVersion: v1.34.0
+churn:
+  duration: 10m
+  clustersPerMinute: 2
+faultProfiles:
+- name: flaky-nodes
+  clusterCount: 2
+  machineAnnotations:
+    faults.inmemory.infrastructure.cluster.x-k8s.io/node-ready-flapping-period: 5m
+`), 0600)).To(Succeed())
+
+	s, err := LoadScenario(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.Name).To(Equal("soak"))
+	g.Expect(s.Namespace).To(Equal("soak"))
+	g.Expect(s.ControlPlaneMachineCount).To(Equal(int32(1)))
+	g.Expect(s.MachineDeploymentCount).To(Equal(ptr.To[int32](1)))
+	g.Expect(s.WorkerMachineCount).To(Equal(ptr.To[int32](1)))
+	g.Expect(s.Concurrency).To(Equal(int32(5)))
+	g.Expect(s.UpgradeWaves[0].ClusterPercentage).To(Equal(int32(10)))
+	g.Expect(s.UpgradeWaves[1].ClusterPercentage).To(Equal(int32(100)))
+	g.Expect(s.Churn.Duration.Duration).To(Equal(10 * time.Minute))
+	g.Expect(s.Timeouts.Create.Duration).To(Equal(30 * time.Minute))
+	g.Expect(s.FaultProfiles[0].MachineAnnotations).To(HaveLen(1))
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		path := filepath.Join(t.TempDir(), "scenario.yaml")
+		g.Expect(os.WriteFile(path, []byte("name: soak\nclusterCount: 10\nkubernetesVersion: v1.33.0\nfoo: bar\n"), 0600)).To(Succeed())
+		_, err := LoadScenario(path)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestLoadScenarioExample(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := LoadScenario(filepath.Join("examples", "scenario.yaml"))
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestScenarioValidate(t *testing.T) {
+	valid := func() *Scenario {
+		s := &Scenario{Name: "scale", ClusterCount: 10, KubernetesVersion: "v1.33.0"}
+		s.Default()
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(s *Scenario)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*Scenario) {}},
+		{name: "invalid name", mutate: func(s *Scenario) { s.Name = "Scale" }, wantErr: true},
+		{name: "no clusters", mutate: func(s *Scenario) { s.ClusterCount = 0 }, wantErr: true},
+		{name: "even control plane machines", mutate: func(s *Scenario) { s.ControlPlaneMachineCount = 2 }, wantErr: true},
+		{name: "no kubernetes version", mutate: func(s *Scenario) { s.KubernetesVersion = "" }, wantErr: true},
+		{name: "invalid upgrade wave", mutate: func(s *Scenario) {
+			s.UpgradeWaves = []UpgradeWave{{KubernetesVersion: "v1.34.0", ClusterPercentage: 101}}
+		}, wantErr: true},
+		{name: "invalid churn", mutate: func(s *Scenario) {
+			s.Churn = &Churn{Duration: metav1.Duration{Duration: time.Minute}}
+		}, wantErr: true},
+		{name: "duplicated fault profiles", mutate: func(s *Scenario) {
+			s.FaultProfiles = []FaultProfile{{Name: "a", ClusterCount: 1}, {Name: "a", ClusterCount: 1}}
+		}, wantErr: true},
+		{name: "too many clusters with faults", mutate: func(s *Scenario) {
+			s.FaultProfiles = []FaultProfile{{Name: "a", ClusterCount: 6}, {Name: "b", ClusterCount: 5}}
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := valid()
+			tt.mutate(s)
+			err := s.Validate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestScenarioHash(t *testing.T) {
+	g := NewWithT(t)
+
+	s1 := &Scenario{Name: "scale", ClusterCount: 10, KubernetesVersion: "v1.33.0"}
+	s1.Default()
+	s2 := &Scenario{Name: "scale", ClusterCount: 10, KubernetesVersion: "v1.33.0", Concurrency: 5}
+	s2.Default()
+	s3 := &Scenario{Name: "scale", ClusterCount: 20, KubernetesVersion: "v1.33.0"}
+	s3.Default()
+
+	h1, err := s1.Hash()
+	g.Expect(err).ToNot(HaveOccurred())
+	h2, err := s2.Hash()
+	g.Expect(err).ToNot(HaveOccurred())
+	h3, err := s3.Hash()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Scenarios which are the same after defaulting have the same hash.
+	g.Expect(h1).To(Equal(h2))
+	g.Expect(h1).ToNot(Equal(h3))
+}
+
+func TestScenarioFaultProfileFor(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &Scenario{
+		ClusterCount: 10,
+		FaultProfiles: []FaultProfile{
+			{Name: "a", ClusterCount: 2},
+			{Name: "b", ClusterCount: 1},
+		},
+	}
+
+	g.Expect(s.faultProfileFor(0).Name).To(Equal("a"))
+	g.Expect(s.faultProfileFor(1).Name).To(Equal("a"))
+	g.Expect(s.faultProfileFor(2).Name).To(Equal("b"))
+	g.Expect(s.faultProfileFor(3)).To(BeNil())
+}
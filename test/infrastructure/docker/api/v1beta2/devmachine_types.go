@@ -40,6 +40,10 @@ const (
 
 	// VMWaitingForStartupTimeoutReason (Severity=Info) documents a InMemoryMachine VM provisioning.
 	VMWaitingForStartupTimeoutReason = "WaitingForStartupTimeout"
+
+	// VMProvisioningFailedReason (Severity=Error) documents a InMemoryMachine VM failing to provision
+	// because of a fault injected with the vm-provisioning-failure-rate annotation.
+	VMProvisioningFailedReason = "ProvisioningFailed"
 )
 
 const (
@@ -165,6 +169,10 @@ const (
 	// is provisioning (it waits for a startup timeout).
 	DevMachineInMemoryVMWaitingForStartupTimeoutReason = "WaitingForStartupTimeout"
 
+	// DevMachineInMemoryVMProvisioningFailedReason documents a fake VM for a DevMachine's in memory backend failing to provision
+	// because of a fault injected with the vm-provisioning-failure-rate annotation.
+	DevMachineInMemoryVMProvisioningFailedReason = "ProvisioningFailed"
+
 	// DevMachineInMemoryVMProvisionedReason documents when a fake VM for a DevMachine's in memory backend is fully provisioned.
 	DevMachineInMemoryVMProvisionedReason = clusterv1.ProvisionedReason
 
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+// Annotations that can be used to inject faults in DevMachines with the in memory backend.
+// NOTE: Annotations can be set on DevMachines, or on DevMachineTemplates (in spec.template.metadata) in order to
+// inject the same faults into all the DevMachines created from a template.
+// NOTE: Delays are expressed as "<duration>", or as "<distribution>:<parameters>" where distribution is one of
+// "fixed:<delay>", "uniform:<min>,<max>", "normal:<mean>,<stddev>" or "exponential:<mean>".
+// NOTE: Rates are expressed as a probability in the [0, 1] interval; the decision if a fault applies to a DevMachine
+// is stable across reconciles.
+const (
+	// VMProvisioningDelayFaultAnnotationName defines an additional delay for the provisioning of the VM
+	// of an in memory DevMachine, on top of spec.backend.inMemory.vm.provisioning.
+	VMProvisioningDelayFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/vm-provisioning-delay"
+
+	// VMProvisioningFailureRateFaultAnnotationName defines the rate of in memory DevMachines whose VM fails to provision;
+	// such DevMachines never become provisioned.
+	VMProvisioningFailureRateFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/vm-provisioning-failure-rate"
+
+	// NodeNeverReadyRateFaultAnnotationName defines the rate of in memory DevMachines whose Node never becomes Ready.
+	NodeNeverReadyRateFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/node-never-ready-rate"
+
+	// NodeReadyFlappingPeriodFaultAnnotationName defines a period, e.g. "1m", after which the Ready condition
+	// of the Node hosted on an in memory DevMachine flips between True and False.
+	NodeReadyFlappingPeriodFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/node-ready-flapping-period"
+
+	// EtcdAlarmsFaultAnnotationName defines alarms raised by the etcd member hosted on an in memory DevMachine,
+	// e.g. "NOSPACE" or "NOSPACE,CORRUPT".
+	EtcdAlarmsFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/etcd-alarms"
+
+	// EtcdLeaderLossFaultAnnotationName defines if the etcd member hosted on an in memory DevMachine lost the
+	// connection with the etcd leader; when set to "true", requests served by the member fail with a "no leader" error.
+	EtcdLeaderLossFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/etcd-leader-loss"
+)
+
+// Annotations that can be used to inject faults in DevClusters with the in memory backend.
+const (
+	// APIServerLatencyFaultAnnotationName defines a delay added to every request served by the API server of
+	// an in memory DevCluster.
+	APIServerLatencyFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/apiserver-latency"
+
+	// APIServerErrorRateFaultAnnotationName defines the rate of requests served by the API server of an
+	// in memory DevCluster failing with an internal server error.
+	APIServerErrorRateFaultAnnotationName = "faults.inmemory.infrastructure.cluster.x-k8s.io/apiserver-error-rate"
+)
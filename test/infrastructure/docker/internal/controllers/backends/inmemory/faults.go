@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/faults"
+)
+
+// machineFaults are the faults to be injected in a DevMachine with the in memory backend.
+type machineFaults struct {
+	vmProvisioningDelay     time.Duration
+	vmProvisioningFailure   bool
+	nodeNeverReady          bool
+	nodeReadyFlappingPeriod time.Duration
+	etcdAlarms              string
+	etcdLeaderLoss          bool
+}
+
+// getMachineFaults returns the faults to be injected in a DevMachine, as defined by the fault annotations.
+// NOTE: random decisions, like e.g. if a fault with a rate applies to a DevMachine, are made with a random number
+// generator seeded with the UID of the DevMachine, so they are stable across reconciles.
+func getMachineFaults(inMemoryMachine *infrav1.DevMachine) (machineFaults, error) {
+	f := machineFaults{}
+	annotations := inMemoryMachine.GetAnnotations()
+
+	randFor := func(annotation string) faults.Rand {
+		return faults.NewRand(string(inMemoryMachine.UID) + "/" + annotation)
+	}
+
+	if v, ok := annotations[infrav1.VMProvisioningDelayFaultAnnotationName]; ok {
+		delay, err := faults.ParseDelay(v)
+		if err != nil {
+			return machineFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.VMProvisioningDelayFaultAnnotationName)
+		}
+		f.vmProvisioningDelay = delay.Sample(randFor(infrav1.VMProvisioningDelayFaultAnnotationName))
+	}
+
+	if v, ok := annotations[infrav1.VMProvisioningFailureRateFaultAnnotationName]; ok {
+		rate, err := faults.ParseRate(v)
+		if err != nil {
+			return machineFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.VMProvisioningFailureRateFaultAnnotationName)
+		}
+		f.vmProvisioningFailure = randFor(infrav1.VMProvisioningFailureRateFaultAnnotationName).Float64() < rate
+	}
+
+	if v, ok := annotations[infrav1.NodeNeverReadyRateFaultAnnotationName]; ok {
+		rate, err := faults.ParseRate(v)
+		if err != nil {
+			return machineFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.NodeNeverReadyRateFaultAnnotationName)
+		}
+		f.nodeNeverReady = randFor(infrav1.NodeNeverReadyRateFaultAnnotationName).Float64() < rate
+	}
+
+	if v, ok := annotations[infrav1.NodeReadyFlappingPeriodFaultAnnotationName]; ok {
+		period, err := time.ParseDuration(v)
+		if err != nil {
+			return machineFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.NodeReadyFlappingPeriodFaultAnnotationName)
+		}
+		if period < 0 {
+			return machineFaults{}, errors.Errorf("failed to parse %s annotation: period must not be negative", infrav1.NodeReadyFlappingPeriodFaultAnnotationName)
+		}
+		f.nodeReadyFlappingPeriod = period
+	}
+
+	if v, ok := annotations[infrav1.EtcdAlarmsFaultAnnotationName]; ok {
+		alarms := []string{}
+		for _, alarm := range strings.Split(v, ",") {
+			alarm = strings.ToUpper(strings.TrimSpace(alarm))
+			switch alarm {
+			case "":
+				continue
+			case "NOSPACE", "CORRUPT":
+				alarms = append(alarms, alarm)
+			default:
+				return machineFaults{}, errors.Errorf("failed to parse %s annotation: invalid alarm %q, must be one of NOSPACE, CORRUPT", infrav1.EtcdAlarmsFaultAnnotationName, alarm)
+			}
+		}
+		f.etcdAlarms = strings.Join(alarms, ",")
+	}
+
+	if v, ok := annotations[infrav1.EtcdLeaderLossFaultAnnotationName]; ok {
+		leaderLoss, err := strconv.ParseBool(v)
+		if err != nil {
+			return machineFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.EtcdLeaderLossFaultAnnotationName)
+		}
+		f.etcdLeaderLoss = leaderLoss
+	}
+
+	return f, nil
+}
+
+// nodeReady returns if the Node hosted on the DevMachine should be Ready at a given time, and, if the Node
+// is flapping, the time of the next flip.
+func (f machineFaults) nodeReady(nodeCreation, now time.Time) (ready bool, nextFlip time.Duration) {
+	if f.nodeNeverReady {
+		return false, 0
+	}
+	if f.nodeReadyFlappingPeriod == 0 {
+		return true, 0
+	}
+
+	// The node starts Ready, and then flips every period.
+	elapsed := max(now.Sub(nodeCreation), 0)
+	periods := elapsed / f.nodeReadyFlappingPeriod
+	return periods%2 == 0, f.nodeReadyFlappingPeriod - elapsed%f.nodeReadyFlappingPeriod
+}
+
+// getClusterFaults returns the faults to be injected in a DevCluster, as defined by the fault annotations.
+func getClusterFaults(inMemoryCluster *infrav1.DevCluster) (faults.APIServerFaults, error) {
+	f := faults.APIServerFaults{}
+	annotations := inMemoryCluster.GetAnnotations()
+
+	if v, ok := annotations[infrav1.APIServerLatencyFaultAnnotationName]; ok {
+		latency, err := faults.ParseDelay(v)
+		if err != nil {
+			return faults.APIServerFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.APIServerLatencyFaultAnnotationName)
+		}
+		f.Latency = latency
+	}
+
+	if v, ok := annotations[infrav1.APIServerErrorRateFaultAnnotationName]; ok {
+		rate, err := faults.ParseRate(v)
+		if err != nil {
+			return faults.APIServerFaults{}, errors.Wrapf(err, "failed to parse %s annotation", infrav1.APIServerErrorRateFaultAnnotationName)
+		}
+		f.ErrorRate = rate
+	}
+
+	return f, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+)
+
+func TestGetMachineFaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        machineFaults
+		wantErr     bool
+	}{
+		{
+			name: "no faults",
+			want: machineFaults{},
+		},
+		{
+			name: "all faults",
+			annotations: map[string]string{
+				infrav1.VMProvisioningDelayFaultAnnotationName:       "30s",
+				infrav1.VMProvisioningFailureRateFaultAnnotationName: "1",
+				infrav1.NodeNeverReadyRateFaultAnnotationName:        "1",
+				infrav1.NodeReadyFlappingPeriodFaultAnnotationName:   "1m",
+				infrav1.EtcdAlarmsFaultAnnotationName:                "nospace, CORRUPT",
+				infrav1.EtcdLeaderLossFaultAnnotationName:            "true",
+			},
+			want: machineFaults{
+				vmProvisioningDelay:     30 * time.Second,
+				vmProvisioningFailure:   true,
+				nodeNeverReady:          true,
+				nodeReadyFlappingPeriod: time.Minute,
+				etcdAlarms:              "NOSPACE,CORRUPT",
+				etcdLeaderLoss:          true,
+			},
+		},
+		{
+			name: "zero rates never apply",
+			annotations: map[string]string{
+				infrav1.VMProvisioningFailureRateFaultAnnotationName: "0",
+				infrav1.NodeNeverReadyRateFaultAnnotationName:        "0",
+			},
+			want: machineFaults{},
+		},
+		{
+			name:        "invalid delay",
+			annotations: map[string]string{infrav1.VMProvisioningDelayFaultAnnotationName: "foo"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid rate",
+			annotations: map[string]string{infrav1.NodeNeverReadyRateFaultAnnotationName: "2"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid flapping period",
+			annotations: map[string]string{infrav1.NodeReadyFlappingPeriodFaultAnnotationName: "-1m"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid alarm",
+			annotations: map[string]string{infrav1.EtcdAlarmsFaultAnnotationName: "NOSPACE,FOO"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid leader loss",
+			annotations: map[string]string{infrav1.EtcdLeaderLossFaultAnnotationName: "foo"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			inMemoryMachine := &infrav1.DevMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "bar",
+					UID:         "7a3c0b1e",
+					Annotations: tt.annotations,
+				},
+			}
+
+			got, err := getMachineFaults(inMemoryMachine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("decisions are stable for a DevMachine", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inMemoryMachine := &infrav1.DevMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "bar",
+				UID:  "7a3c0b1e",
+				Annotations: map[string]string{
+					infrav1.VMProvisioningDelayFaultAnnotationName:       "uniform:10s,1m",
+					infrav1.VMProvisioningFailureRateFaultAnnotationName: "0.5",
+				},
+			},
+		}
+
+		want, err := getMachineFaults(inMemoryMachine)
+		g.Expect(err).ToNot(HaveOccurred())
+		for range 10 {
+			got, err := getMachineFaults(inMemoryMachine)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(want))
+		}
+	})
+}
+
+func TestMachineFaultsNodeReady(t *testing.T) {
+	creation := time.Now()
+
+	tests := []struct {
+		name         string
+		faults       machineFaults
+		elapsed      time.Duration
+		wantReady    bool
+		wantNextFlip time.Duration
+	}{
+		{
+			name:      "no faults",
+			faults:    machineFaults{},
+			elapsed:   time.Hour,
+			wantReady: true,
+		},
+		{
+			name:      "never ready",
+			faults:    machineFaults{nodeNeverReady: true, nodeReadyFlappingPeriod: time.Minute},
+			elapsed:   time.Hour,
+			wantReady: false,
+		},
+		{
+			name:         "flapping, first period",
+			faults:       machineFaults{nodeReadyFlappingPeriod: time.Minute},
+			elapsed:      20 * time.Second,
+			wantReady:    true,
+			wantNextFlip: 40 * time.Second,
+		},
+		{
+			name:         "flapping, second period",
+			faults:       machineFaults{nodeReadyFlappingPeriod: time.Minute},
+			elapsed:      90 * time.Second,
+			wantReady:    false,
+			wantNextFlip: 30 * time.Second,
+		},
+		{
+			name:         "flapping, third period",
+			faults:       machineFaults{nodeReadyFlappingPeriod: time.Minute},
+			elapsed:      2 * time.Minute,
+			wantReady:    true,
+			wantNextFlip: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ready, nextFlip := tt.faults.nodeReady(creation, creation.Add(tt.elapsed))
+			g.Expect(ready).To(Equal(tt.wantReady))
+			g.Expect(nextFlip).To(Equal(tt.wantNextFlip))
+		})
+	}
+}
+
+func TestGetClusterFaults(t *testing.T) {
+	t.Run("no faults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got, err := getClusterFaults(&infrav1.DevCluster{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got.IsZero()).To(BeTrue())
+	})
+	t.Run("latency and error rate", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got, err := getClusterFaults(&infrav1.DevCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					infrav1.APIServerLatencyFaultAnnotationName:   "uniform:100ms,1s",
+					infrav1.APIServerErrorRateFaultAnnotationName: "0.1",
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got.Latency.String()).To(Equal("uniform:100ms,1s"))
+		g.Expect(got.ErrorRate).To(Equal(0.1))
+	})
+	t.Run("invalid annotations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := getClusterFaults(&infrav1.DevCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					infrav1.APIServerErrorRateFaultAnnotationName: "foo",
+				},
+			},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -120,6 +120,15 @@ func (r *ClusterBackendReconciler) ReconcileNormal(ctx context.Context, cluster
 		return ctrl.Result{}, errors.Wrap(err, "failed to register the resource group for the workload cluster")
 	}
 
+	// Inject faults on the API server of the workload cluster, if any.
+	apiServerFaults, err := getClusterFaults(inMemoryCluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.APIServerMux.SetAPIServerFaults(listenerName, apiServerFaults); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to set API server faults for the workload cluster")
+	}
+
 	// Surface the control plane endpoint
 	if inMemoryCluster.Spec.ControlPlaneEndpoint.Host == "" {
 		inMemoryCluster.Spec.ControlPlaneEndpoint.Host = listener.Host()
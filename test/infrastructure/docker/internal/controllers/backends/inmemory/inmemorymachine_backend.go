@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"maps"
 	"math/rand"
 	"strconv"
 	"time"
@@ -196,6 +197,11 @@ func (r *MachineBackendReconciler) reconcileNormalCloudMachine(ctx context.Conte
 		}
 	}
 
+	machineFaults, err := getMachineFaults(inMemoryMachine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Wait for the VM to be provisioned; provisioned happens a configurable time after the cloud machine creation.
 	provisioningDuration := time.Duration(0)
 	if inMemoryMachine.Spec.Backend.InMemory.VM != nil {
@@ -212,6 +218,7 @@ func (r *MachineBackendReconciler) reconcileNormalCloudMachine(ctx context.Conte
 			}
 		}
 	}
+	provisioningDuration += machineFaults.vmProvisioningDelay
 
 	start := cloudMachine.CreationTimestamp
 	now := time.Now()
@@ -225,6 +232,19 @@ func (r *MachineBackendReconciler) reconcileNormalCloudMachine(ctx context.Conte
 		return ctrl.Result{RequeueAfter: start.Add(provisioningDuration).Sub(now)}, nil
 	}
 
+	// If a provisioning failure has been injected, the VM never completes provisioning.
+	// NOTE: the fault applies only to VMs not yet provisioned.
+	if machineFaults.vmProvisioningFailure && !conditions.IsTrue(inMemoryMachine, infrav1.DevMachineInMemoryVMProvisionedCondition) {
+		v1beta1conditions.MarkFalse(inMemoryMachine, infrav1.VMProvisionedCondition, infrav1.VMProvisioningFailedReason, clusterv1.ConditionSeverityError, "VM provisioning failed (fault injected)")
+		conditions.Set(inMemoryMachine, metav1.Condition{
+			Type:    infrav1.DevMachineInMemoryVMProvisionedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.DevMachineInMemoryVMProvisioningFailedReason,
+			Message: "VM provisioning failed (fault injected)",
+		})
+		return ctrl.Result{}, nil
+	}
+
 	// TODO: consider if to surface VM provisioned also on the cloud machine (currently it surfaces only on the inMemoryMachine)
 
 	inMemoryMachine.Spec.ProviderID = calculateProviderID(inMemoryMachine)
@@ -350,13 +370,59 @@ func (r *MachineBackendReconciler) reconcileNormalNode(ctx context.Context, clus
 		}
 	}
 
+	// Inject faults on the Node Ready condition, if any.
+	machineFaults, err := getMachineFaults(inMemoryMachine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	ready, nextFlip := machineFaults.nodeReady(node.CreationTimestamp.Time, time.Now())
+	if err := r.setNodeReady(ctx, inmemoryClient, node, ready); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	v1beta1conditions.MarkTrue(inMemoryMachine, infrav1.NodeProvisionedCondition)
 	conditions.Set(inMemoryMachine, metav1.Condition{
 		Type:   infrav1.DevMachineInMemoryNodeProvisionedCondition,
 		Status: metav1.ConditionTrue,
 		Reason: infrav1.DevMachineInMemoryNodeProvisionedReason,
 	})
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: nextFlip}, nil
+}
+
+// setNodeReady sets the Ready condition of a Node.
+func (r *MachineBackendReconciler) setNodeReady(ctx context.Context, inmemoryClient inmemoryruntime.Client, node *corev1.Node, ready bool) error {
+	desired := corev1.NodeCondition{
+		LastTransitionTime: metav1.Now(),
+		Type:               corev1.NodeReady,
+		Status:             corev1.ConditionTrue,
+		Reason:             "KubeletReady",
+	}
+	if !ready {
+		desired.Status = corev1.ConditionFalse
+		desired.Reason = "KubeletNotReady"
+		desired.Message = "Node not ready (fault injected)"
+	}
+
+	updatedNode := node.DeepCopy()
+	found := false
+	for i, c := range updatedNode.Status.Conditions {
+		if c.Type != corev1.NodeReady {
+			continue
+		}
+		found = true
+		if c.Status == desired.Status {
+			return nil
+		}
+		updatedNode.Status.Conditions[i] = desired
+	}
+	if !found {
+		updatedNode.Status.Conditions = append(updatedNode.Status.Conditions, desired)
+	}
+
+	if err := inmemoryClient.Patch(ctx, updatedNode, client.MergeFrom(node)); err != nil {
+		return errors.Wrapf(err, "failed to patch Node")
+	}
+	return nil
 }
 
 func calculateProviderID(inMemoryMachine *infrav1.DevMachine) string {
@@ -508,6 +574,15 @@ func (r *MachineBackendReconciler) reconcileNormalETCD(ctx context.Context, clus
 		}
 	}
 
+	// Inject faults on the etcd member, if any.
+	machineFaults, err := getMachineFaults(inMemoryMachine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.setEtcdFaults(ctx, inmemoryClient, etcdPod, machineFaults); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// If there is not yet an etcd member listener for this machine, add it to the server.
 	if !r.APIServerMux.HasEtcdMember(listenerName, etcdMember) {
 		// Getting the etcd CA
@@ -549,6 +624,34 @@ func (r *MachineBackendReconciler) reconcileNormalETCD(ctx context.Context, clus
 	return ctrl.Result{}, nil
 }
 
+// setEtcdFaults sets the annotations used by the etcd server to inject faults on an etcd member.
+func (r *MachineBackendReconciler) setEtcdFaults(ctx context.Context, inmemoryClient inmemoryruntime.Client, etcdPod *corev1.Pod, machineFaults machineFaults) error {
+	updatedPod := etcdPod.DeepCopy()
+	if updatedPod.Annotations == nil {
+		updatedPod.Annotations = map[string]string{}
+	}
+
+	if machineFaults.etcdAlarms != "" {
+		updatedPod.Annotations[cloudv1.EtcdAlarmsAnnotationName] = machineFaults.etcdAlarms
+	} else {
+		delete(updatedPod.Annotations, cloudv1.EtcdAlarmsAnnotationName)
+	}
+
+	if machineFaults.etcdLeaderLoss {
+		updatedPod.Annotations[cloudv1.EtcdNoLeaderAnnotationName] = ""
+	} else {
+		delete(updatedPod.Annotations, cloudv1.EtcdNoLeaderAnnotationName)
+	}
+
+	if maps.Equal(etcdPod.Annotations, updatedPod.Annotations) {
+		return nil
+	}
+	if err := inmemoryClient.Patch(ctx, updatedPod, client.MergeFrom(etcdPod)); err != nil {
+		return errors.Wrapf(err, "failed to patch etcd Pod")
+	}
+	return nil
+}
+
 type etcdInfo struct {
 	clusterID string
 	leaderID  string
@@ -146,6 +146,35 @@ func TestReconcileNormalCloudMachine(t *testing.T) {
 			g.Expect(res.IsZero()).To(BeTrue())
 		})
 	})
+
+	t.Run("VM provisioning fails if a provisioning failure is injected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		inMemoryMachineWithFaults := &infrav1.DevMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "bar",
+				Annotations: map[string]string{
+					infrav1.VMProvisioningFailureRateFaultAnnotationName: "1",
+				},
+			},
+			Spec: infrav1.DevMachineSpec{
+				Backend: infrav1.DevMachineBackendSpec{
+					InMemory: &infrav1.InMemoryMachineBackendSpec{},
+				},
+			},
+		}
+
+		r := MachineBackendReconciler{
+			InMemoryManager: inmemoryruntime.NewManager(scheme),
+		}
+		r.InMemoryManager.AddResourceGroup(klog.KObj(cluster).String())
+
+		res, err := r.reconcileNormalCloudMachine(ctx, cluster, cpMachine, inMemoryMachineWithFaults)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(conditions.IsFalse(inMemoryMachineWithFaults, infrav1.DevMachineInMemoryVMProvisionedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(inMemoryMachineWithFaults, infrav1.DevMachineInMemoryVMProvisionedCondition)).To(Equal(infrav1.DevMachineInMemoryVMProvisioningFailedReason))
+	})
 }
 
 func TestReconcileNormalNode(t *testing.T) {
@@ -262,6 +291,26 @@ func TestReconcileNormalNode(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			g.Expect(res.IsZero()).To(BeTrue())
 		})
+
+		t.Run("node is not ready if a fault is injected", func(t *testing.T) {
+			g := NewWithT(t)
+
+			inMemoryMachineWithFaults := inMemoryMachineWithVMProvisioned.DeepCopy()
+			inMemoryMachineWithFaults.Annotations = map[string]string{
+				infrav1.NodeNeverReadyRateFaultAnnotationName: "1",
+			}
+
+			res, err := r.reconcileNormalNode(ctx, cluster, cpMachine, inMemoryMachineWithFaults)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.IsZero()).To(BeTrue())
+
+			err = c.Get(ctx, client.ObjectKeyFromObject(got), got)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", corev1.NodeReady),
+				HaveField("Status", corev1.ConditionFalse),
+			)))
+		})
 	})
 }
 
@@ -37,4 +37,13 @@ const (
 
 	// EtcdMemberRemoved is added to etcd pods which have been removed from the etcd cluster.
 	EtcdMemberRemoved = "etcd.inmemory.infrastructure.cluster.x-k8s.io/member-removed"
+
+	// EtcdAlarmsAnnotationName defines the name of the annotation applied to in memory etcd
+	// pods to track the alarms raised by the etcd member each pod represent, e.g. "NOSPACE,CORRUPT".
+	EtcdAlarmsAnnotationName = "etcd.inmemory.infrastructure.cluster.x-k8s.io/alarms"
+
+	// EtcdNoLeaderAnnotationName defines the name of the annotation applied to in memory etcd
+	// pods to simulate the etcd member each pod represent having lost the connection with the leader;
+	// when the annotation is present, requests served by the member fail with a "no leader" error.
+	EtcdNoLeaderAnnotationName = "etcd.inmemory.infrastructure.cluster.x-k8s.io/no-leader"
 )
@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package faults defines the building blocks for injecting faults in in memory clusters, like e.g.
+delays sampled from a distribution or failures happening with a given rate.
+
+Faults make it possible to test how Cluster API behaves when the infrastructure or the workload clusters
+do not behave perfectly, e.g. to test MachineHealthChecks, KCP remediation or ClusterCache health checking at scale.
+*/
+package faults
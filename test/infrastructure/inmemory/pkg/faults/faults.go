@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faults
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DelayDistribution defines the distribution used to sample a Delay.
+type DelayDistribution string
+
+const (
+	// FixedDelayDistribution always returns the same delay.
+	FixedDelayDistribution DelayDistribution = "fixed"
+
+	// UniformDelayDistribution returns delays uniformly distributed between a min and a max.
+	UniformDelayDistribution DelayDistribution = "uniform"
+
+	// NormalDelayDistribution returns delays normally distributed with a mean and a standard deviation;
+	// negative samples are truncated to zero.
+	NormalDelayDistribution DelayDistribution = "normal"
+
+	// ExponentialDelayDistribution returns delays exponentially distributed with a mean.
+	ExponentialDelayDistribution DelayDistribution = "exponential"
+)
+
+// Delay is a delay sampled from a distribution.
+// The zero value is a delay that is always zero.
+type Delay struct {
+	distribution DelayDistribution
+	params       []time.Duration
+}
+
+// ParseDelay parses a Delay. Supported formats are:
+//   - "30s" or "fixed:30s", a delay that is always 30s.
+//   - "uniform:10s,1m", a delay uniformly distributed between 10s and 1m.
+//   - "normal:30s,10s", a delay normally distributed with mean 30s and standard deviation 10s.
+//   - "exponential:30s", a delay exponentially distributed with mean 30s.
+func ParseDelay(s string) (Delay, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Delay{}, nil
+	}
+
+	distribution, rawParams, found := strings.Cut(s, ":")
+	if !found {
+		distribution, rawParams = string(FixedDelayDistribution), s
+	}
+
+	params := []time.Duration{}
+	for _, p := range strings.Split(rawParams, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(p))
+		if err != nil {
+			return Delay{}, errors.Wrapf(err, "invalid delay %q", s)
+		}
+		if d < 0 {
+			return Delay{}, errors.Errorf("invalid delay %q: durations must not be negative", s)
+		}
+		params = append(params, d)
+	}
+
+	expectedParams := map[DelayDistribution]int{
+		FixedDelayDistribution:       1,
+		UniformDelayDistribution:     2,
+		NormalDelayDistribution:      2,
+		ExponentialDelayDistribution: 1,
+	}
+	n, ok := expectedParams[DelayDistribution(distribution)]
+	if !ok {
+		return Delay{}, errors.Errorf("invalid delay %q: unknown distribution %q", s, distribution)
+	}
+	if len(params) != n {
+		return Delay{}, errors.Errorf("invalid delay %q: distribution %q requires %d parameters", s, distribution, n)
+	}
+	if DelayDistribution(distribution) == UniformDelayDistribution && params[0] > params[1] {
+		return Delay{}, errors.Errorf("invalid delay %q: min must be less than or equal to max", s)
+	}
+
+	return Delay{distribution: DelayDistribution(distribution), params: params}, nil
+}
+
+// IsZero returns true if the Delay is always zero.
+func (d Delay) IsZero() bool {
+	for _, p := range d.params {
+		if p != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Rand is a source of random numbers used for sampling.
+type Rand interface {
+	Float64() float64
+	NormFloat64() float64
+	ExpFloat64() float64
+}
+
+// GlobalRand is a Rand backed by the top-level functions of the math/rand package; it is safe for concurrent use.
+var GlobalRand Rand = globalRand{}
+
+type globalRand struct{}
+
+func (globalRand) Float64() float64     { return rand.Float64() }     //nolint:gosec // Intentionally using a weak random number generator here.
+func (globalRand) NormFloat64() float64 { return rand.NormFloat64() } //nolint:gosec // Intentionally using a weak random number generator here.
+func (globalRand) ExpFloat64() float64  { return rand.ExpFloat64() }  //nolint:gosec // Intentionally using a weak random number generator here.
+
+// Sample returns a delay sampled from the distribution.
+func (d Delay) Sample(r Rand) time.Duration {
+	switch d.distribution {
+	case FixedDelayDistribution:
+		return d.params[0]
+	case UniformDelayDistribution:
+		return d.params[0] + time.Duration(r.Float64()*float64(d.params[1]-d.params[0]))
+	case NormalDelayDistribution:
+		return max(0, d.params[0]+time.Duration(r.NormFloat64()*float64(d.params[1])))
+	case ExponentialDelayDistribution:
+		return time.Duration(r.ExpFloat64() * float64(d.params[0]))
+	default:
+		return 0
+	}
+}
+
+// String returns the string representation of the Delay.
+func (d Delay) String() string {
+	if d.distribution == "" {
+		return "0s"
+	}
+	params := make([]string, 0, len(d.params))
+	for _, p := range d.params {
+		params = append(params, p.String())
+	}
+	return string(d.distribution) + ":" + strings.Join(params, ",")
+}
+
+// ParseRate parses a rate, i.e. a probability in the [0, 1] interval.
+func ParseRate(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid rate %q", s)
+	}
+	if rate < 0 || rate > 1 {
+		return 0, errors.Errorf("invalid rate %q: must be in the [0, 1] interval", s)
+	}
+	return rate, nil
+}
+
+// NewRand returns a random number generator seeded from the given value.
+// This is used to make random decisions stable across reconciles, e.g. if a fault should be injected for a specific
+// object, by using a random number generator seeded with the object's UID.
+func NewRand(seed string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	return rand.New(rand.NewSource(int64(h.Sum64()))) //nolint:gosec // Intentionally using a weak random number generator here.
+}
+
+// APIServerFaults defines faults to be injected in the API server of an in memory workload cluster.
+type APIServerFaults struct {
+	// Latency is added to every request.
+	Latency Delay
+
+	// ErrorRate is the fraction of requests failing with an internal server error.
+	ErrorRate float64
+}
+
+// IsZero returns true if no faults are defined.
+func (f APIServerFaults) IsZero() bool {
+	return f.Latency.IsZero() && f.ErrorRate == 0
+}
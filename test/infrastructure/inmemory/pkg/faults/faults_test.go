@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faults
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", s: "", want: "0s"},
+		{name: "duration", s: "30s", want: "fixed:30s"},
+		{name: "fixed", s: "fixed:1m", want: "fixed:1m0s"},
+		{name: "uniform", s: "uniform:10s, 1m", want: "uniform:10s,1m0s"},
+		{name: "normal", s: "normal:30s,10s", want: "normal:30s,10s"},
+		{name: "exponential", s: "exponential:30s", want: "exponential:30s"},
+		{name: "invalid duration", s: "foo", wantErr: true},
+		{name: "negative duration", s: "-1s", wantErr: true},
+		{name: "unknown distribution", s: "poisson:1s", wantErr: true},
+		{name: "wrong number of parameters", s: "uniform:1s", wantErr: true},
+		{name: "uniform with min greater than max", s: "uniform:1m,1s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ParseDelay(tt.s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.String()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDelaySample(t *testing.T) {
+	r := NewRand("test")
+
+	t.Run("zero", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d := Delay{}
+		g.Expect(d.IsZero()).To(BeTrue())
+		g.Expect(d.Sample(r)).To(Equal(time.Duration(0)))
+	})
+	t.Run("fixed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d, err := ParseDelay("30s")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(d.IsZero()).To(BeFalse())
+		g.Expect(d.Sample(r)).To(Equal(30 * time.Second))
+	})
+	t.Run("uniform", func(t *testing.T) {
+		g := NewWithT(t)
+
+		d, err := ParseDelay("uniform:10s,20s")
+		g.Expect(err).ToNot(HaveOccurred())
+		for range 100 {
+			s := d.Sample(r)
+			g.Expect(s).To(BeNumerically(">=", 10*time.Second))
+			g.Expect(s).To(BeNumerically("<=", 20*time.Second))
+		}
+	})
+	t.Run("normal and exponential are never negative", func(t *testing.T) {
+		g := NewWithT(t)
+
+		normal, err := ParseDelay("normal:1s,10s")
+		g.Expect(err).ToNot(HaveOccurred())
+		exponential, err := ParseDelay("exponential:1s")
+		g.Expect(err).ToNot(HaveOccurred())
+		for range 100 {
+			g.Expect(normal.Sample(r)).To(BeNumerically(">=", 0))
+			g.Expect(exponential.Sample(r)).To(BeNumerically(">=", 0))
+		}
+	})
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    float64
+		wantErr bool
+	}{
+		{s: "", want: 0},
+		{s: "0", want: 0},
+		{s: "0.25", want: 0.25},
+		{s: "1", want: 1},
+		{s: "-0.1", wantErr: true},
+		{s: "1.1", wantErr: true},
+		{s: "foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ParseRate(tt.s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNewRand(t *testing.T) {
+	g := NewWithT(t)
+
+	// Random number generators with the same seed return the same sequence.
+	g.Expect(NewRand("foo").Float64()).To(Equal(NewRand("foo").Float64()))
+	g.Expect(NewRand("foo").Float64()).ToNot(Equal(NewRand("bar").Float64()))
+}
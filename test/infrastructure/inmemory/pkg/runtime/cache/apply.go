@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// apply implements server-side apply for obj.
+// The applied configuration is merged into the object tracked by the cache (if any), and the fields
+// owned by each field manager are tracked in metadata.managedFields like in a real API server.
+// NOTE: The cache doesn't have the OpenAPI schema of the objects, so field ownership is tracked using
+// deduced types, i.e. maps are granular while lists are atomic; it is an acceptable proxy for this use case.
+func (c *cache) apply(resourceGroup string, obj client.Object, patchData []byte, patchOpts *client.PatchOptions) error {
+	if patchOpts.FieldManager == "" {
+		return apierrors.NewBadRequest("fieldManager is required for apply requests")
+	}
+
+	objGVK, err := c.gvkGetAndSet(obj)
+	if err != nil {
+		return err
+	}
+
+	appliedJS, err := yaml.YAMLToJSON(patchData)
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("error decoding apply patch: %v", err))
+	}
+	appliedObj := &unstructured.Unstructured{}
+	if err := appliedObj.UnmarshalJSON(appliedJS); err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("error decoding apply patch: %v", err))
+	}
+	if appliedObj.GroupVersionKind() != objGVK {
+		return apierrors.NewBadRequest(fmt.Sprintf("apply patch for %s does not match object %s", appliedObj.GroupVersionKind(), objGVK))
+	}
+	if appliedObj.GetName() != obj.GetName() {
+		return apierrors.NewBadRequest(fmt.Sprintf("the name of the apply patch (%s) does not match the name of the object (%s)", appliedObj.GetName(), obj.GetName()))
+	}
+	if appliedObj.GetNamespace() == "" {
+		appliedObj.SetNamespace(obj.GetNamespace())
+	}
+	if appliedObj.GetNamespace() != obj.GetNamespace() {
+		return apierrors.NewBadRequest(fmt.Sprintf("the namespace of the apply patch (%s) does not match the namespace of the object (%s)", appliedObj.GetNamespace(), obj.GetNamespace()))
+	}
+
+	// Gets the live object; if it does not exist, apply creates it.
+	liveObj := &unstructured.Unstructured{}
+	liveObj.SetGroupVersionKind(objGVK)
+	exists := true
+	if err := c.Get(resourceGroup, client.ObjectKeyFromObject(obj), liveObj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		exists = false
+	}
+
+	fieldManager, err := managedfields.NewDefaultFieldManager(
+		managedfields.NewDeducedTypeConverter(),
+		unstructuredObjectConvertor{},
+		unstructuredObjectDefaulter{},
+		unstructuredObjectCreater{},
+		objGVK,
+		objGVK.GroupVersion(),
+		"",
+		nil,
+	)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	force := patchOpts.Force != nil && *patchOpts.Force
+	newObj, err := fieldManager.Apply(liveObj, appliedObj, patchOpts.FieldManager, force)
+	if err != nil {
+		if _, ok := err.(apierrors.APIStatus); ok {
+			return err
+		}
+		return apierrors.NewInternalError(err)
+	}
+
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u.SetUnstructuredContent(newObj.(*unstructured.Unstructured).UnstructuredContent())
+	} else if err := c.scheme.Convert(newObj, obj, nil); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(objGVK)
+
+	return c.store(resourceGroup, obj, exists)
+}
+
+// unstructuredObjectConvertor is a runtime.ObjectConvertor for unstructured objects
+// served in a single version, like all the objects in the cache.
+type unstructuredObjectConvertor struct{}
+
+var _ runtime.ObjectConvertor = unstructuredObjectConvertor{}
+
+func (unstructuredObjectConvertor) Convert(in, out, _ interface{}) error {
+	unstructuredIn, ok := in.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("input type %T is not unstructured", in)
+	}
+	unstructuredOut, ok := out.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("output type %T is not unstructured", out)
+	}
+	unstructuredOut.SetUnstructuredContent(unstructuredIn.UnstructuredContent())
+	return nil
+}
+
+func (unstructuredObjectConvertor) ConvertToVersion(in runtime.Object, _ runtime.GroupVersioner) (runtime.Object, error) {
+	return in, nil
+}
+
+func (unstructuredObjectConvertor) ConvertFieldLabel(_ schema.GroupVersionKind, label, value string) (string, string, error) {
+	return label, value, nil
+}
+
+// unstructuredObjectDefaulter is a runtime.ObjectDefaulter that does not apply any default.
+type unstructuredObjectDefaulter struct{}
+
+var _ runtime.ObjectDefaulter = unstructuredObjectDefaulter{}
+
+func (unstructuredObjectDefaulter) Default(_ runtime.Object) {}
+
+// unstructuredObjectCreater is a runtime.ObjectCreater for unstructured objects.
+type unstructuredObjectCreater struct{}
+
+var _ runtime.ObjectCreater = unstructuredObjectCreater{}
+
+func (unstructuredObjectCreater) New(kind schema.GroupVersionKind) (runtime.Object, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(kind)
+	return obj, nil
+}
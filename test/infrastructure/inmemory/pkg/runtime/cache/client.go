@@ -101,12 +101,19 @@ func (c *cache) List(resourceGroup string, list client.ObjectList, opts ...clien
 	tracker.lock.RLock()
 	defer tracker.lock.RUnlock()
 
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	objGVK := unsafeGuessObjectKindFromList(gvk)
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		if err := c.validateFieldSelector(objGVK, listOpts.FieldSelector); err != nil {
+			return err
+		}
+	}
+
 	items := make([]runtime.Object, 0)
-	objects, ok := tracker.objects[unsafeGuessObjectKindFromList(gvk)]
+	objects, ok := tracker.objects[objGVK]
 	if ok {
-		listOpts := client.ListOptions{}
-		listOpts.ApplyOptions(opts)
-
 		for _, obj := range objects {
 			if listOpts.Namespace != "" && obj.GetNamespace() != listOpts.Namespace {
 				continue
@@ -119,12 +126,9 @@ func (c *cache) List(resourceGroup string, list client.ObjectList, opts ...clien
 				}
 			}
 
-			// TODO(killianmuldoon): This only matches the nodeName field for pods. No other fieldSelectors are implemented. This should return an error if another fieldselector is used.
-			if pod, ok := obj.(*corev1.Pod); ok {
-				if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
-					if !listOpts.FieldSelector.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName}) {
-						continue
-					}
+			if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+				if !listOpts.FieldSelector.Matches(SelectableFields(obj)) {
+					continue
 				}
 			}
 
@@ -151,6 +155,48 @@ func (c *cache) List(resourceGroup string, list client.ObjectList, opts ...clien
 	return nil
 }
 
+// validateFieldSelector returns an error if the field selector uses fields that are not supported for the given kind.
+func (c *cache) validateFieldSelector(gvk schema.GroupVersionKind, selector fields.Selector) error {
+	var obj client.Object = &unstructured.Unstructured{}
+	if o, err := c.scheme.New(gvk); err == nil {
+		obj = o.(client.Object)
+	}
+	objFields := SelectableFields(obj)
+	for _, r := range selector.Requirements() {
+		if _, ok := objFields[r.Field]; !ok {
+			return apierrors.NewBadRequest(fmt.Sprintf("field label not supported: %s", r.Field))
+		}
+	}
+	return nil
+}
+
+// SelectableFields returns the fields of an object that can be used in field selectors.
+// NOTE: This supports metadata.name and metadata.namespace for all the objects, plus the most commonly used
+// fields for Pods, Nodes, Namespaces and Secrets, which is a subset of the fields supported by a real API server.
+func SelectableFields(obj client.Object) fields.Set {
+	objFields := fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	}
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		objFields["spec.nodeName"] = o.Spec.NodeName
+		objFields["spec.restartPolicy"] = string(o.Spec.RestartPolicy)
+		objFields["spec.schedulerName"] = o.Spec.SchedulerName
+		objFields["spec.serviceAccountName"] = o.Spec.ServiceAccountName
+		objFields["status.phase"] = string(o.Status.Phase)
+		objFields["status.podIP"] = o.Status.PodIP
+		objFields["status.nominatedNodeName"] = o.Status.NominatedNodeName
+	case *corev1.Node:
+		objFields["spec.unschedulable"] = fmt.Sprintf("%t", o.Spec.Unschedulable)
+	case *corev1.Namespace:
+		objFields["status.phase"] = string(o.Status.Phase)
+	case *corev1.Secret:
+		objFields["type"] = string(o.Type)
+	}
+	return objFields
+}
+
 func (c *cache) Create(resourceGroup string, obj client.Object) error {
 	return c.store(resourceGroup, obj, false)
 }
@@ -283,12 +329,18 @@ func updateTrackerOwnerReferences(tracker *resourceGroupTracker, oldObj, newObj
 	}
 }
 
-func (c *cache) Patch(resourceGroup string, obj client.Object, patch client.Patch) error {
+func (c *cache) Patch(resourceGroup string, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 	patchData, err := patch.Data(obj)
 	if err != nil {
 		return apierrors.NewInternalError(err)
 	}
 
+	if patch.Type() == types.ApplyPatchType {
+		patchOpts := &client.PatchOptions{}
+		patchOpts.ApplyOptions(opts)
+		return c.apply(resourceGroup, obj, patchData, patchOpts)
+	}
+
 	encoder, err := c.getEncoder(obj, obj.GetObjectKind().GroupVersionKind().GroupVersion())
 	if err != nil {
 		return apierrors.NewInternalError(err)
@@ -312,6 +364,15 @@ func (c *cache) Patch(resourceGroup string, obj client.Object, patch client.Patc
 		if err != nil {
 			return apierrors.NewInternalError(err)
 		}
+	case types.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(patchData)
+		if err != nil {
+			return apierrors.NewBadRequest(fmt.Sprintf("error decoding json patch: %v", err))
+		}
+		changedJS, err = jsonPatch.Apply(originalObjJS)
+		if err != nil {
+			return apierrors.NewBadRequest(fmt.Sprintf("error applying json patch: %v", err))
+		}
 	default:
 		return apierrors.NewBadRequest(fmt.Sprintf("patch of type %s is not supported", patch.Type()))
 	}
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -332,9 +333,105 @@ func Test_cache_client(t *testing.T) {
 			g.Expect(i2.GetAnnotations()).To(HaveKey(lastSyncTimeAnnotation), "last sync annotation must be present")
 		})
 
+		t.Run("list with field selector", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &cloudv1.CloudMachineList{}
+			err := c.List("foo", obj, client.MatchingFields{"metadata.name": "bar"})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(obj.Items).To(HaveLen(1))
+			g.Expect(obj.Items[0].GetName()).To(Equal("bar"))
+		})
+
+		t.Run("fails if field selector is not supported", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &cloudv1.CloudMachineList{}
+			err := c.List("foo", obj, client.MatchingFields{"spec.nodeName": "bar"})
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+		})
+
 		// TODO: test filtering by labels
 	})
 
+	t.Run("patch objects", func(t *testing.T) {
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		t.Run("merge patch", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := createMachine(t, c, "foo", "merge")
+			err := c.Patch("foo", obj, client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"labels":{"foo":"bar"}}}`)))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"foo": "bar"}))
+		})
+
+		t.Run("json patch", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := createMachine(t, c, "foo", "json")
+			err := c.Patch("foo", obj, client.RawPatch(types.JSONPatchType, []byte(`[{"op":"add","path":"/metadata/labels","value":{"foo":"bar"}}]`)))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"foo": "bar"}))
+
+			err = c.Patch("foo", obj, client.RawPatch(types.JSONPatchType, []byte(`[{"op":"test","path":"/metadata/labels/foo","value":"baz"}]`)))
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+		})
+
+		t.Run("apply fails without field manager", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &cloudv1.CloudMachine{ObjectMeta: metav1.ObjectMeta{Name: "apply"}}
+			err := c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{"a": "1"})))
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+		})
+
+		t.Run("apply", func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Apply creates the object if it does not exist.
+			obj := &cloudv1.CloudMachine{ObjectMeta: metav1.ObjectMeta{Name: "apply"}}
+			err := c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{"a": "1", "b": "1"})), client.FieldOwner("manager-a"))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"a": "1", "b": "1"}))
+			g.Expect(obj.GetResourceVersion()).ToNot(BeEmpty())
+			g.Expect(managers(obj)).To(ConsistOf("manager-a"))
+
+			// Another manager can apply fields not owned by other managers.
+			obj = &cloudv1.CloudMachine{ObjectMeta: metav1.ObjectMeta{Name: "apply"}}
+			err = c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{"c": "1"})), client.FieldOwner("manager-b"))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"a": "1", "b": "1", "c": "1"}))
+			g.Expect(managers(obj)).To(ConsistOf("manager-a", "manager-b"))
+
+			// Changing a field owned by another manager is a conflict, unless forced.
+			err = c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{"a": "2", "c": "1"})), client.FieldOwner("manager-b"))
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(apierrors.IsConflict(err)).To(BeTrue())
+
+			err = c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{"a": "2", "c": "1"})), client.FieldOwner("manager-b"), client.ForceOwnership)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"a": "2", "b": "1", "c": "1"}))
+
+			// Fields no longer applied by a manager are removed.
+			err = c.Patch("foo", obj, client.RawPatch(types.ApplyPatchType, applyMachineConfiguration("apply", map[string]string{})), client.FieldOwner("manager-a"))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.GetLabels()).To(Equal(map[string]string{"a": "2", "c": "1"}))
+
+			// Changes are persisted.
+			objAfter := &cloudv1.CloudMachine{}
+			err = c.Get("foo", types.NamespacedName{Name: "apply"}, objAfter)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(objAfter.GetLabels()).To(Equal(map[string]string{"a": "2", "c": "1"}))
+			g.Expect(objAfter.GetManagedFields()).To(Equal(obj.GetManagedFields()))
+		})
+	})
+
 	t.Run("update objects", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -753,6 +850,29 @@ func createMachine(t *testing.T, c *cache, resourceGroup, name string) *cloudv1.
 	return obj
 }
 
+func applyMachineConfiguration(name string, labels map[string]string) []byte {
+	obj := &cloudv1.CloudMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cloudv1.GroupVersion.String(),
+			Kind:       cloudv1.CloudMachineKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+	data, _ := json.Marshal(obj)
+	return data
+}
+
+func managers(obj client.Object) []string {
+	managers := []string{}
+	for _, f := range obj.GetManagedFields() {
+		managers = append(managers, f.Manager)
+	}
+	return managers
+}
+
 var _ Informer = &fakeInformer{}
 
 type fakeInformer struct {
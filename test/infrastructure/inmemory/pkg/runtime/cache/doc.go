@@ -20,6 +20,9 @@ Package cache defines resource group aware Cache.
 The Cache implements sync loop and garbage collector inspired from the ones existing
 in Kubernetes.
 
+The Cache also supports server-side apply, and it tracks the fields owned by each field manager
+in metadata.managedFields like the Kubernetes API server does.
+
 Note: The cloud runtime is using a Cache for all the resource groups.
 */
 package cache
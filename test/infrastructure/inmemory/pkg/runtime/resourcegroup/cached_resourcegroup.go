@@ -74,6 +74,6 @@ func (c *cachedClient) Update(_ context.Context, obj client.Object) error {
 	return c.cache.Update(c.resourceGroup, obj)
 }
 
-func (c *cachedClient) Patch(_ context.Context, obj client.Object, patch client.Patch) error {
-	return c.cache.Patch(c.resourceGroup, obj, patch)
+func (c *cachedClient) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.cache.Patch(c.resourceGroup, obj, patch, opts...)
 }
@@ -86,37 +86,37 @@ func NewAPIServerHandler(manager inmemoryruntime.Manager, log logr.Logger, resol
 	ws.Route(ws.GET("/apis/{group}/{version}").To(apiServer.apisDiscovery))
 
 	// CRUD endpoints (global objects)
-	ws.Route(ws.POST("/api/v1/{resource}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Create))
+	ws.Route(ws.POST("/api/v1/{resource}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Create))
 	ws.Route(ws.GET("/api/v1/{resource}").If(isList).To(apiServer.apiV1List))
 	ws.Route(ws.GET("/api/v1/{resource}").If(isWatch).To(apiServer.apiV1Watch))
 	ws.Route(ws.GET("/api/v1/{resource}/{name}").To(apiServer.apiV1Get))
-	ws.Route(ws.PUT("/api/v1/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Update))
-	ws.Route(ws.PATCH("/api/v1/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType)).To(apiServer.apiV1Patch))
+	ws.Route(ws.PUT("/api/v1/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Update))
+	ws.Route(ws.PATCH("/api/v1/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType), string(types.JSONPatchType), string(types.ApplyPatchType)).To(apiServer.apiV1Patch))
 	ws.Route(ws.DELETE("/api/v1/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON).To(apiServer.apiV1Delete))
 
-	ws.Route(ws.POST("/apis/{group}/{version}/{resource}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Create))
+	ws.Route(ws.POST("/apis/{group}/{version}/{resource}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Create))
 	ws.Route(ws.GET("/apis/{group}/{version}/{resource}").If(isList).To(apiServer.apiV1List))
 	ws.Route(ws.GET("/apis/{group}/{version}/{resource}").If(isWatch).To(apiServer.apiV1Watch))
 	ws.Route(ws.GET("/apis/{group}/{version}/{resource}/{name}").To(apiServer.apiV1Get))
-	ws.Route(ws.PUT("/apis/{group}/{version}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Update))
-	ws.Route(ws.PATCH("/apis/{group}/{version}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType)).To(apiServer.apiV1Patch))
+	ws.Route(ws.PUT("/apis/{group}/{version}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Update))
+	ws.Route(ws.PATCH("/apis/{group}/{version}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType), string(types.JSONPatchType), string(types.ApplyPatchType)).To(apiServer.apiV1Patch))
 	ws.Route(ws.DELETE("/apis/{group}/{version}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON).To(apiServer.apiV1Delete))
 
 	// CRUD endpoints (namespaced objects)
-	ws.Route(ws.POST("/api/v1/namespaces/{namespace}/{resource}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Create))
+	ws.Route(ws.POST("/api/v1/namespaces/{namespace}/{resource}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Create))
 	ws.Route(ws.GET("/api/v1/namespaces/{namespace}/{resource}").If(isList).To(apiServer.apiV1List))
 	ws.Route(ws.GET("/api/v1/namespaces/{namespace}/{resource}").If(isWatch).To(apiServer.apiV1Watch))
 	ws.Route(ws.GET("/api/v1/namespaces/{namespace}/{resource}/{name}").To(apiServer.apiV1Get))
-	ws.Route(ws.PUT("/api/v1/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Update))
-	ws.Route(ws.PATCH("/api/v1/namespaces/{namespace}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType)).To(apiServer.apiV1Patch))
+	ws.Route(ws.PUT("/api/v1/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Update))
+	ws.Route(ws.PATCH("/api/v1/namespaces/{namespace}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType), string(types.JSONPatchType), string(types.ApplyPatchType)).To(apiServer.apiV1Patch))
 	ws.Route(ws.DELETE("/api/v1/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON).To(apiServer.apiV1Delete))
 
-	ws.Route(ws.POST("/apis/{group}/{version}/namespaces/{namespace}/{resource}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Create))
+	ws.Route(ws.POST("/apis/{group}/{version}/namespaces/{namespace}/{resource}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Create))
 	ws.Route(ws.GET("/apis/{group}/{version}/namespaces/{namespace}/{resource}").If(isList).To(apiServer.apiV1List))
 	ws.Route(ws.GET("/apis/{group}/{version}/namespaces/{namespace}/{resource}").If(isWatch).To(apiServer.apiV1Watch))
 	ws.Route(ws.GET("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").To(apiServer.apiV1Get))
-	ws.Route(ws.PUT("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf).To(apiServer.apiV1Update))
-	ws.Route(ws.PATCH("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType)).To(apiServer.apiV1Patch))
+	ws.Route(ws.PUT("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON, runtime.ContentTypeYAML).To(apiServer.apiV1Update))
+	ws.Route(ws.PATCH("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").Consumes(string(types.MergePatchType), string(types.StrategicMergePatchType), string(types.JSONPatchType), string(types.ApplyPatchType)).To(apiServer.apiV1Patch))
 	ws.Route(ws.DELETE("/apis/{group}/{version}/namespaces/{namespace}/{resource}/{name}").Consumes(runtime.ContentTypeProtobuf, runtime.ContentTypeJSON).To(apiServer.apiV1Delete))
 
 	// Port forward endpoints
@@ -345,7 +345,6 @@ func (h *apiServerHandler) v1List(ctx context.Context, req *restful.Request, gvk
 		listOpts = append(listOpts, client.InNamespace(req.PathParameter("namespace")))
 	}
 
-	// TODO: The only field Selector which works is for `spec.nodeName` on pods.
 	fieldSelector, err := fields.ParseSelector(req.QueryParameter("fieldSelector"))
 	if err != nil {
 		return nil, err
@@ -523,11 +522,26 @@ func (h *apiServerHandler) apiV1Patch(req *restful.Request, resp *restful.Respon
 	obj.SetName(req.PathParameter("name"))
 	obj.SetNamespace(req.PathParameter("namespace"))
 
-	if err := inmemoryClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
-		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
-		return
+	patchOpts := []client.PatchOption{}
+	if fieldManager := req.QueryParameter("fieldManager"); fieldManager != "" {
+		patchOpts = append(patchOpts, client.FieldOwner(fieldManager))
+	}
+	if force := req.QueryParameter("force"); force == "true" {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+
+	// NOTE: Server-side apply creates the object if it does not exist, so there is no need to get it.
+	if patchType != types.ApplyPatchType {
+		if err := inmemoryClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if status, ok := err.(apierrors.APIStatus); ok || errors.As(err, &status) {
+				_ = resp.WriteHeaderAndEntity(int(status.Status().Code), status)
+				return
+			}
+			_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+			return
+		}
 	}
-	if err := inmemoryClient.Patch(ctx, obj, patch); err != nil {
+	if err := inmemoryClient.Patch(ctx, obj, patch, patchOpts...); err != nil {
 		if status, ok := err.(apierrors.APIStatus); ok || errors.As(err, &status) {
 			_ = resp.WriteHeaderAndEntity(int(status.Status().Code), status)
 			return
@@ -25,10 +25,14 @@ import (
 
 	"github.com/emicklei/go-restful/v3"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inmemorycache "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/runtime/cache"
 )
 
 // Event records a lifecycle event for a Kubernetes object.
@@ -38,14 +42,32 @@ type Event struct {
 }
 
 // WatchEventDispatcher dispatches events for a single resourceGroup.
+// If namespace, labelSelector or fieldSelector are set, only events for matching objects are dispatched.
 type WatchEventDispatcher struct {
 	resourceGroup string
+	namespace     string
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
 	events        chan *Event
 }
 
+// matches returns true if the object is in the namespace and matches the selectors of the watch.
+func (m *WatchEventDispatcher) matches(o client.Object) bool {
+	if m.namespace != "" && o.GetNamespace() != m.namespace {
+		return false
+	}
+	if m.labelSelector != nil && !m.labelSelector.Empty() && !m.labelSelector.Matches(labels.Set(o.GetLabels())) {
+		return false
+	}
+	if m.fieldSelector != nil && !m.fieldSelector.Empty() && !m.fieldSelector.Matches(inmemorycache.SelectableFields(o)) {
+		return false
+	}
+	return true
+}
+
 // OnCreate dispatches Create events.
 func (m *WatchEventDispatcher) OnCreate(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
+	if resourceGroup != m.resourceGroup || !m.matches(o) {
 		return
 	}
 	m.events <- &Event{
@@ -55,19 +77,33 @@ func (m *WatchEventDispatcher) OnCreate(resourceGroup string, o client.Object) {
 }
 
 // OnUpdate dispatches Update events.
-func (m *WatchEventDispatcher) OnUpdate(resourceGroup string, _, o client.Object) {
+// Like in a real API server, if the update moves the object in or out of the selection of the watch,
+// an Added or a Deleted event is dispatched instead.
+func (m *WatchEventDispatcher) OnUpdate(resourceGroup string, oldObj, o client.Object) {
 	if resourceGroup != m.resourceGroup {
 		return
 	}
+	oldMatches, newMatches := m.matches(oldObj), m.matches(o)
+	var eventType watch.EventType
+	switch {
+	case oldMatches && newMatches:
+		eventType = watch.Modified
+	case !oldMatches && newMatches:
+		eventType = watch.Added
+	case oldMatches && !newMatches:
+		eventType = watch.Deleted
+	default:
+		return
+	}
 	m.events <- &Event{
-		Type:   watch.Modified,
+		Type:   eventType,
 		Object: o,
 	}
 }
 
 // OnDelete dispatches Delete events.
 func (m *WatchEventDispatcher) OnDelete(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
+	if resourceGroup != m.resourceGroup || !m.matches(o) {
 		return
 	}
 	m.events <- &Event{
@@ -78,7 +114,7 @@ func (m *WatchEventDispatcher) OnDelete(resourceGroup string, o client.Object) {
 
 // OnGeneric dispatches Generic events.
 func (m *WatchEventDispatcher) OnGeneric(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
+	if resourceGroup != m.resourceGroup || !m.matches(o) {
 		return
 	}
 	m.events <- &Event{
@@ -93,6 +129,14 @@ func (h *apiServerHandler) watchForResource(req *restful.Request, resp *restful.
 	ctx = ctrl.LoggerInto(ctx, log)
 	queryTimeout := req.QueryParameter("timeoutSeconds")
 	resourceVersion := req.QueryParameter("resourceVersion")
+	fieldSelector, err := fields.ParseSelector(req.QueryParameter("fieldSelector"))
+	if err != nil {
+		return err
+	}
+	labelSelector, err := labels.Parse(req.QueryParameter("labelSelector"))
+	if err != nil {
+		return err
+	}
 	c := h.manager.GetCache()
 	i, err := c.GetInformerForKind(ctx, gvk)
 	if err != nil {
@@ -104,6 +148,9 @@ func (h *apiServerHandler) watchForResource(req *restful.Request, resp *restful.
 	events := make(chan *Event, 1000)
 	watcher := &WatchEventDispatcher{
 		resourceGroup: resourceGroup,
+		namespace:     req.PathParameter("namespace"),
+		labelSelector: labelSelector,
+		fieldSelector: fieldSelector,
 		events:        events,
 	}
 
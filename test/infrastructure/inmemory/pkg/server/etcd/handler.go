@@ -27,6 +27,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	corev1 "k8s.io/api/core/v1"
@@ -83,9 +84,15 @@ func (m *maintenanceServer) Alarm(ctx context.Context, _ *pb.AlarmRequest) (*pb.
 		return nil, err
 	}
 
+	inmemoryClient := m.manager.GetResourceGroup(resourceGroup).GetClient()
+
 	m.log.V(4).Info("Etcd: Alarm", "resourceGroup", resourceGroup, "etcdMember", etcdMember)
+	alarms, err := m.inspectEtcdAlarms(ctx, inmemoryClient)
+	if err != nil {
+		return nil, err
+	}
 
-	return &pb.AlarmResponse{}, nil
+	return &pb.AlarmResponse{Alarms: alarms}, nil
 }
 
 func (m *maintenanceServer) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
@@ -304,6 +311,7 @@ func (b *baseServer) inspectEtcd(ctx context.Context, inmemoryClient inmemoryrun
 	var clusterID int
 	var leaderID int
 	var leaderFrom time.Time
+	var noLeader bool
 	for _, pod := range etcdPods.Items {
 		if _, ok := pod.Annotations[cloudv1.EtcdMemberRemoved]; ok {
 			if pod.Name == fmt.Sprintf("%s%s", "etcd-", etcdMember) {
@@ -333,6 +341,12 @@ func (b *baseServer) inspectEtcd(ctx context.Context, inmemoryClient inmemoryrun
 			}
 		}
 
+		if pod.Name == fmt.Sprintf("%s%s", "etcd-", etcdMember) {
+			if _, ok := pod.Annotations[cloudv1.EtcdNoLeaderAnnotationName]; ok {
+				noLeader = true
+			}
+		}
+
 		if pod.Name == etcdMember {
 			memberList.Header = &pb.ResponseHeader{
 				ClusterId: uint64(clusterID),
@@ -346,6 +360,10 @@ func (b *baseServer) inspectEtcd(ctx context.Context, inmemoryClient inmemoryrun
 		})
 	}
 
+	if noLeader {
+		return nil, nil, rpctypes.ErrGRPCNoLeader
+	}
+
 	if leaderID == 0 {
 		// TODO: consider if and how to automatically recover from this case
 		//  note: this can happen also when adding a new etcd members in the handler, might be it is something we have to take case before deletion...
@@ -356,3 +374,42 @@ func (b *baseServer) inspectEtcd(ctx context.Context, inmemoryClient inmemoryrun
 
 	return memberList, statusResponse, nil
 }
+
+func (b *baseServer) inspectEtcdAlarms(ctx context.Context, inmemoryClient inmemoryruntime.Client) ([]*pb.AlarmMember, error) {
+	etcdPods := &corev1.PodList{}
+	if err := inmemoryClient.List(ctx, etcdPods,
+		client.InNamespace(metav1.NamespaceSystem),
+		client.MatchingLabels{
+			"component": "etcd",
+			"tier":      "control-plane"},
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd members")
+	}
+
+	alarms := []*pb.AlarmMember{}
+	for _, pod := range etcdPods.Items {
+		if _, ok := pod.Annotations[cloudv1.EtcdMemberRemoved]; ok {
+			continue
+		}
+		if pod.Annotations[cloudv1.EtcdAlarmsAnnotationName] == "" {
+			continue
+		}
+
+		memberID, err := strconv.ParseUint(pod.Annotations[cloudv1.EtcdMemberIDAnnotationName], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed read member ID annotation from etcd member with name %s", pod.Name)
+		}
+
+		for _, alarm := range strings.Split(pod.Annotations[cloudv1.EtcdAlarmsAnnotationName], ",") {
+			alarmType, ok := pb.AlarmType_value[strings.TrimSpace(alarm)]
+			if !ok {
+				return nil, errors.Errorf("invalid alarm %q for etcd member with name %s", alarm, pod.Name)
+			}
+			alarms = append(alarms, &pb.AlarmMember{
+				MemberID: memberID,
+				Alarm:    pb.AlarmType(alarmType),
+			})
+		}
+	}
+	return alarms, nil
+}
@@ -24,6 +24,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"google.golang.org/grpc/metadata"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -119,3 +120,68 @@ func Test_etcd_scalingflow(t *testing.T) {
 		g.Expect(members.GetMembers()).NotTo(ContainElement(fmt.Sprintf("etcd-%d", etcdMemberToRemove)))
 	})
 }
+
+func Test_etcd_faults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{":authority": "etcd-1"}))
+	manager := inmemoryruntime.NewManager(scheme)
+	resourceGroupResolver := func(string) (string, error) { return "group1", nil }
+	m := &maintenanceServer{
+		baseServer: &baseServer{
+			log:                   log.FromContext(ctx),
+			manager:               manager,
+			resourceGroupResolver: resourceGroupResolver,
+		},
+	}
+	m.manager.AddResourceGroup("group1")
+	inmemoryClient := m.manager.GetResourceGroup("group1").GetClient()
+
+	for i := 1; i <= 2; i++ {
+		etcdPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      fmt.Sprintf("etcd-%d", i),
+				Labels: map[string]string{
+					"component": "etcd",
+					"tier":      "control-plane",
+				},
+				Annotations: map[string]string{
+					cloudv1.EtcdMemberIDAnnotationName:  fmt.Sprintf("%d", i),
+					cloudv1.EtcdClusterIDAnnotationName: "15",
+				},
+			},
+		}
+		switch i {
+		case 1:
+			etcdPod.Annotations[cloudv1.EtcdLeaderFromAnnotationName] = time.Date(2020, 07, 03, 14, 25, 58, 651387237, time.UTC).Format(time.RFC3339)
+		case 2:
+			etcdPod.Annotations[cloudv1.EtcdAlarmsAnnotationName] = "NOSPACE,CORRUPT"
+			etcdPod.Annotations[cloudv1.EtcdNoLeaderAnnotationName] = ""
+		}
+		g.Expect(inmemoryClient.Create(ctx, etcdPod)).To(Succeed())
+	}
+
+	t.Run("alarms are reported for etcd members with the alarms annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resp, err := m.Alarm(ctx, &pb.AlarmRequest{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(resp.Alarms).To(ConsistOf(
+			&pb.AlarmMember{MemberID: 2, Alarm: pb.AlarmType_NOSPACE},
+			&pb.AlarmMember{MemberID: 2, Alarm: pb.AlarmType_CORRUPT},
+		))
+	})
+
+	t.Run("inspect fails for etcd members with the no-leader annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, _, err := m.inspectEtcd(ctx, inmemoryClient, "1")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = m.inspectEtcd(ctx, inmemoryClient, "2")
+		g.Expect(err).To(MatchError(rpctypes.ErrGRPCNoLeader))
+	})
+}
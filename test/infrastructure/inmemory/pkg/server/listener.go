@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/faults"
 	"sigs.k8s.io/cluster-api/util/certs"
 )
 
@@ -54,6 +55,8 @@ type WorkloadClusterListener struct {
 	etcdMembers             sets.Set[string]
 	etcdServingCertificates map[string]*tls.Certificate
 
+	apiServerFaults faults.APIServerFaults
+
 	listener net.Listener
 }
 
@@ -21,6 +21,7 @@ import (
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -32,12 +33,15 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/faults"
 	inmemoryruntime "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/runtime"
 	inmemoryapi "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/server/api"
 	inmemoryetcd "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/server/etcd"
@@ -202,12 +206,67 @@ func (m *WorkloadClustersMux) mixedHandler() http.Handler {
 			etcdHandler.ServeHTTP(w, r)
 			return
 		}
+		if m.injectAPIServerFaults(w, r) {
+			return
+		}
 		apiHandler.ServeHTTP(w, r)
 	})
 
 	return h2c.NewHandler(mixedHandler, &http2.Server{})
 }
 
+// injectAPIServerFaults injects the faults defined for the API server of the workload cluster a request targets to;
+// it returns true if the request failed and it should not be processed further.
+func (m *WorkloadClustersMux) injectAPIServerFaults(w http.ResponseWriter, r *http.Request) bool {
+	apiServerFaults := func() faults.APIServerFaults {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+
+		_, port, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			return faults.APIServerFaults{}
+		}
+		wcl, ok := m.workloadClusterListeners[m.workloadClusterNameByPort[port]]
+		if !ok {
+			return faults.APIServerFaults{}
+		}
+		return wcl.apiServerFaults
+	}()
+	if apiServerFaults.IsZero() {
+		return false
+	}
+
+	if latency := apiServerFaults.Latency.Sample(faults.GlobalRand); latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	if apiServerFaults.ErrorRate > 0 && faults.GlobalRand.Float64() < apiServerFaults.ErrorRate {
+		status := apierrors.NewInternalError(errors.New("fault injected by the in memory API server")).Status()
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		w.WriteHeader(int(status.Code))
+		_ = json.NewEncoder(w).Encode(status)
+		return true
+	}
+	return false
+}
+
+// SetAPIServerFaults sets the faults to be injected in the API server of a WorkloadClusterListener.
+func (m *WorkloadClustersMux) SetAPIServerFaults(wclName string, apiServerFaults faults.APIServerFaults) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	wcl, ok := m.workloadClusterListeners[wclName]
+	if !ok {
+		return errors.Errorf("workloadClusterListener with name %s must be initialized before setting API server faults", wclName)
+	}
+	wcl.apiServerFaults = apiServerFaults
+	return nil
+}
+
 // getCertificate selects certificates for a specific cluster depending on the request being processed
 // (API server and etcd have different certificates).
 func (m *WorkloadClustersMux) getCertificate(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
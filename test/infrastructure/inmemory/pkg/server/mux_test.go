@@ -34,15 +34,20 @@ import (
 	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cloudv1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/cloud/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/faults"
 	inmemoryruntime "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/runtime"
 	inmemoryproxy "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/pkg/server/proxy"
 	"sigs.k8s.io/cluster-api/util/certs"
@@ -256,6 +261,110 @@ func TestAPI_rbacv1_CRUD(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestAPI_corev1_ServerSideApply(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	wcmux, c := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 500,
+		MaxPort:   DefaultMinPort + 599,
+		DebugPort: DefaultDebugPort + 5,
+	})
+
+	// create with a JSON body (unstructured objects are always sent as JSON)
+
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetNamespace(metav1.NamespaceDefault)
+	cm.SetName("foo")
+	g.Expect(unstructured.SetNestedStringMap(cm.Object, map[string]string{"a": "1"}, "data")).To(Succeed())
+	g.Expect(c.Create(ctx, cm)).To(Succeed())
+
+	// list with field selector on metadata.name
+
+	cml := &corev1.ConfigMapList{}
+	g.Expect(c.List(ctx, cml, client.MatchingFields{"metadata.name": "foo"})).To(Succeed())
+	g.Expect(cml.Items).To(HaveLen(1))
+	g.Expect(cml.Items[0].Data).To(Equal(map[string]string{"a": "1"}))
+
+	// list with an unsupported field selector
+
+	g.Expect(c.List(ctx, cml, client.MatchingFields{"data.a": "1"})).ToNot(Succeed())
+
+	// apply creates the object
+
+	applied := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: metav1.NamespaceDefault},
+		Data:       map[string]string{"a": "1"},
+	}
+	g.Expect(c.Patch(ctx, applied, client.Apply, client.FieldOwner("manager-a"))).To(Succeed())
+	g.Expect(applied.GetManagedFields()).To(HaveLen(1))
+	g.Expect(applied.GetManagedFields()[0].Manager).To(Equal("manager-a"))
+	g.Expect(applied.GetManagedFields()[0].Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+
+	// apply from another manager conflicts, unless forced
+
+	applied = &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: metav1.NamespaceDefault},
+		Data:       map[string]string{"a": "2", "b": "2"},
+	}
+	err := c.Patch(ctx, applied, client.Apply, client.FieldOwner("manager-b"))
+	g.Expect(apierrors.IsConflict(err)).To(BeTrue())
+	g.Expect(c.Patch(ctx, applied, client.Apply, client.FieldOwner("manager-b"), client.ForceOwnership)).To(Succeed())
+
+	got := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(applied), got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string]string{"a": "2", "b": "2"}))
+
+	// json patch
+
+	g.Expect(c.Patch(ctx, got, client.RawPatch(types.JSONPatchType, []byte(`[{"op":"remove","path":"/data/b"}]`)))).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(applied), got)).To(Succeed())
+	g.Expect(got.Data).To(Equal(map[string]string{"a": "2"}))
+
+	err = wcmux.Shutdown(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestAPI_Faults(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	wcmux, c := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 600,
+		MaxPort:   DefaultMinPort + 699,
+		DebugPort: DefaultDebugPort + 6,
+	})
+	wcl1 := "workload-cluster1-controlPlaneEndpoint"
+
+	// requests fail if the error rate is 1
+
+	g.Expect(wcmux.SetAPIServerFaults(wcl1, faults.APIServerFaults{ErrorRate: 1})).To(Succeed())
+	err := c.List(ctx, &corev1.NodeList{})
+	g.Expect(apierrors.IsInternalError(err)).To(BeTrue())
+
+	// requests are delayed by the latency
+
+	latency, err := faults.ParseDelay("200ms")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(wcmux.SetAPIServerFaults(wcl1, faults.APIServerFaults{Latency: latency})).To(Succeed())
+	start := time.Now()
+	g.Expect(c.List(ctx, &corev1.NodeList{})).To(Succeed())
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+
+	// setting faults on an unknown listener fails
+
+	g.Expect(wcmux.SetAPIServerFaults("unknown", faults.APIServerFaults{})).ToNot(Succeed())
+
+	err = wcmux.Shutdown(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
 func TestAPI_PortForward(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -489,6 +598,71 @@ func TestAPI_corev1_Watch(t *testing.T) {
 	g.Expect(receivedEvents).To(Equal(expectedEvents))
 }
 
+func TestAPI_corev1_WatchWithSelectors(t *testing.T) {
+	g := NewWithT(t)
+
+	_, c := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 700,
+		MaxPort:   DefaultMinPort + 799,
+		DebugPort: DefaultDebugPort + 7,
+	})
+
+	ctx := context.Background()
+
+	labelWatcher, err := c.Watch(ctx, &corev1.PodList{}, client.InNamespace("one"), client.MatchingLabels{"app": "foo"})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer labelWatcher.Stop()
+
+	fieldWatcher, err := c.Watch(ctx, &corev1.PodList{}, client.MatchingFields{"metadata.name": "b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer fieldWatcher.Stop()
+
+	// nextEvent returns the next event of a watcher as <type>/<namespace>/<name>.
+	nextEvent := func(w watch.Interface) string {
+		select {
+		case event := <-w.ResultChan():
+			o, ok := event.Object.(client.Object)
+			if !ok {
+				return ""
+			}
+			return fmt.Sprintf("%s/%s/%s", event.Type, o.GetNamespace(), o.GetName())
+		case <-time.After(5 * time.Second):
+			return "timeout"
+		}
+	}
+
+	// Create pods; only pods in the namespace and with the label of the watch should be dispatched.
+	podA := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "one", Name: "a", Labels: map[string]string{"app": "foo"}}}
+	g.Expect(c.Create(ctx, podA)).To(Succeed())
+	podB := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "two", Name: "b", Labels: map[string]string{"app": "foo"}}}
+	g.Expect(c.Create(ctx, podB)).To(Succeed())
+	podC := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "one", Name: "c"}}
+	g.Expect(c.Create(ctx, podC)).To(Succeed())
+
+	g.Expect(nextEvent(labelWatcher)).To(Equal("ADDED/one/a"))
+	g.Expect(nextEvent(fieldWatcher)).To(Equal("ADDED/two/b"))
+
+	// Adding the label to a pod moves it into the selection of the watch.
+	podCWithLabel := podC.DeepCopy()
+	podCWithLabel.SetLabels(map[string]string{"app": "foo"})
+	g.Expect(c.Patch(ctx, podCWithLabel, client.MergeFrom(podC))).To(Succeed())
+	g.Expect(nextEvent(labelWatcher)).To(Equal("ADDED/one/c"))
+
+	// Removing the label from a pod moves it out of the selection of the watch.
+	podAWithoutLabel := podA.DeepCopy()
+	podAWithoutLabel.SetLabels(map[string]string{"app": "bar"})
+	g.Expect(c.Patch(ctx, podAWithoutLabel, client.MergeFrom(podA))).To(Succeed())
+	g.Expect(nextEvent(labelWatcher)).To(Equal("DELETED/one/a"))
+
+	// Deleting pods out of the selection of the watch is not dispatched.
+	g.Expect(c.Delete(ctx, podAWithoutLabel)).To(Succeed())
+	g.Expect(c.Delete(ctx, podB)).To(Succeed())
+	g.Expect(c.Delete(ctx, podCWithLabel)).To(Succeed())
+	g.Expect(nextEvent(labelWatcher)).To(Equal("DELETED/one/c"))
+	g.Expect(nextEvent(fieldWatcher)).To(Equal("DELETED/two/b"))
+}
+
 func setupWorkloadClusterListener(g Gomega, ports CustomPorts) (*WorkloadClustersMux, client.WithWatch) {
 	manager := inmemoryruntime.NewManager(scheme)
 
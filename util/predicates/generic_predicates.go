@@ -17,6 +17,8 @@ limitations under the License.
 package predicates
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
@@ -243,6 +245,47 @@ func processIfLabelMatch(scheme *runtime.Scheme, logger logr.Logger, obj client.
 	return false
 }
 
+// ShardOwner determines if objects are in a shard owned by the current replica, e.g. a sharding.Sharder.
+type ShardOwner interface {
+	OwnsObject(ctx context.Context, obj client.Object) bool
+}
+
+// ResourceIsInOwnedShard returns a predicate that returns true only if the resource is in a shard
+// owned by the current replica; if owner is nil, i.e. sharding is disabled, the predicate always returns true.
+func ResourceIsInOwnedShard(ctx context.Context, scheme *runtime.Scheme, logger logr.Logger, owner ShardOwner) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return processIfInOwnedShard(ctx, scheme, logger.WithValues("predicate", "ResourceIsInOwnedShard", "eventType", "update"), e.ObjectNew, owner)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return processIfInOwnedShard(ctx, scheme, logger.WithValues("predicate", "ResourceIsInOwnedShard", "eventType", "create"), e.Object, owner)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return processIfInOwnedShard(ctx, scheme, logger.WithValues("predicate", "ResourceIsInOwnedShard", "eventType", "delete"), e.Object, owner)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return processIfInOwnedShard(ctx, scheme, logger.WithValues("predicate", "ResourceIsInOwnedShard", "eventType", "generic"), e.Object, owner)
+		},
+	}
+}
+
+func processIfInOwnedShard(ctx context.Context, scheme *runtime.Scheme, logger logr.Logger, obj client.Object, owner ShardOwner) bool {
+	// Return early if sharding is disabled.
+	if owner == nil {
+		return true
+	}
+
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		logger = logger.WithValues(gvk.Kind, klog.KObj(obj))
+	}
+	if owner.OwnsObject(ctx, obj) {
+		logger.V(6).Info("Resource is in an owned shard, will attempt to map resource")
+		return true
+	}
+	logger.V(4).Info("Resource is not in an owned shard, will not attempt to map resource")
+	return false
+}
+
 // ResourceIsNotExternallyManaged returns a predicate that returns true only if the resource does not contain
 // the externally managed annotation.
 // This implements a requirement for InfraCluster providers to be able to ignore externally managed
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+type fakeShardOwner struct {
+	ownedClusters []string
+}
+
+func (o fakeShardOwner) OwnsObject(_ context.Context, obj client.Object) bool {
+	for _, name := range o.ownedClusters {
+		if obj.GetLabels()[clusterv1.ClusterNameLabel] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResourceIsInOwnedShard(t *testing.T) {
+	ownedMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+		Name:   "owned",
+		Labels: map[string]string{clusterv1.ClusterNameLabel: "cluster-a"},
+	}}
+	notOwnedMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+		Name:   "not-owned",
+		Labels: map[string]string{clusterv1.ClusterNameLabel: "cluster-b"},
+	}}
+
+	testcases := []struct {
+		name     string
+		owner    predicates.ShardOwner
+		obj      client.Object
+		expected bool
+	}{
+		{
+			name:     "sharding disabled: should return true",
+			owner:    nil,
+			obj:      notOwnedMachine,
+			expected: true,
+		},
+		{
+			name:     "object in owned shard: should return true",
+			owner:    fakeShardOwner{ownedClusters: []string{"cluster-a"}},
+			obj:      ownedMachine,
+			expected: true,
+		},
+		{
+			name:     "object not in owned shard: should return false",
+			owner:    fakeShardOwner{ownedClusters: []string{"cluster-a"}},
+			obj:      notOwnedMachine,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			predicate := predicates.ResourceIsInOwnedShard(context.Background(), runtime.NewScheme(), logr.New(log.NullLogSink{}), tc.owner)
+
+			g.Expect(predicate.Create(event.CreateEvent{Object: tc.obj})).To(Equal(tc.expected))
+			g.Expect(predicate.Update(event.UpdateEvent{ObjectOld: tc.obj, ObjectNew: tc.obj})).To(Equal(tc.expected))
+			g.Expect(predicate.Delete(event.DeleteEvent{Object: tc.obj})).To(Equal(tc.expected))
+			g.Expect(predicate.Generic(event.GenericEvent{Object: tc.obj})).To(Equal(tc.expected))
+		})
+	}
+}
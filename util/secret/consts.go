@@ -48,9 +48,15 @@ const (
 
 	// APIServerEtcdClient is the secret name of user-supplied secret containing the apiserver-etcd-client key/cert.
 	APIServerEtcdClient = Purpose("apiserver-etcd-client")
+
+	// EncryptionKeys is the secret name suffix storing the keys used to encrypt Secrets at rest in the workload cluster.
+	EncryptionKeys = Purpose("encryption-keys")
+
+	// ReverseTunnel is the secret name suffix storing the token used by the reverse tunnel agents of the Cluster.
+	ReverseTunnel = Purpose("tunnel")
 )
 
 var (
 	// allSecretPurposes defines a lists with all the secret suffix used by Cluster API.
-	allSecretPurposes = []Purpose{Kubeconfig, ClusterCA, EtcdCA, ServiceAccount, FrontProxyCA, APIServerEtcdClient}
+	allSecretPurposes = []Purpose{Kubeconfig, ClusterCA, EtcdCA, ServiceAccount, FrontProxyCA, APIServerEtcdClient, EncryptionKeys, ReverseTunnel}
 )
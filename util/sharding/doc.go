@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding implements sharding of controllers across multiple replicas, so that each replica
+// reconciles only a subset of the Clusters.
+//
+// # Shards
+//
+// Each Cluster is assigned to one of a fixed number of shards: the shard is set with the cluster.x-k8s.io/shard
+// label on the Cluster, or it is determined by hashing the namespace and the name of the Cluster.
+// All the objects belonging to a Cluster (i.e. objects with the cluster.x-k8s.io/cluster-name label) are in the
+// shard of the Cluster.
+//
+// # Ownership
+//
+// Each shard is owned by at most one replica at a time; ownership is tracked with a Lease per shard, which is
+// acquired and renewed by the owner using the same mechanism used for leader election.
+// Each replica also renews a member Lease, which is used to determine the number of live replicas and thus how
+// many shards each replica should own.
+//
+// When replicas join or leave, shards are rebalanced safely:
+//
+// - A replica that owns more shards than its share stops reconciling the extra shards, keeps renewing the
+// corresponding Leases for the drain period, so in-flight reconciles can complete, and then releases them.
+//
+// - A replica that owns fewer shards than its share acquires shards that are free or whose Lease expired, e.g.
+// because the previous owner crashed.
+//
+// - A replica stops reconciling a shard if it can't renew the corresponding Lease before the renew deadline,
+// which is shorter than the Lease duration.
+//
+// Whenever the ownership of a shard changes, events are sent for all the Clusters of the shard to the sources
+// returned by GetClusterSource, so controllers can start reconciling newly acquired Clusters.
+package sharding
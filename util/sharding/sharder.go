@@ -0,0 +1,669 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// groupLabel is the label used to identify the Leases of a Sharder; the value is Options.Name.
+	groupLabel = "sharding.cluster.x-k8s.io/group"
+
+	// memberLabel is the label used to identify the member Leases of a Sharder.
+	memberLabel = "sharding.cluster.x-k8s.io/member"
+)
+
+// Options are the options of a Sharder.
+type Options struct {
+	// Namespace is the namespace of the Leases used for sharding.
+	Namespace string
+
+	// Name is used as a prefix for the names of the Leases used for sharding.
+	// All the replicas sharing the same shards must use the same Name.
+	Name string
+
+	// Identity is the identity of this replica.
+	// Defaults to the hostname with a random suffix.
+	Identity string
+
+	// Shards is the number of shards.
+	// NOTE: Changing the number of shards changes the shard of most Clusters; all replicas must be
+	// restarted with the new number of shards at the same time.
+	Shards int
+
+	// LeaseDuration is the duration non-owner replicas wait before acquiring a shard that has not been renewed.
+	// Defaults to 15s.
+	LeaseDuration time.Duration
+
+	// RenewDeadline is the duration the owner of a shard keeps reconciling it without being able to renew it.
+	// It must be shorter than LeaseDuration.
+	// Defaults to 10s.
+	RenewDeadline time.Duration
+
+	// RetryPeriod is the interval at which Leases are renewed and shards are rebalanced.
+	// Defaults to 2s.
+	RetryPeriod time.Duration
+
+	// DrainPeriod is the duration a replica keeps a shard after it stopped reconciling it before releasing it,
+	// so in-flight reconciles can complete before another replica starts reconciling the shard.
+	// Defaults to 10s.
+	DrainPeriod time.Duration
+}
+
+// Sharder tracks the shards owned by this replica.
+// A nil Sharder owns all the shards, i.e. sharding is disabled.
+// Sharded controllers use the Sharder to reconcile only objects in shards owned by this replica:
+// they filter events with predicates.ResourceIsInOwnedShard, watch the source returned by GetClusterSource
+// and return early from Reconcile if OwnsObject returns false.
+type Sharder struct {
+	client    client.Client
+	apiReader client.Reader
+	options   Options
+	now       func() time.Time
+
+	// shardsLock is used to synchronize access to shards.
+	shardsLock sync.RWMutex
+	// shards are the shards held by this replica, i.e. the shards for which this replica holds the Lease.
+	shards map[int]*shardState
+
+	// sourcesLock is used to synchronize access to sources.
+	sourcesLock sync.Mutex
+	// sources are the channels of the sources returned by GetClusterSource.
+	sources []chan event.GenericEvent
+}
+
+// shardState is the state of a shard held by this replica.
+type shardState struct {
+	// renewTime is the last time the Lease of the shard has been renewed.
+	renewTime time.Time
+
+	// drainingSince is set when the replica stopped reconciling the shard before releasing it.
+	drainingSince *time.Time
+}
+
+// New creates a new Sharder.
+// The client is used to write Leases and to read Clusters, the apiReader is used to read Leases; it is recommended
+// to use a reader that does not cache Leases, e.g. the APIReader of the Manager.
+// NOTE: The Sharder must be added to the Manager, e.g. with mgr.Add.
+func New(c client.Client, apiReader client.Reader, options Options) (*Sharder, error) {
+	if options.Namespace == "" {
+		return nil, errors.New("options.Namespace must be set")
+	}
+	if options.Name == "" {
+		return nil, errors.New("options.Name must be set")
+	}
+	if options.Shards <= 0 {
+		return nil, errors.New("options.Shards must be greater than 0")
+	}
+	if options.Identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get hostname")
+		}
+		options.Identity = hostname + "_" + string(uuid.NewUUID())
+	}
+	if options.LeaseDuration == 0 {
+		options.LeaseDuration = 15 * time.Second
+	}
+	if options.RenewDeadline == 0 {
+		options.RenewDeadline = 10 * time.Second
+	}
+	if options.RetryPeriod == 0 {
+		options.RetryPeriod = 2 * time.Second
+	}
+	if options.DrainPeriod == 0 {
+		options.DrainPeriod = 10 * time.Second
+	}
+	if options.RenewDeadline >= options.LeaseDuration {
+		return nil, errors.New("options.RenewDeadline must be shorter than options.LeaseDuration")
+	}
+
+	return &Sharder{
+		client:    c,
+		apiReader: apiReader,
+		options:   options,
+		now:       time.Now,
+		shards:    map[int]*shardState{},
+	}, nil
+}
+
+// Identity returns the identity of this replica.
+func (s *Sharder) Identity() string {
+	if s == nil {
+		return ""
+	}
+	return s.options.Identity
+}
+
+// ShardForCluster returns the shard of a Cluster.
+func (s *Sharder) ShardForCluster(cluster *clusterv1.Cluster) int {
+	if s == nil {
+		return 0
+	}
+	return s.shardFor(client.ObjectKeyFromObject(cluster), cluster.Labels)
+}
+
+func (s *Sharder) shardFor(cluster client.ObjectKey, labels map[string]string) int {
+	if value, ok := labels[clusterv1.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 && shard < s.options.Shards {
+			return shard
+		}
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cluster.String()))
+	return int(h.Sum32() % uint32(s.options.Shards)) //nolint:gosec // Shards is validated to be greater than 0.
+}
+
+// OwnsShard returns true if this replica is currently reconciling the given shard.
+func (s *Sharder) OwnsShard(shard int) bool {
+	if s == nil {
+		return true
+	}
+
+	s.shardsLock.RLock()
+	defer s.shardsLock.RUnlock()
+
+	state, ok := s.shards[shard]
+	if !ok || state.drainingSince != nil {
+		return false
+	}
+	// Stop reconciling the shard if the Lease could not be renewed before the renew deadline.
+	return s.now().Before(state.renewTime.Add(s.options.RenewDeadline))
+}
+
+// OwnsCluster returns true if this replica is currently reconciling the given Cluster.
+func (s *Sharder) OwnsCluster(cluster *clusterv1.Cluster) bool {
+	if s == nil {
+		return true
+	}
+	return s.OwnsShard(s.ShardForCluster(cluster))
+}
+
+// OwnsObject returns true if this replica is currently reconciling the given object.
+// Clusters are in their own shard, all the other objects are in the shard of the Cluster set in the
+// cluster.x-k8s.io/cluster-name label; objects without the label are owned by all the replicas.
+// NOTE: Controllers are already filtering events with predicates.ResourceIsInOwnedShard, but they should
+// call OwnsObject at the beginning of Reconcile as a safeguard for requests that were queued before the
+// shard has been released.
+func (s *Sharder) OwnsObject(ctx context.Context, obj client.Object) bool {
+	if s == nil {
+		return true
+	}
+
+	if cluster, ok := obj.(*clusterv1.Cluster); ok {
+		return s.OwnsCluster(cluster)
+	}
+
+	clusterName, ok := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if !ok || clusterName == "" {
+		return true
+	}
+
+	clusterKey := client.ObjectKey{Namespace: obj.GetNamespace(), Name: clusterName}
+	cluster := &clusterv1.Cluster{}
+	if err := s.client.Get(ctx, clusterKey, cluster); err != nil {
+		// If the Cluster can't be read (e.g. because it has been deleted) fall back to the shard determined
+		// by the name of the Cluster.
+		return s.OwnsShard(s.shardFor(clusterKey, nil))
+	}
+	return s.OwnsShard(s.shardFor(clusterKey, cluster.Labels))
+}
+
+// GetClusterSource returns a source that sends events for all the Clusters of a shard
+// whenever the ownership of the shard changes. The mapFunc is called with the Cluster.
+// If the Sharder is nil, the source never sends events.
+func (s *Sharder) GetClusterSource(mapFunc handler.MapFunc) source.Source {
+	if s == nil {
+		return source.Func(func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			return nil
+		})
+	}
+
+	s.sourcesLock.Lock()
+	defer s.sourcesLock.Unlock()
+
+	ch := make(chan event.GenericEvent)
+	s.sources = append(s.sources, ch)
+	return source.Channel(ch, handler.EnqueueRequestsFromMapFunc(mapFunc))
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; all the replicas
+// must participate in sharding.
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the Sharder until the context is done; at shutdown, all the held shards are released.
+func (s *Sharder) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("controller", "sharding", "identity", s.options.Identity)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	log.Info("Starting sharding", "shards", s.options.Shards)
+
+	ticker := time.NewTicker(s.options.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		if err := s.sync(ctx); err != nil {
+			log.Error(err, "Failed to sync shards")
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping sharding, releasing all shards")
+			// Use a new context as ctx is already done.
+			releaseCtx, cancel := context.WithTimeout(context.Background(), s.options.RenewDeadline)
+			defer cancel()
+			s.releaseAll(ctrl.LoggerInto(releaseCtx, log))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync renews the Leases held by this replica and rebalances shards.
+func (s *Sharder) sync(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	now := s.now()
+
+	if err := s.renewMember(ctx, now); err != nil {
+		return err
+	}
+
+	leaseList := &coordinationv1.LeaseList{}
+	if err := s.apiReader.List(ctx, leaseList, client.InNamespace(s.options.Namespace), client.MatchingLabels{groupLabel: s.options.Name}); err != nil {
+		return errors.Wrap(err, "failed to list Leases")
+	}
+
+	members := map[string]int{s.options.Identity: 0}
+	shardLeases := map[string]*coordinationv1.Lease{}
+	for i := range leaseList.Items {
+		lease := &leaseList.Items[i]
+		if _, ok := lease.Labels[memberLabel]; ok {
+			if !isExpired(lease, now) && lease.Spec.HolderIdentity != nil {
+				members[*lease.Spec.HolderIdentity] = 0
+			}
+			continue
+		}
+		shardLeases[lease.Name] = lease
+	}
+	for _, lease := range shardLeases {
+		if lease.Spec.HolderIdentity == nil || isExpired(lease, now) {
+			continue
+		}
+		if _, ok := members[*lease.Spec.HolderIdentity]; ok {
+			members[*lease.Spec.HolderIdentity]++
+		}
+	}
+
+	// Renew, drain and release the shards held by this replica.
+	changed := sets.Set[int]{}
+	owned := []int{}
+	for _, shard := range s.heldShards() {
+		state := s.getShardState(shard)
+		lease := shardLeases[s.shardLeaseName(shard)]
+
+		if lease == nil || ptr.Deref(lease.Spec.HolderIdentity, "") != s.options.Identity {
+			log.Info("Lost shard, Lease is held by another replica", "shard", shard)
+			s.deleteShardState(shard)
+			changed.Insert(shard)
+			continue
+		}
+
+		if state.drainingSince != nil && now.Sub(*state.drainingSince) >= s.options.DrainPeriod {
+			if err := s.release(ctx, lease); err != nil {
+				log.Error(err, "Failed to release shard", "shard", shard)
+			} else {
+				log.Info("Released shard", "shard", shard)
+			}
+			// Drop the shard in any case; if the Lease was not released it will expire.
+			s.deleteShardState(shard)
+			continue
+		}
+
+		if err := s.renew(ctx, lease, now); err != nil {
+			log.Error(err, "Failed to renew shard", "shard", shard)
+			if now.Sub(state.renewTime) >= s.options.LeaseDuration {
+				// The Lease expired, other replicas might have acquired the shard already.
+				s.deleteShardState(shard)
+				changed.Insert(shard)
+			}
+			continue
+		}
+		s.setShardRenewTime(shard, now)
+
+		if state.drainingSince == nil {
+			owned = append(owned, shard)
+		}
+	}
+
+	// Compute how many shards this replica should own.
+	// All replicas own at least minShards shards and at most maxShards shards; replicas are allowed to own
+	// more than minShards shards only if all the other replicas own at least minShards shards.
+	minShards := s.options.Shards / len(members)
+	maxShards := (s.options.Shards + len(members) - 1) / len(members)
+	limit := maxShards
+	for identity, count := range members {
+		if identity != s.options.Identity && count < minShards {
+			limit = minShards
+			break
+		}
+	}
+
+	switch {
+	case len(owned) > limit:
+		// Drain the extra shards, they will be released after the drain period.
+		sort.Ints(owned)
+		for _, shard := range owned[limit:] {
+			log.Info("Draining shard", "shard", shard, "ownedShards", len(owned), "maxShards", limit)
+			s.setShardDraining(shard, now)
+			changed.Insert(shard)
+		}
+	case len(owned) < limit:
+		for _, shard := range s.candidateShards() {
+			if len(owned) >= limit {
+				break
+			}
+			if s.getShardState(shard) != nil {
+				continue
+			}
+
+			acquired, err := s.acquire(ctx, shard, shardLeases[s.shardLeaseName(shard)], now)
+			if err != nil {
+				log.Error(err, "Failed to acquire shard", "shard", shard)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+			log.Info("Acquired shard", "shard", shard)
+			owned = append(owned, shard)
+			changed.Insert(shard)
+		}
+	}
+
+	if len(changed) > 0 {
+		s.sendEvents(ctx, changed)
+	}
+	return nil
+}
+
+// renewMember creates or renews the member Lease of this replica.
+func (s *Sharder) renewMember(ctx context.Context, now time.Time) error {
+	lease := &coordinationv1.Lease{}
+	key := client.ObjectKey{Namespace: s.options.Namespace, Name: s.memberLeaseName()}
+	if err := s.apiReader.Get(ctx, key, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "failed to get member Lease")
+		}
+
+		lease = s.newLease(key.Name, now)
+		lease.Labels[memberLabel] = ""
+		if err := s.client.Create(ctx, lease); err != nil {
+			return errors.Wrap(err, "failed to create member Lease")
+		}
+		return nil
+	}
+
+	if err := s.renew(ctx, lease, now); err != nil {
+		return errors.Wrap(err, "failed to renew member Lease")
+	}
+	return nil
+}
+
+// acquire acquires the Lease of a shard if it is free or expired.
+func (s *Sharder) acquire(ctx context.Context, shard int, lease *coordinationv1.Lease, now time.Time) (bool, error) {
+	if lease == nil {
+		lease = s.newLease(s.shardLeaseName(shard), now)
+		if err := s.client.Create(ctx, lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica created the Lease in the meantime.
+				return false, nil
+			}
+			return false, errors.Wrap(err, "failed to create Lease")
+		}
+	} else {
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != "" && !isExpired(lease, now) {
+			return false, nil
+		}
+
+		lease = lease.DeepCopy()
+		lease.Spec.HolderIdentity = ptr.To(s.options.Identity)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(s.options.LeaseDuration.Seconds()))
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+		// Update uses the resourceVersion of the listed Lease, so the update fails if
+		// another replica acquired the Lease in the meantime.
+		if err := s.client.Update(ctx, lease); err != nil {
+			if apierrors.IsConflict(err) {
+				return false, nil
+			}
+			return false, errors.Wrap(err, "failed to update Lease")
+		}
+	}
+
+	s.shardsLock.Lock()
+	defer s.shardsLock.Unlock()
+	s.shards[shard] = &shardState{renewTime: now}
+	return true, nil
+}
+
+// renew renews a Lease held by this replica.
+func (s *Sharder) renew(ctx context.Context, lease *coordinationv1.Lease, now time.Time) error {
+	lease = lease.DeepCopy()
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	return s.client.Update(ctx, lease)
+}
+
+// release releases a Lease held by this replica, so it can be acquired immediately by other replicas.
+func (s *Sharder) release(ctx context.Context, lease *coordinationv1.Lease) error {
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+	return s.client.Update(ctx, lease)
+}
+
+// releaseAll releases all the shards held by this replica and deletes its member Lease.
+func (s *Sharder) releaseAll(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, shard := range s.heldShards() {
+		// Stop reconciling the shard before releasing it.
+		s.deleteShardState(shard)
+
+		lease := &coordinationv1.Lease{}
+		if err := s.apiReader.Get(ctx, client.ObjectKey{Namespace: s.options.Namespace, Name: s.shardLeaseName(shard)}, lease); err != nil {
+			log.Error(err, "Failed to release shard", "shard", shard)
+			continue
+		}
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != s.options.Identity {
+			continue
+		}
+		if err := s.release(ctx, lease); err != nil {
+			log.Error(err, "Failed to release shard", "shard", shard)
+		}
+	}
+
+	member := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: s.options.Namespace, Name: s.memberLeaseName()}}
+	if err := s.client.Delete(ctx, member); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Failed to delete member Lease")
+	}
+}
+
+// sendEvents sends events for all the Clusters of the given shards to all the sources.
+func (s *Sharder) sendEvents(ctx context.Context, shards sets.Set[int]) {
+	log := ctrl.LoggerFrom(ctx)
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := s.client.List(ctx, clusterList); err != nil {
+		log.Error(err, "Failed to list Clusters to send events for changed shards")
+		return
+	}
+
+	events := []event.GenericEvent{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if shards.Has(s.ShardForCluster(cluster)) {
+			events = append(events, event.GenericEvent{Object: cluster})
+		}
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	s.sourcesLock.Lock()
+	sources := append([]chan event.GenericEvent{}, s.sources...)
+	s.sourcesLock.Unlock()
+
+	// Send events asynchronously as sources only receive events after the corresponding controllers are started.
+	for _, ch := range sources {
+		go func(ch chan event.GenericEvent) {
+			for _, e := range events {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch)
+	}
+}
+
+// candidateShards returns all the shards, starting from an offset depending on the identity of this replica,
+// so that replicas starting at the same time try to acquire different shards.
+func (s *Sharder) candidateShards() []int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s.options.Identity))
+	offset := int(h.Sum32() % uint32(s.options.Shards)) //nolint:gosec // Shards is validated to be greater than 0.
+
+	shards := make([]int, 0, s.options.Shards)
+	for i := range s.options.Shards {
+		shards = append(shards, (offset+i)%s.options.Shards)
+	}
+	return shards
+}
+
+func (s *Sharder) newLease(name string, now time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.options.Namespace,
+			Name:      name,
+			Labels:    map[string]string{groupLabel: s.options.Name},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(s.options.Identity),
+			LeaseDurationSeconds: ptr.To(int32(s.options.LeaseDuration.Seconds())),
+			AcquireTime:          &metav1.MicroTime{Time: now},
+			RenewTime:            &metav1.MicroTime{Time: now},
+		},
+	}
+}
+
+func (s *Sharder) shardLeaseName(shard int) string {
+	return fmt.Sprintf("%s-%d", s.options.Name, shard)
+}
+
+func (s *Sharder) memberLeaseName() string {
+	// Use a hash of the identity, as the identity might not be a valid name.
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s.options.Identity))
+	return fmt.Sprintf("%s-member-%08x", s.options.Name, h.Sum32())
+}
+
+func (s *Sharder) heldShards() []int {
+	s.shardsLock.RLock()
+	defer s.shardsLock.RUnlock()
+
+	shards := make([]int, 0, len(s.shards))
+	for shard := range s.shards {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+func (s *Sharder) getShardState(shard int) *shardState {
+	s.shardsLock.RLock()
+	defer s.shardsLock.RUnlock()
+
+	state, ok := s.shards[shard]
+	if !ok {
+		return nil
+	}
+	stateCopy := *state
+	return &stateCopy
+}
+
+func (s *Sharder) setShardRenewTime(shard int, now time.Time) {
+	s.shardsLock.Lock()
+	defer s.shardsLock.Unlock()
+
+	if state, ok := s.shards[shard]; ok {
+		state.renewTime = now
+	}
+}
+
+func (s *Sharder) setShardDraining(shard int, now time.Time) {
+	s.shardsLock.Lock()
+	defer s.shardsLock.Unlock()
+
+	if state, ok := s.shards[shard]; ok {
+		state.drainingSince = ptr.To(now)
+	}
+}
+
+func (s *Sharder) deleteShardState(shard int) {
+	s.shardsLock.Lock()
+	defer s.shardsLock.Unlock()
+
+	delete(s.shards, shard)
+}
+
+// isExpired returns true if a Lease has not been renewed within its duration.
+func isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return !now.Before(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+var (
+	ctx = ctrl.SetupSignalHandler()
+)
+
+func TestShardForCluster(t *testing.T) {
+	s, err := New(nil, nil, Options{Namespace: "capi-system", Name: "capi-shard", Identity: "a", Shards: 4})
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		want    int
+	}{
+		{
+			name:    "shard label is used",
+			cluster: clusterWithLabels("foo", map[string]string{clusterv1.ShardLabel: "3"}),
+			want:    3,
+		},
+		{
+			name:    "shard label out of range falls back to hash",
+			cluster: clusterWithLabels("foo", map[string]string{clusterv1.ShardLabel: "4"}),
+			want:    s.shardFor(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}, nil),
+		},
+		{
+			name:    "invalid shard label falls back to hash",
+			cluster: clusterWithLabels("foo", map[string]string{clusterv1.ShardLabel: "not-a-number"}),
+			want:    s.shardFor(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(s.ShardForCluster(tt.cluster)).To(Equal(tt.want))
+		})
+	}
+
+	t.Run("hash is stable and distributes Clusters across shards", func(t *testing.T) {
+		g := NewWithT(t)
+
+		shards := map[int]int{}
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+			shard := s.ShardForCluster(clusterWithLabels(name, nil))
+			g.Expect(s.ShardForCluster(clusterWithLabels(name, nil))).To(Equal(shard))
+			g.Expect(shard).To(BeNumerically(">=", 0))
+			g.Expect(shard).To(BeNumerically("<", 4))
+			shards[shard]++
+		}
+		g.Expect(len(shards)).To(BeNumerically(">", 1))
+	})
+
+	t.Run("nil Sharder owns everything", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var nilSharder *Sharder
+		g.Expect(nilSharder.OwnsShard(1)).To(BeTrue())
+		g.Expect(nilSharder.OwnsObject(ctx, &clusterv1.Machine{})).To(BeTrue())
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "valid options",
+			options: Options{Namespace: "capi-system", Name: "capi-shard", Shards: 2},
+		},
+		{
+			name:    "fails without namespace",
+			options: Options{Name: "capi-shard", Shards: 2},
+			wantErr: true,
+		},
+		{
+			name:    "fails without shards",
+			options: Options{Namespace: "capi-system", Name: "capi-shard"},
+			wantErr: true,
+		},
+		{
+			name:    "fails if renew deadline is not shorter than lease duration",
+			options: Options{Namespace: "capi-system", Name: "capi-shard", Shards: 2, LeaseDuration: 10 * time.Second, RenewDeadline: 10 * time.Second},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s, err := New(nil, nil, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(s.Identity()).ToNot(BeEmpty())
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(coordinationv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := clusterWithLabels("foo", map[string]string{clusterv1.ShardLabel: "3"})
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "foo-machine",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newSharder := func(identity string) *Sharder {
+		s, err := New(c, c, Options{Namespace: "capi-system", Name: "capi-shard", Identity: identity, Shards: 4})
+		g.Expect(err).ToNot(HaveOccurred())
+		s.now = func() time.Time { return now }
+		return s
+	}
+	ownedShards := func(s *Sharder) []int {
+		shards := []int{}
+		for shard := range 4 {
+			if s.OwnsShard(shard) {
+				shards = append(shards, shard)
+			}
+		}
+		return shards
+	}
+	// expectNoOverlap verifies that no shard is reconciled by more than one replica.
+	expectNoOverlap := func(sharders ...*Sharder) {
+		for shard := range 4 {
+			owners := 0
+			for _, s := range sharders {
+				if s.OwnsShard(shard) {
+					owners++
+				}
+			}
+			g.Expect(owners).To(BeNumerically("<=", 1), "shard %d is owned by more than one replica", shard)
+		}
+	}
+
+	// A single replica acquires all the shards.
+	a := newSharder("a")
+	a.GetClusterSource(func(_ context.Context, _ client.Object) []reconcile.Request { return nil })
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(a)).To(ConsistOf(0, 1, 2, 3))
+	g.Expect(a.OwnsObject(ctx, cluster)).To(BeTrue())
+	g.Expect(a.OwnsObject(ctx, machine)).To(BeTrue())
+	g.Eventually(a.sources[0]).Should(Receive(WithTransform(func(e event.GenericEvent) string { return e.Object.GetName() }, Equal(cluster.Name))))
+
+	// A second replica joins; it can't acquire shards until the first replica drains and releases them.
+	b := newSharder("b")
+	g.Expect(b.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(b)).To(BeEmpty())
+
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(a)).To(ConsistOf(0, 1))
+	expectNoOverlap(a, b)
+
+	// Shards are not released before the drain period.
+	now = now.Add(2 * time.Second)
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(b.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(b)).To(BeEmpty())
+	expectNoOverlap(a, b)
+
+	now = now.Add(10 * time.Second)
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(b.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(a)).To(ConsistOf(0, 1))
+	g.Expect(ownedShards(b)).To(ConsistOf(2, 3))
+	g.Expect(a.OwnsObject(ctx, cluster)).To(BeFalse())
+	g.Expect(a.OwnsObject(ctx, machine)).To(BeFalse())
+	g.Expect(b.OwnsObject(ctx, machine)).To(BeTrue())
+	expectNoOverlap(a, b)
+
+	// The ownership is stable.
+	now = now.Add(2 * time.Second)
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(b.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(a)).To(ConsistOf(0, 1))
+	g.Expect(ownedShards(b)).To(ConsistOf(2, 3))
+
+	// The second replica shuts down and releases its shards, which are acquired by the first replica.
+	b.releaseAll(ctx)
+	g.Expect(ownedShards(b)).To(BeEmpty())
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(a)).To(ConsistOf(0, 1, 2, 3))
+
+	// The first replica stops renewing its shards, e.g. because it can't reach the API server;
+	// it stops reconciling after the renew deadline, and a new replica acquires the shards after the Lease duration.
+	now = now.Add(10 * time.Second)
+	g.Expect(ownedShards(a)).To(BeEmpty())
+
+	d := newSharder("d")
+	g.Expect(d.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(d)).To(BeEmpty())
+
+	now = now.Add(5 * time.Second)
+	g.Expect(d.sync(ctx)).To(Succeed())
+	g.Expect(ownedShards(d)).To(ConsistOf(0, 1, 2, 3))
+	expectNoOverlap(a, d)
+
+	// When the first replica is able to sync again, it detects that its shards have been lost.
+	g.Expect(a.sync(ctx)).To(Succeed())
+	g.Expect(a.heldShards()).To(BeEmpty())
+}
+
+func clusterWithLabels(name string, labels map[string]string) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+}
@@ -358,6 +358,7 @@ type ClusterClassBuilder struct {
 	variables                                 []clusterv1.ClusterClassVariable
 	statusVariables                           []clusterv1.ClusterClassStatusVariable
 	patches                                   []clusterv1.ClusterClassPatch
+	addons                                    []clusterv1.AddonClass
 	conditions                                []metav1.Condition
 }
 
@@ -462,6 +463,12 @@ func (c *ClusterClassBuilder) WithPatches(patches []clusterv1.ClusterClassPatch)
 	return c
 }
 
+// WithAddons adds the AddonClasses to the ClusterClassBuilder.
+func (c *ClusterClassBuilder) WithAddons(addons ...clusterv1.AddonClass) *ClusterClassBuilder {
+	c.addons = append(c.addons, addons...)
+	return c
+}
+
 // WithWorkerMachineDeploymentClasses adds the variables and objects needed to create MachineDeploymentTemplates for a ClusterClassBuilder.
 func (c *ClusterClassBuilder) WithWorkerMachineDeploymentClasses(mdcs ...clusterv1.MachineDeploymentClass) *ClusterClassBuilder {
 	if c.machineDeploymentClasses == nil {
@@ -494,6 +501,7 @@ func (c *ClusterClassBuilder) Build() *clusterv1.ClusterClass {
 		Spec: clusterv1.ClusterClassSpec{
 			Variables: c.variables,
 			Patches:   c.patches,
+			Addons:    c.addons,
 		},
 		Status: clusterv1.ClusterClassStatus{
 			Variables: c.statusVariables,
@@ -549,6 +557,7 @@ type MachineDeploymentClassBuilder struct {
 	labels                        map[string]string
 	annotations                   map[string]string
 	machineHealthCheckClass       clusterv1.MachineDeploymentClassHealthCheck
+	machineDrainRules             []clusterv1.MachineDrainRuleClass
 	readinessGates                []clusterv1.MachineReadinessGate
 	failureDomain                 *string
 	nodeDrainTimeout              *int32
@@ -597,6 +606,12 @@ func (m *MachineDeploymentClassBuilder) WithMachineHealthCheckClass(mhc clusterv
 	return m
 }
 
+// WithMachineDrainRules sets the MachineDrainRules for the MachineDeploymentClassBuilder.
+func (m *MachineDeploymentClassBuilder) WithMachineDrainRules(machineDrainRules ...clusterv1.MachineDrainRuleClass) *MachineDeploymentClassBuilder {
+	m.machineDrainRules = machineDrainRules
+	return m
+}
+
 // WithReadinessGates sets the readinessGates for the MachineDeploymentClassBuilder.
 func (m *MachineDeploymentClassBuilder) WithReadinessGates(readinessGates []clusterv1.MachineReadinessGate) *MachineDeploymentClassBuilder {
 	m.readinessGates = readinessGates
@@ -667,6 +682,9 @@ func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClas
 		obj.Infrastructure.TemplateRef = objToClusterClassTemplateRef(m.infrastructureMachineTemplate)
 	}
 	obj.HealthCheck = m.machineHealthCheckClass
+	if m.machineDrainRules != nil {
+		obj.MachineDrainRules = m.machineDrainRules
+	}
 	if m.readinessGates != nil {
 		obj.ReadinessGates = m.readinessGates
 	}
@@ -700,6 +718,8 @@ type MachinePoolClassBuilder struct {
 	bootstrapTemplate                 *unstructured.Unstructured
 	labels                            map[string]string
 	annotations                       map[string]string
+	machineHealthCheckClass           clusterv1.MachinePoolClassHealthCheck
+	machineDrainRules                 []clusterv1.MachineDrainRuleClass
 	failureDomains                    []string
 	nodeDrainTimeout                  *int32
 	nodeVolumeDetachTimeout           *int32
@@ -739,6 +759,18 @@ func (m *MachinePoolClassBuilder) WithAnnotations(annotations map[string]string)
 	return m
 }
 
+// WithMachineHealthCheckClass sets the MachineHealthCheckClass for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithMachineHealthCheckClass(mhc clusterv1.MachinePoolClassHealthCheck) *MachinePoolClassBuilder {
+	m.machineHealthCheckClass = mhc
+	return m
+}
+
+// WithMachineDrainRules sets the MachineDrainRules for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithMachineDrainRules(machineDrainRules ...clusterv1.MachineDrainRuleClass) *MachinePoolClassBuilder {
+	m.machineDrainRules = machineDrainRules
+	return m
+}
+
 // WithFailureDomains sets the FailureDomains for the MachinePoolClassBuilder.
 func (m *MachinePoolClassBuilder) WithFailureDomains(failureDomains ...string) *MachinePoolClassBuilder {
 	m.failureDomains = failureDomains
@@ -790,6 +822,10 @@ func (m *MachinePoolClassBuilder) Build() *clusterv1.MachinePoolClass {
 	if m.infrastructureMachinePoolTemplate != nil {
 		obj.Infrastructure.TemplateRef = objToClusterClassTemplateRef(m.infrastructureMachinePoolTemplate)
 	}
+	obj.HealthCheck = m.machineHealthCheckClass
+	if m.machineDrainRules != nil {
+		obj.MachineDrainRules = m.machineDrainRules
+	}
 	if m.failureDomains != nil {
 		obj.FailureDomains = m.failureDomains
 	}
@@ -194,6 +194,11 @@ func (in *ClusterClassBuilder) DeepCopyInto(out *ClusterClassBuilder) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.addons != nil {
+		in, out := &in.addons, &out.addons
+		*out = make([]v1beta2.AddonClass, len(*in))
+		copy(*out, *in)
+	}
 	if in.conditions != nil {
 		in, out := &in.conditions, &out.conditions
 		*out = make([]v1.Condition, len(*in))
@@ -524,6 +529,13 @@ func (in *MachineDeploymentClassBuilder) DeepCopyInto(out *MachineDeploymentClas
 		}
 	}
 	in.machineHealthCheckClass.DeepCopyInto(&out.machineHealthCheckClass)
+	if in.machineDrainRules != nil {
+		in, out := &in.machineDrainRules, &out.machineDrainRules
+		*out = make([]v1beta2.MachineDrainRuleClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.readinessGates != nil {
 		in, out := &in.readinessGates, &out.readinessGates
 		*out = make([]v1beta2.MachineReadinessGate, len(*in))
@@ -724,6 +736,14 @@ func (in *MachinePoolClassBuilder) DeepCopyInto(out *MachinePoolClassBuilder) {
 			(*out)[key] = val
 		}
 	}
+	in.machineHealthCheckClass.DeepCopyInto(&out.machineHealthCheckClass)
+	if in.machineDrainRules != nil {
+		in, out := &in.machineDrainRules, &out.machineDrainRules
+		*out = make([]v1beta2.MachineDrainRuleClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.failureDomains != nil {
 		in, out := &in.failureDomains, &out.failureDomains
 		*out = make([]string, len(*in))
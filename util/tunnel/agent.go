@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultDialTimeout   = 10 * time.Second
+	defaultRetryInterval = 5 * time.Second
+)
+
+// AgentOptions are the options of an Agent.
+type AgentOptions struct {
+	// GatewayAddress is the address (host:port) of the agent endpoint of the Gateway.
+	GatewayAddress string
+
+	// TLSConfig is the TLS config used to connect to the Gateway.
+	// If nil, the connection to the Gateway is not encrypted; this should be used only for testing.
+	TLSConfig *tls.Config
+
+	// Cluster is the Cluster the Agent is running in.
+	Cluster client.ObjectKey
+
+	// Token is the token used to authenticate with the Gateway.
+	// It must match the token stored in the <cluster-name>-tunnel Secret.
+	Token string
+
+	// TargetAddress is the address (host:port) of the API server of the workload cluster.
+	// All the connections forwarded by the Gateway are forwarded to this address.
+	TargetAddress string
+
+	// DialTimeout is the timeout used when connecting to the Gateway and to the target.
+	// Defaults to 10s.
+	DialTimeout time.Duration
+
+	// RetryInterval is the interval after which the Agent connects again to the Gateway after the connection failed
+	// or has been closed.
+	// Defaults to 5s.
+	RetryInterval time.Duration
+}
+
+// Agent opens a connection to the Gateway, and forwards the streams opened by the Gateway to the API server
+// of the workload cluster.
+type Agent struct {
+	options AgentOptions
+}
+
+// NewAgent creates a new Agent.
+func NewAgent(options AgentOptions) (*Agent, error) {
+	if options.GatewayAddress == "" {
+		return nil, errors.New("options.GatewayAddress must be set")
+	}
+	if options.Cluster.Namespace == "" || options.Cluster.Name == "" {
+		return nil, errors.New("options.Cluster must be set")
+	}
+	if options.Token == "" {
+		return nil, errors.New("options.Token must be set")
+	}
+	if options.TargetAddress == "" {
+		return nil, errors.New("options.TargetAddress must be set")
+	}
+	if options.DialTimeout == 0 {
+		options.DialTimeout = defaultDialTimeout
+	}
+	if options.RetryInterval == 0 {
+		options.RetryInterval = defaultRetryInterval
+	}
+	return &Agent{options: options}, nil
+}
+
+// Run connects to the Gateway and reconnects every time the connection fails or is closed, until ctx is done.
+func (a *Agent) Run(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := a.Connect(ctx); err != nil {
+			log.Error(err, "Connection to the Gateway failed", "retryInterval", a.options.RetryInterval)
+		}
+	}, a.options.RetryInterval)
+}
+
+// Connect connects to the Gateway and serves the streams opened by the Gateway until the connection
+// is closed or ctx is done.
+func (a *Agent) Connect(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	dialCtx, cancel := context.WithTimeout(ctx, a.options.DialTimeout)
+	defer cancel()
+
+	conn, err := a.dialGateway(dialCtx)
+	if err != nil {
+		return err
+	}
+
+	upgradedConn, err := upgradeClientConn(dialCtx, conn, clusterPath(agentPathPrefix, a.options.Cluster), a.options.Token)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	session, err := spdy.NewServerConnectionWithPings(upgradedConn, a.handleNewStream(ctx), pingPeriod)
+	if err != nil {
+		_ = upgradedConn.Close()
+		return errors.Wrap(err, "failed to create SPDY connection")
+	}
+	log.Info("Connected to the Gateway", "gatewayAddress", a.options.GatewayAddress)
+
+	select {
+	case <-session.CloseChan():
+		return errors.New("connection closed by the Gateway")
+	case <-ctx.Done():
+		return session.Close()
+	}
+}
+
+func (a *Agent) dialGateway(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if a.options.TLSConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: a.options.TLSConfig}
+		conn, err := tlsDialer.DialContext(ctx, "tcp", a.options.GatewayAddress)
+		return conn, errors.Wrapf(err, "failed to connect to the Gateway at %s", a.options.GatewayAddress)
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", a.options.GatewayAddress)
+	return conn, errors.Wrapf(err, "failed to connect to the Gateway at %s", a.options.GatewayAddress)
+}
+
+// handleNewStream returns the handler for the streams opened by the Gateway.
+func (a *Agent) handleNewStream(ctx context.Context) httpstream.NewStreamHandler {
+	log := ctrl.LoggerFrom(ctx)
+
+	return func(stream httpstream.Stream, replySent <-chan struct{}) error {
+		go func() {
+			<-replySent
+
+			dialer := &net.Dialer{Timeout: a.options.DialTimeout}
+			target, err := dialer.DialContext(ctx, "tcp", a.options.TargetAddress)
+			if err != nil {
+				log.Error(err, "Failed to connect to the target", "targetAddress", a.options.TargetAddress)
+				_ = stream.Reset()
+				return
+			}
+			pipe(target, stream)
+		}()
+		return nil
+	}
+}
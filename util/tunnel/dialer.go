@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// DialContextFunc is a func used to dial a connection.
+type DialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Dialer connects to the API server of workload clusters through the proxy endpoint of the Gateway.
+type Dialer struct {
+	// client is used to read Clusters.
+	client client.Reader
+
+	// gatewayAddress is the address (host:port) of the proxy endpoint of the Gateway.
+	gatewayAddress string
+
+	// proxyToken is the token used to authenticate with the proxy endpoint of the Gateway.
+	proxyToken string
+}
+
+// NewDialer creates a new Dialer.
+// The proxyToken must match the proxy token of the Gateway.
+func NewDialer(c client.Reader, gatewayAddress, proxyToken string) *Dialer {
+	return &Dialer{
+		client:         c,
+		gatewayAddress: gatewayAddress,
+		proxyToken:     proxyToken,
+	}
+}
+
+// DialContextForCluster returns the func to be used to dial the API server of the given Cluster through the Gateway;
+// nil is returned if the Cluster does not have the cluster.x-k8s.io/reverse-tunnel annotation, i.e. if the API server
+// of the Cluster should be accessed directly.
+func (d *Dialer) DialContextForCluster(ctx context.Context, cluster client.ObjectKey) (func(ctx context.Context, network, address string) (net.Conn, error), error) {
+	c := &clusterv1.Cluster{}
+	if err := d.client.Get(ctx, cluster, c); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", cluster)
+	}
+	if _, ok := c.Annotations[clusterv1.ReverseTunnelAnnotation]; !ok {
+		return nil, nil
+	}
+	return d.DialContext(cluster), nil
+}
+
+// DialContext returns a func that dials the API server of the given Cluster through the Gateway.
+// Note: The address passed to the func is ignored, because Agents always forward connections to the API server.
+func (d *Dialer) DialContext(cluster client.ObjectKey) DialContextFunc {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		dialer := &net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", d.gatewayAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to the Gateway at %s", d.gatewayAddress)
+		}
+
+		upgradedConn, err := upgradeClientConn(ctx, conn, clusterPath(clusterPathPrefix, cluster), d.proxyToken)
+		if err != nil {
+			_ = conn.Close()
+			return nil, errors.Wrapf(err, "failed to connect to Cluster %s through the Gateway", cluster)
+		}
+		return upgradedConn, nil
+	}
+}
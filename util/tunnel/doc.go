@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tunnel implements reverse tunnels to access workload clusters that cannot be reached directly from
+// the management cluster, e.g. because they are behind NAT.
+//
+// # Components
+//
+// - The Agent runs in the workload cluster (e.g. as a static Pod added via bootstrap files); it opens an outbound
+// connection to the agent endpoint of the Gateway and forwards all the streams opened by the Gateway to the
+// API server of the workload cluster.
+//
+// - The Gateway runs in the management cluster; it accepts connections from Agents on the agent endpoint and
+// connections from controllers on the proxy endpoint, and it forwards each connection from a controller to a new
+// stream on the connection of an Agent of the corresponding Cluster.
+//
+// - The Dialer is used by controllers (e.g. via the ClusterCache) to connect to the API server of a workload cluster
+// through the proxy endpoint of the Gateway; it is used only for Clusters with the cluster.x-k8s.io/reverse-tunnel
+// annotation.
+//
+// # Protocol
+//
+// Both Agents and Dialers connect to the Gateway using an HTTP/1.1 request with the "Connection: Upgrade" header;
+// after the Gateway replies with 101 Switching Protocols:
+//   - the connection of an Agent is used as a multiplexed SPDY connection, where the Gateway opens a stream for each
+//     connection from a controller.
+//   - the connection of a Dialer is used as a plain connection to the API server of the workload cluster.
+//
+// SPDY is used instead of HTTP/2 CONNECT because the Gateway must open streams towards the Agent, i.e. in the
+// opposite direction of the TCP connection; with HTTP/2 only the client can open streams, so the Agent would have
+// to act as an HTTP/2 server over its own outbound connection. The SPDY implementation of k8s.io/apimachinery,
+// which is also used for port forwarding in Kubernetes, allows both sides to open streams and provides pings to
+// detect broken connections, without adding new dependencies.
+//
+// Agents authenticate with a bearer token, which must match the token stored in the <cluster-name>-tunnel Secret;
+// connections to the agent endpoint are rate limited by source address.
+// Dialers authenticate with a bearer token, which must match the proxy token of the Gateway.
+// Note: The TLS connection between controllers and the API server is established end to end through the tunnel,
+// so neither the Gateway nor the Agent have access to the traffic nor to the credentials of the controllers.
+package tunnel
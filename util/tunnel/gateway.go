@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/lru"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// agentConnectionInterval and agentConnectionBurst limit the connections to the agent endpoint from the same
+	// source address; the burst allows the Agents of multiple Clusters behind the same NAT to reconnect at the same time,
+	// e.g. after the Gateway restarted.
+	agentConnectionInterval = time.Second
+	agentConnectionBurst    = 30
+
+	// maxAgentConnectionLimiters is the maximum number of source addresses for which the Gateway keeps a rate limiter;
+	// when exceeded, the limiters of the least recently seen source addresses are dropped.
+	maxAgentConnectionLimiters = 4096
+)
+
+// Gateway accepts connections from Agents and forwards connections from controllers to them.
+type Gateway struct {
+	// client is used to read the Secrets with the tokens of the Agents.
+	client client.Reader
+
+	// agentLimitersLock is used to synchronize the creation of agentLimiters.
+	agentLimitersLock sync.Mutex
+	// agentLimiters are the rate limiters for connections to the agent endpoint by source address.
+	agentLimiters *lru.Cache
+
+	// proxyToken is the token Dialers must use to authenticate with the proxy endpoint.
+	proxyToken string
+
+	// sessionsLock is used to synchronize access to sessions.
+	sessionsLock sync.RWMutex
+	// sessions are the connections of the Agents by Cluster; the most recent connection is the last one.
+	sessions map[client.ObjectKey][]httpstream.Connection
+}
+
+// NewGateway creates a new Gateway.
+// The client is used to read the <cluster-name>-tunnel Secrets storing the tokens of the Agents; given that
+// the agent endpoint is exposed outside of the management cluster, it is recommended to use a client which
+// reads Secrets from a cache (e.g. a cache limited to Secrets with the cluster.x-k8s.io/cluster-name label),
+// so connection attempts do not result in calls to the API server.
+// The proxyToken is the token Dialers must use to authenticate with the proxy endpoint; if it is empty,
+// all the connections to the proxy endpoint are rejected.
+func NewGateway(c client.Reader, proxyToken string) *Gateway {
+	return &Gateway{
+		client:        c,
+		proxyToken:    proxyToken,
+		agentLimiters: lru.New(maxAgentConnectionLimiters),
+		sessions:      map[client.ObjectKey][]httpstream.Connection{},
+	}
+}
+
+// AgentHandler returns the handler for the agent endpoint, i.e. the endpoint Agents connect to.
+// NOTE: This endpoint must be exposed outside of the management cluster, and it should be served using TLS.
+func (g *Gateway) AgentHandler() http.Handler {
+	return http.HandlerFunc(g.serveAgent)
+}
+
+// ProxyHandler returns the handler for the proxy endpoint, i.e. the endpoint Dialers connect to.
+// Dialers authenticate with the proxy token of the Gateway.
+// NOTE: This endpoint should not be exposed outside of the management cluster.
+func (g *Gateway) ProxyHandler() http.Handler {
+	return http.HandlerFunc(g.serveProxy)
+}
+
+// Connected returns true if at least one Agent is connected for the given Cluster.
+func (g *Gateway) Connected(cluster client.ObjectKey) bool {
+	g.sessionsLock.RLock()
+	defer g.sessionsLock.RUnlock()
+
+	return len(g.sessions[cluster]) > 0
+}
+
+func (g *Gateway) serveAgent(w http.ResponseWriter, r *http.Request) {
+	cluster, err := parseClusterPath(agentPathPrefix, r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log := ctrl.LoggerFrom(r.Context()).WithValues("Cluster", klog.KRef(cluster.Namespace, cluster.Name))
+
+	// Note: Connections are rate limited before authentication, so failed attempts are limited as well.
+	if !g.allowAgentConnection(r.RemoteAddr) {
+		log.V(4).Info("Rejected Agent connection", "reason", "rate limit exceeded", "remoteAddr", r.RemoteAddr)
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+
+	if err := g.authenticate(r.Context(), cluster, r.Header.Get("Authorization")); err != nil {
+		log.V(4).Info("Rejected Agent connection", "reason", err.Error())
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := upgradeServerConn(w, r)
+	if err != nil {
+		log.Error(err, "Failed to upgrade Agent connection")
+		return
+	}
+
+	// Note: The Gateway opens streams on the connections of the Agents, so it acts as the client of the SPDY connection.
+	session, err := spdy.NewClientConnectionWithPings(conn, pingPeriod)
+	if err != nil {
+		_ = conn.Close()
+		log.Error(err, "Failed to create SPDY connection for Agent")
+		return
+	}
+
+	log.Info("Agent connected", "remoteAddr", r.RemoteAddr)
+	g.addSession(cluster, session)
+	select {
+	case <-session.CloseChan():
+	case <-r.Context().Done():
+		_ = session.Close()
+	}
+	g.removeSession(cluster, session)
+	log.Info("Agent disconnected", "remoteAddr", r.RemoteAddr)
+}
+
+func (g *Gateway) serveProxy(w http.ResponseWriter, r *http.Request) {
+	cluster, err := parseClusterPath(clusterPathPrefix, r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log := ctrl.LoggerFrom(r.Context()).WithValues("Cluster", klog.KRef(cluster.Namespace, cluster.Name))
+
+	if err := g.authenticateProxy(r.Header.Get("Authorization")); err != nil {
+		log.V(4).Info("Rejected proxy connection", "reason", err.Error(), "remoteAddr", r.RemoteAddr)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	stream, err := g.createStream(cluster)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := upgradeServerConn(w, r)
+	if err != nil {
+		_ = stream.Reset()
+		log.Error(err, "Failed to upgrade proxy connection")
+		return
+	}
+
+	pipe(conn, stream)
+}
+
+// createStream opens a new stream on the most recent connection of an Agent of the given Cluster.
+func (g *Gateway) createStream(cluster client.ObjectKey) (httpstream.Stream, error) {
+	g.sessionsLock.RLock()
+	sessions := g.sessions[cluster]
+	g.sessionsLock.RUnlock()
+
+	if len(sessions) == 0 {
+		return nil, errors.Errorf("no Agent connected for Cluster %s", cluster)
+	}
+
+	// Note: Try the connections starting from the most recent one, because older connections are more likely to be stale.
+	var err error
+	for i := len(sessions) - 1; i >= 0; i-- {
+		var stream httpstream.Stream
+		stream, err = sessions[i].CreateStream(http.Header{})
+		if err == nil {
+			return stream, nil
+		}
+	}
+	return nil, errors.Wrapf(err, "failed to create stream to Agent for Cluster %s", cluster)
+}
+
+// authenticate checks that the bearer token in the authorization header matches the token in the
+// <cluster-name>-tunnel Secret.
+func (g *Gateway) authenticate(ctx context.Context, cluster client.ObjectKey, authorization string) error {
+	token, err := bearerToken(authorization)
+	if err != nil {
+		return err
+	}
+
+	tokenSecret := &corev1.Secret{}
+	tokenSecretKey := client.ObjectKey{Namespace: cluster.Namespace, Name: secret.Name(cluster.Name, secret.ReverseTunnel)}
+	if err := g.client.Get(ctx, tokenSecretKey, tokenSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errors.Errorf("Secret %s does not exist or it does not have the %s label", tokenSecretKey, clusterv1.ClusterNameLabel)
+		}
+		return errors.Wrapf(err, "failed to get Secret %s", tokenSecretKey)
+	}
+
+	expected := tokenSecret.Data[TokenKey]
+	if len(expected) == 0 {
+		return errors.Errorf("Secret %s does not contain %s", tokenSecretKey, TokenKey)
+	}
+	if subtle.ConstantTimeCompare(expected, []byte(token)) != 1 {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// allowAgentConnection returns true if a connection to the agent endpoint from the given remote address
+// is within the rate limit of its source address.
+func (g *Gateway) allowAgentConnection(remoteAddr string) bool {
+	source, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		source = remoteAddr
+	}
+
+	g.agentLimitersLock.Lock()
+	defer g.agentLimitersLock.Unlock()
+
+	if limiter, ok := g.agentLimiters.Get(source); ok {
+		return limiter.(*rate.Limiter).Allow()
+	}
+	limiter := rate.NewLimiter(rate.Every(agentConnectionInterval), agentConnectionBurst)
+	g.agentLimiters.Add(source, limiter)
+	return limiter.Allow()
+}
+
+// authenticateProxy checks that the bearer token in the authorization header matches the proxy token.
+func (g *Gateway) authenticateProxy(authorization string) error {
+	token, err := bearerToken(authorization)
+	if err != nil {
+		return err
+	}
+	if g.proxyToken == "" {
+		return errors.New("proxy token not configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(g.proxyToken), []byte(token)) != 1 {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// bearerToken returns the bearer token from an authorization header.
+func bearerToken(authorization string) (string, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return "", errors.New("bearer token missing")
+	}
+	return token, nil
+}
+
+func (g *Gateway) addSession(cluster client.ObjectKey, session httpstream.Connection) {
+	g.sessionsLock.Lock()
+	defer g.sessionsLock.Unlock()
+
+	g.sessions[cluster] = append(g.sessions[cluster], session)
+}
+
+func (g *Gateway) removeSession(cluster client.ObjectKey, session httpstream.Connection) {
+	g.sessionsLock.Lock()
+	defer g.sessionsLock.Unlock()
+
+	sessions := make([]httpstream.Connection, 0, len(g.sessions[cluster]))
+	for _, s := range g.sessions[cluster] {
+		if s != session {
+			sessions = append(sessions, s)
+		}
+	}
+	if len(sessions) == 0 {
+		delete(g.sessions, cluster)
+		return
+	}
+	g.sessions[cluster] = sessions
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// GatewayServer serves the agent and the proxy endpoints of a Gateway.
+// It can be added to a manager, and it runs only on the leader.
+type GatewayServer struct {
+	// Gateway is the Gateway to serve.
+	Gateway *Gateway
+
+	// AgentBindAddress is the address the agent endpoint binds to.
+	AgentBindAddress string
+
+	// ProxyBindAddress is the address the proxy endpoint binds to.
+	ProxyBindAddress string
+
+	// CertFile and KeyFile are the paths of the certificate and key used to serve the agent endpoint.
+	CertFile string
+	KeyFile  string
+
+	// TLSOpts is used to allow configuring the TLS config used for the agent endpoint.
+	TLSOpts []func(*tls.Config)
+}
+
+var _ manager.LeaderElectionRunnable = &GatewayServer{}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *GatewayServer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start starts the servers for the agent and the proxy endpoints, and blocks until ctx is done.
+func (s *GatewayServer) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("reverse-tunnel-gateway")
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	certWatcher, err := certwatcher.New(s.CertFile, s.KeyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load reverse tunnel gateway certificate")
+	}
+	go func() {
+		if err := certWatcher.Start(ctx); err != nil {
+			log.Error(err, "Certificate watcher failed")
+		}
+	}()
+
+	tlsConfig := &tls.Config{
+		// Note: Connections are hijacked after the upgrade, so HTTP/2 must not be used.
+		NextProtos:     []string{"http/1.1"},
+		GetCertificate: certWatcher.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	for _, opt := range s.TLSOpts {
+		opt(tlsConfig)
+	}
+
+	agentListener, err := tls.Listen("tcp", s.AgentBindAddress, tlsConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", s.AgentBindAddress)
+	}
+	proxyListener, err := net.Listen("tcp", s.ProxyBindAddress)
+	if err != nil {
+		return kerrors.NewAggregate([]error{errors.Wrapf(err, "failed to listen on %s", s.ProxyBindAddress), agentListener.Close()})
+	}
+
+	servers := []*http.Server{
+		newServer(ctx, s.Gateway.AgentHandler()),
+		newServer(ctx, s.Gateway.ProxyHandler()),
+	}
+	errCh := make(chan error, len(servers))
+	for i, l := range []net.Listener{agentListener, proxyListener} {
+		go func() {
+			if err := servers[i].Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+			}
+		}()
+	}
+	log.Info("Serving reverse tunnel gateway", "agentAddress", s.AgentBindAddress, "proxyAddress", s.ProxyBindAddress)
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-errCh:
+	}
+
+	// Note: Hijacked connections are not closed by Shutdown, but they are closed when the manager exits.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errs := []error{serveErr}
+	for _, server := range servers {
+		errs = append(errs, server.Shutdown(shutdownCtx))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func newServer(ctx context.Context, handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+}
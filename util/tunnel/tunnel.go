@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TokenKey is the key used to store the token of the Agents in the <cluster-name>-tunnel Secret.
+	TokenKey = "token"
+
+	// upgradeProtocol is the protocol used in the Upgrade header by Agents and Dialers.
+	upgradeProtocol = "cluster-api-tunnel"
+
+	// agentPathPrefix is the prefix of the path used by Agents, i.e. /agents/<namespace>/<name>.
+	agentPathPrefix = "/agents/"
+
+	// clusterPathPrefix is the prefix of the path used by Dialers, i.e. /clusters/<namespace>/<name>.
+	clusterPathPrefix = "/clusters/"
+
+	// pingPeriod is the period used for pings on the connections of the Agents.
+	// Note: Pings also keep alive the mapping of the connection on NAT devices.
+	pingPeriod = 10 * time.Second
+)
+
+// ReadTokenFile reads a token from a file, e.g. the token used by Dialers to authenticate with the proxy endpoint
+// of the Gateway. Leading and trailing whitespaces are ignored, and an error is returned if the token is empty.
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The path of the token file is provided by the user.
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read token file %s", path)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// clusterPath returns the path for a Cluster.
+func clusterPath(prefix string, cluster client.ObjectKey) string {
+	return fmt.Sprintf("%s%s/%s", prefix, cluster.Namespace, cluster.Name)
+}
+
+// parseClusterPath parses the Cluster from a path.
+func parseClusterPath(prefix, path string) (client.ObjectKey, error) {
+	parts := strings.Split(strings.TrimPrefix(path, prefix), "/")
+	if !strings.HasPrefix(path, prefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return client.ObjectKey{}, errors.Errorf("invalid path %q, expected %s<namespace>/<name>", path, prefix)
+	}
+	return client.ObjectKey{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// upgradeClientConn sends an upgrade request for path on conn and waits for the response.
+// If the upgrade succeeds, the returned connection can be used for the upgraded protocol.
+func upgradeClientConn(ctx context.Context, conn net.Conn, path, token string) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, errors.Wrap(err, "failed to set deadline")
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://tunnel"+path, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create upgrade request")
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", upgradeProtocol)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, errors.Wrap(err, "failed to send upgrade request")
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read upgrade response")
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, errors.Errorf("upgrade request failed with status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	// Reset the deadline, it was only meant for the upgrade.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, errors.Wrap(err, "failed to reset deadline")
+	}
+	return newBufferedConn(conn, reader), nil
+}
+
+// upgradeServerConn validates an upgrade request, hijacks the connection and sends the upgrade response.
+// If an error is returned, the response has already been written.
+func upgradeServerConn(w http.ResponseWriter, r *http.Request) (net.Conn, error) {
+	if r.Method != http.MethodPost || !strings.EqualFold(r.Header.Get("Upgrade"), upgradeProtocol) {
+		err := errors.Errorf("expected a POST request with header Upgrade: %s", upgradeProtocol)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+
+	conn, buffered, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		err = errors.Wrap(err, "failed to hijack connection")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n", upgradeProtocol); err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrap(err, "failed to send upgrade response")
+	}
+	return newBufferedConn(conn, buffered.Reader), nil
+}
+
+// bufferedConn is a net.Conn which reads data already buffered by reader before reading from the
+// underlying connection.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func newBufferedConn(conn net.Conn, reader *bufio.Reader) net.Conn {
+	if reader == nil || reader.Buffered() == 0 {
+		return conn
+	}
+	return &bufferedConn{Conn: conn, reader: reader}
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	if c.reader.Buffered() > 0 {
+		return c.reader.Read(b)
+	}
+	return c.Conn.Read(b)
+}
+
+// pipe copies data between a and b until one of them is closed, then it closes both.
+func pipe(a, b io.ReadWriteCloser) {
+	var once sync.Once
+	closeAll := func() {
+		once.Do(func() {
+			_ = a.Close()
+			_ = b.Close()
+		})
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer closeAll()
+		_, _ = io.Copy(a, b)
+	}()
+	go func() {
+		defer wg.Done()
+		defer closeAll()
+		_, _ = io.Copy(b, a)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+var (
+	ctx = ctrl.SetupSignalHandler()
+)
+
+func TestTunnel(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	clusterKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   clusterKey.Namespace,
+			Name:        clusterKey.Name,
+			Annotations: map[string]string{clusterv1.ReverseTunnelAnnotation: ""},
+		},
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterKey.Namespace,
+			Name:      secret.Name(clusterKey.Name, secret.ReverseTunnel),
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterKey.Name},
+		},
+		Data: map[string][]byte{TokenKey: []byte("test-token")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, tokenSecret).Build()
+
+	// Start a target server which echoes all the data it receives.
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer target.Close()
+	go func() {
+		for {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	gateway := NewGateway(c, "proxy-token")
+	agentServer := httptest.NewTLSServer(gateway.AgentHandler())
+	defer agentServer.Close()
+	proxyServer := httptest.NewServer(gateway.ProxyHandler())
+	defer proxyServer.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(agentServer.Certificate())
+	agentTLSConfig := &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	newAgent := func(token string) *Agent {
+		agent, err := NewAgent(AgentOptions{
+			GatewayAddress: agentServer.Listener.Addr().String(),
+			TLSConfig:      agentTLSConfig,
+			Cluster:        clusterKey,
+			Token:          token,
+			TargetAddress:  target.Addr().String(),
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		return agent
+	}
+
+	dialer := NewDialer(c, strings.TrimPrefix(proxyServer.URL, "http://"), "proxy-token")
+	dial, err := dialer.DialContextForCluster(ctx, clusterKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dial).ToNot(BeNil())
+
+	// Dialers with an invalid token are rejected.
+	_, err = NewDialer(c, strings.TrimPrefix(proxyServer.URL, "http://"), "invalid-token").DialContext(clusterKey)(ctx, "tcp", "foo:6443")
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+
+	// Dial fails if there is no Agent connected.
+	_, err = dial(ctx, "tcp", "foo:6443")
+	g.Expect(err).To(MatchError(ContainSubstring("no Agent connected for Cluster default/foo")))
+
+	// Agents with an invalid token are rejected.
+	err = newAgent("invalid-token").Connect(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+	g.Expect(gateway.Connected(clusterKey)).To(BeFalse())
+
+	// Connections are forwarded to the target after the Agent is connected.
+	agentCtx, agentCancel := context.WithCancel(ctx)
+	agentDone := make(chan error)
+	go func() {
+		agentDone <- newAgent("test-token").Connect(agentCtx)
+	}()
+	g.Eventually(func() bool { return gateway.Connected(clusterKey) }, 5*time.Second).Should(BeTrue())
+
+	for _, message := range []string{"hello", "world"} {
+		conn, err := dial(ctx, "tcp", "foo:6443")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = conn.Write([]byte(message))
+		g.Expect(err).ToNot(HaveOccurred())
+		got := make([]byte, len(message))
+		_, err = io.ReadFull(conn, got)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(got)).To(Equal(message))
+		g.Expect(conn.Close()).To(Succeed())
+	}
+
+	// The Agent is removed after it disconnects.
+	agentCancel()
+	g.Eventually(agentDone, 5*time.Second).Should(Receive())
+	g.Eventually(func() bool { return gateway.Connected(clusterKey) }, 5*time.Second).Should(BeFalse())
+}
+
+func TestAllowAgentConnection(t *testing.T) {
+	g := NewWithT(t)
+
+	gateway := NewGateway(fake.NewClientBuilder().Build(), "proxy-token")
+
+	// Connections are allowed up to the burst for each source address, independently of the port.
+	for i := range agentConnectionBurst {
+		g.Expect(gateway.allowAgentConnection(fmt.Sprintf("10.0.0.1:%d", 40000+i))).To(BeTrue())
+	}
+	g.Expect(gateway.allowAgentConnection("10.0.0.1:50000")).To(BeFalse())
+
+	// Other source addresses are not affected.
+	g.Expect(gateway.allowAgentConnection("10.0.0.2:40000")).To(BeTrue())
+}
+
+func TestDialContextForCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "foo",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+	dialer := NewDialer(c, "gateway:9446", "proxy-token")
+
+	// Clusters without the annotation are accessed directly.
+	dial, err := dialer.DialContextForCluster(ctx, client.ObjectKeyFromObject(cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dial).To(BeNil())
+
+	// Getting a dial func fails if the Cluster does not exist.
+	_, err = dialer.DialContextForCluster(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "bar"})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestParseClusterPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    client.ObjectKey
+		wantErr bool
+	}{
+		{
+			name: "valid path",
+			path: "/agents/default/foo",
+			want: client.ObjectKey{Namespace: "default", Name: "foo"},
+		},
+		{
+			name:    "invalid prefix",
+			path:    "/clusters/default/foo",
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			path:    "/agents/default/",
+			wantErr: true,
+		},
+		{
+			name:    "too many segments",
+			path:    "/agents/default/foo/bar",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := parseClusterPath(agentPathPrefix, tt.path)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	runtimewebhooks "sigs.k8s.io/cluster-api/internal/webhooks/runtime"
 )
@@ -32,6 +33,9 @@ import (
 type Cluster struct {
 	Client             client.Reader
 	ClusterCacheReader ClusterCacheReader
+
+	// RuntimeClient is a client for calling runtime extensions.
+	RuntimeClient runtimeclient.Client
 }
 
 // ClusterCacheReader is a read-only ClusterCacheReader useful to gather information
@@ -43,6 +47,7 @@ func (webhook *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return (&webhooks.Cluster{
 		Client:             webhook.Client,
 		ClusterCacheReader: webhook.ClusterCacheReader,
+		RuntimeClient:      webhook.RuntimeClient,
 	}).SetupWebhookWithManager(mgr)
 }
 
@@ -71,6 +76,14 @@ func (webhook *ClusterClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	}).SetupWebhookWithManager(mgr)
 }
 
+// ClusterClassRevision implements a validating webhook for ClusterClassRevision.
+type ClusterClassRevision struct{}
+
+// SetupWebhookWithManager sets up ClusterClassRevision webhooks.
+func (webhook *ClusterClassRevision) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return (&webhooks.ClusterClassRevision{}).SetupWebhookWithManager(mgr)
+}
+
 // Machine implements a validating and defaulting webhook for Machine.
 type Machine struct{}
 